@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	cfg "github.com/fajaramaulana/go-grpc-micro-bank-server/config"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+)
+
+const auditUsage = `usage: bank-server audit [flags] <action>
+
+actions:
+  verify      walk the audit log from the first entry and check that every
+              entry is linked to the one before it and matches its hash
+
+The database is taken from the configuration flags below.
+
+flags:`
+
+// runAudit works on the audit log of the configured database. verify exits
+// non-zero when the chain is broken.
+func runAudit(args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("bank-server audit", flag.ContinueOnError)
+	flagSet.Usage = func() {
+		fmt.Fprintln(flagSet.Output(), auditUsage)
+		flagSet.PrintDefaults()
+	}
+
+	configuration, err := loadConfig(flagSet, args)
+	if err != nil {
+		return err
+	}
+	if configuration.DB.Driver == cfg.DriverMemory {
+		return usageError{errors.New("the memory driver keeps no audit log across runs")}
+	}
+
+	rest := flagSet.Args()
+	if len(rest) != 1 || rest[0] != "verify" {
+		flagSet.Usage()
+		return usageError{fmt.Errorf("expected the verify action, got %v", rest)}
+	}
+
+	store, err := openStorage(configuration.DB)
+	if err != nil {
+		return err
+	}
+	defer store.close()
+
+	auditLog, ok := store.db.(port.AuditLogPort)
+	if !ok {
+		return fmt.Errorf("the %s driver has no audit log", configuration.DB.Driver)
+	}
+
+	n, err := application.VerifyAuditLog(context.Background(), auditLog)
+	if err != nil {
+		return fmt.Errorf("audit log verification failed after %d entries : %w", n, err)
+	}
+
+	fmt.Fprintf(out, "audit log verified: %d entries\n", n)
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+)
+
+func TestAuditVerifySQLite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.db")
+	flags := []string{"--db-driver=sqlite", "--db-path=" + path, "--metrics-port=0"}
+
+	if err := runSeed(append(flags, "--profile=demo"), &bytes.Buffer{}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runAudit(append(flags, "verify"), &out); err != nil {
+		t.Fatalf("audit verify: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "audit log verified: 1 entries" {
+		t.Errorf("audit verify printed %q", got)
+	}
+
+	// the triggers keep the application from doing this, not someone with
+	// access to the database
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer conn.Close()
+	for _, stmt := range []string{
+		"DROP TRIGGER bank_audit_log_no_update",
+		"UPDATE bank_audit_log SET principal = 'someone else'",
+	} {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := runAudit(append(flags, "verify"), &bytes.Buffer{}); !errors.Is(err, domainAudit.ErrChainBroken) {
+		t.Errorf("audit verify of a tampered log = %v, want ErrChainBroken", err)
+	}
+}
+
+func TestAuditUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.db")
+
+	for _, args := range [][]string{
+		{"--db-driver=sqlite", "--db-path=" + path},
+		{"--db-driver=sqlite", "--db-path=" + path, "repair"},
+		{"--db-driver=memory", "verify"},
+	} {
+		var usageErr usageError
+		if err := runAudit(args, &bytes.Buffer{}); !errors.As(err, &usageErr) {
+			t.Errorf("audit %v = %v, want a usage error", args, err)
+		}
+	}
+}
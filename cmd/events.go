@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	cfg "github.com/fajaramaulana/go-grpc-micro-bank-server/config"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/publisher"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/webhook"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+)
+
+// openPublisher returns the configured event publisher, nil for none.
+func openPublisher(c cfg.EventsConfig) (port.EventPublisherPort, error) {
+	switch c.Publisher {
+	case cfg.PublisherStdout:
+		return publisher.NewStdoutPublisher(), nil
+	case cfg.PublisherFile:
+		return publisher.NewFilePublisher(c.File)
+	case cfg.PublisherNATS:
+		return publisher.NewNATSPublisher(c.NATSURL, c.Subject)
+	default:
+		return nil, nil
+	}
+}
+
+// openWebhooks returns the webhook service on the webhook tables of db.
+func openWebhooks(c *cfg.Config, db port.BankDatabasePort) (*application.WebhookService, error) {
+	store, ok := db.(port.WebhookStorePort)
+	if !ok {
+		return nil, fmt.Errorf("the %s driver can't store webhooks", c.DB.Driver)
+	}
+
+	return application.NewWebhookService(store, db, webhook.NewHTTPSender(), clock.Real(), application.WebhookOptions{
+		Timeout:     c.Webhooks.Timeout,
+		MaxAttempts: c.Webhooks.MaxAttempts,
+		BackoffBase: c.Webhooks.BackoffBase,
+		BackoffMax:  c.Webhooks.BackoffMax,
+		BatchSize:   c.Webhooks.BatchSize,
+	}), nil
+}
+
+// relayPublisher adds the webhooks to the publisher of the relay, p may be
+// nil.
+func relayPublisher(p port.EventPublisherPort, webhooks *application.WebhookService) port.EventPublisherPort {
+	if p == nil {
+		return webhooks
+	}
+
+	return publisher.NewFanout(p, webhooks)
+}
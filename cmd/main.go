@@ -3,78 +3,400 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
-	"strconv"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	cfg "github.com/fajaramaulana/go-grpc-micro-bank-server/config"
-	dbmigration "github.com/fajaramaulana/go-grpc-micro-bank-server/db"
-	mydb "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/database"
 	mygrpc "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/grpc"
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
 	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/pdf"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/rand"
+	"google.golang.org/grpc"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
+	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/chilts/sid"
 )
 
 // main is the entry point of the application.
-// It initializes the necessary components and starts the gRPC server.
+// It runs the command named by the first argument, serve by default:
+//
+//	bank-server [serve] [flags]          start the gRPC server
+//	bank-server migrate [flags] <action> manage the schema, see runMigrate
+//	bank-server seed [flags]             load a seed profile, see runSeed
+//	bank-server audit [flags] verify     check the audit log, see runAudit
+//	bank-server tax-report [flags]       write a monthly tax report, see runTaxReport
 func main() {
-	sidString := sid.Id()
 	// Configure the logger to output logs to the console
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}).Hook(tracing.ZerologHook{})
+
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	var err error
+	switch command {
+	case "serve":
+		err = serve(args)
+	case "migrate":
+		err = runMigrate(args, os.Stdout)
+	case "seed":
+		err = runSeed(args, os.Stdout)
+	case "audit":
+		err = runAudit(args, os.Stdout)
+	case "tax-report":
+		err = runTaxReport(args, os.Stdout)
+	default:
+		err = usageError{fmt.Errorf("unknown command %q, expected serve, migrate, seed, audit or tax-report", command)}
+	}
+
+	var usageErr usageError
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(0)
+	case errors.As(err, &usageErr):
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// usageError is a mistake on the command line, reported with exit code 2.
+type usageError struct {
+	error
+}
 
-	// Load the configuration from the .env file
-	configuration := cfg.New("../.env")
+func (e usageError) Unwrap() error {
+	return e.error
+}
+
+// loadConfig parses the flags of command, the configuration flags included,
+// and loads the configuration.
+func loadConfig(flagSet *flag.FlagSet, args []string) (*cfg.Config, error) {
+	// Load the configuration from defaults, the config file, the environment and flags
+	configuration, err := cfg.Load(flagSet, args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		return nil, usageError{err}
+	}
 
-	// Create the connection string for the database
-	conn := fmt.Sprintf("%s://%s:%s@%s:%s/%s?sslmode=%s", configuration.Get("DB_DRIVER"), configuration.Get("DB_USER"), configuration.Get("DB_PASSWORD"), configuration.Get("DB_HOST"), configuration.Get("DB_PORT"), configuration.Get("DB_NAME"), configuration.Get("DB_SSLMODE"))
+	return configuration, err
+}
+
+// serve initializes the necessary components and starts the gRPC server.
+// On SIGINT or SIGTERM it drains the server, stops background jobs and
+// closes the database pool.
+func serve(args []string) error {
+	sidString := sid.Id()
 
-	// Open a connection to the database
-	sqlDb, err := sql.Open("pgx", conn)
+	flagSet := flag.NewFlagSet("bank-server serve", flag.ContinueOnError)
+	printConfig := flagSet.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+
+	configuration, err := loadConfig(flagSet, args)
 	if err != nil {
-		logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - sql.Open")
-		log.Fatal().Msg(logErr)
+		return err
 	}
 
-	// Run database migrations
-	dbmigration.Migrate(sqlDb)
+	if *printConfig {
+		return configuration.Print(os.Stdout)
+	}
 
-	databaseAdapter, err := mydb.NewDatabaseAdapter(sqlDb)
+	configureLogger(configuration.Log)
+
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Options{
+		Exporter:    configuration.Tracing.Exporter,
+		FilePath:    configuration.Tracing.File,
+		SampleRatio: configuration.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - tracing.Init")
+		log.Fatal().Msg(logErr)
+	}
 
+	store, err := openStorage(configuration.DB)
 	if err != nil {
-		logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - mydb.NewDatabaseAdapter")
+		logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - openStorage")
 		log.Fatal().Msg(logErr)
 	}
 
 	// Create an instance of the BankService
-	bankService := application.NewBankService(databaseAdapter)
+	bankService := application.NewBankService(store.db, clock.Real())
 
-	go generateExchangeRates(bankService, "USD", "IDR", 5*time.Second)
-	// Create a gRPC adapter with the BankService and start the server
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	portInt, err := strconv.Atoi(configuration.Get("PORT"))
+	var jobs sync.WaitGroup
+
+	// The jobs that must run on one replica only run on the leader. They stop
+	// after the gRPC server drained, so the relay publishes what the drained
+	// RPCs wrote before the lock goes to another replica.
+	leaderCtx, resign := context.WithCancel(context.Background())
+	defer resign()
+	election := application.NewLeaderElection(store.locker, configuration.Leader.LockName, clock.Real(), configuration.Leader.Interval)
+
+	if configuration.RateGenerator.Enabled {
+		for _, pair := range configuration.RateGenerator.Pairs {
+			pair := pair
+			election.Add(func(ctx context.Context) {
+				generateExchangeRates(ctx, bankService, clock.Real(), pair.From, pair.To, configuration.RateGenerator.Interval)
+			})
+		}
+	}
+
+	eventPublisher, err := openPublisher(configuration.Events)
 	if err != nil {
-		logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - Conv String to int Port")
+		logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - openPublisher")
 		log.Fatal().Msg(logErr)
 	}
-	grpcAdapter := mygrpc.NewGrpcAdapter(bankService, portInt)
-	grpcAdapter.Run()
+
+	var webhookService *application.WebhookService
+	if configuration.Webhooks.Enabled {
+		webhookService, err = openWebhooks(configuration, store.db)
+		if err != nil {
+			logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - openWebhooks")
+			log.Fatal().Msg(logErr)
+		}
+
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			webhookService.Run(ctx, configuration.Webhooks.DeliveryInterval)
+		}()
+
+		// the relay enqueues the deliveries next to publishing the events
+		eventPublisher = relayPublisher(eventPublisher, webhookService)
+	}
+
+	var eventRelay *application.EventRelay
+	if eventPublisher != nil {
+		outbox, ok := store.db.(port.OutboxPort)
+		if !ok {
+			log.Fatal().Msgf("The %s driver has no outbox to publish events from", configuration.DB.Driver)
+		}
+		eventRelay = application.NewEventRelay(outbox, eventPublisher, clock.Real(), configuration.Events.BatchSize)
+
+		election.Add(func(ctx context.Context) {
+			eventRelay.Run(ctx, configuration.Events.RelayInterval)
+			if leaderCtx.Err() == nil {
+				// lost the lock, the new leader relays the rest
+				return
+			}
+
+			// publish what the drained RPCs wrote, the rest goes out on the next start
+			drainCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := eventRelay.Drain(drainCtx); err != nil {
+				logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - eventRelay.Drain")
+				log.Error().Msg(logErr)
+			}
+		})
+	}
+
+	interestStore, ok := store.db.(port.InterestStorePort)
+	if !ok {
+		log.Fatal().Msgf("The %s driver has no interest store", configuration.DB.Driver)
+	}
+	taxStore, ok := store.db.(port.TaxStorePort)
+	if !ok {
+		log.Fatal().Msgf("The %s driver has no tax store", configuration.DB.Driver)
+	}
+	interestService := application.NewInterestService(interestStore, taxStore, bankService, store.db, clock.Real())
+	taxService := application.NewTaxService(taxStore, store.db, clock.Real())
+	feeStore, ok := store.db.(port.FeeStorePort)
+	if !ok {
+		log.Fatal().Msgf("The %s driver has no fee store", configuration.DB.Driver)
+	}
+	feeService := application.NewFeeService(feeStore, store.db, clock.Real())
+	if configuration.Interest.Enabled {
+		election.Add(func(ctx context.Context) {
+			interestService.Run(ctx, configuration.Interest.Interval)
+		})
+	}
+
+	scheduleStore, ok := store.db.(port.ScheduleStorePort)
+	if !ok {
+		log.Fatal().Msgf("The %s driver has no scheduled transfer store", configuration.DB.Driver)
+	}
+	scheduleService := application.NewScheduleService(scheduleStore, bankService, store.db, clock.Real(), application.ScheduleOptions{
+		BatchSize: configuration.Scheduler.BatchSize,
+		Lease:     configuration.Scheduler.Lease,
+	})
+	if configuration.Scheduler.Enabled {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			scheduleService.Run(ctx, configuration.Scheduler.Interval)
+		}()
+	}
+
+	batchStore, ok := store.db.(port.BatchStorePort)
+	if !ok {
+		log.Fatal().Msgf("The %s driver has no transfer batch store", configuration.DB.Driver)
+	}
+	batchService := application.NewBatchService(batchStore, bankService, bankService, store.db, clock.Real(), application.BatchOptions{
+		BatchSize:   configuration.TransferBatches.BatchSize,
+		Lease:       configuration.TransferBatches.Lease,
+		MaxItems:    configuration.TransferBatches.MaxItems,
+		MaxFileSize: int64(configuration.TransferBatches.MaxFileSize),
+	})
+	if configuration.TransferBatches.Enabled {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			batchService.Run(ctx, configuration.TransferBatches.Interval)
+		}()
+	}
+
+	statementStore, ok := store.db.(port.StatementStorePort)
+	if !ok {
+		log.Fatal().Msgf("The %s driver has no statement store", configuration.DB.Driver)
+	}
+	brandColor, err := pdf.ParseColor(configuration.Statements.BrandColor)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid statements.brand_color")
+	}
+	statementService := application.NewStatementService(statementStore, store.db, clock.Real(), application.StatementOptions{
+		BankId: configuration.Statements.BankId,
+		Branding: pdf.Branding{
+			BankName: configuration.Statements.BankName,
+			Address:  configuration.Statements.Address,
+			Color:    brandColor,
+		},
+	})
+
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		election.Run(leaderCtx)
+	}()
+
+	// Create a gRPC adapter with the BankService and start the server
+	serverOpts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(configuration.Limits.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(configuration.Limits.MaxSendMsgSize),
+		grpc.MaxConcurrentStreams(configuration.Limits.MaxConcurrentStreams),
+	}
+	if configuration.TLS.Enabled {
+		creds, err := mygrpc.TLSCredentials(configuration.TLS.CertFile, configuration.TLS.KeyFile, configuration.TLS.ClientCAFile)
+		if err != nil {
+			logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - mygrpc.TLSCredentials")
+			log.Fatal().Msg(logErr)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
+	grpcAdapter := mygrpc.NewGrpcAdapter(bankService, clock.Real(), configuration.GRPC.Port, serverOpts...)
+	grpcAdapter.RegisterAccountAdmin(bankService, interestService, taxService, feeService)
+	grpcAdapter.RegisterScheduledTransfers(scheduleService)
+	grpcAdapter.RegisterTransferBatches(batchService)
+	grpcAdapter.RegisterStatements(statementService)
+	if webhookService != nil {
+		grpcAdapter.RegisterWebhookAdmin(webhookService)
+	}
+
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		grpcAdapter.WatchHealth(ctx, store.ping, configuration.GRPC.HealthCheckInterval)
+	}()
+
+	var metricsServer *metrics.Server
+	if configuration.Metrics.Port != 0 {
+		metricsServer = metrics.NewServer(configuration.Metrics.Port)
+		go metricsServer.Run()
+	}
+
+	serverDone := make(chan struct{})
+	go func() {
+		defer close(serverDone)
+		grpcAdapter.Run()
+	}()
+
+	select {
+	case <-ctx.Done():
+	case <-serverDone:
+	}
+	stop()
+
+	log.Info().Msg("Shutting down")
+	grpcAdapter.Stop(configuration.GRPC.ShutdownTimeout)
+	resign()
+	jobs.Wait()
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if eventRelay != nil {
+		if err := eventPublisher.Close(); err != nil {
+			logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - eventPublisher.Close")
+			log.Error().Msg(logErr)
+		}
+	}
+	if metricsServer != nil {
+		if err := metricsServer.Stop(flushCtx); err != nil {
+			logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - metricsServer.Stop")
+			log.Error().Msg(logErr)
+		}
+	}
+	if err := shutdownTracing(flushCtx); err != nil {
+		logErr := util.LogError(err.Error(), "Main-"+sidString, "Main - shutdownTracing")
+		log.Error().Msg(logErr)
+	}
+	cancel()
+
+	store.close()
+
+	log.Info().Msg("Shutdown complete")
+
+	return nil
+}
+
+// configureLogger applies the configured level and output format.
+func configureLogger(c cfg.LogConfig) {
+	level, err := zerolog.ParseLevel(c.Level)
+	if err == nil {
+		zerolog.SetGlobalLevel(level)
+	}
+
+	if c.Format == "json" {
+		log.Logger = zerolog.New(os.Stderr).With().Timestamp().Logger().Hook(tracing.ZerologHook{})
+	}
 }
 
-func generateExchangeRates(bs *application.BankService, fromCurrency, toCurrency string, duration time.Duration) {
-	ticker := time.NewTicker(duration)
+func generateExchangeRates(ctx context.Context, bs *application.BankService, clk clock.Clock, fromCurrency, toCurrency string, duration time.Duration) {
+	ticker := clk.NewTicker(duration)
+	defer ticker.Stop()
 
-	for range ticker.C {
-		now := time.Now()
+	ctx = domainAudit.WithActor(ctx, domainAudit.Actor{Principal: "system:rate-generator", Method: "generateExchangeRates"})
+
+	for {
+		var tick time.Time
+
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Exchange rate generator stopped")
+			return
+		case tick = <-ticker.C():
+		}
+
+		now := clk.Now()
 		validFrom := now.Truncate(time.Second).Add(3 * time.Second)
 		validTo := validFrom.Add(duration).Add(-1 * time.Millisecond)
 
@@ -86,6 +408,8 @@ func generateExchangeRates(bs *application.BankService, fromCurrency, toCurrency
 			Rate:               2000 + float64(rand.Intn(300)),
 		}
 
-		bs.CreateExchangeRate(dummyRate)
+		if _, err := bs.CreateExchangeRate(ctx, dummyRate); err == nil {
+			metrics.RateGeneratorLag.WithLabelValues(fromCurrency, toCurrency).Set(clk.Now().Sub(tick).Seconds())
+		}
 	}
 }
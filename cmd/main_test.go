@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/memory"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+)
+
+func TestGenerateExchangeRates(t *testing.T) {
+	epoch := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(epoch)
+	store := memory.NewMemoryAdapter()
+	bs := application.NewBankService(store, clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		generateExchangeRates(ctx, bs, clk, "USD", "IDR", 5*time.Second)
+	}()
+
+	clk.BlockUntil(1)
+	clk.Advance(5 * time.Second)
+
+	// a tick at t publishes the rate for [t+3s, t+3s+interval)
+	validFrom := epoch.Add(8 * time.Second)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := store.GetExchangeRateAtTimestamp(ctx, "USD", "IDR", validFrom)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no rate valid at %v after the first tick: %v", validFrom, err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, ts := range []time.Time{validFrom.Add(-time.Millisecond), validFrom.Add(5 * time.Second)} {
+		if _, err := store.GetExchangeRateAtTimestamp(ctx, "USD", "IDR", ts); err == nil {
+			t.Errorf("rate found at %v, outside the published window", ts)
+		}
+	}
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	cfg "github.com/fajaramaulana/go-grpc-micro-bank-server/config"
+	dbmigration "github.com/fajaramaulana/go-grpc-micro-bank-server/db"
+	migrate "github.com/golang-migrate/migrate/v4"
+)
+
+const migrateUsage = `usage: bank-server migrate [flags] <action>
+
+actions:
+  up [N]      apply all pending migrations, or the next N
+  down N|all  revert the last N migrations, or all of them
+  goto V      migrate up or down to version V
+  version     print the current version
+  force V     set the version without running anything, to recover from a
+              failed migration; -1 means no version
+
+The database is taken from the configuration flags below.
+
+flags:`
+
+// runMigrate manages the schema of the configured database with the
+// migrations embedded in the binary.
+func runMigrate(args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("bank-server migrate", flag.ContinueOnError)
+	flagSet.Usage = func() {
+		fmt.Fprintln(flagSet.Output(), migrateUsage)
+		flagSet.PrintDefaults()
+	}
+
+	configuration, err := loadConfig(flagSet, args)
+	if err != nil {
+		return err
+	}
+	if configuration.DB.Driver == cfg.DriverMemory {
+		return usageError{errors.New("the memory driver has no schema to migrate")}
+	}
+
+	rest := flagSet.Args()
+	if len(rest) == 0 {
+		flagSet.Usage()
+		return usageError{errors.New("missing migrate action")}
+	}
+	action, params := rest[0], rest[1:]
+
+	run, err := migrateAction(action, params, out)
+	if err != nil {
+		return usageError{err}
+	}
+
+	conn, err := openDB(configuration.DB.Driver, configuration.DB.DSN(), configuration.DB.Pool)
+	if err != nil {
+		return fmt.Errorf("can't open database : %v", err)
+	}
+
+	m, err := dbmigration.New(conn, configuration.DB.Driver)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("can't prepare migrations : %v", err)
+	}
+	// closes conn as well
+	defer m.Close()
+
+	err = run(m)
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Fprintln(out, "no change")
+		return nil
+	}
+
+	return err
+}
+
+// migrateAction checks the parameters of action before anything touches the
+// database.
+func migrateAction(action string, params []string, out io.Writer) (func(m *migrate.Migrate) error, error) {
+	switch action {
+	case "up":
+		if len(params) == 0 {
+			return (*migrate.Migrate).Up, nil
+		}
+		n, err := countParam(action, params)
+		if err != nil {
+			return nil, err
+		}
+		return func(m *migrate.Migrate) error { return m.Steps(n) }, nil
+	case "down":
+		if len(params) == 1 && params[0] == "all" {
+			return (*migrate.Migrate).Down, nil
+		}
+		n, err := countParam(action, params)
+		if err != nil {
+			return nil, fmt.Errorf("%v, or all", err)
+		}
+		return func(m *migrate.Migrate) error { return m.Steps(-n) }, nil
+	case "goto":
+		v, err := versionParam(action, params)
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 {
+			return nil, errors.New("goto needs a version of 0 or more")
+		}
+		return func(m *migrate.Migrate) error { return m.Migrate(uint(v)) }, nil
+	case "force":
+		v, err := versionParam(action, params)
+		if err != nil {
+			return nil, err
+		}
+		if v < -1 {
+			return nil, errors.New("force needs a version of -1 or more")
+		}
+		return func(m *migrate.Migrate) error { return m.Force(v) }, nil
+	case "version":
+		if len(params) != 0 {
+			return nil, errors.New("version takes no arguments")
+		}
+		return func(m *migrate.Migrate) error {
+			v, dirty, err := m.Version()
+			if errors.Is(err, migrate.ErrNilVersion) {
+				fmt.Fprintln(out, "no migration applied")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if dirty {
+				fmt.Fprintf(out, "%d (dirty, fix the database and run force %d)\n", v, v)
+			} else {
+				fmt.Fprintln(out, v)
+			}
+			return nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown migrate action %q, expected up, down, goto, version or force", action)
+	}
+}
+
+func countParam(action string, params []string) (int, error) {
+	if len(params) != 1 {
+		return 0, fmt.Errorf("%s needs a number of migrations", action)
+	}
+
+	n, err := strconv.Atoi(params[0])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s needs a positive number of migrations, got %q", action, params[0])
+	}
+
+	return n, nil
+}
+
+func versionParam(action string, params []string) (int, error) {
+	if len(params) != 1 {
+		return 0, fmt.Errorf("%s needs a version", action)
+	}
+
+	v, err := strconv.Atoi(params[0])
+	if err != nil {
+		return 0, fmt.Errorf("%s needs a numeric version, got %q", action, params[0])
+	}
+
+	return v, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfg "github.com/fajaramaulana/go-grpc-micro-bank-server/config"
+)
+
+func TestMigrateSQLite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.db")
+	flags := []string{"--db-driver=sqlite", "--db-path=" + path}
+
+	run := func(args ...string) string {
+		t.Helper()
+
+		var out bytes.Buffer
+		if err := runMigrate(append(flags, args...), &out); err != nil {
+			t.Fatalf("migrate %v: %v", args, err)
+		}
+
+		return strings.TrimSpace(out.String())
+	}
+
+	if got := run("version"); got != "no migration applied" {
+		t.Errorf("version of a new database = %q", got)
+	}
+
+	run("up")
+	if got := run("version"); got != "21" {
+		t.Errorf("version after up = %q, want 21", got)
+	}
+	if got := run("up"); got != "no change" {
+		t.Errorf("second up = %q, want no change", got)
+	}
+
+	// migrations no longer insert data
+	if n := countRows(t, path, "bank_accounts"); n != 0 {
+		t.Errorf("up inserted %d accounts", n)
+	}
+
+	run("down", "18")
+	if got := run("version"); got != "3" {
+		t.Errorf("version after down 18 = %q, want 3", got)
+	}
+
+	run("goto", "5")
+	if got := run("version"); got != "5" {
+		t.Errorf("version after goto 5 = %q, want 5", got)
+	}
+
+	// every down migration undoes its up, so the schema can be rebuilt
+	run("down", "all")
+	if got := run("version"); got != "no migration applied" {
+		t.Errorf("version after down all = %q", got)
+	}
+	run("up", "2")
+	run("up")
+	if got := run("version"); got != "21" {
+		t.Errorf("version after rebuilding = %q, want 21", got)
+	}
+
+	run("force", "3")
+	if got := run("version"); got != "3" {
+		t.Errorf("version after force 3 = %q, want 3", got)
+	}
+}
+
+func TestMigrateUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.db")
+
+	for _, args := range [][]string{
+		{},
+		{"sideways"},
+		{"down"},
+		{"down", "0"},
+		{"up", "many"},
+		{"goto"},
+		{"goto", "-1"},
+		{"force", "-2"},
+		{"version", "7"},
+	} {
+		err := runMigrate(append([]string{"--db-driver=sqlite", "--db-path=" + path}, args...), &bytes.Buffer{})
+
+		var usageErr usageError
+		if !errors.As(err, &usageErr) {
+			t.Errorf("migrate %v: error = %v, want a usage error", args, err)
+		}
+	}
+
+	err := runMigrate([]string{"--db-driver=" + cfg.DriverMemory, "up"}, &bytes.Buffer{})
+	var usageErr usageError
+	if !errors.As(err, &usageErr) {
+		t.Errorf("migrate with the memory driver: error = %v, want a usage error", err)
+	}
+}
+
+func countRows(t *testing.T, path, table string) int {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer conn.Close()
+
+	var n int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		t.Fatalf("count %s: %v", table, err)
+	}
+
+	return n
+}
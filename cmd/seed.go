@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os/user"
+	"strings"
+	"time"
+
+	cfg "github.com/fajaramaulana/go-grpc-micro-bank-server/config"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/db/seed"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+)
+
+// runSeed loads a seed profile into the configured database. It is never run
+// implicitly, the profiles are meant for demos, development and load tests.
+func runSeed(args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("bank-server seed", flag.ContinueOnError)
+	profileName := flagSet.String("profile", "demo", "seed profile to load ("+strings.Join(seed.Names(), ", ")+")")
+	list := flagSet.Bool("list", false, "list the seed profiles and exit")
+
+	configuration, err := loadConfig(flagSet, args)
+	if err != nil {
+		return err
+	}
+
+	if *list {
+		for _, name := range seed.Names() {
+			profile, _ := seed.Lookup(name, time.Now())
+			fmt.Fprintf(out, "%-10s %s\n", name, profile.Description)
+		}
+		return nil
+	}
+
+	if flagSet.NArg() != 0 {
+		return usageError{fmt.Errorf("unexpected arguments %v", flagSet.Args())}
+	}
+	if configuration.DB.Driver == cfg.DriverMemory {
+		return usageError{errors.New("the memory driver is seeded with the demo profile on startup")}
+	}
+
+	profile, err := seed.Lookup(*profileName, time.Now())
+	if err != nil {
+		return usageError{err}
+	}
+
+	store, err := openStorage(configuration.DB)
+	if err != nil {
+		return err
+	}
+	defer store.close()
+
+	seeder, ok := store.db.(seed.Store)
+	if !ok {
+		return fmt.Errorf("the %s driver can't be seeded", configuration.DB.Driver)
+	}
+
+	if err := applySeed(store, seeder, profile); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "seed profile %s applied: %d accounts, %d transactions, existing rows kept\n",
+		profile.Name, len(profile.Accounts), len(profile.Transactions))
+
+	return nil
+}
+
+// applySeed applies profile and records it in the audit log of store, on
+// behalf of the user running the command.
+func applySeed(store *storage, seeder seed.Store, profile seed.Profile) (err error) {
+	ctx := context.Background()
+
+	auditLog, ok := store.db.(port.AuditLogPort)
+	if !ok {
+		return seed.Apply(ctx, seeder, profile)
+	}
+
+	principal := "cli"
+	if u, err := user.Current(); err == nil {
+		principal = "cli:" + u.Username
+	}
+	ctx = domainAudit.WithActor(ctx, domainAudit.Actor{Principal: principal, Method: "bank-server seed -profile " + profile.Name})
+
+	auditor := application.NewAuditor(auditLog, clock.Real())
+	ctx, scope := auditor.Start(ctx, "ApplySeed")
+	defer auditor.End(ctx, scope, &err)
+
+	for _, acc := range profile.Accounts {
+		scope.Affect(acc.AccountUuid)
+	}
+
+	return seed.Apply(ctx, seeder, profile)
+}
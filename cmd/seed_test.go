@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSeedSQLite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.db")
+	args := []string{"--db-driver=sqlite", "--db-path=" + path, "--metrics-port=0", "--profile=demo"}
+
+	// the second run finds every row in place
+	for i := 0; i < 2; i++ {
+		var out bytes.Buffer
+		if err := runSeed(args, &out); err != nil {
+			t.Fatalf("seed #%d: %v", i+1, err)
+		}
+		if !strings.Contains(out.String(), "seed profile demo applied") {
+			t.Errorf("seed #%d printed %q", i+1, out.String())
+		}
+	}
+
+	if n := countRows(t, path, "bank_accounts"); n != 5 {
+		t.Errorf("accounts = %d, want 5", n)
+	}
+	if n := countRows(t, path, "bank_transactions"); n != 5 {
+		t.Errorf("transactions = %d, want 5", n)
+	}
+}
+
+func TestSeedUnknownProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.db")
+
+	if err := runSeed([]string{"--db-driver=sqlite", "--db-path=" + path, "--profile=nope"}, &bytes.Buffer{}); err == nil {
+		t.Error("seeding an unknown profile succeeded")
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	cfg "github.com/fajaramaulana/go-grpc-micro-bank-server/config"
+	dbmigration "github.com/fajaramaulana/go-grpc-micro-bank-server/db"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/db/seed"
+	mydb "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/database"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/memory"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+)
+
+// storage is the database behind the bank service, with what main needs to
+// health check and release it and to elect the leader of the replicas.
+type storage struct {
+	db     port.BankDatabasePort
+	locker port.LeaderLockPort
+	ping   func(context.Context) error
+	close  func()
+}
+
+// openStorage opens the configured driver. Postgres and SQLite are migrated
+// unless disabled and their pools are registered with the metrics; the memory
+// store starts with the demo seed profile.
+func openStorage(c cfg.DBConfig) (*storage, error) {
+	if c.Driver == cfg.DriverMemory {
+		memoryAdapter := memory.NewMemoryAdapter()
+		profile, err := seed.Lookup("demo", time.Now())
+		if err == nil {
+			err = seed.Apply(context.Background(), memoryAdapter, profile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't seed memory store : %v", err)
+		}
+		log.Warn().Msg("Using the in-memory store, all data is lost on shutdown")
+
+		return &storage{
+			db:     memoryAdapter,
+			locker: memory.NewLocker(),
+			ping:   func(context.Context) error { return nil },
+			close:  func() {},
+		}, nil
+	}
+
+	// Open a connection to the database
+	sqlDb, err := openDB(c.Driver, c.DSN(), c.Pool)
+	if err != nil {
+		return nil, fmt.Errorf("can't open database : %v", err)
+	}
+
+	// Run database migrations
+	if c.AutoMigrate {
+		dbmigration.Migrate(sqlDb, c.Driver)
+	}
+
+	name := c.Name
+	newAdapter := mydb.NewDatabaseAdapter
+	if c.Driver == cfg.DriverSQLite {
+		name = "sqlite"
+		newAdapter = mydb.NewSQLiteDatabaseAdapter
+	}
+
+	unregister, err := metrics.RegisterDB(sqlDb, name)
+	if err != nil {
+		sqlDb.Close()
+		return nil, fmt.Errorf("can't register database metrics : %v", err)
+	}
+
+	databaseAdapter, err := newAdapter(sqlDb)
+	if err != nil {
+		unregister()
+		sqlDb.Close()
+		return nil, fmt.Errorf("can't create database adapter : %v", err)
+	}
+
+	closers := []*sql.DB{sqlDb}
+	unregisters := []func(){unregister}
+	closeAll := func() {
+		for _, unregister := range unregisters {
+			unregister()
+		}
+		for _, db := range closers {
+			if err := db.Close(); err != nil {
+				logErr := util.LogError(err.Error(), "", "Main - storage close")
+				log.Error().Msg(logErr)
+			}
+		}
+	}
+
+	if c.ReplicaDSN != "" {
+		replicaDb, err := openDB(c.Driver, c.ReplicaDSN, c.Pool)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("can't open replica : %v", err)
+		}
+		closers = append(closers, replicaDb)
+
+		if err := databaseAdapter.WithReplica(replicaDb, c.ReplicaFreshness); err != nil {
+			closeAll()
+			return nil, fmt.Errorf("can't use replica : %v", err)
+		}
+
+		unregister, err := metrics.RegisterDB(replicaDb, c.Name+"_replica")
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("can't register replica metrics : %v", err)
+		}
+		unregisters = append(unregisters, unregister)
+	}
+
+	// SQLite serves a single node, whose jobs need no election across
+	// processes
+	var locker port.LeaderLockPort = memory.NewLocker()
+	if c.Driver == cfg.DriverPostgres {
+		locker = mydb.NewAdvisoryLocker(sqlDb)
+	}
+
+	return &storage{
+		db:     databaseAdapter,
+		locker: locker,
+		ping:   sqlDb.PingContext,
+		close:  closeAll,
+	}, nil
+}
+
+// sqlDrivers maps the configured driver to the database/sql one.
+var sqlDrivers = map[string]string{
+	cfg.DriverPostgres: "pgx",
+	cfg.DriverSQLite:   "sqlite3",
+}
+
+// openDB opens a pool with the configured limits.
+func openDB(driver, dsn string, pool cfg.PoolConfig) (*sql.DB, error) {
+	db, err := sql.Open(sqlDrivers[driver], dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
+	return db, nil
+}
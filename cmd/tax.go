@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	cfg "github.com/fajaramaulana/go-grpc-micro-bank-server/config"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+)
+
+const taxReportUsage = `usage: bank-server tax-report --month YYYY-MM [flags]
+
+Writes, as CSV, the interest posted to every customer in the month and the
+tax withheld from it, one row per customer and currency. An account without
+a customer id is a customer of its own.
+
+The database is taken from the configuration flags below.
+
+flags:`
+
+// runTaxReport writes the tax report of a month to out, or to the file of
+// --out.
+func runTaxReport(args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("bank-server tax-report", flag.ContinueOnError)
+	flagSet.Usage = func() {
+		fmt.Fprintln(flagSet.Output(), taxReportUsage)
+		flagSet.PrintDefaults()
+	}
+	monthFlag := flagSet.String("month", "", "month of the report, as YYYY-MM")
+	customerId := flagSet.String("customer", "", "report only this customer id")
+	outPath := flagSet.String("out", "", "write the report to this file rather than stdout")
+
+	configuration, err := loadConfig(flagSet, args)
+	if err != nil {
+		return err
+	}
+	if configuration.DB.Driver == cfg.DriverMemory {
+		return usageError{errors.New("the memory driver keeps no withholdings across runs")}
+	}
+
+	month, err := time.Parse("2006-01", *monthFlag)
+	if err != nil {
+		flagSet.Usage()
+		return usageError{fmt.Errorf("expected --month as YYYY-MM, got %q", *monthFlag)}
+	}
+	if rest := flagSet.Args(); len(rest) != 0 {
+		flagSet.Usage()
+		return usageError{fmt.Errorf("unexpected arguments %v", rest)}
+	}
+
+	store, err := openStorage(configuration.DB)
+	if err != nil {
+		return err
+	}
+	defer store.close()
+
+	taxStore, ok := store.db.(port.TaxStorePort)
+	if !ok {
+		return fmt.Errorf("the %s driver has no tax store", configuration.DB.Driver)
+	}
+
+	rows, err := application.NewTaxService(taxStore, store.db, clock.Real()).TaxReport(context.Background(), month, *customerId)
+	if err != nil {
+		return fmt.Errorf("can't build the tax report of %v : %w", *monthFlag, err)
+	}
+
+	if *outPath == "" {
+		return domainTax.WriteReport(out, month, rows)
+	}
+
+	file, err := os.Create(*outPath)
+	if err != nil {
+		return err
+	}
+	if err := domainTax.WriteReport(file, month, rows); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
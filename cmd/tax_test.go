@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTaxReportSQLite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bank.db")
+	flags := []string{"--db-driver=sqlite", "--db-path=" + path, "--metrics-port=0"}
+
+	if err := runSeed(append(flags, "--profile=demo"), &bytes.Buffer{}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	// the two accounts of a customer withheld tax in May, one of them in
+	// June too
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Exec(`UPDATE bank_accounts SET customer_id = 'CUST-1'`); err != nil {
+		t.Fatalf("update customer: %v", err)
+	}
+	rows, err := conn.Query(`SELECT t.transaction_uuid, a.account_number, a.currency
+		FROM bank_transactions t JOIN bank_accounts a ON a.account_uuid = t.account_uuid ORDER BY a.account_number LIMIT 2`)
+	if err != nil {
+		t.Fatalf("select transactions: %v", err)
+	}
+	var trxs, accountNums []string
+	var currency string
+	for rows.Next() {
+		var trx, accountNum string
+		if err := rows.Scan(&trx, &accountNum, &currency); err != nil {
+			t.Fatalf("Scan: %v", err)
+		}
+		trxs, accountNums = append(trxs, trx), append(accountNums, accountNum)
+	}
+	rows.Close()
+	if len(trxs) != 2 {
+		t.Fatalf("seeded transactions = %v, want 2 accounts", accountNums)
+	}
+	for i, withheldAt := range []string{"2024-05-31 17:00:00+00:00", "2024-05-01 00:00:00+00:00", "2024-06-01 00:00:00+00:00"} {
+		interestTrx := uuid.NewString()
+		_, err := conn.Exec(`INSERT INTO bank_transactions (transaction_uuid, account_uuid, transaction_timestamp, amount,
+			transaction_type, notes, created_at, updated_at) SELECT ?, account_uuid, transaction_timestamp, 12.5, 'IN', 'Interest',
+			created_at, updated_at FROM bank_transactions WHERE transaction_uuid = ?`, interestTrx, trxs[i%2])
+		if err == nil {
+			_, err = conn.Exec(`INSERT INTO bank_tax_withholdings (withholding_uuid, account_uuid, interest_transaction_uuid, status,
+				balance, interest_amount, rate, tax_amount, withheld_at) SELECT ?, account_uuid, transaction_uuid, 'WITHHELD', 100, 12.5, 20, 2.5, ?
+				FROM bank_transactions WHERE transaction_uuid = ?`,
+				uuid.NewString(), withheldAt, interestTrx)
+		}
+		if err != nil {
+			t.Fatalf("insert withholding: %v", err)
+		}
+	}
+
+	out := filepath.Join(dir, "tax-2024-05.csv")
+	if err := runTaxReport(append(flags, "--month=2024-05", "--customer=CUST-1", "--out="+out), &bytes.Buffer{}); err != nil {
+		t.Fatalf("tax-report: %v", err)
+	}
+	report, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want := "period,customer_id,account_numbers,currency,postings,gross_interest,tax_withheld,net_interest\n" +
+		"2024-05,CUST-1," + accountNums[0] + " " + accountNums[1] + "," + currency + ",2,25.00,5.00,20.00\n"
+	if string(report) != want {
+		t.Errorf("tax-report wrote\n%s\nwant\n%s", report, want)
+	}
+
+	if err := runTaxReport(append(flags, "--month=2024-05", "--customer=CUST-2"), &bytes.Buffer{}); err == nil {
+		t.Error("tax-report of an unknown customer succeeded")
+	}
+
+	var empty bytes.Buffer
+	if err := runTaxReport(append(flags, "--month=2024-04"), &empty); err != nil {
+		t.Fatalf("tax-report of April: %v", err)
+	}
+	if lines := strings.Count(empty.String(), "\n"); lines != 1 {
+		t.Errorf("tax-report of a month without postings wrote %q, want the header only", empty.String())
+	}
+}
+
+func TestTaxReportUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.db")
+
+	for _, args := range [][]string{
+		{"--db-driver=sqlite", "--db-path=" + path},
+		{"--db-driver=sqlite", "--db-path=" + path, "--month=May"},
+		{"--db-driver=sqlite", "--db-path=" + path, "--month=2024-05", "extra"},
+		{"--db-driver=memory", "--month=2024-05"},
+	} {
+		var usageErr usageError
+		if err := runTaxReport(args, &bytes.Buffer{}); !errors.As(err, &usageErr) {
+			t.Errorf("tax-report %v = %v, want a usage error", args, err)
+		}
+	}
+}
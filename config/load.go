@@ -0,0 +1,265 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// dotEnvFiles are loaded into the environment when present, for local
+// development. Variables already set in the environment win.
+var dotEnvFiles = []string{".env", "../.env"}
+
+const redactedValue = "******"
+
+// field is one configurable leaf of Config.
+type field struct {
+	key    string // dotted yaml path, e.g. db.host
+	env    string
+	flag   string
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+// Load builds the configuration from, in increasing order of precedence:
+// built-in defaults, the YAML file given by --config or CONFIG_FILE,
+// environment variables (including .env files) and command line flags.
+// The configuration flags are registered on flagSet, so callers can add
+// their own flags before calling Load. The result is validated.
+func Load(flagSet *flag.FlagSet, args []string) (*Config, error) {
+	cfg := Default()
+	fields := fieldsOf(&cfg)
+
+	configFile := flagSet.String("config", "", "optional YAML configuration file (env CONFIG_FILE)")
+
+	// flags are collected first and applied last so they win over the file
+	// and the environment
+	flagValues := map[string]string{}
+	for _, f := range fields {
+		f := f
+		if f.value.Kind() == reflect.Bool {
+			flagSet.BoolFunc(f.flag, f.usage+" (env "+f.env+")", func(s string) error {
+				flagValues[f.flag] = s
+				return nil
+			})
+			continue
+		}
+
+		flagSet.Func(f.flag, f.usage+" (env "+f.env+")", func(s string) error {
+			flagValues[f.flag] = s
+			return nil
+		})
+	}
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	for _, name := range dotEnvFiles {
+		if err := godotenv.Load(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("can't load %s : %v", name, err)
+		}
+	}
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(&cfg, path); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields {
+		raw, ok := os.LookupEnv(f.env)
+		if !ok || raw == "" {
+			continue
+		}
+
+		if err := setValue(f.value, raw); err != nil {
+			return nil, fmt.Errorf("invalid %s=%q : %v", f.env, raw, err)
+		}
+	}
+
+	for _, f := range fields {
+		raw, ok := flagValues[f.flag]
+		if !ok {
+			continue
+		}
+
+		if err := setValue(f.value, raw); err != nil {
+			return nil, fmt.Errorf("invalid --%s=%q : %v", f.flag, raw, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("can't open config file : %v", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("can't parse config file %s : %v", path, err)
+	}
+
+	return nil
+}
+
+// Redacted returns a copy of the configuration with every secret replaced,
+// safe to print or log.
+func (c Config) Redacted() Config {
+	for _, f := range fieldsOf(&c) {
+		if f.secret && f.value.String() != "" {
+			f.value.SetString(redactedValue)
+		}
+	}
+
+	return c
+}
+
+// Print writes the redacted configuration as YAML.
+func (c Config) Print(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+func fieldsOf(cfg *Config) []field {
+	var fields []field
+	collectFields(reflect.ValueOf(cfg).Elem(), "", &fields)
+
+	return fields
+}
+
+func collectFields(v reflect.Value, prefix string, fields *[]field) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if sf.Tag.Get("env") == "" && sf.Type.Kind() == reflect.Struct {
+			collectFields(v.Field(i), key, fields)
+			continue
+		}
+
+		*fields = append(*fields, field{
+			key:    key,
+			env:    sf.Tag.Get("env"),
+			flag:   sf.Tag.Get("flag"),
+			usage:  sf.Tag.Get("usage"),
+			secret: sf.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+}
+
+// sourceOf returns the env var and flag of a dotted key, for error messages.
+func sourceOf(key string) (field, bool) {
+	var cfg Config
+	for _, f := range fieldsOf(&cfg) {
+		if f.key == key {
+			return f, true
+		}
+	}
+
+	return field{}, false
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setValue(v reflect.Value, raw string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(raw)
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Uint32:
+		n, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case v.Kind() == reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case v.Kind() == reflect.Slice:
+		parts := strings.Split(raw, ",")
+		s := reflect.MakeSlice(v.Type(), 0, len(parts))
+		for _, p := range parts {
+			if strings.TrimSpace(p) == "" {
+				continue
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(elem, p); err != nil {
+				return err
+			}
+			s = reflect.Append(s, elem)
+		}
+		v.Set(s)
+	default:
+		return fmt.Errorf("unsupported config type %v", v.Type())
+	}
+
+	return nil
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -2,51 +2,51 @@ package dbmigration
 
 import (
 	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
 	migrate "github.com/golang-migrate/migrate/v4"
 
 	"github.com/chilts/sid"
+	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"github.com/rs/zerolog/log"
 )
 
+// migrations holds the Postgres migrations at the top level and those of
+// every other dialect in a sub directory named after it.
+//
+//go:embed migrations
+var migrations embed.FS
+
 // Migrate migrates the database using the provided SQL connection.
 // It performs the following steps:
 // 1. Generates a unique session ID.
 // 2. Logs the start of the migration process.
-// 3. Sets up the database driver using the provided SQL connection.
-// 4. Creates a new migration instance with the specified migration files directory and database driver.
-// 5. Executes the "up" migration.
-// 6. Logs the result of the migration process.
+// 3. Sets up the migrations of dialect ("postgres" or "sqlite") on the provided SQL connection, see New.
+// 4. Executes the "up" migration.
+// 5. Logs the result of the migration process.
 //
 // If an error occurs during any of the steps, the function logs the error and terminates the migration process.
 // The function returns no values.
-func Migrate(conn *sql.DB) {
+func Migrate(conn *sql.DB, dialect string) {
 	sidString := sid.Id()
 	logStart := util.LogRequest("", "Migrate-"+sidString, "dbmigration - Migrate")
 	log.Info().Msg(logStart)
 
-	dbDriver, err := postgres.WithInstance(conn, &postgres.Config{})
-	if err != nil {
-		logErr := util.LogError(err.Error(), "Migrate-"+sidString, "dbmigration - Migrate - postgres.WithInstance")
-		log.Fatal().Msg(logErr)
-	}
-
-	m, err := migrate.NewWithDatabaseInstance("file://../db/migrations", "postgres", dbDriver)
-
+	m, err := New(conn, dialect)
 	if err != nil {
-		logErr := util.LogError(err.Error(), "Migrate-"+sidString, "dbmigration - Migrate - migrate.NewWithDatabaseInstance")
+		logErr := util.LogError(err.Error(), "Migrate-"+sidString, "dbmigration - Migrate - New")
 		log.Fatal().Msg(logErr)
 	}
 
-	// if err := m.Down(); err != nil {
-	// 	log.Println("Database migration (down) failed :", err)
-	// }
-
 	if err := m.Up(); err != nil {
-		if err.Error() == "no change" {
+		if errors.Is(err, migrate.ErrNoChange) {
 			log.Info().Msg("Database migration (up) no change")
 		} else {
 			logErr := util.LogError(err.Error(), "Migrate-"+sidString, "dbmigration - Migrate - m.Up()")
@@ -57,3 +57,51 @@ func Migrate(conn *sql.DB) {
 		log.Info().Msg(logStop)
 	}
 }
+
+// New returns a migrate instance running the embedded migrations of dialect
+// on conn. Closing it closes conn as well.
+func New(conn *sql.DB, dialect string) (*migrate.Migrate, error) {
+	dbDriver, dir, err := driverFor(conn, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := iofs.New(migrations, dir)
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s migrations : %v", dialect, err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, dialect, dbDriver)
+	if err != nil {
+		return nil, err
+	}
+	m.Log = logger{}
+
+	return m, nil
+}
+
+// driverFor returns the migrate driver of dialect and the directory of its
+// migrations.
+func driverFor(conn *sql.DB, dialect string) (database.Driver, string, error) {
+	switch dialect {
+	case "postgres":
+		dbDriver, err := postgres.WithInstance(conn, &postgres.Config{})
+		return dbDriver, "migrations", err
+	case "sqlite":
+		dbDriver, err := sqlite3.WithInstance(conn, &sqlite3.Config{})
+		return dbDriver, "migrations/sqlite", err
+	default:
+		return nil, "", fmt.Errorf("no migrations for database %q", dialect)
+	}
+}
+
+// logger reports every applied migration through zerolog.
+type logger struct{}
+
+func (logger) Printf(format string, v ...interface{}) {
+	log.Info().Msg(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (logger) Verbose() bool {
+	return false
+}
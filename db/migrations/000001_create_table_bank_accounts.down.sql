@@ -1 +1 @@
-DROP TABLE IF EXISTS bank_accounts CASCADE;
\ No newline at end of file
+DROP TABLE IF EXISTS bank_accounts;
\ No newline at end of file
@@ -1 +1 @@
-DROP TABLE IF EXISTS bank_transactions CASCADE;
\ No newline at end of file
+DROP TABLE IF EXISTS bank_transactions;
\ No newline at end of file
@@ -1 +1 @@
-DROP TABLE IF EXISTS bank_exchange_rates CASCADE;
\ No newline at end of file
+DROP TABLE IF EXISTS bank_exchange_rates;
\ No newline at end of file
@@ -1 +1 @@
-DROP TABLE IF EXISTS bank_transfers CASCADE;
\ No newline at end of file
+DROP TABLE IF EXISTS bank_transfers;
\ No newline at end of file
@@ -1 +1,2 @@
-DELETE FROM bank_transactions; 
\ No newline at end of file
+-- Nothing to undo, see the up migration.
+SELECT 1;
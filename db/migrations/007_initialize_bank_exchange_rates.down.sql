@@ -1 +1,2 @@
-DELETE FROM bank_exchange_rates;
\ No newline at end of file
+-- Nothing to undo, see the up migration.
+SELECT 1;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS bank_outbox(
+    outbox_id               BIGSERIAL       PRIMARY KEY,
+    event_uuid              UUID            NOT NULL UNIQUE,
+    event_type              VARCHAR(100)    NOT NULL,
+    event_version           INTEGER         NOT NULL,
+    aggregate_uuid          UUID            NOT NULL,
+    occurred_at             TIMESTAMPTZ     NOT NULL,
+    payload                 JSONB           NOT NULL,
+    published_at            TIMESTAMPTZ,
+    created_at              TIMESTAMPTZ     NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bank_outbox_pending_idx ON bank_outbox (outbox_id) WHERE published_at IS NULL;
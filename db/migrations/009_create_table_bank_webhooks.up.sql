@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS bank_webhook_subscriptions(
+    subscription_uuid       UUID            PRIMARY KEY,
+    account_uuid            UUID            NOT NULL REFERENCES bank_accounts,
+    account_number          VARCHAR(20)     NOT NULL,
+    url                     TEXT            NOT NULL,
+    event_types             TEXT            NOT NULL,
+    secret                  TEXT            NOT NULL,
+    created_at              TIMESTAMPTZ     NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bank_webhook_subscriptions_account_idx ON bank_webhook_subscriptions (account_uuid);
+
+CREATE TABLE IF NOT EXISTS bank_webhook_deliveries(
+    delivery_uuid           UUID            PRIMARY KEY,
+    subscription_uuid       UUID            NOT NULL REFERENCES bank_webhook_subscriptions ON DELETE CASCADE,
+    event_uuid              UUID            NOT NULL,
+    event_type              VARCHAR(100)    NOT NULL,
+    payload                 TEXT            NOT NULL,
+    state                   VARCHAR(20)     NOT NULL,
+    attempt_count           INTEGER         NOT NULL DEFAULT 0,
+    next_attempt_at         TIMESTAMPTZ     NOT NULL,
+    last_error              TEXT            NOT NULL DEFAULT '',
+    created_at              TIMESTAMPTZ     NOT NULL,
+    updated_at              TIMESTAMPTZ     NOT NULL,
+    UNIQUE (subscription_uuid, event_uuid)
+);
+
+CREATE INDEX IF NOT EXISTS bank_webhook_deliveries_due_idx ON bank_webhook_deliveries (next_attempt_at) WHERE state = 'PENDING';
+
+CREATE TABLE IF NOT EXISTS bank_webhook_attempts(
+    attempt_id              BIGSERIAL       PRIMARY KEY,
+    delivery_uuid           UUID            NOT NULL REFERENCES bank_webhook_deliveries ON DELETE CASCADE,
+    attempted_at            TIMESTAMPTZ     NOT NULL,
+    status_code             INTEGER         NOT NULL,
+    error                   TEXT            NOT NULL DEFAULT '',
+    duration_ms             BIGINT          NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bank_webhook_attempts_delivery_idx ON bank_webhook_attempts (delivery_uuid);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bank_audit_head;
+DROP TABLE IF EXISTS bank_audit_log;
+DROP FUNCTION IF EXISTS bank_audit_log_append_only();
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS bank_audit_log(
+    seq                     BIGINT          PRIMARY KEY,
+    occurred_at             TIMESTAMPTZ     NOT NULL,
+    principal               TEXT            NOT NULL,
+    remote_addr             TEXT            NOT NULL,
+    method                  TEXT            NOT NULL,
+    action                  VARCHAR(100)    NOT NULL,
+    fingerprint             VARCHAR(64)     NOT NULL,
+    outcome                 VARCHAR(10)     NOT NULL,
+    error                   TEXT            NOT NULL,
+    entities                TEXT            NOT NULL,
+    prev_hash               VARCHAR(64)     NOT NULL,
+    hash                    VARCHAR(64)     NOT NULL
+);
+
+-- the single row holds the last seq and hash, updating it first serializes
+-- the appends
+CREATE TABLE IF NOT EXISTS bank_audit_head(
+    id                      INTEGER         PRIMARY KEY CHECK (id = 1),
+    seq                     BIGINT          NOT NULL,
+    hash                    VARCHAR(64)     NOT NULL
+);
+
+INSERT INTO bank_audit_head (id, seq, hash)
+VALUES (1, 0, '0000000000000000000000000000000000000000000000000000000000000000')
+ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION bank_audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'bank_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS bank_audit_log_append_only ON bank_audit_log;
+CREATE TRIGGER bank_audit_log_append_only BEFORE UPDATE OR DELETE ON bank_audit_log
+    FOR EACH ROW EXECUTE FUNCTION bank_audit_log_append_only();
+
+DROP TRIGGER IF EXISTS bank_audit_log_no_truncate ON bank_audit_log;
+CREATE TRIGGER bank_audit_log_no_truncate BEFORE TRUNCATE ON bank_audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION bank_audit_log_append_only();
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS bank_transfers_reversal_of_uuid;
+
+ALTER TABLE bank_transfers
+    DROP CONSTRAINT IF EXISTS bank_transfers_reversed_amount,
+    DROP COLUMN IF EXISTS reversed_amount,
+    DROP COLUMN IF EXISTS reversal_reason,
+    DROP COLUMN IF EXISTS reversal_of_uuid;
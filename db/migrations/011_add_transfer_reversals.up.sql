@@ -0,0 +1,10 @@
+ALTER TABLE bank_transfers
+    ADD COLUMN IF NOT EXISTS reversal_of_uuid   UUID            REFERENCES bank_transfers,
+    ADD COLUMN IF NOT EXISTS reversal_reason    TEXT            NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS reversed_amount    NUMERIC(15,2)   NOT NULL DEFAULT 0;
+
+ALTER TABLE bank_transfers DROP CONSTRAINT IF EXISTS bank_transfers_reversed_amount;
+ALTER TABLE bank_transfers ADD CONSTRAINT bank_transfers_reversed_amount
+    CHECK (reversed_amount >= 0 AND reversed_amount <= amount);
+
+CREATE INDEX IF NOT EXISTS bank_transfers_reversal_of_uuid ON bank_transfers (reversal_of_uuid);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS bank_transfers_timestamp;
+DROP INDEX IF EXISTS bank_transfers_to_account_timestamp;
+DROP INDEX IF EXISTS bank_transfers_from_account_timestamp;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS bank_holds(
+    hold_uuid               UUID            PRIMARY KEY,
+    account_uuid            UUID            NOT NULL REFERENCES bank_accounts,
+    account_number          VARCHAR(20)     NOT NULL,
+    amount                  NUMERIC(15,2)   NOT NULL,
+    captured_amount         NUMERIC(15,2)   NOT NULL DEFAULT 0,
+    status                  VARCHAR(20)     NOT NULL,
+    notes                   TEXT            NOT NULL DEFAULT '',
+    expires_at              TIMESTAMPTZ     NOT NULL,
+    created_at              TIMESTAMPTZ     NOT NULL,
+    updated_at              TIMESTAMPTZ     NOT NULL,
+    CONSTRAINT bank_holds_captured_amount CHECK (amount > 0 AND captured_amount >= 0 AND captured_amount <= amount)
+);
+
+CREATE INDEX IF NOT EXISTS bank_holds_active_idx ON bank_holds (account_uuid, expires_at) WHERE status = 'ACTIVE';
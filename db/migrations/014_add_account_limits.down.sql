@@ -0,0 +1,8 @@
+ALTER TABLE bank_accounts
+    DROP CONSTRAINT IF EXISTS bank_accounts_limits,
+    DROP COLUMN IF EXISTS overdraft_used,
+    DROP COLUMN IF EXISTS minimum_balance,
+    DROP COLUMN IF EXISTS overdraft_limit,
+    DROP COLUMN IF EXISTS product_code;
+
+DROP TABLE IF EXISTS bank_products;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS bank_products(
+    product_code            VARCHAR(20)     PRIMARY KEY,
+    name                    VARCHAR(100)    NOT NULL,
+    overdraft_limit         NUMERIC(15,2)   NOT NULL DEFAULT 0,
+    minimum_balance         NUMERIC(15,2)   NOT NULL DEFAULT 0,
+    created_at              TIMESTAMPTZ     NOT NULL,
+    updated_at              TIMESTAMPTZ     NOT NULL,
+    CONSTRAINT bank_products_limits CHECK (overdraft_limit >= 0 AND minimum_balance >= 0)
+);
+
+-- an account of a product without a row has no overdraft and no minimum
+-- balance, the NULL limits of an account fall back to its product
+ALTER TABLE bank_accounts
+    ADD COLUMN IF NOT EXISTS product_code       VARCHAR(20)     NOT NULL DEFAULT 'STANDARD',
+    ADD COLUMN IF NOT EXISTS overdraft_limit    NUMERIC(15,2),
+    ADD COLUMN IF NOT EXISTS minimum_balance    NUMERIC(15,2),
+    ADD COLUMN IF NOT EXISTS overdraft_used     NUMERIC(15,2)   NOT NULL DEFAULT 0;
+
+UPDATE bank_accounts SET overdraft_used = -current_balance WHERE current_balance < 0;
+
+ALTER TABLE bank_accounts DROP CONSTRAINT IF EXISTS bank_accounts_limits;
+ALTER TABLE bank_accounts ADD CONSTRAINT bank_accounts_limits
+    CHECK (overdraft_limit >= 0 AND minimum_balance >= 0 AND overdraft_used >= 0);
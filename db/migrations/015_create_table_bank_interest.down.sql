@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS bank_interest_accruals;
+DROP TABLE IF EXISTS bank_interest_rates;
+
+ALTER TABLE bank_products
+    DROP COLUMN IF EXISTS interest_capitalization,
+    DROP COLUMN IF EXISTS interest_day_count;
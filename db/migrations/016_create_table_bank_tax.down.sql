@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS bank_tax_withholdings;
+DROP TABLE IF EXISTS bank_tax_rules;
+
+ALTER TABLE bank_accounts
+    DROP COLUMN IF EXISTS tax_exempt;
@@ -0,0 +1,30 @@
+ALTER TABLE bank_accounts
+    ADD COLUMN IF NOT EXISTS tax_exempt    BOOLEAN     NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS bank_tax_rules(
+    currency                VARCHAR(5)      NOT NULL,
+    effective_from          DATE            NOT NULL,
+    -- percent of the interest
+    rate                    NUMERIC(9,6)    NOT NULL,
+    min_balance             NUMERIC(15,2)   NOT NULL,
+    payable_account_uuid    UUID            NOT NULL REFERENCES bank_accounts,
+    created_at              TIMESTAMPTZ     NOT NULL,
+    PRIMARY KEY (currency, effective_from),
+    CONSTRAINT bank_tax_rules_valid CHECK (rate >= 0 AND rate <= 100 AND min_balance >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS bank_tax_withholdings(
+    withholding_uuid            UUID            PRIMARY KEY,
+    account_uuid                UUID            NOT NULL REFERENCES bank_accounts,
+    interest_transaction_uuid   UUID            NOT NULL UNIQUE REFERENCES bank_transactions,
+    tax_transaction_uuid        UUID            REFERENCES bank_transactions,
+    payable_transaction_uuid    UUID            REFERENCES bank_transactions,
+    status                      VARCHAR(20)     NOT NULL,
+    balance                     NUMERIC(15,2)   NOT NULL,
+    interest_amount             NUMERIC(15,2)   NOT NULL,
+    rate                        NUMERIC(9,6)    NOT NULL,
+    tax_amount                  NUMERIC(15,2)   NOT NULL,
+    withheld_at                 TIMESTAMPTZ     NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bank_tax_withholdings_withheld_at_idx ON bank_tax_withholdings (withheld_at, account_uuid);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS bank_transfer_fees;
+DROP TABLE IF EXISTS bank_fee_tiers;
+DROP TABLE IF EXISTS bank_fee_schedules;
+
+ALTER TABLE bank_accounts
+    DROP COLUMN IF EXISTS customer_segment;
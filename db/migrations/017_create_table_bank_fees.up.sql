@@ -0,0 +1,47 @@
+ALTER TABLE bank_accounts
+    ADD COLUMN IF NOT EXISTS customer_segment  VARCHAR(20) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS bank_fee_schedules(
+    schedule_uuid           UUID            PRIMARY KEY,
+    currency                VARCHAR(5)      NOT NULL,
+    -- empty matches every channel or segment
+    channel                 VARCHAR(20)     NOT NULL,
+    customer_segment        VARCHAR(20)     NOT NULL,
+    min_fee                 NUMERIC(15,2)   NOT NULL,
+    -- 0 is no cap
+    max_fee                 NUMERIC(15,2)   NOT NULL,
+    free_per_month          INTEGER         NOT NULL,
+    fee_account_uuid        UUID            NOT NULL REFERENCES bank_accounts,
+    created_at              TIMESTAMPTZ     NOT NULL,
+    updated_at              TIMESTAMPTZ     NOT NULL,
+    CONSTRAINT bank_fee_schedules_key UNIQUE (currency, channel, customer_segment),
+    CONSTRAINT bank_fee_schedules_valid CHECK (min_fee >= 0 AND max_fee >= 0 AND free_per_month >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS bank_fee_tiers(
+    schedule_uuid           UUID            NOT NULL REFERENCES bank_fee_schedules ON DELETE CASCADE,
+    min_amount              NUMERIC(15,2)   NOT NULL,
+    flat_amount             NUMERIC(15,2)   NOT NULL,
+    -- percent of the amount
+    rate                    NUMERIC(9,6)    NOT NULL,
+    PRIMARY KEY (schedule_uuid, min_amount),
+    CONSTRAINT bank_fee_tiers_valid CHECK (min_amount >= 0 AND flat_amount >= 0 AND rate >= 0 AND rate <= 100)
+);
+
+CREATE TABLE IF NOT EXISTS bank_transfer_fees(
+    transfer_uuid           UUID            PRIMARY KEY REFERENCES bank_transfers,
+    schedule_uuid           UUID            NOT NULL REFERENCES bank_fee_schedules,
+    account_uuid            UUID            NOT NULL REFERENCES bank_accounts,
+    fee_account_uuid        UUID            NOT NULL REFERENCES bank_accounts,
+    currency                VARCHAR(5)      NOT NULL,
+    -- in the currency of the transfer, before any waiver
+    fee                     NUMERIC(15,2)   NOT NULL,
+    -- what was debited, in the unit of the amount booked on the transfer
+    amount                  NUMERIC(15,2)   NOT NULL,
+    waived                  BOOLEAN         NOT NULL,
+    fee_transaction_uuid    UUID            REFERENCES bank_transactions,
+    income_transaction_uuid UUID            REFERENCES bank_transactions,
+    charged_at              TIMESTAMPTZ     NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bank_transfer_fees_allowance_idx ON bank_transfer_fees (account_uuid, schedule_uuid, charged_at);
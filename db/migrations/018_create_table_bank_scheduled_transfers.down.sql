@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bank_scheduled_transfer_executions;
+DROP TABLE IF EXISTS bank_scheduled_transfers;
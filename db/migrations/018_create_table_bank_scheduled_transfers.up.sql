@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS bank_scheduled_transfers(
+    schedule_uuid           UUID            PRIMARY KEY,
+    from_account_uuid       UUID            NOT NULL REFERENCES bank_accounts,
+    to_account_uuid         UUID            NOT NULL REFERENCES bank_accounts,
+    currency                VARCHAR(5)      NOT NULL,
+    amount                  NUMERIC(15,2)   NOT NULL,
+    notes                   TEXT            NOT NULL,
+    channel                 VARCHAR(20)     NOT NULL,
+    -- an RRULE, empty for a single transfer at start_at
+    recurrence              TEXT            NOT NULL,
+    start_at                TIMESTAMPTZ     NOT NULL,
+    status                  VARCHAR(20)     NOT NULL,
+    -- null once no occurrence is left, moved ahead while a worker runs it
+    next_run_at             TIMESTAMPTZ,
+    occurrence              INTEGER         NOT NULL,
+    attempt                 INTEGER         NOT NULL,
+    max_retries             INTEGER         NOT NULL,
+    retry_interval_seconds  BIGINT          NOT NULL,
+    created_at              TIMESTAMPTZ     NOT NULL,
+    updated_at              TIMESTAMPTZ     NOT NULL,
+    CONSTRAINT bank_scheduled_transfers_valid CHECK (amount > 0 AND max_retries >= 0 AND retry_interval_seconds >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS bank_scheduled_transfers_due_idx ON bank_scheduled_transfers (status, next_run_at);
+CREATE INDEX IF NOT EXISTS bank_scheduled_transfers_from_idx ON bank_scheduled_transfers (from_account_uuid);
+
+CREATE TABLE IF NOT EXISTS bank_scheduled_transfer_executions(
+    execution_uuid          UUID            PRIMARY KEY,
+    schedule_uuid           UUID            NOT NULL REFERENCES bank_scheduled_transfers ON DELETE CASCADE,
+    occurrence              INTEGER         NOT NULL,
+    attempt                 INTEGER         NOT NULL,
+    scheduled_for           TIMESTAMPTZ     NOT NULL,
+    executed_at             TIMESTAMPTZ     NOT NULL,
+    status                  VARCHAR(20)     NOT NULL,
+    transfer_uuid           UUID            REFERENCES bank_transfers,
+    error                   TEXT            NOT NULL,
+    -- an attempt is recorded once, whichever worker ran it
+    CONSTRAINT bank_scheduled_transfer_executions_key UNIQUE (schedule_uuid, occurrence, attempt)
+);
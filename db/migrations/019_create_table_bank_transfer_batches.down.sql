@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bank_transfer_batch_items;
+DROP TABLE IF EXISTS bank_transfer_batches;
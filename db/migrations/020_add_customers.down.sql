@@ -0,0 +1,11 @@
+DELETE FROM bank_webhook_subscriptions WHERE account_uuid IS NULL;
+
+DROP INDEX IF EXISTS bank_webhook_subscriptions_customer_idx;
+ALTER TABLE bank_webhook_subscriptions
+    DROP CONSTRAINT IF EXISTS bank_webhook_subscriptions_scope,
+    DROP COLUMN IF EXISTS customer_id,
+    ALTER COLUMN account_number DROP DEFAULT,
+    ALTER COLUMN account_uuid SET NOT NULL;
+
+DROP INDEX IF EXISTS bank_accounts_customer_idx;
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS customer_id;
@@ -0,0 +1,18 @@
+-- the accounts of a customer share its id, an account without one is a
+-- customer of its own
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS customer_id VARCHAR(36) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS bank_accounts_customer_idx ON bank_accounts (customer_id) WHERE customer_id <> '';
+
+-- a webhook subscription is for one account or for every account of a
+-- customer
+ALTER TABLE bank_webhook_subscriptions
+    ALTER COLUMN account_uuid DROP NOT NULL,
+    ALTER COLUMN account_number SET DEFAULT '',
+    ADD COLUMN IF NOT EXISTS customer_id VARCHAR(36) NOT NULL DEFAULT '';
+
+ALTER TABLE bank_webhook_subscriptions DROP CONSTRAINT IF EXISTS bank_webhook_subscriptions_scope;
+ALTER TABLE bank_webhook_subscriptions ADD CONSTRAINT bank_webhook_subscriptions_scope
+    CHECK ((account_uuid IS NULL) <> (customer_id = ''));
+
+CREATE INDEX IF NOT EXISTS bank_webhook_subscriptions_customer_idx ON bank_webhook_subscriptions (customer_id) WHERE customer_id <> '';
@@ -0,0 +1,2 @@
+ALTER TABLE bank_products
+    DROP COLUMN IF EXISTS interest_accrual_frequency;
@@ -0,0 +1,2 @@
+ALTER TABLE bank_products
+    ADD COLUMN IF NOT EXISTS interest_accrual_frequency VARCHAR(10)     NOT NULL DEFAULT 'DAILY';
@@ -0,0 +1,25 @@
+-- SQLite ignores the scale of NUMERIC columns, the triggers round balances
+-- to cents the way NUMERIC(15,2) does in Postgres.
+CREATE TABLE IF NOT EXISTS bank_accounts(
+  account_uuid        TEXT            PRIMARY KEY,
+  account_number      VARCHAR(20)     UNIQUE NOT NULL,
+  account_name        VARCHAR(100)    NOT NULL,
+  currency            VARCHAR(5)      NOT NULL,
+  current_balance     NUMERIC(15,2)   NOT NULL,
+  created_at          TIMESTAMP,
+  updated_at          TIMESTAMP
+);
+
+CREATE TRIGGER IF NOT EXISTS bank_accounts_round_balance_insert
+AFTER INSERT ON bank_accounts
+WHEN NEW.current_balance <> ROUND(NEW.current_balance, 2)
+BEGIN
+  UPDATE bank_accounts SET current_balance = ROUND(NEW.current_balance, 2) WHERE account_uuid = NEW.account_uuid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS bank_accounts_round_balance_update
+AFTER UPDATE OF current_balance ON bank_accounts
+WHEN NEW.current_balance <> ROUND(NEW.current_balance, 2)
+BEGIN
+  UPDATE bank_accounts SET current_balance = ROUND(NEW.current_balance, 2) WHERE account_uuid = NEW.account_uuid;
+END;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS bank_transactions(
+  transaction_uuid          TEXT            PRIMARY KEY,
+  account_uuid              TEXT            NOT NULL REFERENCES bank_accounts,
+  transaction_timestamp     TIMESTAMP       NOT NULL,
+  amount                    NUMERIC(15,2)   NOT NULL,
+  transaction_type          VARCHAR(25)     NOT NULL,
+  notes                     TEXT,
+  created_at                TIMESTAMP,
+  updated_at                TIMESTAMP
+);
+
+CREATE TRIGGER IF NOT EXISTS bank_transactions_round_amount
+AFTER INSERT ON bank_transactions
+WHEN NEW.amount <> ROUND(NEW.amount, 2)
+BEGIN
+  UPDATE bank_transactions SET amount = ROUND(NEW.amount, 2) WHERE transaction_uuid = NEW.transaction_uuid;
+END;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS bank_exchange_rates(
+    exchange_rate_uuid          TEXT            PRIMARY KEY,
+    from_currency               VARCHAR(5)      NOT NULL,
+    to_currency                 VARCHAR(5)      NOT NULL,
+    rate                        NUMERIC(20,10)  NOT NULL,
+    valid_from_timestamp        TIMESTAMP       NOT NULL,
+    valid_to_timestamp          TIMESTAMP       NOT NULL,
+    created_at                  TIMESTAMP,
+    updated_at                  TIMESTAMP
+);
+
+CREATE TRIGGER IF NOT EXISTS bank_exchange_rates_round_rate
+AFTER INSERT ON bank_exchange_rates
+WHEN NEW.rate <> ROUND(NEW.rate, 10)
+BEGIN
+  UPDATE bank_exchange_rates SET rate = ROUND(NEW.rate, 10) WHERE exchange_rate_uuid = NEW.exchange_rate_uuid;
+END;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS bank_transfers(
+    transfer_uuid           TEXT            PRIMARY KEY,
+    from_account_uuid       TEXT            REFERENCES bank_accounts,
+    to_account_uuid         TEXT            REFERENCES bank_accounts,
+    currency                VARCHAR(5)      NOT NULL,
+    amount                  NUMERIC(15,2)   NOT NULL,
+    transfer_timestamp      TIMESTAMP       NOT NULL,
+    transfer_success        BOOLEAN         NOT NULL DEFAULT FALSE,
+    created_at              TIMESTAMP,
+    updated_at              TIMESTAMP
+);
+
+CREATE TRIGGER IF NOT EXISTS bank_transfers_round_amount
+AFTER INSERT ON bank_transfers
+WHEN NEW.amount <> ROUND(NEW.amount, 2)
+BEGIN
+  UPDATE bank_transfers SET amount = ROUND(NEW.amount, 2) WHERE transfer_uuid = NEW.transfer_uuid;
+END;
@@ -0,0 +1,3 @@
+-- Demo data is no longer part of the schema, it is loaded on request with
+-- the seed command. The version stays so existing databases keep migrating.
+SELECT 1;
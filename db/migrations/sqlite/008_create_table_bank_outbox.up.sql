@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS bank_outbox(
+    outbox_id               INTEGER         PRIMARY KEY AUTOINCREMENT,
+    event_uuid              TEXT            NOT NULL UNIQUE,
+    event_type              VARCHAR(100)    NOT NULL,
+    event_version           INTEGER         NOT NULL,
+    aggregate_uuid          TEXT            NOT NULL,
+    occurred_at             TIMESTAMP       NOT NULL,
+    payload                 TEXT            NOT NULL,
+    published_at            TIMESTAMP,
+    created_at              TIMESTAMP       NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bank_outbox_pending_idx ON bank_outbox (outbox_id) WHERE published_at IS NULL;
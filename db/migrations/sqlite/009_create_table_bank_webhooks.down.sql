@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bank_webhook_attempts;
+DROP TABLE IF EXISTS bank_webhook_deliveries;
+DROP TABLE IF EXISTS bank_webhook_subscriptions;
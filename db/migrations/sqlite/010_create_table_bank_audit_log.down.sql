@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bank_audit_head;
+DROP TABLE IF EXISTS bank_audit_log;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS bank_audit_log(
+    seq                     INTEGER         PRIMARY KEY,
+    occurred_at             TIMESTAMP       NOT NULL,
+    principal               TEXT            NOT NULL,
+    remote_addr             TEXT            NOT NULL,
+    method                  TEXT            NOT NULL,
+    action                  VARCHAR(100)    NOT NULL,
+    fingerprint             VARCHAR(64)     NOT NULL,
+    outcome                 VARCHAR(10)     NOT NULL,
+    error                   TEXT            NOT NULL,
+    entities                TEXT            NOT NULL,
+    prev_hash               VARCHAR(64)     NOT NULL,
+    hash                    VARCHAR(64)     NOT NULL
+);
+
+-- the single row holds the last seq and hash, updating it first serializes
+-- the appends
+CREATE TABLE IF NOT EXISTS bank_audit_head(
+    id                      INTEGER         PRIMARY KEY CHECK (id = 1),
+    seq                     INTEGER         NOT NULL,
+    hash                    VARCHAR(64)     NOT NULL
+);
+
+INSERT OR IGNORE INTO bank_audit_head (id, seq, hash)
+VALUES (1, 0, '0000000000000000000000000000000000000000000000000000000000000000');
+
+CREATE TRIGGER IF NOT EXISTS bank_audit_log_no_update BEFORE UPDATE ON bank_audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'bank_audit_log is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS bank_audit_log_no_delete BEFORE DELETE ON bank_audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'bank_audit_log is append-only');
+END;
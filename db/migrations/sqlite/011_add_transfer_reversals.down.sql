@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS bank_transfers_reversal_of_uuid;
+
+ALTER TABLE bank_transfers DROP COLUMN reversed_amount;
+ALTER TABLE bank_transfers DROP COLUMN reversal_reason;
+ALTER TABLE bank_transfers DROP COLUMN reversal_of_uuid;
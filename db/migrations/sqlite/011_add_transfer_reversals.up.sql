@@ -0,0 +1,6 @@
+-- without REFERENCES, SQLite can't drop a column that is part of a foreign key
+ALTER TABLE bank_transfers ADD COLUMN reversal_of_uuid TEXT;
+ALTER TABLE bank_transfers ADD COLUMN reversal_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE bank_transfers ADD COLUMN reversed_amount NUMERIC(15,2) NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS bank_transfers_reversal_of_uuid ON bank_transfers (reversal_of_uuid);
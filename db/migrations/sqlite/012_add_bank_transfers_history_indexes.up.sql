@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS bank_transfers_from_account_timestamp ON bank_transfers (from_account_uuid, transfer_timestamp DESC, transfer_uuid DESC);
+CREATE INDEX IF NOT EXISTS bank_transfers_to_account_timestamp ON bank_transfers (to_account_uuid, transfer_timestamp DESC, transfer_uuid DESC);
+CREATE INDEX IF NOT EXISTS bank_transfers_timestamp ON bank_transfers (transfer_timestamp DESC, transfer_uuid DESC);
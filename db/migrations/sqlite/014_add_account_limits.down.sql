@@ -0,0 +1,6 @@
+ALTER TABLE bank_accounts DROP COLUMN overdraft_used;
+ALTER TABLE bank_accounts DROP COLUMN minimum_balance;
+ALTER TABLE bank_accounts DROP COLUMN overdraft_limit;
+ALTER TABLE bank_accounts DROP COLUMN product_code;
+
+DROP TABLE IF EXISTS bank_products;
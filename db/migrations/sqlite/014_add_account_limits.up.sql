@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS bank_products(
+    product_code            VARCHAR(20)     PRIMARY KEY,
+    name                    VARCHAR(100)    NOT NULL,
+    overdraft_limit         NUMERIC(15,2)   NOT NULL DEFAULT 0,
+    minimum_balance         NUMERIC(15,2)   NOT NULL DEFAULT 0,
+    created_at              TIMESTAMP       NOT NULL,
+    updated_at              TIMESTAMP       NOT NULL,
+    CHECK (overdraft_limit >= 0 AND minimum_balance >= 0)
+);
+
+-- an account of a product without a row has no overdraft and no minimum
+-- balance, the NULL limits of an account fall back to its product
+ALTER TABLE bank_accounts ADD COLUMN product_code VARCHAR(20) NOT NULL DEFAULT 'STANDARD';
+ALTER TABLE bank_accounts ADD COLUMN overdraft_limit NUMERIC(15,2) CHECK (overdraft_limit >= 0);
+ALTER TABLE bank_accounts ADD COLUMN minimum_balance NUMERIC(15,2) CHECK (minimum_balance >= 0);
+ALTER TABLE bank_accounts ADD COLUMN overdraft_used NUMERIC(15,2) NOT NULL DEFAULT 0 CHECK (overdraft_used >= 0);
+
+UPDATE bank_accounts SET overdraft_used = -current_balance WHERE current_balance < 0;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS bank_interest_accruals;
+DROP TABLE IF EXISTS bank_interest_rates;
+
+ALTER TABLE bank_products DROP COLUMN interest_capitalization;
+ALTER TABLE bank_products DROP COLUMN interest_day_count;
@@ -0,0 +1,30 @@
+ALTER TABLE bank_products ADD COLUMN interest_day_count VARCHAR(10) NOT NULL DEFAULT 'ACT/365';
+ALTER TABLE bank_products ADD COLUMN interest_capitalization VARCHAR(10) NOT NULL DEFAULT 'MONTHLY';
+
+CREATE TABLE IF NOT EXISTS bank_interest_rates(
+    rate_uuid               TEXT            PRIMARY KEY,
+    product_code            VARCHAR(20)     NOT NULL REFERENCES bank_products,
+    effective_from          DATE            NOT NULL,
+    min_balance             NUMERIC(15,2)   NOT NULL,
+    -- percent a year
+    annual_rate             NUMERIC(9,6)    NOT NULL,
+    created_at              TIMESTAMP       NOT NULL,
+    UNIQUE (product_code, effective_from, min_balance),
+    CHECK (min_balance >= 0 AND annual_rate >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS bank_interest_accruals(
+    account_uuid            TEXT            NOT NULL REFERENCES bank_accounts,
+    accrual_date            DATE            NOT NULL,
+    product_code            VARCHAR(20)     NOT NULL,
+    balance                 NUMERIC(15,2)   NOT NULL,
+    annual_rate             NUMERIC(9,6)    NOT NULL,
+    -- fractions of a cent add up until the interest is capitalized
+    amount                  NUMERIC(18,6)   NOT NULL,
+    transaction_uuid        TEXT            REFERENCES bank_transactions,
+    capitalized_at          TIMESTAMP,
+    created_at              TIMESTAMP       NOT NULL,
+    PRIMARY KEY (account_uuid, accrual_date)
+);
+
+CREATE INDEX IF NOT EXISTS bank_interest_accruals_pending_idx ON bank_interest_accruals (account_uuid, accrual_date) WHERE capitalized_at IS NULL;
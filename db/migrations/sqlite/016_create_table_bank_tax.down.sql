@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bank_tax_withholdings;
+DROP TABLE IF EXISTS bank_tax_rules;
+
+ALTER TABLE bank_accounts DROP COLUMN tax_exempt;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS bank_transfer_fees;
+DROP TABLE IF EXISTS bank_fee_tiers;
+DROP TABLE IF EXISTS bank_fee_schedules;
+
+ALTER TABLE bank_accounts DROP COLUMN customer_segment;
@@ -0,0 +1,46 @@
+ALTER TABLE bank_accounts ADD COLUMN customer_segment VARCHAR(20) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS bank_fee_schedules(
+    schedule_uuid           TEXT            PRIMARY KEY,
+    currency                VARCHAR(5)      NOT NULL,
+    -- empty matches every channel or segment
+    channel                 VARCHAR(20)     NOT NULL,
+    customer_segment        VARCHAR(20)     NOT NULL,
+    min_fee                 NUMERIC(15,2)   NOT NULL,
+    -- 0 is no cap
+    max_fee                 NUMERIC(15,2)   NOT NULL,
+    free_per_month          INTEGER         NOT NULL,
+    fee_account_uuid        TEXT            NOT NULL REFERENCES bank_accounts,
+    created_at              TIMESTAMP       NOT NULL,
+    updated_at              TIMESTAMP       NOT NULL,
+    UNIQUE (currency, channel, customer_segment),
+    CHECK (min_fee >= 0 AND max_fee >= 0 AND free_per_month >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS bank_fee_tiers(
+    schedule_uuid           TEXT            NOT NULL REFERENCES bank_fee_schedules ON DELETE CASCADE,
+    min_amount              NUMERIC(15,2)   NOT NULL,
+    flat_amount             NUMERIC(15,2)   NOT NULL,
+    -- percent of the amount
+    rate                    NUMERIC(9,6)    NOT NULL,
+    PRIMARY KEY (schedule_uuid, min_amount),
+    CHECK (min_amount >= 0 AND flat_amount >= 0 AND rate >= 0 AND rate <= 100)
+);
+
+CREATE TABLE IF NOT EXISTS bank_transfer_fees(
+    transfer_uuid           TEXT            PRIMARY KEY REFERENCES bank_transfers,
+    schedule_uuid           TEXT            NOT NULL REFERENCES bank_fee_schedules,
+    account_uuid            TEXT            NOT NULL REFERENCES bank_accounts,
+    fee_account_uuid        TEXT            NOT NULL REFERENCES bank_accounts,
+    currency                VARCHAR(5)      NOT NULL,
+    -- in the currency of the transfer, before any waiver
+    fee                     NUMERIC(15,2)   NOT NULL,
+    -- what was debited, in the unit of the amount booked on the transfer
+    amount                  NUMERIC(15,2)   NOT NULL,
+    waived                  BOOLEAN         NOT NULL,
+    fee_transaction_uuid    TEXT            REFERENCES bank_transactions,
+    income_transaction_uuid TEXT            REFERENCES bank_transactions,
+    charged_at              TIMESTAMP       NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bank_transfer_fees_allowance_idx ON bank_transfer_fees (account_uuid, schedule_uuid, charged_at);
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS bank_transfer_batches(
+    batch_uuid              TEXT            PRIMARY KEY,
+    -- given by the upload or the file, a file is imported once
+    message_id              TEXT            NOT NULL,
+    format                  VARCHAR(20)     NOT NULL,
+    mode                    VARCHAR(20)     NOT NULL,
+    channel                 VARCHAR(20)     NOT NULL,
+    status                  VARCHAR(20)     NOT NULL,
+    number_of_transactions  INTEGER         NOT NULL,
+    control_sum             NUMERIC(17,2)   NOT NULL,
+    reason_code             VARCHAR(4)      NOT NULL,
+    reason                  TEXT            NOT NULL,
+    -- null until a worker claims the batch, moved ahead while it runs
+    lease_until             TIMESTAMP,
+    created_at              TIMESTAMP       NOT NULL,
+    updated_at              TIMESTAMP       NOT NULL,
+    completed_at            TIMESTAMP,
+    UNIQUE (message_id)
+);
+
+CREATE INDEX IF NOT EXISTS bank_transfer_batches_due_idx ON bank_transfer_batches (status, lease_until);
+
+CREATE TABLE IF NOT EXISTS bank_transfer_batch_items(
+    -- the uuid of the transfer of the item too
+    item_uuid               TEXT            PRIMARY KEY,
+    batch_uuid              TEXT            NOT NULL REFERENCES bank_transfer_batches ON DELETE CASCADE,
+    line_number             INTEGER         NOT NULL,
+    end_to_end_id           TEXT            NOT NULL,
+    debtor_account          TEXT            NOT NULL,
+    creditor_account        TEXT            NOT NULL,
+    currency                VARCHAR(5)      NOT NULL,
+    amount                  NUMERIC(15,2)   NOT NULL,
+    remittance_information  TEXT            NOT NULL,
+    status                  VARCHAR(20)     NOT NULL,
+    reason_code             VARCHAR(4)      NOT NULL,
+    reason                  TEXT            NOT NULL,
+    transfer_uuid           TEXT            REFERENCES bank_transfers,
+    updated_at              TIMESTAMP       NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bank_transfer_batch_items_batch_idx ON bank_transfer_batch_items (batch_uuid, line_number);
@@ -0,0 +1,67 @@
+-- the accounts of a customer share its id, an account without one is a
+-- customer of its own
+ALTER TABLE bank_accounts ADD COLUMN customer_id VARCHAR(36) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS bank_accounts_customer_idx ON bank_accounts (customer_id) WHERE customer_id <> '';
+
+-- a webhook subscription is for one account or for every account of a
+-- customer. SQLite can't drop the NOT NULL of account_uuid, the webhook
+-- tables are rebuilt, children first so dropping them cascades nothing.
+CREATE TABLE bank_webhook_attempts_copy AS SELECT * FROM bank_webhook_attempts;
+CREATE TABLE bank_webhook_deliveries_copy AS SELECT * FROM bank_webhook_deliveries;
+CREATE TABLE bank_webhook_subscriptions_copy AS SELECT * FROM bank_webhook_subscriptions;
+DROP TABLE bank_webhook_attempts;
+DROP TABLE bank_webhook_deliveries;
+DROP TABLE bank_webhook_subscriptions;
+
+CREATE TABLE bank_webhook_subscriptions(
+    subscription_uuid       TEXT            PRIMARY KEY,
+    account_uuid            TEXT            REFERENCES bank_accounts,
+    account_number          VARCHAR(20)     NOT NULL DEFAULT '',
+    url                     TEXT            NOT NULL,
+    event_types             TEXT            NOT NULL,
+    secret                  TEXT            NOT NULL,
+    created_at              TIMESTAMP       NOT NULL,
+    customer_id             VARCHAR(36)     NOT NULL DEFAULT '',
+    CHECK ((account_uuid IS NULL) <> (customer_id = ''))
+);
+
+INSERT INTO bank_webhook_subscriptions (subscription_uuid, account_uuid, account_number, url, event_types, secret, created_at)
+    SELECT subscription_uuid, account_uuid, account_number, url, event_types, secret, created_at FROM bank_webhook_subscriptions_copy;
+
+CREATE TABLE bank_webhook_deliveries(
+    delivery_uuid           TEXT            PRIMARY KEY,
+    subscription_uuid       TEXT            NOT NULL REFERENCES bank_webhook_subscriptions ON DELETE CASCADE,
+    event_uuid              TEXT            NOT NULL,
+    event_type              VARCHAR(100)    NOT NULL,
+    payload                 TEXT            NOT NULL,
+    state                   VARCHAR(20)     NOT NULL,
+    attempt_count           INTEGER         NOT NULL DEFAULT 0,
+    next_attempt_at         TIMESTAMP       NOT NULL,
+    last_error              TEXT            NOT NULL DEFAULT '',
+    created_at              TIMESTAMP       NOT NULL,
+    updated_at              TIMESTAMP       NOT NULL,
+    UNIQUE (subscription_uuid, event_uuid)
+);
+
+INSERT INTO bank_webhook_deliveries SELECT * FROM bank_webhook_deliveries_copy;
+
+CREATE TABLE bank_webhook_attempts(
+    attempt_id              INTEGER         PRIMARY KEY AUTOINCREMENT,
+    delivery_uuid           TEXT            NOT NULL REFERENCES bank_webhook_deliveries ON DELETE CASCADE,
+    attempted_at            TIMESTAMP       NOT NULL,
+    status_code             INTEGER         NOT NULL,
+    error                   TEXT            NOT NULL DEFAULT '',
+    duration_ms             INTEGER         NOT NULL
+);
+
+INSERT INTO bank_webhook_attempts SELECT * FROM bank_webhook_attempts_copy;
+
+DROP TABLE bank_webhook_attempts_copy;
+DROP TABLE bank_webhook_deliveries_copy;
+DROP TABLE bank_webhook_subscriptions_copy;
+
+CREATE INDEX IF NOT EXISTS bank_webhook_subscriptions_account_idx ON bank_webhook_subscriptions (account_uuid);
+CREATE INDEX IF NOT EXISTS bank_webhook_subscriptions_customer_idx ON bank_webhook_subscriptions (customer_id) WHERE customer_id <> '';
+CREATE INDEX IF NOT EXISTS bank_webhook_deliveries_due_idx ON bank_webhook_deliveries (next_attempt_at) WHERE state = 'PENDING';
+CREATE INDEX IF NOT EXISTS bank_webhook_attempts_delivery_idx ON bank_webhook_attempts (delivery_uuid);
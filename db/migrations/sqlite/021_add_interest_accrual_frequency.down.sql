@@ -0,0 +1 @@
+ALTER TABLE bank_products DROP COLUMN interest_accrual_frequency;
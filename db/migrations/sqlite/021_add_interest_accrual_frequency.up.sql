@@ -0,0 +1 @@
+ALTER TABLE bank_products ADD COLUMN interest_accrual_frequency VARCHAR(10) NOT NULL DEFAULT 'DAILY';
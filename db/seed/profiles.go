@@ -0,0 +1,58 @@
+package seed
+
+import (
+	"fmt"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// demo is the data the migrations used to insert, with the same keys.
+func demo(now time.Time) ([]domainBank.BankAccountOrm, []domainBank.BankTransactionOrm) {
+	rows := []struct {
+		uuid, trxUuid, number, name string
+	}{
+		{"3781b5e8-3eca-4e5a-afa2-2ca93b632e12", "5967d18c-ada9-4e51-8a0c-da7c03dcaf83", "7835697001", "Kate Bishop"},
+		{"3962555b-79f0-40c4-88c0-20306257b7ac", "f8db7680-699a-4a02-9ed2-6db0ff7188ab", "7835697002", "Riri Williams"},
+		{"1e9230bd-4264-4526-a9cd-2a86d3ca9594", "ab14ef06-ee20-4403-96a9-7af47a4144a1", "7835697003", "Cassie Lang"},
+		{"2a7d5f68-baa1-4264-bf41-facba0414c59", "a2374824-7aba-4841-86b5-465840dcc52f", "7835697004", "Shuri"},
+		{"66f93615-7c97-4395-8a26-a0e8ced7bb97", "eedf7a81-3f58-43ed-8ee3-a272dc307927", "7835697005", "Elijah Bradley"},
+	}
+
+	accounts := make([]domainBank.BankAccountOrm, 0, len(rows))
+	transactions := make([]domainBank.BankTransactionOrm, 0, len(rows))
+	for _, row := range rows {
+		acc, trx := account(uuid.MustParse(row.uuid), uuid.MustParse(row.trxUuid), row.number, row.name, 10, now)
+		accounts = append(accounts, acc)
+		transactions = append(transactions, trx)
+	}
+
+	return accounts, transactions
+}
+
+const (
+	loadTestAccounts = 1000
+	loadTestBalance  = 1_000_000
+)
+
+// loadTestNamespace makes the load test keys stable across runs, so the
+// profile can be applied again.
+var loadTestNamespace = uuid.MustParse("0f3ad5a4-4c0b-4bb4-9a43-36f0c1b5d0f1")
+
+func loadTest(now time.Time) ([]domainBank.BankAccountOrm, []domainBank.BankTransactionOrm) {
+	accounts := make([]domainBank.BankAccountOrm, 0, loadTestAccounts)
+	transactions := make([]domainBank.BankTransactionOrm, 0, loadTestAccounts)
+
+	for i := 1; i <= loadTestAccounts; i++ {
+		number := fmt.Sprintf("99%08d", i)
+		acc, trx := account(
+			uuid.NewSHA1(loadTestNamespace, []byte("account/"+number)),
+			uuid.NewSHA1(loadTestNamespace, []byte("deposit/"+number)),
+			number, fmt.Sprintf("Load Test %04d", i), loadTestBalance, now)
+		accounts = append(accounts, acc)
+		transactions = append(transactions, trx)
+	}
+
+	return accounts, transactions
+}
@@ -0,0 +1,102 @@
+// Package seed holds the named data sets that can be loaded into a database
+// on request, for demos, local development and load tests. None of them is
+// part of the schema migrations.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// Store is implemented by the storage adapters that can be seeded. Rows that
+// already exist, by primary key or account number, are left alone, so
+// applying a profile twice is harmless.
+type Store interface {
+	ApplySeed(ctx context.Context, accounts []domainBank.BankAccountOrm, transactions []domainBank.BankTransactionOrm) error
+}
+
+type Profile struct {
+	Name         string
+	Description  string
+	Accounts     []domainBank.BankAccountOrm
+	Transactions []domainBank.BankTransactionOrm
+}
+
+// profiles build their data for a point in time, so timestamps are fresh.
+var profiles = map[string]struct {
+	description string
+	build       func(now time.Time) ([]domainBank.BankAccountOrm, []domainBank.BankTransactionOrm)
+}{
+	"demo": {
+		description: "five USD accounts with an initial deposit of 10 each",
+		build:       demo,
+	},
+	"loadtest": {
+		description: fmt.Sprintf("%d USD accounts with %v each, for load tests", loadTestAccounts, loadTestBalance),
+		build:       loadTest,
+	},
+}
+
+// Lookup returns the profile called name, built for now.
+func Lookup(name string, now time.Time) (Profile, error) {
+	p, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown seed profile %q, valid profiles are %v", name, Names())
+	}
+
+	accounts, transactions := p.build(now)
+
+	return Profile{
+		Name:         name,
+		Description:  p.description,
+		Accounts:     accounts,
+		Transactions: transactions,
+	}, nil
+}
+
+// Names lists the profiles in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Apply loads profile into store.
+func Apply(ctx context.Context, store Store, profile Profile) error {
+	if err := store.ApplySeed(ctx, profile.Accounts, profile.Transactions); err != nil {
+		return fmt.Errorf("can't apply seed profile %s : %v", profile.Name, err)
+	}
+
+	return nil
+}
+
+// account returns an account whose balance is backed by one initial deposit.
+func account(id, trxId uuid.UUID, number, name string, balance float64, now time.Time) (domainBank.BankAccountOrm, domainBank.BankTransactionOrm) {
+	return domainBank.BankAccountOrm{
+		AccountUuid:    id,
+		AccountNumber:  number,
+		AccountName:    name,
+		Currency:       "USD",
+		CurrentBalance: balance,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, domainBank.BankTransactionOrm{
+		TransactionUuid:      trxId,
+		AccountUuid:          id,
+		TransactionTimestamp: now,
+		Amount:               balance,
+		TransactionType:      domainBank.TransactionTypeIn,
+		Notes:                "Initial deposit",
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+}
@@ -0,0 +1,58 @@
+package seed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProfiles(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			p, err := Lookup(name, now)
+			if err != nil {
+				t.Fatalf("Lookup: %v", err)
+			}
+			if len(p.Accounts) == 0 {
+				t.Fatal("profile has no accounts")
+			}
+
+			numbers := map[string]bool{}
+			balances := map[string]float64{}
+			for _, acc := range p.Accounts {
+				if numbers[acc.AccountNumber] {
+					t.Errorf("account number %v used twice", acc.AccountNumber)
+				}
+				numbers[acc.AccountNumber] = true
+				balances[acc.AccountUuid.String()] = acc.CurrentBalance
+			}
+
+			// every balance is backed by the seeded transactions
+			for _, trx := range p.Transactions {
+				id := trx.AccountUuid.String()
+				if _, ok := balances[id]; !ok {
+					t.Fatalf("transaction %v of an account outside the profile", trx.TransactionUuid)
+				}
+				balances[id] -= trx.Amount
+			}
+			for id, rest := range balances {
+				if rest != 0 {
+					t.Errorf("balance of %v differs from its transactions by %v", id, rest)
+				}
+			}
+
+			// the keys must not change, so a profile can be applied again
+			again, _ := Lookup(name, now.Add(time.Hour))
+			if again.Accounts[0].AccountUuid != p.Accounts[0].AccountUuid || again.Transactions[0].TransactionUuid != p.Transactions[0].TransactionUuid {
+				t.Error("keys differ between two builds of the profile")
+			}
+		})
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	if _, err := Lookup("nope", time.Now()); err == nil {
+		t.Error("Lookup of an unknown profile succeeded")
+	}
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// auditHead is the single row of bank_audit_head.
+type auditHead struct {
+	Id   int `gorm:"primaryKey"`
+	Seq  int64
+	Hash string
+}
+
+func (auditHead) TableName() string {
+	return "bank_audit_head"
+}
+
+func (a *DatabaseAdapter) AppendAuditEntry(ctx context.Context, entry domainAudit.AuditEntryOrm) (domainAudit.AuditEntryOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.AppendAuditEntry")
+	defer span.End()
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// bumping the head first locks it until commit, so concurrent
+		// appends line up behind each other
+		if err := tx.Model(&auditHead{}).Where("id = 1").Update("seq", gorm.Expr("seq + 1")).Error; err != nil {
+			return fmt.Errorf("head : %v", err)
+		}
+
+		var head auditHead
+		if err := tx.Take(&head, "id = 1").Error; err != nil {
+			return fmt.Errorf("head : %v", err)
+		}
+
+		entry.Seal(head.Seq, head.Hash)
+		if err := tx.Create(&entry).Error; err != nil {
+			return fmt.Errorf("entry : %v", err)
+		}
+
+		return tx.Model(&auditHead{}).Where("id = 1").Update("hash", entry.Hash).Error
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't append audit entry : %v\n", err), "", "BankAdapter - AppendAuditEntry")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainAudit.AuditEntryOrm{}, err
+	}
+
+	return entry, nil
+}
+
+func (a *DatabaseAdapter) AuditEntries(ctx context.Context, afterSeq int64, limit int) ([]domainAudit.AuditEntryOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.AuditEntries")
+	defer span.End()
+
+	var entries []domainAudit.AuditEntryOrm
+	if err := a.db.WithContext(ctx).Where("seq > ?", afterSeq).Order("seq").Limit(limit).Find(&entries).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read audit entries : %v\n", err), "", "BankAdapter - AuditEntries")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (a *DatabaseAdapter) AuditHead(ctx context.Context) (int64, string, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.AuditHead")
+	defer span.End()
+
+	var head auditHead
+	if err := a.db.WithContext(ctx).Take(&head, "id = 1").Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read audit head : %v\n", err), "", "BankAdapter - AuditHead")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return 0, "", err
+	}
+
+	return head.Seq, head.Hash, nil
+}
@@ -1,162 +1,302 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
-func (a *DatabaseAdapter) GetDetailBankAccountByAccountNumber(accountNum string) (domainBank.BankAccountOrm, error) {
+func (a *DatabaseAdapter) GetDetailBankAccountByAccountNumber(ctx context.Context, accountNum string) (domainBank.BankAccountOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetDetailBankAccountByAccountNumber")
+	defer span.End()
+
 	var bankAccountOrm domainBank.BankAccountOrm
 
-	if err := a.db.First(&bankAccountOrm, "account_number = ?", accountNum).Error; err != nil {
+	if err := a.db.WithContext(ctx).First(&bankAccountOrm, "account_number = ?", accountNum).Error; err != nil {
 		logErr := util.LogError(fmt.Sprintf("Can't find bank account number %v : %v\n", accountNum, err), "", "BankAdapter - GetDetailBankAccountByAccountNumber")
-		log.Error().Msg(logErr)
-		return bankAccountOrm, err
+		log.Error().Ctx(ctx).Msg(logErr)
+		return bankAccountOrm, translateError(err)
 	}
 
 	return bankAccountOrm, nil
 }
 
-func (a *DatabaseAdapter) GetBalanceBankAccountByAccountNumber(acct string) (domainBank.BalanceAccountOrm, error) {
+func (a *DatabaseAdapter) GetBalanceBankAccountByAccountNumber(ctx context.Context, acct string) (domainBank.BalanceAccountOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetBalanceBankAccountByAccountNumber")
+	defer span.End()
+
 	var bankAccountOrm domainBank.BalanceAccountOrm
 
-	if err := a.db.Select("account_uuid, account_number, currency, current_balance").First(&bankAccountOrm, "account_number = ?", acct).Error; err != nil {
+	err := a.read(ctx, func(db *gorm.DB) error {
+		return db.Select("account_uuid, account_number, currency, current_balance").First(&bankAccountOrm, "account_number = ?", acct).Error
+	}, accountKey(acct))
+
+	if err != nil {
 		logErr := util.LogError(fmt.Sprintf("Can't find bank account number %v : %v\n", acct, err), "", "BankAdapter - GetBankAccountByAccountNumber")
-		log.Error().Msg(logErr)
-		return bankAccountOrm, err
+		log.Error().Ctx(ctx).Msg(logErr)
+		return bankAccountOrm, translateError(err)
 	}
 
 	return bankAccountOrm, nil
 }
 
-func (a *DatabaseAdapter) InsertExchangeRate(r domainBank.BankExchangeRateOrm) (uuid.UUID, error) {
-	if err := a.db.Create(&r).Error; err != nil {
+func (a *DatabaseAdapter) InsertExchangeRate(ctx context.Context, r domainBank.BankExchangeRateOrm) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.InsertExchangeRate")
+	defer span.End()
+
+	created, err := domainEvent.NewExchangeRateCreated(r)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	tx := a.db.WithContext(ctx).Begin()
+
+	if err := tx.Create(&r).Error; err != nil {
+		tx.Rollback()
 		logErr := util.LogError(fmt.Sprintf("Can't insert exchange rate : %v\n", err), "", "BankAdapter - InsertExchangeRate")
-		log.Error().Msg(logErr)
+		log.Error().Ctx(ctx).Msg(logErr)
+		return uuid.Nil, err
+	}
+
+	if err := insertEvents(tx, created); err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		return uuid.Nil, err
 	}
 
 	// log success
-	log.Info().Msgf("Exchange rate inserted with uuid %v", r.ExchangeRateUuid)
+	log.Info().Ctx(ctx).Msgf("Exchange rate inserted with uuid %v", r.ExchangeRateUuid)
 
 	return r.ExchangeRateUuid, nil
 }
 
-func (a *DatabaseAdapter) GetExchangeRateAtTimestamp(fromCurrency string, toCurrency string, ts time.Time) (domainBank.BankExchangeRateOrm, error) {
+func (a *DatabaseAdapter) GetExchangeRateAtTimestamp(ctx context.Context, fromCurrency string, toCurrency string, ts time.Time) (domainBank.BankExchangeRateOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetExchangeRateAtTimestamp")
+	defer span.End()
+
 	var exchangeRateOrm domainBank.BankExchangeRateOrm
 
-	err := a.db.First(&exchangeRateOrm, "from_currency = ? AND to_currency = ? AND (? BETWEEN valid_from_timestamp and valid_to_timestamp)", fromCurrency, toCurrency, ts).Error
+	// rates are inserted ahead of their validity window, so there is no
+	// freshness key here: a replica that hasn't caught up yet answers "not
+	// found" and read retries on the primary
+	err := a.read(ctx, func(db *gorm.DB) error {
+		return db.First(&exchangeRateOrm, "from_currency = ? AND to_currency = ? AND (? BETWEEN valid_from_timestamp and valid_to_timestamp)", fromCurrency, toCurrency, ts).Error
+	})
 
 	if err != nil {
 		logErr := util.LogError(fmt.Sprintf("Can't find exchange rate from %v to %v at %v : %v\n", fromCurrency, toCurrency, ts, err), "", "BankAdapter - GetExchangeRateAtTimestamp")
-		log.Error().Msg(logErr)
+		log.Error().Ctx(ctx).Msg(logErr)
 	}
 
-	return exchangeRateOrm, err
+	return exchangeRateOrm, translateError(err)
 }
 
-func (a *DatabaseAdapter) CreateTransaction(account domainBank.BankAccountOrm, trx domainBank.BankTransactionOrm) (uuid.UUID, error) {
-	tx := a.db.Begin()
+func (a *DatabaseAdapter) CreateTransaction(ctx context.Context, account domainBank.BankAccountOrm, trx domainBank.BankTransactionOrm) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CreateTransaction")
+	defer span.End()
 
-	newAmount := trx.Amount
-
-	if trx.TransactionType == domainBank.TransactionTypeOut {
-		newAmount = -1 * newAmount
+	created, err := domainEvent.NewTransactionCreated(account, trx)
+	if err != nil {
+		return uuid.Nil, err
 	}
 
+	tx := a.db.WithContext(ctx).Begin()
+
 	// Create the transaction
-	if err := tx.Create(trx).Error; err != nil {
+	if err := tx.Create(&trx).Error; err != nil {
 		tx.Rollback()
 		logErr := util.LogError(fmt.Sprintf("Can't create transaction : %v\n", err), "", "BankAdapter - CreateTransaction")
-		log.Error().Msg(logErr)
+		log.Error().Ctx(ctx).Msg(logErr)
 		return uuid.Nil, err
 	}
 
-	newAccountBalance := account.CurrentBalance + newAmount
+	// a debit is checked again under the lock of the account
+	if trx.TransactionType == domainBank.TransactionTypeOut {
+		if err := checkDebit(tx, account.AccountUuid, trx.Amount, trx.TransactionTimestamp); err != nil {
+			tx.Rollback()
+			return uuid.Nil, err
+		}
+	}
 
 	// update account balance
-	if err := tx.Model(&account).Updates(
-		map[string]interface{}{
-			"current_balance": newAccountBalance,
-			"updated_at":      time.Now(),
-		},
-	).Error; err != nil {
+	if err := updateBalance(tx, account.AccountUuid, signedAmount(trx)); err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+
+	if err := insertEvents(tx, created); err != nil {
 		tx.Rollback()
 		return uuid.Nil, err
 	}
 
-	tx.Commit()
+	if err := tx.Commit().Error; err != nil {
+		return uuid.Nil, err
+	}
+	a.freshness.touch(accountKey(account.AccountNumber))
 
 	return trx.TransactionUuid, nil
 }
 
-func (a *DatabaseAdapter) CreateTransfer(trf domainBank.BankTransferOrm) (uuid.UUID, error) {
-	if err := a.db.Create(trf).Error; err != nil {
+func (a *DatabaseAdapter) CreateTransfer(ctx context.Context, trf domainBank.BankTransferOrm) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CreateTransfer")
+	defer span.End()
+
+	created, err := domainEvent.NewTransferCreated(trf)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	tx := a.db.WithContext(ctx).Begin()
+
+	if err := tx.Create(&trf).Error; err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+
+	if err := insertEvents(tx, created); err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		return uuid.Nil, err
 	}
 
 	return trf.TransferUuid, nil
 }
 
-func (a *DatabaseAdapter) CreateTransferTransactionPair(fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
+func (a *DatabaseAdapter) CreateTransferTransactionPair(ctx context.Context, fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
 	fromTransactionOrm domainBank.BankTransactionOrm, toTransactionOrm domainBank.BankTransactionOrm) (bool, error) {
-	tx := a.db.Begin()
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CreateTransferTransactionPair")
+	defer span.End()
+
+	tx := a.db.WithContext(ctx).Begin()
 
-	// from account
-	if err := tx.Create(fromTransactionOrm).Error; err != nil {
+	if err := bookTransferPair(tx, fromAccountOrm, toAccountOrm, fromTransactionOrm, toTransactionOrm); err != nil {
+		tx.Rollback()
 		return false, err
 	}
 
-	// to account
-	if err := tx.Create(toTransactionOrm).Error; err != nil {
+	if err := tx.Commit().Error; err != nil {
 		return false, err
 	}
+	a.freshness.touch(accountKey(fromAccountOrm.AccountNumber), accountKey(toAccountOrm.AccountNumber))
 
-	// recalculate balance from account
-	newBalanceFrom := fromAccountOrm.CurrentBalance - fromTransactionOrm.Amount
+	return true, nil
+}
 
-	// recalculate balance to account
-	newBalanceTo := toAccountOrm.CurrentBalance + toTransactionOrm.Amount
+// bookTransferPair writes the transactions of a transfer, moves the amount
+// and records their events. checkDebit leaves the sender locked for the rest
+// of tx.
+func bookTransferPair(tx *gorm.DB, fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
+	fromTransactionOrm domainBank.BankTransactionOrm, toTransactionOrm domainBank.BankTransactionOrm) error {
+	fromCreated, err := domainEvent.NewTransactionCreated(fromAccountOrm, fromTransactionOrm)
+	if err != nil {
+		return err
+	}
+	toCreated, err := domainEvent.NewTransactionCreated(toAccountOrm, toTransactionOrm)
+	if err != nil {
+		return err
+	}
 
-	if err := tx.Model(&fromAccountOrm).Updates(
-		map[string]interface{}{
-			"current_balance": newBalanceFrom,
-			"updated_at":      time.Now(),
-		},
-	).Error; err != nil {
-		tx.Rollback()
-		return false, err
+	if err := tx.Create(&fromTransactionOrm).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(&toTransactionOrm).Error; err != nil {
+		return err
+	}
+	if err := checkDebit(tx, fromAccountOrm.AccountUuid, fromTransactionOrm.Amount, fromTransactionOrm.TransactionTimestamp); err != nil {
+		return err
+	}
+	if err := updateBalance(tx, fromAccountOrm.AccountUuid, -fromTransactionOrm.Amount); err != nil {
+		return err
+	}
+	if err := updateBalance(tx, toAccountOrm.AccountUuid, toTransactionOrm.Amount); err != nil {
+		return err
 	}
 
-	if err := tx.Model(&toAccountOrm).Updates(
+	return insertEvents(tx, fromCreated, toCreated)
+}
+
+func (a *DatabaseAdapter) UpdateTransferStatus(ctx context.Context, transfer domainBank.BankTransferOrm, status bool) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.UpdateTransferStatus")
+	defer span.End()
+
+	now := time.Now()
+
+	finished, err := domainEvent.NewTransferFinished(transfer, status, now)
+	if err != nil {
+		return err
+	}
+
+	tx := a.db.WithContext(ctx).Begin()
+
+	res := tx.Model(&transfer).Updates(
 		map[string]interface{}{
-			"current_balance": newBalanceTo,
-			"updated_at":      time.Now(),
+			"transfer_success": status,
+			"updated_at":       now,
 		},
-	).Error; err != nil {
+	)
+	if res.Error != nil {
 		tx.Rollback()
-		return false, err
+		return res.Error
 	}
 
-	tx.Commit()
+	// an UPDATE matching no row changed nothing, so there is nothing to tell
+	if res.RowsAffected > 0 {
+		if err := insertEvents(tx, finished); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
 
-	return true, nil
+	return tx.Commit().Error
 }
 
-func (a *DatabaseAdapter) UpdateTransferStatus(transfer domainBank.BankTransferOrm, status bool) error {
-	if err := a.db.Model(&transfer).Updates(
-		map[string]interface{}{
-			"transfer_success": status,
-			"updated_at":       time.Now(),
-		},
-	).Error; err != nil {
-		return err
+// updateBalance adds delta to the stored balance in the database rather than
+// writing back a balance read earlier, so concurrent postings don't overwrite
+// each other.
+func updateBalance(tx *gorm.DB, accountUuid uuid.UUID, delta float64) error {
+	res := tx.Model(&domainBank.BankAccountOrm{}).
+		Where("account_uuid = ?", accountUuid).
+		Updates(balanceChange(delta))
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return domainBank.ErrRecordNotFound
 	}
 
 	return nil
 }
+
+// balanceChange is what an update adding delta to the balance of an account
+// sets, the overdraft it uses follows the balance.
+func balanceChange(delta float64) map[string]interface{} {
+	return map[string]interface{}{
+		"current_balance": gorm.Expr("current_balance + ?", delta),
+		"overdraft_used":  gorm.Expr("CASE WHEN current_balance + ? < 0 THEN ROUND(CAST(-(current_balance + ?) AS NUMERIC), 2) ELSE 0 END", delta, delta),
+		"updated_at":      time.Now(),
+	}
+}
+
+// signedAmount is the effect of trx on the account balance.
+func signedAmount(trx domainBank.BankTransactionOrm) float64 {
+	if trx.TransactionType == domainBank.TransactionTypeOut {
+		return -trx.Amount
+	}
+
+	return trx.Amount
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainBatch "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/batch"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// batchInsertSize items are inserted by one statement, well below the bind
+// parameter limits of Postgres and SQLite.
+const batchInsertSize = 500
+
+func (a *DatabaseAdapter) CreateTransferBatch(ctx context.Context, batch domainBatch.BatchOrm, items []domainBatch.ItemOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CreateTransferBatch")
+	defer span.End()
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&batch).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		for i := range items {
+			items[i].BatchUuid = batch.BatchUuid
+		}
+		return tx.CreateInBatches(&items, batchInsertSize).Error
+	})
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't insert transfer batch : %v\n", err), "", "BankAdapter - CreateTransferBatch")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) GetTransferBatch(ctx context.Context, id uuid.UUID) (domainBatch.BatchOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetTransferBatch")
+	defer span.End()
+
+	var b domainBatch.BatchOrm
+	if err := a.db.WithContext(ctx).First(&b, "batch_uuid = ?", id).Error; err != nil {
+		return b, translateError(err)
+	}
+
+	return b, nil
+}
+
+func (a *DatabaseAdapter) GetTransferBatchByMessageId(ctx context.Context, messageId string) (domainBatch.BatchOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetTransferBatchByMessageId")
+	defer span.End()
+
+	var b domainBatch.BatchOrm
+	if err := a.db.WithContext(ctx).First(&b, "message_id = ?", messageId).Error; err != nil {
+		return b, translateError(err)
+	}
+
+	return b, nil
+}
+
+func (a *DatabaseAdapter) CountTransferBatchItems(ctx context.Context, batchUuid uuid.UUID) (map[string]int, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CountTransferBatchItems")
+	defer span.End()
+
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	err := a.db.WithContext(ctx).Model(&domainBatch.ItemOrm{}).
+		Select("status, COUNT(*) AS count").
+		Where("batch_uuid = ?", batchUuid).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't count items of transfer batch %v : %v\n", batchUuid, err), "", "BankAdapter - CountTransferBatchItems")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+func (a *DatabaseAdapter) ListTransferBatchItems(ctx context.Context, batchUuid uuid.UUID) ([]domainBatch.ItemOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListTransferBatchItems")
+	defer span.End()
+
+	items := []domainBatch.ItemOrm{}
+	err := a.db.WithContext(ctx).Where("batch_uuid = ?", batchUuid).Order("line_number, item_uuid").Find(&items).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read items of transfer batch %v : %v\n", batchUuid, err), "", "BankAdapter - ListTransferBatchItems")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (a *DatabaseAdapter) ClaimTransferBatches(ctx context.Context, now time.Time, until time.Time, limit int) ([]domainBatch.BatchOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ClaimTransferBatches")
+	defer span.End()
+
+	db := a.db.WithContext(ctx)
+	running := []string{domainBatch.StatusAccepted, domainBatch.StatusProcessing, domainBatch.StatusRollingBack}
+
+	var due []domainBatch.BatchOrm
+	err := db.Select("batch_uuid").
+		Where("status IN ? AND (lease_until IS NULL OR lease_until <= ?)", running, now).
+		Order("created_at, batch_uuid").Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read due transfer batches : %v\n", err), "", "BankAdapter - ClaimTransferBatches")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	// another replica may have read the same rows, the conditional update
+	// lets only one of them take the lease
+	claimed := make([]uuid.UUID, 0, len(due))
+	for _, b := range due {
+		res := db.Model(&domainBatch.BatchOrm{}).
+			Where("batch_uuid = ? AND status IN ? AND (lease_until IS NULL OR lease_until <= ?)", b.BatchUuid, running, now).
+			Updates(map[string]interface{}{
+				"lease_until": until,
+				"status":      gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END", domainBatch.StatusAccepted, domainBatch.StatusProcessing),
+				"updated_at":  gorm.Expr("CASE WHEN status = ? THEN ? ELSE updated_at END", domainBatch.StatusAccepted, now),
+			})
+		if res.Error != nil {
+			logErr := util.LogError(fmt.Sprintf("Can't claim transfer batch %v : %v\n", b.BatchUuid, res.Error), "", "BankAdapter - ClaimTransferBatches")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return nil, res.Error
+		}
+		if res.RowsAffected == 1 {
+			claimed = append(claimed, b.BatchUuid)
+		}
+	}
+
+	batches := []domainBatch.BatchOrm{}
+	if len(claimed) == 0 {
+		return batches, nil
+	}
+	if err := db.Where("batch_uuid IN ?", claimed).Order("created_at, batch_uuid").Find(&batches).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read claimed transfer batches : %v\n", err), "", "BankAdapter - ClaimTransferBatches")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return batches, nil
+}
+
+func (a *DatabaseAdapter) UpdateTransferBatch(ctx context.Context, batch domainBatch.BatchOrm, from string) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.UpdateTransferBatch")
+	defer span.End()
+
+	res := a.db.WithContext(ctx).Model(&domainBatch.BatchOrm{}).
+		Where("batch_uuid = ? AND status = ?", batch.BatchUuid, from).
+		Updates(map[string]interface{}{
+			"status":       batch.Status,
+			"reason_code":  batch.ReasonCode,
+			"reason":       batch.Reason,
+			"lease_until":  batch.LeaseUntil,
+			"updated_at":   batch.UpdatedAt,
+			"completed_at": batch.CompletedAt,
+		})
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't update transfer batch %v : %v\n", batch.BatchUuid, res.Error), "", "BankAdapter - UpdateTransferBatch")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		if _, err := a.GetTransferBatch(ctx, batch.BatchUuid); err != nil {
+			return err
+		}
+		return domainBatch.ErrBatchChanged
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) UpdateTransferBatchItem(ctx context.Context, item domainBatch.ItemOrm, from string) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.UpdateTransferBatchItem")
+	defer span.End()
+
+	db := a.db.WithContext(ctx)
+	res := db.Model(&domainBatch.ItemOrm{}).
+		Where("item_uuid = ? AND status = ?", item.ItemUuid, from).
+		Updates(map[string]interface{}{
+			"status":        item.Status,
+			"reason_code":   item.ReasonCode,
+			"reason":        item.Reason,
+			"transfer_uuid": item.TransferUuid,
+			"updated_at":    item.UpdatedAt,
+		})
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't update transfer batch item %v : %v\n", item.ItemUuid, res.Error), "", "BankAdapter - UpdateTransferBatchItem")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		var stored domainBatch.ItemOrm
+		if err := db.Select("item_uuid").First(&stored, "item_uuid = ?", item.ItemUuid).Error; err != nil {
+			return translateError(err)
+		}
+		return domainBatch.ErrBatchChanged
+	}
+
+	return nil
+}
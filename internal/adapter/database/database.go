@@ -1,9 +1,14 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
 	"github.com/rs/zerolog/log"
 	"gorm.io/driver/postgres"
@@ -12,12 +17,14 @@ import (
 
 type DatabaseAdapter struct {
 	db *gorm.DB
+
+	// replica serves the read paths when configured, see reader.
+	replica   *gorm.DB
+	freshness *freshnessTracker
 }
 
 func NewDatabaseAdapter(conn *sql.DB) (*DatabaseAdapter, error) {
-	db, err := gorm.Open(postgres.New(postgres.Config{
-		Conn: conn,
-	}), &gorm.Config{})
+	db, err := openGorm(postgres.New(postgres.Config{Conn: conn}))
 
 	if err != nil {
 		logErr := util.LogError(fmt.Sprintf("can't connect database (gorm) : %v", err), "", "DatabaseAdapter - GetBankAccountByAccountNumber")
@@ -26,6 +33,125 @@ func NewDatabaseAdapter(conn *sql.DB) (*DatabaseAdapter, error) {
 	}
 
 	return &DatabaseAdapter{
-		db: db,
+		db:        db,
+		freshness: newFreshnessTracker(0),
 	}, nil
 }
+
+// WithReplica routes the read-only queries that tolerate replication lag to
+// conn. Keys written through this adapter keep being read from the primary
+// for the freshness window, so a client sees its own writes.
+func (a *DatabaseAdapter) WithReplica(conn *sql.DB, freshness time.Duration) error {
+	replica, err := openGorm(postgres.New(postgres.Config{Conn: conn}))
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("can't connect replica database (gorm) : %v", err), "", "DatabaseAdapter - WithReplica")
+		log.Error().Msg(logErr)
+		return fmt.Errorf("can't connect replica database (gorm) : %v", err)
+	}
+
+	a.replica = replica
+	a.freshness = newFreshnessTracker(freshness)
+
+	return nil
+}
+
+func openGorm(dialector gorm.Dialector) (*gorm.DB, error) {
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Use(tracingPlugin{}); err != nil {
+		return nil, fmt.Errorf("can't register gorm tracing plugin : %v", err)
+	}
+
+	return db, nil
+}
+
+// read runs query against the replica, unless there is none or one of keys
+// was written within the freshness window. A failed replica read, e.g.
+// because the replica is down or has not caught up with an insert yet, is
+// retried on the primary.
+func (a *DatabaseAdapter) read(ctx context.Context, query func(db *gorm.DB) error, keys ...string) error {
+	if a.replica == nil || a.freshness.recent(keys...) {
+		return query(a.db.WithContext(ctx))
+	}
+
+	if err := query(a.replica.WithContext(ctx)); err == nil {
+		return nil
+	}
+
+	return query(a.db.WithContext(ctx))
+}
+
+// translateError maps GORM errors onto the domain errors of the port.
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainBank.ErrRecordNotFound
+	}
+
+	return err
+}
+
+func accountKey(accountNumber string) string {
+	return "account:" + accountNumber
+}
+
+// freshnessTracker remembers when keys were last written.
+type freshnessTracker struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	written map[string]time.Time
+}
+
+func newFreshnessTracker(window time.Duration) *freshnessTracker {
+	return &freshnessTracker{
+		window:  window,
+		written: map[string]time.Time{},
+	}
+}
+
+func (t *freshnessTracker) touch(keys ...string) {
+	if t.window <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, k := range keys {
+		t.written[k] = now
+	}
+
+	// drop expired entries once the map grows, so it stays bounded by the
+	// write rate of one window
+	if len(t.written) > 1024 {
+		for k, at := range t.written {
+			if now.Sub(at) > t.window {
+				delete(t.written, k)
+			}
+		}
+	}
+}
+
+func (t *freshnessTracker) recent(keys ...string) bool {
+	if t.window <= 0 {
+		return false
+	}
+
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, k := range keys {
+		if at, ok := t.written[k]; ok && now.Sub(at) <= t.window {
+			return true
+		}
+	}
+
+	return false
+}
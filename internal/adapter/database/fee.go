@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (a *DatabaseAdapter) SaveFeeSchedule(ctx context.Context, schedule domainFee.Schedule) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.SaveFeeSchedule")
+	defer span.End()
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "schedule_uuid"}},
+			DoUpdates: clause.AssignmentColumns([]string{"currency", "channel", "customer_segment", "min_fee", "max_fee",
+				"free_per_month", "fee_account_uuid", "updated_at"}),
+		}).Create(&schedule.FeeScheduleOrm).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("schedule_uuid = ?", schedule.ScheduleUuid).Delete(&domainFee.FeeTierOrm{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&schedule.Tiers).Error
+	})
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't save the fee schedule of %v : %v\n", schedule.Currency, err), "", "BankAdapter - SaveFeeSchedule")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) ListFeeSchedules(ctx context.Context) ([]domainFee.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListFeeSchedules")
+	defer span.End()
+
+	var rows []domainFee.FeeScheduleOrm
+	var tiers []domainFee.FeeTierOrm
+
+	err := a.db.WithContext(ctx).Order("currency, channel, customer_segment").Find(&rows).Error
+	if err == nil {
+		err = a.db.WithContext(ctx).Order("schedule_uuid, min_amount").Find(&tiers).Error
+	}
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read fee schedules : %v\n", err), "", "BankAdapter - ListFeeSchedules")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	byUuid := map[uuid.UUID][]domainFee.FeeTierOrm{}
+	for _, t := range tiers {
+		byUuid[t.ScheduleUuid] = append(byUuid[t.ScheduleUuid], t)
+	}
+	schedules := make([]domainFee.Schedule, 0, len(rows))
+	for _, r := range rows {
+		schedules = append(schedules, domainFee.Schedule{FeeScheduleOrm: r, Tiers: byUuid[r.ScheduleUuid]})
+	}
+
+	return schedules, nil
+}
+
+func (a *DatabaseAdapter) CountTransferFees(ctx context.Context, accountUuid uuid.UUID, scheduleUuid uuid.UUID, since time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CountTransferFees")
+	defer span.End()
+
+	n, err := countTransferFees(a.db.WithContext(ctx), accountUuid, scheduleUuid, since)
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't count the transfer fees of %v : %v\n", accountUuid, err), "", "BankAdapter - CountTransferFees")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func countTransferFees(db *gorm.DB, accountUuid uuid.UUID, scheduleUuid uuid.UUID, since time.Time) (int, error) {
+	var n int64
+	err := db.Model(&domainFee.TransferFeeOrm{}).
+		Where("account_uuid = ? AND schedule_uuid = ? AND charged_at >= ?", accountUuid, scheduleUuid, since).
+		Count(&n).Error
+
+	return int(n), err
+}
+
+func (a *DatabaseAdapter) CreateChargedTransferTransactionPair(ctx context.Context, fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
+	fromTransactionOrm domainBank.BankTransactionOrm, toTransactionOrm domainBank.BankTransactionOrm, charge domainFee.Charge) (domainFee.Breakdown, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CreateChargedTransferTransactionPair")
+	defer span.End()
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := bookTransferPair(tx, fromAccountOrm, toAccountOrm, fromTransactionOrm, toTransactionOrm); err != nil {
+			return err
+		}
+
+		// the sender is locked, another of its transfers can't take the
+		// same free one
+		used, err := countTransferFees(tx, fromAccountOrm.AccountUuid, charge.Breakdown.ScheduleUuid, charge.MonthStart)
+		if err != nil {
+			return err
+		}
+		charge.Breakdown = charge.Breakdown.Allow(charge.FreePerMonth, used, charge.Rate)
+		record := charge.Record(fromAccountOrm.AccountUuid)
+
+		if record.Amount > 0 {
+			feeTrx, incomeTrx := charge.FeeTransaction, charge.IncomeTransaction
+			feeTrx.Amount, incomeTrx.Amount = record.Amount, record.Amount
+
+			charged, err := domainEvent.NewTransactionCreated(fromAccountOrm, feeTrx)
+			if err != nil {
+				return err
+			}
+			income, err := domainEvent.NewTransactionCreated(charge.FeeAccount, incomeTrx)
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Create(&feeTrx).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&incomeTrx).Error; err != nil {
+				return err
+			}
+			if err := checkDebit(tx, fromAccountOrm.AccountUuid, record.Amount, feeTrx.TransactionTimestamp); err != nil {
+				return err
+			}
+			if err := updateBalance(tx, fromAccountOrm.AccountUuid, -record.Amount); err != nil {
+				return err
+			}
+			if err := updateBalance(tx, charge.FeeAccount.AccountUuid, record.Amount); err != nil {
+				return err
+			}
+			if err := insertEvents(tx, charged, income); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&record).Error
+	})
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't book the transfer %v with its fee : %v\n", charge.TransferUuid, err), "", "BankAdapter - CreateChargedTransferTransactionPair")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainFee.Breakdown{}, translateError(err)
+	}
+	a.freshness.touch(accountKey(fromAccountOrm.AccountNumber), accountKey(toAccountOrm.AccountNumber), accountKey(charge.FeeAccount.AccountNumber))
+
+	return charge.Breakdown, nil
+}
+
+func (a *DatabaseAdapter) SetCustomerSegment(ctx context.Context, accountUuid uuid.UUID, segment string, at time.Time) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.SetCustomerSegment")
+	defer span.End()
+
+	res := a.db.WithContext(ctx).Model(&domainBank.BankAccountOrm{}).
+		Where("account_uuid = ?", accountUuid).
+		Updates(map[string]interface{}{"customer_segment": segment, "updated_at": at})
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't set the customer segment of %v : %v\n", accountUuid, res.Error), "", "BankAdapter - SetCustomerSegment")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainBank.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+func (a *DatabaseAdapter) HeldAmount(ctx context.Context, accountUuid uuid.UUID, at time.Time) (float64, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.HeldAmount")
+	defer span.End()
+
+	held, err := heldAmount(a.db.WithContext(ctx), accountUuid, at)
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't sum the holds of %v : %v\n", accountUuid, err), "", "BankAdapter - HeldAmount")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return 0, err
+	}
+
+	return held, nil
+}
+
+func heldAmount(tx *gorm.DB, accountUuid uuid.UUID, at time.Time) (float64, error) {
+	var held float64
+
+	err := tx.Model(&domainBank.BankHoldOrm{}).
+		Select("COALESCE(SUM(amount - captured_amount), 0)").
+		Where("account_uuid = ? AND status = ? AND expires_at > ?", accountUuid, domainBank.HoldStatusActive, at).
+		Scan(&held).Error
+
+	return math.Round(held*100) / 100, err
+}
+
+// checkDebit locks accountUuid for the rest of tx and returns the
+// InsufficientFundsError of its limits when its balance less its holds
+// active at at can't be debited amount. BankService checks the same before
+// the write, this check holds against concurrent debits and holds.
+func checkDebit(tx *gorm.DB, accountUuid uuid.UUID, amount float64, at time.Time) error {
+	// the no-op update locks the account, as in CreateHold
+	res := tx.Model(&domainBank.BankAccountOrm{}).
+		Where("account_uuid = ?", accountUuid).
+		Update("current_balance", gorm.Expr("current_balance"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainBank.ErrRecordNotFound
+	}
+
+	var account domainBank.BankAccountOrm
+	if err := tx.First(&account, "account_uuid = ?", accountUuid).Error; err != nil {
+		return err
+	}
+	// an account of an unknown product has the limits of none
+	var product domainBank.BankProductOrm
+	if err := tx.Limit(1).Find(&product, "product_code = ?", account.ProductCode).Error; err != nil {
+		return err
+	}
+	held, err := heldAmount(tx, accountUuid, at)
+	if err != nil {
+		return err
+	}
+
+	available := math.Round((account.CurrentBalance-held)*100) / 100
+	return domainBank.LimitsOf(account, product).Check(account.AccountNumber, available, amount)
+}
+
+func (a *DatabaseAdapter) CreateHold(ctx context.Context, hold domainBank.BankHoldOrm, floor float64) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CreateHold")
+	defer span.End()
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the no-op update locks the account until the hold is in, so
+		// concurrent holds can't reserve the same money
+		res := tx.Model(&domainBank.BankAccountOrm{}).
+			Where("account_uuid = ?", hold.AccountUuid).
+			Update("current_balance", gorm.Expr("current_balance"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domainBank.ErrRecordNotFound
+		}
+
+		var account domainBank.BalanceAccountOrm
+		if err := tx.First(&account, "account_uuid = ?", hold.AccountUuid).Error; err != nil {
+			return err
+		}
+		held, err := heldAmount(tx, hold.AccountUuid, hold.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if math.Round((account.CurrentBalance-held-hold.Amount)*100)/100 < floor {
+			return domainBank.ErrInsufficientBalance
+		}
+
+		return tx.Create(&hold).Error
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't create hold on %v : %v\n", hold.AccountUuid, err), "", "BankAdapter - CreateHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) GetHold(ctx context.Context, holdUuid uuid.UUID) (domainBank.BankHoldOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetHold")
+	defer span.End()
+
+	var hold domainBank.BankHoldOrm
+
+	if err := a.db.WithContext(ctx).First(&hold, "hold_uuid = ?", holdUuid).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't find hold %v : %v\n", holdUuid, err), "", "BankAdapter - GetHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return hold, translateError(err)
+	}
+
+	return hold, nil
+}
+
+func (a *DatabaseAdapter) CaptureHold(ctx context.Context, capture domainBank.HoldCapture) (domainBank.BankHoldOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CaptureHold")
+	defer span.End()
+
+	created, err := domainEvent.NewTransactionCreated(capture.Account, capture.Transaction)
+	if err != nil {
+		return domainBank.BankHoldOrm{}, err
+	}
+
+	var hold domainBank.BankHoldOrm
+	err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		amount := capture.Transaction.Amount
+		at := capture.Transaction.TransactionTimestamp
+
+		// the conditions make concurrent captures of one hold add up to its
+		// amount at most
+		captured := gorm.Expr("ROUND(CAST(captured_amount + ? AS NUMERIC), 2)", amount)
+		res := tx.Model(&domainBank.BankHoldOrm{}).
+			Where("hold_uuid = ? AND status = ? AND expires_at > ?", capture.Hold.HoldUuid, domainBank.HoldStatusActive, at).
+			Where("ROUND(CAST(captured_amount + ? AS NUMERIC), 2) <= amount", amount).
+			Updates(map[string]interface{}{
+				"captured_amount": captured,
+				"status": gorm.Expr("CASE WHEN ROUND(CAST(captured_amount + ? AS NUMERIC), 2) >= amount THEN ? ELSE status END",
+					amount, domainBank.HoldStatusCaptured),
+				"updated_at": at,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return holdNotUpdated(tx, capture.Hold.HoldUuid, at, domainBank.ErrHoldExceeded)
+		}
+
+		if err := tx.Create(&capture.Transaction).Error; err != nil {
+			return err
+		}
+		if err := updateBalance(tx, capture.Account.AccountUuid, -amount); err != nil {
+			return err
+		}
+		if err := insertEvents(tx, created); err != nil {
+			return err
+		}
+
+		return tx.First(&hold, "hold_uuid = ?", capture.Hold.HoldUuid).Error
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't capture hold %v : %v\n", capture.Hold.HoldUuid, err), "", "BankAdapter - CaptureHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainBank.BankHoldOrm{}, err
+	}
+	a.freshness.touch(accountKey(capture.Account.AccountNumber))
+
+	return hold, nil
+}
+
+func (a *DatabaseAdapter) ReleaseHold(ctx context.Context, holdUuid uuid.UUID, at time.Time) (domainBank.BankHoldOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ReleaseHold")
+	defer span.End()
+
+	var hold domainBank.BankHoldOrm
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domainBank.BankHoldOrm{}).
+			Where("hold_uuid = ? AND status = ? AND expires_at > ?", holdUuid, domainBank.HoldStatusActive, at).
+			Updates(map[string]interface{}{
+				"status":     domainBank.HoldStatusReleased,
+				"updated_at": at,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return holdNotUpdated(tx, holdUuid, at, domainBank.ErrHoldNotActive)
+		}
+
+		return tx.First(&hold, "hold_uuid = ?", holdUuid).Error
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't release hold %v : %v\n", holdUuid, err), "", "BankAdapter - ReleaseHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainBank.BankHoldOrm{}, err
+	}
+
+	return hold, nil
+}
+
+// holdNotUpdated tells why a conditional update of holdUuid matched no row:
+// the hold doesn't exist, isn't active at at, or else fallback.
+func holdNotUpdated(tx *gorm.DB, holdUuid uuid.UUID, at time.Time, fallback error) error {
+	var hold domainBank.BankHoldOrm
+	if err := tx.First(&hold, "hold_uuid = ?", holdUuid).Error; err != nil {
+		return translateError(err)
+	}
+	if hold.StatusAt(at) != domainBank.HoldStatusActive {
+		return domainBank.ErrHoldNotActive
+	}
+
+	return fallback
+}
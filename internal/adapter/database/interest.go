@@ -0,0 +1,261 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (a *DatabaseAdapter) SaveInterestRates(ctx context.Context, version domainInterest.RateVersion) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.SaveInterestRates")
+	defer span.End()
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("product_code = ? AND effective_from = ?", version.ProductCode, version.EffectiveFrom).
+			Delete(&domainInterest.InterestRateOrm{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&version.Tiers).Error
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't save the rates of %v : %v\n", version.ProductCode, err), "", "BankAdapter - SaveInterestRates")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) ListInterestRates(ctx context.Context, productCode string) ([]domainInterest.InterestRateOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListInterestRates")
+	defer span.End()
+
+	var rates []domainInterest.InterestRateOrm
+
+	query := a.db.WithContext(ctx).Order("product_code, effective_from, min_balance")
+	if productCode != "" {
+		query = query.Where("product_code = ?", productCode)
+	}
+	if err := query.Find(&rates).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read interest rates : %v\n", err), "", "BankAdapter - ListInterestRates")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (a *DatabaseAdapter) InterestAccounts(ctx context.Context) ([]domainInterest.InterestAccount, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.InterestAccounts")
+	defer span.End()
+
+	var products []domainBank.BankProductOrm
+	var accounts []domainBank.BankAccountOrm
+
+	withRates := a.db.WithContext(ctx).Model(&domainInterest.InterestRateOrm{}).Distinct("product_code")
+	err := a.db.WithContext(ctx).Where("product_code IN (?)", withRates).Find(&products).Error
+	if err == nil {
+		err = a.db.WithContext(ctx).Where("product_code IN (?)", withRates).Order("account_number").Find(&accounts).Error
+	}
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read the accounts earning interest : %v\n", err), "", "BankAdapter - InterestAccounts")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	byCode := map[string]domainBank.BankProductOrm{}
+	for _, p := range products {
+		byCode[p.ProductCode] = p
+	}
+	interestAccounts := make([]domainInterest.InterestAccount, 0, len(accounts))
+	for _, acc := range accounts {
+		interestAccounts = append(interestAccounts, domainInterest.InterestAccount{Account: acc, Product: byCode[acc.ProductCode]})
+	}
+
+	return interestAccounts, nil
+}
+
+func (a *DatabaseAdapter) LastAccrualDate(ctx context.Context, accountUuid uuid.UUID) (time.Time, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.LastAccrualDate")
+	defer span.End()
+
+	var last []domainInterest.InterestAccrualOrm
+
+	err := a.db.WithContext(ctx).Where("account_uuid = ?", accountUuid).
+		Order("accrual_date DESC").Limit(1).Find(&last).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read the last accrual of %v : %v\n", accountUuid, err), "", "BankAdapter - LastAccrualDate")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return time.Time{}, err
+	}
+	if len(last) == 0 {
+		return time.Time{}, nil
+	}
+
+	return domainInterest.Day(last[0].AccrualDate), nil
+}
+
+func (a *DatabaseAdapter) ClosingBalances(ctx context.Context, accountUuid uuid.UUID, from time.Time, to time.Time) ([]float64, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ClosingBalances")
+	defer span.End()
+
+	var account domainBank.BalanceAccountOrm
+	var trxs []domainBank.BankTransactionOrm
+
+	// one transaction so the balance and the transactions since from agree
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&account, "account_uuid = ?", accountUuid).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("account_uuid = ? AND transaction_timestamp >= ?", accountUuid, from).Find(&trxs).Error
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read the balances of %v : %v\n", accountUuid, err), "", "BankAdapter - ClosingBalances")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, translateError(err)
+	}
+
+	return domainInterest.ClosingBalances(account.CurrentBalance, from, to, trxs), nil
+}
+
+func (a *DatabaseAdapter) SaveInterestAccruals(ctx context.Context, accruals []domainInterest.InterestAccrualOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.SaveInterestAccruals")
+	defer span.End()
+
+	if len(accruals) == 0 {
+		return nil
+	}
+
+	err := a.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&accruals).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't save interest accruals : %v\n", err), "", "BankAdapter - SaveInterestAccruals")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) CapitalizeInterest(ctx context.Context, c domainInterest.Capitalization) (domainInterest.Posting, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CapitalizeInterest")
+	defer span.End()
+
+	var posting domainInterest.Posting
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		pending := func() *gorm.DB {
+			return tx.Model(&domainInterest.InterestAccrualOrm{}).
+				Where("account_uuid = ? AND capitalized_at IS NULL AND accrual_date < ?", c.Account.AccountUuid, c.Before)
+		}
+
+		// the no-op update locks the account, so two runs can't post the
+		// same accruals
+		res := tx.Model(&domainBank.BankAccountOrm{}).
+			Where("account_uuid = ?", c.Account.AccountUuid).
+			Update("current_balance", gorm.Expr("current_balance"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domainBank.ErrRecordNotFound
+		}
+
+		var accrued float64
+		if err := pending().Select("COALESCE(SUM(amount), 0)").Scan(&accrued).Error; err != nil {
+			return err
+		}
+		trx := c.Transaction
+		trx.Amount = math.Round(math.Round(accrued*1e6)/1e6*100) / 100
+		if trx.Amount <= 0 {
+			return nil
+		}
+
+		// the threshold and the exemption are the customer's
+		balance, exempt, err := customerStanding(tx, c.Account.AccountUuid)
+		if err != nil {
+			return err
+		}
+		withholding := c.Withholding
+		withholding.Exempt = exempt
+
+		created, err := domainEvent.NewTransactionCreated(c.Account, trx)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&trx).Error; err != nil {
+			return err
+		}
+		if err := updateBalance(tx, c.Account.AccountUuid, trx.Amount); err != nil {
+			return err
+		}
+		err = pending().Updates(map[string]interface{}{
+			"transaction_uuid": trx.TransactionUuid,
+			"capitalized_at":   trx.TransactionTimestamp,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if err := insertEvents(tx, created); err != nil {
+			return err
+		}
+
+		withheld, err := withholdTax(tx, c.Account, withholding, trx, balance)
+		if err != nil {
+			return err
+		}
+		posting = domainInterest.Posting{Interest: trx.Amount, Tax: withheld.TaxAmount}
+
+		return nil
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't capitalize the interest of %v : %v\n", c.Account.AccountUuid, err), "", "BankAdapter - CapitalizeInterest")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainInterest.Posting{}, translateError(err)
+	}
+	if posting.Interest > 0 {
+		a.freshness.touch(accountKey(c.Account.AccountNumber))
+	}
+	if posting.Tax > 0 {
+		a.freshness.touch(accountKey(c.Withholding.PayableAccount.AccountNumber))
+	}
+
+	return posting, nil
+}
+
+func (a *DatabaseAdapter) ListInterestAccruals(ctx context.Context, accountUuid uuid.UUID, from time.Time, to time.Time) ([]domainInterest.InterestAccrualOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListInterestAccruals")
+	defer span.End()
+
+	var accruals []domainInterest.InterestAccrualOrm
+
+	query := a.db.WithContext(ctx).Where("account_uuid = ?", accountUuid).Order("accrual_date")
+	if !from.IsZero() {
+		query = query.Where("accrual_date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("accrual_date < ?", to)
+	}
+	if err := query.Find(&accruals).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read the accruals of %v : %v\n", accountUuid, err), "", "BankAdapter - ListInterestAccruals")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return accruals, nil
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+)
+
+// AdvisoryLocker implements LeaderLockPort with Postgres session advisory
+// locks. A held lock keeps a connection of the pool, and Postgres releases
+// it when that session ends, so a replica that dies or loses the database
+// hands the lock over without waiting for a timeout.
+type AdvisoryLocker struct {
+	db *sql.DB
+}
+
+func NewAdvisoryLocker(db *sql.DB) *AdvisoryLocker {
+	return &AdvisoryLocker{db: db}
+}
+
+func (l *AdvisoryLocker) TryLock(ctx context.Context, name string) (port.HeldLock, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get a connection for lock %q : %v", name, err)
+	}
+
+	key := advisoryKey(name)
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		// the lock may have been taken before the error
+		discard(conn)
+		logErr := util.LogError(fmt.Sprintf("Can't take advisory lock %q : %v\n", name, err), "", "AdvisoryLocker - TryLock")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+	if !locked {
+		conn.Close()
+		return nil, nil
+	}
+
+	return &advisoryLock{conn: conn, name: name, key: key}, nil
+}
+
+// advisoryKey maps name onto the bigint key space of advisory locks.
+func advisoryKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+
+	return int64(h.Sum64())
+}
+
+type advisoryLock struct {
+	conn *sql.Conn
+	name string
+	key  int64
+}
+
+// Check pings the session holding the lock. A session that is gone took
+// the lock with it.
+func (l *advisoryLock) Check(ctx context.Context) error {
+	if err := l.conn.PingContext(ctx); err != nil {
+		discard(l.conn)
+		return fmt.Errorf("session of advisory lock %q lost : %v", l.name, err)
+	}
+
+	return nil
+}
+
+func (l *advisoryLock) Unlock(ctx context.Context) error {
+	var unlocked bool
+	if err := l.conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", l.key).Scan(&unlocked); err != nil {
+		discard(l.conn)
+		logErr := util.LogError(fmt.Sprintf("Can't release advisory lock %q : %v\n", l.name, err), "", "AdvisoryLocker - Unlock")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+	l.conn.Close()
+	if !unlocked {
+		return fmt.Errorf("advisory lock %q was not held", l.name)
+	}
+
+	return nil
+}
+
+// discard closes the session of conn instead of returning it to the pool,
+// where it would keep a lock it may still hold.
+func discard(conn *sql.Conn) {
+	conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+	conn.Close()
+}
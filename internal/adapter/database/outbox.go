@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// outboxRecord is a row of bank_outbox. outbox_id keeps the events in the
+// order they were written, also within one database transaction.
+type outboxRecord struct {
+	OutboxId      int64 `gorm:"primaryKey;autoIncrement"`
+	EventUuid     uuid.UUID
+	EventType     string
+	EventVersion  int
+	AggregateUuid uuid.UUID
+	OccurredAt    time.Time
+	Payload       string
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+func (outboxRecord) TableName() string {
+	return "bank_outbox"
+}
+
+// insertEvents adds events to the outbox within tx, so they are committed or
+// rolled back together with the change they describe.
+func insertEvents(tx *gorm.DB, events ...domainEvent.Event) error {
+	now := time.Now()
+
+	records := make([]outboxRecord, 0, len(events))
+	for _, e := range events {
+		records = append(records, outboxRecord{
+			EventUuid:     e.ID,
+			EventType:     e.Type,
+			EventVersion:  e.Version,
+			AggregateUuid: e.AggregateID,
+			OccurredAt:    e.OccurredAt,
+			Payload:       string(e.Data),
+			CreatedAt:     now,
+		})
+	}
+
+	if err := tx.Create(&records).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't write events to the outbox : %v\n", err), "", "BankAdapter - insertEvents")
+		log.Error().Ctx(tx.Statement.Context).Msg(logErr)
+		return err
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) PendingEvents(ctx context.Context, limit int) ([]domainEvent.Event, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.PendingEvents")
+	defer span.End()
+
+	var records []outboxRecord
+	if err := a.db.WithContext(ctx).Where("published_at IS NULL").Order("outbox_id").Limit(limit).Find(&records).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read the outbox : %v\n", err), "", "BankAdapter - PendingEvents")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	events := make([]domainEvent.Event, 0, len(records))
+	for _, r := range records {
+		events = append(events, domainEvent.Event{
+			ID:          r.EventUuid,
+			Type:        r.EventType,
+			Version:     r.EventVersion,
+			AggregateID: r.AggregateUuid,
+			OccurredAt:  r.OccurredAt.UTC(),
+			Data:        json.RawMessage(r.Payload),
+		})
+	}
+
+	return events, nil
+}
+
+func (a *DatabaseAdapter) MarkEventPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.MarkEventPublished")
+	defer span.End()
+
+	res := a.db.WithContext(ctx).Model(&outboxRecord{}).Where("event_uuid = ?", id).Update("published_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return domainBank.ErrRecordNotFound
+	}
+
+	return nil
+}
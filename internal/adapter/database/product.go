@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (a *DatabaseAdapter) GetProduct(ctx context.Context, productCode string) (domainBank.BankProductOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetProduct")
+	defer span.End()
+
+	var product domainBank.BankProductOrm
+
+	if err := a.db.WithContext(ctx).First(&product, "product_code = ?", productCode).Error; err != nil {
+		return product, translateError(err)
+	}
+
+	return product, nil
+}
+
+func (a *DatabaseAdapter) ListProducts(ctx context.Context) ([]domainBank.BankProductOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListProducts")
+	defer span.End()
+
+	var products []domainBank.BankProductOrm
+
+	if err := a.db.WithContext(ctx).Order("product_code").Find(&products).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read products : %v\n", err), "", "BankAdapter - ListProducts")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (a *DatabaseAdapter) SaveProduct(ctx context.Context, product domainBank.BankProductOrm) (domainBank.BankProductOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.SaveProduct")
+	defer span.End()
+
+	var saved domainBank.BankProductOrm
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_code"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "overdraft_limit", "minimum_balance", "interest_day_count", "interest_accrual_frequency", "interest_capitalization", "updated_at"}),
+		}).Create(&product).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.First(&saved, "product_code = ?", product.ProductCode).Error
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't save product %v : %v\n", product.ProductCode, err), "", "BankAdapter - SaveProduct")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainBank.BankProductOrm{}, err
+	}
+
+	return saved, nil
+}
+
+func (a *DatabaseAdapter) UpdateAccountLimits(ctx context.Context, account domainBank.BankAccountOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.UpdateAccountLimits")
+	defer span.End()
+
+	res := a.db.WithContext(ctx).Model(&domainBank.BankAccountOrm{}).
+		Where("account_uuid = ?", account.AccountUuid).
+		Select("product_code", "overdraft_limit", "minimum_balance", "updated_at").
+		Updates(&domainBank.BankAccountOrm{
+			ProductCode:    account.ProductCode,
+			OverdraftLimit: account.OverdraftLimit,
+			MinimumBalance: account.MinimumBalance,
+			UpdatedAt:      account.UpdatedAt,
+		})
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't update the limits of %v : %v\n", account.AccountUuid, res.Error), "", "BankAdapter - UpdateAccountLimits")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainBank.ErrRecordNotFound
+	}
+	a.freshness.touch(accountKey(account.AccountNumber))
+
+	return nil
+}
+
+func (a *DatabaseAdapter) SetAccountCustomer(ctx context.Context, accountUuid uuid.UUID, customerId string, at time.Time) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.SetAccountCustomer")
+	defer span.End()
+
+	res := a.db.WithContext(ctx).Model(&domainBank.BankAccountOrm{}).
+		Where("account_uuid = ?", accountUuid).
+		Updates(map[string]interface{}{"customer_id": customerId, "updated_at": at})
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't set the customer of %v : %v\n", accountUuid, res.Error), "", "BankAdapter - SetAccountCustomer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainBank.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) ListAccountsOfCustomer(ctx context.Context, customerId string) ([]domainBank.BankAccountOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListAccountsOfCustomer")
+	defer span.End()
+
+	var accounts []domainBank.BankAccountOrm
+	if customerId == "" {
+		return accounts, nil
+	}
+
+	if err := a.db.WithContext(ctx).Where("customer_id = ?", customerId).Order("account_number").Find(&accounts).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read the accounts of customer %v : %v\n", customerId, err), "", "BankAdapter - ListAccountsOfCustomer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return accounts, nil
+}
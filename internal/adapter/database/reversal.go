@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+func (a *DatabaseAdapter) GetDetailBankAccountByUuid(ctx context.Context, accountUuid uuid.UUID) (domainBank.BankAccountOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetDetailBankAccountByUuid")
+	defer span.End()
+
+	var bankAccountOrm domainBank.BankAccountOrm
+
+	if err := a.db.WithContext(ctx).First(&bankAccountOrm, "account_uuid = ?", accountUuid).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't find bank account %v : %v\n", accountUuid, err), "", "BankAdapter - GetDetailBankAccountByUuid")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return bankAccountOrm, translateError(err)
+	}
+
+	return bankAccountOrm, nil
+}
+
+func (a *DatabaseAdapter) GetTransfer(ctx context.Context, transferUuid uuid.UUID) (domainBank.BankTransferOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetTransfer")
+	defer span.End()
+
+	var transfer domainBank.BankTransferOrm
+
+	if err := a.db.WithContext(ctx).First(&transfer, "transfer_uuid = ?", transferUuid).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't find transfer %v : %v\n", transferUuid, err), "", "BankAdapter - GetTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return transfer, translateError(err)
+	}
+
+	return transfer, nil
+}
+
+func (a *DatabaseAdapter) ReverseTransfer(ctx context.Context, booking domainBank.TransferReversalBooking) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ReverseTransfer")
+	defer span.End()
+
+	reversed, err := domainEvent.NewTransferReversed(booking.Original, booking.Reversal)
+	if err != nil {
+		return err
+	}
+	debited, err := domainEvent.NewTransactionCreated(booking.Recipient, booking.Debit)
+	if err != nil {
+		return err
+	}
+	credited, err := domainEvent.NewTransactionCreated(booking.Sender, booking.Credit)
+	if err != nil {
+		return err
+	}
+
+	err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the condition makes concurrent reversals of one transfer add up to
+		// its amount at most
+		amount := booking.Reversal.Amount
+		res := tx.Model(&domainBank.BankTransferOrm{}).
+			Where("transfer_uuid = ? AND transfer_success AND reversal_of_uuid IS NULL", booking.Original.TransferUuid).
+			Where("ROUND(CAST(reversed_amount + ? AS NUMERIC), 2) <= amount", amount).
+			Updates(map[string]interface{}{
+				"reversed_amount": gorm.Expr("ROUND(CAST(reversed_amount + ? AS NUMERIC), 2)", amount),
+				"updated_at":      time.Now(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domainBank.ErrReversalExceedsTransfer
+		}
+
+		if err := tx.Create(&booking.Reversal).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&booking.Debit).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&booking.Credit).Error; err != nil {
+			return err
+		}
+
+		if err := debitBalance(tx, booking.Recipient.AccountUuid, booking.Debit.Amount, booking.Debit.TransactionTimestamp, booking.AllowOverdraft); err != nil {
+			return err
+		}
+		if err := updateBalance(tx, booking.Sender.AccountUuid, booking.Credit.Amount); err != nil {
+			return err
+		}
+
+		return insertEvents(tx, reversed, debited, credited)
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't reverse transfer %v : %v\n", booking.Original.TransferUuid, err), "", "BankAdapter - ReverseTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+	a.freshness.touch(accountKey(booking.Recipient.AccountNumber), accountKey(booking.Sender.AccountNumber))
+
+	return nil
+}
+
+// debitBalance takes amount off the stored balance, unless allowOverdraft is
+// false and checkDebit refuses it.
+func debitBalance(tx *gorm.DB, accountUuid uuid.UUID, amount float64, at time.Time, allowOverdraft bool) error {
+	if !allowOverdraft {
+		if err := checkDebit(tx, accountUuid, amount, at); err != nil {
+			return err
+		}
+	}
+
+	return updateBalance(tx, accountUuid, -amount)
+}
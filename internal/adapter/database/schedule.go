@@ -0,0 +1,219 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainSchedule "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/schedule"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+func (a *DatabaseAdapter) CreateScheduledTransfer(ctx context.Context, s domainSchedule.ScheduledTransferOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CreateScheduledTransfer")
+	defer span.End()
+
+	if err := a.db.WithContext(ctx).Create(&s).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't insert scheduled transfer : %v\n", err), "", "BankAdapter - CreateScheduledTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) GetScheduledTransfer(ctx context.Context, id uuid.UUID) (domainSchedule.ScheduledTransferOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetScheduledTransfer")
+	defer span.End()
+
+	var s domainSchedule.ScheduledTransferOrm
+	if err := a.db.WithContext(ctx).First(&s, "schedule_uuid = ?", id).Error; err != nil {
+		return s, translateError(err)
+	}
+
+	return s, nil
+}
+
+func (a *DatabaseAdapter) ListScheduledTransfers(ctx context.Context, accountUuid uuid.UUID) ([]domainSchedule.ScheduledTransferOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListScheduledTransfers")
+	defer span.End()
+
+	query := a.db.WithContext(ctx).Order("created_at, schedule_uuid")
+	if accountUuid != uuid.Nil {
+		query = query.Where("from_account_uuid = ?", accountUuid)
+	}
+
+	schedules := []domainSchedule.ScheduledTransferOrm{}
+	if err := query.Find(&schedules).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read scheduled transfers : %v\n", err), "", "BankAdapter - ListScheduledTransfers")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (a *DatabaseAdapter) UpdateScheduledTransferStatus(ctx context.Context, id uuid.UUID, from []string, status string, at time.Time) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.UpdateScheduledTransferStatus")
+	defer span.End()
+
+	res := a.db.WithContext(ctx).Model(&domainSchedule.ScheduledTransferOrm{}).
+		Where("schedule_uuid = ? AND status IN ?", id, from).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": at,
+		})
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't update scheduled transfer %v : %v\n", id, res.Error), "", "BankAdapter - UpdateScheduledTransferStatus")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return a.scheduleNotUpdated(ctx, id)
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) ResumeScheduledTransfer(ctx context.Context, paused domainSchedule.ScheduledTransferOrm, resumed domainSchedule.ScheduledTransferOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ResumeScheduledTransfer")
+	defer span.End()
+
+	res := a.db.WithContext(ctx).Model(&domainSchedule.ScheduledTransferOrm{}).
+		Where("schedule_uuid = ? AND status = ? AND occurrence = ? AND attempt = ?",
+			paused.ScheduleUuid, domainSchedule.StatusPaused, paused.Occurrence, paused.Attempt).
+		Updates(map[string]interface{}{
+			"status":      resumed.Status,
+			"next_run_at": resumed.NextRunAt,
+			"occurrence":  resumed.Occurrence,
+			"attempt":     resumed.Attempt,
+			"updated_at":  resumed.UpdatedAt,
+		})
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't resume scheduled transfer %v : %v\n", paused.ScheduleUuid, res.Error), "", "BankAdapter - ResumeScheduledTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return a.scheduleNotUpdated(ctx, paused.ScheduleUuid)
+	}
+
+	return nil
+}
+
+// scheduleNotUpdated tells why a conditional update of id matched no row:
+// the schedule doesn't exist, or isn't in the status the update needs.
+func (a *DatabaseAdapter) scheduleNotUpdated(ctx context.Context, id uuid.UUID) error {
+	if _, err := a.GetScheduledTransfer(ctx, id); err != nil {
+		return err
+	}
+
+	return domainSchedule.ErrScheduleNotActive
+}
+
+func (a *DatabaseAdapter) ClaimScheduledTransfers(ctx context.Context, now time.Time, until time.Time, limit int) ([]domainSchedule.ScheduledTransferOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ClaimScheduledTransfers")
+	defer span.End()
+
+	db := a.db.WithContext(ctx)
+
+	var due []domainSchedule.ScheduledTransferOrm
+	err := db.Select("schedule_uuid").
+		Where("status = ? AND next_run_at <= ?", domainSchedule.StatusActive, now).
+		Order("next_run_at, schedule_uuid").Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read due scheduled transfers : %v\n", err), "", "BankAdapter - ClaimScheduledTransfers")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	// another replica may have read the same rows, the conditional update
+	// lets only one of them move the next run
+	claimed := make([]uuid.UUID, 0, len(due))
+	for _, s := range due {
+		res := db.Model(&domainSchedule.ScheduledTransferOrm{}).
+			Where("schedule_uuid = ? AND status = ? AND next_run_at <= ?", s.ScheduleUuid, domainSchedule.StatusActive, now).
+			Update("next_run_at", until)
+		if res.Error != nil {
+			logErr := util.LogError(fmt.Sprintf("Can't claim scheduled transfer %v : %v\n", s.ScheduleUuid, res.Error), "", "BankAdapter - ClaimScheduledTransfers")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return nil, res.Error
+		}
+		if res.RowsAffected == 1 {
+			claimed = append(claimed, s.ScheduleUuid)
+		}
+	}
+
+	schedules := []domainSchedule.ScheduledTransferOrm{}
+	if len(claimed) == 0 {
+		return schedules, nil
+	}
+	if err := db.Where("schedule_uuid IN ?", claimed).Order("created_at, schedule_uuid").Find(&schedules).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read claimed scheduled transfers : %v\n", err), "", "BankAdapter - ClaimScheduledTransfers")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (a *DatabaseAdapter) RecordScheduledTransferRun(ctx context.Context, s domainSchedule.ScheduledTransferOrm, execution domainSchedule.ExecutionOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.RecordScheduledTransferRun")
+	defer span.End()
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored domainSchedule.ScheduledTransferOrm
+		if err := tx.Select("schedule_uuid").First(&stored, "schedule_uuid = ?", s.ScheduleUuid).Error; err != nil {
+			return translateError(err)
+		}
+
+		execution.ScheduleUuid = s.ScheduleUuid
+		if err := tx.Create(&execution).Error; err != nil {
+			return err
+		}
+
+		// resumed past the occurrence meanwhile, the run is history only
+		res := tx.Model(&domainSchedule.ScheduledTransferOrm{}).
+			Where("schedule_uuid = ? AND occurrence = ? AND attempt = ?", s.ScheduleUuid, execution.Occurrence, execution.Attempt).
+			Updates(map[string]interface{}{
+				"next_run_at": s.NextRunAt,
+				"occurrence":  s.Occurrence,
+				"attempt":     s.Attempt,
+				"updated_at":  s.UpdatedAt,
+			})
+		if res.Error != nil || res.RowsAffected == 0 || s.Status == domainSchedule.StatusActive {
+			return res.Error
+		}
+
+		return tx.Model(&domainSchedule.ScheduledTransferOrm{}).
+			Where("schedule_uuid = ? AND status = ?", s.ScheduleUuid, domainSchedule.StatusActive).
+			Update("status", s.Status).Error
+	})
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't record the run of scheduled transfer %v : %v\n", s.ScheduleUuid, err), "", "BankAdapter - RecordScheduledTransferRun")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) ListScheduledTransferExecutions(ctx context.Context, scheduleUuid uuid.UUID) ([]domainSchedule.ExecutionOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListScheduledTransferExecutions")
+	defer span.End()
+
+	executions := []domainSchedule.ExecutionOrm{}
+	err := a.db.WithContext(ctx).Where("schedule_uuid = ?", scheduleUuid).Order("occurrence, attempt").Find(&executions).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read executions of scheduled transfer %v : %v\n", scheduleUuid, err), "", "BankAdapter - ListScheduledTransferExecutions")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return executions, nil
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const seedBatchSize = 200
+
+// ApplySeed inserts the rows that don't exist yet in one transaction, see
+// seed.Store.
+func (a *DatabaseAdapter) ApplySeed(ctx context.Context, accounts []domainBank.BankAccountOrm, transactions []domainBank.BankTransactionOrm) error {
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx = tx.Clauses(clause.OnConflict{DoNothing: true})
+
+		if len(accounts) > 0 {
+			for i := range accounts {
+				accounts[i].OverdraftUsed = max(0, -accounts[i].CurrentBalance)
+			}
+			if err := tx.Omit("Transactions").CreateInBatches(&accounts, seedBatchSize).Error; err != nil {
+				return fmt.Errorf("accounts : %v", err)
+			}
+		}
+
+		if len(transactions) > 0 {
+			if err := tx.CreateInBatches(&transactions, seedBatchSize).Error; err != nil {
+				return fmt.Errorf("transactions : %v", err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't apply seed : %v\n", err), "", "BankAdapter - ApplySeed")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	return nil
+}
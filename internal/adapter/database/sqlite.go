@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NewSQLiteDatabaseAdapter serves the port from a SQLite database migrated
+// with db/migrations/sqlite. conn should be opened with foreign keys enabled,
+// see config.DBConfig.DSN.
+func NewSQLiteDatabaseAdapter(conn *sql.DB) (*DatabaseAdapter, error) {
+	db, err := openGorm(sqlite.Dialector{Conn: utcConnPool{conn}})
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("can't connect sqlite database (gorm) : %v", err), "", "DatabaseAdapter - NewSQLiteDatabaseAdapter")
+		log.Error().Msg(logErr)
+		return nil, fmt.Errorf("can't connect sqlite database (gorm) : %v", err)
+	}
+
+	return &DatabaseAdapter{
+		db:        db,
+		freshness: newFreshnessTracker(0),
+	}, nil
+}
+
+// utcConnPool converts every time argument to UTC. SQLite keeps timestamps as
+// text and compares them as strings, which only orders them correctly when
+// they all carry the same offset.
+type utcConnPool struct {
+	db *sql.DB
+}
+
+func (p utcConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.db.PrepareContext(ctx, query)
+}
+
+func (p utcConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.db.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (p utcConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.db.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (p utcConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.db.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+func (p utcConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &utcTx{tx}, nil
+}
+
+func (p utcConnPool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+// utcTx is used through a pointer, GORM checks committers for nil.
+type utcTx struct {
+	tx *sql.Tx
+}
+
+func (t *utcTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.tx.PrepareContext(ctx, query)
+}
+
+func (t *utcTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *utcTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *utcTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *utcTx) StmtContext(ctx context.Context, stmt *sql.Stmt) *sql.Stmt {
+	return t.tx.StmtContext(ctx, stmt)
+}
+
+func (t *utcTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *utcTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func utcArgs(args []interface{}) []interface{} {
+	for i, arg := range args {
+		if ts, ok := arg.(time.Time); ok {
+			args[i] = ts.UTC()
+		}
+	}
+
+	return args
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	dbmigration "github.com/fajaramaulana/go-grpc-micro-bank-server/db"
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port/porttest"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newMigratedSQLiteAdapter(t *testing.T) *DatabaseAdapter {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "bank.db")
+	conn, err := sql.Open("sqlite3", "file:"+path+"?_foreign_keys=1&_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	m, err := dbmigration.New(conn, "sqlite")
+	if err != nil {
+		t.Fatalf("dbmigration.New: %v", err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	adapter, err := NewSQLiteDatabaseAdapter(conn)
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabaseAdapter: %v", err)
+	}
+
+	return adapter
+}
+
+func TestSQLiteDatabaseAdapterContract(t *testing.T) {
+	adapter := newMigratedSQLiteAdapter(t)
+
+	porttest.RunBankDatabasePortTests(t, func(t *testing.T) porttest.Harness {
+		return porttest.Harness{
+			DB: adapter,
+			Seed: func(t *testing.T, accounts ...domainBank.BankAccountOrm) {
+				t.Helper()
+				if err := adapter.db.Create(&accounts).Error; err != nil {
+					t.Fatalf("seed accounts: %v", err)
+				}
+			},
+		}
+	})
+}
+
+func TestSQLiteAuditLogIsAppendOnly(t *testing.T) {
+	adapter := newMigratedSQLiteAdapter(t)
+
+	entry, err := adapter.AppendAuditEntry(context.Background(), domainAudit.AuditEntryOrm{OccurredAt: time.Now(), Principal: "system", Action: "Transfer", Outcome: domainAudit.OutcomeOK})
+	if err != nil {
+		t.Fatalf("AppendAuditEntry: %v", err)
+	}
+
+	if err := adapter.db.Model(&entry).Update("outcome", domainAudit.OutcomeFailed).Error; err == nil {
+		t.Error("updating an audit entry succeeded")
+	}
+	if err := adapter.db.Delete(&entry).Error; err == nil {
+		t.Error("deleting an audit entry succeeded")
+	}
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainStatement "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/statement"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+func (a *DatabaseAdapter) StatementBalances(ctx context.Context, account domainBank.BankAccountOrm, from time.Time, to time.Time) (float64, float64, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.StatementBalances")
+	defer span.End()
+
+	var balance domainBank.BalanceAccountOrm
+	var since struct {
+		From float64
+		To   float64
+	}
+
+	// one transaction so the balance and what was posted since agree
+	err := a.read(ctx, func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.First(&balance, "account_uuid = ?", account.AccountUuid).Error; err != nil {
+				return err
+			}
+
+			signed := "CASE WHEN transaction_type = ? THEN -amount ELSE amount END"
+			return tx.Model(&domainBank.BankTransactionOrm{}).
+				Select("COALESCE(SUM("+signed+"), 0) AS \"from\", COALESCE(SUM(CASE WHEN transaction_timestamp >= ? THEN "+signed+" ELSE 0 END), 0) AS \"to\"",
+					domainBank.TransactionTypeOut, to, domainBank.TransactionTypeOut).
+				Where("account_uuid = ? AND transaction_timestamp >= ?", account.AccountUuid, from).
+				Scan(&since).Error
+		})
+	}, accountKey(account.AccountNumber))
+
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read the statement balances of %v : %v\n", account.AccountNumber, err), "", "BankAdapter - StatementBalances")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return 0, 0, translateError(err)
+	}
+
+	return balance.CurrentBalance - since.From, balance.CurrentBalance - since.To, nil
+}
+
+func (a *DatabaseAdapter) ListTransactions(ctx context.Context, account domainBank.BankAccountOrm, from time.Time, to time.Time, after *domainStatement.Cursor,
+	limit int) ([]domainBank.BankTransactionOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListTransactions")
+	defer span.End()
+
+	var trxs []domainBank.BankTransactionOrm
+	err := a.read(ctx, func(db *gorm.DB) error {
+		query := db.Where("account_uuid = ? AND transaction_timestamp >= ? AND transaction_timestamp < ?", account.AccountUuid, from, to).
+			Order("transaction_timestamp, transaction_uuid")
+		if after != nil {
+			query = query.Where("(transaction_timestamp > ? OR (transaction_timestamp = ? AND transaction_uuid > ?))",
+				after.Timestamp, after.Timestamp, after.TransactionUuid)
+		}
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+
+		return query.Find(&trxs).Error
+	}, accountKey(account.AccountNumber))
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read the transactions of %v : %v\n", account.AccountNumber, err), "", "BankAdapter - ListTransactions")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return trxs, nil
+}
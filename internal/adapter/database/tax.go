@@ -0,0 +1,151 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (a *DatabaseAdapter) SaveTaxRule(ctx context.Context, rule domainTax.TaxRuleOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.SaveTaxRule")
+	defer span.End()
+
+	err := a.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}, {Name: "effective_from"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "min_balance", "payable_account_uuid", "created_at"}),
+	}).Create(&rule).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't save the tax rule of %v : %v\n", rule.Currency, err), "", "BankAdapter - SaveTaxRule")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) ListTaxRules(ctx context.Context) ([]domainTax.TaxRuleOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListTaxRules")
+	defer span.End()
+
+	var rules []domainTax.TaxRuleOrm
+
+	if err := a.db.WithContext(ctx).Order("currency, effective_from").Find(&rules).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read tax rules : %v\n", err), "", "BankAdapter - ListTaxRules")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (a *DatabaseAdapter) SetTaxExempt(ctx context.Context, accountUuid uuid.UUID, exempt bool, at time.Time) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.SetTaxExempt")
+	defer span.End()
+
+	res := a.db.WithContext(ctx).Model(&domainBank.BankAccountOrm{}).
+		Where("account_uuid = ? OR customer_id IN (SELECT customer_id FROM bank_accounts WHERE account_uuid = ? AND customer_id <> '')", accountUuid, accountUuid).
+		Updates(map[string]interface{}{"tax_exempt": exempt, "updated_at": at})
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't set the tax exemption of %v : %v\n", accountUuid, res.Error), "", "BankAdapter - SetTaxExempt")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainBank.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) ListWithholdings(ctx context.Context, accountUuid uuid.UUID, from time.Time, to time.Time) ([]domainTax.WithholdingOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListWithholdings")
+	defer span.End()
+
+	var withholdings []domainTax.WithholdingOrm
+
+	query := a.db.WithContext(ctx).Where("withheld_at >= ? AND withheld_at < ?", from, to).Order("withheld_at, withholding_uuid")
+	if accountUuid != uuid.Nil {
+		query = query.Where("account_uuid = ?", accountUuid)
+	}
+	if err := query.Find(&withholdings).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read withholdings : %v\n", err), "", "BankAdapter - ListWithholdings")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return withholdings, nil
+}
+
+// customerStanding is what withholding needs of the customer of
+// accountUuid: the balances of its accounts in the currency of accountUuid
+// added up, and whether it is exempt, which any of its accounts being exempt
+// makes it.
+func customerStanding(tx *gorm.DB, accountUuid uuid.UUID) (float64, bool, error) {
+	var account domainBank.BankAccountOrm
+	if err := tx.First(&account, "account_uuid = ?", accountUuid).Error; err != nil {
+		return 0, false, err
+	}
+	if account.CustomerId == "" {
+		return account.CurrentBalance, account.TaxExempt, nil
+	}
+
+	var standing struct {
+		Balance float64
+		Exempt  int
+	}
+	err := tx.Model(&domainBank.BankAccountOrm{}).
+		Select("COALESCE(SUM(current_balance), 0) AS balance, COALESCE(SUM(CASE WHEN tax_exempt THEN 1 ELSE 0 END), 0) AS exempt").
+		Where("customer_id = ? AND currency = ?", account.CustomerId, account.Currency).
+		Scan(&standing).Error
+
+	return math.Round(standing.Balance*100) / 100, standing.Exempt > 0, err
+}
+
+// withholdTax books the tax of interestTrx, posted to account whose customer
+// had balance before it, and records the withholding.
+func withholdTax(tx *gorm.DB, account domainBank.BankAccountOrm, w domainTax.Withholding, interestTrx domainBank.BankTransactionOrm, balance float64) (domainTax.WithholdingOrm, error) {
+	record := w.Record(interestTrx, balance)
+
+	if record.TaxAmount > 0 {
+		taxTrx, payableTrx := w.TaxTransaction, w.PayableTransaction
+		taxTrx.Amount, payableTrx.Amount = record.TaxAmount, record.TaxAmount
+
+		withheld, err := domainEvent.NewTransactionCreated(account, taxTrx)
+		if err != nil {
+			return record, err
+		}
+		payable, err := domainEvent.NewTransactionCreated(w.PayableAccount, payableTrx)
+		if err != nil {
+			return record, err
+		}
+
+		if err := tx.Create(&taxTrx).Error; err != nil {
+			return record, err
+		}
+		if err := tx.Create(&payableTrx).Error; err != nil {
+			return record, err
+		}
+		if err := updateBalance(tx, account.AccountUuid, -record.TaxAmount); err != nil {
+			return record, err
+		}
+		if err := updateBalance(tx, w.PayableAccount.AccountUuid, record.TaxAmount); err != nil {
+			return record, err
+		}
+		if err := insertEvents(tx, withheld, payable); err != nil {
+			return record, err
+		}
+	}
+
+	return record, tx.Create(&record).Error
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	tracingSpanKey      = "tracing:span"
+	tracingParentCtxKey = "tracing:parent_ctx"
+)
+
+// tracingPlugin opens a client span around every query GORM executes. The
+// span is a child of the span carried by the statement context, so callers
+// must use db.WithContext(ctx).
+type tracingPlugin struct{}
+
+func (tracingPlugin) Name() string {
+	return "tracing"
+}
+
+func (p tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	registrations := []struct {
+		name   string
+		before func(name string, fn func(*gorm.DB)) error
+		after  func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, r := range registrations {
+		if err := r.before("tracing:before_"+r.name, p.before("gorm."+r.name)); err != nil {
+			return err
+		}
+
+		if err := r.after("tracing:after_"+r.name, p.after); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tracingPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			db.Statement.Context = context.Background()
+		}
+
+		parent := db.Statement.Context
+		ctx, span := tracing.Start(parent, operation, trace.WithSpanKind(trace.SpanKindClient))
+
+		db.Statement.Context = ctx
+		db.InstanceSet(tracingParentCtxKey, parent)
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func (tracingPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	if parent, ok := db.InstanceGet(tracingParentCtxKey); ok {
+		if ctx, ok := parent.(context.Context); ok {
+			db.Statement.Context = ctx
+		}
+	}
+
+	span.SetAttributes(
+		semconv.DBSystemKey.String(db.Dialector.Name()),
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		semconv.DBCollectionName(db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
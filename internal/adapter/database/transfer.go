@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// transferDetails selects the transfers with the numbers of their accounts,
+// the columns of bank_transfers prefixed with t.
+func transferDetails(db *gorm.DB) *gorm.DB {
+	return db.Table("bank_transfers AS t").
+		Select("t.*, f.account_number AS from_account_number, r.account_number AS to_account_number").
+		Joins("JOIN bank_accounts AS f ON f.account_uuid = t.from_account_uuid").
+		Joins("JOIN bank_accounts AS r ON r.account_uuid = t.to_account_uuid")
+}
+
+func (a *DatabaseAdapter) GetTransferDetail(ctx context.Context, transferUuid uuid.UUID) (domainBank.TransferDetail, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.GetTransferDetail")
+	defer span.End()
+
+	var transfers []domainBank.TransferDetail
+
+	// read from the primary, a client looks a transfer up when it lost the
+	// answer of the call that made it
+	if err := transferDetails(a.db.WithContext(ctx)).Where("t.transfer_uuid = ?", transferUuid).Limit(1).Scan(&transfers).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read transfer %v : %v\n", transferUuid, err), "", "BankAdapter - GetTransferDetail")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainBank.TransferDetail{}, err
+	}
+	if len(transfers) == 0 {
+		return domainBank.TransferDetail{}, domainBank.ErrRecordNotFound
+	}
+
+	return transfers[0], nil
+}
+
+func (a *DatabaseAdapter) ListTransfers(ctx context.Context, filter domainBank.TransferFilter) ([]domainBank.TransferDetail, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListTransfers")
+	defer span.End()
+
+	var keys []string
+	if filter.AccountNumber != "" {
+		keys = append(keys, accountKey(filter.AccountNumber))
+	}
+
+	var transfers []domainBank.TransferDetail
+	err := a.read(ctx, func(db *gorm.DB) error {
+		return listTransfers(db, filter).Scan(&transfers).Error
+	}, keys...)
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read transfers : %v\n", err), "", "BankAdapter - ListTransfers")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// listTransfers is the query of the transfers filter selects on db.
+func listTransfers(db *gorm.DB, filter domainBank.TransferFilter) *gorm.DB {
+	query := transferDetails(db).Order("t.transfer_timestamp DESC, t.transfer_uuid DESC")
+	if filter.AccountUuid != uuid.Nil {
+		switch filter.Direction {
+		case domainBank.TransferDirectionOutgoing:
+			query = query.Where("t.from_account_uuid = ?", filter.AccountUuid)
+		case domainBank.TransferDirectionIncoming:
+			query = query.Where("t.to_account_uuid = ?", filter.AccountUuid)
+		default:
+			query = query.Where("(t.from_account_uuid = ? OR t.to_account_uuid = ?)", filter.AccountUuid, filter.AccountUuid)
+		}
+	}
+	if filter.Success != nil {
+		query = query.Where("t.transfer_success = ?", *filter.Success)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("t.transfer_timestamp >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("t.transfer_timestamp < ?", filter.To)
+	}
+	if filter.After != nil {
+		query = query.Where("(t.transfer_timestamp < ? OR (t.transfer_timestamp = ? AND t.transfer_uuid < ?))",
+			filter.After.Timestamp, filter.After.Timestamp, filter.After.TransferUuid)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	return query
+}
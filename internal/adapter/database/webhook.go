@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (a *DatabaseAdapter) CreateWebhookSubscription(ctx context.Context, sub domainWebhook.WebhookSubscriptionOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.CreateWebhookSubscription")
+	defer span.End()
+
+	if err := a.db.WithContext(ctx).Create(&sub).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't insert webhook subscription : %v\n", err), "", "BankAdapter - CreateWebhookSubscription")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) ListWebhookSubscriptions(ctx context.Context, accountUuid uuid.UUID, customerId string) ([]domainWebhook.WebhookSubscriptionOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListWebhookSubscriptions")
+	defer span.End()
+
+	query := a.db.WithContext(ctx).Order("created_at, subscription_uuid")
+	if accountUuid != uuid.Nil {
+		query = query.Where("account_uuid = ?", accountUuid)
+	}
+	if customerId != "" {
+		query = query.Where("customer_id = ?", customerId)
+	}
+
+	var subs []domainWebhook.WebhookSubscriptionOrm
+	if err := query.Find(&subs).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read webhook subscriptions : %v\n", err), "", "BankAdapter - ListWebhookSubscriptions")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return subs, nil
+}
+
+func (a *DatabaseAdapter) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.DeleteWebhookSubscription")
+	defer span.End()
+
+	// the deliveries and their attempts go with it, ON DELETE CASCADE
+	res := a.db.WithContext(ctx).Delete(&domainWebhook.WebhookSubscriptionOrm{}, "subscription_uuid = ?", id)
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't delete webhook subscription %v : %v\n", id, res.Error), "", "BankAdapter - DeleteWebhookSubscription")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return domainBank.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) WebhookSubscriptionsOfAccounts(ctx context.Context, accountUuids []uuid.UUID) ([]domainWebhook.WebhookSubscriptionOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.WebhookSubscriptionsOfAccounts")
+	defer span.End()
+
+	var subs []domainWebhook.WebhookSubscriptionOrm
+	if len(accountUuids) == 0 {
+		return subs, nil
+	}
+
+	customers := a.db.Model(&domainBank.BankAccountOrm{}).Select("customer_id").Where("account_uuid IN ? AND customer_id <> ''", accountUuids)
+	err := a.db.WithContext(ctx).
+		Where("account_uuid IN ? OR customer_id IN (?)", accountUuids, customers).
+		Order("created_at, subscription_uuid").
+		Find(&subs).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read webhook subscriptions : %v\n", err), "", "BankAdapter - WebhookSubscriptionsOfAccounts")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return subs, nil
+}
+
+func (a *DatabaseAdapter) EnqueueWebhookDeliveries(ctx context.Context, deliveries []domainWebhook.WebhookDeliveryOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.EnqueueWebhookDeliveries")
+	defer span.End()
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	err := a.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(&deliveries).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't insert webhook deliveries : %v\n", err), "", "BankAdapter - EnqueueWebhookDeliveries")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) ClaimWebhookDeliveries(ctx context.Context, now time.Time, until time.Time, limit int) ([]domainWebhook.WebhookDeliveryOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ClaimWebhookDeliveries")
+	defer span.End()
+
+	db := a.db.WithContext(ctx)
+
+	var due []domainWebhook.WebhookDeliveryOrm
+	err := db.Select("delivery_uuid").
+		Where("state = ? AND next_attempt_at <= ?", domainWebhook.StatePending, now).
+		Order("next_attempt_at, delivery_uuid").Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read due webhook deliveries : %v\n", err), "", "BankAdapter - ClaimWebhookDeliveries")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	// another worker may have read the same rows, the conditional update
+	// lets only one of them move the next attempt
+	claimed := make([]uuid.UUID, 0, len(due))
+	for _, d := range due {
+		res := db.Model(&domainWebhook.WebhookDeliveryOrm{}).
+			Where("delivery_uuid = ? AND state = ? AND next_attempt_at <= ?", d.DeliveryUuid, domainWebhook.StatePending, now).
+			Update("next_attempt_at", until)
+		if res.Error != nil {
+			logErr := util.LogError(fmt.Sprintf("Can't claim webhook delivery %v : %v\n", d.DeliveryUuid, res.Error), "", "BankAdapter - ClaimWebhookDeliveries")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return nil, res.Error
+		}
+		if res.RowsAffected == 1 {
+			claimed = append(claimed, d.DeliveryUuid)
+		}
+	}
+
+	deliveries := []domainWebhook.WebhookDeliveryOrm{}
+	if len(claimed) == 0 {
+		return deliveries, nil
+	}
+
+	if err := db.Preload("Subscription").Where("delivery_uuid IN ?", claimed).Order("created_at, delivery_uuid").Find(&deliveries).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read claimed webhook deliveries : %v\n", err), "", "BankAdapter - ClaimWebhookDeliveries")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (a *DatabaseAdapter) RecordWebhookAttempt(ctx context.Context, delivery domainWebhook.WebhookDeliveryOrm, attempt domainWebhook.WebhookAttemptOrm) error {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.RecordWebhookAttempt")
+	defer span.End()
+
+	tx := a.db.WithContext(ctx).Begin()
+
+	res := tx.Model(&domainWebhook.WebhookDeliveryOrm{}).Where("delivery_uuid = ?", delivery.DeliveryUuid).Updates(map[string]interface{}{
+		"state":           delivery.State,
+		"attempt_count":   delivery.AttemptCount,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"last_error":      delivery.LastError,
+		"updated_at":      delivery.UpdatedAt,
+	})
+	if res.Error != nil {
+		tx.Rollback()
+		logErr := util.LogError(fmt.Sprintf("Can't update webhook delivery %v : %v\n", delivery.DeliveryUuid, res.Error), "", "BankAdapter - RecordWebhookAttempt")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		// deleted with its subscription while it was sent
+		tx.Rollback()
+		return domainBank.ErrRecordNotFound
+	}
+
+	attempt.DeliveryUuid = delivery.DeliveryUuid
+	if err := tx.Create(&attempt).Error; err != nil {
+		tx.Rollback()
+		logErr := util.LogError(fmt.Sprintf("Can't insert webhook attempt : %v\n", err), "", "BankAdapter - RecordWebhookAttempt")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (a *DatabaseAdapter) ListWebhookDeliveries(ctx context.Context, filter domainWebhook.DeliveryFilter) ([]domainWebhook.WebhookDeliveryOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ListWebhookDeliveries")
+	defer span.End()
+
+	query := a.db.WithContext(ctx).Preload("Attempts", orderAttempts).Order("created_at DESC, delivery_uuid")
+	if filter.SubscriptionUuid != uuid.Nil {
+		query = query.Where("subscription_uuid = ?", filter.SubscriptionUuid)
+	}
+	if filter.State != "" {
+		query = query.Where("state = ?", filter.State)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var deliveries []domainWebhook.WebhookDeliveryOrm
+	if err := query.Find(&deliveries).Error; err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read webhook deliveries : %v\n", err), "", "BankAdapter - ListWebhookDeliveries")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (a *DatabaseAdapter) ScheduleWebhookDelivery(ctx context.Context, id uuid.UUID, at time.Time) (domainWebhook.WebhookDeliveryOrm, error) {
+	ctx, span := tracing.Start(ctx, "DatabaseAdapter.ScheduleWebhookDelivery")
+	defer span.End()
+
+	var delivery domainWebhook.WebhookDeliveryOrm
+
+	res := a.db.WithContext(ctx).Model(&domainWebhook.WebhookDeliveryOrm{}).Where("delivery_uuid = ?", id).Updates(map[string]interface{}{
+		"state":           domainWebhook.StatePending,
+		"attempt_count":   0,
+		"next_attempt_at": at,
+		"updated_at":      at,
+	})
+	if res.Error != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't schedule webhook delivery %v : %v\n", id, res.Error), "", "BankAdapter - ScheduleWebhookDelivery")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return delivery, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return delivery, domainBank.ErrRecordNotFound
+	}
+
+	if err := a.db.WithContext(ctx).Preload("Attempts", orderAttempts).First(&delivery, "delivery_uuid = ?", id).Error; err != nil {
+		return delivery, translateError(err)
+	}
+
+	return delivery, nil
+}
+
+func orderAttempts(db *gorm.DB) *gorm.DB {
+	return db.Order("attempt_id")
+}
@@ -0,0 +1,469 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accountAdminServer serves the AccountAdminService, registered by
+// GrpcAdapter.RegisterAccountAdmin.
+type accountAdminServer struct {
+	accountService  port.AccountAdminServicePort
+	interestService port.InterestServicePort
+	taxService      port.TaxServicePort
+	feeService      port.FeeServicePort
+	bank.UnimplementedAccountAdminServiceServer
+}
+
+func (s *accountAdminServer) SaveProduct(ctx context.Context, req *bank.Product) (*bank.Product, error) {
+	product, err := s.accountService.SaveProduct(ctx, domainBank.BankProductOrm{
+		ProductCode:              req.GetProductCode(),
+		Name:                     req.GetName(),
+		OverdraftLimit:           req.GetOverdraftLimit(),
+		MinimumBalance:           req.GetMinimumBalance(),
+		InterestDayCount:         fromEnumProto(dayCounts, req.GetInterestDayCount()),
+		InterestCapitalization:   fromEnumProto(capitalizationSchedules, req.GetInterestCapitalization()),
+		InterestAccrualFrequency: fromEnumProto(accrualFrequencies, req.GetInterestAccrualFrequency()),
+	})
+	if err != nil {
+		logErr := util.LogError("Error on SaveProduct : "+err.Error(), "", "Account Admin GRPC - SaveProduct")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		if errors.Is(err, domainBank.ErrLimitsInvalid) {
+			field := "overdraft_limit"
+			switch {
+			case req.GetProductCode() == "":
+				field = "product_code"
+			case req.GetName() == "":
+				field = "name"
+			case req.GetMinimumBalance() < 0:
+				field = "minimum_balance"
+			}
+			return nil, badRequest(err, field)
+		}
+		if errors.Is(err, domainInterest.ErrTermsInvalid) {
+			field := "interest_capitalization"
+			if _, ok := dayCounts[req.GetInterestDayCount()]; !ok {
+				field = "interest_day_count"
+			} else if _, ok := accrualFrequencies[req.GetInterestAccrualFrequency()]; !ok {
+				field = "interest_accrual_frequency"
+			}
+			return nil, badRequest(err, field)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toProductProto(product), nil
+}
+
+func (s *accountAdminServer) ListProducts(ctx context.Context, req *bank.ListProductsRequest) (*bank.ListProductsResponse, error) {
+	products, err := s.accountService.ListProducts(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &bank.ListProductsResponse{}
+	for _, p := range products {
+		res.Products = append(res.Products, toProductProto(p))
+	}
+
+	return res, nil
+}
+
+func (s *accountAdminServer) GetAccountLimits(ctx context.Context, req *bank.GetAccountLimitsRequest) (*bank.AccountLimits, error) {
+	standing, err := s.accountService.GetAccountStanding(ctx, req.GetAccountNumber())
+	if err != nil {
+		return nil, buildLimitsErrorStatusGrpc(err, req.GetAccountNumber())
+	}
+
+	return toAccountLimitsProto(standing), nil
+}
+
+func (s *accountAdminServer) SetAccountLimits(ctx context.Context, req *bank.SetAccountLimitsRequest) (*bank.AccountLimits, error) {
+	standing, err := s.accountService.SetAccountLimits(ctx, req.GetAccountNumber(), domainBank.AccountLimitsUpdate{
+		ProductCode:    req.GetProductCode(),
+		OverdraftLimit: req.OverdraftLimit,
+		MinimumBalance: req.MinimumBalance,
+	})
+	if err != nil {
+		logErr := util.LogError("Error on SetAccountLimits : "+err.Error(), "", "Account Admin GRPC - SetAccountLimits")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		if errors.Is(err, domainBank.ErrLimitsInvalid) {
+			field := "overdraft_limit"
+			if req.GetMinimumBalance() < 0 {
+				field = "minimum_balance"
+			}
+			return nil, badRequest(err, field)
+		}
+		return nil, buildLimitsErrorStatusGrpc(err, req.GetAccountNumber())
+	}
+
+	return toAccountLimitsProto(standing), nil
+}
+
+func (s *accountAdminServer) SetInterestRates(ctx context.Context, req *bank.InterestRates) (*bank.InterestRates, error) {
+	version := domainInterest.RateVersion{
+		ProductCode:   req.GetProductCode(),
+		EffectiveFrom: util.DateToTime(req.GetEffectiveFrom()),
+	}
+	for _, t := range req.GetTiers() {
+		version.Tiers = append(version.Tiers, domainInterest.InterestRateOrm{MinBalance: t.GetMinBalance(), AnnualRate: t.GetAnnualRate()})
+	}
+
+	saved, err := s.interestService.SetInterestRates(ctx, version)
+	if err != nil {
+		logErr := util.LogError("Error on SetInterestRates : "+err.Error(), "", "Account Admin GRPC - SetInterestRates")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		switch {
+		case errors.Is(err, domainInterest.ErrRatesInvalid):
+			field := "tiers"
+			switch {
+			case req.GetProductCode() == "":
+				field = "product_code"
+			case req.GetEffectiveFrom() == nil:
+				field = "effective_from"
+			}
+			return nil, badRequest(err, field)
+		case errors.Is(err, domainBank.ErrRecordNotFound):
+			return nil, resourceNotFound("product", req.GetProductCode())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toInterestRatesProto(saved), nil
+}
+
+func (s *accountAdminServer) ListInterestRates(ctx context.Context, req *bank.ListInterestRatesRequest) (*bank.ListInterestRatesResponse, error) {
+	versions, err := s.interestService.ListInterestRates(ctx, req.GetProductCode())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &bank.ListInterestRatesResponse{}
+	for _, v := range versions {
+		res.Rates = append(res.Rates, toInterestRatesProto(v))
+	}
+
+	return res, nil
+}
+
+func (s *accountAdminServer) ListInterestAccruals(ctx context.Context, req *bank.ListInterestAccrualsRequest) (*bank.ListInterestAccrualsResponse, error) {
+	accruals, err := s.interestService.ListInterestAccruals(ctx, req.GetAccountNumber(),
+		util.DateToTime(req.GetFrom()), util.DateToTime(req.GetTo()))
+	if err != nil {
+		return nil, buildLimitsErrorStatusGrpc(err, req.GetAccountNumber())
+	}
+
+	res := &bank.ListInterestAccrualsResponse{}
+	for _, a := range accruals {
+		accrual := &bank.InterestAccrual{
+			AccrualDate: util.ToDate(a.AccrualDate),
+			ProductCode: a.ProductCode,
+			Balance:     a.Balance,
+			AnnualRate:  a.AnnualRate,
+			Amount:      a.Amount,
+		}
+		if a.CapitalizedAt != nil {
+			accrual.Capitalized = true
+			accrual.CapitalizedAt = util.ToDatetime(*a.CapitalizedAt)
+		}
+		if a.TransactionUuid != nil {
+			accrual.TransactionUuid = a.TransactionUuid.String()
+		}
+		res.Accruals = append(res.Accruals, accrual)
+	}
+
+	return res, nil
+}
+
+func (s *accountAdminServer) SetTaxRule(ctx context.Context, req *bank.TaxRule) (*bank.TaxRule, error) {
+	detail, err := s.taxService.SetTaxRule(ctx, domainTax.TaxRuleOrm{
+		Currency:      req.GetCurrency(),
+		EffectiveFrom: util.DateToTime(req.GetEffectiveFrom()),
+		Rate:          req.GetRate(),
+		MinBalance:    req.GetMinBalance(),
+	}, req.GetPayableAccountNumber())
+	if err != nil {
+		logErr := util.LogError("Error on SetTaxRule : "+err.Error(), "", "Account Admin GRPC - SetTaxRule")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		switch {
+		case errors.Is(err, domainTax.ErrRuleInvalid):
+			field := "payable_account_number"
+			switch {
+			case req.GetCurrency() == "":
+				field = "currency"
+			case req.GetEffectiveFrom() == nil:
+				field = "effective_from"
+			case req.GetRate() < 0 || req.GetRate() > 100:
+				field = "rate"
+			case req.GetMinBalance() < 0:
+				field = "min_balance"
+			}
+			return nil, badRequest(err, field)
+		case errors.Is(err, domainBank.ErrRecordNotFound):
+			return nil, resourceNotFound("account", req.GetPayableAccountNumber())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toTaxRuleProto(detail), nil
+}
+
+func (s *accountAdminServer) ListTaxRules(ctx context.Context, req *bank.ListTaxRulesRequest) (*bank.ListTaxRulesResponse, error) {
+	details, err := s.taxService.ListTaxRules(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &bank.ListTaxRulesResponse{}
+	for _, d := range details {
+		res.Rules = append(res.Rules, toTaxRuleProto(d))
+	}
+
+	return res, nil
+}
+
+func (s *accountAdminServer) SetTaxExemption(ctx context.Context, req *bank.SetTaxExemptionRequest) (*bank.TaxExemption, error) {
+	account, err := s.taxService.SetTaxExemption(ctx, req.GetAccountNumber(), req.GetExempt())
+	if err != nil {
+		logErr := util.LogError("Error on SetTaxExemption : "+err.Error(), "", "Account Admin GRPC - SetTaxExemption")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, buildLimitsErrorStatusGrpc(err, req.GetAccountNumber())
+	}
+
+	return &bank.TaxExemption{AccountNumber: account.AccountNumber, Exempt: account.TaxExempt}, nil
+}
+
+func (s *accountAdminServer) SetFeeSchedule(ctx context.Context, req *bank.FeeSchedule) (*bank.FeeSchedule, error) {
+	schedule := domainFee.Schedule{FeeScheduleOrm: domainFee.FeeScheduleOrm{
+		Currency:        req.GetCurrency(),
+		Channel:         req.GetChannel(),
+		CustomerSegment: req.GetCustomerSegment(),
+		MinFee:          req.GetMinFee(),
+		MaxFee:          req.GetMaxFee(),
+		FreePerMonth:    int(req.GetFreeTransfersPerMonth()),
+	}}
+	for _, t := range req.GetTiers() {
+		schedule.Tiers = append(schedule.Tiers, domainFee.FeeTierOrm{MinAmount: t.GetMinAmount(), FlatAmount: t.GetFlatAmount(), Rate: t.GetRate()})
+	}
+
+	detail, err := s.feeService.SetFeeSchedule(ctx, schedule, req.GetFeeAccountNumber())
+	if err != nil {
+		logErr := util.LogError("Error on SetFeeSchedule : "+err.Error(), "", "Account Admin GRPC - SetFeeSchedule")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		switch {
+		case errors.Is(err, domainFee.ErrScheduleInvalid):
+			field := "tiers"
+			switch {
+			case req.GetCurrency() == "":
+				field = "currency"
+			case req.GetMinFee() < 0:
+				field = "min_fee"
+			case req.GetMaxFee() < 0 || (req.GetMaxFee() > 0 && req.GetMaxFee() < req.GetMinFee()):
+				field = "max_fee"
+			case req.GetFreeTransfersPerMonth() < 0:
+				field = "free_transfers_per_month"
+			}
+			return nil, badRequest(err, field)
+		case errors.Is(err, domainBank.ErrRecordNotFound):
+			return nil, resourceNotFound("account", req.GetFeeAccountNumber())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toFeeScheduleProto(detail), nil
+}
+
+func (s *accountAdminServer) ListFeeSchedules(ctx context.Context, req *bank.ListFeeSchedulesRequest) (*bank.ListFeeSchedulesResponse, error) {
+	details, err := s.feeService.ListFeeSchedules(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &bank.ListFeeSchedulesResponse{}
+	for _, d := range details {
+		res.Schedules = append(res.Schedules, toFeeScheduleProto(d))
+	}
+
+	return res, nil
+}
+
+func (s *accountAdminServer) SetCustomerSegment(ctx context.Context, req *bank.SetCustomerSegmentRequest) (*bank.CustomerSegment, error) {
+	account, err := s.feeService.SetCustomerSegment(ctx, req.GetAccountNumber(), req.GetCustomerSegment())
+	if err != nil {
+		logErr := util.LogError("Error on SetCustomerSegment : "+err.Error(), "", "Account Admin GRPC - SetCustomerSegment")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, buildLimitsErrorStatusGrpc(err, req.GetAccountNumber())
+	}
+
+	return &bank.CustomerSegment{AccountNumber: account.AccountNumber, CustomerSegment: account.CustomerSegment}, nil
+}
+
+func (s *accountAdminServer) SetAccountCustomer(ctx context.Context, req *bank.SetAccountCustomerRequest) (*bank.AccountCustomer, error) {
+	account, err := s.accountService.SetAccountCustomer(ctx, req.GetAccountNumber(), req.GetCustomerId())
+	if err != nil {
+		logErr := util.LogError("Error on SetAccountCustomer : "+err.Error(), "", "Account Admin GRPC - SetAccountCustomer")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		if errors.Is(err, domainBank.ErrCustomerIdInvalid) {
+			return nil, badRequest(err, "customer_id")
+		}
+		return nil, buildLimitsErrorStatusGrpc(err, req.GetAccountNumber())
+	}
+
+	return &bank.AccountCustomer{AccountNumber: account.AccountNumber, CustomerId: account.CustomerId}, nil
+}
+
+func buildLimitsErrorStatusGrpc(err error, accountNum string) error {
+	if errors.Is(err, domainBank.ErrRecordNotFound) {
+		return resourceNotFound("account", accountNum)
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+// insufficientFundsFailure tells which limit of its account a debit breaks
+// and how much the account could be debited instead.
+func insufficientFundsFailure(breach *domainBank.InsufficientFundsError) *errdetails.PreconditionFailure {
+	return &errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{
+				Type:        breach.Rule,
+				Subject:     "account " + breach.AccountNumber,
+				Description: fmt.Sprintf("%.2f requested, %.2f available", breach.Requested, breach.Available),
+			},
+		},
+	}
+}
+
+// dayCounts, accrualFrequencies and capitalizationSchedules map the enums of the proto to the
+// terms of the interest domain, unspecified to the default.
+var dayCounts = map[bank.DayCountConvention]string{
+	bank.DayCountConvention_DAY_COUNT_CONVENTION_UNSPECIFIED: "",
+	bank.DayCountConvention_ACT_365:                          domainInterest.DayCountAct365,
+	bank.DayCountConvention_ACT_360:                          domainInterest.DayCountAct360,
+	bank.DayCountConvention_ACT_ACT:                          domainInterest.DayCountActAct,
+}
+
+var accrualFrequencies = map[bank.AccrualFrequency]string{
+	bank.AccrualFrequency_ACCRUAL_FREQUENCY_UNSPECIFIED: "",
+	bank.AccrualFrequency_ACCRUAL_DAILY:                 domainInterest.AccrueDaily,
+	bank.AccrualFrequency_ACCRUAL_MONTHLY:               domainInterest.AccrueMonthly,
+}
+
+var capitalizationSchedules = map[bank.CapitalizationSchedule]string{
+	bank.CapitalizationSchedule_CAPITALIZATION_SCHEDULE_UNSPECIFIED: "",
+	bank.CapitalizationSchedule_MONTHLY:                             domainInterest.CapitalizeMonthly,
+	bank.CapitalizationSchedule_QUARTERLY:                           domainInterest.CapitalizeQuarterly,
+	bank.CapitalizationSchedule_YEARLY:                              domainInterest.CapitalizeYearly,
+}
+
+// fromEnumProto is the term value stands for, its number for a value the
+// server doesn't know, which the service rejects.
+func fromEnumProto[E interface {
+	comparable
+	String() string
+}](terms map[E]string, value E) string {
+	if term, ok := terms[value]; ok {
+		return term
+	}
+
+	return value.String()
+}
+
+func toProductProto(p domainBank.BankProductOrm) *bank.Product {
+	res := &bank.Product{
+		ProductCode:    p.ProductCode,
+		Name:           p.Name,
+		OverdraftLimit: p.OverdraftLimit,
+		MinimumBalance: p.MinimumBalance,
+	}
+	for value, dayCount := range dayCounts {
+		if dayCount != "" && dayCount == p.InterestDayCount {
+			res.InterestDayCount = value
+		}
+	}
+	for value, frequency := range accrualFrequencies {
+		if frequency != "" && frequency == p.InterestAccrualFrequency {
+			res.InterestAccrualFrequency = value
+		}
+	}
+	for value, schedule := range capitalizationSchedules {
+		if schedule != "" && schedule == p.InterestCapitalization {
+			res.InterestCapitalization = value
+		}
+	}
+
+	return res
+}
+
+func toInterestRatesProto(v domainInterest.RateVersion) *bank.InterestRates {
+	res := &bank.InterestRates{
+		ProductCode:   v.ProductCode,
+		EffectiveFrom: util.ToDate(v.EffectiveFrom),
+	}
+	for _, t := range v.Tiers {
+		res.Tiers = append(res.Tiers, &bank.InterestRateTier{MinBalance: t.MinBalance, AnnualRate: t.AnnualRate})
+	}
+
+	return res
+}
+
+func toTaxRuleProto(d domainTax.RuleDetail) *bank.TaxRule {
+	return &bank.TaxRule{
+		Currency:             d.Rule.Currency,
+		EffectiveFrom:        util.ToDate(d.Rule.EffectiveFrom),
+		Rate:                 d.Rule.Rate,
+		MinBalance:           d.Rule.MinBalance,
+		PayableAccountNumber: d.PayableAccount.AccountNumber,
+	}
+}
+
+func toFeeScheduleProto(d domainFee.ScheduleDetail) *bank.FeeSchedule {
+	res := &bank.FeeSchedule{
+		ScheduleId:            d.Schedule.ScheduleUuid.String(),
+		Currency:              d.Schedule.Currency,
+		Channel:               d.Schedule.Channel,
+		CustomerSegment:       d.Schedule.CustomerSegment,
+		MinFee:                d.Schedule.MinFee,
+		MaxFee:                d.Schedule.MaxFee,
+		FreeTransfersPerMonth: int32(d.Schedule.FreePerMonth),
+		FeeAccountNumber:      d.FeeAccount.AccountNumber,
+	}
+	for _, t := range d.Schedule.Tiers {
+		res.Tiers = append(res.Tiers, &bank.FeeTier{MinAmount: t.MinAmount, FlatAmount: t.FlatAmount, Rate: t.Rate})
+	}
+
+	return res
+}
+
+func toAccountLimitsProto(s domainBank.AccountStanding) *bank.AccountLimits {
+	return &bank.AccountLimits{
+		AccountNumber:            s.Account.AccountNumber,
+		ProductCode:              s.Limits.ProductCode,
+		OverdraftLimit:           s.Limits.OverdraftLimit,
+		MinimumBalance:           s.Limits.MinimumBalance,
+		OverdraftLimitOverridden: s.Account.OverdraftLimit != nil,
+		MinimumBalanceOverridden: s.Account.MinimumBalance != nil,
+		OverdraftUsed:            s.Account.OverdraftUsed,
+		AvailableAmount:          s.Available,
+		SpendableAmount:          s.Spendable,
+	}
+}
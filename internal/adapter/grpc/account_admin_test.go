@@ -0,0 +1,105 @@
+package grpc_test
+
+import (
+	"testing"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOverdraftLimit(t *testing.T) {
+	h := newHarness(t)
+
+	limits, err := h.accounts.SetAccountLimits(h.ctx(), &bank.SetAccountLimitsRequest{AccountNumber: kate, OverdraftLimit: proto.Float64(5)})
+	if err != nil {
+		t.Fatalf("SetAccountLimits: %v", err)
+	}
+	if limits.ProductCode != "STANDARD" || limits.OverdraftLimit != 5 || !limits.OverdraftLimitOverridden || limits.SpendableAmount != 15 {
+		t.Errorf("limits = %v, want an overdraft of 5 and 15 to spend", limits)
+	}
+
+	h.transfer(kate, riri, 14)
+	if h.balance(kate) != -4 {
+		t.Errorf("balance = %v, want -4", h.balance(kate))
+	}
+
+	// past the overdraft, the transfer fails the way it did without one
+	stream, err := h.client.TransferMultiple(h.ctx())
+	if err != nil {
+		t.Fatalf("TransferMultiple: %v", err)
+	}
+	if err := stream.Send(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 2}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	_, err = stream.Recv()
+	errorDetail[*errdetails.ErrorInfo](t, err, codes.InvalidArgument)
+	failure := errorDetail[*errdetails.PreconditionFailure](t, err, codes.InvalidArgument)
+	if v := failure.Violations[0]; v.Type != "OVERDRAFT_LIMIT" || v.Subject != "account "+kate || v.Description != "2.00 requested, 1.00 available" {
+		t.Errorf("violation = %v", v)
+	}
+
+	limits, err = h.accounts.GetAccountLimits(h.ctx(), &bank.GetAccountLimitsRequest{AccountNumber: kate})
+	if err != nil {
+		t.Fatalf("GetAccountLimits: %v", err)
+	}
+	if limits.OverdraftUsed != 4 || limits.AvailableAmount != -4 || limits.SpendableAmount != 1 {
+		t.Errorf("limits = %v, want 4 of the overdraft used and 1 to spend", limits)
+	}
+}
+
+func TestProductMinimumBalance(t *testing.T) {
+	h := newHarness(t)
+
+	product, err := h.accounts.SaveProduct(h.ctx(), &bank.Product{ProductCode: "saver", Name: "Saver", MinimumBalance: 8})
+	if err != nil {
+		t.Fatalf("SaveProduct: %v", err)
+	}
+	if product.ProductCode != "SAVER" {
+		t.Errorf("product code = %q, want SAVER", product.ProductCode)
+	}
+	products, err := h.accounts.ListProducts(h.ctx(), &bank.ListProductsRequest{})
+	if err != nil || len(products.Products) != 1 || products.Products[0].MinimumBalance != 8 {
+		t.Errorf("ListProducts = %v, %v", products, err)
+	}
+
+	limits, err := h.accounts.SetAccountLimits(h.ctx(), &bank.SetAccountLimitsRequest{AccountNumber: riri, ProductCode: "SAVER"})
+	if err != nil {
+		t.Fatalf("SetAccountLimits: %v", err)
+	}
+	if limits.MinimumBalance != 8 || limits.MinimumBalanceOverridden || limits.SpendableAmount != 2 {
+		t.Errorf("limits = %v, want the minimum balance of the product", limits)
+	}
+
+	_, err = h.client.CreateHold(h.ctx(), &bank.CreateHoldRequest{AccountNumber: riri, Amount: 3})
+	failure := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition)
+	if v := failure.Violations[0]; v.Type != "MINIMUM_BALANCE" {
+		t.Errorf("violation = %v, want MINIMUM_BALANCE", v)
+	}
+	h.createHold(riri, 2)
+
+	// the account's own limit wins over the product
+	limits, err = h.accounts.SetAccountLimits(h.ctx(), &bank.SetAccountLimitsRequest{AccountNumber: riri, MinimumBalance: proto.Float64(0)})
+	if err != nil {
+		t.Fatalf("SetAccountLimits: %v", err)
+	}
+	if limits.ProductCode != "SAVER" || limits.MinimumBalance != 0 || !limits.MinimumBalanceOverridden || limits.SpendableAmount != 8 {
+		t.Errorf("limits = %v, want no minimum balance and 8 to spend", limits)
+	}
+}
+
+func TestAccountLimitsErrors(t *testing.T) {
+	h := newHarness(t)
+
+	_, err := h.accounts.SetAccountLimits(h.ctx(), &bank.SetAccountLimitsRequest{AccountNumber: kate, OverdraftLimit: proto.Float64(-1)})
+	if v := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument).FieldViolations[0]; v.Field != "overdraft_limit" {
+		t.Errorf("field = %v, want overdraft_limit", v.Field)
+	}
+	_, err = h.accounts.SaveProduct(h.ctx(), &bank.Product{ProductCode: "GOLD"})
+	if v := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument).FieldViolations[0]; v.Field != "name" {
+		t.Errorf("field = %v, want name", v.Field)
+	}
+	_, err = h.accounts.GetAccountLimits(h.ctx(), &bank.GetAccountLimitsRequest{AccountNumber: ghost})
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+}
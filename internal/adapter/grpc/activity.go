@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// SubscribeAccountActivity streams every transaction posted on the account,
+// transfer legs included, with the balance after it. A client that
+// reconnects passes the event_id of the last activity it received and gets
+// the ones it missed first. The response headers are sent once the
+// subscription is in place, so nothing posted after they arrive is missed.
+func (a *GrpcAdapter) SubscribeAccountActivity(req *bank.AccountActivityRequest, stream grpc.ServerStreamingServer[bank.AccountActivity]) error {
+	ctx := stream.Context()
+
+	sub, err := a.bankService.SubscribeAccountActivity(ctx, req.GetAccountNumber(), req.GetLastEventId())
+	if err != nil {
+		return buildActivityErrorStatusGrpc(err, req)
+	}
+	defer sub.Close()
+
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for _, activity := range sub.Replay() {
+		if err := stream.Send(toAccountActivityProto(activity)); err != nil {
+			logErr := util.LogError("Error while sending activity to client : "+err.Error(), "", "Bank Adapter GRPC - SubscribeAccountActivity - stream.Send()")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Ctx(ctx).Msg("Client cancelled activity stream")
+			return nil
+		case <-a.shutdown:
+			log.Info().Ctx(ctx).Msg("Server shutting down, closing activity stream")
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case activity, ok := <-sub.Activities():
+			if !ok {
+				s := status.New(codes.ResourceExhausted, "subscriber fell behind, resubscribe with the last event id")
+				s, _ = s.WithDetails(&errdetails.ErrorInfo{
+					Domain: "my-bank-website.com",
+					Reason: "SUBSCRIBER_TOO_SLOW",
+					Metadata: map[string]string{
+						"account_number": req.GetAccountNumber(),
+					},
+				})
+
+				return s.Err()
+			}
+
+			if err := stream.Send(toAccountActivityProto(activity)); err != nil {
+				logErr := util.LogError("Error while sending activity to client : "+err.Error(), "", "Bank Adapter GRPC - SubscribeAccountActivity - stream.Send()")
+				log.Error().Ctx(ctx).Msg(logErr)
+				return err
+			}
+		}
+	}
+}
+
+func toAccountActivityProto(activity domainBank.AccountActivity) *bank.AccountActivity {
+	res := &bank.AccountActivity{
+		EventId:                   activity.EventID,
+		AccountNumber:             activity.AccountNumber,
+		Type:                      toTransactionTypeProto(activity.TransactionType),
+		Amount:                    activity.Amount,
+		Balance:                   activity.Balance,
+		TransactionId:             activity.TransactionUuid.String(),
+		CounterpartyAccountNumber: activity.CounterpartyAccountNumber,
+		Notes:                     activity.Notes,
+		Timestamp:                 util.ToDatetime(activity.Timestamp),
+	}
+
+	if activity.TransferUuid != uuid.Nil {
+		res.TransferId = activity.TransferUuid.String()
+	}
+
+	return res
+}
+
+func toTransactionTypeProto(trxType string) bank.TransactionType {
+	switch trxType {
+	case domainBank.TransactionTypeIn:
+		return bank.TransactionType_TRANSACTION_TYPE_IN
+	case domainBank.TransactionTypeOut:
+		return bank.TransactionType_TRANSACTION_TYPE_OUT
+	default:
+		return bank.TransactionType_TRANSACTION_TYPE_UNSPECIFIED
+	}
+}
+
+func buildActivityErrorStatusGrpc(err error, req *bank.AccountActivityRequest) error {
+	switch {
+	case errors.Is(err, domainBank.ErrRecordNotFound):
+		s := status.New(codes.NotFound, fmt.Sprintf("account %v not found", req.GetAccountNumber()))
+		s, _ = s.WithDetails(&errdetails.ResourceInfo{
+			ResourceType: "account",
+			ResourceName: req.GetAccountNumber(),
+			Description:  "account not found",
+		})
+
+		return s.Err()
+	case errors.Is(err, domainBank.ErrActivityCursorExpired):
+		s := status.New(codes.OutOfRange, err.Error())
+		s, _ = s.WithDetails(&errdetails.ErrorInfo{
+			Domain: "my-bank-website.com",
+			Reason: "EVENT_ID_EXPIRED",
+			Metadata: map[string]string{
+				"account_number": req.GetAccountNumber(),
+				"last_event_id":  req.GetLastEventId(),
+			},
+		})
+
+		return s.Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,134 @@
+package grpc_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestSubscribeAccountActivity(t *testing.T) {
+	h := newHarness(t)
+
+	subCtx, cancel := context.WithCancel(h.ctx())
+	sub := h.subscribe(subCtx, kate, "")
+
+	summary, err := h.client.SummarizeTransactions(h.ctx())
+	if err != nil {
+		t.Fatalf("SummarizeTransactions: %v", err)
+	}
+	if err := summary.Send(&bank.Transaction{AccountNumber: kate, Type: bank.TransactionType_TRANSACTION_TYPE_IN, Amount: 5, Notes: "salary"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if _, err := summary.CloseAndRecv(); err != nil {
+		t.Fatalf("CloseAndRecv: %v", err)
+	}
+
+	transfer, err := h.client.TransferMultiple(h.ctx())
+	if err != nil {
+		t.Fatalf("TransferMultiple: %v", err)
+	}
+	if err := transfer.Send(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 4}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if _, err := transfer.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	transfer.CloseSend()
+
+	deposit := recvActivity(t, sub)
+	if deposit.AccountNumber != kate || deposit.Type != bank.TransactionType_TRANSACTION_TYPE_IN || deposit.Amount != 5 || deposit.Balance != 15 {
+		t.Errorf("first activity = %v, want IN 5 with balance 15", deposit)
+	}
+	if deposit.TransferId != "" || deposit.Notes != "salary" || deposit.TransactionId == "" {
+		t.Errorf("first activity = %v, want a plain transaction", deposit)
+	}
+
+	debit := recvActivity(t, sub)
+	if debit.Type != bank.TransactionType_TRANSACTION_TYPE_OUT || debit.Amount != 4 || debit.Balance != 11 {
+		t.Errorf("second activity = %v, want OUT 4 with balance 11", debit)
+	}
+	if debit.TransferId == "" || debit.CounterpartyAccountNumber != riri {
+		t.Errorf("second activity = %v, want a transfer leg to %v", debit, riri)
+	}
+	if debit.Timestamp.GetYear() != 2024 || debit.Timestamp.GetHours() != 10 {
+		t.Errorf("timestamp = %v, want the fake clock", debit.Timestamp)
+	}
+
+	// reconnecting after the first activity replays the second one
+	cancel()
+	resumed := h.subscribe(h.ctx(), kate, deposit.EventId)
+	if got := recvActivity(t, resumed); got.EventId != debit.EventId {
+		t.Errorf("replayed %v, want %v", got.EventId, debit.EventId)
+	}
+
+	// the receiving leg goes to the subscribers of the other account
+	credit := recvActivity(t, h.subscribe(h.ctx(), riri, deposit.EventId))
+	if credit.Type != bank.TransactionType_TRANSACTION_TYPE_IN || credit.Balance != 14 || credit.TransferId != debit.TransferId || credit.CounterpartyAccountNumber != kate {
+		t.Errorf("receiver activity = %v, want IN 4 with balance 14 from %v", credit, kate)
+	}
+}
+
+func TestSubscribeAccountActivityErrors(t *testing.T) {
+	h := newHarness(t)
+
+	t.Run("unknown account", func(t *testing.T) {
+		stream, err := h.client.SubscribeAccountActivity(h.ctx(), &bank.AccountActivityRequest{AccountNumber: ghost})
+		if err != nil {
+			t.Fatalf("SubscribeAccountActivity: %v", err)
+		}
+		_, err = stream.Recv()
+
+		info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+		if info.ResourceType != "account" || info.ResourceName != ghost {
+			t.Errorf("resource info = %v", info)
+		}
+	})
+
+	t.Run("expired event id", func(t *testing.T) {
+		// issued by an earlier server process
+		stream, err := h.client.SubscribeAccountActivity(h.ctx(), &bank.AccountActivityRequest{AccountNumber: kate, LastEventId: "0badcafe-1"})
+		if err != nil {
+			t.Fatalf("SubscribeAccountActivity: %v", err)
+		}
+		_, err = stream.Recv()
+
+		info := errorDetail[*errdetails.ErrorInfo](t, err, codes.OutOfRange)
+		if info.Reason != "EVENT_ID_EXPIRED" || info.Metadata["last_event_id"] != "0badcafe-1" {
+			t.Errorf("error info = %v", info)
+		}
+	})
+}
+
+// subscribe opens an activity stream and waits until it is live.
+func (h *harness) subscribe(ctx context.Context, account, lastEventID string) grpc.ServerStreamingClient[bank.AccountActivity] {
+	h.t.Helper()
+
+	stream, err := h.client.SubscribeAccountActivity(ctx, &bank.AccountActivityRequest{AccountNumber: account, LastEventId: lastEventID})
+	if err != nil {
+		h.t.Fatalf("SubscribeAccountActivity: %v", err)
+	}
+	if _, err := stream.Header(); err != nil {
+		h.t.Fatalf("Header: %v", err)
+	}
+
+	return stream
+}
+
+func recvActivity(t *testing.T, stream grpc.ServerStreamingClient[bank.AccountActivity]) *bank.AccountActivity {
+	t.Helper()
+
+	activity, err := stream.Recv()
+	if err == io.EOF {
+		t.Fatal("activity stream ended")
+	}
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+
+	return activity
+}
@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+const anonymousPrincipal = "anonymous"
+
+// auditUnaryInterceptor puts the audit actor of the call into its context.
+func auditUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	actor := actorOf(ctx, info.FullMethod)
+	if msg, ok := req.(proto.Message); ok {
+		actor.Fingerprint = fingerprint(msg)
+	}
+
+	return handler(domainAudit.WithActor(ctx, actor), req)
+}
+
+// auditStreamInterceptor puts the audit actor of the stream into its
+// context. Every message is an operation of its own, so the handlers set the
+// fingerprint per message.
+func auditStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := domainAudit.WithActor(ss.Context(), actorOf(ss.Context(), info.FullMethod))
+
+	return handler(srv, &actorServerStream{ServerStream: ss, ctx: ctx})
+}
+
+type actorServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// actorOf identifies the caller by the common name of its verified client
+// certificate; without mutual TLS every caller is anonymous.
+func actorOf(ctx context.Context, method string) domainAudit.Actor {
+	actor := domainAudit.Actor{Principal: anonymousPrincipal, Method: method}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return actor
+	}
+	if p.Addr != nil {
+		actor.RemoteAddr = p.Addr.String()
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 && len(tlsInfo.State.VerifiedChains[0]) > 0 {
+		actor.Principal = "cert:" + tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	}
+
+	return actor
+}
+
+// fingerprint is the hex SHA-256 of the deterministic encoding of msg.
+func fingerprint(msg proto.Message) string {
+	content, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,63 @@
+package grpc_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"google.golang.org/protobuf/proto"
+)
+
+func fingerprintOf(t *testing.T, msg proto.Message) string {
+	t.Helper()
+
+	content, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAuditActorOfCalls(t *testing.T) {
+	h := newHarness(t)
+
+	create := &bank.CreateWebhookSubscriptionRequest{AccountNumber: kate, Url: "https://example.com/hook", EventTypes: []string{domainEvent.TypeTransactionCreated}}
+	if _, err := h.admin.CreateWebhookSubscription(h.ctx(), create); err != nil {
+		t.Fatalf("CreateWebhookSubscription: %v", err)
+	}
+
+	stream, err := h.client.TransferMultiple(h.ctx())
+	if err != nil {
+		t.Fatalf("TransferMultiple: %v", err)
+	}
+	transfer := &bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 1}
+	if err := stream.Send(transfer); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	stream.CloseSend()
+
+	entries, err := h.store.AuditEntries(context.Background(), 0, 10)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("AuditEntries = %+v, %v; want 2 entries", entries, err)
+	}
+
+	for i, want := range []domainAudit.AuditEntryOrm{
+		{Action: "CreateWebhookSubscription", Method: "/bank.WebhookAdminService/CreateWebhookSubscription", Fingerprint: fingerprintOf(t, create)},
+		{Action: "Transfer", Method: "/bank.BankService/TransferMultiple", Fingerprint: fingerprintOf(t, transfer)},
+	} {
+		got := entries[i]
+		if got.Action != want.Action || got.Method != want.Method || got.Fingerprint != want.Fingerprint ||
+			got.Principal != "anonymous" || got.RemoteAddr == "" || got.Outcome != domainAudit.OutcomeOK {
+			t.Errorf("entry %d = %+v, want %+v by an anonymous caller", i, got, want)
+		}
+	}
+}
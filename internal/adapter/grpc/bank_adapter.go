@@ -8,13 +8,14 @@ import (
 	"time"
 
 	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
 	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/genproto/googleapis/type/date"
-	"google.golang.org/genproto/googleapis/type/datetime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -27,14 +28,14 @@ import (
 // If an error occurs during the retrieval, it returns nil and the error.
 // Otherwise, it constructs a *bank.CurrentBalanceResponse with the retrieved balance and the current date.
 func (a *GrpcAdapter) GetCurrentBalance(ctx context.Context, req *bank.CurrentBalanceRequest) (*bank.CurrentBalanceResponse, error) {
-	now := time.Now()
+	now := a.clock.Now()
 
-	balance, err := a.bankService.GetCurrentBalance(req.GetAccountNumber())
+	balance, available, err := a.bankService.GetCurrentBalance(ctx, req.GetAccountNumber())
 	if err != nil {
 		return nil, err
 	}
 	// get exchange rate
-	exchangeRate, err := a.bankService.FindExchangeRate("USD", "IDR", now)
+	exchangeRate, err := a.bankService.FindExchangeRate(ctx, "USD", "IDR", now)
 
 	if err != nil {
 		return nil, err
@@ -44,8 +45,10 @@ func (a *GrpcAdapter) GetCurrentBalance(ctx context.Context, req *bank.CurrentBa
 	balanceExchange := balance * exchangeRate
 
 	return &bank.CurrentBalanceResponse{
-		Amount:        balance,
-		AmountConvert: balanceExchange,
+		Amount:                 balance,
+		AmountConvert:          balanceExchange,
+		AvailableAmount:        available,
+		AvailableAmountConvert: available * exchangeRate,
 		CurrentDate: &date.Date{
 			Year:  int32(now.Year()),
 			Month: int32(now.Month()),
@@ -60,12 +63,15 @@ func (a *GrpcAdapter) FetchExchangeRates(req *bank.ExchangeRateRequest, stream g
 	for {
 		select {
 		case <-context.Done():
-			log.Info().Msg("Client Cancelled stream")
+			log.Info().Ctx(context).Msg("Client Cancelled stream")
 			// log.Println("Client cancelled stream")
 			return nil
+		case <-a.shutdown:
+			log.Info().Ctx(context).Msg("Server shutting down, closing exchange rate stream")
+			return status.Error(codes.Unavailable, "server is shutting down")
 		default:
-			now := time.Now().Truncate(time.Second)
-			rate, err := a.bankService.FindExchangeRate(req.FromCurrency, req.ToCurrency, now)
+			now := a.clock.Now().Truncate(time.Second)
+			rate, err := a.bankService.FindExchangeRate(context, req.FromCurrency, req.ToCurrency, now)
 
 			if err != nil {
 				s := status.New(codes.InvalidArgument,
@@ -90,17 +96,22 @@ func (a *GrpcAdapter) FetchExchangeRates(req *bank.ExchangeRateRequest, stream g
 					Timestamp:    now.Format(time.RFC3339),
 				},
 			)
-			log.Info().Msg(fmt.Sprintf("Exchange rate sent to client, %v to %v : %v\n", req.FromCurrency,
+			log.Info().Ctx(context).Msg(fmt.Sprintf("Exchange rate sent to client, %v to %v : %v\n", req.FromCurrency,
 				req.ToCurrency, rate))
 
-			time.Sleep(3 * time.Second)
+			select {
+			case <-a.clock.After(3 * time.Second):
+			case <-context.Done():
+			case <-a.shutdown:
+			}
 		}
 	}
 }
 
 func (a *GrpcAdapter) SummarizeTransactions(stream grpc.ClientStreamingServer[bank.Transaction, bank.TransactionSummary]) error {
+	ctx := stream.Context()
 	trxSum := domainBank.TransactionSummary{
-		SummaryDate: time.Now(),
+		SummaryDate: a.clock.Now(),
 		SumIn:       0,
 		SumOut:      0,
 		SumTotal:    0,
@@ -117,15 +128,7 @@ func (a *GrpcAdapter) SummarizeTransactions(stream grpc.ClientStreamingServer[ba
 				SumAmountIn:   trxSum.SumIn,
 				SumAmountOut:  trxSum.SumOut,
 				SumAmount:     trxSum.SumTotal,
-				Timestamp: &datetime.DateTime{
-					Year:    int32(trxSum.SummaryDate.Year()),
-					Month:   int32(trxSum.SummaryDate.Month()),
-					Day:     int32(trxSum.SummaryDate.Day()),
-					Hours:   int32(time.Now().Hour()),
-					Minutes: int32(time.Now().Minute()),
-					Seconds: int32(time.Now().Second()),
-					Nanos:   int32(time.Now().Nanosecond()),
-				},
+				Timestamp:     util.ToDatetime(a.clock.Now()),
 			}
 
 			return stream.SendAndClose(&res)
@@ -133,13 +136,14 @@ func (a *GrpcAdapter) SummarizeTransactions(stream grpc.ClientStreamingServer[ba
 
 		if err != nil {
 			logErr := util.LogError("Error while reading from client : "+err.Error(), "", "Bank Adapter GRPC - SummarizeTransactions - stream.Recv()")
-			log.Fatal().Msg(logErr)
+			log.Error().Ctx(ctx).Msg(logErr)
+			return err
 		}
 
 		if req.Amount < 0 {
 			errMsg := fmt.Sprintf("Requested amount %v is negative", req.Amount)
 			logErr := util.LogError(errMsg, "", "Bank Adapter GRPC - SummarizeTransactions - check negative req.Amount")
-			log.Error().Msg(logErr)
+			log.Error().Ctx(ctx).Msg(logErr)
 			s := status.New(codes.InvalidArgument, errMsg)
 			s, _ = s.WithDetails(&errdetails.BadRequest{
 				FieldViolations: []*errdetails.BadRequest_FieldViolation{
@@ -158,7 +162,7 @@ func (a *GrpcAdapter) SummarizeTransactions(stream grpc.ClientStreamingServer[ba
 
 		if err != nil {
 			logErr := util.LogError(fmt.Sprintf("Error while parsing timestamp %v : %v", req.Timestamp, err), "", "Bank Adapter GRPC - SummarizeTransactions - util.ToTime")
-			log.Fatal().Msg(logErr)
+			log.Fatal().Ctx(ctx).Msg(logErr)
 		}
 
 		trxType := domainBank.TransactionTypeUnknown
@@ -172,13 +176,14 @@ func (a *GrpcAdapter) SummarizeTransactions(stream grpc.ClientStreamingServer[ba
 			Amount:          req.Amount,
 			Timestamp:       ts,
 			TransactionType: trxType,
+			Notes:           req.Notes,
 		}
 
-		accountUuid, err := a.bankService.CreateTransaction(req.AccountNumber, trxCurrent)
+		_, err = a.bankService.CreateTransaction(domainAudit.WithFingerprint(ctx, fingerprint(req)), req.AccountNumber, trxCurrent)
 
-		if err != nil && accountUuid == uuid.Nil {
+		if err != nil && !errors.Is(err, domainBank.ErrInsufficientBalance) {
 			logErr := util.LogError(fmt.Sprintf("Invalid account number: %v", err), "", "Bank Adapter GRPC - SummarizeTransactions - a.bankService.CreateTransaction")
-			log.Error().Msg(logErr)
+			log.Error().Ctx(ctx).Msg(logErr)
 			s := status.New(codes.InvalidArgument, err.Error())
 			s, _ = s.WithDetails(&errdetails.BadRequest{
 				FieldViolations: []*errdetails.BadRequest_FieldViolation{
@@ -190,10 +195,10 @@ func (a *GrpcAdapter) SummarizeTransactions(stream grpc.ClientStreamingServer[ba
 			})
 
 			return s.Err()
-		} else if err != nil && accountUuid != uuid.Nil {
+		} else if err != nil {
 			errMsg := fmt.Sprintf("Requested amount %v exceed available balance", req.Amount)
 			logErr := util.LogError(errMsg, "", "Bank Adapter GRPC - SummarizeTransactions - a.bankService.CreateTransaction")
-			log.Error().Msg(logErr)
+			log.Error().Ctx(ctx).Msg(logErr)
 			s := status.New(codes.InvalidArgument, err.Error())
 			s, _ = s.WithDetails(&errdetails.BadRequest{
 				FieldViolations: []*errdetails.BadRequest_FieldViolation{
@@ -203,15 +208,14 @@ func (a *GrpcAdapter) SummarizeTransactions(stream grpc.ClientStreamingServer[ba
 					},
 				},
 			})
+			var breach *domainBank.InsufficientFundsError
+			if errors.As(err, &breach) {
+				s, _ = s.WithDetails(insufficientFundsFailure(breach))
+			}
 
 			return s.Err()
 		}
 
-		if err != nil {
-			logErr := util.LogError("Error while creating transaction : "+err.Error(), "", "Bank Adapter GRPC - SummarizeTransactions - a.bankService.CreateTransaction")
-			log.Error().Msg(logErr)
-		}
-
 		err = a.bankService.CalculateTransactionSummary(&trxSum, trxCurrent)
 
 		if err != nil {
@@ -228,6 +232,9 @@ func (a *GrpcAdapter) TransferMultiple(stream grpc.BidiStreamingServer[bank.Tran
 		case <-context.Done():
 			log.Info().Msg("Client cancelled stream")
 			return nil
+		case <-a.shutdown:
+			log.Info().Msg("Server shutting down, closing transfer stream")
+			return status.Error(codes.Unavailable, "server is shutting down")
 		default:
 			req, err := stream.Recv()
 
@@ -236,20 +243,14 @@ func (a *GrpcAdapter) TransferMultiple(stream grpc.BidiStreamingServer[bank.Tran
 			}
 
 			if err != nil {
-				log.Fatal().Msg(fmt.Sprintf("Error while reading from client : %s", err.Error()))
+				logErr := util.LogError("Error while reading from client : "+err.Error(), "", "Bank Adapter GRPC - TransferMultiple - stream.Recv()")
+				log.Error().Ctx(context).Msg(logErr)
+				return err
 			}
 
-			transferTrx := domainBank.TransferTransaction{
-				FromAccountNumber: req.AccountNumberSender,
-				ToAccountNumber:   req.AccountNumberReciever,
-				Currency:          req.GetCurrency(),
-				Amount:            req.GetAmount(),
-				Notes:             req.Notes,
-			}
-
-			_, transferSuccess, err := a.bankService.Transfer(transferTrx)
+			transferUuid, transferSuccess, fee, err := a.bankService.Transfer(domainAudit.WithFingerprint(context, fingerprint(req)), toTransferTransaction(req))
 			if err != nil {
-				return buildTransferErrorStatusGrpc(err, *req)
+				return buildTransferErrorStatusGrpc(err, req)
 			}
 
 			res := bank.TransferResponse{
@@ -257,7 +258,12 @@ func (a *GrpcAdapter) TransferMultiple(stream grpc.BidiStreamingServer[bank.Tran
 				AccountNumberReciever: req.AccountNumberReciever,
 				Currency:              req.Currency,
 				Amount:                req.Amount,
-				Timestamp:             util.CurrentDatetime(),
+				Timestamp:             util.ToDatetime(a.clock.Now()),
+				Fee:                   toFeeBreakdownProto(fee),
+			}
+
+			if transferUuid != uuid.Nil {
+				res.TransferId = transferUuid.String()
 			}
 
 			if transferSuccess {
@@ -269,13 +275,60 @@ func (a *GrpcAdapter) TransferMultiple(stream grpc.BidiStreamingServer[bank.Tran
 			err = stream.Send(&res)
 
 			if err != nil {
-				log.Fatal().Msg(fmt.Sprintf("Error while sending response to client : %s", err.Error()))
+				logErr := util.LogError("Error while sending response to client : "+err.Error(), "", "Bank Adapter GRPC - TransferMultiple - stream.Send()")
+				log.Error().Ctx(context).Msg(logErr)
+				return err
 			}
 		}
 	}
 }
 
-func buildTransferErrorStatusGrpc(err error, req bank.TransferRequest) error {
+func (a *GrpcAdapter) QuoteTransfer(ctx context.Context, req *bank.TransferRequest) (*bank.TransferQuote, error) {
+	booked, fee, err := a.bankService.QuoteTransfer(ctx, toTransferTransaction(req))
+	if err != nil {
+		logErr := util.LogError("Error on QuoteTransfer : "+err.Error(), "", "Bank Adapter GRPC - QuoteTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, buildTransferErrorStatusGrpc(err, req)
+	}
+
+	return &bank.TransferQuote{
+		Currency:     req.GetCurrency(),
+		Amount:       req.GetAmount(),
+		BookedAmount: booked,
+		Fee:          toFeeBreakdownProto(fee),
+		DebitAmount:  booked + fee.Charged,
+	}, nil
+}
+
+func toTransferTransaction(req *bank.TransferRequest) domainBank.TransferTransaction {
+	return domainBank.TransferTransaction{
+		FromAccountNumber: req.AccountNumberSender,
+		ToAccountNumber:   req.AccountNumberReciever,
+		Currency:          req.GetCurrency(),
+		Amount:            req.GetAmount(),
+		Notes:             req.Notes,
+		Channel:           req.GetChannel(),
+	}
+}
+
+func toFeeBreakdownProto(fee domainFee.Breakdown) *bank.FeeBreakdown {
+	res := &bank.FeeBreakdown{
+		FlatAmount:             fee.FlatAmount,
+		PercentageAmount:       fee.PercentageAmount,
+		Rate:                   fee.Tier.Rate,
+		Fee:                    fee.Fee,
+		Waived:                 fee.Waived,
+		FreeTransfersRemaining: int32(fee.FreeRemaining),
+		ChargedAmount:          fee.Charged,
+	}
+	if fee.ScheduleUuid != uuid.Nil {
+		res.ScheduleId = fee.ScheduleUuid.String()
+	}
+
+	return res
+}
+
+func buildTransferErrorStatusGrpc(err error, req *bank.TransferRequest) error {
 	switch {
 	case errors.Is(err, domainBank.ErrTransferSourceAccountNotFound):
 		s := status.New(codes.FailedPrecondition, err.Error())
@@ -327,6 +380,10 @@ func buildTransferErrorStatusGrpc(err error, req bank.TransferRequest) error {
 				"amount":       fmt.Sprintf("%f", req.Amount),
 			},
 		})
+		var breach *domainBank.InsufficientFundsError
+		if errors.As(err, &breach) {
+			s, _ = s.WithDetails(insufficientFundsFailure(breach))
+		}
 
 		return s.Err()
 	default:
@@ -0,0 +1,311 @@
+package grpc_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetCurrentBalance(t *testing.T) {
+	h := newHarness(t)
+	h.seedRate("USD", "IDR", 15000, epoch, time.Minute)
+
+	res, err := h.client.GetCurrentBalance(h.ctx(), &bank.CurrentBalanceRequest{AccountNumber: kate})
+	if err != nil {
+		t.Fatalf("GetCurrentBalance: %v", err)
+	}
+
+	if res.Amount != 10 || res.AmountConvert != 150000 {
+		t.Errorf("amount = %v, converted %v; want 10, 150000", res.Amount, res.AmountConvert)
+	}
+	if res.AvailableAmount != 10 || res.AvailableAmountConvert != 150000 {
+		t.Errorf("available = %v, converted %v; want 10, 150000", res.AvailableAmount, res.AvailableAmountConvert)
+	}
+	want := &date.Date{Year: 2024, Month: 5, Day: 1}
+	if !proto.Equal(res.CurrentDate, want) {
+		t.Errorf("current date = %v, want %v", res.CurrentDate, want)
+	}
+}
+
+func TestGetCurrentBalanceErrors(t *testing.T) {
+	h := newHarness(t)
+	h.seedRate("USD", "IDR", 15000, epoch, time.Minute)
+
+	if _, err := h.client.GetCurrentBalance(h.ctx(), &bank.CurrentBalanceRequest{AccountNumber: ghost}); err == nil {
+		t.Error("GetCurrentBalance of an unknown account succeeded")
+	}
+
+	// the only rate has expired
+	h.clock.Advance(time.Hour)
+	if _, err := h.client.GetCurrentBalance(h.ctx(), &bank.CurrentBalanceRequest{AccountNumber: kate}); err == nil {
+		t.Error("GetCurrentBalance without an exchange rate succeeded")
+	}
+}
+
+func TestFetchExchangeRates(t *testing.T) {
+	h := newHarness(t)
+	h.seedRate("USD", "IDR", 15000, epoch, 3*time.Second)
+	h.seedRate("USD", "IDR", 15100, epoch.Add(3*time.Second), 3*time.Second)
+
+	stream, err := h.client.FetchExchangeRates(h.ctx(), &bank.ExchangeRateRequest{FromCurrency: "USD", ToCurrency: "IDR"})
+	if err != nil {
+		t.Fatalf("FetchExchangeRates: %v", err)
+	}
+
+	for i, want := range []struct {
+		rate float64
+		ts   time.Time
+	}{
+		{15000, epoch},
+		{15100, epoch.Add(3 * time.Second)},
+	} {
+		if i > 0 {
+			// the server sleeps between updates, wake it
+			h.clock.BlockUntil(1)
+			h.clock.Advance(3 * time.Second)
+		}
+
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv %d: %v", i, err)
+		}
+		if res.Rate != want.rate || res.Timestamp != want.ts.Format(time.RFC3339) {
+			t.Errorf("update %d = %v at %v, want %v at %v", i, res.Rate, res.Timestamp, want.rate, want.ts.Format(time.RFC3339))
+		}
+		if res.FromCurrency != "USD" || res.ToCurrency != "IDR" {
+			t.Errorf("update %d is for %v/%v", i, res.FromCurrency, res.ToCurrency)
+		}
+	}
+}
+
+func TestFetchExchangeRatesInvalidCurrency(t *testing.T) {
+	h := newHarness(t)
+
+	stream, err := h.client.FetchExchangeRates(h.ctx(), &bank.ExchangeRateRequest{FromCurrency: "USD", ToCurrency: "XXX"})
+	if err != nil {
+		t.Fatalf("FetchExchangeRates: %v", err)
+	}
+
+	_, err = stream.Recv()
+	info := errorDetail[*errdetails.ErrorInfo](t, err, codes.InvalidArgument)
+	if info.Reason != "INVALID_CURRENCY" || info.Metadata["from_currency"] != "USD" || info.Metadata["to_currency"] != "XXX" {
+		t.Errorf("error info = %v", info)
+	}
+}
+
+func TestSummarizeTransactions(t *testing.T) {
+	h := newHarness(t)
+
+	stream, err := h.client.SummarizeTransactions(h.ctx())
+	if err != nil {
+		t.Fatalf("SummarizeTransactions: %v", err)
+	}
+
+	for _, trx := range []*bank.Transaction{
+		{AccountNumber: kate, Type: bank.TransactionType_TRANSACTION_TYPE_IN, Amount: 5},
+		{AccountNumber: kate, Type: bank.TransactionType_TRANSACTION_TYPE_OUT, Amount: 3},
+		{AccountNumber: kate, Type: bank.TransactionType_TRANSACTION_TYPE_IN, Amount: 1.5},
+	} {
+		if err := stream.Send(trx); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("CloseAndRecv: %v", err)
+	}
+
+	if res.AccountNumber != kate || res.SumAmountIn != 6.5 || res.SumAmountOut != 3 || res.SumAmount != 3.5 {
+		t.Errorf("summary = %v", res)
+	}
+	if res.Timestamp.GetYear() != 2024 || res.Timestamp.GetHours() != 10 {
+		t.Errorf("summary timestamp = %v, want the fake clock", res.Timestamp)
+	}
+	if got := h.balance(kate); got != 13.5 {
+		t.Errorf("balance = %v, want 13.5", got)
+	}
+}
+
+func TestSummarizeTransactionsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		trx   *bank.Transaction
+		field string
+	}{
+		{"negative amount", &bank.Transaction{AccountNumber: kate, Type: bank.TransactionType_TRANSACTION_TYPE_IN, Amount: -1}, "amount"},
+		{"unknown account", &bank.Transaction{AccountNumber: ghost, Type: bank.TransactionType_TRANSACTION_TYPE_IN, Amount: 1}, "account_number"},
+		{"insufficient balance", &bank.Transaction{AccountNumber: kate, Type: bank.TransactionType_TRANSACTION_TYPE_OUT, Amount: 11}, "amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarness(t)
+
+			stream, err := h.client.SummarizeTransactions(h.ctx())
+			if err != nil {
+				t.Fatalf("SummarizeTransactions: %v", err)
+			}
+			if err := stream.Send(tt.trx); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+
+			_, err = stream.CloseAndRecv()
+			badRequest := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument)
+			if len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != tt.field {
+				t.Errorf("field violations = %v, want one on %v", badRequest.FieldViolations, tt.field)
+			}
+			if got := h.balance(kate); got != 10 {
+				t.Errorf("balance = %v, want it untouched", got)
+			}
+		})
+	}
+}
+
+func TestTransferMultiple(t *testing.T) {
+	h := newHarness(t)
+	h.seedRate("USD", "IDR", 15000, epoch, time.Minute)
+
+	stream, err := h.client.TransferMultiple(h.ctx())
+	if err != nil {
+		t.Fatalf("TransferMultiple: %v", err)
+	}
+
+	for _, req := range []*bank.TransferRequest{
+		{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 4},
+		// converted to USD at the current rate
+		{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "IDR", Amount: 15000},
+	} {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if res.Status != bank.TransferStatus_TRANSFER_STATUS_SUCCESS || res.Amount != req.Amount || res.Currency != req.Currency {
+			t.Errorf("response = %v, want a successful echo of %v", res, req)
+		}
+		if res.Timestamp.GetYear() != 2024 || res.Timestamp.GetHours() != 10 {
+			t.Errorf("response timestamp = %v, want the fake clock", res.Timestamp)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("Recv after CloseSend = %v, want EOF", err)
+	}
+
+	if got := h.balance(kate); got != 5 {
+		t.Errorf("sender balance = %v, want 5", got)
+	}
+	if got := h.balance(riri); got != 15 {
+		t.Errorf("receiver balance = %v, want 15", got)
+	}
+}
+
+func TestTransferMultipleErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   *bank.TransferRequest
+		code  codes.Code
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "unknown sender",
+			req:  &bank.TransferRequest{AccountNumberSender: ghost, AccountNumberReciever: riri, Currency: "USD", Amount: 1},
+			check: func(t *testing.T, err error) {
+				pf := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition)
+				if len(pf.Violations) != 1 || pf.Violations[0].Type != "INVALID_ACCOUNT" || pf.Violations[0].Subject != "Source account not found" {
+					t.Errorf("violations = %v", pf.Violations)
+				}
+			},
+		},
+		{
+			name: "unknown receiver",
+			req:  &bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: ghost, Currency: "USD", Amount: 1},
+			check: func(t *testing.T, err error) {
+				pf := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition)
+				if len(pf.Violations) != 1 || pf.Violations[0].Type != "INVALID_ACCOUNT" || pf.Violations[0].Subject != "Destination account not found" {
+					t.Errorf("violations = %v", pf.Violations)
+				}
+			},
+		},
+		{
+			name: "insufficient balance",
+			req:  &bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 11},
+			check: func(t *testing.T, err error) {
+				info := errorDetail[*errdetails.ErrorInfo](t, err, codes.InvalidArgument)
+				if info.Reason != "TRANSACTION_PAIR_FAILED" || info.Metadata["from_account"] != kate || info.Metadata["to_account"] != riri {
+					t.Errorf("error info = %v", info)
+				}
+			},
+		},
+		{
+			name: "unsupported currency",
+			req:  &bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "EUR", Amount: 1},
+			check: func(t *testing.T, err error) {
+				help := errorDetail[*errdetails.Help](t, err, codes.Internal)
+				if len(help.Links) != 1 || help.Links[0].Url != "my-bank-website.com/faq" {
+					t.Errorf("help = %v", help)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarness(t)
+
+			stream, err := h.client.TransferMultiple(h.ctx())
+			if err != nil {
+				t.Fatalf("TransferMultiple: %v", err)
+			}
+			if err := stream.Send(tt.req); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+
+			_, err = stream.Recv()
+			tt.check(t, err)
+
+			if kateBalance, ririBalance := h.balance(kate), h.balance(riri); kateBalance != 10 || ririBalance != 10 {
+				t.Errorf("balances = %v, %v; want them untouched", kateBalance, ririBalance)
+			}
+		})
+	}
+}
+
+// errorDetail asserts err is a status with code and returns its detail of
+// type T.
+func errorDetail[T proto.Message](t *testing.T, err error, code codes.Code) T {
+	t.Helper()
+
+	var zero T
+
+	s, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("error %v is not a gRPC status", err)
+	}
+	if s.Code() != code {
+		t.Fatalf("code = %v (%v), want %v", s.Code(), s.Message(), code)
+	}
+
+	for _, d := range s.Details() {
+		if detail, ok := d.(T); ok {
+			return detail
+		}
+	}
+
+	t.Fatalf("status %v has no %T detail", s.Proto(), zero)
+	return zero
+}
@@ -0,0 +1,195 @@
+package grpc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// feeIncome stands in for the fee income account, an account of the demo
+// seed profile.
+const feeIncome = "7835697005"
+
+// transferRequest sends req on a new TransferMultiple stream and returns
+// the response.
+func (h *harness) transferRequest(req *bank.TransferRequest) (*bank.TransferResponse, error) {
+	h.t.Helper()
+
+	stream, err := h.client.TransferMultiple(h.ctx())
+	if err != nil {
+		h.t.Fatalf("TransferMultiple: %v", err)
+	}
+	defer stream.CloseSend()
+
+	if err := stream.Send(req); err != nil {
+		h.t.Fatalf("Send: %v", err)
+	}
+
+	return stream.Recv()
+}
+
+func TestTransferFee(t *testing.T) {
+	h := newHarness(t)
+
+	schedule, err := h.accounts.SetFeeSchedule(h.ctx(), &bank.FeeSchedule{Currency: "usd", FreeTransfersPerMonth: 1, FeeAccountNumber: feeIncome,
+		Tiers: []*bank.FeeTier{{MinAmount: 5, Rate: 10}, {MinAmount: 0, FlatAmount: 0.5}}})
+	if err != nil {
+		t.Fatalf("SetFeeSchedule: %v", err)
+	}
+	if schedule.ScheduleId == "" || schedule.Currency != "USD" || schedule.Tiers[0].MinAmount != 0 || schedule.FeeAccountNumber != feeIncome {
+		t.Errorf("SetFeeSchedule = %v", schedule)
+	}
+
+	res, err := h.transferRequest(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 2})
+	if err != nil {
+		t.Fatalf("first transfer: %v", err)
+	}
+	if fee := res.Fee; fee.ScheduleId != schedule.ScheduleId || fee.FlatAmount != 0.5 || fee.Fee != 0.5 || !fee.Waived || fee.ChargedAmount != 0 {
+		t.Errorf("fee of the first transfer of the month = %v, want 0.5 waived", fee)
+	}
+
+	res, err = h.transferRequest(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 6})
+	if err != nil {
+		t.Fatalf("second transfer: %v", err)
+	}
+	if fee := res.Fee; fee.Rate != 10 || fee.PercentageAmount != 0.6 || fee.Waived || fee.FreeTransfersRemaining != 0 || fee.ChargedAmount != 0.6 {
+		t.Errorf("fee of the second transfer of the month = %v, want 0.6 charged", fee)
+	}
+	if h.balance(kate) != 1.4 || h.balance(riri) != 18 || h.balance(feeIncome) != 10.6 {
+		t.Errorf("balances = %v, %v, %v; want 1.4, 18, 10.6", h.balance(kate), h.balance(riri), h.balance(feeIncome))
+	}
+
+	// the allowance starts over with the month
+	h.clock.Advance(31 * 24 * time.Hour)
+	res, err = h.transferRequest(&bank.TransferRequest{AccountNumberSender: riri, AccountNumberReciever: kate, Currency: "USD", Amount: 1})
+	if err != nil || !res.Fee.Waived {
+		t.Errorf("first transfer of June = %v, %v; want its fee waived", res, err)
+	}
+}
+
+func TestTransferFeeInsufficientBalance(t *testing.T) {
+	h := newHarness(t)
+
+	if _, err := h.accounts.SetFeeSchedule(h.ctx(), &bank.FeeSchedule{Currency: "USD", FeeAccountNumber: feeIncome,
+		Tiers: []*bank.FeeTier{{FlatAmount: 1}}}); err != nil {
+		t.Fatalf("SetFeeSchedule: %v", err)
+	}
+
+	// the balance covers the amount but not the fee
+	_, err := h.transferRequest(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 9.5})
+	if info := errorDetail[*errdetails.ErrorInfo](t, err, codes.InvalidArgument); info.Reason != "TRANSACTION_PAIR_FAILED" {
+		t.Errorf("error info = %v", info)
+	}
+	if h.balance(kate) != 10 || h.balance(feeIncome) != 10 {
+		t.Errorf("balances = %v, %v; want them untouched", h.balance(kate), h.balance(feeIncome))
+	}
+}
+
+func TestQuoteTransfer(t *testing.T) {
+	h := newHarness(t)
+	h.seedRate("USD", "IDR", 15000, epoch, time.Hour)
+
+	for _, s := range []*bank.FeeSchedule{
+		{Currency: "USD", Tiers: []*bank.FeeTier{{FlatAmount: 1}}},
+		{Currency: "USD", Channel: "mobile", Tiers: []*bank.FeeTier{{FlatAmount: 0.5}}},
+		{Currency: "USD", CustomerSegment: "PREMIUM", Tiers: []*bank.FeeTier{{FlatAmount: 0}}},
+		{Currency: "IDR", Tiers: []*bank.FeeTier{{Rate: 1}}, MinFee: 2000, MaxFee: 5000},
+	} {
+		s.FeeAccountNumber = feeIncome
+		if _, err := h.accounts.SetFeeSchedule(h.ctx(), s); err != nil {
+			t.Fatalf("SetFeeSchedule(%v): %v", s, err)
+		}
+	}
+	if schedules, err := h.accounts.ListFeeSchedules(h.ctx(), &bank.ListFeeSchedulesRequest{}); err != nil || len(schedules.Schedules) != 4 {
+		t.Fatalf("ListFeeSchedules = %v, %v; want 4 schedules", schedules, err)
+	}
+
+	quote := func(req *bank.TransferRequest) *bank.TransferQuote {
+		t.Helper()
+		req.AccountNumberSender, req.AccountNumberReciever = kate, riri
+		q, err := h.client.QuoteTransfer(h.ctx(), req)
+		if err != nil {
+			t.Fatalf("QuoteTransfer(%v): %v", req, err)
+		}
+		return q
+	}
+
+	if q := quote(&bank.TransferRequest{Currency: "USD", Amount: 4, Channel: "BRANCH"}); q.BookedAmount != 4 || q.Fee.Fee != 1 || q.DebitAmount != 5 {
+		t.Errorf("quote at a branch = %v, want a fee of 1", q)
+	}
+	if q := quote(&bank.TransferRequest{Currency: "USD", Amount: 4, Channel: " Mobile"}); q.Fee.Fee != 0.5 {
+		t.Errorf("quote on mobile = %v, want a fee of 0.5", q)
+	}
+	// the IDR fee of 1% is raised to its minimum and booked in USD
+	if q := quote(&bank.TransferRequest{Currency: "IDR", Amount: 150000}); q.BookedAmount != 10 || q.Fee.PercentageAmount != 1500 ||
+		q.Fee.Fee != 2000 || q.Fee.ChargedAmount != 0.13 || q.DebitAmount != 10.13 {
+		t.Errorf("quote in IDR = %v, want 10 booked and 0.13 charged", q)
+	}
+
+	segment, err := h.accounts.SetCustomerSegment(h.ctx(), &bank.SetCustomerSegmentRequest{AccountNumber: kate, CustomerSegment: "premium"})
+	if err != nil || segment.CustomerSegment != "PREMIUM" {
+		t.Fatalf("SetCustomerSegment = %v, %v", segment, err)
+	}
+	if q := quote(&bank.TransferRequest{Currency: "USD", Amount: 4, Channel: "MOBILE"}); q.Fee.Fee != 0 || q.DebitAmount != 4 {
+		t.Errorf("quote of a premium sender = %v, want no fee", q)
+	}
+
+	if h.balance(kate) != 10 || h.balance(feeIncome) != 10 {
+		t.Errorf("balances after quotes = %v, %v; want them untouched", h.balance(kate), h.balance(feeIncome))
+	}
+
+	_, err = h.client.QuoteTransfer(h.ctx(), &bank.TransferRequest{AccountNumberSender: ghost, Currency: "USD", Amount: 1})
+	if pf := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition); pf.Violations[0].Subject != "Source account not found" {
+		t.Errorf("violations of a quote from an unknown sender = %v", pf.Violations)
+	}
+}
+
+func TestFeeAdminErrors(t *testing.T) {
+	h := newHarness(t)
+	tiers := []*bank.FeeTier{{FlatAmount: 1}}
+
+	for _, tt := range []struct {
+		schedule *bank.FeeSchedule
+		field    string
+	}{
+		{&bank.FeeSchedule{Tiers: tiers, FeeAccountNumber: feeIncome}, "currency"},
+		{&bank.FeeSchedule{Currency: "USD", FeeAccountNumber: feeIncome}, "tiers"},
+		{&bank.FeeSchedule{Currency: "USD", Tiers: []*bank.FeeTier{{Rate: 1}, {Rate: 2}}, FeeAccountNumber: feeIncome}, "tiers"},
+		{&bank.FeeSchedule{Currency: "USD", Tiers: []*bank.FeeTier{{Rate: 101}}, FeeAccountNumber: feeIncome}, "tiers"},
+		{&bank.FeeSchedule{Currency: "USD", Tiers: tiers, MinFee: -1, FeeAccountNumber: feeIncome}, "min_fee"},
+		{&bank.FeeSchedule{Currency: "USD", Tiers: tiers, MinFee: 5, MaxFee: 2, FeeAccountNumber: feeIncome}, "max_fee"},
+		{&bank.FeeSchedule{Currency: "USD", Tiers: tiers, FreeTransfersPerMonth: -1, FeeAccountNumber: feeIncome}, "free_transfers_per_month"},
+	} {
+		_, err := h.accounts.SetFeeSchedule(h.ctx(), tt.schedule)
+		badRequest := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument)
+		if len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != tt.field {
+			t.Errorf("SetFeeSchedule(%v) violations = %v, want %v", tt.schedule, badRequest.FieldViolations, tt.field)
+		}
+	}
+
+	_, err := h.accounts.SetFeeSchedule(h.ctx(), &bank.FeeSchedule{Currency: "USD", Tiers: tiers, FeeAccountNumber: "nope"})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.ResourceName != "nope" {
+		t.Errorf("resource of SetFeeSchedule with an unknown fee account = %q, want nope", info.ResourceName)
+	}
+	_, err = h.accounts.SetCustomerSegment(h.ctx(), &bank.SetCustomerSegmentRequest{AccountNumber: "nope", CustomerSegment: "PREMIUM"})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.ResourceType != "account" {
+		t.Errorf("resource of SetCustomerSegment of an unknown account = %q, want account", info.ResourceType)
+	}
+
+	// a schedule of the same currency, channel and segment is replaced
+	first, err := h.accounts.SetFeeSchedule(h.ctx(), &bank.FeeSchedule{Currency: "USD", Tiers: tiers, FeeAccountNumber: feeIncome})
+	if err != nil {
+		t.Fatalf("SetFeeSchedule: %v", err)
+	}
+	second, err := h.accounts.SetFeeSchedule(h.ctx(), &bank.FeeSchedule{Currency: "USD", Tiers: []*bank.FeeTier{{FlatAmount: 2}}, FeeAccountNumber: riri})
+	if err != nil || second.ScheduleId != first.ScheduleId {
+		t.Fatalf("SetFeeSchedule again = %v, %v; want schedule %v replaced", second, err, first.ScheduleId)
+	}
+	schedules, err := h.accounts.ListFeeSchedules(h.ctx(), &bank.ListFeeSchedulesRequest{})
+	if err != nil || len(schedules.Schedules) != 1 || schedules.Schedules[0].Tiers[0].FlatAmount != 2 || schedules.Schedules[0].FeeAccountNumber != riri {
+		t.Errorf("ListFeeSchedules = %v, %v; want the replaced schedule", schedules, err)
+	}
+}
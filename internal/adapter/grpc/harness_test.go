@@ -0,0 +1,185 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/db/seed"
+	mygrpc "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/grpc"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/memory"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/webhook"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/pdf"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// epoch is where the fake clock of every harness starts.
+var epoch = time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+
+// Accounts of the demo seed profile, each with a balance of 10 USD.
+const (
+	kate  = "7835697001"
+	riri  = "7835697002"
+	ghost = "0000000000"
+)
+
+// harness runs the whole server in process: BankService, WebhookService,
+// InterestService, ScheduleService, BatchService and StatementService on a
+// seeded memory store, the gRPC adapter on a bufconn listener and clients
+// connected to it.
+type harness struct {
+	t          *testing.T
+	clock      *clock.Fake
+	store      *memory.MemoryAdapter
+	adapter    *mygrpc.GrpcAdapter
+	client     bank.BankServiceClient
+	webhooks   *application.WebhookService
+	interest   *application.InterestService
+	scheduler  *application.ScheduleService
+	batcher    *application.BatchService
+	admin      bank.WebhookAdminServiceClient
+	accounts   bank.AccountAdminServiceClient
+	schedules  bank.ScheduledTransferServiceClient
+	batches    bank.TransferBatchServiceClient
+	statements bank.StatementServiceClient
+	conn       *grpc.ClientConn
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	return newHarnessWithBankStore(t, func(store *memory.MemoryAdapter) port.BankDatabasePort { return store })
+}
+
+// newHarnessWithBankStore is newHarness with BankService on the store
+// bankStore wraps the memory store in.
+func newHarnessWithBankStore(t *testing.T, bankStore func(store *memory.MemoryAdapter) port.BankDatabasePort) *harness {
+	t.Helper()
+
+	clk := clock.NewFake(epoch)
+	store := memory.NewMemoryAdapter()
+	profile, err := seed.Lookup("demo", epoch)
+	if err != nil {
+		t.Fatalf("seed.Lookup: %v", err)
+	}
+	if err := seed.Apply(context.Background(), store, profile); err != nil {
+		t.Fatalf("seed.Apply: %v", err)
+	}
+
+	webhooks := application.NewWebhookService(store, store, webhook.NewHTTPSender(), clk, application.WebhookOptions{
+		Timeout:     time.Second,
+		MaxAttempts: 2,
+		BackoffBase: time.Minute,
+		BackoffMax:  time.Minute,
+		BatchSize:   10,
+	})
+
+	bankService := application.NewBankService(bankStore(store), clk)
+	adapter := mygrpc.NewGrpcAdapter(bankService, clk, 0)
+	adapter.RegisterWebhookAdmin(webhooks)
+	interest := application.NewInterestService(store, store, bankService, store, clk)
+	adapter.RegisterAccountAdmin(bankService, interest, application.NewTaxService(store, store, clk), application.NewFeeService(store, store, clk))
+	scheduler := application.NewScheduleService(store, bankService, store, clk, application.ScheduleOptions{BatchSize: 10, Lease: time.Minute})
+	adapter.RegisterScheduledTransfers(scheduler)
+	batcher := application.NewBatchService(store, bankService, bankService, store, clk, application.BatchOptions{
+		BatchSize:   10,
+		Lease:       time.Minute,
+		MaxItems:    100,
+		MaxFileSize: 4096,
+	})
+	adapter.RegisterTransferBatches(batcher)
+	adapter.RegisterStatements(application.NewStatementService(store, store, clk, application.StatementOptions{
+		BankId:   "MICROBANK",
+		Branding: pdf.Branding{BankName: "Micro Bank", Address: "Jakarta, Indonesia", Color: pdf.Black},
+	}))
+
+	lis := bufconn.Listen(1 << 20)
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		if err := adapter.Serve(lis); err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		adapter.Stop(time.Second)
+		<-served
+	})
+
+	return &harness{
+		t:          t,
+		clock:      clk,
+		store:      store,
+		adapter:    adapter,
+		client:     bank.NewBankServiceClient(conn),
+		webhooks:   webhooks,
+		interest:   interest,
+		scheduler:  scheduler,
+		batcher:    batcher,
+		admin:      bank.NewWebhookAdminServiceClient(conn),
+		accounts:   bank.NewAccountAdminServiceClient(conn),
+		schedules:  bank.NewScheduledTransferServiceClient(conn),
+		batches:    bank.NewTransferBatchServiceClient(conn),
+		statements: bank.NewStatementServiceClient(conn),
+		conn:       conn,
+	}
+}
+
+// seedRate stores a rate valid for d starting at from.
+func (h *harness) seedRate(fromCurrency, toCurrency string, rate float64, from time.Time, d time.Duration) {
+	h.t.Helper()
+
+	_, err := h.store.InsertExchangeRate(context.Background(), domainBank.BankExchangeRateOrm{
+		ExchangeRateUuid:   uuid.New(),
+		FromCurrency:       fromCurrency,
+		ToCurrency:         toCurrency,
+		Rate:               rate,
+		ValidFromTimestamp: from,
+		ValidToTimestamp:   from.Add(d).Add(-time.Millisecond),
+		CreatedAt:          from,
+		UpdatedAt:          from,
+	})
+	if err != nil {
+		h.t.Fatalf("InsertExchangeRate: %v", err)
+	}
+}
+
+func (h *harness) balance(account string) float64 {
+	h.t.Helper()
+
+	acc, err := h.store.GetBalanceBankAccountByAccountNumber(context.Background(), account)
+	if err != nil {
+		h.t.Fatalf("GetBalanceBankAccountByAccountNumber(%v): %v", account, err)
+	}
+
+	return acc.CurrentBalance
+}
+
+// ctx is cancelled when the test ends.
+func (h *harness) ctx() context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	h.t.Cleanup(cancel)
+
+	return ctx
+}
@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// SetServing updates the grpc.health.v1 status of the server as a whole ("")
+// and of every registered service.
+func (a *GrpcAdapter) SetServing(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+
+	a.health.SetServingStatus("", status)
+	for _, service := range a.services {
+		a.health.SetServingStatus(service, status)
+	}
+}
+
+// WatchHealth runs check every interval of the clock of the adapter and
+// reports the result through the health service until ctx is done. It is
+// meant to be started in its own goroutine, typically with the database ping
+// as check.
+func (a *GrpcAdapter) WatchHealth(ctx context.Context, check func(context.Context) error, interval time.Duration) {
+	ticker := a.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	healthy := true
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			err := check(checkCtx)
+			cancel()
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err != nil && healthy {
+				logErr := util.LogError("Health check failed : "+err.Error(), "", "Bank Adapter GRPC - WatchHealth")
+				log.Error().Msg(logErr)
+			} else if err == nil && !healthy {
+				log.Info().Msg("Health check recovered")
+			}
+
+			healthy = err == nil
+			a.SetServing(healthy)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestWatchHealth(t *testing.T) {
+	h := newHarness(t)
+
+	var failing atomic.Bool
+	ctx, cancel := context.WithCancel(h.ctx())
+	defer cancel()
+	go h.adapter.WatchHealth(ctx, func(context.Context) error {
+		if failing.Load() {
+			return errors.New("database unreachable")
+		}
+		return nil
+	}, 10*time.Second)
+
+	watch, err := healthpb.NewHealthClient(h.conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	next := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		res, err := watch.Recv()
+		if err != nil || res.GetStatus() != want {
+			t.Fatalf("health = %v, %v; want %v", res, err, want)
+		}
+	}
+	next(healthpb.HealthCheckResponse_SERVING)
+
+	// the check runs on the ticks of the clock only
+	h.clock.BlockUntil(1)
+	failing.Store(true)
+	h.clock.Advance(10 * time.Second)
+	next(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	failing.Store(false)
+	h.clock.Advance(10 * time.Second)
+	next(healthpb.HealthCheckResponse_SERVING)
+}
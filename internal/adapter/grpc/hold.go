@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateHold reserves funds of an account without moving them yet.
+func (a *GrpcAdapter) CreateHold(ctx context.Context, req *bank.CreateHoldRequest) (*bank.Hold, error) {
+	h := domainBank.Hold{
+		Amount: req.GetAmount(),
+		Notes:  req.GetNotes(),
+	}
+	if req.GetExpiresAt() != nil {
+		h.ExpiresAt, _ = util.ToTime(req.GetExpiresAt())
+	}
+
+	hold, err := a.bankService.CreateHold(ctx, req.GetAccountNumber(), h)
+	if err != nil {
+		logErr := util.LogError("Error on CreateHold : "+err.Error(), "", "Bank Adapter GRPC - CreateHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		switch {
+		case errors.Is(err, domainBank.ErrRecordNotFound):
+			return nil, resourceNotFound("account", req.GetAccountNumber())
+		case errors.Is(err, domainBank.ErrHoldInvalid):
+			field := "expires_at"
+			if req.GetAmount() <= 0 {
+				field = "amount"
+			}
+			return nil, badRequest(err, field)
+		case errors.Is(err, domainBank.ErrInsufficientBalance):
+			violation := domainBank.LimitRuleBalance
+			var breach *domainBank.InsufficientFundsError
+			if errors.As(err, &breach) {
+				violation = breach.Rule
+			}
+			return nil, holdPreconditionFailure(err, violation, "account "+req.GetAccountNumber())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return a.toHoldProto(hold), nil
+}
+
+func (a *GrpcAdapter) GetHold(ctx context.Context, req *bank.GetHoldRequest) (*bank.Hold, error) {
+	id, err := parseUuid("hold_id", req.GetHoldId())
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := a.bankService.GetHold(ctx, id)
+	if err != nil {
+		return nil, buildHoldErrorStatusGrpc(err, req.GetHoldId())
+	}
+
+	return a.toHoldProto(hold), nil
+}
+
+// CaptureHold debits a hold, in full or in part, from its account.
+func (a *GrpcAdapter) CaptureHold(ctx context.Context, req *bank.CaptureHoldRequest) (*bank.CaptureHoldResponse, error) {
+	id, err := parseUuid("hold_id", req.GetHoldId())
+	if err != nil {
+		return nil, err
+	}
+
+	hold, trxUuid, err := a.bankService.CaptureHold(ctx, id, req.GetAmount(), req.GetNotes())
+	if err != nil {
+		logErr := util.LogError("Error on CaptureHold : "+err.Error(), "", "Bank Adapter GRPC - CaptureHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, buildHoldErrorStatusGrpc(err, req.GetHoldId())
+	}
+
+	return &bank.CaptureHoldResponse{
+		Hold:          a.toHoldProto(hold),
+		TransactionId: trxUuid.String(),
+	}, nil
+}
+
+// ReleaseHold makes what is left of a hold available again.
+func (a *GrpcAdapter) ReleaseHold(ctx context.Context, req *bank.ReleaseHoldRequest) (*bank.Hold, error) {
+	id, err := parseUuid("hold_id", req.GetHoldId())
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := a.bankService.ReleaseHold(ctx, id)
+	if err != nil {
+		logErr := util.LogError("Error on ReleaseHold : "+err.Error(), "", "Bank Adapter GRPC - ReleaseHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, buildHoldErrorStatusGrpc(err, req.GetHoldId())
+	}
+
+	return a.toHoldProto(hold), nil
+}
+
+func buildHoldErrorStatusGrpc(err error, holdId string) error {
+	switch {
+	case errors.Is(err, domainBank.ErrRecordNotFound):
+		return resourceNotFound("hold", holdId)
+	case errors.Is(err, domainBank.ErrHoldInvalid):
+		return badRequest(err, "amount")
+	case errors.Is(err, domainBank.ErrHoldNotActive):
+		return holdPreconditionFailure(err, "HOLD_NOT_ACTIVE", "hold "+holdId)
+	case errors.Is(err, domainBank.ErrHoldExceeded):
+		return holdPreconditionFailure(err, "AMOUNT_EXCEEDS_HOLD", "hold "+holdId)
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func holdPreconditionFailure(err error, violation string, subject string) error {
+	s := status.New(codes.FailedPrecondition, err.Error())
+	s, _ = s.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: violation, Subject: subject, Description: err.Error()},
+		},
+	})
+
+	return s.Err()
+}
+
+func (a *GrpcAdapter) toHoldProto(h domainBank.BankHoldOrm) *bank.Hold {
+	res := &bank.Hold{
+		HoldId:         h.HoldUuid.String(),
+		AccountNumber:  h.AccountNumber,
+		Amount:         h.Amount,
+		CapturedAmount: h.CapturedAmount,
+		Notes:          h.Notes,
+		ExpiresAt:      util.ToDatetime(h.ExpiresAt),
+		CreatedAt:      util.ToDatetime(h.CreatedAt),
+	}
+
+	switch h.StatusAt(a.clock.Now()) {
+	case domainBank.HoldStatusActive:
+		res.Status = bank.HoldStatus_HOLD_STATUS_ACTIVE
+	case domainBank.HoldStatusCaptured:
+		res.Status = bank.HoldStatus_HOLD_STATUS_CAPTURED
+	case domainBank.HoldStatusReleased:
+		res.Status = bank.HoldStatus_HOLD_STATUS_RELEASED
+	case domainBank.HoldStatusExpired:
+		res.Status = bank.HoldStatus_HOLD_STATUS_EXPIRED
+	}
+
+	return res
+}
@@ -0,0 +1,217 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/memory"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+func (h *harness) createHold(account string, amount float64) *bank.Hold {
+	h.t.Helper()
+
+	hold, err := h.client.CreateHold(h.ctx(), &bank.CreateHoldRequest{AccountNumber: account, Amount: amount, Notes: "hotel"})
+	if err != nil {
+		h.t.Fatalf("CreateHold: %v", err)
+	}
+
+	return hold
+}
+
+func (h *harness) available(account string) float64 {
+	h.t.Helper()
+
+	res, err := h.client.GetCurrentBalance(h.ctx(), &bank.CurrentBalanceRequest{AccountNumber: account})
+	if err != nil {
+		h.t.Fatalf("GetCurrentBalance: %v", err)
+	}
+
+	return res.AvailableAmount
+}
+
+func TestHoldCaptureAndRelease(t *testing.T) {
+	h := newHarness(t)
+	h.seedRate("USD", "IDR", 15000, epoch, time.Hour)
+
+	hold := h.createHold(kate, 6)
+	if hold.Status != bank.HoldStatus_HOLD_STATUS_ACTIVE || hold.AccountNumber != kate || hold.Amount != 6 {
+		t.Errorf("new hold = %v", hold)
+	}
+	res, err := h.client.GetCurrentBalance(h.ctx(), &bank.CurrentBalanceRequest{AccountNumber: kate})
+	if err != nil {
+		t.Fatalf("GetCurrentBalance: %v", err)
+	}
+	if res.Amount != 10 || res.AvailableAmount != 4 || res.AvailableAmountConvert != 60000 {
+		t.Errorf("balance = %v, available %v (%v IDR); want 10, 4 (60000 IDR)", res.Amount, res.AvailableAmount, res.AvailableAmountConvert)
+	}
+
+	// the held money can't be spent
+	stream, err := h.client.TransferMultiple(h.ctx())
+	if err != nil {
+		t.Fatalf("TransferMultiple: %v", err)
+	}
+	if err := stream.Send(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 5}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	_, err = stream.Recv()
+	errorDetail[*errdetails.ErrorInfo](t, err, codes.InvalidArgument)
+	h.transfer(kate, riri, 4)
+
+	captured, err := h.client.CaptureHold(h.ctx(), &bank.CaptureHoldRequest{HoldId: hold.HoldId, Amount: 2.5})
+	if err != nil {
+		t.Fatalf("CaptureHold: %v", err)
+	}
+	if captured.Hold.CapturedAmount != 2.5 || captured.Hold.Status != bank.HoldStatus_HOLD_STATUS_ACTIVE || captured.TransactionId == "" {
+		t.Errorf("partial capture = %v", captured)
+	}
+	if h.balance(kate) != 3.5 || h.available(kate) != 0 {
+		t.Errorf("balance after the capture = %v, available %v; want 3.5, 0", h.balance(kate), h.available(kate))
+	}
+
+	released, err := h.client.ReleaseHold(h.ctx(), &bank.ReleaseHoldRequest{HoldId: hold.HoldId})
+	if err != nil {
+		t.Fatalf("ReleaseHold: %v", err)
+	}
+	if released.Status != bank.HoldStatus_HOLD_STATUS_RELEASED || released.CapturedAmount != 2.5 {
+		t.Errorf("released hold = %v", released)
+	}
+	if h.balance(kate) != 3.5 || h.available(kate) != 3.5 {
+		t.Errorf("balance after the release = %v, available %v; want 3.5, 3.5", h.balance(kate), h.available(kate))
+	}
+
+	_, err = h.client.CaptureHold(h.ctx(), &bank.CaptureHoldRequest{HoldId: hold.HoldId})
+	if pf := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition); pf.Violations[0].Type != "HOLD_NOT_ACTIVE" {
+		t.Errorf("capture of a released hold violation = %v", pf.Violations[0].Type)
+	}
+
+	// without an amount, the rest is captured
+	full := h.createHold(riri, 7)
+	captured, err = h.client.CaptureHold(h.ctx(), &bank.CaptureHoldRequest{HoldId: full.HoldId})
+	if err != nil {
+		t.Fatalf("CaptureHold of the rest: %v", err)
+	}
+	if captured.Hold.CapturedAmount != 7 || captured.Hold.Status != bank.HoldStatus_HOLD_STATUS_CAPTURED {
+		t.Errorf("full capture = %v", captured.Hold)
+	}
+	if h.balance(riri) != 7 {
+		t.Errorf("balance of riri = %v, want 7", h.balance(riri))
+	}
+}
+
+// holdingStore places a hold of the sender between the check of a transfer
+// and its booking, as a concurrent CreateHold would.
+type holdingStore struct {
+	*memory.MemoryAdapter
+	amount float64
+}
+
+func (s holdingStore) CreateTransferTransactionPair(ctx context.Context, fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
+	fromTransactionOrm domainBank.BankTransactionOrm, toTransactionOrm domainBank.BankTransactionOrm) (bool, error) {
+	now := fromTransactionOrm.TransactionTimestamp
+	err := s.CreateHold(ctx, domainBank.BankHoldOrm{HoldUuid: uuid.New(), AccountUuid: fromAccountOrm.AccountUuid, AccountNumber: fromAccountOrm.AccountNumber,
+		Amount: s.amount, Status: domainBank.HoldStatusActive, ExpiresAt: now.Add(time.Hour), CreatedAt: now, UpdatedAt: now}, 0)
+	if err != nil {
+		return false, err
+	}
+
+	return s.MemoryAdapter.CreateTransferTransactionPair(ctx, fromAccountOrm, toAccountOrm, fromTransactionOrm, toTransactionOrm)
+}
+
+func TestTransferLosesRaceToHold(t *testing.T) {
+	h := newHarnessWithBankStore(t, func(store *memory.MemoryAdapter) port.BankDatabasePort { return holdingStore{store, 6} })
+
+	stream, err := h.client.TransferMultiple(h.ctx())
+	if err != nil {
+		t.Fatalf("TransferMultiple: %v", err)
+	}
+	if err := stream.Send(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 5}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	_, err = stream.Recv()
+
+	// the same status as a transfer the check refuses
+	failure := errorDetail[*errdetails.PreconditionFailure](t, err, codes.InvalidArgument)
+	if len(failure.Violations) != 1 || failure.Violations[0].Subject != "account "+kate || failure.Violations[0].Description != "5.00 requested, 4.00 available" {
+		t.Errorf("violations = %v, want 4.00 of %v available", failure.Violations, kate)
+	}
+	if h.balance(kate) != 10 || h.balance(riri) != 10 {
+		t.Errorf("balances = %v, %v; want both untouched", h.balance(kate), h.balance(riri))
+	}
+}
+
+func TestHoldExpires(t *testing.T) {
+	h := newHarness(t)
+	h.seedRate("USD", "IDR", 15000, epoch, 30*24*time.Hour)
+
+	hold := h.createHold(kate, 10)
+	if got, want := hold.ExpiresAt, util.ToDatetime(epoch.Add(7*24*time.Hour)); got.Day != want.Day || got.Month != want.Month {
+		t.Errorf("default expiry = %v, want %v", got, want)
+	}
+	if h.available(kate) != 0 {
+		t.Errorf("available with the hold = %v, want 0", h.available(kate))
+	}
+
+	h.clock.Advance(7 * 24 * time.Hour)
+	got, err := h.client.GetHold(h.ctx(), &bank.GetHoldRequest{HoldId: hold.HoldId})
+	if err != nil {
+		t.Fatalf("GetHold: %v", err)
+	}
+	if got.Status != bank.HoldStatus_HOLD_STATUS_EXPIRED {
+		t.Errorf("status after the expiry = %v, want EXPIRED", got.Status)
+	}
+	if h.available(kate) != 10 {
+		t.Errorf("available after the expiry = %v, want 10", h.available(kate))
+	}
+
+	_, err = h.client.CaptureHold(h.ctx(), &bank.CaptureHoldRequest{HoldId: hold.HoldId})
+	errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition)
+}
+
+func TestHoldErrors(t *testing.T) {
+	h := newHarness(t)
+
+	tests := []struct {
+		name  string
+		req   *bank.CreateHoldRequest
+		field string
+	}{
+		{"no amount", &bank.CreateHoldRequest{AccountNumber: kate}, "amount"},
+		{"expired", &bank.CreateHoldRequest{AccountNumber: kate, Amount: 1, ExpiresAt: util.ToDatetime(epoch.Add(-time.Minute))}, "expires_at"},
+		{"too long", &bank.CreateHoldRequest{AccountNumber: kate, Amount: 1, ExpiresAt: util.ToDatetime(epoch.Add(31 * 24 * time.Hour))}, "expires_at"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := h.client.CreateHold(h.ctx(), tt.req)
+			if br := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument); br.FieldViolations[0].Field != tt.field {
+				t.Errorf("violation on %v, want %v", br.FieldViolations[0].Field, tt.field)
+			}
+		})
+	}
+
+	_, err := h.client.CreateHold(h.ctx(), &bank.CreateHoldRequest{AccountNumber: kate, Amount: 10.01})
+	if pf := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition); pf.Violations[0].Type != "INSUFFICIENT_BALANCE" {
+		t.Errorf("hold beyond the balance violation = %v", pf.Violations[0].Type)
+	}
+	_, err = h.client.CreateHold(h.ctx(), &bank.CreateHoldRequest{AccountNumber: ghost, Amount: 1})
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+
+	hold := h.createHold(kate, 3)
+	_, err = h.client.CaptureHold(h.ctx(), &bank.CaptureHoldRequest{HoldId: hold.HoldId, Amount: 3.01})
+	if pf := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition); pf.Violations[0].Type != "AMOUNT_EXCEEDS_HOLD" {
+		t.Errorf("capture beyond the hold violation = %v", pf.Violations[0].Type)
+	}
+	_, err = h.client.ReleaseHold(h.ctx(), &bank.ReleaseHoldRequest{HoldId: uuid.NewString()})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.ResourceType != "hold" {
+		t.Errorf("resource of an unknown hold = %v", info.ResourceType)
+	}
+	_, err = h.client.GetHold(h.ctx(), &bank.GetHoldRequest{HoldId: "nope"})
+	errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument)
+}
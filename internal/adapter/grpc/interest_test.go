@@ -0,0 +1,172 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc/codes"
+)
+
+func TestInterestBackfillAndCapitalization(t *testing.T) {
+	h := newHarness(t)
+
+	product, err := h.accounts.SaveProduct(h.ctx(), &bank.Product{ProductCode: "SAVER", Name: "Saver", InterestDayCount: bank.DayCountConvention_ACT_360})
+	if err != nil {
+		t.Fatalf("SaveProduct: %v", err)
+	}
+	if product.InterestDayCount != bank.DayCountConvention_ACT_360 || product.InterestAccrualFrequency != bank.AccrualFrequency_ACCRUAL_DAILY ||
+		product.InterestCapitalization != bank.CapitalizationSchedule_MONTHLY {
+		t.Errorf("interest terms = %v, %v, %v; want ACT_360 and the DAILY and MONTHLY defaults", product.InterestDayCount,
+			product.InterestAccrualFrequency, product.InterestCapitalization)
+	}
+
+	for _, rates := range []*bank.InterestRates{
+		{ProductCode: "SAVER", EffectiveFrom: &date.Date{Year: 2024, Month: 5, Day: 1}, Tiers: []*bank.InterestRateTier{{MinBalance: 100, AnnualRate: 5}, {AnnualRate: 3.6}}},
+		{ProductCode: "SAVER", EffectiveFrom: &date.Date{Year: 2024, Month: 5, Day: 20}, Tiers: []*bank.InterestRateTier{{AnnualRate: 7.2}}},
+	} {
+		if _, err := h.accounts.SetInterestRates(h.ctx(), rates); err != nil {
+			t.Fatalf("SetInterestRates: %v", err)
+		}
+	}
+	listed, err := h.accounts.ListInterestRates(h.ctx(), &bank.ListInterestRatesRequest{ProductCode: "saver"})
+	if err != nil || len(listed.Rates) != 2 || len(listed.Rates[0].Tiers) != 2 || listed.Rates[0].Tiers[0].AnnualRate != 3.6 {
+		t.Fatalf("ListInterestRates = %v, %v; want 2 versions, tiers by minimum balance", listed, err)
+	}
+
+	if _, err := h.accounts.SetAccountLimits(h.ctx(), &bank.SetAccountLimitsRequest{AccountNumber: kate, ProductCode: "SAVER"}); err != nil {
+		t.Fatalf("SetAccountLimits: %v", err)
+	}
+
+	sub := h.subscribe(h.ctx(), kate, "")
+
+	// the job was down for a month: May 1 to June 1 accrue on the first run,
+	// 19 days at 3.6% and 12 at 7.2% of 10 USD, and May is capitalized
+	h.clock.Advance(32 * 24 * time.Hour)
+	recorded, err := h.interest.Accrue(context.Background())
+	if err != nil || recorded != 32 {
+		t.Fatalf("Accrue = %v, %v; want 32 days", recorded, err)
+	}
+	if h.balance(kate) != 10.04 || h.balance(riri) != 10 {
+		t.Errorf("balances = %v, %v; want 10.04, 10", h.balance(kate), h.balance(riri))
+	}
+	if a := recvActivity(t, sub); a.Type != bank.TransactionType_TRANSACTION_TYPE_IN || a.Amount != 0.04 || a.Balance != 10.04 || a.Notes != "Interest to 2024-05-31" {
+		t.Errorf("activity = %v, want the interest of May", a)
+	}
+
+	if recorded, err := h.interest.Accrue(context.Background()); err != nil || recorded != 0 {
+		t.Errorf("Accrue again = %v, %v; want nothing", recorded, err)
+	}
+	if h.balance(kate) != 10.04 {
+		t.Errorf("balance after accruing again = %v, want 10.04", h.balance(kate))
+	}
+
+	// June 2 accrues, nothing is due until June ends
+	h.clock.Advance(24 * time.Hour)
+	if recorded, err := h.interest.Accrue(context.Background()); err != nil || recorded != 1 {
+		t.Errorf("Accrue the next day = %v, %v; want 1 day", recorded, err)
+	}
+	entries, err := h.store.AuditEntries(context.Background(), 0, 100)
+	if err != nil {
+		t.Fatalf("AuditEntries: %v", err)
+	}
+	capitalized := 0
+	for _, e := range entries {
+		if e.Action == "CapitalizeInterest" {
+			capitalized++
+		}
+	}
+	if capitalized != 1 {
+		t.Errorf("%d capitalizations audited, want only the one that posted", capitalized)
+	}
+
+	accruals, err := h.accounts.ListInterestAccruals(h.ctx(), &bank.ListInterestAccrualsRequest{
+		AccountNumber: kate,
+		From:          &date.Date{Year: 2024, Month: 5, Day: 19},
+		To:            &date.Date{Year: 2024, Month: 6, Day: 2},
+	})
+	if err != nil || len(accruals.Accruals) != 14 {
+		t.Fatalf("ListInterestAccruals = %v, %v; want May 19 to June 1", accruals, err)
+	}
+	if a := accruals.Accruals[0]; a.AnnualRate != 3.6 || a.Amount != 0.001 || !a.Capitalized || a.TransactionUuid == "" {
+		t.Errorf("accrual of May 19 = %v", a)
+	}
+	if a := accruals.Accruals[13]; a.AccrualDate.Day != 1 || a.AnnualRate != 7.2 || a.Amount != 0.002 || a.Capitalized {
+		t.Errorf("accrual of June 1 = %v, want it pending", a)
+	}
+}
+
+func TestInterestMonthlyAccrual(t *testing.T) {
+	h := newHarness(t)
+
+	if _, err := h.accounts.SaveProduct(h.ctx(), &bank.Product{ProductCode: "SAVER", Name: "Saver", InterestDayCount: bank.DayCountConvention_ACT_360,
+		InterestAccrualFrequency: bank.AccrualFrequency_ACCRUAL_MONTHLY}); err != nil {
+		t.Fatalf("SaveProduct: %v", err)
+	}
+	if _, err := h.accounts.SetInterestRates(h.ctx(), &bank.InterestRates{ProductCode: "SAVER", EffectiveFrom: &date.Date{Year: 2024, Month: 5, Day: 1},
+		Tiers: []*bank.InterestRateTier{{AnnualRate: 3.6}}}); err != nil {
+		t.Fatalf("SetInterestRates: %v", err)
+	}
+	if _, err := h.accounts.SetAccountLimits(h.ctx(), &bank.SetAccountLimitsRequest{AccountNumber: kate, ProductCode: "SAVER"}); err != nil {
+		t.Fatalf("SetAccountLimits: %v", err)
+	}
+
+	// the days of May accrue once it ended, on June 1
+	for _, step := range []struct {
+		days     int
+		recorded int
+		balance  float64
+	}{
+		{30, 0, 10},
+		{1, 31, 10.03},
+		{1, 0, 10.03},
+		{29, 30, 10.06},
+	} {
+		h.clock.Advance(time.Duration(step.days) * 24 * time.Hour)
+		recorded, err := h.interest.Accrue(context.Background())
+		if err != nil || recorded != step.recorded {
+			t.Errorf("Accrue on %v = %v, %v; want %v days", h.clock.Now().Format(time.DateOnly), recorded, err, step.recorded)
+		}
+		if h.balance(kate) != step.balance {
+			t.Errorf("balance on %v = %v, want %v", h.clock.Now().Format(time.DateOnly), h.balance(kate), step.balance)
+		}
+	}
+}
+
+func TestSetInterestRatesErrors(t *testing.T) {
+	h := newHarness(t)
+
+	_, err := h.accounts.SetInterestRates(h.ctx(), &bank.InterestRates{ProductCode: "NOPE", EffectiveFrom: &date.Date{Year: 2024, Month: 5, Day: 1},
+		Tiers: []*bank.InterestRateTier{{AnnualRate: 1}}})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.ResourceType != "product" {
+		t.Errorf("resource = %v, want the product", info)
+	}
+
+	if _, err := h.accounts.SaveProduct(h.ctx(), &bank.Product{ProductCode: "SAVER", Name: "Saver"}); err != nil {
+		t.Fatalf("SaveProduct: %v", err)
+	}
+	for field, rates := range map[string]*bank.InterestRates{
+		"effective_from": {ProductCode: "SAVER", Tiers: []*bank.InterestRateTier{{AnnualRate: 1}}},
+		"tiers": {ProductCode: "SAVER", EffectiveFrom: &date.Date{Year: 2024, Month: 5, Day: 1},
+			Tiers: []*bank.InterestRateTier{{AnnualRate: 1}, {AnnualRate: 2}}},
+	} {
+		_, err := h.accounts.SetInterestRates(h.ctx(), rates)
+		if v := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument).FieldViolations[0]; v.Field != field {
+			t.Errorf("violation = %v, want %v", v, field)
+		}
+	}
+
+	for field, product := range map[string]*bank.Product{
+		"interest_day_count":         {ProductCode: "SAVER", Name: "Saver", InterestDayCount: 9},
+		"interest_accrual_frequency": {ProductCode: "SAVER", Name: "Saver", InterestAccrualFrequency: 9},
+		"interest_capitalization":    {ProductCode: "SAVER", Name: "Saver", InterestCapitalization: 9},
+	} {
+		_, err = h.accounts.SaveProduct(h.ctx(), product)
+		if v := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument).FieldViolations[0]; v.Field != field {
+			t.Errorf("violation = %v, want %v", v, field)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainSchedule "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/schedule"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scheduledTransferServer serves the ScheduledTransferService, registered by
+// GrpcAdapter.RegisterScheduledTransfers.
+type scheduledTransferServer struct {
+	scheduleService port.ScheduleServicePort
+	bank.UnimplementedScheduledTransferServiceServer
+}
+
+func (s *scheduledTransferServer) CreateScheduledTransfer(ctx context.Context, req *bank.CreateScheduledTransferRequest) (*bank.ScheduledTransfer, error) {
+	schedule := domainSchedule.ScheduledTransferOrm{
+		Currency:             req.GetCurrency(),
+		Amount:               req.GetAmount(),
+		Notes:                req.GetNotes(),
+		Channel:              req.GetChannel(),
+		Recurrence:           req.GetRecurrence(),
+		MaxRetries:           int(req.GetMaxRetries()),
+		RetryIntervalSeconds: req.GetRetryIntervalSeconds(),
+	}
+	if req.GetStartAt() != nil {
+		startAt, err := util.ToTime(req.GetStartAt())
+		if err != nil {
+			return nil, badRequest(err, "start_at")
+		}
+		schedule.StartAt = startAt
+	}
+
+	detail, err := s.scheduleService.CreateScheduledTransfer(ctx, schedule, req.GetAccountNumberSender(), req.GetAccountNumberReciever())
+	if err != nil {
+		logErr := util.LogError("Error on CreateScheduledTransfer : "+err.Error(), "", "Scheduled Transfer GRPC - CreateScheduledTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		switch {
+		case errors.Is(err, domainSchedule.ErrRuleInvalid):
+			return nil, badRequest(err, "recurrence")
+		case errors.Is(err, domainSchedule.ErrCurrencyNotSupported):
+			return nil, badRequest(err, "currency")
+		case errors.Is(err, domainSchedule.ErrAmountInvalid):
+			return nil, badRequest(err, "amount")
+		case errors.Is(err, domainSchedule.ErrStartInPast):
+			return nil, badRequest(err, "start_at")
+		case errors.Is(err, domainSchedule.ErrRetryInvalid):
+			if req.GetMaxRetries() < 0 {
+				return nil, badRequest(err, "max_retries")
+			}
+			return nil, badRequest(err, "retry_interval_seconds")
+		case errors.Is(err, domainBank.ErrTransferSourceAccountNotFound):
+			return nil, resourceNotFound("account", req.GetAccountNumberSender())
+		case errors.Is(err, domainBank.ErrTransferDestinationAccountNotFound):
+			return nil, resourceNotFound("account", req.GetAccountNumberReciever())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toScheduledTransferProto(detail), nil
+}
+
+func (s *scheduledTransferServer) ListScheduledTransfers(ctx context.Context, req *bank.ListScheduledTransfersRequest) (*bank.ListScheduledTransfersResponse, error) {
+	details, err := s.scheduleService.ListScheduledTransfers(ctx, req.GetAccountNumber())
+	if err != nil {
+		return nil, buildScheduleErrorStatusGrpc(err, "account", req.GetAccountNumber())
+	}
+
+	res := &bank.ListScheduledTransfersResponse{}
+	for _, d := range details {
+		res.ScheduledTransfers = append(res.ScheduledTransfers, toScheduledTransferProto(d))
+	}
+
+	return res, nil
+}
+
+func (s *scheduledTransferServer) PauseScheduledTransfer(ctx context.Context, req *bank.ScheduledTransferRequest) (*bank.ScheduledTransfer, error) {
+	return s.changeStatus(ctx, req, s.scheduleService.PauseScheduledTransfer)
+}
+
+func (s *scheduledTransferServer) ResumeScheduledTransfer(ctx context.Context, req *bank.ScheduledTransferRequest) (*bank.ScheduledTransfer, error) {
+	return s.changeStatus(ctx, req, s.scheduleService.ResumeScheduledTransfer)
+}
+
+func (s *scheduledTransferServer) CancelScheduledTransfer(ctx context.Context, req *bank.ScheduledTransferRequest) (*bank.ScheduledTransfer, error) {
+	return s.changeStatus(ctx, req, s.scheduleService.CancelScheduledTransfer)
+}
+
+func (s *scheduledTransferServer) changeStatus(ctx context.Context, req *bank.ScheduledTransferRequest,
+	change func(context.Context, uuid.UUID) (domainSchedule.ScheduleDetail, error)) (*bank.ScheduledTransfer, error) {
+	id, err := parseUuid("schedule_id", req.GetScheduleId())
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := change(ctx, id)
+	if err != nil {
+		return nil, buildScheduleErrorStatusGrpc(err, "scheduled_transfer", req.GetScheduleId())
+	}
+
+	return toScheduledTransferProto(detail), nil
+}
+
+func (s *scheduledTransferServer) ListScheduledTransferExecutions(ctx context.Context, req *bank.ScheduledTransferRequest) (*bank.ListScheduledTransferExecutionsResponse, error) {
+	id, err := parseUuid("schedule_id", req.GetScheduleId())
+	if err != nil {
+		return nil, err
+	}
+
+	executions, err := s.scheduleService.ListScheduledTransferExecutions(ctx, id)
+	if err != nil {
+		return nil, buildScheduleErrorStatusGrpc(err, "scheduled_transfer", req.GetScheduleId())
+	}
+
+	res := &bank.ListScheduledTransferExecutionsResponse{}
+	for _, e := range executions {
+		execution := &bank.ScheduledTransferExecution{
+			ExecutionId:  e.ExecutionUuid.String(),
+			Occurrence:   int32(e.Occurrence),
+			Attempt:      int32(e.Attempt),
+			ScheduledFor: util.ToDatetime(e.ScheduledFor),
+			ExecutedAt:   util.ToDatetime(e.ExecutedAt),
+			Error:        e.Error,
+		}
+		switch e.Status {
+		case domainSchedule.ExecutionSucceeded:
+			execution.Status = bank.ScheduledTransferExecutionStatus_SCHEDULED_TRANSFER_EXECUTION_STATUS_SUCCEEDED
+		case domainSchedule.ExecutionRetry:
+			execution.Status = bank.ScheduledTransferExecutionStatus_SCHEDULED_TRANSFER_EXECUTION_STATUS_RETRY
+		case domainSchedule.ExecutionFailed:
+			execution.Status = bank.ScheduledTransferExecutionStatus_SCHEDULED_TRANSFER_EXECUTION_STATUS_FAILED
+		}
+		if e.TransferUuid != nil {
+			execution.TransferId = e.TransferUuid.String()
+		}
+		res.Executions = append(res.Executions, execution)
+	}
+
+	return res, nil
+}
+
+// buildScheduleErrorStatusGrpc maps err to a status, resourceType and
+// resourceName name what the request referred to for NotFound.
+func buildScheduleErrorStatusGrpc(err error, resourceType string, resourceName string) error {
+	switch {
+	case errors.Is(err, domainBank.ErrRecordNotFound):
+		return resourceNotFound(resourceType, resourceName)
+	case errors.Is(err, domainSchedule.ErrScheduleNotActive):
+		s := status.New(codes.FailedPrecondition, err.Error())
+		s, _ = s.WithDetails(&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: "SCHEDULE_STATUS", Subject: "scheduled_transfer " + resourceName, Description: err.Error()},
+			},
+		})
+		return s.Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toScheduledTransferProto(d domainSchedule.ScheduleDetail) *bank.ScheduledTransfer {
+	res := &bank.ScheduledTransfer{
+		ScheduleId:            d.ScheduleUuid.String(),
+		AccountNumberSender:   d.FromAccountNumber,
+		AccountNumberReciever: d.ToAccountNumber,
+		Currency:              d.Currency,
+		Amount:                d.Amount,
+		Notes:                 d.Notes,
+		Channel:               d.Channel,
+		StartAt:               util.ToDatetime(d.StartAt),
+		Recurrence:            d.Recurrence,
+		OccurrencesRun:        int32(d.Occurrence),
+		MaxRetries:            int32(d.MaxRetries),
+		RetryIntervalSeconds:  d.RetryIntervalSeconds,
+		CreatedAt:             util.ToDatetime(d.CreatedAt),
+	}
+
+	switch d.Status {
+	case domainSchedule.StatusActive:
+		res.Status = bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_ACTIVE
+		if d.NextRunAt != nil {
+			res.NextRunAt = util.ToDatetime(*d.NextRunAt)
+		}
+	case domainSchedule.StatusPaused:
+		res.Status = bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_PAUSED
+	case domainSchedule.StatusCancelled:
+		res.Status = bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_CANCELLED
+	case domainSchedule.StatusCompleted:
+		res.Status = bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_COMPLETED
+	}
+
+	return res
+}
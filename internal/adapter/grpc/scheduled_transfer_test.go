@@ -0,0 +1,224 @@
+package grpc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/googleapis/type/datetime"
+	"google.golang.org/grpc/codes"
+)
+
+// time converts dt, failing the test when it is not a valid time.
+func (h *harness) time(dt *datetime.DateTime) time.Time {
+	h.t.Helper()
+
+	t, err := util.ToTime(dt)
+	if err != nil {
+		h.t.Fatalf("ToTime(%v): %v", dt, err)
+	}
+
+	return t
+}
+
+// execute runs the due scheduled transfers and returns how many succeeded.
+func (h *harness) execute() int {
+	h.t.Helper()
+
+	n, err := h.scheduler.Execute(h.ctx())
+	if err != nil {
+		h.t.Fatalf("Execute: %v", err)
+	}
+
+	return n
+}
+
+func TestScheduledTransferMonthly(t *testing.T) {
+	h := newHarness(t)
+
+	schedule, err := h.schedules.CreateScheduledTransfer(h.ctx(), &bank.CreateScheduledTransferRequest{
+		AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "usd", Amount: 2, Notes: "rent",
+		Recurrence: "rrule:freq=monthly;bymonthday=1;count=3",
+	})
+	if err != nil {
+		t.Fatalf("CreateScheduledTransfer: %v", err)
+	}
+	if schedule.Status != bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_ACTIVE || schedule.Currency != "USD" ||
+		schedule.Recurrence != "FREQ=MONTHLY;BYMONTHDAY=1;COUNT=3" || !h.time(schedule.NextRunAt).Equal(epoch) {
+		t.Errorf("CreateScheduledTransfer = %v", schedule)
+	}
+
+	if n := h.execute(); n != 1 {
+		t.Errorf("first Execute = %v, want 1", n)
+	}
+	// nothing is due until June
+	h.clock.Advance(24 * time.Hour)
+	if n := h.execute(); n != 0 {
+		t.Errorf("Execute on May 2nd = %v, want 0", n)
+	}
+
+	// missed occurrences are caught up
+	h.clock.Advance(90 * 24 * time.Hour)
+	if n := h.execute(); n != 2 {
+		t.Errorf("Execute in August = %v, want 2", n)
+	}
+	if h.balance(kate) != 4 || h.balance(riri) != 16 {
+		t.Errorf("balances = %v, %v; want 4, 16", h.balance(kate), h.balance(riri))
+	}
+
+	res, err := h.schedules.ListScheduledTransfers(h.ctx(), &bank.ListScheduledTransfersRequest{AccountNumber: kate})
+	if err != nil {
+		t.Fatalf("ListScheduledTransfers: %v", err)
+	}
+	if len(res.ScheduledTransfers) != 1 {
+		t.Fatalf("ListScheduledTransfers = %v, want the schedule", res)
+	}
+	if s := res.ScheduledTransfers[0]; s.Status != bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_COMPLETED || s.OccurrencesRun != 3 || s.NextRunAt != nil {
+		t.Errorf("schedule after its last occurrence = %v", s)
+	}
+
+	executions, err := h.schedules.ListScheduledTransferExecutions(h.ctx(), &bank.ScheduledTransferRequest{ScheduleId: schedule.ScheduleId})
+	if err != nil {
+		t.Fatalf("ListScheduledTransferExecutions: %v", err)
+	}
+	if len(executions.Executions) != 3 {
+		t.Fatalf("executions = %v, want 3", executions)
+	}
+	for i, e := range executions.Executions {
+		want := time.Date(2024, time.May+time.Month(i), 1, 10, 0, 0, 0, time.UTC)
+		if e.Status != bank.ScheduledTransferExecutionStatus_SCHEDULED_TRANSFER_EXECUTION_STATUS_SUCCEEDED || e.TransferId == "" ||
+			!h.time(e.ScheduledFor).Equal(want) {
+			t.Errorf("execution %d = %v, want a transfer scheduled for %v", i, e, want)
+		}
+	}
+}
+
+func TestScheduledTransferRetry(t *testing.T) {
+	h := newHarness(t)
+
+	schedule, err := h.schedules.CreateScheduledTransfer(h.ctx(), &bank.CreateScheduledTransferRequest{
+		AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 15,
+		StartAt: util.ToDatetime(epoch.Add(time.Hour)), MaxRetries: 1, RetryIntervalSeconds: 600,
+	})
+	if err != nil {
+		t.Fatalf("CreateScheduledTransfer: %v", err)
+	}
+
+	if n := h.execute(); n != 0 {
+		t.Errorf("Execute before the start = %v, want 0", n)
+	}
+	h.clock.Advance(time.Hour)
+	h.execute()
+	h.clock.Advance(10 * time.Minute)
+	h.execute()
+
+	executions, err := h.schedules.ListScheduledTransferExecutions(h.ctx(), &bank.ScheduledTransferRequest{ScheduleId: schedule.ScheduleId})
+	if err != nil {
+		t.Fatalf("ListScheduledTransferExecutions: %v", err)
+	}
+	if len(executions.Executions) != 2 {
+		t.Fatalf("executions = %v, want 2", executions)
+	}
+	if e := executions.Executions[0]; e.Status != bank.ScheduledTransferExecutionStatus_SCHEDULED_TRANSFER_EXECUTION_STATUS_RETRY || e.Attempt != 0 || e.Error == "" {
+		t.Errorf("first attempt = %v, want a retry", e)
+	}
+	if e := executions.Executions[1]; e.Status != bank.ScheduledTransferExecutionStatus_SCHEDULED_TRANSFER_EXECUTION_STATUS_FAILED || e.Attempt != 1 ||
+		!h.time(e.ExecutedAt).Equal(epoch.Add(70*time.Minute)) {
+		t.Errorf("second attempt = %v, want it failed", e)
+	}
+	if h.balance(kate) != 10 {
+		t.Errorf("balance of %v = %v, want 10", kate, h.balance(kate))
+	}
+
+	res, err := h.schedules.ListScheduledTransfers(h.ctx(), &bank.ListScheduledTransfersRequest{})
+	if err != nil || len(res.ScheduledTransfers) != 1 || res.ScheduledTransfers[0].Status != bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_COMPLETED {
+		t.Errorf("ListScheduledTransfers = %v, %v; want the schedule completed", res, err)
+	}
+}
+
+func TestScheduledTransferPauseResume(t *testing.T) {
+	h := newHarness(t)
+
+	schedule, err := h.schedules.CreateScheduledTransfer(h.ctx(), &bank.CreateScheduledTransferRequest{
+		AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 1, Recurrence: "FREQ=DAILY",
+	})
+	if err != nil {
+		t.Fatalf("CreateScheduledTransfer: %v", err)
+	}
+	req := &bank.ScheduledTransferRequest{ScheduleId: schedule.ScheduleId}
+
+	paused, err := h.schedules.PauseScheduledTransfer(h.ctx(), req)
+	if err != nil || paused.Status != bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_PAUSED || paused.NextRunAt != nil {
+		t.Fatalf("PauseScheduledTransfer = %v, %v", paused, err)
+	}
+	_, err = h.schedules.PauseScheduledTransfer(h.ctx(), req)
+	if v := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition).Violations[0]; v.Type != "SCHEDULE_STATUS" {
+		t.Errorf("violation of pausing twice = %v", v)
+	}
+
+	// paused days are skipped, not caught up
+	h.clock.Advance(3*24*time.Hour + time.Hour)
+	if n := h.execute(); n != 0 {
+		t.Errorf("Execute while paused = %v, want 0", n)
+	}
+	resumed, err := h.schedules.ResumeScheduledTransfer(h.ctx(), req)
+	if err != nil {
+		t.Fatalf("ResumeScheduledTransfer: %v", err)
+	}
+	if want := epoch.Add(4 * 24 * time.Hour); resumed.Status != bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_ACTIVE ||
+		!h.time(resumed.NextRunAt).Equal(want) || resumed.OccurrencesRun != 4 {
+		t.Errorf("ResumeScheduledTransfer = %v, want the next run at %v", resumed, want)
+	}
+
+	cancelled, err := h.schedules.CancelScheduledTransfer(h.ctx(), req)
+	if err != nil || cancelled.Status != bank.ScheduledTransferStatus_SCHEDULED_TRANSFER_STATUS_CANCELLED {
+		t.Fatalf("CancelScheduledTransfer = %v, %v", cancelled, err)
+	}
+	_, err = h.schedules.ResumeScheduledTransfer(h.ctx(), req)
+	errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition)
+
+	h.clock.Advance(24 * time.Hour)
+	if n := h.execute(); n != 0 || h.balance(kate) != 10 {
+		t.Errorf("Execute after cancelling = %v, balance %v; want nothing transferred", n, h.balance(kate))
+	}
+}
+
+func TestScheduledTransferErrors(t *testing.T) {
+	h := newHarness(t)
+
+	tests := []struct {
+		name  string
+		req   *bank.CreateScheduledTransferRequest
+		field string
+	}{
+		{"recurrence", &bank.CreateScheduledTransferRequest{Currency: "USD", Amount: 1, Recurrence: "FREQ=HOURLY"}, "recurrence"},
+		{"currency", &bank.CreateScheduledTransferRequest{Currency: "EUR", Amount: 1}, "currency"},
+		{"amount", &bank.CreateScheduledTransferRequest{Currency: "USD", Amount: 0.001}, "amount"},
+		{"start in the past", &bank.CreateScheduledTransferRequest{Currency: "USD", Amount: 1, StartAt: util.ToDatetime(epoch.Add(-time.Hour))}, "start_at"},
+		{"negative retries", &bank.CreateScheduledTransferRequest{Currency: "USD", Amount: 1, MaxRetries: -1}, "max_retries"},
+		{"retries without interval", &bank.CreateScheduledTransferRequest{Currency: "USD", Amount: 1, MaxRetries: 2}, "retry_interval_seconds"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.AccountNumberSender, tt.req.AccountNumberReciever = kate, riri
+			_, err := h.schedules.CreateScheduledTransfer(h.ctx(), tt.req)
+			if v := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument).FieldViolations[0]; v.Field != tt.field {
+				t.Errorf("field = %v, want %v", v.Field, tt.field)
+			}
+		})
+	}
+
+	_, err := h.schedules.CreateScheduledTransfer(h.ctx(), &bank.CreateScheduledTransferRequest{
+		AccountNumberSender: kate, AccountNumberReciever: ghost, Currency: "USD", Amount: 1,
+	})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.ResourceName != ghost {
+		t.Errorf("ResourceInfo = %v, want %v", info, ghost)
+	}
+
+	_, err = h.schedules.CancelScheduledTransfer(h.ctx(), &bank.ScheduledTransferRequest{ScheduleId: "6f1c3a52-5ad0-4c8e-9a7e-0d8bd1f3a111"})
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+	_, err = h.schedules.ListScheduledTransferExecutions(h.ctx(), &bank.ScheduledTransferRequest{ScheduleId: "nope"})
+	errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument)
+}
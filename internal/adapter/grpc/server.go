@@ -3,27 +3,104 @@ package grpc
 import (
 	"fmt"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/logger"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 type GrpcAdapter struct {
 	bankService port.BankServicePort
+	clock       clock.Clock
 	grpcPort    int
 	server      *grpc.Server
+	health      *health.Server
+	services    []string
+	shutdown    chan struct{}
+	stopOnce    sync.Once
 	bank.BankServiceServer
 }
 
-func NewGrpcAdapter(bankService port.BankServicePort, grpcPort int) *GrpcAdapter {
-	return &GrpcAdapter{
+func NewGrpcAdapter(bankService port.BankServicePort, clk clock.Clock, grpcPort int, opts ...grpc.ServerOption) *GrpcAdapter {
+	a := &GrpcAdapter{
 		bankService: bankService,
+		clock:       clk,
 		grpcPort:    grpcPort,
+		health:      health.NewServer(),
+		services:    []string{bank.BankService_ServiceDesc.ServiceName},
+		shutdown:    make(chan struct{}),
 	}
+
+	opts = append([]grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(logger.GrpcLogger, metrics.UnaryServerInterceptor, auditUnaryInterceptor),
+		grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor, auditStreamInterceptor),
+	}, opts...)
+	a.server = grpc.NewServer(opts...)
+	reflection.Register(a.server)
+	healthpb.RegisterHealthServer(a.server, a.health)
+	bank.RegisterBankServiceServer(a.server, a)
+
+	return a
+}
+
+// RegisterWebhookAdmin serves the WebhookAdminService with webhookService.
+// It must be called before Serve.
+func (a *GrpcAdapter) RegisterWebhookAdmin(webhookService port.WebhookServicePort) {
+	bank.RegisterWebhookAdminServiceServer(a.server, &webhookAdminServer{
+		webhookService: webhookService,
+	})
+	a.services = append(a.services, bank.WebhookAdminService_ServiceDesc.ServiceName)
+}
+
+// RegisterScheduledTransfers serves the ScheduledTransferService with
+// scheduleService. It must be called before Serve.
+func (a *GrpcAdapter) RegisterScheduledTransfers(scheduleService port.ScheduleServicePort) {
+	bank.RegisterScheduledTransferServiceServer(a.server, &scheduledTransferServer{
+		scheduleService: scheduleService,
+	})
+	a.services = append(a.services, bank.ScheduledTransferService_ServiceDesc.ServiceName)
+}
+
+// RegisterTransferBatches serves the TransferBatchService with batchService.
+// It must be called before Serve.
+func (a *GrpcAdapter) RegisterTransferBatches(batchService port.BatchServicePort) {
+	bank.RegisterTransferBatchServiceServer(a.server, &transferBatchServer{
+		batchService: batchService,
+	})
+	a.services = append(a.services, bank.TransferBatchService_ServiceDesc.ServiceName)
+}
+
+// RegisterStatements serves the StatementService with statementService. It
+// must be called before Serve.
+func (a *GrpcAdapter) RegisterStatements(statementService port.StatementServicePort) {
+	bank.RegisterStatementServiceServer(a.server, &statementServer{
+		statementService: statementService,
+	})
+	a.services = append(a.services, bank.StatementService_ServiceDesc.ServiceName)
+}
+
+// RegisterAccountAdmin serves the AccountAdminService with accountService,
+// interestService, taxService and feeService. It must be called before Serve.
+func (a *GrpcAdapter) RegisterAccountAdmin(accountService port.AccountAdminServicePort, interestService port.InterestServicePort, taxService port.TaxServicePort,
+	feeService port.FeeServicePort) {
+	bank.RegisterAccountAdminServiceServer(a.server, &accountAdminServer{
+		accountService:  accountService,
+		interestService: interestService,
+		taxService:      taxService,
+		feeService:      feeService,
+	})
+	a.services = append(a.services, bank.AccountAdminService_ServiceDesc.ServiceName)
 }
 
 func (a *GrpcAdapter) Run() {
@@ -37,18 +114,39 @@ func (a *GrpcAdapter) Run() {
 
 	log.Info().Msgf("Server listening on port %d", a.grpcPort)
 
-	grpcLogger := grpc.UnaryInterceptor(logger.GrpcLogger)
-	grpcServer := grpc.NewServer(grpcLogger)
-	reflection.Register(grpcServer)
-	a.server = grpcServer
-
-	bank.RegisterBankServiceServer(grpcServer, a)
-
-	if err := grpcServer.Serve(listen); err != nil {
+	if err := a.Serve(listen); err != nil {
 		log.Fatal().Err(err).Msgf("Failed to serve gRPC server over port %d", a.grpcPort)
 	}
 }
 
-func (a *GrpcAdapter) Stop() {
-	a.server.Stop()
+// Serve marks the server SERVING and serves on lis until Stop is called. Run
+// uses it with a TCP listener, tests with an in-memory one.
+func (a *GrpcAdapter) Serve(lis net.Listener) error {
+	a.SetServing(true)
+
+	return a.server.Serve(lis)
+}
+
+// Stop marks every service NOT_SERVING, ends the long-lived streams and waits
+// for in-flight RPCs to drain. If they don't finish within timeout the server
+// is stopped forcibly.
+func (a *GrpcAdapter) Stop(timeout time.Duration) {
+	a.stopOnce.Do(func() {
+		a.health.Shutdown()
+		close(a.shutdown)
+
+		drained := make(chan struct{})
+		go func() {
+			a.server.GracefulStop()
+			close(drained)
+		}()
+
+		select {
+		case <-drained:
+			log.Info().Msg("gRPC server stopped gracefully")
+		case <-time.After(timeout):
+			log.Warn().Msgf("gRPC server did not drain within %v, forcing stop", timeout)
+			a.server.Stop()
+		}
+	})
 }
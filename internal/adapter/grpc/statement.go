@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainStatement "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/statement"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/type/datetime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statementServer serves the StatementService, registered by
+// GrpcAdapter.RegisterStatements.
+type statementServer struct {
+	statementService port.StatementServicePort
+	bank.UnimplementedStatementServiceServer
+}
+
+var statementFormats = map[bank.StatementFormat]string{
+	bank.StatementFormat_STATEMENT_FORMAT_CSV:      domainStatement.FormatCSV,
+	bank.StatementFormat_STATEMENT_FORMAT_OFX:      domainStatement.FormatOFX,
+	bank.StatementFormat_STATEMENT_FORMAT_CAMT_053: domainStatement.FormatCamt053,
+}
+
+func (s *statementServer) GenerateStatement(req *bank.StatementRequest, stream bank.StatementService_GenerateStatementServer) error {
+	format, ok := statementFormats[req.GetFormat()]
+	if !ok {
+		return badRequest(domainStatement.ErrFormatInvalid, "format")
+	}
+
+	return s.streamStatement(stream, req.GetAccountNumber(), format, req.GetFromTime(), req.GetToTime(), "GenerateStatement")
+}
+
+func (s *statementServer) GetStatementPdf(req *bank.StatementPdfRequest, stream bank.StatementService_GetStatementPdfServer) error {
+	return s.streamStatement(stream, req.GetAccountNumber(), domainStatement.FormatPDF, req.GetFromTime(), req.GetToTime(), "GetStatementPdf")
+}
+
+// streamStatement sends the statement of accountNum in format to stream, for
+// the rpc named method.
+func (s *statementServer) streamStatement(stream grpc.ServerStreamingServer[bank.StatementChunk], accountNum string, format string,
+	fromTime *datetime.DateTime, toTime *datetime.DateTime, method string) error {
+	ctx := stream.Context()
+
+	if fromTime == nil {
+		return badRequest(domainStatement.ErrPeriodInvalid, "from_time")
+	}
+	from, err := util.ToTime(fromTime)
+	if err != nil {
+		return badRequest(err, "from_time")
+	}
+	var to time.Time
+	if toTime != nil {
+		if to, err = util.ToTime(toTime); err != nil {
+			return badRequest(err, "to_time")
+		}
+	}
+
+	w := bufio.NewWriterSize(chunkWriter(func(p []byte) error {
+		return stream.Send(&bank.StatementChunk{Data: p})
+	}), chunkSize)
+	err = s.statementService.GenerateStatement(ctx, accountNum, format, from, to, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		logErr := util.LogError("Error on "+method+" : "+err.Error(), "", "Statement GRPC - "+method)
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		switch {
+		case errors.Is(err, domainBank.ErrRecordNotFound):
+			return resourceNotFound("account", accountNum)
+		case errors.Is(err, domainStatement.ErrFormatInvalid):
+			return badRequest(err, "format")
+		case errors.Is(err, domainStatement.ErrPeriodInvalid):
+			return badRequest(err, "from_time")
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (s *statementServer) GetTransferReceipt(ctx context.Context, req *bank.TransferReceiptRequest) (*bank.TransferReceipt, error) {
+	id, err := parseUuid("transfer_id", req.GetTransferId())
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := s.statementService.WriteTransferReceipt(ctx, id, &out); err != nil {
+		logErr := util.LogError("Error on GetTransferReceipt : "+err.Error(), "", "Statement GRPC - GetTransferReceipt")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		if errors.Is(err, domainBank.ErrRecordNotFound) {
+			return nil, resourceNotFound("transfer", req.GetTransferId())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &bank.TransferReceipt{TransferId: id.String(), Pdf: out.Bytes()}, nil
+}
@@ -0,0 +1,168 @@
+package grpc_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// statement returns the statement req asks for, or the error the stream
+// ended with.
+func (h *harness) statement(req *bank.StatementRequest) (string, error) {
+	h.t.Helper()
+
+	stream, err := h.statements.GenerateStatement(h.ctx(), req)
+	if err != nil {
+		h.t.Fatalf("GenerateStatement: %v", err)
+	}
+	var data []byte
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return string(data), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		data = append(data, chunk.GetData()...)
+	}
+}
+
+func TestGenerateStatementCSV(t *testing.T) {
+	h := newHarness(t)
+
+	h.clock.Advance(time.Hour)
+	if _, err := h.transferRequest(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 3,
+		Notes: "lunch"}); err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	h.clock.Advance(time.Hour)
+
+	// the seed deposit is before the period, the end is now
+	out, err := h.statement(&bank.StatementRequest{AccountNumber: kate, Format: bank.StatementFormat_STATEMENT_FORMAT_CSV,
+		FromTime: util.ToDatetime(epoch.Add(time.Minute))})
+	if err != nil {
+		t.Fatalf("GenerateStatement: %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("statement isn't CSV: %v\n%v", err, out)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("statement =\n%v\nwant a header, the opening balance, the transfer and the closing balance", out)
+	}
+	for i, want := range [][]string{
+		{"2024-05-01T10:01:00Z", "", "OPENING_BALANCE", "", "10.00", "USD", ""},
+		{"2024-05-01T11:00:00Z", rows[2][1], "OUT", "-3.00", "7.00", "USD", "lunch"},
+		{"2024-05-01T12:00:00Z", "", "CLOSING_BALANCE", "", "7.00", "USD", ""},
+	} {
+		if got := strings.Join(rows[i+1], ","); got != strings.Join(want, ",") {
+			t.Errorf("row %d = %v, want %v", i+1, got, strings.Join(want, ","))
+		}
+	}
+
+	// the transfer and the seed deposit show on camt.053 too
+	out, err = h.statement(&bank.StatementRequest{AccountNumber: kate, Format: bank.StatementFormat_STATEMENT_FORMAT_CAMT_053,
+		FromTime: util.ToDatetime(epoch), ToTime: util.ToDatetime(epoch.Add(24 * time.Hour))})
+	if err != nil {
+		t.Fatalf("GenerateStatement: %v", err)
+	}
+	if strings.Count(out, "<Ntry>") != 2 || !strings.Contains(out, `<Amt Ccy="USD">7.00</Amt>`) || !strings.Contains(out, "<ToDtTm>2024-05-01T12:00:00Z</ToDtTm>") {
+		t.Errorf("camt.053 statement =\n%v\nwant 2 entries closing at 7.00 now", out)
+	}
+}
+
+func TestGenerateStatementErrors(t *testing.T) {
+	h := newHarness(t)
+	from := util.ToDatetime(epoch.Add(-time.Hour))
+
+	for _, tt := range []struct {
+		name  string
+		req   *bank.StatementRequest
+		field string
+	}{
+		{"no format", &bank.StatementRequest{AccountNumber: kate, FromTime: from}, "format"},
+		{"no period", &bank.StatementRequest{AccountNumber: kate, Format: bank.StatementFormat_STATEMENT_FORMAT_OFX}, "from_time"},
+		{"period in the future", &bank.StatementRequest{AccountNumber: kate, Format: bank.StatementFormat_STATEMENT_FORMAT_OFX,
+			FromTime: util.ToDatetime(epoch.Add(time.Hour))}, "from_time"},
+	} {
+		_, err := h.statement(tt.req)
+		if violation := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument); violation.FieldViolations[0].Field != tt.field {
+			t.Errorf("%v: field = %v, want %v", tt.name, violation.FieldViolations[0].Field, tt.field)
+		}
+	}
+
+	_, err := h.statement(&bank.StatementRequest{AccountNumber: ghost, Format: bank.StatementFormat_STATEMENT_FORMAT_OFX, FromTime: from})
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+}
+
+func TestGetStatementPdf(t *testing.T) {
+	h := newHarness(t)
+	h.clock.Advance(time.Hour)
+	h.transfer(kate, riri, 3)
+	h.clock.Advance(time.Hour)
+
+	stream, err := h.statements.GetStatementPdf(h.ctx(), &bank.StatementPdfRequest{AccountNumber: kate, FromTime: util.ToDatetime(epoch)})
+	if err != nil {
+		t.Fatalf("GetStatementPdf: %v", err)
+	}
+	var data []byte
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		data = append(data, chunk.GetData()...)
+	}
+	out := string(data)
+	if !strings.HasPrefix(out, "%PDF-") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatalf("statement isn't a PDF:\n%.200v", out)
+	}
+	if !strings.Contains(out, "(Kate Bishop)") || !strings.Contains(out, "(USD 7,00)") {
+		t.Errorf("statement has no account name or closing balance of 7,00")
+	}
+
+	stream, err = h.statements.GetStatementPdf(h.ctx(), &bank.StatementPdfRequest{AccountNumber: ghost, FromTime: util.ToDatetime(epoch)})
+	if err != nil {
+		t.Fatalf("GetStatementPdf: %v", err)
+	}
+	_, err = stream.Recv()
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+}
+
+func TestGetTransferReceipt(t *testing.T) {
+	h := newHarness(t)
+	transferId := h.transfer(kate, riri, 3)
+
+	receipt, err := h.statements.GetTransferReceipt(h.ctx(), &bank.TransferReceiptRequest{TransferId: transferId})
+	if err != nil {
+		t.Fatalf("GetTransferReceipt: %v", err)
+	}
+	out := string(receipt.GetPdf())
+	if receipt.GetTransferId() != transferId || !strings.HasPrefix(out, "%PDF-") {
+		t.Fatalf("receipt of %v = %v, %.200v; want a PDF", transferId, receipt.GetTransferId(), out)
+	}
+	for _, want := range []string{"(TRANSFER BERHASIL)", "(USD 3,00)", "(Kate Bishop)", "(Riri Williams)", "(" + transferId + ")"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("receipt has no %v", want)
+		}
+	}
+
+	_, err = h.statements.GetTransferReceipt(h.ctx(), &bank.TransferReceiptRequest{TransferId: "nope"})
+	if violation := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument); violation.FieldViolations[0].Field != "transfer_id" {
+		t.Errorf("field = %v, want transfer_id", violation.FieldViolations[0].Field)
+	}
+	_, err = h.statements.GetTransferReceipt(h.ctx(), &bank.TransferReceiptRequest{TransferId: "0b1c2d3e-4f50-4617-8293-a4b5c6d7e8f9"})
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+}
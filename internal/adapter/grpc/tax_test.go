@@ -0,0 +1,125 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc/codes"
+)
+
+// setUpSaver puts kate on a product paying 3.6% a year from May 1.
+func setUpSaver(t *testing.T, h *harness) {
+	t.Helper()
+
+	if _, err := h.accounts.SaveProduct(h.ctx(), &bank.Product{ProductCode: "SAVER", Name: "Saver", InterestDayCount: bank.DayCountConvention_ACT_360}); err != nil {
+		t.Fatalf("SaveProduct: %v", err)
+	}
+	if _, err := h.accounts.SetInterestRates(h.ctx(), &bank.InterestRates{ProductCode: "SAVER", EffectiveFrom: &date.Date{Year: 2024, Month: 5, Day: 1},
+		Tiers: []*bank.InterestRateTier{{AnnualRate: 3.6}}}); err != nil {
+		t.Fatalf("SetInterestRates: %v", err)
+	}
+	if _, err := h.accounts.SetAccountLimits(h.ctx(), &bank.SetAccountLimitsRequest{AccountNumber: kate, ProductCode: "SAVER"}); err != nil {
+		t.Fatalf("SetAccountLimits: %v", err)
+	}
+}
+
+func TestTaxWithheldFromInterest(t *testing.T) {
+	h := newHarness(t)
+	setUpSaver(t, h)
+
+	// riri stands in for the tax payable account
+	rule, err := h.accounts.SetTaxRule(h.ctx(), &bank.TaxRule{Currency: "usd", EffectiveFrom: &date.Date{Year: 2024, Month: 1, Day: 1},
+		Rate: 20, MinBalance: 5, PayableAccountNumber: riri})
+	if err != nil {
+		t.Fatalf("SetTaxRule: %v", err)
+	}
+	if rule.Currency != "USD" || rule.PayableAccountNumber != riri {
+		t.Errorf("SetTaxRule = %v", rule)
+	}
+	rules, err := h.accounts.ListTaxRules(h.ctx(), &bank.ListTaxRulesRequest{})
+	if err != nil || len(rules.Rules) != 1 || rules.Rules[0].Rate != 20 || rules.Rules[0].PayableAccountNumber != riri {
+		t.Fatalf("ListTaxRules = %v, %v", rules, err)
+	}
+
+	saver, payable := h.subscribe(h.ctx(), kate, ""), h.subscribe(h.ctx(), riri, "")
+
+	// May earns 0.03 on 10 USD, 20% of which is withheld
+	h.clock.Advance(31 * 24 * time.Hour)
+	if _, err := h.interest.Accrue(context.Background()); err != nil {
+		t.Fatalf("Accrue: %v", err)
+	}
+	if h.balance(kate) != 10.02 || h.balance(riri) != 10.01 {
+		t.Errorf("balances = %v, %v; want 10.02, 10.01", h.balance(kate), h.balance(riri))
+	}
+
+	if a := recvActivity(t, saver); a.Type != bank.TransactionType_TRANSACTION_TYPE_IN || a.Amount != 0.03 {
+		t.Errorf("first activity of the saver = %v, want the interest", a)
+	}
+	if a := recvActivity(t, saver); a.Type != bank.TransactionType_TRANSACTION_TYPE_OUT || a.Amount != 0.01 || a.Balance != 10.02 ||
+		a.CounterpartyAccountNumber != riri {
+		t.Errorf("second activity of the saver = %v, want the tax to %v", a, riri)
+	}
+	if a := recvActivity(t, payable); a.Type != bank.TransactionType_TRANSACTION_TYPE_IN || a.Amount != 0.01 || a.Balance != 10.01 ||
+		a.CounterpartyAccountNumber != kate {
+		t.Errorf("activity of the payable account = %v, want the tax of %v", a, kate)
+	}
+}
+
+func TestTaxExemption(t *testing.T) {
+	h := newHarness(t)
+	setUpSaver(t, h)
+
+	if _, err := h.accounts.SetTaxRule(h.ctx(), &bank.TaxRule{Currency: "USD", EffectiveFrom: &date.Date{Year: 2024, Month: 1, Day: 1},
+		Rate: 20, PayableAccountNumber: riri}); err != nil {
+		t.Fatalf("SetTaxRule: %v", err)
+	}
+	exemption, err := h.accounts.SetTaxExemption(h.ctx(), &bank.SetTaxExemptionRequest{AccountNumber: kate, Exempt: true})
+	if err != nil || !exemption.Exempt {
+		t.Fatalf("SetTaxExemption = %v, %v", exemption, err)
+	}
+
+	h.clock.Advance(31 * 24 * time.Hour)
+	if _, err := h.interest.Accrue(context.Background()); err != nil {
+		t.Fatalf("Accrue: %v", err)
+	}
+	if h.balance(kate) != 10.03 || h.balance(riri) != 10 {
+		t.Errorf("balances = %v, %v; want 10.03, 10", h.balance(kate), h.balance(riri))
+	}
+
+	_, err = h.accounts.SetTaxExemption(h.ctx(), &bank.SetTaxExemptionRequest{AccountNumber: "nope", Exempt: true})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.ResourceType != "account" {
+		t.Errorf("resource of SetTaxExemption of an unknown account = %q, want account", info.ResourceType)
+	}
+}
+
+func TestSetTaxRuleErrors(t *testing.T) {
+	h := newHarness(t)
+	may := &date.Date{Year: 2024, Month: 5, Day: 1}
+
+	for _, tt := range []struct {
+		rule  *bank.TaxRule
+		field string
+	}{
+		{&bank.TaxRule{EffectiveFrom: may, Rate: 20, PayableAccountNumber: riri}, "currency"},
+		{&bank.TaxRule{Currency: "USD", Rate: 20, PayableAccountNumber: riri}, "effective_from"},
+		{&bank.TaxRule{Currency: "USD", EffectiveFrom: may, Rate: 120, PayableAccountNumber: riri}, "rate"},
+		{&bank.TaxRule{Currency: "USD", EffectiveFrom: may, Rate: 20, MinBalance: -1, PayableAccountNumber: riri}, "min_balance"},
+		// the payable account holds another currency
+		{&bank.TaxRule{Currency: "IDR", EffectiveFrom: may, Rate: 20, PayableAccountNumber: riri}, "payable_account_number"},
+	} {
+		_, err := h.accounts.SetTaxRule(h.ctx(), tt.rule)
+		badRequest := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument)
+		if len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != tt.field {
+			t.Errorf("SetTaxRule(%v) violations = %v, want %v", tt.rule, badRequest.FieldViolations, tt.field)
+		}
+	}
+
+	_, err := h.accounts.SetTaxRule(h.ctx(), &bank.TaxRule{Currency: "USD", EffectiveFrom: may, Rate: 20, PayableAccountNumber: "nope"})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.ResourceName != "nope" {
+		t.Errorf("resource of SetTaxRule with an unknown payable account = %q, want nope", info.ResourceName)
+	}
+}
@@ -0,0 +1,249 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReverseTransfer refunds a completed transfer to its sender, all of what is
+// left to refund unless an amount is given.
+func (a *GrpcAdapter) ReverseTransfer(ctx context.Context, req *bank.ReverseTransferRequest) (*bank.ReverseTransferResponse, error) {
+	id, err := parseUuid("transfer_id", req.GetTransferId())
+	if err != nil {
+		return nil, err
+	}
+
+	reversal, remaining, err := a.bankService.ReverseTransfer(ctx, domainBank.TransferReversal{
+		TransferUuid:   id,
+		Amount:         req.GetAmount(),
+		Reason:         req.GetReason(),
+		AllowOverdraft: req.GetAllowOverdraft(),
+	})
+	if err != nil {
+		logErr := util.LogError("Error on ReverseTransfer : "+err.Error(), "", "Bank Adapter GRPC - ReverseTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, buildReversalErrorStatusGrpc(err, req)
+	}
+
+	return &bank.ReverseTransferResponse{
+		ReversalId:      reversal.TransferUuid.String(),
+		TransferId:      id.String(),
+		Amount:          reversal.Amount,
+		RemainingAmount: remaining,
+		Timestamp:       util.ToDatetime(reversal.TransferTimestamp),
+	}, nil
+}
+
+func buildReversalErrorStatusGrpc(err error, req *bank.ReverseTransferRequest) error {
+	switch {
+	case errors.Is(err, domainBank.ErrRecordNotFound):
+		return resourceNotFound("transfer", req.GetTransferId())
+	case errors.Is(err, domainBank.ErrReversalInvalid):
+		field := "amount"
+		if strings.TrimSpace(req.GetReason()) == "" {
+			field = "reason"
+		}
+		return badRequest(err, field)
+	case errors.Is(err, domainBank.ErrTransferNotReversible):
+		return reversalPreconditionFailure(err, "TRANSFER_NOT_REVERSIBLE", req)
+	case errors.Is(err, domainBank.ErrTransferAlreadyReversed):
+		return reversalPreconditionFailure(err, "ALREADY_REVERSED", req)
+	case errors.Is(err, domainBank.ErrReversalExceedsTransfer):
+		return reversalPreconditionFailure(err, "AMOUNT_EXCEEDS_REMAINING", req)
+	case errors.Is(err, domainBank.ErrInsufficientBalance):
+		return reversalPreconditionFailure(err, "INSUFFICIENT_BALANCE", req)
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func reversalPreconditionFailure(err error, violation string, req *bank.ReverseTransferRequest) error {
+	s := status.New(codes.FailedPrecondition, err.Error())
+	s, _ = s.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{
+				Type:        violation,
+				Subject:     "transfer " + req.GetTransferId(),
+				Description: err.Error(),
+			},
+		},
+	})
+
+	return s.Err()
+}
+
+// GetTransfer returns a transfer with its status, for clients that lost the
+// response of the call that made it.
+func (a *GrpcAdapter) GetTransfer(ctx context.Context, req *bank.GetTransferRequest) (*bank.Transfer, error) {
+	id, err := parseUuid("transfer_id", req.GetTransferId())
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := a.bankService.GetTransfer(ctx, id)
+	if err != nil {
+		if errors.Is(err, domainBank.ErrRecordNotFound) {
+			return nil, resourceNotFound("transfer", req.GetTransferId())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toTransferProto(transfer), nil
+}
+
+// ListTransfers pages through the transfers of an account, or of every
+// account, newest first.
+func (a *GrpcAdapter) ListTransfers(ctx context.Context, req *bank.ListTransfersRequest) (*bank.ListTransfersResponse, error) {
+	filter := domainBank.TransferFilter{
+		Limit: int(req.GetPageSize()),
+	}
+
+	switch req.GetDirection() {
+	case bank.TransferDirection_TRANSFER_DIRECTION_OUTGOING:
+		filter.Direction = domainBank.TransferDirectionOutgoing
+	case bank.TransferDirection_TRANSFER_DIRECTION_INCOMING:
+		filter.Direction = domainBank.TransferDirectionIncoming
+	}
+
+	switch req.GetStatus() {
+	case bank.TransferStatus_TRANSFER_STATUS_SUCCESS:
+		success := true
+		filter.Success = &success
+	case bank.TransferStatus_TRANSFER_STATUS_FAILED:
+		success := false
+		filter.Success = &success
+	}
+
+	if req.GetFromTime() != nil {
+		filter.From, _ = util.ToTime(req.GetFromTime())
+	}
+	if req.GetToTime() != nil {
+		filter.To, _ = util.ToTime(req.GetToTime())
+	}
+
+	if req.GetPageToken() != "" {
+		cursor, err := decodeTransferPageToken(req.GetPageToken())
+		if err != nil {
+			return nil, badRequest(err, "page_token")
+		}
+		filter.After = &cursor
+	}
+
+	transfers, next, err := a.bankService.ListTransfers(ctx, req.GetAccountNumber(), filter)
+	if err != nil {
+		logErr := util.LogError("Error on ListTransfers : "+err.Error(), "", "Bank Adapter GRPC - ListTransfers")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		switch {
+		case errors.Is(err, domainBank.ErrRecordNotFound):
+			return nil, resourceNotFound("account", req.GetAccountNumber())
+		case errors.Is(err, domainBank.ErrTransferFilterInvalid):
+			field := "to_time"
+			if req.GetAccountNumber() == "" && filter.Direction != domainBank.TransferDirectionAny {
+				field = "direction"
+			}
+			return nil, badRequest(err, field)
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	res := &bank.ListTransfersResponse{}
+	for _, t := range transfers {
+		res.Transfers = append(res.Transfers, toTransferProto(t))
+	}
+	if next != nil {
+		res.NextPageToken = encodeTransferPageToken(*next)
+	}
+
+	return res, nil
+}
+
+func toTransferProto(t domainBank.TransferDetail) *bank.Transfer {
+	res := &bank.Transfer{
+		TransferId:            t.TransferUuid.String(),
+		AccountNumberSender:   t.FromAccountNumber,
+		AccountNumberReciever: t.ToAccountNumber,
+		Currency:              t.Currency,
+		Amount:                t.Amount,
+		Status:                bank.TransferStatus_TRANSFER_STATUS_FAILED,
+		Timestamp:             util.ToDatetime(t.TransferTimestamp),
+		ReversalReason:        t.ReversalReason,
+		ReversedAmount:        t.ReversedAmount,
+	}
+	if t.TransferSuccess {
+		res.Status = bank.TransferStatus_TRANSFER_STATUS_SUCCESS
+	}
+	if t.ReversalOfUuid != nil {
+		res.ReversalOfTransferId = t.ReversalOfUuid.String()
+	}
+
+	return res
+}
+
+// encodeTransferPageToken makes cursor opaque to clients, which only hand it
+// back.
+func encodeTransferPageToken(cursor domainBank.TransferCursor) string {
+	raw := strconv.FormatInt(cursor.Timestamp.UnixNano(), 10) + "_" + cursor.TransferUuid.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTransferPageToken(token string) (domainBank.TransferCursor, error) {
+	invalid := errors.New("page_token is not a token returned by ListTransfers")
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domainBank.TransferCursor{}, invalid
+	}
+	nanos, id, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return domainBank.TransferCursor{}, invalid
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return domainBank.TransferCursor{}, invalid
+	}
+	transferUuid, err := uuid.Parse(id)
+	if err != nil {
+		return domainBank.TransferCursor{}, invalid
+	}
+
+	return domainBank.TransferCursor{Timestamp: time.Unix(0, n).UTC(), TransferUuid: transferUuid}, nil
+}
+
+func resourceNotFound(resource string, name string) error {
+	s := status.New(codes.NotFound, fmt.Sprintf("%v %v not found", resource, name))
+	s, _ = s.WithDetails(&errdetails.ResourceInfo{
+		ResourceType: resource,
+		ResourceName: name,
+		Description:  resource + " not found",
+	})
+
+	return s.Err()
+}
+
+func badRequest(err error, field string) error {
+	s := status.New(codes.InvalidArgument, err.Error())
+	s, _ = s.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: err.Error()},
+		},
+	})
+
+	return s.Err()
+}
@@ -0,0 +1,236 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainBatch "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/batch"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chunkSize bytes of a report or statement are sent per message.
+const chunkSize = 32 * 1024
+
+// transferBatchServer serves the TransferBatchService, registered by
+// GrpcAdapter.RegisterTransferBatches.
+type transferBatchServer struct {
+	batchService port.BatchServicePort
+	bank.UnimplementedTransferBatchServiceServer
+}
+
+var batchFormats = map[bank.TransferBatchFormat]string{
+	bank.TransferBatchFormat_TRANSFER_BATCH_FORMAT_CSV:      domainBatch.FormatCSV,
+	bank.TransferBatchFormat_TRANSFER_BATCH_FORMAT_PAIN_001: domainBatch.FormatPain001,
+}
+
+var batchModes = map[bank.TransferBatchMode]string{
+	bank.TransferBatchMode_TRANSFER_BATCH_MODE_UNSPECIFIED:    domainBatch.ModeAllOrNothing,
+	bank.TransferBatchMode_TRANSFER_BATCH_MODE_ALL_OR_NOTHING: domainBatch.ModeAllOrNothing,
+	bank.TransferBatchMode_TRANSFER_BATCH_MODE_BEST_EFFORT:    domainBatch.ModeBestEffort,
+}
+
+var batchStatuses = map[string]bank.TransferBatchStatus{
+	domainBatch.StatusRejected:           bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_REJECTED,
+	domainBatch.StatusAccepted:           bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_ACCEPTED,
+	domainBatch.StatusProcessing:         bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_PROCESSING,
+	domainBatch.StatusRollingBack:        bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_ROLLING_BACK,
+	domainBatch.StatusCompleted:          bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_COMPLETED,
+	domainBatch.StatusPartiallyCompleted: bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_PARTIALLY_COMPLETED,
+	domainBatch.StatusFailed:             bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_FAILED,
+}
+
+func (s *transferBatchServer) UploadTransferBatch(stream bank.TransferBatchService_UploadTransferBatchServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) || (err == nil && first.GetMetadata() == nil) {
+		return badRequest(errors.New("the first message must carry the metadata"), "metadata")
+	}
+	if err != nil {
+		return err
+	}
+	meta := first.GetMetadata()
+
+	upload := domainBatch.Upload{
+		Format:        batchFormats[meta.GetFormat()],
+		Mode:          batchModes[meta.GetMode()],
+		MessageId:     meta.GetMessageId(),
+		DebtorAccount: meta.GetDebtorAccountNumber(),
+		Channel:       meta.GetChannel(),
+	}
+	if upload.Format == "" {
+		return badRequest(domainBatch.ErrFormatInvalid, "metadata.format")
+	}
+	if upload.Mode == "" {
+		return badRequest(domainBatch.ErrModeInvalid, "metadata.mode")
+	}
+	if meta.NumberOfTransactions != nil {
+		n := int(meta.GetNumberOfTransactions())
+		upload.NumberOfTransactions = &n
+	}
+	if meta.ControlSum != nil {
+		sum := meta.GetControlSum()
+		upload.ControlSum = &sum
+	}
+
+	// the file is parsed as its chunks arrive, a failed receive is reported
+	// before it closes the pipe, so it is known once the import fails
+	pr, pw := io.Pipe()
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				pw.Close()
+				return
+			}
+			if err == nil && req.GetMetadata() != nil {
+				err = badRequest(errors.New("only the first message may carry the metadata"), "metadata")
+			}
+			if err != nil {
+				recvErr <- err
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := pw.Write(req.GetChunk()); err != nil {
+				// the import stopped reading
+				return
+			}
+		}
+	}()
+
+	detail, err := s.batchService.ImportTransferBatch(ctx, upload, pr)
+	pr.Close()
+	if err != nil {
+		select {
+		case err := <-recvErr:
+			return err
+		default:
+		}
+
+		logErr := util.LogError("Error on ImportTransferBatch : "+err.Error(), "", "Transfer Batch GRPC - UploadTransferBatch")
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		switch {
+		case errors.Is(err, domainBatch.ErrFormatInvalid):
+			return badRequest(err, "metadata.format")
+		case errors.Is(err, domainBatch.ErrModeInvalid):
+			return badRequest(err, "metadata.mode")
+		case errors.Is(err, domainBatch.ErrMessageIdInvalid):
+			return badRequest(err, "metadata.message_id")
+		case errors.Is(err, domainBatch.ErrFileInvalid), errors.Is(err, domainBatch.ErrBatchEmpty),
+			errors.Is(err, domainBatch.ErrFileTooLarge), errors.Is(err, domainBatch.ErrTooManyItems):
+			return badRequest(err, "chunk")
+		case errors.Is(err, domainBatch.ErrDuplicateMessage):
+			st := status.New(codes.AlreadyExists, err.Error())
+			st, _ = st.WithDetails(&errdetails.ResourceInfo{
+				ResourceType: "transfer_batch",
+				ResourceName: meta.GetMessageId(),
+				Description:  err.Error(),
+			})
+			return st.Err()
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return stream.SendAndClose(toTransferBatchProto(detail))
+}
+
+func (s *transferBatchServer) GetTransferBatch(ctx context.Context, req *bank.TransferBatchRequest) (*bank.TransferBatch, error) {
+	id, err := parseUuid("batch_id", req.GetBatchId())
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := s.batchService.GetTransferBatch(ctx, id)
+	if err != nil {
+		return nil, buildBatchErrorStatusGrpc(err, req.GetBatchId())
+	}
+
+	return toTransferBatchProto(detail), nil
+}
+
+// chunkWriter sends what is written to it as a chunk of a server stream.
+type chunkWriter func(p []byte) error
+
+func (send chunkWriter) Write(p []byte) (int, error) {
+	// the message is marshalled before Send returns, p may be reused after
+	if err := send(p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (s *transferBatchServer) GetTransferBatchReport(req *bank.TransferBatchRequest, stream bank.TransferBatchService_GetTransferBatchReportServer) error {
+	id, err := parseUuid("batch_id", req.GetBatchId())
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriterSize(chunkWriter(func(p []byte) error {
+		return stream.Send(&bank.TransferBatchReportChunk{Data: p})
+	}), chunkSize)
+	if err := s.batchService.WriteTransferBatchReport(stream.Context(), id, w); err != nil {
+		return buildBatchErrorStatusGrpc(err, req.GetBatchId())
+	}
+	if err := w.Flush(); err != nil {
+		return buildBatchErrorStatusGrpc(err, req.GetBatchId())
+	}
+
+	return nil
+}
+
+func buildBatchErrorStatusGrpc(err error, batchId string) error {
+	if errors.Is(err, domainBank.ErrRecordNotFound) {
+		return resourceNotFound("transfer_batch", batchId)
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toTransferBatchProto(d domainBatch.BatchDetail) *bank.TransferBatch {
+	res := &bank.TransferBatch{
+		BatchId:              d.BatchUuid.String(),
+		MessageId:            d.MessageId,
+		Status:               batchStatuses[d.Status],
+		NumberOfTransactions: int32(d.NumberOfTransactions),
+		ControlSum:           d.ControlSum,
+		ReasonCode:           d.ReasonCode,
+		Reason:               d.Reason,
+		Pending:              int32(d.Counts[domainBatch.ItemPending]),
+		Succeeded:            int32(d.Counts[domainBatch.ItemSucceeded]),
+		Failed:               int32(d.Counts[domainBatch.ItemFailed]),
+		Rejected:             int32(d.Counts[domainBatch.ItemRejected]),
+		Cancelled:            int32(d.Counts[domainBatch.ItemCancelled]),
+		Reversed:             int32(d.Counts[domainBatch.ItemReversed]),
+		ReversalFailed:       int32(d.Counts[domainBatch.ItemReversalFailed]),
+		CreatedAt:            util.ToDatetime(d.CreatedAt),
+	}
+	for format, name := range batchFormats {
+		if name == d.Format {
+			res.Format = format
+		}
+	}
+	res.Mode = bank.TransferBatchMode_TRANSFER_BATCH_MODE_ALL_OR_NOTHING
+	if d.Mode == domainBatch.ModeBestEffort {
+		res.Mode = bank.TransferBatchMode_TRANSFER_BATCH_MODE_BEST_EFFORT
+	}
+	if d.CompletedAt != nil {
+		res.CompletedAt = util.ToDatetime(*d.CompletedAt)
+	}
+
+	return res
+}
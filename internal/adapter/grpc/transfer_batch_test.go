@@ -0,0 +1,299 @@
+package grpc_test
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/memory"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// cassie is another account of the demo seed profile.
+const cassie = "7835697003"
+
+// upload sends file after meta in chunks of a few bytes, so the file is
+// parsed across many messages.
+func (h *harness) upload(meta *bank.TransferBatchMetadata, file string) (*bank.TransferBatch, error) {
+	h.t.Helper()
+
+	stream, err := h.batches.UploadTransferBatch(h.ctx())
+	if err != nil {
+		h.t.Fatalf("UploadTransferBatch: %v", err)
+	}
+	if meta != nil {
+		if err := stream.Send(&bank.UploadTransferBatchRequest{Content: &bank.UploadTransferBatchRequest_Metadata{Metadata: meta}}); err != nil {
+			h.t.Fatalf("Send: %v", err)
+		}
+	}
+	for data := []byte(file); len(data) > 0; {
+		n := min(len(data), 16)
+		// the server fails the stream early on a file it rejects
+		if err := stream.Send(&bank.UploadTransferBatchRequest{Content: &bank.UploadTransferBatchRequest_Chunk{Chunk: data[:n]}}); err != nil {
+			break
+		}
+		data = data[n:]
+	}
+
+	return stream.CloseAndRecv()
+}
+
+func (h *harness) executeBatches() int {
+	h.t.Helper()
+
+	n, err := h.batcher.Execute(h.ctx())
+	if err != nil {
+		h.t.Fatalf("Execute: %v", err)
+	}
+
+	return n
+}
+
+type reportTransaction struct {
+	EndToEndId string `xml:"OrgnlEndToEndId"`
+	Status     string `xml:"TxSts"`
+	Code       string `xml:"StsRsnInf>Rsn>Cd"`
+}
+
+// report fetches the status report of batch id and returns its group status
+// and transactions.
+func (h *harness) report(id string) (string, []reportTransaction) {
+	h.t.Helper()
+
+	stream, err := h.batches.GetTransferBatchReport(h.ctx(), &bank.TransferBatchRequest{BatchId: id})
+	if err != nil {
+		h.t.Fatalf("GetTransferBatchReport: %v", err)
+	}
+	var data []byte
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			h.t.Fatalf("Recv: %v", err)
+		}
+		data = append(data, chunk.GetData()...)
+	}
+
+	var report struct {
+		GroupStatus  string              `xml:"CstmrPmtStsRpt>OrgnlGrpInfAndSts>GrpSts"`
+		Transactions []reportTransaction `xml:"CstmrPmtStsRpt>OrgnlPmtInfAndSts>TxInfAndSts"`
+	}
+	if err := xml.Unmarshal(data, &report); err != nil {
+		h.t.Fatalf("report doesn't parse : %v\n%s", err, data)
+	}
+
+	return report.GroupStatus, report.Transactions
+}
+
+func TestTransferBatchBestEffort(t *testing.T) {
+	h := newHarness(t)
+
+	batch, err := h.upload(&bank.TransferBatchMetadata{
+		Format: bank.TransferBatchFormat_TRANSFER_BATCH_FORMAT_CSV, Mode: bank.TransferBatchMode_TRANSFER_BATCH_MODE_BEST_EFFORT,
+		DebtorAccountNumber: kate,
+	}, "end_to_end_id,creditor_account,amount,currency,remittance_information\n"+
+		"PAY-1,"+riri+",2,USD,May salary\n"+
+		"PAY-2,"+ghost+",1,USD,\n"+
+		"PAY-3,"+cassie+",20,usd,\n")
+	if err != nil {
+		t.Fatalf("UploadTransferBatch: %v", err)
+	}
+	if batch.Status != bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_ACCEPTED || batch.NumberOfTransactions != 3 || batch.ControlSum != 23 ||
+		batch.Pending != 2 || batch.Rejected != 1 || len(batch.MessageId) != 32 {
+		t.Fatalf("UploadTransferBatch = %v, want accepted with the unknown creditor rejected", batch)
+	}
+
+	if n := h.executeBatches(); n != 1 {
+		t.Errorf("Execute = %v, want 1", n)
+	}
+	batch, err = h.batches.GetTransferBatch(h.ctx(), &bank.TransferBatchRequest{BatchId: batch.BatchId})
+	if err != nil {
+		t.Fatalf("GetTransferBatch: %v", err)
+	}
+	if batch.Status != bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_PARTIALLY_COMPLETED || batch.Succeeded != 1 || batch.Failed != 1 || batch.CompletedAt == nil {
+		t.Errorf("GetTransferBatch = %v, want partially completed", batch)
+	}
+	if h.balance(kate) != 8 || h.balance(riri) != 12 {
+		t.Errorf("balances = %v, %v; want 8, 12", h.balance(kate), h.balance(riri))
+	}
+
+	status, transactions := h.report(batch.BatchId)
+	want := []reportTransaction{{"PAY-1", "ACSC", ""}, {"PAY-2", "RJCT", "AC03"}, {"PAY-3", "RJCT", "AM04"}}
+	if status != "PART" || len(transactions) != len(want) {
+		t.Fatalf("report = %v %+v, want PART with 3 transactions", status, transactions)
+	}
+	for i, w := range want {
+		if transactions[i] != w {
+			t.Errorf("report transaction %d = %+v, want %+v", i, transactions[i], w)
+		}
+	}
+}
+
+const payroll = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03">
+  <CstmrCdtTrfInitn>
+    <GrpHdr><MsgId>PAYROLL-MAY</MsgId><NbOfTxs>2</NbOfTxs><CtrlSum>7</CtrlSum></GrpHdr>
+    <PmtInf>
+      <DbtrAcct><Id><Othr><Id>7835697001</Id></Othr></Id></DbtrAcct>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>SALARY-RIRI</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">3</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>7835697002</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>SALARY-CASSIE</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">4</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>7835697003</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>
+`
+
+func TestTransferBatchAllOrNothing(t *testing.T) {
+	h := newHarness(t)
+	meta := &bank.TransferBatchMetadata{Format: bank.TransferBatchFormat_TRANSFER_BATCH_FORMAT_PAIN_001}
+
+	batch, err := h.upload(meta, payroll)
+	if err != nil {
+		t.Fatalf("UploadTransferBatch: %v", err)
+	}
+	if batch.Status != bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_ACCEPTED || batch.MessageId != "PAYROLL-MAY" || batch.Pending != 2 ||
+		batch.Mode != bank.TransferBatchMode_TRANSFER_BATCH_MODE_ALL_OR_NOTHING {
+		t.Fatalf("UploadTransferBatch = %v, want accepted", batch)
+	}
+
+	// kate spends most of the money before the batch runs, the second
+	// salary fails and the first is reversed
+	if _, err := h.transferRequest(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 5}); err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	h.executeBatches()
+
+	batch, err = h.batches.GetTransferBatch(h.ctx(), &bank.TransferBatchRequest{BatchId: batch.BatchId})
+	if err != nil {
+		t.Fatalf("GetTransferBatch: %v", err)
+	}
+	if batch.Status != bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_FAILED || batch.Reversed != 1 || batch.Failed != 1 || batch.ReasonCode != "AM04" {
+		t.Errorf("GetTransferBatch = %v, want failed with the first salary reversed", batch)
+	}
+	if h.balance(kate) != 5 || h.balance(riri) != 15 || h.balance(cassie) != 10 {
+		t.Errorf("balances = %v, %v, %v; want 5, 15, 10", h.balance(kate), h.balance(riri), h.balance(cassie))
+	}
+	if status, _ := h.report(batch.BatchId); status != "RJCT" {
+		t.Errorf("report status = %v, want RJCT", status)
+	}
+
+	// the same payroll again is refused, under another id it is rejected up
+	// front as kate can't pay it
+	_, err = h.upload(meta, payroll)
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.AlreadyExists)
+
+	meta.MessageId = "PAYROLL-MAY-2"
+	batch, err = h.upload(meta, payroll)
+	if err != nil {
+		t.Fatalf("UploadTransferBatch: %v", err)
+	}
+	if batch.Status != bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_REJECTED || batch.Rejected != 2 || batch.CompletedAt == nil {
+		t.Errorf("UploadTransferBatch beyond the balance = %v, want rejected", batch)
+	}
+	if _, transactions := h.report(batch.BatchId); len(transactions) != 2 || transactions[0].Code != "AM04" {
+		t.Errorf("report of the rejected batch = %+v, want AM04", transactions)
+	}
+}
+
+// reversalStore reads every transfer as a reversal, which can't be reversed.
+type reversalStore struct {
+	*memory.MemoryAdapter
+}
+
+func (s reversalStore) GetTransfer(ctx context.Context, transferUuid uuid.UUID) (domainBank.BankTransferOrm, error) {
+	trf, err := s.MemoryAdapter.GetTransfer(ctx, transferUuid)
+	original := uuid.New()
+	trf.ReversalOfUuid = &original
+
+	return trf, err
+}
+
+func TestTransferBatchRollbackCantReverse(t *testing.T) {
+	h := newHarnessWithBankStore(t, func(store *memory.MemoryAdapter) port.BankDatabasePort { return reversalStore{store} })
+
+	batch, err := h.upload(&bank.TransferBatchMetadata{Format: bank.TransferBatchFormat_TRANSFER_BATCH_FORMAT_PAIN_001}, payroll)
+	if err != nil {
+		t.Fatalf("UploadTransferBatch: %v", err)
+	}
+	if _, err := h.transferRequest(&bank.TransferRequest{AccountNumberSender: kate, AccountNumberReciever: riri, Currency: "USD", Amount: 5}); err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	h.executeBatches()
+
+	// the first salary stays paid, and the batch and its report say so
+	batch, err = h.batches.GetTransferBatch(h.ctx(), &bank.TransferBatchRequest{BatchId: batch.BatchId})
+	if err != nil {
+		t.Fatalf("GetTransferBatch: %v", err)
+	}
+	if batch.Status != bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_FAILED || batch.ReversalFailed != 1 || batch.Reversed != 0 || batch.Succeeded != 0 ||
+		!strings.HasSuffix(batch.Reason, "1 transfers can't be reversed") {
+		t.Errorf("GetTransferBatch = %v, want failed with the first salary not reversed", batch)
+	}
+	if h.balance(kate) != 2 || h.balance(riri) != 18 {
+		t.Errorf("balances = %v, %v; want 2, 18", h.balance(kate), h.balance(riri))
+	}
+
+	status, transactions := h.report(batch.BatchId)
+	if status != "RJCT" || len(transactions) != 2 {
+		t.Fatalf("report = %v, %+v; want RJCT with both salaries", status, transactions)
+	}
+	if salary := transactions[0]; salary.EndToEndId != "SALARY-RIRI" || salary.Status != "ACSC" || salary.Code != "NARR" {
+		t.Errorf("report of the salary not reversed = %+v, want ACSC with NARR", salary)
+	}
+}
+
+func TestTransferBatchErrors(t *testing.T) {
+	h := newHarness(t)
+	csv := &bank.TransferBatchMetadata{Format: bank.TransferBatchFormat_TRANSFER_BATCH_FORMAT_CSV, DebtorAccountNumber: kate}
+
+	for _, tt := range []struct {
+		name  string
+		meta  *bank.TransferBatchMetadata
+		file  string
+		field string
+	}{
+		{"no metadata", nil, "amount\n", "metadata"},
+		{"no format", &bank.TransferBatchMetadata{}, "amount\n", "metadata.format"},
+		{"unknown column", csv, "amount,currency,creditor_account,iban\n", "chunk"},
+		{"no transactions", csv, "amount,currency,creditor_account\n", "chunk"},
+		{"too large", csv, "amount,currency,creditor_account\n" + strings.Repeat("1,USD,"+riri+"\n", 300), "chunk"},
+	} {
+		_, err := h.upload(tt.meta, tt.file)
+		if violation := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument); violation.FieldViolations[0].Field != tt.field {
+			t.Errorf("%v: field = %v, want %v", tt.name, violation.FieldViolations[0].Field, tt.field)
+		}
+	}
+
+	// totals that don't match the file reject it
+	count := int32(1)
+	batch, err := h.upload(&bank.TransferBatchMetadata{Format: bank.TransferBatchFormat_TRANSFER_BATCH_FORMAT_CSV, DebtorAccountNumber: kate,
+		NumberOfTransactions: &count}, "amount,currency,creditor_account\n1,USD,"+riri+"\n2,USD,"+riri+"\n")
+	if err != nil {
+		t.Fatalf("UploadTransferBatch: %v", err)
+	}
+	if batch.Status != bank.TransferBatchStatus_TRANSFER_BATCH_STATUS_REJECTED || batch.ReasonCode != "AM18" || batch.Cancelled != 2 {
+		t.Errorf("UploadTransferBatch with a wrong count = %v, want rejected for AM18", batch)
+	}
+
+	_, err = h.batches.GetTransferBatch(h.ctx(), &bank.TransferBatchRequest{BatchId: "nope"})
+	errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument)
+	_, err = h.batches.GetTransferBatch(h.ctx(), &bank.TransferBatchRequest{BatchId: "3b8a6a2e-7e36-4c4e-9a53-5b8c1f0c9a10"})
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+}
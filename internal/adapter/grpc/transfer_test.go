@@ -0,0 +1,221 @@
+package grpc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/googleapis/type/datetime"
+	"google.golang.org/grpc/codes"
+)
+
+// transfer moves amount USD from one account to another and returns the id
+// of the transfer.
+func (h *harness) transfer(from, to string, amount float64) string {
+	h.t.Helper()
+
+	stream, err := h.client.TransferMultiple(h.ctx())
+	if err != nil {
+		h.t.Fatalf("TransferMultiple: %v", err)
+	}
+	defer stream.CloseSend()
+
+	if err := stream.Send(&bank.TransferRequest{AccountNumberSender: from, AccountNumberReciever: to, Currency: "USD", Amount: amount}); err != nil {
+		h.t.Fatalf("Send: %v", err)
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		h.t.Fatalf("Recv: %v", err)
+	}
+	if res.Status != bank.TransferStatus_TRANSFER_STATUS_SUCCESS || res.TransferId == "" {
+		h.t.Fatalf("transfer response = %v, want a successful transfer with its id", res)
+	}
+
+	return res.TransferId
+}
+
+func TestReverseTransfer(t *testing.T) {
+	h := newHarness(t)
+	transferId := h.transfer(kate, riri, 4)
+
+	partial, err := h.client.ReverseTransfer(h.ctx(), &bank.ReverseTransferRequest{TransferId: transferId, Reason: "charged twice", Amount: 1.5})
+	if err != nil {
+		t.Fatalf("ReverseTransfer: %v", err)
+	}
+	if partial.TransferId != transferId || partial.Amount != 1.5 || partial.RemainingAmount != 2.5 || partial.ReversalId == "" {
+		t.Errorf("partial reversal = %v, want 1.5 refunded and 2.5 left", partial)
+	}
+	if h.balance(kate) != 7.5 || h.balance(riri) != 12.5 {
+		t.Errorf("balances after the partial reversal = %v, %v; want 7.5, 12.5", h.balance(kate), h.balance(riri))
+	}
+
+	rest, err := h.client.ReverseTransfer(h.ctx(), &bank.ReverseTransferRequest{TransferId: transferId, Reason: "wrong recipient"})
+	if err != nil {
+		t.Fatalf("ReverseTransfer of the rest: %v", err)
+	}
+	if rest.Amount != 2.5 || rest.RemainingAmount != 0 {
+		t.Errorf("reversal of the rest = %v, want 2.5 refunded and nothing left", rest)
+	}
+	if h.balance(kate) != 10 || h.balance(riri) != 10 {
+		t.Errorf("balances after the full reversal = %v, %v; want 10, 10", h.balance(kate), h.balance(riri))
+	}
+
+	_, err = h.client.ReverseTransfer(h.ctx(), &bank.ReverseTransferRequest{TransferId: transferId, Reason: "again"})
+	if got := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition); got.Violations[0].Type != "ALREADY_REVERSED" {
+		t.Errorf("second full reversal violation = %v, want ALREADY_REVERSED", got.Violations[0].Type)
+	}
+
+	_, err = h.client.ReverseTransfer(h.ctx(), &bank.ReverseTransferRequest{TransferId: rest.ReversalId, Reason: "undo the undo"})
+	if got := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition); got.Violations[0].Type != "TRANSFER_NOT_REVERSIBLE" {
+		t.Errorf("reversal of a reversal violation = %v, want TRANSFER_NOT_REVERSIBLE", got.Violations[0].Type)
+	}
+}
+
+func TestReverseTransferOverdraft(t *testing.T) {
+	h := newHarness(t)
+	transferId := h.transfer(kate, riri, 5)
+	// riri spends the money
+	h.transfer(riri, kate, 15)
+
+	_, err := h.client.ReverseTransfer(h.ctx(), &bank.ReverseTransferRequest{TransferId: transferId, Reason: "fraud"})
+	if got := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition); got.Violations[0].Type != "INSUFFICIENT_BALANCE" {
+		t.Errorf("overdrawing reversal violation = %v, want INSUFFICIENT_BALANCE", got.Violations[0].Type)
+	}
+
+	if _, err := h.client.ReverseTransfer(h.ctx(), &bank.ReverseTransferRequest{TransferId: transferId, Reason: "fraud", AllowOverdraft: true}); err != nil {
+		t.Fatalf("ReverseTransfer with overdraft: %v", err)
+	}
+	if h.balance(riri) != -5 || h.balance(kate) != 25 {
+		t.Errorf("balances = %v, %v; want -5, 25", h.balance(riri), h.balance(kate))
+	}
+}
+
+func TestReverseTransferErrors(t *testing.T) {
+	h := newHarness(t)
+	transferId := h.transfer(kate, riri, 4)
+
+	tests := []struct {
+		name  string
+		req   *bank.ReverseTransferRequest
+		field string
+	}{
+		{"malformed id", &bank.ReverseTransferRequest{TransferId: "nope", Reason: "x"}, "transfer_id"},
+		{"no reason", &bank.ReverseTransferRequest{TransferId: transferId, Reason: " "}, "reason"},
+		{"negative amount", &bank.ReverseTransferRequest{TransferId: transferId, Reason: "x", Amount: -1}, "amount"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := h.client.ReverseTransfer(h.ctx(), tt.req)
+			if got := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument); got.FieldViolations[0].Field != tt.field {
+				t.Errorf("field = %v, want %v", got.FieldViolations[0].Field, tt.field)
+			}
+		})
+	}
+
+	_, err := h.client.ReverseTransfer(h.ctx(), &bank.ReverseTransferRequest{TransferId: transferId, Reason: "x", Amount: 4.01})
+	if got := errorDetail[*errdetails.PreconditionFailure](t, err, codes.FailedPrecondition); got.Violations[0].Type != "AMOUNT_EXCEEDS_REMAINING" {
+		t.Errorf("violation = %v, want AMOUNT_EXCEEDS_REMAINING", got.Violations[0].Type)
+	}
+
+	unknown := uuid.NewString()
+	_, err = h.client.ReverseTransfer(h.ctx(), &bank.ReverseTransferRequest{TransferId: unknown, Reason: "x"})
+	if got := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); got.ResourceName != unknown {
+		t.Errorf("resource = %v, want %v", got.ResourceName, unknown)
+	}
+}
+
+func TestGetTransfer(t *testing.T) {
+	h := newHarness(t)
+	transferId := h.transfer(kate, riri, 4)
+	reversal, err := h.client.ReverseTransfer(h.ctx(), &bank.ReverseTransferRequest{TransferId: transferId, Reason: "charged twice", Amount: 1})
+	if err != nil {
+		t.Fatalf("ReverseTransfer: %v", err)
+	}
+
+	got, err := h.client.GetTransfer(h.ctx(), &bank.GetTransferRequest{TransferId: transferId})
+	if err != nil {
+		t.Fatalf("GetTransfer: %v", err)
+	}
+	if got.AccountNumberSender != kate || got.AccountNumberReciever != riri || got.Amount != 4 ||
+		got.Status != bank.TransferStatus_TRANSFER_STATUS_SUCCESS || got.ReversedAmount != 1 || got.Timestamp == nil {
+		t.Errorf("GetTransfer = %v", got)
+	}
+
+	got, err = h.client.GetTransfer(h.ctx(), &bank.GetTransferRequest{TransferId: reversal.ReversalId})
+	if err != nil {
+		t.Fatalf("GetTransfer of the reversal: %v", err)
+	}
+	if got.ReversalOfTransferId != transferId || got.ReversalReason != "charged twice" || got.AccountNumberSender != riri {
+		t.Errorf("GetTransfer of the reversal = %v", got)
+	}
+
+	_, err = h.client.GetTransfer(h.ctx(), &bank.GetTransferRequest{TransferId: uuid.NewString()})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.ResourceType != "transfer" {
+		t.Errorf("resource of an unknown transfer = %v", info.ResourceType)
+	}
+	_, err = h.client.GetTransfer(h.ctx(), &bank.GetTransferRequest{TransferId: "42"})
+	errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument)
+}
+
+func TestListTransfers(t *testing.T) {
+	h := newHarness(t)
+	first := h.transfer(kate, riri, 1)
+	second := h.transfer(riri, kate, 2)
+	third := h.transfer(kate, riri, 3)
+
+	// page through the transfers of kate one at a time
+	var ids []string
+	req := &bank.ListTransfersRequest{AccountNumber: kate, PageSize: 1}
+	for {
+		res, err := h.client.ListTransfers(h.ctx(), req)
+		if err != nil {
+			t.Fatalf("ListTransfers: %v", err)
+		}
+		for _, trf := range res.Transfers {
+			ids = append(ids, trf.TransferId)
+		}
+		if res.NextPageToken == "" {
+			break
+		}
+		req.PageToken = res.NextPageToken
+	}
+	if len(ids) != 3 || !slices.Contains(ids, first) || !slices.Contains(ids, second) || !slices.Contains(ids, third) {
+		t.Errorf("pages of kate = %v, want the 3 transfers once each", ids)
+	}
+
+	outgoing, err := h.client.ListTransfers(h.ctx(), &bank.ListTransfersRequest{AccountNumber: riri, Direction: bank.TransferDirection_TRANSFER_DIRECTION_OUTGOING})
+	if err != nil {
+		t.Fatalf("ListTransfers outgoing: %v", err)
+	}
+	if len(outgoing.Transfers) != 1 || outgoing.Transfers[0].TransferId != second || outgoing.NextPageToken != "" {
+		t.Errorf("outgoing transfers of riri = %v, want %v only", outgoing.Transfers, second)
+	}
+
+	failed, err := h.client.ListTransfers(h.ctx(), &bank.ListTransfersRequest{Status: bank.TransferStatus_TRANSFER_STATUS_FAILED})
+	if err != nil {
+		t.Fatalf("ListTransfers failed: %v", err)
+	}
+	if len(failed.Transfers) != 0 {
+		t.Errorf("failed transfers = %v, want none", failed.Transfers)
+	}
+
+	_, err = h.client.ListTransfers(h.ctx(), &bank.ListTransfersRequest{AccountNumber: ghost})
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+
+	for field, req := range map[string]*bank.ListTransfersRequest{
+		"direction":  {Direction: bank.TransferDirection_TRANSFER_DIRECTION_INCOMING},
+		"page_token": {AccountNumber: kate, PageToken: "not-a-token"},
+		"to_time": {
+			AccountNumber: kate,
+			FromTime:      &datetime.DateTime{Year: 2024, Month: 5, Day: 2},
+			ToTime:        &datetime.DateTime{Year: 2024, Month: 5, Day: 1},
+		},
+	} {
+		_, err := h.client.ListTransfers(h.ctx(), req)
+		if got := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument); got.FieldViolations[0].Field != field {
+			t.Errorf("violation = %v, want %v", got.FieldViolations[0].Field, field)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// webhookAdminServer serves the WebhookAdminService, registered by
+// GrpcAdapter.RegisterWebhookAdmin.
+type webhookAdminServer struct {
+	webhookService port.WebhookServicePort
+	bank.UnimplementedWebhookAdminServiceServer
+}
+
+func (w *webhookAdminServer) CreateWebhookSubscription(ctx context.Context, req *bank.CreateWebhookSubscriptionRequest) (*bank.WebhookSubscription, error) {
+	sub, err := w.webhookService.CreateSubscription(ctx, req.GetAccountNumber(), req.GetCustomerId(), req.GetUrl(), req.GetEventTypes(), req.GetSecret())
+	if err != nil {
+		logErr := util.LogError("Error on CreateSubscription : "+err.Error(), "", "Webhook Adapter GRPC - CreateWebhookSubscription")
+		log.Error().Ctx(ctx).Msg(logErr)
+		if req.GetCustomerId() != "" && req.GetAccountNumber() == "" {
+			return nil, buildWebhookErrorStatusGrpc(err, "customer", req.GetCustomerId())
+		}
+		return nil, buildWebhookErrorStatusGrpc(err, "account", req.GetAccountNumber())
+	}
+
+	// the secret is only shown once
+	res := toWebhookSubscriptionProto(sub)
+	res.Secret = sub.Secret
+
+	return res, nil
+}
+
+func (w *webhookAdminServer) ListWebhookSubscriptions(ctx context.Context, req *bank.ListWebhookSubscriptionsRequest) (*bank.ListWebhookSubscriptionsResponse, error) {
+	subs, err := w.webhookService.ListSubscriptions(ctx, req.GetAccountNumber(), req.GetCustomerId())
+	if err != nil {
+		return nil, buildWebhookErrorStatusGrpc(err, "account", req.GetAccountNumber())
+	}
+
+	res := &bank.ListWebhookSubscriptionsResponse{}
+	for _, sub := range subs {
+		res.Subscriptions = append(res.Subscriptions, toWebhookSubscriptionProto(sub))
+	}
+
+	return res, nil
+}
+
+func (w *webhookAdminServer) DeleteWebhookSubscription(ctx context.Context, req *bank.DeleteWebhookSubscriptionRequest) (*bank.DeleteWebhookSubscriptionResponse, error) {
+	id, err := parseUuid("subscription_id", req.GetSubscriptionId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.webhookService.DeleteSubscription(ctx, id); err != nil {
+		return nil, buildWebhookErrorStatusGrpc(err, "webhook_subscription", req.GetSubscriptionId())
+	}
+
+	return &bank.DeleteWebhookSubscriptionResponse{}, nil
+}
+
+func (w *webhookAdminServer) ListWebhookDeliveries(ctx context.Context, req *bank.ListWebhookDeliveriesRequest) (*bank.ListWebhookDeliveriesResponse, error) {
+	filter := domainWebhook.DeliveryFilter{
+		State: fromWebhookDeliveryStateProto(req.GetState()),
+		Limit: int(req.GetLimit()),
+	}
+
+	if req.GetSubscriptionId() != "" {
+		id, err := parseUuid("subscription_id", req.GetSubscriptionId())
+		if err != nil {
+			return nil, err
+		}
+		filter.SubscriptionUuid = id
+	}
+
+	deliveries, err := w.webhookService.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, buildWebhookErrorStatusGrpc(err, "webhook_subscription", req.GetSubscriptionId())
+	}
+
+	res := &bank.ListWebhookDeliveriesResponse{}
+	for _, d := range deliveries {
+		res.Deliveries = append(res.Deliveries, toWebhookDeliveryProto(d))
+	}
+
+	return res, nil
+}
+
+func (w *webhookAdminServer) RedeliverWebhook(ctx context.Context, req *bank.RedeliverWebhookRequest) (*bank.WebhookDelivery, error) {
+	id, err := parseUuid("delivery_id", req.GetDeliveryId())
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := w.webhookService.Redeliver(ctx, id)
+	if err != nil {
+		return nil, buildWebhookErrorStatusGrpc(err, "webhook_delivery", req.GetDeliveryId())
+	}
+
+	return toWebhookDeliveryProto(delivery), nil
+}
+
+func parseUuid(field string, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		s := status.New(codes.InvalidArgument, fmt.Sprintf("%v %q is not a uuid", field, value))
+		s, _ = s.WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: field, Description: "must be a uuid"},
+			},
+		})
+
+		return uuid.Nil, s.Err()
+	}
+
+	return id, nil
+}
+
+func toWebhookSubscriptionProto(sub domainWebhook.WebhookSubscriptionOrm) *bank.WebhookSubscription {
+	return &bank.WebhookSubscription{
+		SubscriptionId: sub.SubscriptionUuid.String(),
+		AccountNumber:  sub.AccountNumber,
+		CustomerId:     sub.CustomerId,
+		Url:            sub.Url,
+		EventTypes:     sub.EventTypes,
+		CreatedAt:      util.ToDatetime(sub.CreatedAt),
+	}
+}
+
+func toWebhookDeliveryProto(d domainWebhook.WebhookDeliveryOrm) *bank.WebhookDelivery {
+	res := &bank.WebhookDelivery{
+		DeliveryId:     d.DeliveryUuid.String(),
+		SubscriptionId: d.SubscriptionUuid.String(),
+		EventId:        d.EventUuid.String(),
+		EventType:      d.EventType,
+		State:          toWebhookDeliveryStateProto(d.State),
+		AttemptCount:   int32(d.AttemptCount),
+		LastError:      d.LastError,
+		CreatedAt:      util.ToDatetime(d.CreatedAt),
+	}
+	if d.State == domainWebhook.StatePending {
+		res.NextAttemptAt = util.ToDatetime(d.NextAttemptAt)
+	}
+
+	for _, attempt := range d.Attempts {
+		res.Attempts = append(res.Attempts, &bank.WebhookDeliveryAttempt{
+			AttemptedAt: util.ToDatetime(attempt.AttemptedAt),
+			StatusCode:  int32(attempt.StatusCode),
+			Error:       attempt.Error,
+			DurationMs:  attempt.DurationMs,
+		})
+	}
+
+	return res
+}
+
+func toWebhookDeliveryStateProto(state string) bank.WebhookDeliveryState {
+	switch state {
+	case domainWebhook.StatePending:
+		return bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING
+	case domainWebhook.StateDelivered:
+		return bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DELIVERED
+	case domainWebhook.StateDead:
+		return bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DEAD
+	default:
+		return bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_UNSPECIFIED
+	}
+}
+
+func fromWebhookDeliveryStateProto(state bank.WebhookDeliveryState) string {
+	switch state {
+	case bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING:
+		return domainWebhook.StatePending
+	case bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DELIVERED:
+		return domainWebhook.StateDelivered
+	case bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DEAD:
+		return domainWebhook.StateDead
+	default:
+		return ""
+	}
+}
+
+// buildWebhookErrorStatusGrpc maps err to a status, resourceType and
+// resourceName name what the request referred to for NotFound.
+func buildWebhookErrorStatusGrpc(err error, resourceType string, resourceName string) error {
+	switch {
+	case errors.Is(err, domainBank.ErrRecordNotFound):
+		s := status.New(codes.NotFound, fmt.Sprintf("%v %v not found", resourceType, resourceName))
+		s, _ = s.WithDetails(&errdetails.ResourceInfo{
+			ResourceType: resourceType,
+			ResourceName: resourceName,
+			Description:  resourceType + " not found",
+		})
+
+		return s.Err()
+	case errors.Is(err, domainWebhook.ErrInvalidURL):
+		return webhookBadRequest(err, "url")
+	case errors.Is(err, domainWebhook.ErrUnknownEventType):
+		return webhookBadRequest(err, "event_types")
+	case errors.Is(err, domainWebhook.ErrSecretTooShort):
+		return webhookBadRequest(err, "secret")
+	case errors.Is(err, domainWebhook.ErrScopeInvalid):
+		return webhookBadRequest(err, "customer_id")
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func webhookBadRequest(err error, field string) error {
+	s := status.New(codes.InvalidArgument, err.Error())
+	s, _ = s.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: err.Error()},
+		},
+	})
+
+	return s.Err()
+}
@@ -0,0 +1,238 @@
+package grpc_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-proto/protogen/go/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// deposit stores a deposit on account and relays its events to the webhooks.
+func (h *harness) deposit(account string, amount float64) {
+	h.t.Helper()
+
+	ctx := context.Background()
+	acc, err := h.store.GetDetailBankAccountByAccountNumber(ctx, account)
+	if err != nil {
+		h.t.Fatalf("GetDetailBankAccountByAccountNumber: %v", err)
+	}
+	trx := domainBank.BankTransactionOrm{
+		TransactionUuid:      uuid.New(),
+		AccountUuid:          acc.AccountUuid,
+		TransactionTimestamp: h.clock.Now(),
+		Amount:               amount,
+		TransactionType:      domainBank.TransactionTypeIn,
+		CreatedAt:            h.clock.Now(),
+		UpdatedAt:            h.clock.Now(),
+	}
+	if _, err := h.store.CreateTransaction(ctx, acc, trx); err != nil {
+		h.t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	if _, err := application.NewEventRelay(h.store, h.webhooks, h.clock, 10).Drain(ctx); err != nil {
+		h.t.Fatalf("Drain: %v", err)
+	}
+}
+
+func TestWebhookAdmin(t *testing.T) {
+	h := newHarness(t)
+	ctx := h.ctx()
+
+	var status atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(server.Close)
+
+	sub, err := h.admin.CreateWebhookSubscription(ctx, &bank.CreateWebhookSubscriptionRequest{
+		AccountNumber: kate,
+		Url:           server.URL,
+		EventTypes:    []string{domainEvent.TypeTransactionCreated},
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhookSubscription: %v", err)
+	}
+	if sub.GetSecret() == "" || sub.GetAccountNumber() != kate || sub.GetCreatedAt() == nil {
+		t.Errorf("created subscription = %v, want it with its secret", sub)
+	}
+
+	listed, err := h.admin.ListWebhookSubscriptions(ctx, &bank.ListWebhookSubscriptionsRequest{AccountNumber: kate})
+	if err != nil || len(listed.GetSubscriptions()) != 1 {
+		t.Fatalf("ListWebhookSubscriptions = %v, %v; want the subscription", listed, err)
+	}
+	if got := listed.GetSubscriptions()[0]; got.GetSubscriptionId() != sub.GetSubscriptionId() || got.GetSecret() != "" {
+		t.Errorf("listed subscription = %v, want %v without its secret", got, sub.GetSubscriptionId())
+	}
+
+	h.deposit(kate, 5)
+	h.deposit(riri, 5)
+	if _, err := h.webhooks.Deliver(ctx); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+	h.clock.Advance(time.Minute)
+	if _, err := h.webhooks.Deliver(ctx); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	dead, err := h.admin.ListWebhookDeliveries(ctx, &bank.ListWebhookDeliveriesRequest{
+		SubscriptionId: sub.GetSubscriptionId(),
+		State:          bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DEAD,
+	})
+	if err != nil || len(dead.GetDeliveries()) != 1 {
+		t.Fatalf("dead deliveries = %v, %v; want the deposit on kate", dead, err)
+	}
+	d := dead.GetDeliveries()[0]
+	if d.GetEventType() != domainEvent.TypeTransactionCreated || d.GetAttemptCount() != 2 || len(d.GetAttempts()) != 2 ||
+		d.GetAttempts()[1].GetStatusCode() != http.StatusInternalServerError || d.GetNextAttemptAt() != nil {
+		t.Errorf("dead delivery = %v, want 2 attempts answered with 500", d)
+	}
+
+	status.Store(http.StatusOK)
+	redelivered, err := h.admin.RedeliverWebhook(ctx, &bank.RedeliverWebhookRequest{DeliveryId: d.GetDeliveryId()})
+	if err != nil || redelivered.GetState() != bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING || redelivered.GetNextAttemptAt() == nil {
+		t.Fatalf("RedeliverWebhook = %v, %v; want a PENDING delivery", redelivered, err)
+	}
+	if n, err := h.webhooks.Deliver(ctx); err != nil || n != 1 {
+		t.Fatalf("Deliver after RedeliverWebhook = %d, %v; want 1, nil", n, err)
+	}
+
+	all, err := h.admin.ListWebhookDeliveries(ctx, &bank.ListWebhookDeliveriesRequest{})
+	if err != nil || len(all.GetDeliveries()) != 1 ||
+		all.GetDeliveries()[0].GetState() != bank.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DELIVERED || len(all.GetDeliveries()[0].GetAttempts()) != 3 {
+		t.Errorf("deliveries = %v, %v; want one DELIVERED after 3 attempts", all, err)
+	}
+
+	if _, err := h.admin.DeleteWebhookSubscription(ctx, &bank.DeleteWebhookSubscriptionRequest{SubscriptionId: sub.GetSubscriptionId()}); err != nil {
+		t.Fatalf("DeleteWebhookSubscription: %v", err)
+	}
+	_, err = h.admin.DeleteWebhookSubscription(ctx, &bank.DeleteWebhookSubscriptionRequest{SubscriptionId: sub.GetSubscriptionId()})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.GetResourceType() != "webhook_subscription" {
+		t.Errorf("ResourceInfo = %v, want the webhook_subscription", info)
+	}
+
+	health, err := healthpb.NewHealthClient(h.conn).Check(ctx, &healthpb.HealthCheckRequest{Service: bank.WebhookAdminService_ServiceDesc.ServiceName})
+	if err != nil || health.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("health of the webhook admin service = %v, %v; want SERVING", health, err)
+	}
+}
+
+func TestWebhookCustomerSubscription(t *testing.T) {
+	h := newHarness(t)
+	ctx := h.ctx()
+
+	for _, account := range []string{kate, riri} {
+		set, err := h.accounts.SetAccountCustomer(ctx, &bank.SetAccountCustomerRequest{AccountNumber: account, CustomerId: "CUST-42"})
+		if err != nil || set.GetCustomerId() != "CUST-42" {
+			t.Fatalf("SetAccountCustomer(%v) = %v, %v; want CUST-42", account, set, err)
+		}
+	}
+
+	var secret atomic.Value
+	received := make(chan error, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- domainWebhook.Verify(secret.Load().(string), r.Header.Get(domainWebhook.SignatureHeader), body, h.clock.Now(), time.Minute)
+	}))
+	t.Cleanup(server.Close)
+
+	sub, err := h.admin.CreateWebhookSubscription(ctx, &bank.CreateWebhookSubscriptionRequest{
+		CustomerId: "CUST-42",
+		Url:        server.URL,
+		EventTypes: []string{domainEvent.TypeTransactionCreated},
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhookSubscription: %v", err)
+	}
+	if sub.GetCustomerId() != "CUST-42" || sub.GetAccountNumber() != "" {
+		t.Errorf("created subscription = %v, want it for the customer", sub)
+	}
+	secret.Store(sub.GetSecret())
+
+	listed, err := h.admin.ListWebhookSubscriptions(ctx, &bank.ListWebhookSubscriptionsRequest{CustomerId: "CUST-42"})
+	if err != nil || len(listed.GetSubscriptions()) != 1 || listed.GetSubscriptions()[0].GetSubscriptionId() != sub.GetSubscriptionId() {
+		t.Errorf("ListWebhookSubscriptions of the customer = %v, %v; want the subscription", listed, err)
+	}
+
+	h.deposit(kate, 5)
+	h.deposit(riri, 5)
+	h.deposit(cassie, 5)
+	if n, err := h.webhooks.Deliver(ctx); err != nil || n != 2 {
+		t.Fatalf("Deliver = %d, %v; want the deposits on the 2 accounts of the customer", n, err)
+	}
+	for range 2 {
+		if err := <-received; err != nil {
+			t.Errorf("delivery signature: %v", err)
+		}
+	}
+
+	_, err = h.admin.CreateWebhookSubscription(ctx, &bank.CreateWebhookSubscriptionRequest{
+		CustomerId: "CUST-NONE", Url: server.URL, EventTypes: []string{domainEvent.TypeTransactionCreated},
+	})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.GetResourceType() != "customer" || info.GetResourceName() != "CUST-NONE" {
+		t.Errorf("ResourceInfo = %v, want customer CUST-NONE", info)
+	}
+	_, err = h.admin.CreateWebhookSubscription(ctx, &bank.CreateWebhookSubscriptionRequest{
+		AccountNumber: kate, CustomerId: "CUST-42", Url: server.URL, EventTypes: []string{domainEvent.TypeTransactionCreated},
+	})
+	if v := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument).GetFieldViolations(); len(v) != 1 || v[0].GetField() != "customer_id" {
+		t.Errorf("violations = %v, want customer_id", v)
+	}
+	_, err = h.accounts.SetAccountCustomer(ctx, &bank.SetAccountCustomerRequest{AccountNumber: kate, CustomerId: strings.Repeat("x", 37)})
+	if v := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument).GetFieldViolations(); len(v) != 1 || v[0].GetField() != "customer_id" {
+		t.Errorf("violations = %v, want customer_id", v)
+	}
+}
+
+func TestWebhookAdminErrors(t *testing.T) {
+	h := newHarness(t)
+	ctx := h.ctx()
+
+	tests := []struct {
+		name  string
+		req   *bank.CreateWebhookSubscriptionRequest
+		field string
+	}{
+		{"url", &bank.CreateWebhookSubscriptionRequest{AccountNumber: kate, Url: "example.com", EventTypes: []string{domainEvent.TypeTransactionCreated}}, "url"},
+		{"event type", &bank.CreateWebhookSubscriptionRequest{AccountNumber: kate, Url: "https://example.com", EventTypes: []string{"Nope"}}, "event_types"},
+		{"secret", &bank.CreateWebhookSubscriptionRequest{AccountNumber: kate, Url: "https://example.com", EventTypes: []string{domainEvent.TypeTransactionCreated}, Secret: "short"}, "secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := h.admin.CreateWebhookSubscription(ctx, tt.req)
+			badRequest := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument)
+			if v := badRequest.GetFieldViolations(); len(v) != 1 || v[0].GetField() != tt.field {
+				t.Errorf("violations = %v, want %v", v, tt.field)
+			}
+		})
+	}
+
+	_, err := h.admin.CreateWebhookSubscription(ctx, &bank.CreateWebhookSubscriptionRequest{
+		AccountNumber: ghost, Url: "https://example.com", EventTypes: []string{domainEvent.TypeTransactionCreated},
+	})
+	if info := errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound); info.GetResourceName() != ghost {
+		t.Errorf("ResourceInfo = %v, want account %v", info, ghost)
+	}
+
+	_, err = h.admin.RedeliverWebhook(ctx, &bank.RedeliverWebhookRequest{DeliveryId: "42"})
+	if v := errorDetail[*errdetails.BadRequest](t, err, codes.InvalidArgument).GetFieldViolations(); len(v) != 1 || v[0].GetField() != "delivery_id" {
+		t.Errorf("violations = %v, want delivery_id", v)
+	}
+
+	_, err = h.admin.RedeliverWebhook(ctx, &bank.RedeliverWebhookRequest{DeliveryId: uuid.NewString()})
+	errorDetail[*errdetails.ResourceInfo](t, err, codes.NotFound)
+}
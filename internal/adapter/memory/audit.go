@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	"github.com/google/uuid"
+)
+
+func (a *MemoryAdapter) AppendAuditEntry(ctx context.Context, entry domainAudit.AuditEntryOrm) (domainAudit.AuditEntryOrm, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	prevHash := domainAudit.GenesisHash
+	if n := len(a.auditLog); n > 0 {
+		prevHash = a.auditLog[n-1].Hash
+	}
+
+	entry.Entities = append([]uuid.UUID(nil), entry.Entities...)
+	entry.Seal(int64(len(a.auditLog))+1, prevHash)
+	a.auditLog = append(a.auditLog, entry)
+
+	return entry, nil
+}
+
+func (a *MemoryAdapter) AuditEntries(ctx context.Context, afterSeq int64, limit int) ([]domainAudit.AuditEntryOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	entries := []domainAudit.AuditEntryOrm{}
+	for i := max(afterSeq, 0); i < int64(len(a.auditLog)) && len(entries) < limit; i++ {
+		entries = append(entries, a.auditLog[i])
+	}
+
+	return entries, nil
+}
+
+func (a *MemoryAdapter) AuditHead(ctx context.Context) (int64, string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if n := len(a.auditLog); n > 0 {
+		return int64(n), a.auditLog[n-1].Hash, nil
+	}
+
+	return 0, domainAudit.GenesisHash, nil
+}
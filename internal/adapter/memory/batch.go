@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainBatch "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/batch"
+	"github.com/google/uuid"
+)
+
+func (a *MemoryAdapter) CreateTransferBatch(ctx context.Context, batch domainBatch.BatchOrm, items []domainBatch.ItemOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.transferBatches[batch.BatchUuid]; ok {
+		return fmt.Errorf("transfer batch %v : %w", batch.BatchUuid, ErrDuplicateKey)
+	}
+	for _, b := range a.transferBatches {
+		if b.MessageId == batch.MessageId {
+			return fmt.Errorf("transfer batch message id %v : %w", batch.MessageId, ErrDuplicateKey)
+		}
+	}
+
+	stored := make([]domainBatch.ItemOrm, 0, len(items))
+	for _, item := range items {
+		if item.TransferUuid != nil {
+			if _, ok := a.transfers[*item.TransferUuid]; !ok {
+				return fmt.Errorf("transfer batch item %v : %w", item.ItemUuid, ErrForeignKeyViolation)
+			}
+		}
+		item.BatchUuid = batch.BatchUuid
+		item.Amount = roundAmount(item.Amount)
+		stored = append(stored, copyItem(item))
+	}
+	sort.SliceStable(stored, func(i, j int) bool {
+		return stored[i].LineNumber < stored[j].LineNumber
+	})
+
+	batch.ControlSum = roundAmount(batch.ControlSum)
+	a.transferBatches[batch.BatchUuid] = copyBatch(batch)
+	a.batchItems[batch.BatchUuid] = stored
+
+	return nil
+}
+
+func (a *MemoryAdapter) GetTransferBatch(ctx context.Context, id uuid.UUID) (domainBatch.BatchOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	b, ok := a.transferBatches[id]
+	if !ok {
+		return domainBatch.BatchOrm{}, domainBank.ErrRecordNotFound
+	}
+
+	return copyBatch(b), nil
+}
+
+func (a *MemoryAdapter) GetTransferBatchByMessageId(ctx context.Context, messageId string) (domainBatch.BatchOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, b := range a.transferBatches {
+		if b.MessageId == messageId {
+			return copyBatch(b), nil
+		}
+	}
+
+	return domainBatch.BatchOrm{}, domainBank.ErrRecordNotFound
+}
+
+func (a *MemoryAdapter) CountTransferBatchItems(ctx context.Context, batchUuid uuid.UUID) (map[string]int, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	counts := map[string]int{}
+	for _, item := range a.batchItems[batchUuid] {
+		counts[item.Status]++
+	}
+
+	return counts, nil
+}
+
+func (a *MemoryAdapter) ListTransferBatchItems(ctx context.Context, batchUuid uuid.UUID) ([]domainBatch.ItemOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	items := make([]domainBatch.ItemOrm, 0, len(a.batchItems[batchUuid]))
+	for _, item := range a.batchItems[batchUuid] {
+		items = append(items, copyItem(item))
+	}
+
+	return items, nil
+}
+
+func (a *MemoryAdapter) ClaimTransferBatches(ctx context.Context, now time.Time, until time.Time, limit int) ([]domainBatch.BatchOrm, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var due []domainBatch.BatchOrm
+	for _, b := range a.transferBatches {
+		switch b.Status {
+		case domainBatch.StatusAccepted, domainBatch.StatusProcessing, domainBatch.StatusRollingBack:
+		default:
+			continue
+		}
+		if b.LeaseUntil == nil || !b.LeaseUntil.After(now) {
+			due = append(due, b)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].CreatedAt.Equal(due[j].CreatedAt) {
+			return due[i].CreatedAt.Before(due[j].CreatedAt)
+		}
+		return due[i].BatchUuid.String() < due[j].BatchUuid.String()
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	batches := []domainBatch.BatchOrm{}
+	for _, b := range due {
+		b.LeaseUntil = &until
+		if b.Status == domainBatch.StatusAccepted {
+			b.Status = domainBatch.StatusProcessing
+			b.UpdatedAt = now
+		}
+		b = copyBatch(b)
+		a.transferBatches[b.BatchUuid] = b
+		batches = append(batches, copyBatch(b))
+	}
+
+	return batches, nil
+}
+
+func (a *MemoryAdapter) UpdateTransferBatch(ctx context.Context, batch domainBatch.BatchOrm, from string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	b, ok := a.transferBatches[batch.BatchUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	if b.Status != from {
+		return domainBatch.ErrBatchChanged
+	}
+
+	b.Status = batch.Status
+	b.ReasonCode = batch.ReasonCode
+	b.Reason = batch.Reason
+	b.LeaseUntil = batch.LeaseUntil
+	b.UpdatedAt = batch.UpdatedAt
+	b.CompletedAt = batch.CompletedAt
+	a.transferBatches[b.BatchUuid] = copyBatch(b)
+
+	return nil
+}
+
+func (a *MemoryAdapter) UpdateTransferBatchItem(ctx context.Context, item domainBatch.ItemOrm, from string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	items := a.batchItems[item.BatchUuid]
+	i := sort.Search(len(items), func(i int) bool {
+		return items[i].LineNumber >= item.LineNumber
+	})
+	for ; i < len(items) && items[i].ItemUuid != item.ItemUuid; i++ {
+	}
+	if i == len(items) {
+		return domainBank.ErrRecordNotFound
+	}
+	if items[i].Status != from {
+		return domainBatch.ErrBatchChanged
+	}
+	if item.TransferUuid != nil {
+		if _, ok := a.transfers[*item.TransferUuid]; !ok {
+			return fmt.Errorf("transfer batch item %v : %w", item.ItemUuid, ErrForeignKeyViolation)
+		}
+	}
+
+	stored := items[i]
+	stored.Status = item.Status
+	stored.ReasonCode = item.ReasonCode
+	stored.Reason = item.Reason
+	stored.TransferUuid = item.TransferUuid
+	stored.UpdatedAt = item.UpdatedAt
+	items[i] = copyItem(stored)
+
+	return nil
+}
+
+// copyBatch and copyItem keep callers from changing the stored times and
+// transfers through the pointers.
+func copyBatch(b domainBatch.BatchOrm) domainBatch.BatchOrm {
+	if b.LeaseUntil != nil {
+		lease := *b.LeaseUntil
+		b.LeaseUntil = &lease
+	}
+	if b.CompletedAt != nil {
+		completed := *b.CompletedAt
+		b.CompletedAt = &completed
+	}
+
+	return b
+}
+
+func copyItem(item domainBatch.ItemOrm) domainBatch.ItemOrm {
+	if item.TransferUuid != nil {
+		transfer := *item.TransferUuid
+		item.TransferUuid = &transfer
+	}
+
+	return item
+}
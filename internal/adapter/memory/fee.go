@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
+	"github.com/google/uuid"
+)
+
+func (a *MemoryAdapter) SaveFeeSchedule(ctx context.Context, schedule domainFee.Schedule) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.accounts[schedule.FeeAccountUuid]; !ok {
+		return ErrForeignKeyViolation
+	}
+	for id, s := range a.feeSchedules {
+		if id != schedule.ScheduleUuid && s.Currency == schedule.Currency && s.Channel == schedule.Channel &&
+			s.CustomerSegment == schedule.CustomerSegment {
+			return ErrDuplicateKey
+		}
+	}
+
+	if stored, ok := a.feeSchedules[schedule.ScheduleUuid]; ok {
+		schedule.CreatedAt = stored.CreatedAt
+	}
+	schedule.MinFee = roundAmount(schedule.MinFee)
+	schedule.MaxFee = roundAmount(schedule.MaxFee)
+	schedule.Tiers = slices.Clone(schedule.Tiers)
+	for i := range schedule.Tiers {
+		schedule.Tiers[i].ScheduleUuid = schedule.ScheduleUuid
+		schedule.Tiers[i].MinAmount = roundAmount(schedule.Tiers[i].MinAmount)
+		schedule.Tiers[i].FlatAmount = roundAmount(schedule.Tiers[i].FlatAmount)
+	}
+	domainFee.SortTiers(schedule.Tiers)
+	a.feeSchedules[schedule.ScheduleUuid] = schedule
+
+	return nil
+}
+
+func (a *MemoryAdapter) ListFeeSchedules(ctx context.Context) ([]domainFee.Schedule, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	schedules := make([]domainFee.Schedule, 0, len(a.feeSchedules))
+	for _, s := range a.feeSchedules {
+		s.Tiers = slices.Clone(s.Tiers)
+		schedules = append(schedules, s)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		x, y := schedules[i], schedules[j]
+		if x.Currency != y.Currency {
+			return x.Currency < y.Currency
+		}
+		if x.Channel != y.Channel {
+			return x.Channel < y.Channel
+		}
+		return x.CustomerSegment < y.CustomerSegment
+	})
+
+	return schedules, nil
+}
+
+func (a *MemoryAdapter) CountTransferFees(ctx context.Context, accountUuid uuid.UUID, scheduleUuid uuid.UUID, since time.Time) (int, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.countTransferFees(accountUuid, scheduleUuid, since), nil
+}
+
+func (a *MemoryAdapter) countTransferFees(accountUuid uuid.UUID, scheduleUuid uuid.UUID, since time.Time) int {
+	n := 0
+	for _, f := range a.transferFees {
+		if f.AccountUuid == accountUuid && f.ScheduleUuid == scheduleUuid && !f.ChargedAt.Before(since) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (a *MemoryAdapter) CreateChargedTransferTransactionPair(ctx context.Context, fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
+	fromTransactionOrm domainBank.BankTransactionOrm, toTransactionOrm domainBank.BankTransactionOrm, charge domainFee.Charge) (domainFee.Breakdown, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	book, err := a.prepareTransferPair(fromAccountOrm, toAccountOrm, fromTransactionOrm, toTransactionOrm)
+	if err != nil {
+		return domainFee.Breakdown{}, err
+	}
+	if _, ok := a.transferFees[charge.TransferUuid]; ok {
+		return domainFee.Breakdown{}, ErrDuplicateKey
+	}
+	if _, ok := a.transfers[charge.TransferUuid]; !ok {
+		return domainFee.Breakdown{}, ErrForeignKeyViolation
+	}
+	if _, ok := a.feeSchedules[charge.Breakdown.ScheduleUuid]; !ok {
+		return domainFee.Breakdown{}, ErrForeignKeyViolation
+	}
+	if _, ok := a.accounts[charge.FeeAccount.AccountUuid]; !ok {
+		return domainFee.Breakdown{}, ErrForeignKeyViolation
+	}
+
+	used := a.countTransferFees(fromAccountOrm.AccountUuid, charge.Breakdown.ScheduleUuid, charge.MonthStart)
+	charge.Breakdown = charge.Breakdown.Allow(charge.FreePerMonth, used, charge.Rate)
+	record := charge.Record(fromAccountOrm.AccountUuid)
+	record.Fee = roundAmount(record.Fee)
+
+	var bookFee func()
+	if record.Amount > 0 {
+		feeTrx, incomeTrx := charge.FeeTransaction, charge.IncomeTransaction
+		feeTrx.Amount, incomeTrx.Amount = record.Amount, record.Amount
+		for _, trx := range []domainBank.BankTransactionOrm{feeTrx, incomeTrx} {
+			if err := a.checkTransaction(trx); err != nil {
+				return domainFee.Breakdown{}, err
+			}
+			if trx.TransactionUuid == fromTransactionOrm.TransactionUuid || trx.TransactionUuid == toTransactionOrm.TransactionUuid {
+				return domainFee.Breakdown{}, ErrDuplicateKey
+			}
+		}
+		if feeTrx.TransactionUuid == incomeTrx.TransactionUuid {
+			return domainFee.Breakdown{}, ErrDuplicateKey
+		}
+		// the fee is debited after the amount
+		if err := a.checkDebit(fromAccountOrm.AccountUuid, roundAmount(fromTransactionOrm.Amount+feeTrx.Amount), feeTrx.TransactionTimestamp); err != nil {
+			if breach := (*domainBank.InsufficientFundsError)(nil); errors.As(err, &breach) {
+				breach.Available = roundAmount(breach.Available - fromTransactionOrm.Amount)
+				breach.Requested = feeTrx.Amount
+			}
+			return domainFee.Breakdown{}, err
+		}
+		charged, err := domainEvent.NewTransactionCreated(fromAccountOrm, feeTrx)
+		if err != nil {
+			return domainFee.Breakdown{}, err
+		}
+		income, err := domainEvent.NewTransactionCreated(charge.FeeAccount, incomeTrx)
+		if err != nil {
+			return domainFee.Breakdown{}, err
+		}
+
+		bookFee = func() {
+			a.insertTransaction(feeTrx)
+			a.insertTransaction(incomeTrx)
+			a.addToBalance(fromAccountOrm.AccountUuid, -feeTrx.Amount)
+			a.addToBalance(charge.FeeAccount.AccountUuid, incomeTrx.Amount)
+			a.addEvents(charged, income)
+		}
+	}
+
+	book()
+	if bookFee != nil {
+		bookFee()
+	}
+	a.transferFees[record.TransferUuid] = record
+
+	return charge.Breakdown, nil
+}
+
+func (a *MemoryAdapter) SetCustomerSegment(ctx context.Context, accountUuid uuid.UUID, segment string, at time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	account, ok := a.accounts[accountUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	account.CustomerSegment = segment
+	account.UpdatedAt = at
+	a.accounts[accountUuid] = account
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/google/uuid"
+)
+
+func (a *MemoryAdapter) HeldAmount(ctx context.Context, accountUuid uuid.UUID, at time.Time) (float64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.heldAmount(accountUuid, at), nil
+}
+
+func (a *MemoryAdapter) heldAmount(accountUuid uuid.UUID, at time.Time) float64 {
+	var held float64
+	for _, h := range a.holds {
+		if h.AccountUuid == accountUuid && h.StatusAt(at) == domainBank.HoldStatusActive {
+			held += h.RemainingAmount()
+		}
+	}
+
+	return roundAmount(held)
+}
+
+// checkDebit returns the InsufficientFundsError of the limits of accountUuid
+// when its balance less its holds active at at can't be debited amount, the
+// check the DatabaseAdapter makes under the lock of the account.
+func (a *MemoryAdapter) checkDebit(accountUuid uuid.UUID, amount float64, at time.Time) error {
+	account, ok := a.accounts[accountUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+
+	available := roundAmount(account.CurrentBalance - a.heldAmount(accountUuid, at))
+	return domainBank.LimitsOf(account, a.products[account.ProductCode]).Check(account.AccountNumber, available, amount)
+}
+
+func (a *MemoryAdapter) CreateHold(ctx context.Context, hold domainBank.BankHoldOrm, floor float64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.holds[hold.HoldUuid]; ok {
+		return ErrDuplicateKey
+	}
+	account, ok := a.accounts[hold.AccountUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	if roundAmount(account.CurrentBalance-a.heldAmount(hold.AccountUuid, hold.CreatedAt)-roundAmount(hold.Amount)) < floor {
+		return domainBank.ErrInsufficientBalance
+	}
+
+	hold.Amount = roundAmount(hold.Amount)
+	a.holds[hold.HoldUuid] = hold
+
+	return nil
+}
+
+func (a *MemoryAdapter) GetHold(ctx context.Context, holdUuid uuid.UUID) (domainBank.BankHoldOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	hold, ok := a.holds[holdUuid]
+	if !ok {
+		return domainBank.BankHoldOrm{}, domainBank.ErrRecordNotFound
+	}
+
+	return hold, nil
+}
+
+func (a *MemoryAdapter) CaptureHold(ctx context.Context, capture domainBank.HoldCapture) (domainBank.BankHoldOrm, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	hold, ok := a.holds[capture.Hold.HoldUuid]
+	if !ok {
+		return domainBank.BankHoldOrm{}, domainBank.ErrRecordNotFound
+	}
+	at := capture.Transaction.TransactionTimestamp
+	if hold.StatusAt(at) != domainBank.HoldStatusActive {
+		return domainBank.BankHoldOrm{}, domainBank.ErrHoldNotActive
+	}
+	amount := roundAmount(capture.Transaction.Amount)
+	if roundAmount(hold.CapturedAmount+amount) > hold.Amount {
+		return domainBank.BankHoldOrm{}, domainBank.ErrHoldExceeded
+	}
+	if err := a.checkTransaction(capture.Transaction); err != nil {
+		return domainBank.BankHoldOrm{}, err
+	}
+
+	created, err := domainEvent.NewTransactionCreated(capture.Account, capture.Transaction)
+	if err != nil {
+		return domainBank.BankHoldOrm{}, err
+	}
+
+	hold.CapturedAmount = roundAmount(hold.CapturedAmount + amount)
+	if hold.CapturedAmount >= hold.Amount {
+		hold.Status = domainBank.HoldStatusCaptured
+	}
+	hold.UpdatedAt = at
+	a.holds[hold.HoldUuid] = hold
+
+	a.insertTransaction(capture.Transaction)
+	a.addToBalance(capture.Account.AccountUuid, -amount)
+	a.addEvents(created)
+
+	return hold, nil
+}
+
+func (a *MemoryAdapter) ReleaseHold(ctx context.Context, holdUuid uuid.UUID, at time.Time) (domainBank.BankHoldOrm, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	hold, ok := a.holds[holdUuid]
+	if !ok {
+		return domainBank.BankHoldOrm{}, domainBank.ErrRecordNotFound
+	}
+	if hold.StatusAt(at) != domainBank.HoldStatusActive {
+		return domainBank.BankHoldOrm{}, domainBank.ErrHoldNotActive
+	}
+
+	hold.Status = domainBank.HoldStatusReleased
+	hold.UpdatedAt = at
+	a.holds[holdUuid] = hold
+
+	return hold, nil
+}
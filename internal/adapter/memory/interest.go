@@ -0,0 +1,223 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	"github.com/google/uuid"
+)
+
+// accrualKey is the primary key of bank_interest_accruals.
+type accrualKey struct {
+	accountUuid uuid.UUID
+	day         time.Time
+}
+
+func (a *MemoryAdapter) SaveInterestRates(ctx context.Context, version domainInterest.RateVersion) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.products[version.ProductCode]; !ok {
+		return ErrForeignKeyViolation
+	}
+	seen := map[float64]bool{}
+	for _, r := range version.Tiers {
+		if seen[r.MinBalance] {
+			return ErrDuplicateKey
+		}
+		seen[r.MinBalance] = true
+	}
+
+	for id, r := range a.interestRates {
+		if r.ProductCode == version.ProductCode && r.EffectiveFrom.Equal(version.EffectiveFrom) {
+			delete(a.interestRates, id)
+		}
+	}
+	for _, r := range version.Tiers {
+		r.MinBalance = roundAmount(r.MinBalance)
+		r.AnnualRate = math.Round(r.AnnualRate*1e6) / 1e6
+		a.interestRates[r.RateUuid] = r
+	}
+
+	return nil
+}
+
+func (a *MemoryAdapter) ListInterestRates(ctx context.Context, productCode string) ([]domainInterest.InterestRateOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var rates []domainInterest.InterestRateOrm
+	for _, r := range a.interestRates {
+		if productCode == "" || r.ProductCode == productCode {
+			rates = append(rates, r)
+		}
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].ProductCode != rates[j].ProductCode {
+			return rates[i].ProductCode < rates[j].ProductCode
+		}
+		if !rates[i].EffectiveFrom.Equal(rates[j].EffectiveFrom) {
+			return rates[i].EffectiveFrom.Before(rates[j].EffectiveFrom)
+		}
+		return rates[i].MinBalance < rates[j].MinBalance
+	})
+
+	return rates, nil
+}
+
+func (a *MemoryAdapter) InterestAccounts(ctx context.Context) ([]domainInterest.InterestAccount, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	withRates := map[string]bool{}
+	for _, r := range a.interestRates {
+		withRates[r.ProductCode] = true
+	}
+
+	var accounts []domainInterest.InterestAccount
+	for _, acc := range a.accounts {
+		if withRates[acc.ProductCode] {
+			accounts = append(accounts, domainInterest.InterestAccount{Account: acc, Product: a.products[acc.ProductCode]})
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Account.AccountNumber < accounts[j].Account.AccountNumber
+	})
+
+	return accounts, nil
+}
+
+func (a *MemoryAdapter) LastAccrualDate(ctx context.Context, accountUuid uuid.UUID) (time.Time, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var last time.Time
+	for key := range a.interestAccruals {
+		if key.accountUuid == accountUuid && key.day.After(last) {
+			last = key.day
+		}
+	}
+
+	return last, nil
+}
+
+func (a *MemoryAdapter) ClosingBalances(ctx context.Context, accountUuid uuid.UUID, from time.Time, to time.Time) ([]float64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	account, ok := a.accounts[accountUuid]
+	if !ok {
+		return nil, domainBank.ErrRecordNotFound
+	}
+
+	var trxs []domainBank.BankTransactionOrm
+	for _, trx := range a.transactions {
+		if trx.AccountUuid == accountUuid && !trx.TransactionTimestamp.Before(from) {
+			trxs = append(trxs, trx)
+		}
+	}
+
+	return domainInterest.ClosingBalances(account.CurrentBalance, from, to, trxs), nil
+}
+
+func (a *MemoryAdapter) SaveInterestAccruals(ctx context.Context, accruals []domainInterest.InterestAccrualOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, acc := range accruals {
+		if _, ok := a.accounts[acc.AccountUuid]; !ok {
+			return ErrForeignKeyViolation
+		}
+	}
+
+	for _, acc := range accruals {
+		key := accrualKey{acc.AccountUuid, domainInterest.Day(acc.AccrualDate)}
+		if _, ok := a.interestAccruals[key]; ok {
+			continue
+		}
+		acc.AccrualDate = key.day
+		acc.Balance = roundAmount(acc.Balance)
+		acc.Amount = math.Round(acc.Amount*1e6) / 1e6
+		a.interestAccruals[key] = acc
+	}
+
+	return nil
+}
+
+func (a *MemoryAdapter) CapitalizeInterest(ctx context.Context, c domainInterest.Capitalization) (domainInterest.Posting, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	account, ok := a.accounts[c.Account.AccountUuid]
+	if !ok {
+		return domainInterest.Posting{}, domainBank.ErrRecordNotFound
+	}
+
+	var pending []accrualKey
+	accrued := 0.0
+	for key, acc := range a.interestAccruals {
+		if key.accountUuid == c.Account.AccountUuid && acc.CapitalizedAt == nil && key.day.Before(c.Before) {
+			pending = append(pending, key)
+			accrued += acc.Amount
+		}
+	}
+
+	trx := c.Transaction
+	trx.Amount = roundAmount(math.Round(accrued*1e6) / 1e6)
+	if trx.Amount <= 0 {
+		return domainInterest.Posting{}, nil
+	}
+	if err := a.checkTransaction(trx); err != nil {
+		return domainInterest.Posting{}, err
+	}
+	created, err := domainEvent.NewTransactionCreated(c.Account, trx)
+	if err != nil {
+		return domainInterest.Posting{}, err
+	}
+	// check the tax postings too before changing anything, the threshold
+	// and the exemption are the customer's
+	balance, exempt := a.customerStanding(account)
+	withholding := c.Withholding
+	withholding.Exempt = exempt
+	withheld, err := a.prepareWithholding(c.Account, withholding, trx, balance)
+	if err != nil {
+		return domainInterest.Posting{}, err
+	}
+
+	a.insertTransaction(trx)
+	a.addToBalance(trx.AccountUuid, trx.Amount)
+	for _, key := range pending {
+		acc := a.interestAccruals[key]
+		id, capitalizedAt := trx.TransactionUuid, trx.TransactionTimestamp
+		acc.TransactionUuid = &id
+		acc.CapitalizedAt = &capitalizedAt
+		a.interestAccruals[key] = acc
+	}
+	a.addEvents(created)
+	withheld()
+
+	return domainInterest.Posting{Interest: trx.Amount, Tax: a.withholdings[c.Withholding.WithholdingUuid].TaxAmount}, nil
+}
+
+func (a *MemoryAdapter) ListInterestAccruals(ctx context.Context, accountUuid uuid.UUID, from time.Time, to time.Time) ([]domainInterest.InterestAccrualOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var accruals []domainInterest.InterestAccrualOrm
+	for key, acc := range a.interestAccruals {
+		if key.accountUuid != accountUuid || (!from.IsZero() && key.day.Before(from)) || (!to.IsZero() && !key.day.Before(to)) {
+			continue
+		}
+		accruals = append(accruals, acc)
+	}
+	sort.Slice(accruals, func(i, j int) bool {
+		return accruals[i].AccrualDate.Before(accruals[j].AccrualDate)
+	})
+
+	return accruals, nil
+}
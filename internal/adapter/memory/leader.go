@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+)
+
+// Locker implements LeaderLockPort for the replicas sharing it, which is
+// enough for one process: a single node, or the elections of a test.
+type Locker struct {
+	mu   sync.Mutex
+	held map[string]*heldLock
+}
+
+func NewLocker() *Locker {
+	return &Locker{held: map[string]*heldLock{}}
+}
+
+func (l *Locker) TryLock(ctx context.Context, name string) (port.HeldLock, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.held[name]; ok {
+		return nil, nil
+	}
+	lock := &heldLock{locker: l, name: name}
+	l.held[name] = lock
+
+	return lock, nil
+}
+
+type heldLock struct {
+	locker *Locker
+	name   string
+}
+
+func (h *heldLock) Check(ctx context.Context) error {
+	return nil
+}
+
+func (h *heldLock) Unlock(ctx context.Context) error {
+	h.locker.mu.Lock()
+	defer h.locker.mu.Unlock()
+
+	if h.locker.held[h.name] == h {
+		delete(h.locker.held, h.name)
+	}
+
+	return nil
+}
@@ -0,0 +1,472 @@
+// Package memory implements BankDatabasePort in process memory, for tests,
+// demos and local development without Postgres.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainBatch "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/batch"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	domainSchedule "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/schedule"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+	"github.com/google/uuid"
+)
+
+// These mirror the constraint violations Postgres reports for the same
+// operations.
+var (
+	ErrDuplicateKey        = errors.New("duplicate key value violates unique constraint")
+	ErrForeignKeyViolation = errors.New("insert violates foreign key constraint")
+)
+
+// MemoryAdapter keeps the tables of the bank schema in maps guarded by one
+// lock, so every method is atomic like the database transactions of
+// DatabaseAdapter.
+type MemoryAdapter struct {
+	mu sync.RWMutex
+
+	accounts         map[uuid.UUID]domainBank.BankAccountOrm
+	accountsByNumber map[string]uuid.UUID
+	transactions     map[uuid.UUID]domainBank.BankTransactionOrm
+	exchangeRates    map[uuid.UUID]domainBank.BankExchangeRateOrm
+	transfers        map[uuid.UUID]domainBank.BankTransferOrm
+	holds            map[uuid.UUID]domainBank.BankHoldOrm
+	products         map[string]domainBank.BankProductOrm
+	interestRates    map[uuid.UUID]domainInterest.InterestRateOrm
+	interestAccruals map[accrualKey]domainInterest.InterestAccrualOrm
+	taxRules         map[taxRuleKey]domainTax.TaxRuleOrm
+	withholdings     map[uuid.UUID]domainTax.WithholdingOrm
+	feeSchedules     map[uuid.UUID]domainFee.Schedule
+	transferFees     map[uuid.UUID]domainFee.TransferFeeOrm
+	outbox           []outboxEntry
+
+	scheduledTransfers map[uuid.UUID]domainSchedule.ScheduledTransferOrm
+	scheduleExecutions map[uuid.UUID][]domainSchedule.ExecutionOrm
+
+	transferBatches map[uuid.UUID]domainBatch.BatchOrm
+	batchItems      map[uuid.UUID][]domainBatch.ItemOrm
+
+	webhookSubscriptions map[uuid.UUID]domainWebhook.WebhookSubscriptionOrm
+	webhookDeliveries    map[uuid.UUID]domainWebhook.WebhookDeliveryOrm
+	webhookAttempts      map[uuid.UUID][]domainWebhook.WebhookAttemptOrm
+	lastAttemptId        int64
+
+	auditLog []domainAudit.AuditEntryOrm
+}
+
+type outboxEntry struct {
+	event       domainEvent.Event
+	publishedAt *time.Time
+}
+
+func NewMemoryAdapter() *MemoryAdapter {
+	return &MemoryAdapter{
+		accounts:         map[uuid.UUID]domainBank.BankAccountOrm{},
+		accountsByNumber: map[string]uuid.UUID{},
+		transactions:     map[uuid.UUID]domainBank.BankTransactionOrm{},
+		exchangeRates:    map[uuid.UUID]domainBank.BankExchangeRateOrm{},
+		transfers:        map[uuid.UUID]domainBank.BankTransferOrm{},
+		holds:            map[uuid.UUID]domainBank.BankHoldOrm{},
+		products:         map[string]domainBank.BankProductOrm{},
+		interestRates:    map[uuid.UUID]domainInterest.InterestRateOrm{},
+		interestAccruals: map[accrualKey]domainInterest.InterestAccrualOrm{},
+		taxRules:         map[taxRuleKey]domainTax.TaxRuleOrm{},
+		withholdings:     map[uuid.UUID]domainTax.WithholdingOrm{},
+		feeSchedules:     map[uuid.UUID]domainFee.Schedule{},
+		transferFees:     map[uuid.UUID]domainFee.TransferFeeOrm{},
+
+		scheduledTransfers: map[uuid.UUID]domainSchedule.ScheduledTransferOrm{},
+		scheduleExecutions: map[uuid.UUID][]domainSchedule.ExecutionOrm{},
+
+		transferBatches: map[uuid.UUID]domainBatch.BatchOrm{},
+		batchItems:      map[uuid.UUID][]domainBatch.ItemOrm{},
+
+		webhookSubscriptions: map[uuid.UUID]domainWebhook.WebhookSubscriptionOrm{},
+		webhookDeliveries:    map[uuid.UUID]domainWebhook.WebhookDeliveryOrm{},
+		webhookAttempts:      map[uuid.UUID][]domainWebhook.WebhookAttemptOrm{},
+	}
+}
+
+// Seed inserts accounts, which the port itself can't create.
+func (a *MemoryAdapter) Seed(accounts ...domainBank.BankAccountOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, acc := range accounts {
+		if _, ok := a.accounts[acc.AccountUuid]; ok {
+			return fmt.Errorf("account %v : %w", acc.AccountUuid, ErrDuplicateKey)
+		}
+		if _, ok := a.accountsByNumber[acc.AccountNumber]; ok {
+			return fmt.Errorf("account number %v : %w", acc.AccountNumber, ErrDuplicateKey)
+		}
+
+		a.insertAccount(acc)
+	}
+
+	return nil
+}
+
+// ApplySeed inserts the rows that don't exist yet, see seed.Store.
+func (a *MemoryAdapter) ApplySeed(ctx context.Context, accounts []domainBank.BankAccountOrm, transactions []domainBank.BankTransactionOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// work out what goes in before changing anything, so a violation leaves
+	// the store as it was, like the rolled back transaction of the database
+	var newAccounts []domainBank.BankAccountOrm
+	newNumbers := map[string]bool{}
+	inserted := map[uuid.UUID]bool{}
+	for _, acc := range accounts {
+		_, exists := a.accounts[acc.AccountUuid]
+		_, numberTaken := a.accountsByNumber[acc.AccountNumber]
+		if exists || numberTaken || inserted[acc.AccountUuid] || newNumbers[acc.AccountNumber] {
+			continue
+		}
+		newAccounts = append(newAccounts, acc)
+		newNumbers[acc.AccountNumber] = true
+		inserted[acc.AccountUuid] = true
+	}
+
+	for _, trx := range transactions {
+		if _, ok := a.transactions[trx.TransactionUuid]; ok {
+			continue
+		}
+		if _, ok := a.accounts[trx.AccountUuid]; !ok && !inserted[trx.AccountUuid] {
+			return fmt.Errorf("transaction %v : %w", trx.TransactionUuid, ErrForeignKeyViolation)
+		}
+	}
+
+	for _, acc := range newAccounts {
+		a.insertAccount(acc)
+	}
+
+	for _, trx := range transactions {
+		if _, ok := a.transactions[trx.TransactionUuid]; !ok {
+			a.insertTransaction(trx)
+		}
+	}
+
+	return nil
+}
+
+func (a *MemoryAdapter) GetDetailBankAccountByAccountNumber(ctx context.Context, accountNum string) (domainBank.BankAccountOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	id, ok := a.accountsByNumber[accountNum]
+	if !ok {
+		return domainBank.BankAccountOrm{}, domainBank.ErrRecordNotFound
+	}
+
+	return a.accounts[id], nil
+}
+
+func (a *MemoryAdapter) GetBalanceBankAccountByAccountNumber(ctx context.Context, acct string) (domainBank.BalanceAccountOrm, error) {
+	account, err := a.GetDetailBankAccountByAccountNumber(ctx, acct)
+	if err != nil {
+		return domainBank.BalanceAccountOrm{}, err
+	}
+
+	return domainBank.BalanceAccountOrm{
+		AccountUuid:    account.AccountUuid,
+		AccountNumber:  account.AccountNumber,
+		Currency:       account.Currency,
+		CurrentBalance: account.CurrentBalance,
+	}, nil
+}
+
+func (a *MemoryAdapter) InsertExchangeRate(ctx context.Context, r domainBank.BankExchangeRateOrm) (uuid.UUID, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.exchangeRates[r.ExchangeRateUuid]; ok {
+		return uuid.Nil, ErrDuplicateKey
+	}
+
+	created, err := domainEvent.NewExchangeRateCreated(r)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	r.Rate = roundRate(r.Rate)
+	a.exchangeRates[r.ExchangeRateUuid] = r
+	a.addEvents(created)
+
+	return r.ExchangeRateUuid, nil
+}
+
+// GetExchangeRateAtTimestamp returns the rate whose validity window contains
+// ts, bounds included. Like SELECT ... LIMIT 1 ordered by primary key, the
+// smallest UUID wins when windows overlap.
+func (a *MemoryAdapter) GetExchangeRateAtTimestamp(ctx context.Context, fromCurrency string, toCurrency string, ts time.Time) (domainBank.BankExchangeRateOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var (
+		found domainBank.BankExchangeRateOrm
+		ok    bool
+	)
+
+	for _, r := range a.exchangeRates {
+		if r.FromCurrency != fromCurrency || r.ToCurrency != toCurrency {
+			continue
+		}
+		if ts.Before(r.ValidFromTimestamp) || ts.After(r.ValidToTimestamp) {
+			continue
+		}
+		if !ok || bytes.Compare(r.ExchangeRateUuid[:], found.ExchangeRateUuid[:]) < 0 {
+			found, ok = r, true
+		}
+	}
+
+	if !ok {
+		return domainBank.BankExchangeRateOrm{}, domainBank.ErrRecordNotFound
+	}
+
+	return found, nil
+}
+
+func (a *MemoryAdapter) CreateTransaction(ctx context.Context, account domainBank.BankAccountOrm, trx domainBank.BankTransactionOrm) (uuid.UUID, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkTransaction(trx); err != nil {
+		return uuid.Nil, err
+	}
+	if _, ok := a.accounts[account.AccountUuid]; !ok {
+		return uuid.Nil, domainBank.ErrRecordNotFound
+	}
+	if trx.TransactionType == domainBank.TransactionTypeOut {
+		if err := a.checkDebit(account.AccountUuid, trx.Amount, trx.TransactionTimestamp); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	created, err := domainEvent.NewTransactionCreated(account, trx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	a.insertTransaction(trx)
+	a.addToBalance(account.AccountUuid, signedAmount(trx))
+	a.addEvents(created)
+
+	return trx.TransactionUuid, nil
+}
+
+func (a *MemoryAdapter) CreateTransfer(ctx context.Context, trf domainBank.BankTransferOrm) (uuid.UUID, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.transfers[trf.TransferUuid]; ok {
+		return uuid.Nil, ErrDuplicateKey
+	}
+	if _, ok := a.accounts[trf.FromAccountUuid]; !ok {
+		return uuid.Nil, ErrForeignKeyViolation
+	}
+	if _, ok := a.accounts[trf.ToAccountUuid]; !ok {
+		return uuid.Nil, ErrForeignKeyViolation
+	}
+
+	created, err := domainEvent.NewTransferCreated(trf)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	trf.Amount = roundAmount(trf.Amount)
+	a.transfers[trf.TransferUuid] = trf
+	a.addEvents(created)
+
+	return trf.TransferUuid, nil
+}
+
+func (a *MemoryAdapter) CreateTransferTransactionPair(ctx context.Context, fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
+	fromTransactionOrm domainBank.BankTransactionOrm, toTransactionOrm domainBank.BankTransactionOrm) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	book, err := a.prepareTransferPair(fromAccountOrm, toAccountOrm, fromTransactionOrm, toTransactionOrm)
+	if err != nil {
+		return false, err
+	}
+	book()
+
+	return true, nil
+}
+
+// prepareTransferPair validates a transfer pair and returns the func that
+// books it, so the caller can check everything before changing anything.
+func (a *MemoryAdapter) prepareTransferPair(fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
+	fromTransactionOrm domainBank.BankTransactionOrm, toTransactionOrm domainBank.BankTransactionOrm) (func(), error) {
+	// validate everything before touching anything, the pair is all or nothing
+	if err := a.checkTransaction(fromTransactionOrm); err != nil {
+		return nil, err
+	}
+	if err := a.checkTransaction(toTransactionOrm); err != nil {
+		return nil, err
+	}
+	if fromTransactionOrm.TransactionUuid == toTransactionOrm.TransactionUuid {
+		return nil, ErrDuplicateKey
+	}
+	if _, ok := a.accounts[fromAccountOrm.AccountUuid]; !ok {
+		return nil, domainBank.ErrRecordNotFound
+	}
+	if _, ok := a.accounts[toAccountOrm.AccountUuid]; !ok {
+		return nil, domainBank.ErrRecordNotFound
+	}
+	if err := a.checkDebit(fromAccountOrm.AccountUuid, fromTransactionOrm.Amount, fromTransactionOrm.TransactionTimestamp); err != nil {
+		return nil, err
+	}
+
+	fromCreated, err := domainEvent.NewTransactionCreated(fromAccountOrm, fromTransactionOrm)
+	if err != nil {
+		return nil, err
+	}
+	toCreated, err := domainEvent.NewTransactionCreated(toAccountOrm, toTransactionOrm)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		a.insertTransaction(fromTransactionOrm)
+		a.insertTransaction(toTransactionOrm)
+		a.addToBalance(fromAccountOrm.AccountUuid, -fromTransactionOrm.Amount)
+		a.addToBalance(toAccountOrm.AccountUuid, toTransactionOrm.Amount)
+		a.addEvents(fromCreated, toCreated)
+	}, nil
+}
+
+func (a *MemoryAdapter) UpdateTransferStatus(ctx context.Context, transfer domainBank.BankTransferOrm, status bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stored, ok := a.transfers[transfer.TransferUuid]
+	if !ok {
+		// an UPDATE matching no row is not an error
+		return nil
+	}
+
+	now := time.Now()
+
+	finished, err := domainEvent.NewTransferFinished(transfer, status, now)
+	if err != nil {
+		return err
+	}
+
+	stored.TransferSuccess = status
+	stored.UpdatedAt = now
+	a.transfers[transfer.TransferUuid] = stored
+	a.addEvents(finished)
+
+	return nil
+}
+
+func (a *MemoryAdapter) PendingEvents(ctx context.Context, limit int) ([]domainEvent.Event, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var events []domainEvent.Event
+	for _, entry := range a.outbox {
+		if len(events) == limit {
+			break
+		}
+		if entry.publishedAt == nil {
+			events = append(events, entry.event)
+		}
+	}
+
+	return events, nil
+}
+
+func (a *MemoryAdapter) MarkEventPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := range a.outbox {
+		if a.outbox[i].event.ID == id {
+			a.outbox[i].publishedAt = &at
+
+			// unlike the database the store keeps no history of published
+			// events, so it doesn't grow while the server runs
+			for len(a.outbox) > 0 && a.outbox[0].publishedAt != nil {
+				a.outbox = a.outbox[1:]
+			}
+
+			return nil
+		}
+	}
+
+	return domainBank.ErrRecordNotFound
+}
+
+func (a *MemoryAdapter) addEvents(events ...domainEvent.Event) {
+	for _, e := range events {
+		a.outbox = append(a.outbox, outboxEntry{event: e})
+	}
+}
+
+func (a *MemoryAdapter) checkTransaction(trx domainBank.BankTransactionOrm) error {
+	if _, ok := a.transactions[trx.TransactionUuid]; ok {
+		return ErrDuplicateKey
+	}
+	if _, ok := a.accounts[trx.AccountUuid]; !ok {
+		return ErrForeignKeyViolation
+	}
+
+	return nil
+}
+
+func (a *MemoryAdapter) insertTransaction(trx domainBank.BankTransactionOrm) {
+	trx.Amount = roundAmount(trx.Amount)
+	a.transactions[trx.TransactionUuid] = trx
+}
+
+// insertAccount stores acc with the defaults the database fills in.
+func (a *MemoryAdapter) insertAccount(acc domainBank.BankAccountOrm) {
+	acc.Transactions = nil
+	acc.CurrentBalance = roundAmount(acc.CurrentBalance)
+	acc.OverdraftUsed = max(0, -acc.CurrentBalance)
+	if acc.ProductCode == "" {
+		acc.ProductCode = domainBank.DefaultProductCode
+	}
+	a.accounts[acc.AccountUuid] = acc
+	a.accountsByNumber[acc.AccountNumber] = acc.AccountUuid
+}
+
+func (a *MemoryAdapter) addToBalance(accountUuid uuid.UUID, delta float64) {
+	account := a.accounts[accountUuid]
+	account.CurrentBalance = roundAmount(account.CurrentBalance + roundAmount(delta))
+	account.OverdraftUsed = max(0, -account.CurrentBalance)
+	account.UpdatedAt = time.Now()
+	a.accounts[accountUuid] = account
+}
+
+// signedAmount is the effect of trx on the account balance.
+func signedAmount(trx domainBank.BankTransactionOrm) float64 {
+	if trx.TransactionType == domainBank.TransactionTypeOut {
+		return -trx.Amount
+	}
+
+	return trx.Amount
+}
+
+// roundAmount and roundRate apply the scale of the NUMERIC(15,2) and
+// NUMERIC(20,10) columns.
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func roundRate(v float64) float64 {
+	return math.Round(v*1e10) / 1e10
+}
@@ -0,0 +1,24 @@
+package memory
+
+import (
+	"testing"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port/porttest"
+)
+
+func TestMemoryAdapterContract(t *testing.T) {
+	porttest.RunBankDatabasePortTests(t, func(t *testing.T) porttest.Harness {
+		db := NewMemoryAdapter()
+
+		return porttest.Harness{
+			DB: db,
+			Seed: func(t *testing.T, accounts ...domainBank.BankAccountOrm) {
+				t.Helper()
+				if err := db.Seed(accounts...); err != nil {
+					t.Fatalf("Seed: %v", err)
+				}
+			},
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	"github.com/google/uuid"
+)
+
+func (a *MemoryAdapter) GetProduct(ctx context.Context, productCode string) (domainBank.BankProductOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	product, ok := a.products[productCode]
+	if !ok {
+		return domainBank.BankProductOrm{}, domainBank.ErrRecordNotFound
+	}
+
+	return product, nil
+}
+
+func (a *MemoryAdapter) ListProducts(ctx context.Context) ([]domainBank.BankProductOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var products []domainBank.BankProductOrm
+	for _, p := range a.products {
+		products = append(products, p)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ProductCode < products[j].ProductCode
+	})
+
+	return products, nil
+}
+
+func (a *MemoryAdapter) SaveProduct(ctx context.Context, product domainBank.BankProductOrm) (domainBank.BankProductOrm, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if existing, ok := a.products[product.ProductCode]; ok {
+		product.CreatedAt = existing.CreatedAt
+	}
+	if product.InterestDayCount == "" {
+		product.InterestDayCount = domainInterest.DayCountAct365
+	}
+	if product.InterestAccrualFrequency == "" {
+		product.InterestAccrualFrequency = domainInterest.AccrueDaily
+	}
+	if product.InterestCapitalization == "" {
+		product.InterestCapitalization = domainInterest.CapitalizeMonthly
+	}
+	product.OverdraftLimit = roundAmount(product.OverdraftLimit)
+	product.MinimumBalance = roundAmount(product.MinimumBalance)
+	a.products[product.ProductCode] = product
+
+	return product, nil
+}
+
+func (a *MemoryAdapter) UpdateAccountLimits(ctx context.Context, account domainBank.BankAccountOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stored, ok := a.accounts[account.AccountUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+
+	stored.ProductCode = account.ProductCode
+	stored.OverdraftLimit = roundedLimit(account.OverdraftLimit)
+	stored.MinimumBalance = roundedLimit(account.MinimumBalance)
+	stored.UpdatedAt = account.UpdatedAt
+	a.accounts[account.AccountUuid] = stored
+
+	return nil
+}
+
+// roundedLimit is a copy of limit rounded to cents, so the caller can't
+// change the stored one.
+func roundedLimit(limit *float64) *float64 {
+	if limit == nil {
+		return nil
+	}
+	rounded := roundAmount(*limit)
+	return &rounded
+}
+
+func (a *MemoryAdapter) SetAccountCustomer(ctx context.Context, accountUuid uuid.UUID, customerId string, at time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	account, ok := a.accounts[accountUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	account.CustomerId = customerId
+	account.UpdatedAt = at
+	a.accounts[accountUuid] = account
+
+	return nil
+}
+
+func (a *MemoryAdapter) ListAccountsOfCustomer(ctx context.Context, customerId string) ([]domainBank.BankAccountOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.accountsOfCustomer(customerId), nil
+}
+
+func (a *MemoryAdapter) accountsOfCustomer(customerId string) []domainBank.BankAccountOrm {
+	var accounts []domainBank.BankAccountOrm
+	if customerId == "" {
+		return accounts
+	}
+	for _, account := range a.accounts {
+		if account.CustomerId == customerId {
+			accounts = append(accounts, account)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].AccountNumber < accounts[j].AccountNumber })
+
+	return accounts
+}
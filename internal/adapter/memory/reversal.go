@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/google/uuid"
+)
+
+func (a *MemoryAdapter) GetDetailBankAccountByUuid(ctx context.Context, accountUuid uuid.UUID) (domainBank.BankAccountOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	account, ok := a.accounts[accountUuid]
+	if !ok {
+		return domainBank.BankAccountOrm{}, domainBank.ErrRecordNotFound
+	}
+
+	return account, nil
+}
+
+func (a *MemoryAdapter) GetTransfer(ctx context.Context, transferUuid uuid.UUID) (domainBank.BankTransferOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	transfer, ok := a.transfers[transferUuid]
+	if !ok {
+		return domainBank.BankTransferOrm{}, domainBank.ErrRecordNotFound
+	}
+
+	return transfer, nil
+}
+
+func (a *MemoryAdapter) ReverseTransfer(ctx context.Context, booking domainBank.TransferReversalBooking) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	original, ok := a.transfers[booking.Original.TransferUuid]
+	amount := roundAmount(booking.Reversal.Amount)
+	if !ok || !original.TransferSuccess || original.ReversalOfUuid != nil || roundAmount(original.ReversedAmount+amount) > original.Amount {
+		return domainBank.ErrReversalExceedsTransfer
+	}
+
+	if _, ok := a.transfers[booking.Reversal.TransferUuid]; ok {
+		return ErrDuplicateKey
+	}
+	if err := a.checkTransaction(booking.Debit); err != nil {
+		return err
+	}
+	if err := a.checkTransaction(booking.Credit); err != nil {
+		return err
+	}
+	if booking.Debit.TransactionUuid == booking.Credit.TransactionUuid {
+		return ErrDuplicateKey
+	}
+	if _, ok := a.accounts[booking.Recipient.AccountUuid]; !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	if _, ok := a.accounts[booking.Sender.AccountUuid]; !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	if !booking.AllowOverdraft {
+		if err := a.checkDebit(booking.Recipient.AccountUuid, booking.Debit.Amount, booking.Debit.TransactionTimestamp); err != nil {
+			return err
+		}
+	}
+
+	reversed, err := domainEvent.NewTransferReversed(booking.Original, booking.Reversal)
+	if err != nil {
+		return err
+	}
+	debited, err := domainEvent.NewTransactionCreated(booking.Recipient, booking.Debit)
+	if err != nil {
+		return err
+	}
+	credited, err := domainEvent.NewTransactionCreated(booking.Sender, booking.Credit)
+	if err != nil {
+		return err
+	}
+
+	original.ReversedAmount = roundAmount(original.ReversedAmount + amount)
+	original.UpdatedAt = time.Now()
+	a.transfers[original.TransferUuid] = original
+
+	reversal := booking.Reversal
+	reversal.Amount = amount
+	a.transfers[reversal.TransferUuid] = reversal
+
+	a.insertTransaction(booking.Debit)
+	a.insertTransaction(booking.Credit)
+	a.addToBalance(booking.Recipient.AccountUuid, -booking.Debit.Amount)
+	a.addToBalance(booking.Sender.AccountUuid, booking.Credit.Amount)
+	a.addEvents(reversed, debited, credited)
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainSchedule "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/schedule"
+	"github.com/google/uuid"
+)
+
+func (a *MemoryAdapter) CreateScheduledTransfer(ctx context.Context, s domainSchedule.ScheduledTransferOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.scheduledTransfers[s.ScheduleUuid]; ok {
+		return fmt.Errorf("scheduled transfer %v : %w", s.ScheduleUuid, ErrDuplicateKey)
+	}
+	if _, ok := a.accounts[s.FromAccountUuid]; !ok {
+		return fmt.Errorf("scheduled transfer %v : %w", s.ScheduleUuid, ErrForeignKeyViolation)
+	}
+	if _, ok := a.accounts[s.ToAccountUuid]; !ok {
+		return fmt.Errorf("scheduled transfer %v : %w", s.ScheduleUuid, ErrForeignKeyViolation)
+	}
+
+	s.Amount = roundAmount(s.Amount)
+	a.scheduledTransfers[s.ScheduleUuid] = copySchedule(s)
+
+	return nil
+}
+
+func (a *MemoryAdapter) GetScheduledTransfer(ctx context.Context, id uuid.UUID) (domainSchedule.ScheduledTransferOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	s, ok := a.scheduledTransfers[id]
+	if !ok {
+		return domainSchedule.ScheduledTransferOrm{}, domainBank.ErrRecordNotFound
+	}
+
+	return copySchedule(s), nil
+}
+
+func (a *MemoryAdapter) ListScheduledTransfers(ctx context.Context, accountUuid uuid.UUID) ([]domainSchedule.ScheduledTransferOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	schedules := []domainSchedule.ScheduledTransferOrm{}
+	for _, s := range a.scheduledTransfers {
+		if accountUuid == uuid.Nil || s.FromAccountUuid == accountUuid {
+			schedules = append(schedules, copySchedule(s))
+		}
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if !schedules[i].CreatedAt.Equal(schedules[j].CreatedAt) {
+			return schedules[i].CreatedAt.Before(schedules[j].CreatedAt)
+		}
+		return schedules[i].ScheduleUuid.String() < schedules[j].ScheduleUuid.String()
+	})
+
+	return schedules, nil
+}
+
+func (a *MemoryAdapter) UpdateScheduledTransferStatus(ctx context.Context, id uuid.UUID, from []string, status string, at time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s, ok := a.scheduledTransfers[id]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	if !slices.Contains(from, s.Status) {
+		return domainSchedule.ErrScheduleNotActive
+	}
+
+	s.Status = status
+	s.UpdatedAt = at
+	a.scheduledTransfers[id] = s
+
+	return nil
+}
+
+func (a *MemoryAdapter) ResumeScheduledTransfer(ctx context.Context, paused domainSchedule.ScheduledTransferOrm, resumed domainSchedule.ScheduledTransferOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s, ok := a.scheduledTransfers[paused.ScheduleUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	if s.Status != domainSchedule.StatusPaused || s.Occurrence != paused.Occurrence || s.Attempt != paused.Attempt {
+		return domainSchedule.ErrScheduleNotActive
+	}
+
+	s.Status = resumed.Status
+	s.NextRunAt = resumed.NextRunAt
+	s.Occurrence = resumed.Occurrence
+	s.Attempt = resumed.Attempt
+	s.UpdatedAt = resumed.UpdatedAt
+	a.scheduledTransfers[s.ScheduleUuid] = copySchedule(s)
+
+	return nil
+}
+
+func (a *MemoryAdapter) ClaimScheduledTransfers(ctx context.Context, now time.Time, until time.Time, limit int) ([]domainSchedule.ScheduledTransferOrm, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var due []domainSchedule.ScheduledTransferOrm
+	for _, s := range a.scheduledTransfers {
+		if s.Status == domainSchedule.StatusActive && s.NextRunAt != nil && !s.NextRunAt.After(now) {
+			due = append(due, s)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextRunAt.Equal(*due[j].NextRunAt) {
+			return due[i].NextRunAt.Before(*due[j].NextRunAt)
+		}
+		return due[i].ScheduleUuid.String() < due[j].ScheduleUuid.String()
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	schedules := []domainSchedule.ScheduledTransferOrm{}
+	for _, s := range due {
+		s.NextRunAt = &until
+		s = copySchedule(s)
+		a.scheduledTransfers[s.ScheduleUuid] = s
+		schedules = append(schedules, copySchedule(s))
+	}
+
+	return schedules, nil
+}
+
+func (a *MemoryAdapter) RecordScheduledTransferRun(ctx context.Context, s domainSchedule.ScheduledTransferOrm, execution domainSchedule.ExecutionOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stored, ok := a.scheduledTransfers[s.ScheduleUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	for _, e := range a.scheduleExecutions[s.ScheduleUuid] {
+		if e.ExecutionUuid == execution.ExecutionUuid || (e.Occurrence == execution.Occurrence && e.Attempt == execution.Attempt) {
+			return fmt.Errorf("scheduled transfer execution %v : %w", execution.ExecutionUuid, ErrDuplicateKey)
+		}
+	}
+	if execution.TransferUuid != nil {
+		if _, ok := a.transfers[*execution.TransferUuid]; !ok {
+			return fmt.Errorf("scheduled transfer execution %v : %w", execution.ExecutionUuid, ErrForeignKeyViolation)
+		}
+	}
+
+	if stored.Occurrence == execution.Occurrence && stored.Attempt == execution.Attempt {
+		stored.NextRunAt = s.NextRunAt
+		stored.Occurrence = s.Occurrence
+		stored.Attempt = s.Attempt
+		stored.UpdatedAt = s.UpdatedAt
+		if stored.Status == domainSchedule.StatusActive {
+			stored.Status = s.Status
+		}
+		a.scheduledTransfers[s.ScheduleUuid] = copySchedule(stored)
+	}
+
+	execution.ScheduleUuid = s.ScheduleUuid
+	a.scheduleExecutions[s.ScheduleUuid] = append(a.scheduleExecutions[s.ScheduleUuid], execution)
+
+	return nil
+}
+
+func (a *MemoryAdapter) ListScheduledTransferExecutions(ctx context.Context, scheduleUuid uuid.UUID) ([]domainSchedule.ExecutionOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	executions := append([]domainSchedule.ExecutionOrm{}, a.scheduleExecutions[scheduleUuid]...)
+	sort.Slice(executions, func(i, j int) bool {
+		if executions[i].Occurrence != executions[j].Occurrence {
+			return executions[i].Occurrence < executions[j].Occurrence
+		}
+		return executions[i].Attempt < executions[j].Attempt
+	})
+
+	return executions, nil
+}
+
+// copySchedule keeps callers from changing the stored next run through the
+// pointer.
+func copySchedule(s domainSchedule.ScheduledTransferOrm) domainSchedule.ScheduledTransferOrm {
+	if s.NextRunAt != nil {
+		next := *s.NextRunAt
+		s.NextRunAt = &next
+	}
+
+	return s
+}
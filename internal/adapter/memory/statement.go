@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainStatement "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/statement"
+)
+
+func (a *MemoryAdapter) StatementBalances(ctx context.Context, account domainBank.BankAccountOrm, from time.Time, to time.Time) (float64, float64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	stored, ok := a.accounts[account.AccountUuid]
+	if !ok {
+		return 0, 0, domainBank.ErrRecordNotFound
+	}
+
+	opening, closing := stored.CurrentBalance, stored.CurrentBalance
+	for _, trx := range a.transactions {
+		if trx.AccountUuid != account.AccountUuid || trx.TransactionTimestamp.Before(from) {
+			continue
+		}
+		opening -= domainStatement.Signed(trx)
+		if !trx.TransactionTimestamp.Before(to) {
+			closing -= domainStatement.Signed(trx)
+		}
+	}
+
+	return opening, closing, nil
+}
+
+func (a *MemoryAdapter) ListTransactions(ctx context.Context, account domainBank.BankAccountOrm, from time.Time, to time.Time, after *domainStatement.Cursor,
+	limit int) ([]domainBank.BankTransactionOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var trxs []domainBank.BankTransactionOrm
+	for _, trx := range a.transactions {
+		if trx.AccountUuid != account.AccountUuid || trx.TransactionTimestamp.Before(from) || !trx.TransactionTimestamp.Before(to) {
+			continue
+		}
+		if after != nil && !statementAfter(trx, *after) {
+			continue
+		}
+		trxs = append(trxs, trx)
+	}
+
+	sort.Slice(trxs, func(i, j int) bool {
+		return statementAfter(trxs[j], domainStatement.CursorOf(trxs[i]))
+	})
+
+	if limit > 0 && len(trxs) > limit {
+		trxs = trxs[:limit]
+	}
+
+	return trxs, nil
+}
+
+// statementAfter reports whether trx comes after cursor in a statement,
+// oldest first.
+func statementAfter(trx domainBank.BankTransactionOrm, cursor domainStatement.Cursor) bool {
+	if !trx.TransactionTimestamp.Equal(cursor.Timestamp) {
+		return trx.TransactionTimestamp.After(cursor.Timestamp)
+	}
+	return bytes.Compare(trx.TransactionUuid[:], cursor.TransactionUuid[:]) > 0
+}
@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/google/uuid"
+)
+
+// taxRuleKey is the primary key of bank_tax_rules.
+type taxRuleKey struct {
+	currency      string
+	effectiveFrom time.Time
+}
+
+func (a *MemoryAdapter) SaveTaxRule(ctx context.Context, rule domainTax.TaxRuleOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.accounts[rule.PayableAccountUuid]; !ok {
+		return ErrForeignKeyViolation
+	}
+
+	rule.MinBalance = roundAmount(rule.MinBalance)
+	a.taxRules[taxRuleKey{rule.Currency, rule.EffectiveFrom.UTC()}] = rule
+
+	return nil
+}
+
+func (a *MemoryAdapter) ListTaxRules(ctx context.Context) ([]domainTax.TaxRuleOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var rules []domainTax.TaxRuleOrm
+	for _, r := range a.taxRules {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Currency != rules[j].Currency {
+			return rules[i].Currency < rules[j].Currency
+		}
+		return rules[i].EffectiveFrom.Before(rules[j].EffectiveFrom)
+	})
+
+	return rules, nil
+}
+
+func (a *MemoryAdapter) SetTaxExempt(ctx context.Context, accountUuid uuid.UUID, exempt bool, at time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	account, ok := a.accounts[accountUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+	customer := []domainBank.BankAccountOrm{account}
+	if account.CustomerId != "" {
+		customer = a.accountsOfCustomer(account.CustomerId)
+	}
+	for _, acc := range customer {
+		acc.TaxExempt = exempt
+		acc.UpdatedAt = at
+		a.accounts[acc.AccountUuid] = acc
+	}
+
+	return nil
+}
+
+func (a *MemoryAdapter) ListWithholdings(ctx context.Context, accountUuid uuid.UUID, from time.Time, to time.Time) ([]domainTax.WithholdingOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var withholdings []domainTax.WithholdingOrm
+	for _, w := range a.withholdings {
+		if (accountUuid == uuid.Nil || w.AccountUuid == accountUuid) && !w.WithheldAt.Before(from) && w.WithheldAt.Before(to) {
+			withholdings = append(withholdings, w)
+		}
+	}
+	sort.Slice(withholdings, func(i, j int) bool {
+		if !withholdings[i].WithheldAt.Equal(withholdings[j].WithheldAt) {
+			return withholdings[i].WithheldAt.Before(withholdings[j].WithheldAt)
+		}
+		return withholdings[i].WithholdingUuid.String() < withholdings[j].WithholdingUuid.String()
+	})
+
+	return withholdings, nil
+}
+
+// customerStanding is what withholding needs of the customer of account:
+// the balances of its accounts in the currency of account added up, and
+// whether it is exempt, which any of its accounts being exempt makes it.
+func (a *MemoryAdapter) customerStanding(account domainBank.BankAccountOrm) (float64, bool) {
+	if account.CustomerId == "" {
+		return account.CurrentBalance, account.TaxExempt
+	}
+
+	balance, exempt := 0.0, false
+	for _, acc := range a.accountsOfCustomer(account.CustomerId) {
+		if acc.Currency == account.Currency {
+			balance += acc.CurrentBalance
+			exempt = exempt || acc.TaxExempt
+		}
+	}
+
+	return roundAmount(balance), exempt
+}
+
+// prepareWithholding checks the withholding of the tax of interestTrx,
+// posted to account whose customer had balance before it, and returns the
+// func that books it, so the caller can check everything before changing
+// anything.
+func (a *MemoryAdapter) prepareWithholding(account domainBank.BankAccountOrm, w domainTax.Withholding, interestTrx domainBank.BankTransactionOrm, balance float64) (func(), error) {
+	record := w.Record(interestTrx, balance)
+	if _, ok := a.withholdings[record.WithholdingUuid]; ok {
+		return nil, ErrDuplicateKey
+	}
+	if record.TaxAmount <= 0 {
+		return func() { a.withholdings[record.WithholdingUuid] = record }, nil
+	}
+
+	taxTrx, payableTrx := w.TaxTransaction, w.PayableTransaction
+	taxTrx.Amount, payableTrx.Amount = record.TaxAmount, record.TaxAmount
+	if err := a.checkTransaction(taxTrx); err != nil {
+		return nil, err
+	}
+	if err := a.checkTransaction(payableTrx); err != nil {
+		return nil, err
+	}
+	if taxTrx.TransactionUuid == payableTrx.TransactionUuid || taxTrx.TransactionUuid == interestTrx.TransactionUuid ||
+		payableTrx.TransactionUuid == interestTrx.TransactionUuid {
+		return nil, ErrDuplicateKey
+	}
+	withheld, err := domainEvent.NewTransactionCreated(account, taxTrx)
+	if err != nil {
+		return nil, err
+	}
+	payable, err := domainEvent.NewTransactionCreated(w.PayableAccount, payableTrx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		a.insertTransaction(taxTrx)
+		a.insertTransaction(payableTrx)
+		a.addToBalance(account.AccountUuid, -taxTrx.Amount)
+		a.addToBalance(w.PayableAccount.AccountUuid, payableTrx.Amount)
+		a.addEvents(withheld, payable)
+		a.withholdings[record.WithholdingUuid] = record
+	}, nil
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+func (a *MemoryAdapter) GetTransferDetail(ctx context.Context, transferUuid uuid.UUID) (domainBank.TransferDetail, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	transfer, ok := a.transfers[transferUuid]
+	if !ok {
+		return domainBank.TransferDetail{}, domainBank.ErrRecordNotFound
+	}
+
+	return a.transferDetail(transfer), nil
+}
+
+func (a *MemoryAdapter) ListTransfers(ctx context.Context, filter domainBank.TransferFilter) ([]domainBank.TransferDetail, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var transfers []domainBank.TransferDetail
+	for _, t := range a.transfers {
+		if filter.AccountUuid != uuid.Nil {
+			outgoing := t.FromAccountUuid == filter.AccountUuid
+			incoming := t.ToAccountUuid == filter.AccountUuid
+			switch filter.Direction {
+			case domainBank.TransferDirectionOutgoing:
+				incoming = false
+			case domainBank.TransferDirectionIncoming:
+				outgoing = false
+			}
+			if !outgoing && !incoming {
+				continue
+			}
+		}
+		if filter.Success != nil && t.TransferSuccess != *filter.Success {
+			continue
+		}
+		if !filter.From.IsZero() && t.TransferTimestamp.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !t.TransferTimestamp.Before(filter.To) {
+			continue
+		}
+		if filter.After != nil && !listedAfter(t, *filter.After) {
+			continue
+		}
+		transfers = append(transfers, a.transferDetail(t))
+	}
+
+	sort.Slice(transfers, func(i, j int) bool {
+		return listedAfter(transfers[j].BankTransferOrm, transfers[i].Cursor())
+	})
+
+	if filter.Limit > 0 && len(transfers) > filter.Limit {
+		transfers = transfers[:filter.Limit]
+	}
+
+	return transfers, nil
+}
+
+// listedAfter reports whether t comes after cursor in a listing, newest
+// first.
+func listedAfter(t domainBank.BankTransferOrm, cursor domainBank.TransferCursor) bool {
+	if !t.TransferTimestamp.Equal(cursor.Timestamp) {
+		return t.TransferTimestamp.Before(cursor.Timestamp)
+	}
+	return bytes.Compare(t.TransferUuid[:], cursor.TransferUuid[:]) < 0
+}
+
+func (a *MemoryAdapter) transferDetail(t domainBank.BankTransferOrm) domainBank.TransferDetail {
+	return domainBank.TransferDetail{
+		BankTransferOrm:   t,
+		FromAccountNumber: a.accounts[t.FromAccountUuid].AccountNumber,
+		ToAccountNumber:   a.accounts[t.ToAccountUuid].AccountNumber,
+	}
+}
@@ -0,0 +1,248 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+	"github.com/google/uuid"
+)
+
+func (a *MemoryAdapter) CreateWebhookSubscription(ctx context.Context, sub domainWebhook.WebhookSubscriptionOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.webhookSubscriptions[sub.SubscriptionUuid]; ok {
+		return fmt.Errorf("webhook subscription %v : %w", sub.SubscriptionUuid, ErrDuplicateKey)
+	}
+	// the CHECK of bank_webhook_subscriptions
+	if (sub.AccountUuid == nil) == (sub.CustomerId == "") {
+		return fmt.Errorf("webhook subscription %v : %w", sub.SubscriptionUuid, domainWebhook.ErrScopeInvalid)
+	}
+	if sub.AccountUuid != nil {
+		if _, ok := a.accounts[*sub.AccountUuid]; !ok {
+			return fmt.Errorf("webhook subscription %v : %w", sub.SubscriptionUuid, ErrForeignKeyViolation)
+		}
+		accountUuid := *sub.AccountUuid
+		sub.AccountUuid = &accountUuid
+	}
+
+	sub.EventTypes = append([]string(nil), sub.EventTypes...)
+	a.webhookSubscriptions[sub.SubscriptionUuid] = sub
+
+	return nil
+}
+
+func (a *MemoryAdapter) ListWebhookSubscriptions(ctx context.Context, accountUuid uuid.UUID, customerId string) ([]domainWebhook.WebhookSubscriptionOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var subs []domainWebhook.WebhookSubscriptionOrm
+	for _, sub := range a.webhookSubscriptions {
+		if (accountUuid == uuid.Nil || (sub.AccountUuid != nil && *sub.AccountUuid == accountUuid)) &&
+			(customerId == "" || sub.CustomerId == customerId) {
+			subs = append(subs, sub)
+		}
+	}
+	sortSubscriptions(subs)
+
+	return subs, nil
+}
+
+func (a *MemoryAdapter) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.webhookSubscriptions[id]; !ok {
+		return domainBank.ErrRecordNotFound
+	}
+
+	delete(a.webhookSubscriptions, id)
+	for deliveryId, d := range a.webhookDeliveries {
+		if d.SubscriptionUuid == id {
+			delete(a.webhookDeliveries, deliveryId)
+			delete(a.webhookAttempts, deliveryId)
+		}
+	}
+
+	return nil
+}
+
+func (a *MemoryAdapter) WebhookSubscriptionsOfAccounts(ctx context.Context, accountUuids []uuid.UUID) ([]domainWebhook.WebhookSubscriptionOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	wanted := map[uuid.UUID]bool{}
+	customers := map[string]bool{}
+	for _, id := range accountUuids {
+		wanted[id] = true
+		if account, ok := a.accounts[id]; ok && account.CustomerId != "" {
+			customers[account.CustomerId] = true
+		}
+	}
+
+	subs := []domainWebhook.WebhookSubscriptionOrm{}
+	for _, sub := range a.webhookSubscriptions {
+		if (sub.AccountUuid != nil && wanted[*sub.AccountUuid]) || customers[sub.CustomerId] {
+			subs = append(subs, sub)
+		}
+	}
+	sortSubscriptions(subs)
+
+	return subs, nil
+}
+
+func (a *MemoryAdapter) EnqueueWebhookDeliveries(ctx context.Context, deliveries []domainWebhook.WebhookDeliveryOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, d := range deliveries {
+		if _, ok := a.webhookSubscriptions[d.SubscriptionUuid]; !ok {
+			return fmt.Errorf("webhook delivery %v : %w", d.DeliveryUuid, ErrForeignKeyViolation)
+		}
+	}
+
+	for _, d := range deliveries {
+		if _, ok := a.webhookDeliveries[d.DeliveryUuid]; ok || a.hasDelivery(d.SubscriptionUuid, d.EventUuid) {
+			continue
+		}
+		d.Subscription = domainWebhook.WebhookSubscriptionOrm{}
+		d.Attempts = nil
+		a.webhookDeliveries[d.DeliveryUuid] = d
+	}
+
+	return nil
+}
+
+func (a *MemoryAdapter) ClaimWebhookDeliveries(ctx context.Context, now time.Time, until time.Time, limit int) ([]domainWebhook.WebhookDeliveryOrm, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var due []domainWebhook.WebhookDeliveryOrm
+	for _, d := range a.webhookDeliveries {
+		if d.State == domainWebhook.StatePending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].DeliveryUuid.String() < due[j].DeliveryUuid.String()
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	deliveries := []domainWebhook.WebhookDeliveryOrm{}
+	for _, d := range due {
+		d.NextAttemptAt = until
+		a.webhookDeliveries[d.DeliveryUuid] = d
+
+		d.Subscription = a.webhookSubscriptions[d.SubscriptionUuid]
+		deliveries = append(deliveries, d)
+	}
+	sortDeliveries(deliveries, false)
+
+	return deliveries, nil
+}
+
+func (a *MemoryAdapter) RecordWebhookAttempt(ctx context.Context, delivery domainWebhook.WebhookDeliveryOrm, attempt domainWebhook.WebhookAttemptOrm) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stored, ok := a.webhookDeliveries[delivery.DeliveryUuid]
+	if !ok {
+		return domainBank.ErrRecordNotFound
+	}
+
+	stored.State = delivery.State
+	stored.AttemptCount = delivery.AttemptCount
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.LastError = delivery.LastError
+	stored.UpdatedAt = delivery.UpdatedAt
+	a.webhookDeliveries[delivery.DeliveryUuid] = stored
+
+	a.lastAttemptId++
+	attempt.AttemptId = a.lastAttemptId
+	attempt.DeliveryUuid = delivery.DeliveryUuid
+	a.webhookAttempts[delivery.DeliveryUuid] = append(a.webhookAttempts[delivery.DeliveryUuid], attempt)
+
+	return nil
+}
+
+func (a *MemoryAdapter) ListWebhookDeliveries(ctx context.Context, filter domainWebhook.DeliveryFilter) ([]domainWebhook.WebhookDeliveryOrm, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var deliveries []domainWebhook.WebhookDeliveryOrm
+	for _, d := range a.webhookDeliveries {
+		if filter.SubscriptionUuid != uuid.Nil && d.SubscriptionUuid != filter.SubscriptionUuid {
+			continue
+		}
+		if filter.State != "" && d.State != filter.State {
+			continue
+		}
+		d.Attempts = append([]domainWebhook.WebhookAttemptOrm(nil), a.webhookAttempts[d.DeliveryUuid]...)
+		deliveries = append(deliveries, d)
+	}
+	sortDeliveries(deliveries, true)
+
+	if filter.Limit > 0 && len(deliveries) > filter.Limit {
+		deliveries = deliveries[:filter.Limit]
+	}
+
+	return deliveries, nil
+}
+
+func (a *MemoryAdapter) ScheduleWebhookDelivery(ctx context.Context, id uuid.UUID, at time.Time) (domainWebhook.WebhookDeliveryOrm, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	d, ok := a.webhookDeliveries[id]
+	if !ok {
+		return domainWebhook.WebhookDeliveryOrm{}, domainBank.ErrRecordNotFound
+	}
+
+	d.State = domainWebhook.StatePending
+	d.AttemptCount = 0
+	d.NextAttemptAt = at
+	d.UpdatedAt = at
+	a.webhookDeliveries[id] = d
+
+	d.Attempts = append([]domainWebhook.WebhookAttemptOrm(nil), a.webhookAttempts[id]...)
+
+	return d, nil
+}
+
+func (a *MemoryAdapter) hasDelivery(subscriptionUuid uuid.UUID, eventUuid uuid.UUID) bool {
+	for _, d := range a.webhookDeliveries {
+		if d.SubscriptionUuid == subscriptionUuid && d.EventUuid == eventUuid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortSubscriptions and sortDeliveries apply the ORDER BY of DatabaseAdapter.
+func sortSubscriptions(subs []domainWebhook.WebhookSubscriptionOrm) {
+	sort.Slice(subs, func(i, j int) bool {
+		if !subs[i].CreatedAt.Equal(subs[j].CreatedAt) {
+			return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+		}
+		return subs[i].SubscriptionUuid.String() < subs[j].SubscriptionUuid.String()
+	})
+}
+
+func sortDeliveries(deliveries []domainWebhook.WebhookDeliveryOrm, newestFirst bool) {
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt) == newestFirst
+		}
+		return deliveries[i].DeliveryUuid.String() < deliveries[j].DeliveryUuid.String()
+	})
+}
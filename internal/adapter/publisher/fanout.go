@@ -0,0 +1,39 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+)
+
+// Fanout publishes every event to each of its publishers in turn. When one
+// fails the relay publishes the event again to all of them, so each must
+// tolerate duplicates.
+type Fanout struct {
+	publishers []port.EventPublisherPort
+}
+
+func NewFanout(publishers ...port.EventPublisherPort) *Fanout {
+	return &Fanout{publishers: publishers}
+}
+
+func (f *Fanout) Publish(ctx context.Context, e domainEvent.Event) error {
+	for _, p := range f.publishers {
+		if err := p.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *Fanout) Close() error {
+	var errs []error
+	for _, p := range f.publishers {
+		errs = append(errs, p.Close())
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,68 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/nats-io/nats.go"
+)
+
+// flushTimeout bounds how long Publish waits for the server to confirm.
+const flushTimeout = 5 * time.Second
+
+// NATSPublisher publishes each event on <prefix>.<type>, e.g.
+// bank.events.TransferCompleted. The Nats-Msg-Id header carries the event ID,
+// so a JetStream stream on these subjects drops the duplicates the relay may
+// send.
+type NATSPublisher struct {
+	conn   *nats.Conn
+	prefix string
+}
+
+func NewNATSPublisher(url, prefix string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url,
+		nats.Name("go-grpc-micro-bank-server"),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to NATS at %v : %v", url, err)
+	}
+
+	return &NATSPublisher{conn: conn, prefix: prefix}, nil
+}
+
+// Publish returns once the server has received e. Core NATS doesn't keep
+// messages without a subscriber, use a JetStream stream for durability.
+func (p *NATSPublisher) Publish(ctx context.Context, e domainEvent.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(p.prefix + "." + e.Type)
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, e.ID.String())
+	msg.Header.Set("Content-Type", "application/json")
+	msg.Header.Set("Event-Version", strconv.Itoa(e.Version))
+
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+
+	// the relay context has no deadline, but the flush needs one
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flushTimeout)
+		defer cancel()
+	}
+
+	return p.conn.FlushWithContext(ctx)
+}
+
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,183 @@
+package publisher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/google/uuid"
+)
+
+func newEvent(t *testing.T) domainEvent.Event {
+	t.Helper()
+
+	e, err := domainEvent.New(domainEvent.TypeTransferCompleted, uuid.New(), time.Now(), domainEvent.Transfer{Currency: "USD", Amount: 1})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	return e
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	events := []domainEvent.Event{newEvent(t), newEvent(t)}
+
+	// a restarted server appends to the same file
+	for _, e := range events {
+		p, err := NewFilePublisher(path)
+		if err != nil {
+			t.Fatalf("NewFilePublisher: %v", err)
+		}
+		if err := p.Publish(context.Background(), e); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+		if err := p.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != len(events) {
+		t.Fatalf("file has %d lines, want %d:\n%s", len(lines), len(events), content)
+	}
+	for i, line := range lines {
+		var got domainEvent.Event
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d: %v", i+1, err)
+		}
+		if got.ID != events[i].ID || got.Type != events[i].Type || !bytes.Equal(got.Data, events[i].Data) {
+			t.Errorf("line %d = %+v, want %+v", i+1, got, events[i])
+		}
+	}
+}
+
+func TestNATSPublisher(t *testing.T) {
+	server := newFakeNATS(t)
+
+	p, err := NewNATSPublisher("nats://"+server.addr, "bank.events")
+	if err != nil {
+		t.Fatalf("NewNATSPublisher: %v", err)
+	}
+	defer p.Close()
+
+	e := newEvent(t)
+	if err := p.Publish(context.Background(), e); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	// Publish flushes, so the message has arrived by now
+	var msg fakeMsg
+	select {
+	case msg = <-server.msgs:
+	default:
+		t.Fatal("Publish returned before the server received the message")
+	}
+
+	if msg.subject != "bank.events.TransferCompleted" {
+		t.Errorf("subject = %q", msg.subject)
+	}
+	if got := msg.header.Get("Nats-Msg-Id"); got != e.ID.String() {
+		t.Errorf("Nats-Msg-Id = %q, want %v", got, e.ID)
+	}
+	if got := msg.header.Get("Event-Version"); got != strconv.Itoa(domainEvent.Version) {
+		t.Errorf("Event-Version = %q", got)
+	}
+
+	var got domainEvent.Event
+	if err := json.Unmarshal(msg.data, &got); err != nil {
+		t.Fatalf("payload %s: %v", msg.data, err)
+	}
+	if got.ID != e.ID || !bytes.Equal(got.Data, e.Data) {
+		t.Errorf("payload = %+v, want %+v", got, e)
+	}
+}
+
+type fakeMsg struct {
+	subject string
+	header  textproto.MIMEHeader
+	data    []byte
+}
+
+// fakeNATS speaks just enough of the NATS client protocol for one client
+// publishing messages with headers.
+type fakeNATS struct {
+	addr string
+	msgs chan fakeMsg
+}
+
+func newFakeNATS(t *testing.T) *fakeNATS {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	s := &fakeNATS{addr: lis.Addr().String(), msgs: make(chan fakeMsg, 16)}
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.serve(conn)
+	}()
+
+	return s
+}
+
+func (s *fakeNATS) serve(conn net.Conn) {
+	host, port, _ := net.SplitHostPort(s.addr)
+	fmt.Fprintf(conn, "INFO {\"server_id\":\"fake\",\"version\":\"2.10.0\",\"host\":%q,\"port\":%s,\"headers\":true,\"max_payload\":1048576,\"proto\":1}\r\n", host, port)
+
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "PING":
+			io.WriteString(conn, "PONG\r\n")
+		case "HPUB":
+			// HPUB <subject> [reply] <header bytes> <total bytes>
+			headerLen, _ := strconv.Atoi(fields[len(fields)-2])
+			totalLen, _ := strconv.Atoi(fields[len(fields)-1])
+			buf := make([]byte, totalLen+2)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+
+			// skip the NATS/1.0 status line of the header block
+			hr := textproto.NewReader(bufio.NewReader(bytes.NewReader(buf[:headerLen])))
+			hr.ReadLine()
+			header, _ := hr.ReadMIMEHeader()
+
+			s.msgs <- fakeMsg{subject: fields[1], header: header, data: buf[headerLen:totalLen]}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Package publisher implements EventPublisherPort for the relay of the
+// outbox.
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+)
+
+// WriterPublisher writes each event as one line of JSON, for local use and
+// for consumers tailing a file.
+type WriterPublisher struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{enc: json.NewEncoder(w)}
+}
+
+func NewStdoutPublisher() *WriterPublisher {
+	return NewWriterPublisher(os.Stdout)
+}
+
+// NewFilePublisher appends to path, creating it if needed.
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("can't open event file %v : %v", path, err)
+	}
+
+	p := NewWriterPublisher(f)
+	p.closer = f
+
+	return p, nil
+}
+
+func (p *WriterPublisher) Publish(ctx context.Context, e domainEvent.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.enc.Encode(e)
+}
+
+func (p *WriterPublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+
+	return p.closer.Close()
+}
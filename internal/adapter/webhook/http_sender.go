@@ -0,0 +1,48 @@
+// Package webhook sends webhook deliveries over HTTP.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// maxResponseBody is read from every response before it is discarded, so
+// the connection can be reused.
+const maxResponseBody = 64 << 10
+
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender returns a sender that doesn't follow redirects: a receiver
+// answering with a redirect has not accepted the delivery. The timeout of
+// an attempt comes with the context passed to Send.
+func NewHTTPSender() *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, url string, header http.Header, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header = header.Clone()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+)
+
+func TestHTTPSenderSignature(t *testing.T) {
+	secret := "whsec_0123456789abcdef"
+	body := []byte(`{"type":"TransactionCreated"}`)
+	sentAt := time.Now()
+
+	received := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method != http.MethodPost:
+			received <- errors.New("method " + r.Method)
+		case r.Header.Get(domainWebhook.EventTypeHeader) != "TransactionCreated":
+			received <- errors.New("no event type header")
+		default:
+			received <- domainWebhook.Verify(secret, r.Header.Get(domainWebhook.SignatureHeader), got, time.Now(), time.Minute)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	header := http.Header{}
+	header.Set(domainWebhook.SignatureHeader, domainWebhook.Sign(secret, sentAt, body))
+	header.Set(domainWebhook.EventTypeHeader, "TransactionCreated")
+
+	code, err := NewHTTPSender().Send(context.Background(), server.URL, header, body)
+	if err != nil || code != http.StatusNoContent {
+		t.Fatalf("Send = %d, %v; want 204", code, err)
+	}
+	if err := <-received; err != nil {
+		t.Errorf("receiver rejected the delivery: %v", err)
+	}
+}
+
+func TestHTTPSenderStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"ok", http.StatusOK},
+		{"server error", http.StatusInternalServerError},
+		{"client error", http.StatusGone},
+		// a redirect is not followed, the receiver hasn't accepted the delivery
+		{"redirect", http.StatusFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("response body"))
+			}))
+			t.Cleanup(server.Close)
+
+			code, err := NewHTTPSender().Send(context.Background(), server.URL, http.Header{}, []byte("{}"))
+			if err != nil || code != tt.status {
+				t.Errorf("Send = %d, %v; want %d, nil", code, err, tt.status)
+			}
+		})
+	}
+}
+
+func TestHTTPSenderTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	code, err := NewHTTPSender().Send(ctx, server.URL, http.Header{}, []byte("{}"))
+	if !errors.Is(err, context.DeadlineExceeded) || code != 0 {
+		t.Errorf("Send = %d, %v; want 0 and the deadline exceeded", code, err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Send returned after %v, want it to give up at the timeout", elapsed)
+	}
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+)
+
+const (
+	// activityHistorySize is how many of the latest activities, of all
+	// accounts, a reconnecting subscriber can resume from.
+	activityHistorySize = 4096
+	// activityBufferSize is how far a subscriber may fall behind before it
+	// is dropped.
+	activityBufferSize = 256
+)
+
+// ActivityBus fans the account activities of this process out to the
+// subscribers of each account. Event IDs are <generation>-<sequence>; the
+// generation is random per bus, so an ID issued before a restart is
+// recognised as expired instead of matching an unrelated event.
+type ActivityBus struct {
+	mu          sync.Mutex
+	generation  string
+	seq         uint64
+	history     []activityEntry
+	historySize int
+	subs        map[*activitySubscription]struct{}
+}
+
+type activityEntry struct {
+	seq      uint64
+	activity domainBank.AccountActivity
+}
+
+func NewActivityBus(historySize int) *ActivityBus {
+	id := make([]byte, 4)
+	rand.Read(id)
+
+	return &ActivityBus{
+		generation:  hex.EncodeToString(id),
+		historySize: historySize,
+		subs:        map[*activitySubscription]struct{}{},
+	}
+}
+
+// Publish assigns the next event ID to activity and delivers it.
+func (b *ActivityBus) Publish(activity domainBank.AccountActivity) domainBank.AccountActivity {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	activity.EventID = fmt.Sprintf("%s-%d", b.generation, b.seq)
+
+	b.history = append(b.history, activityEntry{seq: b.seq, activity: activity})
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for sub := range b.subs {
+		if sub.account != activity.AccountNumber {
+			continue
+		}
+
+		select {
+		case sub.ch <- activity:
+		default:
+			// the subscriber resumes from its last event, which is still
+			// in the history unless it fell very far behind
+			b.drop(sub)
+		}
+	}
+
+	return activity
+}
+
+// Subscribe starts delivering the activities of account. With a lastEventID
+// the retained activities after it are replayed first.
+func (b *ActivityBus) Subscribe(account string, lastEventID string) (*activitySubscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &activitySubscription{
+		bus:     b,
+		account: account,
+		ch:      make(chan domainBank.AccountActivity, activityBufferSize),
+	}
+
+	if lastEventID != "" {
+		after, err := b.parseEventID(lastEventID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range b.history {
+			if entry.seq > after && entry.activity.AccountNumber == account {
+				sub.replay = append(sub.replay, entry.activity)
+			}
+		}
+	}
+
+	b.subs[sub] = struct{}{}
+
+	return sub, nil
+}
+
+// parseEventID returns the sequence of id, as long as every activity after
+// it is still in the history.
+func (b *ActivityBus) parseEventID(id string) (uint64, error) {
+	generation, seqText, ok := strings.Cut(id, "-")
+	if !ok || generation != b.generation {
+		return 0, domainBank.ErrActivityCursorExpired
+	}
+
+	seq, err := strconv.ParseUint(seqText, 10, 64)
+	if err != nil || seq > b.seq || seq < b.seq-uint64(len(b.history)) {
+		return 0, domainBank.ErrActivityCursorExpired
+	}
+
+	return seq, nil
+}
+
+func (b *ActivityBus) drop(sub *activitySubscription) {
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+type activitySubscription struct {
+	bus     *ActivityBus
+	account string
+	replay  []domainBank.AccountActivity
+	ch      chan domainBank.AccountActivity
+}
+
+func (s *activitySubscription) Replay() []domainBank.AccountActivity {
+	return s.replay
+}
+
+func (s *activitySubscription) Activities() <-chan domainBank.AccountActivity {
+	return s.ch
+}
+
+func (s *activitySubscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.drop(s)
+}
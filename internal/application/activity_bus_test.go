@@ -0,0 +1,78 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+)
+
+func TestActivityBusResume(t *testing.T) {
+	bus := application.NewActivityBus(3)
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		a := bus.Publish(domainBank.AccountActivity{AccountNumber: "A", Amount: float64(i)})
+		ids = append(ids, a.EventID)
+	}
+
+	// the last three are retained, so resuming after the second works
+	sub, err := bus.Subscribe("A", ids[1])
+	if err != nil {
+		t.Fatalf("Subscribe after %v: %v", ids[1], err)
+	}
+	defer sub.Close()
+	if replay := sub.Replay(); len(replay) != 3 || replay[0].EventID != ids[2] || replay[2].EventID != ids[4] {
+		t.Errorf("replay = %+v, want %v to %v", replay, ids[2], ids[4])
+	}
+
+	// the activity after the first one is gone
+	for _, id := range []string{ids[0], ids[4] + "0", "other-1", "garbage"} {
+		if _, err := bus.Subscribe("A", id); !errors.Is(err, domainBank.ErrActivityCursorExpired) {
+			t.Errorf("Subscribe after %q: error = %v, want ErrActivityCursorExpired", id, err)
+		}
+	}
+
+	latest, err := bus.Subscribe("A", ids[4])
+	if err != nil || len(latest.Replay()) != 0 {
+		t.Errorf("Subscribe after the latest event = %v, %v; want nothing to replay", latest, err)
+	}
+}
+
+func TestActivityBusDropsSlowSubscriber(t *testing.T) {
+	bus := application.NewActivityBus(1000)
+
+	slow, _ := bus.Subscribe("A", "")
+	other, _ := bus.Subscribe("B", "")
+	defer other.Close()
+
+	for i := 0; i < 500; i++ {
+		bus.Publish(domainBank.AccountActivity{AccountNumber: "A"})
+	}
+
+	var received []domainBank.AccountActivity
+	for a := range slow.Activities() {
+		received = append(received, a)
+	}
+	if len(received) == 0 || len(received) >= 500 {
+		t.Fatalf("slow subscriber got %d activities before being dropped", len(received))
+	}
+	slow.Close()
+
+	select {
+	case a := <-other.Activities():
+		t.Errorf("subscriber of B got %+v", a)
+	default:
+	}
+
+	// it catches up from the history
+	resumed, err := bus.Subscribe("A", received[len(received)-1].EventID)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer resumed.Close()
+	if got := len(received) + len(resumed.Replay()); got != 500 {
+		t.Errorf("received %d activities after resuming, want 500", got)
+	}
+}
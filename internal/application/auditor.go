@@ -0,0 +1,158 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const auditVerifyPageSize = 500
+
+// Auditor writes one audit entry per mutating operation. A nil Auditor
+// records nothing, for stores without an audit log.
+type Auditor struct {
+	log   port.AuditLogPort
+	clock clock.Clock
+}
+
+func NewAuditor(auditLog port.AuditLogPort, clk clock.Clock) *Auditor {
+	return &Auditor{
+		log:   auditLog,
+		clock: clk,
+	}
+}
+
+// auditorOf returns an Auditor when store also keeps the audit log.
+func auditorOf(store interface{}, clk clock.Clock) *Auditor {
+	if auditLog, ok := store.(port.AuditLogPort); ok {
+		return NewAuditor(auditLog, clk)
+	}
+
+	return nil
+}
+
+// AuditScope collects the entities an operation touches.
+type AuditScope struct {
+	action string
+
+	mu       sync.Mutex
+	entities []uuid.UUID
+}
+
+type auditScopeKey struct{}
+
+// Start opens the audit scope of action; the returned context carries it for
+// auditAffect. End must be called when the operation is done.
+func (a *Auditor) Start(ctx context.Context, action string) (context.Context, *AuditScope) {
+	if a == nil {
+		return ctx, nil
+	}
+
+	scope := &AuditScope{action: action}
+
+	return context.WithValue(ctx, auditScopeKey{}, scope), scope
+}
+
+// Affect adds ids to the entities of the operation.
+func (s *AuditScope) Affect(ids ...uuid.UUID) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		if id != uuid.Nil {
+			s.entities = append(s.entities, id)
+		}
+	}
+}
+
+// auditAffect adds ids to the entities of the audit scope of ctx.
+func auditAffect(ctx context.Context, ids ...uuid.UUID) {
+	if scope, ok := ctx.Value(auditScopeKey{}).(*AuditScope); ok {
+		scope.Affect(ids...)
+	}
+}
+
+// End appends the entry of scope with the outcome in *errp. The operation has
+// already happened, so a failing append is logged and counted, not returned.
+func (a *Auditor) End(ctx context.Context, scope *AuditScope, errp *error) {
+	if a == nil || scope == nil {
+		return
+	}
+
+	actor := domainAudit.ActorFrom(ctx)
+	entry := domainAudit.AuditEntryOrm{
+		OccurredAt:  a.clock.Now(),
+		Principal:   actor.Principal,
+		RemoteAddr:  actor.RemoteAddr,
+		Method:      actor.Method,
+		Action:      scope.action,
+		Fingerprint: actor.Fingerprint,
+		Outcome:     domainAudit.OutcomeOK,
+	}
+	if errp != nil && *errp != nil {
+		entry.Outcome = domainAudit.OutcomeFailed
+		entry.Error = (*errp).Error()
+	}
+
+	scope.mu.Lock()
+	entry.Entities = append([]uuid.UUID(nil), scope.entities...)
+	scope.mu.Unlock()
+
+	// the caller's context may be canceled by now, the entry is still due
+	if _, err := a.log.AppendAuditEntry(context.WithoutCancel(ctx), entry); err != nil {
+		metrics.AuditFailures.Inc()
+		logErr := util.LogError(fmt.Sprintf("Can't append audit entry of %v : %v\n", scope.action, err), "", "Auditor - End")
+		log.Error().Ctx(ctx).Msg(logErr)
+	}
+}
+
+// VerifyAuditLog walks the chain from the first entry and checks every link
+// and the head. It returns the number of entries verified; a broken chain is
+// reported as domainAudit.ErrChainBroken.
+func VerifyAuditLog(ctx context.Context, auditLog port.AuditLogPort) (int64, error) {
+	headSeq, headHash, err := auditLog.AuditHead(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	seq, prevHash := int64(0), domainAudit.GenesisHash
+	for {
+		entries, err := auditLog.AuditEntries(ctx, seq, auditVerifyPageSize)
+		if err != nil {
+			return seq, err
+		}
+
+		for _, e := range entries {
+			if err := e.VerifyLink(seq+1, prevHash); err != nil {
+				return seq, err
+			}
+			seq, prevHash = e.Seq, e.Hash
+		}
+
+		if len(entries) < auditVerifyPageSize {
+			break
+		}
+	}
+
+	// entries appended while walking are past the head read first
+	if seq < headSeq {
+		return seq, fmt.Errorf("%w : head is entry %d but the log ends at %d", domainAudit.ErrChainBroken, headSeq, seq)
+	}
+	if seq == headSeq && prevHash != headHash {
+		return seq, fmt.Errorf("%w : hash of the head doesn't match entry %d", domainAudit.ErrChainBroken, seq)
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,121 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/memory"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port/porttest"
+)
+
+func TestBankServiceAuditsMutations(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewMemoryAdapter()
+	from, to := porttest.NewAccount(100), porttest.NewAccount(0)
+	if err := store.Seed(from, to); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	clk := clock.NewFake(time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC))
+	service := application.NewBankService(store, clk)
+
+	actor := domainAudit.Actor{Principal: "cert:teller", RemoteAddr: "10.0.0.1:5000", Method: "/bank.BankService/Transfer", Fingerprint: "ab12"}
+	transferUuid, _, _, err := service.Transfer(domainAudit.WithActor(ctx, actor), domainBank.TransferTransaction{
+		FromAccountNumber: from.AccountNumber,
+		ToAccountNumber:   to.AccountNumber,
+		Currency:          "USD",
+		Amount:            40,
+	})
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if _, err := service.CreateTransaction(ctx, from.AccountNumber, domainBank.Transaction{Amount: 1000, TransactionType: domainBank.TransactionTypeOut}); err == nil {
+		t.Fatal("overdrawing CreateTransaction succeeded")
+	}
+	if _, _, err := service.GetCurrentBalance(ctx, from.AccountNumber); err != nil {
+		t.Fatalf("GetCurrentBalance: %v", err)
+	}
+
+	entries, err := store.AuditEntries(ctx, 0, 10)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("AuditEntries = %+v, %v; want the two mutations", entries, err)
+	}
+
+	transfer := entries[0]
+	if transfer.Action != "Transfer" || transfer.Outcome != domainAudit.OutcomeOK || transfer.Principal != actor.Principal ||
+		transfer.RemoteAddr != actor.RemoteAddr || transfer.Method != actor.Method || transfer.Fingerprint != actor.Fingerprint ||
+		!transfer.OccurredAt.Equal(clk.Now()) {
+		t.Errorf("transfer entry = %+v", transfer)
+	}
+	// both accounts, the transfer and its two transactions
+	if len(transfer.Entities) != 5 || transfer.Entities[0] != from.AccountUuid || transfer.Entities[1] != to.AccountUuid || transfer.Entities[2] != transferUuid {
+		t.Errorf("transfer entities = %v", transfer.Entities)
+	}
+
+	failed := entries[1]
+	if failed.Action != "CreateTransaction" || failed.Outcome != domainAudit.OutcomeFailed || failed.Principal != domainAudit.System.Principal ||
+		!strings.HasPrefix(failed.Error, domainBank.ErrInsufficientBalance.Error()) ||
+		len(failed.Entities) != 1 || failed.Entities[0] != from.AccountUuid {
+		t.Errorf("failed transaction entry = %+v", failed)
+	}
+
+	if n, err := application.VerifyAuditLog(ctx, store); err != nil || n != 2 {
+		t.Errorf("VerifyAuditLog = %d, %v; want 2 entries", n, err)
+	}
+}
+
+// tamperedLog serves the entries of a memory log with one of them changed.
+type tamperedLog struct {
+	*memory.MemoryAdapter
+	tamper func(entries []domainAudit.AuditEntryOrm) []domainAudit.AuditEntryOrm
+}
+
+func (l tamperedLog) AuditEntries(ctx context.Context, afterSeq int64, limit int) ([]domainAudit.AuditEntryOrm, error) {
+	entries, err := l.MemoryAdapter.AuditEntries(ctx, afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.tamper(entries), nil
+}
+
+func TestVerifyAuditLogDetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewMemoryAdapter()
+	for _, action := range []string{"CreateExchangeRate", "Transfer", "CreateTransaction"} {
+		if _, err := store.AppendAuditEntry(ctx, domainAudit.AuditEntryOrm{OccurredAt: time.Now(), Principal: "system", Action: action, Outcome: domainAudit.OutcomeOK}); err != nil {
+			t.Fatalf("AppendAuditEntry: %v", err)
+		}
+	}
+
+	tampers := map[string]func(entries []domainAudit.AuditEntryOrm) []domainAudit.AuditEntryOrm{
+		"changed": func(entries []domainAudit.AuditEntryOrm) []domainAudit.AuditEntryOrm {
+			entries[1].Outcome = domainAudit.OutcomeFailed
+			return entries
+		},
+		"removed": func(entries []domainAudit.AuditEntryOrm) []domainAudit.AuditEntryOrm {
+			return append(entries[:1], entries[2:]...)
+		},
+		"swapped": func(entries []domainAudit.AuditEntryOrm) []domainAudit.AuditEntryOrm {
+			entries[1], entries[2] = entries[2], entries[1]
+			return entries
+		},
+		"truncated": func(entries []domainAudit.AuditEntryOrm) []domainAudit.AuditEntryOrm {
+			return entries[:2]
+		},
+	}
+
+	for name, tamper := range tampers {
+		t.Run(name, func(t *testing.T) {
+			if _, err := application.VerifyAuditLog(ctx, tamperedLog{store, tamper}); !errors.Is(err, domainAudit.ErrChainBroken) {
+				t.Errorf("VerifyAuditLog = %v, want ErrChainBroken", err)
+			}
+		})
+	}
+}
@@ -1,41 +1,89 @@
 package application
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
 	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
+var supportedCurrencies = map[string]bool{
+	"USD": true,
+	"IDR": true,
+}
+
+const (
+	defaultTransferPageSize = 50
+	maxTransferPageSize     = 500
+)
+
 type BankService struct {
-	db port.BankDatabasePort
+	db       port.BankDatabasePort
+	clock    clock.Clock
+	activity *ActivityBus
+	audit    *Auditor
+	// fees is nil when the store keeps no fee schedules, transfers are then
+	// free.
+	fees port.FeeStorePort
 }
 
-func NewBankService(dbPort port.BankDatabasePort) *BankService {
+func NewBankService(dbPort port.BankDatabasePort, clk clock.Clock) *BankService {
 	return &BankService{
-		db: dbPort,
+		db:       dbPort,
+		clock:    clk,
+		activity: NewActivityBus(activityHistorySize),
+		audit:    auditorOf(dbPort, clk),
+		fees:     feeStoreOf(dbPort),
 	}
 }
 
-func (s *BankService) GetCurrentBalance(account string) (float64, error) {
-	bankAccount, err := s.db.GetBalanceBankAccountByAccountNumber(account)
+func feeStoreOf(store interface{}) port.FeeStorePort {
+	fees, _ := store.(port.FeeStorePort)
+	return fees
+}
+
+// GetCurrentBalance returns the ledger balance of account and what of it is
+// available, not reserved by holds.
+func (s *BankService) GetCurrentBalance(ctx context.Context, account string) (balance float64, available float64, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.GetCurrentBalance")
+	defer tracing.End(span, &err)
+
+	bankAccount, err := s.db.GetBalanceBankAccountByAccountNumber(ctx, account)
 
 	if err != nil {
 		logErr := util.LogError("Error on FindCurrentBalance: "+err.Error(), "", "DatabaseAdapter - GetBankAccountByAccountNumber")
-		log.Error().Msg(logErr)
-		return 0, err
+		log.Error().Ctx(ctx).Msg(logErr)
+		return 0, 0, err
+	}
+
+	available, err = s.availableBalance(ctx, bankAccount.AccountUuid, bankAccount.CurrentBalance, s.clock.Now())
+	if err != nil {
+		return 0, 0, err
 	}
 
-	return bankAccount.CurrentBalance, nil
+	return bankAccount.CurrentBalance, available, nil
 }
 
-func (s *BankService) CreateExchangeRate(r domainBank.ExchangeRate) (uuid.UUID, error) {
+func (s *BankService) CreateExchangeRate(ctx context.Context, r domainBank.ExchangeRate) (rateUuid uuid.UUID, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.CreateExchangeRate")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "CreateExchangeRate")
+	defer s.audit.End(ctx, scope, &err)
+
 	newUuid := uuid.New()
-	now := time.Now()
+	now := s.clock.Now()
 
 	exchangeRateOrm := domainBank.BankExchangeRateOrm{
 		ExchangeRateUuid:   newUuid,
@@ -48,15 +96,26 @@ func (s *BankService) CreateExchangeRate(r domainBank.ExchangeRate) (uuid.UUID,
 		UpdatedAt:          now,
 	}
 
-	return s.db.InsertExchangeRate(exchangeRateOrm)
+	rateUuid, err = s.db.InsertExchangeRate(ctx, exchangeRateOrm)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	auditAffect(ctx, rateUuid)
+
+	metrics.ExchangeRate.WithLabelValues(r.FromCurrency, r.ToCurrency).Set(r.Rate)
+
+	return rateUuid, nil
 }
 
-func (s *BankService) FindExchangeRate(fromCurrency string, toCurrency string, ts time.Time) (float64, error) {
-	exchangeRate, err := s.db.GetExchangeRateAtTimestamp(fromCurrency, toCurrency, ts)
+func (s *BankService) FindExchangeRate(ctx context.Context, fromCurrency string, toCurrency string, ts time.Time) (rate float64, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.FindExchangeRate")
+	defer tracing.End(span, &err)
+
+	exchangeRate, err := s.db.GetExchangeRateAtTimestamp(ctx, fromCurrency, toCurrency, ts)
 
 	if err != nil {
 		logErr := util.LogError("Error on FindExchangeRate: "+err.Error(), "", "DatabaseAdapter - GetExchangeRateAtTimestamp")
-		log.Error().Msg(logErr)
+		log.Error().Ctx(ctx).Msg(logErr)
 
 		return 0, err
 	}
@@ -64,24 +123,29 @@ func (s *BankService) FindExchangeRate(fromCurrency string, toCurrency string, t
 	return exchangeRate.Rate, nil
 }
 
-func (s *BankService) CreateTransaction(accountNum string, trx domainBank.Transaction) (uuid.UUID, error) {
+func (s *BankService) CreateTransaction(ctx context.Context, accountNum string, trx domainBank.Transaction) (trxUuid uuid.UUID, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.CreateTransaction")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "CreateTransaction")
+	defer s.audit.End(ctx, scope, &err)
+
 	newUuid := uuid.New()
-	now := time.Now()
+	now := s.clock.Now()
 
-	bankAccountDetail, err := s.db.GetDetailBankAccountByAccountNumber(accountNum)
+	bankAccountDetail, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
 
 	if err != nil {
 		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Bank Service - CreateTransaction")
-		log.Error().Msg(logErr)
+		log.Error().Ctx(ctx).Msg(logErr)
 		return uuid.Nil, err
 	}
+	auditAffect(ctx, bankAccountDetail.AccountUuid)
 
-	// Check if the transaction is an "out" transaction and if the account has sufficient balance
-	if trx.TransactionType == domainBank.TransactionTypeOut && bankAccountDetail.CurrentBalance < trx.Amount {
-		err := fmt.Errorf("insufficient balance: transaction amount %v exceeds current balance %v", trx.Amount, bankAccountDetail.CurrentBalance)
-		logErr := util.LogError(fmt.Sprintf("Can't create transaction : %v\n", err), "", "BankAdapter - CreateTransaction")
-		log.Error().Msg(logErr)
-		return uuid.Nil, err
+	// an "out" transaction has to stay within the limits of the account
+	if trx.TransactionType == domainBank.TransactionTypeOut {
+		if err := s.checkDebit(ctx, "transaction", bankAccountDetail, trx.Amount, now); err != nil {
+			return uuid.Nil, err
+		}
 	}
 
 	transactionOrm := domainBank.BankTransactionOrm{
@@ -95,12 +159,19 @@ func (s *BankService) CreateTransaction(accountNum string, trx domainBank.Transa
 		UpdatedAt:            now,
 	}
 
-	saveUuid, err := s.db.CreateTransaction(bankAccountDetail, transactionOrm)
+	saveUuid, err := s.db.CreateTransaction(ctx, bankAccountDetail, transactionOrm)
 	if err != nil {
+		// a concurrent debit or hold took the money after checkDebit
+		if errors.Is(err, domainBank.ErrInsufficientBalance) {
+			metrics.InsufficientBalance.WithLabelValues("transaction").Inc()
+		}
 		logErr := util.LogError("Error on CreateTransaction: "+err.Error(), "", "Bank Service - CreateTransaction")
-		log.Error().Msg(logErr)
+		log.Error().Ctx(ctx).Msg(logErr)
 		return uuid.Nil, err
 	}
+	auditAffect(ctx, saveUuid)
+
+	s.publishActivity(ctx, bankAccountDetail, transactionOrm, uuid.Nil, "")
 
 	return saveUuid, nil
 }
@@ -120,59 +191,185 @@ func (s *BankService) CalculateTransactionSummary(trxSum *domainBank.Transaction
 	return nil
 }
 
-func (s *BankService) Transfer(trf domainBank.TransferTransaction) (uuid.UUID, bool, error) {
-	// get from account by account number from
-	accountNumberFrom := trf.FromAccountNumber
-	accountnumberTo := trf.ToAccountNumber
-	if trf.Amount < 0 {
-		logErr := util.LogError(fmt.Sprintf("Amount is less than  0 : %v\n", trf.Amount), "", "Bank Service - Transfer - Checking Amount")
-		log.Error().Msg(logErr)
-		return uuid.Nil, false, domainBank.ErrTransferRecordFailed
+// Transfer moves trf.Amount from the sender to the recipient and charges the
+// sender the fee of the transfer, returned as its breakdown.
+func (s *BankService) Transfer(ctx context.Context, trf domainBank.TransferTransaction) (transferUuid uuid.UUID, success bool, fee domainFee.Breakdown, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.Transfer")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "Transfer")
+	defer s.audit.End(ctx, scope, &err)
+
+	trf.Channel = strings.ToUpper(strings.TrimSpace(trf.Channel))
+	transferUuid, success, fee, err = s.transfer(ctx, trf)
+
+	status := metrics.TransferStatusFailed
+	if err == nil && success {
+		status = metrics.TransferStatusSuccess
+	}
+	// keep label cardinality bounded, the currency comes straight from the client
+	currency := trf.Currency
+	if !supportedCurrencies[currency] {
+		currency = "OTHER"
+	}
+	metrics.Transfers.WithLabelValues(currency, status).Inc()
+	if trf.Amount > 0 {
+		metrics.TransferAmount.WithLabelValues(currency, status).Add(trf.Amount)
+	}
+	if fee.Charged > 0 {
+		metrics.TransferFees.WithLabelValues(currency).Add(fee.Fee)
+	}
+
+	return transferUuid, success, fee, err
+}
+
+// QuoteTransfer prices trf without booking it: the amount the recipient would
+// be credited, in the unit of the accounts, and the fee of the sender.
+func (s *BankService) QuoteTransfer(ctx context.Context, trf domainBank.TransferTransaction) (booked float64, fee domainFee.Breakdown, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.QuoteTransfer")
+	defer tracing.End(span, &err)
+
+	trf.Channel = strings.ToUpper(strings.TrimSpace(trf.Channel))
+	now := s.clock.Now()
+
+	booked, unit, err := s.bookedAmount(ctx, trf, now)
+	if err != nil {
+		return 0, domainFee.Breakdown{}, err
 	}
-	now := time.Now()
 
-	currencySet := map[string]bool{
-		"USD": true,
-		"IDR": true,
+	bankAccountDetailFrom, err := s.db.GetDetailBankAccountByAccountNumber(ctx, trf.FromAccountNumber)
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't GetDetailBankAccountByAccountNumber From : %v\n", err), "", "Bank Service - QuoteTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return 0, domainFee.Breakdown{}, domainBank.ErrTransferSourceAccountNotFound
+	}
+
+	fee, _, err = s.transferFee(ctx, trf, bankAccountDetailFrom, unit, now)
+	if err != nil {
+		return 0, domainFee.Breakdown{}, domainBank.ErrTransferRecordFailed
+	}
+
+	return booked, fee, nil
+}
+
+// bookedAmount validates the amount and currency of trf and converts the
+// amount to the unit of the accounts. unit is what one unit of the currency
+// of trf is worth in it.
+func (s *BankService) bookedAmount(ctx context.Context, trf domainBank.TransferTransaction, now time.Time) (amount float64, unit float64, err error) {
+	if trf.Amount < 0 {
+		logErr := util.LogError(fmt.Sprintf("Amount is less than  0 : %v\n", trf.Amount), "", "Bank Service - Transfer - Checking Amount")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return 0, 0, domainBank.ErrTransferRecordFailed
 	}
 
-	if !currencySet[trf.Currency] {
+	if !supportedCurrencies[trf.Currency] {
 		logErr := util.LogError("currency is not available", "", "Bank Service - Transfer - Checking Amount")
-		log.Error().Msg(logErr)
-		return uuid.Nil, false, domainBank.ErrTransferRecordFailed
+		log.Error().Ctx(ctx).Msg(logErr)
+		return 0, 0, domainBank.ErrTransferRecordFailed
 	}
 
-	amountTransfer := trf.Amount
 	if trf.Currency == "IDR" {
-		rate, err := s.db.GetExchangeRateAtTimestamp("USD", "IDR", time.Now())
+		rate, err := s.db.GetExchangeRateAtTimestamp(ctx, "USD", "IDR", now)
 		if err != nil {
 			logErr := util.LogError(fmt.Sprintf("Can't GetExchangeRateAtTimestamp : %v\n", err), "", "Bank Service - Transfer")
-			log.Error().Msg(logErr)
-			return uuid.Nil, false, domainBank.ErrTransferRecordFailed
+			log.Error().Ctx(ctx).Msg(logErr)
+			return 0, 0, domainBank.ErrTransferRecordFailed
+		}
+		return trf.Amount / rate.Rate, 1 / rate.Rate, nil
+	}
+
+	return trf.Amount, 1, nil
+}
+
+// transferFee prices trf for its sender from, with the free transfers the
+// sender has left this month, and returns the schedule that applies. The
+// breakdown has no schedule when none applies.
+func (s *BankService) transferFee(ctx context.Context, trf domainBank.TransferTransaction, from domainBank.BankAccountOrm, unit float64, now time.Time) (domainFee.Breakdown, domainFee.Schedule, error) {
+	if s.fees == nil {
+		return domainFee.Breakdown{}, domainFee.Schedule{}, nil
+	}
+
+	schedules, err := s.fees.ListFeeSchedules(ctx)
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't ListFeeSchedules : %v\n", err), "", "Bank Service - Transfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainFee.Breakdown{}, domainFee.Schedule{}, err
+	}
+	schedule, ok := domainFee.Match(schedules, trf.Currency, trf.Channel, from.CustomerSegment)
+	if !ok {
+		return domainFee.Breakdown{}, domainFee.Schedule{}, nil
+	}
+
+	used := 0
+	if schedule.FreePerMonth > 0 {
+		used, err = s.fees.CountTransferFees(ctx, from.AccountUuid, schedule.ScheduleUuid, domainFee.MonthStart(now))
+		if err != nil {
+			logErr := util.LogError(fmt.Sprintf("Can't CountTransferFees : %v\n", err), "", "Bank Service - Transfer")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return domainFee.Breakdown{}, domainFee.Schedule{}, err
 		}
-		amountTransfer = trf.Amount / rate.Rate
 	}
 
-	bankAccountDetailFrom, err := s.db.GetDetailBankAccountByAccountNumber(accountNumberFrom)
+	return schedule.Evaluate(trf.Amount).Allow(schedule.FreePerMonth, used, unit), schedule, nil
+}
+
+func (s *BankService) transfer(ctx context.Context, trf domainBank.TransferTransaction) (uuid.UUID, bool, domainFee.Breakdown, error) {
+	var fee domainFee.Breakdown
+
+	// get from account by account number from
+	accountNumberFrom := trf.FromAccountNumber
+	accountnumberTo := trf.ToAccountNumber
+	now := s.clock.Now()
+
+	amountTransfer, unit, err := s.bookedAmount(ctx, trf, now)
+	if err != nil {
+		return uuid.Nil, false, fee, err
+	}
+
+	bankAccountDetailFrom, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNumberFrom)
 	if err != nil {
 		logErr := util.LogError(fmt.Sprintf("Can't GetDetailBankAccountByAccountNumber From : %v\n", err), "", "Bank Service - Transfer")
-		log.Error().Msg(logErr)
-		return uuid.Nil, false, domainBank.ErrTransferSourceAccountNotFound
+		log.Error().Ctx(ctx).Msg(logErr)
+		return uuid.Nil, false, fee, domainBank.ErrTransferSourceAccountNotFound
+	}
+	auditAffect(ctx, bankAccountDetailFrom.AccountUuid)
+
+	fee, schedule, err := s.transferFee(ctx, trf, bankAccountDetailFrom, unit, now)
+	if err != nil {
+		return uuid.Nil, false, domainFee.Breakdown{}, domainBank.ErrTransferRecordFailed
 	}
 
-	if bankAccountDetailFrom.CurrentBalance < amountTransfer {
-		return uuid.Nil, false, domainBank.ErrTransferTransactionPair
+	if err := s.checkDebit(ctx, "transfer", bankAccountDetailFrom, amountTransfer+fee.Charged, now); err != nil {
+		if errors.Is(err, domainBank.ErrInsufficientBalance) {
+			return uuid.Nil, false, fee, fmt.Errorf("%w: %w", domainBank.ErrTransferTransactionPair, err)
+		}
+		return uuid.Nil, false, fee, domainBank.ErrTransferRecordFailed
 	}
 
-	bankAccountDetailTo, err := s.db.GetDetailBankAccountByAccountNumber(accountnumberTo)
+	bankAccountDetailTo, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountnumberTo)
 	if err != nil {
 		logErr := util.LogError(fmt.Sprintf("Can't GetDetailBankAccountByAccountNumber To : %v\n", err), "", "Bank Service - Transfer")
-		log.Error().Msg(logErr)
-		return uuid.Nil, false, domainBank.ErrTransferDestinationAccountNotFound
+		log.Error().Ctx(ctx).Msg(logErr)
+		return uuid.Nil, false, fee, domainBank.ErrTransferDestinationAccountNotFound
+	}
+	auditAffect(ctx, bankAccountDetailTo.AccountUuid)
+
+	var feeAccount domainBank.BankAccountOrm
+	if fee.ScheduleUuid != uuid.Nil {
+		feeAccount, err = s.db.GetDetailBankAccountByUuid(ctx, schedule.FeeAccountUuid)
+		if err != nil {
+			logErr := util.LogError(fmt.Sprintf("Can't GetDetailBankAccountByUuid of the fee account : %v\n", err), "", "Bank Service - Transfer")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return uuid.Nil, false, fee, domainBank.ErrTransferRecordFailed
+		}
+	}
+
+	transferUuid := trf.TransferUuid
+	if transferUuid == uuid.Nil {
+		transferUuid = uuid.New()
 	}
 
 	transferDetail := domainBank.BankTransferOrm{
-		TransferUuid:      uuid.New(),
+		TransferUuid:      transferUuid,
 		FromAccountUuid:   bankAccountDetailFrom.AccountUuid,
 		ToAccountUuid:     bankAccountDetailTo.AccountUuid,
 		Currency:          trf.Currency,
@@ -183,19 +380,20 @@ func (s *BankService) Transfer(trf domainBank.TransferTransaction) (uuid.UUID, b
 		UpdatedAt:         now,
 	}
 
-	uuidTrans, err := s.db.CreateTransfer(transferDetail)
+	uuidTrans, err := s.db.CreateTransfer(ctx, transferDetail)
 	if err != nil {
 		logErr := util.LogError(fmt.Sprintf("Can't CreateTransfer : %v\n", err), "", "Bank Service - Transfer")
-		log.Error().Msg(logErr)
-		return uuid.Nil, false, domainBank.ErrTransferRecordFailed
+		log.Error().Ctx(ctx).Msg(logErr)
+		return uuid.Nil, false, fee, domainBank.ErrTransferRecordFailed
 	}
+	auditAffect(ctx, uuidTrans)
 
 	bankTransactionOrmFrom := domainBank.BankTransactionOrm{
 		TransactionUuid:      uuid.New(),
 		AccountUuid:          bankAccountDetailFrom.AccountUuid,
 		TransactionTimestamp: now,
 		Amount:               amountTransfer,
-		TransactionType:      "1",
+		TransactionType:      domainBank.TransactionTypeOut,
 		Notes:                trf.Notes,
 		CreatedAt:            now,
 		UpdatedAt:            now,
@@ -203,27 +401,307 @@ func (s *BankService) Transfer(trf domainBank.TransferTransaction) (uuid.UUID, b
 
 	bankTransactionOrmTo := domainBank.BankTransactionOrm{
 		TransactionUuid:      uuid.New(),
-		AccountUuid:          bankAccountDetailFrom.AccountUuid,
+		AccountUuid:          bankAccountDetailTo.AccountUuid,
 		TransactionTimestamp: now,
 		Amount:               amountTransfer,
-		TransactionType:      "2",
+		TransactionType:      domainBank.TransactionTypeIn,
 		Notes:                trf.Notes,
 		CreatedAt:            now,
 		UpdatedAt:            now,
 	}
 
-	status, err := s.db.CreateTransferTransactionPair(bankAccountDetailFrom, bankAccountDetailTo, bankTransactionOrmFrom, bankTransactionOrmTo)
+	var charge domainFee.Charge
+	status := true
+	if fee.ScheduleUuid != uuid.Nil {
+		charge = domainFee.Charge{
+			TransferUuid: transferDetail.TransferUuid,
+			Currency:     trf.Currency,
+			Breakdown:    fee,
+			FreePerMonth: schedule.FreePerMonth,
+			Rate:         unit,
+			MonthStart:   domainFee.MonthStart(now),
+			FeeAccount:   feeAccount,
+			FeeTransaction: domainBank.BankTransactionOrm{
+				TransactionUuid:      uuid.New(),
+				AccountUuid:          bankAccountDetailFrom.AccountUuid,
+				TransactionTimestamp: now,
+				TransactionType:      domainBank.TransactionTypeOut,
+				Notes:                "Transfer fee",
+				CreatedAt:            now,
+				UpdatedAt:            now,
+			},
+			IncomeTransaction: domainBank.BankTransactionOrm{
+				TransactionUuid:      uuid.New(),
+				AccountUuid:          feeAccount.AccountUuid,
+				TransactionTimestamp: now,
+				TransactionType:      domainBank.TransactionTypeIn,
+				Notes:                "Transfer fee from " + bankAccountDetailFrom.AccountNumber,
+				CreatedAt:            now,
+				UpdatedAt:            now,
+			},
+		}
+		fee, err = s.fees.CreateChargedTransferTransactionPair(ctx, bankAccountDetailFrom, bankAccountDetailTo, bankTransactionOrmFrom, bankTransactionOrmTo, charge)
+	} else {
+		status, err = s.db.CreateTransferTransactionPair(ctx, bankAccountDetailFrom, bankAccountDetailTo, bankTransactionOrmFrom, bankTransactionOrmTo)
+	}
 	if err != nil {
 		logErr := util.LogError(fmt.Sprintf("Can't CreateTransferTransactionPair : %v\n", err), "", "Bank Service - Transfer")
-		log.Error().Msg(logErr)
-		return uuid.Nil, false, domainBank.ErrTransferTransactionPair
+		log.Error().Ctx(ctx).Msg(logErr)
+
+		// record the outcome, so the transfer doesn't look pending forever
+		if err := s.db.UpdateTransferStatus(ctx, transferDetail, false); err != nil {
+			logErr := util.LogError(fmt.Sprintf("Can't UpdateTransferStatus : %v\n", err), "", "Bank Service - Transfer")
+			log.Error().Ctx(ctx).Msg(logErr)
+		}
+
+		// a concurrent debit or hold took the money after checkDebit
+		if errors.Is(err, domainBank.ErrInsufficientBalance) {
+			metrics.InsufficientBalance.WithLabelValues("transfer").Inc()
+			return uuid.Nil, false, domainFee.Breakdown{}, fmt.Errorf("%w: %w", domainBank.ErrTransferTransactionPair, err)
+		}
+		return uuid.Nil, false, domainFee.Breakdown{}, domainBank.ErrTransferTransactionPair
+	}
+
+	auditAffect(ctx, bankTransactionOrmFrom.TransactionUuid, bankTransactionOrmTo.TransactionUuid)
+	if fee.Charged > 0 {
+		auditAffect(ctx, charge.FeeTransaction.TransactionUuid, charge.IncomeTransaction.TransactionUuid)
+	}
+
+	err = s.db.UpdateTransferStatus(ctx, transferDetail, status)
+	if err != nil {
+		return uuid.Nil, false, fee, domainBank.ErrTransferRecordFailed
+	}
+
+	s.publishActivity(ctx, bankAccountDetailFrom, bankTransactionOrmFrom, transferDetail.TransferUuid, bankAccountDetailTo.AccountNumber)
+	s.publishActivity(ctx, bankAccountDetailTo, bankTransactionOrmTo, transferDetail.TransferUuid, bankAccountDetailFrom.AccountNumber)
+	if fee.Charged > 0 {
+		feeTrx, incomeTrx := charge.FeeTransaction, charge.IncomeTransaction
+		feeTrx.Amount, incomeTrx.Amount = fee.Charged, fee.Charged
+		s.publishActivity(ctx, bankAccountDetailFrom, feeTrx, transferDetail.TransferUuid, feeAccount.AccountNumber)
+		s.publishActivity(ctx, feeAccount, incomeTrx, transferDetail.TransferUuid, bankAccountDetailFrom.AccountNumber)
+	}
+
+	return uuidTrans, true, fee, nil
+
+}
+
+// ReverseTransfer refunds a completed transfer, in full or in part, with a
+// transfer back from its recipient to its sender. It returns the reversal
+// and what is left to refund of the transfer after it.
+func (s *BankService) ReverseTransfer(ctx context.Context, r domainBank.TransferReversal) (reversal domainBank.BankTransferOrm, remaining float64, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.ReverseTransfer")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "ReverseTransfer")
+	defer s.audit.End(ctx, scope, &err)
+
+	amount := math.Round(r.Amount*100) / 100
+	if strings.TrimSpace(r.Reason) == "" || r.Amount < 0 || (r.Amount > 0 && amount == 0) {
+		return reversal, 0, domainBank.ErrReversalInvalid
+	}
+
+	original, err := s.db.GetTransfer(ctx, r.TransferUuid)
+	if err != nil {
+		logErr := util.LogError("Error on GetTransfer: "+err.Error(), "", "Bank Service - ReverseTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return reversal, 0, err
+	}
+	auditAffect(ctx, original.TransferUuid)
+
+	if !original.TransferSuccess || original.ReversalOfUuid != nil {
+		return reversal, 0, domainBank.ErrTransferNotReversible
+	}
+
+	left := original.RemainingAmount()
+	switch {
+	case left <= 0:
+		return reversal, 0, domainBank.ErrTransferAlreadyReversed
+	case amount == 0:
+		amount = left
+	case amount > left:
+		return reversal, 0, fmt.Errorf("%w: %v requested, %v left", domainBank.ErrReversalExceedsTransfer, amount, left)
+	}
+
+	// the money goes back the way it came
+	recipient, err := s.db.GetDetailBankAccountByUuid(ctx, original.ToAccountUuid)
+	if err != nil {
+		return reversal, 0, err
+	}
+	sender, err := s.db.GetDetailBankAccountByUuid(ctx, original.FromAccountUuid)
+	if err != nil {
+		return reversal, 0, err
+	}
+	auditAffect(ctx, recipient.AccountUuid, sender.AccountUuid)
+
+	now := s.clock.Now()
+	notes := fmt.Sprintf("Reversal of transfer %v: %v", original.TransferUuid, r.Reason)
+	reversal = domainBank.BankTransferOrm{
+		TransferUuid:      uuid.New(),
+		FromAccountUuid:   recipient.AccountUuid,
+		ToAccountUuid:     sender.AccountUuid,
+		Currency:          original.Currency,
+		Amount:            amount,
+		TransferTimestamp: now,
+		TransferSuccess:   true,
+		ReversalOfUuid:    &original.TransferUuid,
+		ReversalReason:    r.Reason,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	booking := domainBank.TransferReversalBooking{
+		Original:  original,
+		Reversal:  reversal,
+		Recipient: recipient,
+		Sender:    sender,
+		Debit: domainBank.BankTransactionOrm{
+			TransactionUuid:      uuid.New(),
+			AccountUuid:          recipient.AccountUuid,
+			TransactionTimestamp: now,
+			Amount:               amount,
+			TransactionType:      domainBank.TransactionTypeOut,
+			Notes:                notes,
+			CreatedAt:            now,
+			UpdatedAt:            now,
+		},
+		Credit: domainBank.BankTransactionOrm{
+			TransactionUuid:      uuid.New(),
+			AccountUuid:          sender.AccountUuid,
+			TransactionTimestamp: now,
+			Amount:               amount,
+			TransactionType:      domainBank.TransactionTypeIn,
+			Notes:                notes,
+			CreatedAt:            now,
+			UpdatedAt:            now,
+		},
+		AllowOverdraft: r.AllowOverdraft,
+	}
+
+	if err := s.db.ReverseTransfer(ctx, booking); err != nil {
+		if errors.Is(err, domainBank.ErrInsufficientBalance) {
+			metrics.InsufficientBalance.WithLabelValues("reversal").Inc()
+		}
+		logErr := util.LogError("Error on ReverseTransfer: "+err.Error(), "", "Bank Service - ReverseTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainBank.BankTransferOrm{}, 0, err
+	}
+	auditAffect(ctx, reversal.TransferUuid, booking.Debit.TransactionUuid, booking.Credit.TransactionUuid)
+
+	log.Info().Ctx(ctx).Msgf("Transfer %v reversed by %v for %v", original.TransferUuid, reversal.TransferUuid, amount)
+
+	s.publishActivity(ctx, recipient, booking.Debit, reversal.TransferUuid, sender.AccountNumber)
+	s.publishActivity(ctx, sender, booking.Credit, reversal.TransferUuid, recipient.AccountNumber)
+
+	original.ReversedAmount += amount
+
+	return reversal, original.RemainingAmount(), nil
+}
+
+func (s *BankService) GetTransfer(ctx context.Context, transferUuid uuid.UUID) (transfer domainBank.TransferDetail, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.GetTransfer")
+	defer tracing.End(span, &err)
+
+	transfer, err = s.db.GetTransferDetail(ctx, transferUuid)
+	if err != nil {
+		logErr := util.LogError("Error on GetTransferDetail: "+err.Error(), "", "Bank Service - GetTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return transfer, err
+	}
+
+	return transfer, nil
+}
+
+// ListTransfers returns a page of the transfers of accountNum, of every
+// account when it is empty, matching filter, and the cursor of the next page,
+// nil on the last one.
+func (s *BankService) ListTransfers(ctx context.Context, accountNum string, filter domainBank.TransferFilter) (transfers []domainBank.TransferDetail, next *domainBank.TransferCursor, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.ListTransfers")
+	defer tracing.End(span, &err)
+
+	if accountNum == "" && filter.Direction != domainBank.TransferDirectionAny {
+		return nil, nil, domainBank.ErrTransferFilterInvalid
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, nil, domainBank.ErrTransferFilterInvalid
+	}
+
+	if accountNum != "" {
+		account, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+		if err != nil {
+			logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Bank Service - ListTransfers")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return nil, nil, err
+		}
+		filter.AccountUuid, filter.AccountNumber = account.AccountUuid, account.AccountNumber
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTransferPageSize
+	} else if filter.Limit > maxTransferPageSize {
+		filter.Limit = maxTransferPageSize
+	}
+	pageSize := filter.Limit
+	// one more tells whether there is a next page
+	filter.Limit++
+
+	transfers, err = s.db.ListTransfers(ctx, filter)
+	if err != nil {
+		logErr := util.LogError("Error on ListTransfers: "+err.Error(), "", "Bank Service - ListTransfers")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, nil, err
+	}
+
+	if len(transfers) > pageSize {
+		transfers = transfers[:pageSize]
+		cursor := transfers[pageSize-1].Cursor()
+		next = &cursor
 	}
 
-	err = s.db.UpdateTransferStatus(transferDetail, status)
+	return transfers, next, nil
+}
+
+// SubscribeAccountActivity streams the transactions posted on accountNum by
+// this process, resuming after lastEventID when it is set.
+func (s *BankService) SubscribeAccountActivity(ctx context.Context, accountNum string, lastEventID string) (port.ActivitySubscription, error) {
+	if _, err := s.db.GetBalanceBankAccountByAccountNumber(ctx, accountNum); err != nil {
+		logErr := util.LogError("Error on GetBalanceBankAccountByAccountNumber: "+err.Error(), "", "Bank Service - SubscribeAccountActivity")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	sub, err := s.activity.Subscribe(accountNum, lastEventID)
 	if err != nil {
-		return uuid.Nil, false, domainBank.ErrTransferRecordFailed
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// publishActivity tells the subscribers of account about trx, committed
+// before. The balance is read back after the commit, so with concurrent
+// postings it may already include a later one; the last activity always
+// carries the current balance.
+func (s *BankService) publishActivity(ctx context.Context, account domainBank.BankAccountOrm, trx domainBank.BankTransactionOrm, transferUuid uuid.UUID, counterparty string) {
+	balance := account.CurrentBalance + trx.Amount
+	if trx.TransactionType == domainBank.TransactionTypeOut {
+		balance = account.CurrentBalance - trx.Amount
 	}
 
-	return uuidTrans, true, nil
+	current, err := s.db.GetBalanceBankAccountByAccountNumber(ctx, account.AccountNumber)
+	if err != nil {
+		logErr := util.LogError("Error on GetBalanceBankAccountByAccountNumber: "+err.Error(), "", "Bank Service - publishActivity")
+		log.Error().Ctx(ctx).Msg(logErr)
+	} else {
+		balance = current.CurrentBalance
+	}
 
+	s.activity.Publish(domainBank.AccountActivity{
+		AccountNumber:             account.AccountNumber,
+		TransactionType:           trx.TransactionType,
+		Amount:                    trx.Amount,
+		Balance:                   balance,
+		TransactionUuid:           trx.TransactionUuid,
+		TransferUuid:              transferUuid,
+		CounterpartyAccountNumber: counterparty,
+		Notes:                     trx.Notes,
+		Timestamp:                 trx.TransactionTimestamp,
+	})
 }
@@ -0,0 +1,139 @@
+// Package domain defines the audit log: one entry per mutating operation,
+// each sealed with the hash of the entry before it, so changing, removing or
+// reordering entries breaks the chain.
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OutcomeOK     = "OK"
+	OutcomeFailed = "FAILED"
+)
+
+// GenesisHash is the previous hash of the first entry.
+var GenesisHash = hex.EncodeToString(make([]byte, sha256.Size))
+
+var ErrChainBroken = errors.New("audit chain is broken")
+
+// Actor is who asked for an operation and how. The gRPC adapter sets it for
+// every call, background jobs set their own.
+type Actor struct {
+	Principal  string
+	RemoteAddr string
+	// Method is the full gRPC method, or the job or command name.
+	Method string
+	// Fingerprint is the hex SHA-256 of the request message.
+	Fingerprint string
+}
+
+// System is the actor of operations started by the server itself.
+var System = Actor{Principal: "system"}
+
+type AuditEntryOrm struct {
+	Seq         int64 `gorm:"primaryKey;autoIncrement:false"`
+	OccurredAt  time.Time
+	Principal   string
+	RemoteAddr  string
+	Method      string
+	Action      string
+	Fingerprint string
+	Outcome     string
+	Error       string
+	Entities    []uuid.UUID `gorm:"serializer:json"`
+	PrevHash    string
+	Hash        string
+}
+
+func (AuditEntryOrm) TableName() string {
+	return "bank_audit_log"
+}
+
+// Seal places e after the entry with seq-1 and hash prevHash and sets its
+// hash. OccurredAt is truncated to the microseconds the database keeps.
+func (e *AuditEntryOrm) Seal(seq int64, prevHash string) {
+	e.Seq = seq
+	e.PrevHash = prevHash
+	e.OccurredAt = e.OccurredAt.UTC().Truncate(time.Microsecond)
+	if e.Entities == nil {
+		e.Entities = []uuid.UUID{}
+	}
+	e.Hash = e.ComputeHash()
+}
+
+// ComputeHash is the hex SHA-256 of the JSON encoding of every field but
+// Hash.
+func (e AuditEntryOrm) ComputeHash() string {
+	entities := e.Entities
+	if entities == nil {
+		entities = []uuid.UUID{}
+	}
+
+	content, _ := json.Marshal(struct {
+		Seq         int64       `json:"seq"`
+		OccurredAt  string      `json:"occurred_at"`
+		Principal   string      `json:"principal"`
+		RemoteAddr  string      `json:"remote_addr"`
+		Method      string      `json:"method"`
+		Action      string      `json:"action"`
+		Fingerprint string      `json:"fingerprint"`
+		Outcome     string      `json:"outcome"`
+		Error       string      `json:"error"`
+		Entities    []uuid.UUID `json:"entities"`
+		PrevHash    string      `json:"prev_hash"`
+	}{
+		e.Seq, e.OccurredAt.UTC().Format(time.RFC3339Nano), e.Principal, e.RemoteAddr, e.Method,
+		e.Action, e.Fingerprint, e.Outcome, e.Error, entities, e.PrevHash,
+	})
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyLink checks that e follows the entry with seq-1 and hash prevHash and
+// that its own hash matches its content.
+func (e AuditEntryOrm) VerifyLink(seq int64, prevHash string) error {
+	switch {
+	case e.Seq != seq:
+		return fmt.Errorf("%w : entry %d follows entry %d", ErrChainBroken, e.Seq, seq-1)
+	case e.PrevHash != prevHash:
+		return fmt.Errorf("%w : previous hash of entry %d doesn't match entry %d", ErrChainBroken, e.Seq, seq-1)
+	case e.Hash != e.ComputeHash():
+		return fmt.Errorf("%w : content of entry %d doesn't match its hash", ErrChainBroken, e.Seq)
+	}
+
+	return nil
+}
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor of ctx, System when there is none.
+func ActorFrom(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return actor
+	}
+
+	return System
+}
+
+// WithFingerprint sets the fingerprint of the actor of ctx, for streams where
+// every message is its own operation.
+func WithFingerprint(ctx context.Context, fingerprint string) context.Context {
+	actor := ActorFrom(ctx)
+	actor.Fingerprint = fingerprint
+
+	return WithActor(ctx, actor)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSealAndVerifyLink(t *testing.T) {
+	first := AuditEntryOrm{
+		OccurredAt: time.Date(2024, time.May, 1, 10, 0, 0, 123456789, time.FixedZone("WIB", 7*3600)),
+		Principal:  "cert:teller",
+		Action:     "Transfer",
+		Outcome:    OutcomeOK,
+		Entities:   []uuid.UUID{uuid.MustParse("8f1b7a34-2a5c-4d5e-9f7a-2b0c3d4e5f60")},
+	}
+	first.Seal(1, GenesisHash)
+	if first.OccurredAt.Nanosecond() != 123456000 || first.OccurredAt.Location() != time.UTC {
+		t.Errorf("sealed time = %v, want it in UTC at microseconds", first.OccurredAt)
+	}
+	if err := first.VerifyLink(1, GenesisHash); err != nil {
+		t.Errorf("VerifyLink of the first entry: %v", err)
+	}
+
+	second := AuditEntryOrm{OccurredAt: first.OccurredAt, Principal: "system", Action: "CreateExchangeRate", Outcome: OutcomeOK}
+	second.Seal(2, first.Hash)
+	if err := second.VerifyLink(2, first.Hash); err != nil {
+		t.Errorf("VerifyLink of the second entry: %v", err)
+	}
+
+	tampered := first
+	tampered.Outcome = OutcomeFailed
+	reordered := second
+	reordered.Seq = 3
+	for name, check := range map[string]error{
+		"changed content": tampered.VerifyLink(1, GenesisHash),
+		"wrong seq":       reordered.VerifyLink(2, first.Hash),
+		"wrong previous":  second.VerifyLink(2, GenesisHash),
+	} {
+		if !errors.Is(check, ErrChainBroken) {
+			t.Errorf("%s: VerifyLink = %v, want ErrChainBroken", name, check)
+		}
+	}
+}
+
+func TestComputeHashCoversEveryField(t *testing.T) {
+	base := AuditEntryOrm{OccurredAt: time.Unix(0, 0)}
+	base.Seal(1, GenesisHash)
+
+	changes := []func(e *AuditEntryOrm){
+		func(e *AuditEntryOrm) { e.Seq++ },
+		func(e *AuditEntryOrm) { e.OccurredAt = e.OccurredAt.Add(time.Microsecond) },
+		func(e *AuditEntryOrm) { e.Principal = "x" },
+		func(e *AuditEntryOrm) { e.RemoteAddr = "x" },
+		func(e *AuditEntryOrm) { e.Method = "x" },
+		func(e *AuditEntryOrm) { e.Action = "x" },
+		func(e *AuditEntryOrm) { e.Fingerprint = "x" },
+		func(e *AuditEntryOrm) { e.Outcome = "x" },
+		func(e *AuditEntryOrm) { e.Error = "x" },
+		func(e *AuditEntryOrm) { e.Entities = []uuid.UUID{uuid.Nil} },
+		func(e *AuditEntryOrm) { e.PrevHash = "x" },
+	}
+	for i, change := range changes {
+		e := base
+		change(&e)
+		if e.ComputeHash() == base.Hash {
+			t.Errorf("change #%d doesn't change the hash", i)
+		}
+	}
+}
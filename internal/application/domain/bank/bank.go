@@ -2,7 +2,11 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -39,10 +43,250 @@ type TransferTransaction struct {
 	Currency          string
 	Amount            float64
 	Notes             string
+	// Channel is where the transfer comes from, like MOBILE or BRANCH, and
+	// picks its fee schedule.
+	Channel string
+	// TransferUuid identifies the transfer, a new one when uuid.Nil. A
+	// transfer with a known uuid fails, so retrying a request can't book it
+	// twice.
+	TransferUuid uuid.UUID
+}
+
+// TransferReversal asks to refund Amount of the transfer TransferUuid to its
+// sender, everything not refunded yet when Amount is zero. AllowOverdraft lets
+// the refund overdraw the recipient.
+type TransferReversal struct {
+	TransferUuid   uuid.UUID
+	Amount         float64
+	Reason         string
+	AllowOverdraft bool
+}
+
+// TransferReversalBooking is what a reversal of Original writes: Reversal, a
+// transfer back from Recipient to Sender, with the Debit of the recipient and
+// the Credit of the sender.
+type TransferReversalBooking struct {
+	Original       BankTransferOrm
+	Reversal       BankTransferOrm
+	Recipient      BankAccountOrm
+	Sender         BankAccountOrm
+	Debit          BankTransactionOrm
+	Credit         BankTransactionOrm
+	AllowOverdraft bool
+}
+
+// Hold asks to reserve Amount of an account until ExpiresAt, or for a
+// default period when it is zero.
+type Hold struct {
+	Amount    float64
+	ExpiresAt time.Time
+	Notes     string
+}
+
+// HoldCapture is what a capture of Hold writes: Transaction, the debit of
+// Account, whose amount is taken off what is left of the hold.
+type HoldCapture struct {
+	Hold        BankHoldOrm
+	Account     BankAccountOrm
+	Transaction BankTransactionOrm
+}
+
+// Rules an AccountLimits enforces on debits.
+const (
+	LimitRuleBalance        string = "INSUFFICIENT_BALANCE"
+	LimitRuleOverdraft      string = "OVERDRAFT_LIMIT"
+	LimitRuleMinimumBalance string = "MINIMUM_BALANCE"
+)
+
+// AccountLimits is how low debits may take the balance of an account. An
+// overdraft lets the balance go OverdraftLimit below zero, without one the
+// balance has to stay at MinimumBalance at least.
+type AccountLimits struct {
+	ProductCode    string
+	OverdraftLimit float64
+	MinimumBalance float64
+}
+
+// LimitsOf is the limits of account, its own where it has them and the
+// defaults of product otherwise.
+func LimitsOf(account BankAccountOrm, product BankProductOrm) AccountLimits {
+	limits := AccountLimits{
+		ProductCode:    account.ProductCode,
+		OverdraftLimit: product.OverdraftLimit,
+		MinimumBalance: product.MinimumBalance,
+	}
+	if account.OverdraftLimit != nil {
+		limits.OverdraftLimit = *account.OverdraftLimit
+	}
+	if account.MinimumBalance != nil {
+		limits.MinimumBalance = *account.MinimumBalance
+	}
+
+	return limits
+}
+
+// Floor is the lowest balance l lets a debit leave.
+func (l AccountLimits) Floor() float64 {
+	if l.OverdraftLimit > 0 {
+		return -l.OverdraftLimit
+	}
+	return l.MinimumBalance
+}
+
+// Rule is what Floor enforces.
+func (l AccountLimits) Rule() string {
+	switch {
+	case l.OverdraftLimit > 0:
+		return LimitRuleOverdraft
+	case l.MinimumBalance > 0:
+		return LimitRuleMinimumBalance
+	default:
+		return LimitRuleBalance
+	}
+}
+
+// Spendable is how much of an account with available funds, its balance
+// less its holds, a debit can take.
+func (l AccountLimits) Spendable(available float64) float64 {
+	return max(0, math.Round((available-l.Floor())*100)/100)
+}
+
+// Check returns an InsufficientFundsError when l doesn't let accountNum,
+// with available funds, be debited amount.
+func (l AccountLimits) Check(accountNum string, available float64, amount float64) error {
+	spendable := l.Spendable(available)
+	if amount <= spendable {
+		return nil
+	}
+
+	return &InsufficientFundsError{
+		AccountNumber: accountNum,
+		Rule:          l.Rule(),
+		Requested:     amount,
+		Available:     spendable,
+	}
+}
+
+// AccountLimitsUpdate changes the product of an account, unless ProductCode
+// is empty, and replaces its own limits. A nil limit falls back to the
+// product.
+type AccountLimitsUpdate struct {
+	ProductCode    string
+	OverdraftLimit *float64
+	MinimumBalance *float64
+}
+
+// AccountStanding is an account with its limits, what of its balance isn't
+// held, Available, and what a debit can take now, Spendable.
+type AccountStanding struct {
+	Account   BankAccountOrm
+	Limits    AccountLimits
+	Available float64
+	Spendable float64
+}
+
+const (
+	TransferDirectionAny      string = ""
+	TransferDirectionOutgoing string = "OUTGOING"
+	TransferDirectionIncoming string = "INCOMING"
+)
+
+// TransferFilter selects transfers for ListTransfers, zero fields match every
+// transfer. Direction narrows the transfers of AccountUuid to the ones it sent
+// or received. AccountNumber is the number of AccountUuid. From is inclusive
+// and To exclusive. After resumes a listing past the transfer it points at.
+type TransferFilter struct {
+	AccountUuid   uuid.UUID
+	AccountNumber string
+	Direction     string
+	Success       *bool
+	From          time.Time
+	To            time.Time
+	After         *TransferCursor
+	Limit         int
+}
+
+// TransferCursor is the position of a transfer in a listing, which orders
+// transfers newest first.
+type TransferCursor struct {
+	Timestamp    time.Time
+	TransferUuid uuid.UUID
 }
 
+// TransferDetail is a transfer with the numbers of its accounts.
+type TransferDetail struct {
+	BankTransferOrm
+	FromAccountNumber string
+	ToAccountNumber   string
+}
+
+// Cursor is the position of d in a listing.
+func (d TransferDetail) Cursor() TransferCursor {
+	return TransferCursor{Timestamp: d.TransferTimestamp, TransferUuid: d.TransferUuid}
+}
+
+// AccountActivity is a transaction on an account as streamed to its
+// subscribers, with the balance after it. TransferUuid is uuid.Nil unless the
+// transaction is one leg of a transfer.
+type AccountActivity struct {
+	EventID                   string
+	AccountNumber             string
+	TransactionType           string
+	Amount                    float64
+	Balance                   float64
+	TransactionUuid           uuid.UUID
+	TransferUuid              uuid.UUID
+	CounterpartyAccountNumber string
+	Notes                     string
+	Timestamp                 time.Time
+}
+
+// ErrRecordNotFound is returned by BankDatabasePort implementations when the
+// requested row doesn't exist.
+var ErrRecordNotFound = errors.New("record not found")
+
+// ErrInsufficientBalance is returned when a debit exceeds the balance of the
+// account.
+var ErrInsufficientBalance = errors.New("insufficient balance")
+
+// InsufficientFundsError is a debit the limits of an account refuse. Rule is
+// the limit it breaks and Available what the account could be debited.
+type InsufficientFundsError struct {
+	AccountNumber string
+	Rule          string
+	Requested     float64
+	Available     float64
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("%v: %v requested from account %v, %v available", ErrInsufficientBalance, e.Requested, e.AccountNumber, e.Available)
+}
+
+func (e *InsufficientFundsError) Unwrap() error {
+	return ErrInsufficientBalance
+}
+
+// ErrActivityCursorExpired is returned when a subscription resumes from an
+// event that is no longer retained, or that an earlier server process
+// issued. The client has to fetch the balance and subscribe afresh.
+var ErrActivityCursorExpired = errors.New("last event id is unknown or expired")
+
 var ErrTransferSourceAccountNotFound = errors.New("source account not found")
 var ErrTransferDestinationAccountNotFound = errors.New("destination account not found")
 var ErrTransferRecordFailed = errors.New("can't create transfer record")
 var ErrTransferTransactionPair = errors.New("can't create transfer transaction pair, " +
 	"possibly insufficient balance on source account")
+
+var ErrTransferNotReversible = errors.New("only a completed transfer can be reversed, a reversal can't")
+var ErrTransferAlreadyReversed = errors.New("transfer is already fully reversed")
+var ErrReversalExceedsTransfer = errors.New("reversal exceeds the amount of the transfer left to refund")
+var ErrReversalInvalid = errors.New("reversal needs a reason and a positive amount")
+var ErrTransferFilterInvalid = errors.New("a direction needs an account, and the date range must not be empty")
+
+var ErrHoldInvalid = errors.New("a hold needs a positive amount and an expiry in the next 30 days")
+var ErrHoldNotActive = errors.New("hold is already captured, released or expired")
+var ErrHoldExceeded = errors.New("capture exceeds the amount left on the hold")
+
+var ErrCustomerIdInvalid = errors.New("customer id must be at most 36 characters")
+
+var ErrLimitsInvalid = errors.New("limits must not be negative, and a product needs a code and a name")
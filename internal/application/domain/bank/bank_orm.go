@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,9 +20,48 @@ type BankAccountOrm struct {
 	AccountName    string
 	Currency       string
 	CurrentBalance float64
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	Transactions   []BankTransactionOrm `gorm:"foreignKey:AccountUuid"`
+	ProductCode    string `gorm:"default:STANDARD"`
+	// OverdraftLimit and MinimumBalance override the defaults of the product
+	// when they aren't nil.
+	OverdraftLimit *float64
+	MinimumBalance *float64
+	// OverdraftUsed is how far CurrentBalance is below zero, kept apart so
+	// interest can be charged on it.
+	OverdraftUsed float64
+	// TaxExempt spares the interest of the account the withholding tax.
+	TaxExempt bool
+	// CustomerSegment picks the fee schedules of the segment, empty only
+	// the ones of every segment.
+	CustomerSegment string
+	// CustomerId is shared by the accounts of a customer, empty for an
+	// account that is a customer of its own.
+	CustomerId   string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Transactions []BankTransactionOrm `gorm:"foreignKey:AccountUuid"`
+}
+
+// DefaultProductCode is the product of an account opened without one.
+const DefaultProductCode = "STANDARD"
+
+// BankProductOrm holds the defaults of the accounts of a product.
+type BankProductOrm struct {
+	ProductCode    string `gorm:"primaryKey"`
+	Name           string
+	OverdraftLimit float64
+	MinimumBalance float64
+	// InterestDayCount, InterestAccrualFrequency and InterestCapitalization
+	// are the terms the rates of the product are paid on, see the interest
+	// domain.
+	InterestDayCount         string `gorm:"default:ACT/365"`
+	InterestAccrualFrequency string `gorm:"default:DAILY"`
+	InterestCapitalization   string `gorm:"default:MONTHLY"`
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+}
+
+func (BankProductOrm) TableName() string {
+	return "bank_products"
 }
 
 type BalanceAccountOrm struct {
@@ -70,10 +110,64 @@ type BankTransferOrm struct {
 	Amount            float64
 	TransferTimestamp time.Time
 	TransferSuccess   bool
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
+	// ReversalOfUuid is the transfer a reversal refunds, nil for a transfer
+	// made by a customer.
+	ReversalOfUuid *uuid.UUID
+	ReversalReason string
+	// ReversedAmount is how much of the transfer its reversals refunded.
+	ReversedAmount float64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func (BankTransferOrm) TableName() string {
 	return "bank_transfers"
 }
+
+// RemainingAmount is what is left to refund of trf.
+func (trf BankTransferOrm) RemainingAmount() float64 {
+	return math.Round((trf.Amount-trf.ReversedAmount)*100) / 100
+}
+
+const (
+	HoldStatusActive   string = "ACTIVE"
+	HoldStatusCaptured string = "CAPTURED"
+	HoldStatusReleased string = "RELEASED"
+	// HoldStatusExpired is reported for an active hold past ExpiresAt, it is
+	// never stored.
+	HoldStatusExpired string = "EXPIRED"
+)
+
+// BankHoldOrm reserves Amount of an account until it is captured, released or
+// expires. While active, what is left of it to capture isn't available to
+// spend.
+type BankHoldOrm struct {
+	HoldUuid       uuid.UUID `gorm:"primaryKey"`
+	AccountUuid    uuid.UUID
+	AccountNumber  string
+	Amount         float64
+	CapturedAmount float64
+	Status         string
+	Notes          string
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (BankHoldOrm) TableName() string {
+	return "bank_holds"
+}
+
+// RemainingAmount is what is left to capture of h.
+func (h BankHoldOrm) RemainingAmount() float64 {
+	return math.Round((h.Amount-h.CapturedAmount)*100) / 100
+}
+
+// StatusAt is the status of h at t, HoldStatusExpired once an active hold
+// is past its expiry.
+func (h BankHoldOrm) StatusAt(t time.Time) string {
+	if h.Status == HoldStatusActive && !t.Before(h.ExpiresAt) {
+		return HoldStatusExpired
+	}
+	return h.Status
+}
@@ -0,0 +1,171 @@
+// Package domain defines transfer batches, files of credit transfers imported
+// at once, their validation and the status report of their execution.
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FormatCSV     = "CSV"
+	FormatPain001 = "PAIN_001"
+)
+
+const (
+	// ModeAllOrNothing executes a batch only when every line is valid, and
+	// reverses the transfers done when one fails.
+	ModeAllOrNothing = "ALL_OR_NOTHING"
+	// ModeBestEffort executes the valid lines and lets each fail on its own.
+	ModeBestEffort = "BEST_EFFORT"
+)
+
+const (
+	// StatusRejected batches failed validation, nothing was executed.
+	StatusRejected = "REJECTED"
+	// StatusAccepted batches wait for a worker.
+	StatusAccepted   = "ACCEPTED"
+	StatusProcessing = "PROCESSING"
+	// StatusRollingBack batches of ModeAllOrNothing had a transfer fail and
+	// reverse the ones done.
+	StatusRollingBack        = "ROLLING_BACK"
+	StatusCompleted          = "COMPLETED"
+	StatusPartiallyCompleted = "PARTIALLY_COMPLETED"
+	StatusFailed             = "FAILED"
+)
+
+const (
+	ItemPending   = "PENDING"
+	ItemRejected  = "REJECTED"
+	ItemSucceeded = "SUCCEEDED"
+	ItemFailed    = "FAILED"
+	// ItemCancelled items were valid but not executed, their batch was
+	// rejected or rolled back.
+	ItemCancelled = "CANCELLED"
+	// ItemReversed items were transferred and reversed by a rollback.
+	ItemReversed = "REVERSED"
+	// ItemReversalFailed items were transferred and a rollback couldn't
+	// reverse them, their money moved although the batch failed.
+	ItemReversalFailed = "REVERSAL_FAILED"
+)
+
+// Status reason codes of ISO 20022 (ExternalStatusReason1Code).
+const (
+	ReasonDebtorAccount        = "AC02"
+	ReasonCreditorAccount      = "AC03"
+	ReasonCurrency             = "AM03"
+	ReasonInsufficientFunds    = "AM04"
+	ReasonDuplicate            = "AM05"
+	ReasonControlSum           = "AM10"
+	ReasonAmount               = "AM12"
+	ReasonNumberOfTransactions = "AM18"
+	ReasonNarrative            = "NARR"
+)
+
+var ErrFormatInvalid = errors.New("format must be CSV or PAIN_001")
+var ErrModeInvalid = errors.New("mode must be ALL_OR_NOTHING or BEST_EFFORT")
+var ErrFileInvalid = errors.New("file can't be read")
+var ErrFileTooLarge = errors.New("file is too large")
+var ErrTooManyItems = errors.New("file has too many transactions")
+var ErrBatchEmpty = errors.New("file has no transactions")
+var ErrDuplicateMessage = errors.New("a batch with the message id was imported already")
+var ErrMessageIdInvalid = errors.New("message id must be 35 characters at most")
+var ErrBatchChanged = errors.New("batch was changed by another worker")
+
+// Upload describes an uploaded file. DebtorAccount is the sender of the
+// lines that name none, NumberOfTransactions and ControlSum are checked
+// against the file when set.
+type Upload struct {
+	Format               string
+	Mode                 string
+	MessageId            string
+	DebtorAccount        string
+	Channel              string
+	NumberOfTransactions *int
+	ControlSum           *float64
+}
+
+type BatchOrm struct {
+	BatchUuid uuid.UUID `gorm:"primaryKey"`
+	MessageId string
+	Format    string
+	Mode      string
+	Channel   string
+	Status    string
+	// NumberOfTransactions and ControlSum are the count and the sum of the
+	// amounts of the lines of the file.
+	NumberOfTransactions int
+	ControlSum           float64
+	// ReasonCode and Reason tell why a batch was rejected or failed as a
+	// whole.
+	ReasonCode string
+	Reason     string
+	// LeaseUntil is when the worker running the batch may be taken over.
+	LeaseUntil  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+func (BatchOrm) TableName() string {
+	return "bank_transfer_batches"
+}
+
+// ItemOrm is a line of a batch. ItemUuid is the uuid of its transfer too, so
+// a line executed again after a crash finds the transfer of the first run.
+type ItemOrm struct {
+	ItemUuid              uuid.UUID `gorm:"primaryKey"`
+	BatchUuid             uuid.UUID
+	LineNumber            int
+	EndToEndId            string
+	DebtorAccount         string
+	CreditorAccount       string
+	Currency              string
+	Amount                float64
+	RemittanceInformation string
+	Status                string
+	ReasonCode            string
+	Reason                string
+	TransferUuid          *uuid.UUID
+	UpdatedAt             time.Time
+}
+
+func (ItemOrm) TableName() string {
+	return "bank_transfer_batch_items"
+}
+
+// BatchDetail is a batch with the number of its items by status.
+type BatchDetail struct {
+	BatchOrm
+	Counts map[string]int
+}
+
+// Done reports whether the batch won't change any more.
+func (b BatchOrm) Done() bool {
+	switch b.Status {
+	case StatusRejected, StatusCompleted, StatusPartiallyCompleted, StatusFailed:
+		return true
+	}
+
+	return false
+}
+
+var amountPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,2})?$`)
+
+// ParseAmount parses the amount of a line, a positive decimal with two
+// fraction digits at most.
+func ParseAmount(s string) (float64, bool) {
+	if !amountPattern.MatchString(s) {
+		return 0, false
+	}
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+
+	return amount, true
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Line is a credit transfer of a file as written, checked by the importer.
+// Number is where it starts in the file, from 1.
+type Line struct {
+	Number                int
+	EndToEndId            string
+	DebtorAccount         string
+	CreditorAccount       string
+	Currency              string
+	Amount                string
+	RemittanceInformation string
+}
+
+// File is a parsed batch file. NumberOfTransactions and ControlSum are the
+// totals the file declares, if any.
+type File struct {
+	MessageId            string
+	NumberOfTransactions *int
+	ControlSum           *float64
+	Lines                []Line
+}
+
+// notProvided is the end to end id of pain.001 transactions without one.
+const notProvided = "NOTPROVIDED"
+
+// Parse reads a file of format from r as it arrives. A file of more than
+// maxLines lines fails with ErrTooManyItems, one that can't be read with
+// ErrFileInvalid.
+func Parse(format string, r io.Reader, maxLines int) (File, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r, maxLines)
+	case FormatPain001:
+		return parsePain001(r, maxLines)
+	default:
+		return File{}, ErrFormatInvalid
+	}
+}
+
+// csvColumns are the columns a CSV file may have, its header names them in
+// any order.
+var csvColumns = map[string]bool{
+	"end_to_end_id":          false,
+	"debtor_account":         false,
+	"creditor_account":       true,
+	"currency":               true,
+	"amount":                 true,
+	"remittance_information": false,
+}
+
+func parseCSV(r io.Reader, maxLines int) (File, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return File{}, ErrBatchEmpty
+	}
+	if err != nil {
+		return File{}, fmt.Errorf("%w : %v", ErrFileInvalid, err)
+	}
+
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := csvColumns[name]; !ok {
+			return File{}, fmt.Errorf("%w : unknown column %q", ErrFileInvalid, name)
+		}
+		if _, ok := index[name]; ok {
+			return File{}, fmt.Errorf("%w : column %q given twice", ErrFileInvalid, name)
+		}
+		index[name] = i
+	}
+	for name, required := range csvColumns {
+		if _, ok := index[name]; required && !ok {
+			return File{}, fmt.Errorf("%w : column %q missing", ErrFileInvalid, name)
+		}
+	}
+
+	var file File
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return file, nil
+		}
+		if err != nil {
+			return File{}, fmt.Errorf("%w : %v", ErrFileInvalid, err)
+		}
+		if len(file.Lines) == maxLines {
+			return File{}, fmt.Errorf("%w : more than %d", ErrTooManyItems, maxLines)
+		}
+
+		field := func(name string) string {
+			if i, ok := index[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		number, _ := reader.FieldPos(0)
+		file.Lines = append(file.Lines, Line{
+			Number:                number,
+			EndToEndId:            field("end_to_end_id"),
+			DebtorAccount:         field("debtor_account"),
+			CreditorAccount:       field("creditor_account"),
+			Currency:              field("currency"),
+			Amount:                field("amount"),
+			RemittanceInformation: field("remittance_information"),
+		})
+	}
+}
+
+// The parts of a pain.001 (CustomerCreditTransferInitiation) document the
+// importer reads. Namespaces are ignored, so every version of the message
+// reads the same.
+type painGroupHeader struct {
+	MsgId   string `xml:"MsgId"`
+	NbOfTxs string `xml:"NbOfTxs"`
+	CtrlSum string `xml:"CtrlSum"`
+}
+
+type painAccount struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+func (a painAccount) number() string {
+	if a.Other != "" {
+		return strings.TrimSpace(a.Other)
+	}
+
+	return strings.TrimSpace(a.IBAN)
+}
+
+type painCreditTransfer struct {
+	EndToEndId string `xml:"PmtId>EndToEndId"`
+	Amount     struct {
+		Value    string `xml:",chardata"`
+		Currency string `xml:"Ccy,attr"`
+	} `xml:"Amt>InstdAmt"`
+	CreditorAccount painAccount `xml:"CdtrAcct"`
+	Unstructured    []string    `xml:"RmtInf>Ustrd"`
+}
+
+func parsePain001(r io.Reader, maxLines int) (File, error) {
+	decoder := xml.NewDecoder(r)
+
+	var file File
+	initiation := false
+	debtor := ""
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return File{}, fmt.Errorf("%w : %v", ErrFileInvalid, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		line, _ := decoder.InputPos()
+
+		switch start.Name.Local {
+		case "CstmrCdtTrfInitn":
+			initiation = true
+		case "GrpHdr":
+			var header painGroupHeader
+			if err := decoder.DecodeElement(&header, &start); err != nil {
+				return File{}, fmt.Errorf("%w : %v", ErrFileInvalid, err)
+			}
+			if err := file.setHeader(header); err != nil {
+				return File{}, err
+			}
+		case "PmtInf":
+			debtor = ""
+		case "DbtrAcct":
+			var account painAccount
+			if err := decoder.DecodeElement(&account, &start); err != nil {
+				return File{}, fmt.Errorf("%w : %v", ErrFileInvalid, err)
+			}
+			debtor = account.number()
+		case "CdtTrfTxInf":
+			var trf painCreditTransfer
+			if err := decoder.DecodeElement(&trf, &start); err != nil {
+				return File{}, fmt.Errorf("%w : %v", ErrFileInvalid, err)
+			}
+			if len(file.Lines) == maxLines {
+				return File{}, fmt.Errorf("%w : more than %d", ErrTooManyItems, maxLines)
+			}
+
+			endToEndId := strings.TrimSpace(trf.EndToEndId)
+			if endToEndId == notProvided {
+				endToEndId = ""
+			}
+			file.Lines = append(file.Lines, Line{
+				Number:                line,
+				EndToEndId:            endToEndId,
+				DebtorAccount:         debtor,
+				CreditorAccount:       trf.CreditorAccount.number(),
+				Currency:              strings.TrimSpace(trf.Amount.Currency),
+				Amount:                strings.TrimSpace(trf.Amount.Value),
+				RemittanceInformation: strings.TrimSpace(strings.Join(trf.Unstructured, " ")),
+			})
+		}
+	}
+
+	if !initiation {
+		return File{}, fmt.Errorf("%w : not a pain.001 document", ErrFileInvalid)
+	}
+
+	return file, nil
+}
+
+func (f *File) setHeader(header painGroupHeader) error {
+	f.MessageId = strings.TrimSpace(header.MsgId)
+
+	if s := strings.TrimSpace(header.NbOfTxs); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("%w : NbOfTxs %q", ErrFileInvalid, s)
+		}
+		f.NumberOfTransactions = &n
+	}
+	if s := strings.TrimSpace(header.CtrlSum); s != "" {
+		sum, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("%w : CtrlSum %q", ErrFileInvalid, s)
+		}
+		f.ControlSum = &sum
+	}
+
+	return nil
+}
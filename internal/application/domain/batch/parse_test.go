@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	file, err := Parse(FormatCSV, strings.NewReader("\ufeffAmount,Currency,Creditor_Account,end_to_end_id\n"+
+		"12.50,USD,7835697002,PAY-1\n"+
+		"\n"+
+		" 3 , usd ,7835697003,\n"), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Line{
+		{Number: 2, EndToEndId: "PAY-1", CreditorAccount: "7835697002", Currency: "USD", Amount: "12.50"},
+		{Number: 4, CreditorAccount: "7835697003", Currency: "usd", Amount: "3"},
+	}
+	if !reflect.DeepEqual(file.Lines, want) {
+		t.Errorf("lines = %+v, want %+v", file.Lines, want)
+	}
+
+	for _, tt := range []struct {
+		name, csv string
+		want      error
+	}{
+		{"empty", "", ErrBatchEmpty},
+		{"unknown column", "amount,currency,creditor_account,iban\n", ErrFileInvalid},
+		{"missing column", "amount,creditor_account\n", ErrFileInvalid},
+		{"short line", "amount,currency,creditor_account\n1,USD\n", ErrFileInvalid},
+		{"too many lines", "amount,currency,creditor_account\n1,USD,1\n1,USD,2\n1,USD,3\n", ErrTooManyItems},
+	} {
+		if _, err := Parse(FormatCSV, strings.NewReader(tt.csv), 2); !errors.Is(err, tt.want) {
+			t.Errorf("%v: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+const pain001 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>PAYROLL-2024-05</MsgId>
+      <CreDtTm>2024-05-01T09:00:00</CreDtTm>
+      <NbOfTxs>2</NbOfTxs>
+      <CtrlSum>7.25</CtrlSum>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>1</PmtInfId>
+      <DbtrAcct><Id><Othr><Id>7835697001</Id></Othr></Id></DbtrAcct>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>SALARY-1</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">5.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>7835697002</Id></Othr></Id></CdtrAcct>
+        <RmtInf><Ustrd>May</Ustrd><Ustrd>salary</Ustrd></RmtInf>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>NOTPROVIDED</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">2.25</InstdAmt></Amt>
+        <CdtrAcct><Id><IBAN>7835697003</IBAN></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>
+`
+
+func TestParsePain001(t *testing.T) {
+	file, err := Parse(FormatPain001, strings.NewReader(pain001), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file.MessageId != "PAYROLL-2024-05" || file.NumberOfTransactions == nil || *file.NumberOfTransactions != 2 || file.ControlSum == nil || *file.ControlSum != 7.25 {
+		t.Errorf("header = %v %v %v, want PAYROLL-2024-05 2 7.25", file.MessageId, file.NumberOfTransactions, file.ControlSum)
+	}
+	want := []Line{
+		{Number: 13, EndToEndId: "SALARY-1", DebtorAccount: "7835697001", CreditorAccount: "7835697002", Currency: "USD", Amount: "5.00", RemittanceInformation: "May salary"},
+		{Number: 19, DebtorAccount: "7835697001", CreditorAccount: "7835697003", Currency: "USD", Amount: "2.25"},
+	}
+	if !reflect.DeepEqual(file.Lines, want) {
+		t.Errorf("lines = %+v, want %+v", file.Lines, want)
+	}
+
+	for _, tt := range []struct {
+		name, xml string
+		want      error
+	}{
+		{"not xml", "amount,currency\n", ErrFileInvalid},
+		{"other message", `<Document><FIToFICstmrCdtTrf/></Document>`, ErrFileInvalid},
+		{"truncated", pain001[:600], ErrFileInvalid},
+		{"bad count", strings.Replace(pain001, "<NbOfTxs>2", "<NbOfTxs>two", 1), ErrFileInvalid},
+		{"too many lines", pain001, ErrTooManyItems},
+	} {
+		if _, err := Parse(FormatPain001, strings.NewReader(tt.xml), 1); !errors.Is(err, tt.want) {
+			t.Errorf("%v: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want float64
+		ok   bool
+	}{
+		{"10", 10, true},
+		{"0.05", 0.05, true},
+		{"1.5", 1.5, true},
+		{"0", 0, false},
+		{"0.00", 0, false},
+		{"-1", 0, false},
+		{"1.005", 0, false},
+		{"1e3", 0, false},
+		{"1,000", 0, false},
+		{"", 0, false},
+	} {
+		if got, ok := ParseAmount(tt.s); got != tt.want || ok != tt.ok {
+			t.Errorf("ParseAmount(%q) = %v, %v; want %v, %v", tt.s, got, ok, tt.want, tt.ok)
+		}
+	}
+}
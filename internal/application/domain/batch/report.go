@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportNamespace is the pain.002 version the status reports follow.
+const ReportNamespace = "urn:iso:std:iso:20022:tech:xsd:pain.002.001.03"
+
+// Transaction and group statuses of ISO 20022 (ExternalPaymentTransactionStatus1Code).
+const (
+	statusAccepted          = "ACCP"
+	statusSettlementDone    = "ACSC"
+	statusPartiallyAccepted = "PART"
+	statusPending           = "PDNG"
+	statusRejected          = "RJCT"
+)
+
+type reportReason struct {
+	Code       string `xml:"Rsn>Cd,omitempty"`
+	Additional string `xml:"AddtlInf,omitempty"`
+}
+
+type reportGroupHeader struct {
+	MsgId   string `xml:"MsgId"`
+	CreDtTm string `xml:"CreDtTm"`
+}
+
+type reportGroup struct {
+	OrgnlMsgId   string        `xml:"OrgnlMsgId"`
+	OrgnlMsgNmId string        `xml:"OrgnlMsgNmId"`
+	OrgnlNbOfTxs int           `xml:"OrgnlNbOfTxs"`
+	OrgnlCtrlSum string        `xml:"OrgnlCtrlSum"`
+	GrpSts       string        `xml:"GrpSts"`
+	StsRsnInf    *reportReason `xml:"StsRsnInf"`
+}
+
+type reportTransaction struct {
+	StsId           string        `xml:"StsId"`
+	OrgnlEndToEndId string        `xml:"OrgnlEndToEndId"`
+	TxSts           string        `xml:"TxSts"`
+	StsRsnInf       *reportReason `xml:"StsRsnInf"`
+}
+
+// WriteReport writes the pain.002 status report of batch and its items,
+// ordered by line, to w. Items are written one at a time, so a report of a
+// large batch isn't built in memory twice.
+func WriteReport(w io.Writer, batch BatchOrm, items []ItemOrm, createdAt time.Time) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	document := xml.StartElement{Name: xml.Name{Local: "Document"}, Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: ReportNamespace}}}
+	report := xml.StartElement{Name: xml.Name{Local: "CstmrPmtStsRpt"}}
+	payments := xml.StartElement{Name: xml.Name{Local: "OrgnlPmtInfAndSts"}}
+	for _, start := range []xml.StartElement{document, report} {
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+	}
+
+	header := reportGroupHeader{
+		MsgId:   strings.ReplaceAll(batch.BatchUuid.String(), "-", ""),
+		CreDtTm: createdAt.UTC().Format("2006-01-02T15:04:05"),
+	}
+	if err := enc.EncodeElement(header, xml.StartElement{Name: xml.Name{Local: "GrpHdr"}}); err != nil {
+		return err
+	}
+
+	group := reportGroup{
+		OrgnlMsgId:   batch.MessageId,
+		OrgnlMsgNmId: messageName(batch.Format),
+		OrgnlNbOfTxs: batch.NumberOfTransactions,
+		OrgnlCtrlSum: strconv.FormatFloat(batch.ControlSum, 'f', 2, 64),
+		GrpSts:       groupStatus(batch.Status),
+		StsRsnInf:    reason(batch.ReasonCode, batch.Reason),
+	}
+	if err := enc.EncodeElement(group, xml.StartElement{Name: xml.Name{Local: "OrgnlGrpInfAndSts"}}); err != nil {
+		return err
+	}
+
+	if err := enc.EncodeToken(payments); err != nil {
+		return err
+	}
+	if err := enc.EncodeElement(batch.MessageId, xml.StartElement{Name: xml.Name{Local: "OrgnlPmtInfId"}}); err != nil {
+		return err
+	}
+	for _, item := range items {
+		endToEndId := item.EndToEndId
+		if endToEndId == "" {
+			endToEndId = notProvided
+		}
+		trx := reportTransaction{
+			StsId:           strings.ReplaceAll(item.ItemUuid.String(), "-", ""),
+			OrgnlEndToEndId: endToEndId,
+			TxSts:           itemStatus(item.Status),
+			StsRsnInf:       reason(item.ReasonCode, item.Reason),
+		}
+		if err := enc.EncodeElement(trx, xml.StartElement{Name: xml.Name{Local: "TxInfAndSts"}}); err != nil {
+			return err
+		}
+	}
+
+	for _, end := range []xml.EndElement{payments.End(), report.End(), document.End()} {
+		if err := enc.EncodeToken(end); err != nil {
+			return err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func messageName(format string) string {
+	if format == FormatPain001 {
+		return "pain.001.001.03"
+	}
+
+	return format
+}
+
+func groupStatus(status string) string {
+	switch status {
+	case StatusRejected, StatusFailed:
+		return statusRejected
+	case StatusCompleted:
+		return statusSettlementDone
+	case StatusPartiallyCompleted:
+		return statusPartiallyAccepted
+	default:
+		return statusAccepted
+	}
+}
+
+func itemStatus(status string) string {
+	switch status {
+	case ItemSucceeded, ItemReversalFailed:
+		return statusSettlementDone
+	case ItemPending:
+		return statusPending
+	default:
+		return statusRejected
+	}
+}
+
+// reason returns the status reason of code and text, nil when there is
+// neither. AddtlInf holds 105 characters at most.
+func reason(code string, text string) *reportReason {
+	if code == "" && text == "" {
+		return nil
+	}
+	if runes := []rune(text); len(runes) > 105 {
+		text = string(runes[:105])
+	}
+
+	return &reportReason{Code: code, Additional: text}
+}
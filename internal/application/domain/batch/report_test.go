@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWriteReport(t *testing.T) {
+	batch := BatchOrm{
+		BatchUuid:            uuid.New(),
+		MessageId:            "PAYROLL-2024-05",
+		Format:               FormatPain001,
+		Mode:                 ModeBestEffort,
+		Status:               StatusPartiallyCompleted,
+		NumberOfTransactions: 3,
+		ControlSum:           17.25,
+	}
+	items := []ItemOrm{
+		{ItemUuid: uuid.New(), EndToEndId: "SALARY-1", Status: ItemSucceeded},
+		{ItemUuid: uuid.New(), Status: ItemFailed, ReasonCode: ReasonInsufficientFunds, Reason: "insufficient balance"},
+		{ItemUuid: uuid.New(), EndToEndId: "SALARY-3", Status: ItemRejected, ReasonCode: ReasonCreditorAccount, Reason: "creditor account not found"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, batch, items, time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	var report struct {
+		XMLName xml.Name `xml:"urn:iso:std:iso:20022:tech:xsd:pain.002.001.03 Document"`
+		Created string   `xml:"CstmrPmtStsRpt>GrpHdr>CreDtTm"`
+		Group   struct {
+			MessageId string  `xml:"OrgnlMsgId"`
+			Name      string  `xml:"OrgnlMsgNmId"`
+			Count     int     `xml:"OrgnlNbOfTxs"`
+			Sum       float64 `xml:"OrgnlCtrlSum"`
+			Status    string  `xml:"GrpSts"`
+		} `xml:"CstmrPmtStsRpt>OrgnlGrpInfAndSts"`
+		Transactions []struct {
+			EndToEndId string `xml:"OrgnlEndToEndId"`
+			Status     string `xml:"TxSts"`
+			Code       string `xml:"StsRsnInf>Rsn>Cd"`
+		} `xml:"CstmrPmtStsRpt>OrgnlPmtInfAndSts>TxInfAndSts"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("report doesn't parse : %v\n%s", err, buf.String())
+	}
+
+	group := report.Group
+	if report.Created != "2024-05-01T10:00:00" || group.MessageId != "PAYROLL-2024-05" || group.Name != "pain.001.001.03" || group.Count != 3 || group.Sum != 17.25 || group.Status != "PART" {
+		t.Errorf("group = %v %+v, want PAYROLL-2024-05 partially accepted", report.Created, group)
+	}
+	want := []struct{ endToEndId, status, code string }{
+		{"SALARY-1", "ACSC", ""},
+		{"NOTPROVIDED", "RJCT", "AM04"},
+		{"SALARY-3", "RJCT", "AC03"},
+	}
+	if len(report.Transactions) != len(want) {
+		t.Fatalf("%d transactions, want %d", len(report.Transactions), len(want))
+	}
+	for i, w := range want {
+		got := report.Transactions[i]
+		if got.EndToEndId != w.endToEndId || got.Status != w.status || got.Code != w.code {
+			t.Errorf("transaction %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
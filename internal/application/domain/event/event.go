@@ -0,0 +1,196 @@
+// Package domain defines the events the bank publishes about its state
+// changes. Events are written to the outbox in the same database transaction
+// as the change itself and relayed to the publisher afterwards, at least
+// once, so consumers must skip IDs they have already processed.
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+const (
+	TypeTransactionCreated  = "TransactionCreated"
+	TypeTransferCreated     = "TransferCreated"
+	TypeTransferCompleted   = "TransferCompleted"
+	TypeTransferFailed      = "TransferFailed"
+	TypeTransferReversed    = "TransferReversed"
+	TypeExchangeRateCreated = "ExchangeRateCreated"
+)
+
+// Version is the schema version of the data of every event type. Adding a
+// field keeps the version, renaming, removing or changing the meaning of one
+// bumps it.
+const Version = 1
+
+// Event is the envelope of every event. Data holds the JSON encoding of the
+// payload matching Type, e.g. TransactionCreated for TypeTransactionCreated.
+type Event struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        string          `json:"type"`
+	Version     int             `json:"version"`
+	AggregateID uuid.UUID       `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+type TransactionCreated struct {
+	TransactionUuid uuid.UUID `json:"transaction_uuid"`
+	AccountUuid     uuid.UUID `json:"account_uuid"`
+	AccountNumber   string    `json:"account_number"`
+	TransactionType string    `json:"transaction_type"`
+	Amount          float64   `json:"amount"`
+	Notes           string    `json:"notes"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// Transfer is the payload of TransferCreated, TransferCompleted and
+// TransferFailed.
+type Transfer struct {
+	TransferUuid    uuid.UUID `json:"transfer_uuid"`
+	FromAccountUuid uuid.UUID `json:"from_account_uuid"`
+	ToAccountUuid   uuid.UUID `json:"to_account_uuid"`
+	Currency        string    `json:"currency"`
+	Amount          float64   `json:"amount"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// TransferReversed is a refund of TransferUuid, booked as the transfer
+// ReversalUuid from ToAccountUuid back to FromAccountUuid.
+type TransferReversed struct {
+	ReversalUuid    uuid.UUID `json:"reversal_uuid"`
+	TransferUuid    uuid.UUID `json:"transfer_uuid"`
+	FromAccountUuid uuid.UUID `json:"from_account_uuid"`
+	ToAccountUuid   uuid.UUID `json:"to_account_uuid"`
+	Currency        string    `json:"currency"`
+	Amount          float64   `json:"amount"`
+	Reason          string    `json:"reason"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+type ExchangeRateCreated struct {
+	ExchangeRateUuid   uuid.UUID `json:"exchange_rate_uuid"`
+	FromCurrency       string    `json:"from_currency"`
+	ToCurrency         string    `json:"to_currency"`
+	Rate               float64   `json:"rate"`
+	ValidFromTimestamp time.Time `json:"valid_from_timestamp"`
+	ValidToTimestamp   time.Time `json:"valid_to_timestamp"`
+}
+
+// New wraps data in a new envelope.
+func New(eventType string, aggregateID uuid.UUID, occurredAt time.Time, data any) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("can't encode %v event : %v", eventType, err)
+	}
+
+	return Event{
+		ID:          uuid.New(),
+		Type:        eventType,
+		Version:     Version,
+		AggregateID: aggregateID,
+		OccurredAt:  occurredAt.UTC(),
+		Data:        raw,
+	}, nil
+}
+
+// NewTransactionCreated describes trx posted on account.
+func NewTransactionCreated(account domainBank.BankAccountOrm, trx domainBank.BankTransactionOrm) (Event, error) {
+	return New(TypeTransactionCreated, trx.AccountUuid, trx.TransactionTimestamp, TransactionCreated{
+		TransactionUuid: trx.TransactionUuid,
+		AccountUuid:     trx.AccountUuid,
+		AccountNumber:   account.AccountNumber,
+		TransactionType: trx.TransactionType,
+		Amount:          roundAmount(trx.Amount),
+		Notes:           trx.Notes,
+		Timestamp:       trx.TransactionTimestamp.UTC(),
+	})
+}
+
+func NewTransferCreated(trf domainBank.BankTransferOrm) (Event, error) {
+	return New(TypeTransferCreated, trf.TransferUuid, trf.TransferTimestamp, transferData(trf))
+}
+
+// NewTransferFinished describes the outcome of trf recorded at.
+func NewTransferFinished(trf domainBank.BankTransferOrm, success bool, at time.Time) (Event, error) {
+	eventType := TypeTransferFailed
+	if success {
+		eventType = TypeTransferCompleted
+	}
+
+	return New(eventType, trf.TransferUuid, at, transferData(trf))
+}
+
+// NewTransferReversed describes reversal, a refund of original.
+func NewTransferReversed(original domainBank.BankTransferOrm, reversal domainBank.BankTransferOrm) (Event, error) {
+	return New(TypeTransferReversed, original.TransferUuid, reversal.TransferTimestamp, TransferReversed{
+		ReversalUuid:    reversal.TransferUuid,
+		TransferUuid:    original.TransferUuid,
+		FromAccountUuid: original.FromAccountUuid,
+		ToAccountUuid:   original.ToAccountUuid,
+		Currency:        reversal.Currency,
+		Amount:          roundAmount(reversal.Amount),
+		Reason:          reversal.ReversalReason,
+		Timestamp:       reversal.TransferTimestamp.UTC(),
+	})
+}
+
+func NewExchangeRateCreated(r domainBank.BankExchangeRateOrm) (Event, error) {
+	return New(TypeExchangeRateCreated, r.ExchangeRateUuid, r.CreatedAt, ExchangeRateCreated{
+		ExchangeRateUuid:   r.ExchangeRateUuid,
+		FromCurrency:       r.FromCurrency,
+		ToCurrency:         r.ToCurrency,
+		Rate:               math.Round(r.Rate*1e10) / 1e10,
+		ValidFromTimestamp: r.ValidFromTimestamp.UTC(),
+		ValidToTimestamp:   r.ValidToTimestamp.UTC(),
+	})
+}
+
+func transferData(trf domainBank.BankTransferOrm) Transfer {
+	return Transfer{
+		TransferUuid:    trf.TransferUuid,
+		FromAccountUuid: trf.FromAccountUuid,
+		ToAccountUuid:   trf.ToAccountUuid,
+		Currency:        trf.Currency,
+		Amount:          roundAmount(trf.Amount),
+		Timestamp:       trf.TransferTimestamp.UTC(),
+	}
+}
+
+// roundAmount applies the scale of the stored amounts, so an event carries
+// the value a query of the row returns.
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Accounts returns the accounts e concerns, none for events that are not
+// about an account such as ExchangeRateCreated.
+func (e Event) Accounts() ([]uuid.UUID, error) {
+	switch e.Type {
+	case TypeTransactionCreated:
+		var data TransactionCreated
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return nil, fmt.Errorf("can't decode %v event %v : %v", e.Type, e.ID, err)
+		}
+		return []uuid.UUID{data.AccountUuid}, nil
+	case TypeTransferCreated, TypeTransferCompleted, TypeTransferFailed:
+		var data Transfer
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return nil, fmt.Errorf("can't decode %v event %v : %v", e.Type, e.ID, err)
+		}
+		return []uuid.UUID{data.FromAccountUuid, data.ToAccountUuid}, nil
+	case TypeTransferReversed:
+		var data TransferReversed
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return nil, fmt.Errorf("can't decode %v event %v : %v", e.Type, e.ID, err)
+		}
+		return []uuid.UUID{data.FromAccountUuid, data.ToAccountUuid}, nil
+	default:
+		return nil, nil
+	}
+}
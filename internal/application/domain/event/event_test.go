@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// TestSchema pins the encoding of version 1. A failure here means consumers
+// break: keep the encoding, or bump Version and document the change.
+func TestSchema(t *testing.T) {
+	at := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+	account := domainBank.BankAccountOrm{
+		AccountUuid:   uuid.MustParse("7e3b3c5c-9a1b-4b7e-8c6f-3e6c4b0f1a01"),
+		AccountNumber: "7835697001",
+	}
+	trx := domainBank.BankTransactionOrm{
+		TransactionUuid:      uuid.MustParse("2f0d8a4e-5b3c-4d1e-9f7a-6b8c9d0e1f02"),
+		AccountUuid:          account.AccountUuid,
+		TransactionTimestamp: at,
+		Amount:               12.345,
+		TransactionType:      domainBank.TransactionTypeOut,
+		Notes:                "coffee",
+	}
+
+	e, err := NewTransactionCreated(account, trx)
+	if err != nil {
+		t.Fatalf("NewTransactionCreated: %v", err)
+	}
+	e.ID = uuid.MustParse("00000000-0000-4000-8000-000000000001")
+
+	got, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"id":"00000000-0000-4000-8000-000000000001","type":"TransactionCreated","version":1,` +
+		`"aggregate_id":"7e3b3c5c-9a1b-4b7e-8c6f-3e6c4b0f1a01","occurred_at":"2024-05-01T03:00:00Z",` +
+		`"data":{"transaction_uuid":"2f0d8a4e-5b3c-4d1e-9f7a-6b8c9d0e1f02","account_uuid":"7e3b3c5c-9a1b-4b7e-8c6f-3e6c4b0f1a01",` +
+		`"account_number":"7835697001","transaction_type":"OUT","amount":12.35,"notes":"coffee","timestamp":"2024-05-01T03:00:00Z"}}`
+	if string(got) != want {
+		t.Errorf("encoding changed\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestNewTransferFinished(t *testing.T) {
+	trf := domainBank.BankTransferOrm{TransferUuid: uuid.New(), Amount: 1}
+
+	for _, tt := range []struct {
+		success bool
+		want    string
+	}{
+		{true, TypeTransferCompleted},
+		{false, TypeTransferFailed},
+	} {
+		e, err := NewTransferFinished(trf, tt.success, time.Now())
+		if err != nil {
+			t.Fatalf("NewTransferFinished: %v", err)
+		}
+		if e.Type != tt.want || e.AggregateID != trf.TransferUuid {
+			t.Errorf("success %v: event %v of %v, want %v of %v", tt.success, e.Type, e.AggregateID, tt.want, trf.TransferUuid)
+		}
+	}
+}
+
+func TestNewTransferReversed(t *testing.T) {
+	original := domainBank.BankTransferOrm{TransferUuid: uuid.New(), FromAccountUuid: uuid.New(), ToAccountUuid: uuid.New(), Amount: 10}
+	reversal := domainBank.BankTransferOrm{
+		TransferUuid:      uuid.New(),
+		FromAccountUuid:   original.ToAccountUuid,
+		ToAccountUuid:     original.FromAccountUuid,
+		Amount:            2.5,
+		ReversalOfUuid:    &original.TransferUuid,
+		ReversalReason:    "charged twice",
+		TransferTimestamp: time.Now(),
+	}
+
+	e, err := NewTransferReversed(original, reversal)
+	if err != nil {
+		t.Fatalf("NewTransferReversed: %v", err)
+	}
+	if e.Type != TypeTransferReversed || e.AggregateID != original.TransferUuid {
+		t.Errorf("event %v of %v, want %v of %v", e.Type, e.AggregateID, TypeTransferReversed, original.TransferUuid)
+	}
+
+	accounts, err := e.Accounts()
+	if err != nil || len(accounts) != 2 || accounts[0] != original.FromAccountUuid || accounts[1] != original.ToAccountUuid {
+		t.Errorf("Accounts = %v, %v; want the sender and the recipient of the transfer", accounts, err)
+	}
+}
@@ -0,0 +1,228 @@
+// Package domain defines the fees of transfers. A fee schedule prices the
+// transfers in a currency, optionally only those of a channel or of the
+// senders of a customer segment. Its tiers price a transfer by its amount:
+// the tier with the highest MinAmount the amount reaches charges FlatAmount
+// plus Rate percent of the amount, so a single tier is a flat or a
+// percentage fee. The fee is kept between MinFee and MaxFee. The first
+// FreePerMonth transfers of a sender each calendar month are free.
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+var ErrScheduleInvalid = errors.New("a fee schedule needs a currency, tiers with distinct non-negative minimum amounts, non-negative fees, rates from 0 to 100, a maximum fee of 0 or at least the minimum fee and a fee account")
+
+// FeeScheduleOrm prices the transfers in Currency. An empty Channel or
+// CustomerSegment matches every channel or segment.
+type FeeScheduleOrm struct {
+	ScheduleUuid    uuid.UUID `gorm:"primaryKey"`
+	Currency        string
+	Channel         string
+	CustomerSegment string
+	MinFee          float64
+	// MaxFee caps the fee, 0 leaves it uncapped.
+	MaxFee         float64
+	FreePerMonth   int
+	FeeAccountUuid uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (FeeScheduleOrm) TableName() string {
+	return "bank_fee_schedules"
+}
+
+// FeeTierOrm charges FlatAmount plus Rate percent of the amounts of
+// MinAmount or more, unless a tier with a higher MinAmount applies.
+type FeeTierOrm struct {
+	ScheduleUuid uuid.UUID `gorm:"primaryKey"`
+	MinAmount    float64   `gorm:"primaryKey"`
+	FlatAmount   float64
+	Rate         float64
+}
+
+func (FeeTierOrm) TableName() string {
+	return "bank_fee_tiers"
+}
+
+// Schedule is a schedule with its tiers, by MinAmount.
+type Schedule struct {
+	FeeScheduleOrm
+	Tiers []FeeTierOrm
+}
+
+// ScheduleDetail is a schedule with its fee account.
+type ScheduleDetail struct {
+	Schedule
+	FeeAccount domainBank.BankAccountOrm
+}
+
+// Match returns the schedule of the transfers in currency by channel of a
+// sender of segment. A schedule of the segment beats one of the channel,
+// which beats one of neither.
+func Match(schedules []Schedule, currency string, channel string, segment string) (Schedule, bool) {
+	var found Schedule
+	best := -1
+	for _, s := range schedules {
+		if s.Currency != currency || (s.Channel != "" && s.Channel != channel) || (s.CustomerSegment != "" && s.CustomerSegment != segment) {
+			continue
+		}
+		score := 0
+		if s.CustomerSegment != "" {
+			score += 2
+		}
+		if s.Channel != "" {
+			score++
+		}
+		if score > best {
+			found, best = s, score
+		}
+	}
+
+	return found, best >= 0
+}
+
+// Breakdown is how the fee of a transfer was made up.
+type Breakdown struct {
+	// ScheduleUuid is uuid.Nil when no schedule applies and the transfer is
+	// free.
+	ScheduleUuid uuid.UUID
+	Tier         FeeTierOrm
+	// FlatAmount and PercentageAmount are the parts of the tier, Fee their
+	// sum kept between the minimum and maximum fee of the schedule, all in
+	// the currency of the transfer.
+	FlatAmount       float64
+	PercentageAmount float64
+	Fee              float64
+	// Waived is set when a free transfer of the month covered the fee, and
+	// FreeRemaining is how many are left after this transfer.
+	Waived        bool
+	FreeRemaining int
+	// Charged is what the sender is debited, in the unit of the amount
+	// booked on the transfer.
+	Charged float64
+}
+
+// Evaluate prices a transfer of amount, in the currency of s.
+func (s Schedule) Evaluate(amount float64) Breakdown {
+	b := Breakdown{ScheduleUuid: s.ScheduleUuid}
+	found := false
+	for _, t := range s.Tiers {
+		if amount >= t.MinAmount && (!found || t.MinAmount > b.Tier.MinAmount) {
+			b.Tier, found = t, true
+		}
+	}
+
+	b.FlatAmount = b.Tier.FlatAmount
+	b.PercentageAmount = math.Round(amount*b.Tier.Rate) / 100
+	b.Fee = math.Max(b.FlatAmount+b.PercentageAmount, s.MinFee)
+	if s.MaxFee > 0 {
+		b.Fee = math.Min(b.Fee, s.MaxFee)
+	}
+	b.Fee = math.Round(b.Fee*100) / 100
+
+	return b
+}
+
+// Allow applies the free transfers of the month to b, used of them already
+// taken, and books the fee at rate, the units of the amount booked on the
+// transfer per unit of its currency.
+func (b Breakdown) Allow(freePerMonth int, used int, rate float64) Breakdown {
+	if b.ScheduleUuid == uuid.Nil {
+		return b
+	}
+	if used < freePerMonth {
+		b.Waived = true
+		b.FreeRemaining = freePerMonth - used - 1
+		b.Charged = 0
+		return b
+	}
+
+	b.Waived = false
+	b.FreeRemaining = 0
+	b.Charged = math.Round(b.Fee*rate*100) / 100
+
+	return b
+}
+
+// MonthStart is the start of the calendar month of t, in UTC, the period of
+// the free transfers.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// SortTiers orders tiers by MinAmount.
+func SortTiers(tiers []FeeTierOrm) {
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinAmount < tiers[j].MinAmount
+	})
+}
+
+// TransferFeeOrm records the fee of a transfer, charged or waived.
+type TransferFeeOrm struct {
+	TransferUuid   uuid.UUID `gorm:"primaryKey"`
+	ScheduleUuid   uuid.UUID
+	AccountUuid    uuid.UUID
+	FeeAccountUuid uuid.UUID
+	Currency       string
+	Fee            float64
+	Amount         float64
+	Waived         bool
+	// FeeTransactionUuid and IncomeTransactionUuid are the debit of the
+	// sender and the credit of the fee account, nil when nothing was
+	// charged.
+	FeeTransactionUuid    *uuid.UUID
+	IncomeTransactionUuid *uuid.UUID
+	ChargedAt             time.Time
+}
+
+func (TransferFeeOrm) TableName() string {
+	return "bank_transfer_fees"
+}
+
+// Charge is what the store needs to charge the fee of a transfer with its
+// transfer pair. The store counts the fees of the sender under the schedule
+// since MonthStart once the sender is locked, so concurrent transfers can't
+// share a free one, and applies the allowance to Breakdown at Rate.
+type Charge struct {
+	TransferUuid      uuid.UUID
+	Currency          string
+	Breakdown         Breakdown
+	FreePerMonth      int
+	Rate              float64
+	MonthStart        time.Time
+	FeeAccount        domainBank.BankAccountOrm
+	FeeTransaction    domainBank.BankTransactionOrm
+	IncomeTransaction domainBank.BankTransactionOrm
+}
+
+// Record is the row of the fee of c, charged by sender, once its allowance
+// is applied to its breakdown.
+func (c Charge) Record(sender uuid.UUID) TransferFeeOrm {
+	record := TransferFeeOrm{
+		TransferUuid:   c.TransferUuid,
+		ScheduleUuid:   c.Breakdown.ScheduleUuid,
+		AccountUuid:    sender,
+		FeeAccountUuid: c.FeeAccount.AccountUuid,
+		Currency:       c.Currency,
+		Fee:            c.Breakdown.Fee,
+		Amount:         c.Breakdown.Charged,
+		Waived:         c.Breakdown.Waived,
+		ChargedAt:      c.FeeTransaction.TransactionTimestamp,
+	}
+	if record.Amount > 0 {
+		feeId, incomeId := c.FeeTransaction.TransactionUuid, c.IncomeTransaction.TransactionUuid
+		record.FeeTransactionUuid = &feeId
+		record.IncomeTransactionUuid = &incomeId
+	}
+
+	return record
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMatch(t *testing.T) {
+	schedules := []Schedule{
+		{FeeScheduleOrm: FeeScheduleOrm{ScheduleUuid: uuid.New(), Currency: "USD"}},
+		{FeeScheduleOrm: FeeScheduleOrm{ScheduleUuid: uuid.New(), Currency: "USD", Channel: "MOBILE"}},
+		{FeeScheduleOrm: FeeScheduleOrm{ScheduleUuid: uuid.New(), Currency: "USD", CustomerSegment: "PREMIUM"}},
+		{FeeScheduleOrm: FeeScheduleOrm{ScheduleUuid: uuid.New(), Currency: "IDR", Channel: "BRANCH"}},
+	}
+
+	for _, tt := range []struct {
+		currency, channel, segment string
+		want                       int
+	}{
+		{"USD", "", "", 0},
+		{"USD", "BRANCH", "RETAIL", 0},
+		{"USD", "MOBILE", "", 1},
+		{"USD", "MOBILE", "PREMIUM", 2},
+		{"IDR", "BRANCH", "PREMIUM", 3},
+		{"IDR", "MOBILE", "", -1},
+		{"EUR", "", "", -1},
+	} {
+		got, ok := Match(schedules, tt.currency, tt.channel, tt.segment)
+		if tt.want < 0 {
+			if ok {
+				t.Errorf("Match(%v, %v, %v) = %v, want none", tt.currency, tt.channel, tt.segment, got.ScheduleUuid)
+			}
+			continue
+		}
+		if !ok || got.ScheduleUuid != schedules[tt.want].ScheduleUuid {
+			t.Errorf("Match(%v, %v, %v) = %v, %v; want schedule %d", tt.currency, tt.channel, tt.segment, got.ScheduleUuid, ok, tt.want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	s := Schedule{
+		FeeScheduleOrm: FeeScheduleOrm{ScheduleUuid: uuid.New(), MinFee: 1, MaxFee: 25},
+		Tiers: []FeeTierOrm{
+			{MinAmount: 0, FlatAmount: 0.5},
+			{MinAmount: 100, FlatAmount: 1, Rate: 0.5},
+			{MinAmount: 1000, Rate: 1.25},
+		},
+	}
+
+	for _, tt := range []struct {
+		name          string
+		amount        float64
+		flat, percent float64
+		want          float64
+	}{
+		{"raised to the minimum fee", 50, 0.5, 0, 1},
+		{"flat and percentage", 200, 1, 1, 2},
+		{"highest tier reached", 1000, 0, 12.5, 12.5},
+		{"capped", 4000, 0, 50, 25},
+	} {
+		b := s.Evaluate(tt.amount)
+		if b.FlatAmount != tt.flat || b.PercentageAmount != tt.percent || b.Fee != tt.want {
+			t.Errorf("%s: Evaluate(%v) = %+v, want flat %v, percentage %v, fee %v", tt.name, tt.amount, b, tt.flat, tt.percent, tt.want)
+		}
+	}
+
+	uncapped := s
+	uncapped.MaxFee = 0
+	if b := uncapped.Evaluate(4000); b.Fee != 50 {
+		t.Errorf("Evaluate without a maximum fee = %v, want 50", b.Fee)
+	}
+}
+
+func TestAllow(t *testing.T) {
+	b := Breakdown{ScheduleUuid: uuid.New(), Fee: 15000}
+
+	if got := b.Allow(3, 1, 1.0/15000); !got.Waived || got.FreeRemaining != 1 || got.Charged != 0 {
+		t.Errorf("Allow with free transfers left = %+v, want it waived with 1 left", got)
+	}
+	if got := b.Allow(3, 3, 1.0/15000); got.Waived || got.FreeRemaining != 0 || got.Charged != 1 {
+		t.Errorf("Allow without free transfers left = %+v, want 1 charged", got)
+	}
+	if got := (Breakdown{}).Allow(3, 0, 1); got.Waived || got.Charged != 0 {
+		t.Errorf("Allow without a schedule = %+v, want it untouched", got)
+	}
+}
+
+func TestMonthStart(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 3, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+	if got, want := MonthStart(at), time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("MonthStart(%v) = %v, want %v", at, got, want)
+	}
+}
@@ -0,0 +1,243 @@
+// Package domain defines the interest the balances of accounts earn. Interest
+// accrues for every day on the closing balance of the day, at the rate of the
+// product of the account in force that day, as often as the accrual
+// frequency of the product says, and is capitalized, posted as an IN
+// transaction, once a period of the capitalization schedule of the product
+// ends.
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/google/uuid"
+)
+
+// Day count conventions, how many days a year of interest has.
+const (
+	DayCountAct365 string = "ACT/365"
+	DayCountAct360 string = "ACT/360"
+	// DayCountActAct counts the days of the calendar year, 365 or 366.
+	DayCountActAct string = "ACT/ACT"
+)
+
+// Capitalization schedules, when accrued interest is posted.
+const (
+	CapitalizeMonthly   string = "MONTHLY"
+	CapitalizeQuarterly string = "QUARTERLY"
+	CapitalizeYearly    string = "YEARLY"
+)
+
+// Accrual frequencies, when the days of a period are accrued. Every
+// capitalization schedule is made of whole months, so a month accrues
+// before it is capitalized.
+const (
+	AccrueDaily   string = "DAILY"
+	AccrueMonthly string = "MONTHLY"
+)
+
+var DayCounts = []string{DayCountAct365, DayCountAct360, DayCountActAct}
+var AccrualFrequencies = []string{AccrueDaily, AccrueMonthly}
+var CapitalizationSchedules = []string{CapitalizeMonthly, CapitalizeQuarterly, CapitalizeYearly}
+
+var ErrRatesInvalid = errors.New("interest rates need a product, an effective date, and tiers with distinct, non-negative minimum balances and rates")
+var ErrTermsInvalid = errors.New("unknown day count convention, accrual frequency or capitalization schedule")
+
+// InterestRateOrm is one tier of a version of the rates of a product: from
+// EffectiveFrom on, until the next version, a closing balance of MinBalance
+// or more earns AnnualRate percent a year, unless a tier with a higher
+// MinBalance applies.
+type InterestRateOrm struct {
+	RateUuid      uuid.UUID `gorm:"primaryKey"`
+	ProductCode   string
+	EffectiveFrom time.Time
+	MinBalance    float64
+	AnnualRate    float64
+	CreatedAt     time.Time
+}
+
+func (InterestRateOrm) TableName() string {
+	return "bank_interest_rates"
+}
+
+// InterestAccrualOrm is the interest an account earned on AccrualDate, the
+// closing Balance of the day at AnnualRate. TransactionUuid is the
+// transaction that capitalized it, nil until then.
+type InterestAccrualOrm struct {
+	AccountUuid     uuid.UUID `gorm:"primaryKey"`
+	AccrualDate     time.Time `gorm:"primaryKey"`
+	ProductCode     string
+	Balance         float64
+	AnnualRate      float64
+	Amount          float64
+	TransactionUuid *uuid.UUID
+	CapitalizedAt   *time.Time
+	CreatedAt       time.Time
+}
+
+func (InterestAccrualOrm) TableName() string {
+	return "bank_interest_accruals"
+}
+
+// InterestAccount is an account whose product has rates, with the product.
+type InterestAccount struct {
+	Account domainBank.BankAccountOrm
+	Product domainBank.BankProductOrm
+}
+
+// Capitalization posts what Account accrued before Before, rounded to cents,
+// as Transaction, an IN transaction whose amount the store fills in, and
+// withholds the tax of it.
+type Capitalization struct {
+	Account     domainBank.BankAccountOrm
+	Before      time.Time
+	Transaction domainBank.BankTransactionOrm
+	Withholding domainTax.Withholding
+}
+
+// Posting is what a capitalization posted and withheld.
+type Posting struct {
+	Interest float64
+	Tax      float64
+}
+
+// RateVersion is the tiers of a product in force from EffectiveFrom on.
+type RateVersion struct {
+	ProductCode   string
+	EffectiveFrom time.Time
+	Tiers         []InterestRateOrm
+}
+
+// Versions groups rates into their versions, by product and oldest first,
+// the tiers of a version by ascending MinBalance.
+func Versions(rates []InterestRateOrm) []RateVersion {
+	sorted := append([]InterestRateOrm(nil), rates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ProductCode != sorted[j].ProductCode {
+			return sorted[i].ProductCode < sorted[j].ProductCode
+		}
+		if !sorted[i].EffectiveFrom.Equal(sorted[j].EffectiveFrom) {
+			return sorted[i].EffectiveFrom.Before(sorted[j].EffectiveFrom)
+		}
+		return sorted[i].MinBalance < sorted[j].MinBalance
+	})
+
+	var versions []RateVersion
+	for _, r := range sorted {
+		if n := len(versions); n > 0 && versions[n-1].ProductCode == r.ProductCode && versions[n-1].EffectiveFrom.Equal(r.EffectiveFrom) {
+			versions[n-1].Tiers = append(versions[n-1].Tiers, r)
+			continue
+		}
+		versions = append(versions, RateVersion{ProductCode: r.ProductCode, EffectiveFrom: r.EffectiveFrom, Tiers: []InterestRateOrm{r}})
+	}
+
+	return versions
+}
+
+// VersionAt is the version of versions, oldest first, in force on day.
+func VersionAt(versions []RateVersion, day time.Time) (RateVersion, bool) {
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !versions[i].EffectiveFrom.After(day) {
+			return versions[i], true
+		}
+	}
+
+	return RateVersion{}, false
+}
+
+// RateFor is the annual rate v pays on a closing balance, zero below its
+// lowest tier.
+func (v RateVersion) RateFor(balance float64) float64 {
+	rate := 0.0
+	for _, t := range v.Tiers {
+		if balance >= t.MinBalance {
+			rate = t.AnnualRate
+		}
+	}
+
+	return rate
+}
+
+// DailyInterest is what balance earns on day at annualRate percent a year.
+// Balances below zero earn nothing.
+func DailyInterest(balance float64, annualRate float64, dayCount string, day time.Time) float64 {
+	if balance <= 0 || annualRate <= 0 {
+		return 0
+	}
+
+	return math.Round(balance*annualRate/100/YearDays(dayCount, day)*1e6) / 1e6
+}
+
+// YearDays is the length of the year of day under dayCount.
+func YearDays(dayCount string, day time.Time) float64 {
+	switch dayCount {
+	case DayCountAct360:
+		return 360
+	case DayCountActAct:
+		year := day.Year()
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 366
+		}
+		return 365
+	default:
+		return 365
+	}
+}
+
+// AccrualEnd is the day the days due to accrue on day under frequency end
+// before: day itself when they accrue daily, the first of its month when
+// the days of a month accrue once it ended.
+func AccrualEnd(frequency string, day time.Time) time.Time {
+	if frequency == AccrueMonthly {
+		return PeriodStart(CapitalizeMonthly, day)
+	}
+
+	return day
+}
+
+// PeriodStart is the first day of the period of schedule that day falls in.
+func PeriodStart(schedule string, day time.Time) time.Time {
+	month := day.Month()
+	switch schedule {
+	case CapitalizeQuarterly:
+		month -= (month - 1) % 3
+	case CapitalizeYearly:
+		month = time.January
+	}
+
+	return time.Date(day.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ClosingBalances is the balance at the end of every day from from up to,
+// not including, to, of an account whose balance is now current after trxs,
+// every transaction booked since from.
+func ClosingBalances(current float64, from time.Time, to time.Time, trxs []domainBank.BankTransactionOrm) []float64 {
+	var balances []float64
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		balance := current
+		for _, trx := range trxs {
+			if trx.TransactionTimestamp.Before(end) {
+				continue
+			}
+			if trx.TransactionType == domainBank.TransactionTypeOut {
+				balance += trx.Amount
+			} else {
+				balance -= trx.Amount
+			}
+		}
+		balances = append(balances, math.Round(balance*100)/100)
+	}
+
+	return balances
+}
+
+// Day is the UTC date of t, at midnight.
+func Day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestVersionAt(t *testing.T) {
+	versions := Versions([]InterestRateOrm{
+		{ProductCode: "SAVER", EffectiveFrom: date(time.June, 1), MinBalance: 0, AnnualRate: 2},
+		{ProductCode: "SAVER", EffectiveFrom: date(time.January, 1), MinBalance: 1000, AnnualRate: 1.5},
+		{ProductCode: "SAVER", EffectiveFrom: date(time.January, 1), MinBalance: 0, AnnualRate: 1},
+	})
+	if len(versions) != 2 || len(versions[0].Tiers) != 2 || versions[0].Tiers[0].MinBalance != 0 {
+		t.Fatalf("Versions = %+v, want 2 versions, tiers by minimum balance", versions)
+	}
+
+	for _, tt := range []struct {
+		day     time.Time
+		balance float64
+		found   bool
+		want    float64
+	}{
+		{date(time.January, 1), 999.99, true, 1},
+		{date(time.May, 31), 1000, true, 1.5},
+		{date(time.June, 1), 1000, true, 2},
+		{date(time.December, 31), 5, true, 2},
+		{date(time.January, 1).AddDate(0, 0, -1), 5, false, 0},
+	} {
+		v, found := VersionAt(versions, tt.day)
+		if found != tt.found || v.RateFor(tt.balance) != tt.want {
+			t.Errorf("rate on %v for %v = %v, %v; want %v, %v", tt.day.Format(time.DateOnly), tt.balance, v.RateFor(tt.balance), found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestDailyInterest(t *testing.T) {
+	for _, tt := range []struct {
+		dayCount string
+		day      time.Time
+		balance  float64
+		want     float64
+	}{
+		{DayCountAct365, date(time.March, 1), 36500, 1},
+		{DayCountAct360, date(time.March, 1), 36000, 1},
+		{DayCountActAct, date(time.March, 1), 36600, 1},
+		{DayCountActAct, date(time.March, 1).AddDate(1, 0, 0), 36500, 1},
+		{DayCountAct365, date(time.March, 1), 10, 0.000274},
+		{DayCountAct365, date(time.March, 1), -100, 0},
+	} {
+		if got := DailyInterest(tt.balance, 1, tt.dayCount, tt.day); got != tt.want {
+			t.Errorf("DailyInterest(%v, 1%%, %v, %v) = %v, want %v", tt.balance, tt.dayCount, tt.day.Format(time.DateOnly), got, tt.want)
+		}
+	}
+}
+
+func TestPeriodStart(t *testing.T) {
+	day := time.Date(2024, time.August, 17, 0, 0, 0, 0, time.UTC)
+
+	for schedule, want := range map[string]time.Time{
+		CapitalizeMonthly:   date(time.August, 1),
+		CapitalizeQuarterly: date(time.July, 1),
+		CapitalizeYearly:    date(time.January, 1),
+	} {
+		if got := PeriodStart(schedule, day); !got.Equal(want) {
+			t.Errorf("PeriodStart(%v) = %v, want %v", schedule, got, want)
+		}
+	}
+}
+
+func TestClosingBalances(t *testing.T) {
+	trxs := []domainBank.BankTransactionOrm{
+		{TransactionTimestamp: date(time.May, 2).Add(9 * time.Hour), TransactionType: domainBank.TransactionTypeIn, Amount: 50},
+		{TransactionTimestamp: date(time.May, 3), TransactionType: domainBank.TransactionTypeOut, Amount: 20.5},
+	}
+
+	got := ClosingBalances(129.5, date(time.May, 1), date(time.May, 4), trxs)
+	want := []float64{100, 150, 129.5}
+	if len(got) != len(want) {
+		t.Fatalf("ClosingBalances = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ClosingBalances = %v, want %v", got, want)
+		}
+	}
+}
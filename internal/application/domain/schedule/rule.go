@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrRuleInvalid = errors.New("recurrence must be an RRULE with FREQ of DAILY, WEEKLY, MONTHLY or YEARLY and optionally INTERVAL, BYDAY (WEEKLY), BYMONTHDAY (MONTHLY) and one of COUNT or UNTIL")
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is the subset of an RFC 5545 RRULE standing orders need. The zero Rule
+// occurs once, at the start of its schedule.
+//
+// Occurrences keep the time of day of the start. A month without the day of
+// a MONTHLY or YEARLY rule, like February for BYMONTHDAY=31, uses its last
+// day instead of being skipped, as standing orders do.
+type Rule struct {
+	Freq     string
+	Interval int
+	// ByDay are the weekdays of a WEEKLY rule, the weekday of the start when
+	// empty. Weeks start on Monday.
+	ByDay []time.Weekday
+	// ByMonthDay is the day of a MONTHLY rule, -1 for the last one, the day
+	// of the start when 0.
+	ByMonthDay int
+	// Count limits the occurrences, Until the time of the last one. Zero
+	// leaves them unlimited.
+	Count int
+	Until time.Time
+}
+
+// ParseRule parses a rule like FREQ=MONTHLY;BYMONTHDAY=1;COUNT=12. An
+// optional RRULE: prefix is ignored, "" is a rule occurring once. UNTIL is
+// either a date, inclusive, or a UTC time like 20250101T090000Z.
+func ParseRule(s string) (Rule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return Rule{}, nil
+	}
+
+	r := Rule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || seen[key] {
+			return Rule{}, fmt.Errorf("%w : %q", ErrRuleInvalid, part)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = value
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = ErrRuleInvalid
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				wd, ok := weekdays[day]
+				if !ok {
+					err = ErrRuleInvalid
+					break
+				}
+				if !slices.Contains(r.ByDay, wd) {
+					r.ByDay = append(r.ByDay, wd)
+				}
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = strconv.Atoi(value)
+			if err == nil && (r.ByMonthDay == 0 || r.ByMonthDay < -1 || r.ByMonthDay > 31) {
+				err = ErrRuleInvalid
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count < 1 {
+				err = ErrRuleInvalid
+			}
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		default:
+			err = ErrRuleInvalid
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w : %q", ErrRuleInvalid, part)
+		}
+	}
+
+	switch {
+	case r.Freq != FreqDaily && r.Freq != FreqWeekly && r.Freq != FreqMonthly && r.Freq != FreqYearly:
+		return Rule{}, fmt.Errorf("%w : FREQ %q", ErrRuleInvalid, r.Freq)
+	case len(r.ByDay) > 0 && r.Freq != FreqWeekly:
+		return Rule{}, fmt.Errorf("%w : BYDAY needs FREQ=WEEKLY", ErrRuleInvalid)
+	case r.ByMonthDay != 0 && r.Freq != FreqMonthly:
+		return Rule{}, fmt.Errorf("%w : BYMONTHDAY needs FREQ=MONTHLY", ErrRuleInvalid)
+	case r.Count > 0 && !r.Until.IsZero():
+		return Rule{}, fmt.Errorf("%w : COUNT and UNTIL exclude each other", ErrRuleInvalid)
+	}
+	// weeks start on Monday, so Sunday is the last day of the week
+	slices.SortFunc(r.ByDay, func(a, b time.Weekday) int { return (int(a)+6)%7 - (int(b)+6)%7 })
+
+	return r, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return day.Add(24*time.Hour - time.Second), nil
+}
+
+// String formats r the way ParseRule reads it, "" for a rule occurring once.
+func (r Rule) String() string {
+	if r.Freq == "" {
+		return ""
+	}
+
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			days = append(days, strings.ToUpper(wd.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.ByMonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// At returns the n-th occurrence, from 0, of r started at start, false when
+// r ends before it.
+func (r Rule) At(start time.Time, n int) (time.Time, bool) {
+	start = start.UTC()
+	if n < 0 || (r.Count > 0 && n >= r.Count) || (r.Freq == "" && n > 0) {
+		return time.Time{}, false
+	}
+
+	var t time.Time
+	switch r.Freq {
+	case "":
+		t = start
+	case FreqDaily:
+		t = start.AddDate(0, 0, n*r.Interval)
+	case FreqWeekly:
+		t = r.weekly(start, n)
+	case FreqMonthly:
+		day := r.ByMonthDay
+		if day == 0 {
+			day = start.Day()
+		}
+		// the day may fall before the start in its month, the first
+		// occurrence is then a month later
+		if monthDay(start, 0, day).Before(start) {
+			n++
+		}
+		t = monthDay(start, n*r.Interval, day)
+	case FreqYearly:
+		t = monthDay(start, 12*n*r.Interval, start.Day())
+	}
+
+	if !r.Until.IsZero() && t.After(r.Until) {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// After returns the first occurrence of r started at start from the n-th on
+// at or after from, with its number.
+func (r Rule) After(start time.Time, from time.Time, n int) (int, time.Time, bool) {
+	for ; ; n++ {
+		t, ok := r.At(start, n)
+		if !ok {
+			return 0, time.Time{}, false
+		}
+		if !t.Before(from) {
+			return n, t, true
+		}
+	}
+}
+
+func (r Rule) weekly(start time.Time, n int) time.Time {
+	if len(r.ByDay) == 0 {
+		return start.AddDate(0, 0, 7*n*r.Interval)
+	}
+
+	// Monday of the week of start
+	weekStart := start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	for week := 0; ; week += r.Interval {
+		for _, wd := range r.ByDay {
+			t := weekStart.AddDate(0, 0, 7*week+(int(wd)+6)%7)
+			if t.Before(start) {
+				continue
+			}
+			if n == 0 {
+				return t
+			}
+			n--
+		}
+	}
+}
+
+// monthDay is day, -1 for the last, of the month months after the one of
+// start, at the time of day of start. A day past the end of the month is its
+// last day.
+func monthDay(start time.Time, months int, day int) time.Time {
+	first := time.Date(start.Year(), start.Month()+time.Month(months), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if day == -1 || day > last {
+		day = last
+	}
+
+	return first.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"RRULE:freq=monthly;bymonthday=1;count=12", "FREQ=MONTHLY;BYMONTHDAY=1;COUNT=12"},
+		{"FREQ=WEEKLY;BYDAY=FR,MO,FR;INTERVAL=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"},
+		{"FREQ=WEEKLY;BYDAY=SU,MO", "FREQ=WEEKLY;BYDAY=MO,SU"},
+		{"FREQ=DAILY;INTERVAL=1;UNTIL=20240531", "FREQ=DAILY;UNTIL=20240531T235959Z"},
+		{"FREQ=YEARLY;UNTIL=20300101T090000Z", "FREQ=YEARLY;UNTIL=20300101T090000Z"},
+	} {
+		r, err := ParseRule(tt.in)
+		if err != nil || r.String() != tt.want {
+			t.Errorf("ParseRule(%q) = %q, %v; want %q", tt.in, r.String(), err, tt.want)
+		}
+	}
+
+	for _, in := range []string{
+		"FREQ=HOURLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYMONTHDAY=-2",
+		"FREQ=MONTHLY;COUNT=0",
+		"FREQ=MONTHLY;COUNT=2;UNTIL=20300101",
+		"FREQ=MONTHLY;UNTIL=tomorrow",
+		"FREQ=MONTHLY;BYSETPOS=1",
+	} {
+		if _, err := ParseRule(in); !errors.Is(err, ErrRuleInvalid) {
+			t.Errorf("ParseRule(%q) = %v, want ErrRuleInvalid", in, err)
+		}
+	}
+}
+
+func TestRuleAt(t *testing.T) {
+	// a Wednesday
+	start := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 9, 30, 0, 0, time.UTC)
+	}
+
+	for _, tt := range []struct {
+		rule string
+		want []time.Time
+	}{
+		{"", []time.Time{start}},
+		{"FREQ=DAILY;INTERVAL=2;COUNT=3", []time.Time{start, day(time.February, 2), day(time.February, 4)}},
+		{"FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4", []time.Time{start, day(time.February, 5), day(time.February, 7), day(time.February, 12)}},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU;COUNT=2", []time.Time{day(time.February, 13), day(time.February, 27)}},
+		// the 31st falls back to the last day of shorter months
+		{"FREQ=MONTHLY;COUNT=3", []time.Time{start, day(time.February, 29), day(time.March, 31)}},
+		// the 1st of January is before the start
+		{"FREQ=MONTHLY;BYMONTHDAY=1;COUNT=2", []time.Time{day(time.February, 1), day(time.March, 1)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1;INTERVAL=3;COUNT=2", []time.Time{start, day(time.April, 30)}},
+		{"FREQ=DAILY;UNTIL=20240202", []time.Time{start, day(time.February, 1), day(time.February, 2)}},
+	} {
+		r, err := ParseRule(tt.rule)
+		if err != nil {
+			t.Fatalf("ParseRule(%q): %v", tt.rule, err)
+		}
+		for n, want := range tt.want {
+			if got, ok := r.At(start, n); !ok || !got.Equal(want) {
+				t.Errorf("%q: At(%d) = %v, %v; want %v", tt.rule, n, got, ok, want)
+			}
+		}
+		if got, ok := r.At(start, len(tt.want)); ok {
+			t.Errorf("%q: At(%d) = %v, want no occurrence", tt.rule, len(tt.want), got)
+		}
+	}
+
+	// Sunday closes the week of the Monday before it
+	weekend, _ := ParseRule("FREQ=WEEKLY;BYDAY=MO,SU")
+	monday := time.Date(2024, time.May, 6, 9, 30, 0, 0, time.UTC)
+	previous := monday
+	for n, want := range []int{12, 13, 19, 20, 26} {
+		got, ok := weekend.At(monday, n+1)
+		if !ok || !got.After(previous) || got.Day() != want {
+			t.Errorf("MO,SU from Monday May 6: At(%d) = %v, %v; want May %d", n+1, got, ok, want)
+		}
+		previous = got
+	}
+
+	yearly, _ := ParseRule("FREQ=YEARLY")
+	leap := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+	if got, _ := yearly.At(leap, 1); !got.Equal(time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("yearly from the 29th of February: At(1) = %v, want the 28th", got)
+	}
+}
+
+func TestRuleAfter(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+	r, _ := ParseRule("FREQ=MONTHLY;COUNT=6")
+
+	if n, at, ok := r.After(start, time.Date(2024, time.July, 15, 0, 0, 0, 0, time.UTC), 1); !ok || n != 3 || at.Month() != time.August {
+		t.Errorf("After(15 July) = %v, %v, %v; want occurrence 3 in August", n, at, ok)
+	}
+	if n, _, ok := r.After(start, start, 2); !ok || n != 2 {
+		t.Errorf("After(start) from occurrence 2 = %v, %v; want 2", n, ok)
+	}
+	if _, at, ok := r.After(start, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), 0); ok {
+		t.Errorf("After the last occurrence = %v, want none", at)
+	}
+}
@@ -0,0 +1,98 @@
+// Package domain defines scheduled transfers, future-dated and standing
+// orders, and the history of their executions.
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// StatusActive schedules run at NextRunAt.
+	StatusActive    = "ACTIVE"
+	StatusPaused    = "PAUSED"
+	StatusCancelled = "CANCELLED"
+	// StatusCompleted schedules ran their last occurrence.
+	StatusCompleted = "COMPLETED"
+)
+
+const (
+	ExecutionSucceeded = "SUCCEEDED"
+	// ExecutionRetry executions failed for insufficient funds and are tried
+	// again after the retry interval.
+	ExecutionRetry  = "RETRY"
+	ExecutionFailed = "FAILED"
+)
+
+var ErrCurrencyNotSupported = errors.New("currency of the scheduled transfer is not supported")
+var ErrAmountInvalid = errors.New("amount of the scheduled transfer must be positive")
+var ErrStartInPast = errors.New("scheduled transfer can't start in the past")
+var ErrRetryInvalid = errors.New("retries must not be negative, and retrying needs a positive retry interval")
+var ErrScheduleNotActive = errors.New("scheduled transfer is not in a status that allows it")
+
+type ScheduledTransferOrm struct {
+	ScheduleUuid    uuid.UUID `gorm:"primaryKey"`
+	FromAccountUuid uuid.UUID
+	ToAccountUuid   uuid.UUID
+	Currency        string
+	Amount          float64
+	Notes           string
+	Channel         string
+	// Recurrence is the Rule of the schedule, formatted by Rule.String.
+	Recurrence string
+	StartAt    time.Time
+	Status     string
+	// NextRunAt is when Occurrence is executed next, nil once the schedule
+	// has none left.
+	NextRunAt *time.Time
+	// Occurrence is the number of the next occurrence from 0, Attempt the
+	// number of its failed attempts.
+	Occurrence int
+	Attempt    int
+	// MaxRetries is how often an occurrence failing for insufficient funds
+	// is tried again, RetryIntervalSeconds apart.
+	MaxRetries           int
+	RetryIntervalSeconds int64
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (ScheduledTransferOrm) TableName() string {
+	return "bank_scheduled_transfers"
+}
+
+// Rule returns the parsed Recurrence.
+func (s ScheduledTransferOrm) Rule() (Rule, error) {
+	return ParseRule(s.Recurrence)
+}
+
+func (s ScheduledTransferOrm) RetryInterval() time.Duration {
+	return time.Duration(s.RetryIntervalSeconds) * time.Second
+}
+
+// ExecutionOrm is one attempt at an occurrence of a schedule. TransferUuid is
+// the transfer it created, nil when none was.
+type ExecutionOrm struct {
+	ExecutionUuid uuid.UUID `gorm:"primaryKey"`
+	ScheduleUuid  uuid.UUID
+	Occurrence    int
+	Attempt       int
+	ScheduledFor  time.Time
+	ExecutedAt    time.Time
+	Status        string
+	TransferUuid  *uuid.UUID
+	Error         string
+}
+
+func (ExecutionOrm) TableName() string {
+	return "bank_scheduled_transfer_executions"
+}
+
+// ScheduleDetail is a schedule with the account numbers of its accounts.
+type ScheduleDetail struct {
+	ScheduledTransferOrm
+	FromAccountNumber string
+	ToAccountNumber   string
+}
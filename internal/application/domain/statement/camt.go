@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+)
+
+// CamtNamespace is the camt.053 version the statements follow.
+const CamtNamespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+// Balance types of ISO 20022 (BalanceType12Code).
+const (
+	balanceOpeningBooked = "OPBD"
+	balanceClosingBooked = "CLBD"
+)
+
+type camtGroupHeader struct {
+	MsgId   string `xml:"MsgId"`
+	CreDtTm string `xml:"CreDtTm"`
+}
+
+type camtPeriod struct {
+	FrDtTm string `xml:"FrDtTm"`
+	ToDtTm string `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	Id  string `xml:"Id>Othr>Id"`
+	Ccy string `xml:"Ccy"`
+	Nm  string `xml:"Nm,omitempty"`
+}
+
+type camtAmount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+type camtBalance struct {
+	Type      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amt       camtAmount `xml:"Amt"`
+	CdtDbtInd string     `xml:"CdtDbtInd"`
+	DtTm      string     `xml:"Dt>DtTm"`
+}
+
+type camtEntry struct {
+	NtryRef     string     `xml:"NtryRef"`
+	Amt         camtAmount `xml:"Amt"`
+	CdtDbtInd   string     `xml:"CdtDbtInd"`
+	Sts         string     `xml:"Sts"`
+	BookgDt     string     `xml:"BookgDt>DtTm"`
+	ValDt       string     `xml:"ValDt>DtTm"`
+	AcctSvcrRef string     `xml:"AcctSvcrRef"`
+	BkTxCd      string     `xml:"BkTxCd>Prtry>Cd"`
+	Ustrd       string     `xml:"NtryDtls>TxDtls>RmtInf>Ustrd,omitempty"`
+}
+
+type camtWriter struct {
+	w   io.Writer
+	enc *xml.Encoder
+	st  Statement
+}
+
+func newCamtWriter(w io.Writer) *camtWriter {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	return &camtWriter{w: w, enc: enc}
+}
+
+// camtTime writes t as an ISODateTime in UTC.
+func camtTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// creditDebit is the amount of v, always positive in camt.053, and whether
+// it is a credit or a debit.
+func creditDebit(v float64, currency string) (camtAmount, string) {
+	indicator := "CRDT"
+	if v < 0 {
+		indicator = "DBIT"
+	}
+
+	return camtAmount{Ccy: currency, Value: FormatAmount(math.Abs(v), currency)}, indicator
+}
+
+func (w *camtWriter) balance(balanceType string, v float64, at time.Time) camtBalance {
+	amount, indicator := creditDebit(v, w.st.Account.Currency)
+	return camtBalance{Type: balanceType, Amt: amount, CdtDbtInd: indicator, DtTm: camtTime(at)}
+}
+
+func (w *camtWriter) Begin(st Statement) error {
+	w.st = st
+	if _, err := io.WriteString(w.w, xml.Header); err != nil {
+		return err
+	}
+
+	document := xml.StartElement{Name: xml.Name{Local: "Document"}, Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: CamtNamespace}}}
+	for _, start := range []xml.StartElement{document, {Name: xml.Name{Local: "BkToCstmrStmt"}}} {
+		if err := w.enc.EncodeToken(start); err != nil {
+			return err
+		}
+	}
+
+	header := camtGroupHeader{MsgId: st.Id, CreDtTm: camtTime(st.CreatedAt)}
+	if err := w.enc.EncodeElement(header, xml.StartElement{Name: xml.Name{Local: "GrpHdr"}}); err != nil {
+		return err
+	}
+
+	if err := w.enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: "Stmt"}}); err != nil {
+		return err
+	}
+	for _, field := range []struct {
+		name  string
+		value interface{}
+	}{
+		{"Id", st.Id},
+		{"CreDtTm", camtTime(st.CreatedAt)},
+		{"FrToDt", camtPeriod{FrDtTm: camtTime(st.From), ToDtTm: camtTime(st.To)}},
+		{"Acct", camtAccount{Id: st.Account.AccountNumber, Ccy: st.Account.Currency, Nm: truncate(st.Account.AccountName, 70)}},
+		{"Bal", w.balance(balanceOpeningBooked, st.Opening, st.From)},
+		{"Bal", w.balance(balanceClosingBooked, st.Closing, st.To)},
+	} {
+		if err := w.enc.EncodeElement(field.value, xml.StartElement{Name: xml.Name{Local: field.name}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *camtWriter) Entry(trx domainBank.BankTransactionOrm) error {
+	amount, indicator := creditDebit(Signed(trx), w.st.Account.Currency)
+	// Max35Text, a uuid without its dashes fits
+	ref := strings.ReplaceAll(trx.TransactionUuid.String(), "-", "")
+	booked := camtTime(trx.TransactionTimestamp)
+
+	return w.enc.EncodeElement(camtEntry{
+		NtryRef:     ref,
+		Amt:         amount,
+		CdtDbtInd:   indicator,
+		Sts:         "BOOK",
+		BookgDt:     booked,
+		ValDt:       booked,
+		AcctSvcrRef: ref,
+		BkTxCd:      trx.TransactionType,
+		Ustrd:       truncate(trx.Notes, 140),
+	}, xml.StartElement{Name: xml.Name{Local: "Ntry"}})
+}
+
+func (w *camtWriter) End() error {
+	for _, name := range []string{"Stmt", "BkToCstmrStmt", "Document"} {
+		if err := w.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+	if err := w.enc.Close(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w.w, "\n")
+	return err
+}
+
+// truncate keeps the first n characters of s.
+func truncate(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+
+	return s
+}
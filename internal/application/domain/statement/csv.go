@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+)
+
+// CSVHeader is the header of a CSV statement. Amounts are signed, negative
+// for debits, and balance is the one after the row.
+var CSVHeader = []string{"timestamp", "transaction_id", "type", "amount", "balance", "currency", "description"}
+
+// Types of the balance rows of a CSV statement.
+const (
+	RowOpeningBalance = "OPENING_BALANCE"
+	RowClosingBalance = "CLOSING_BALANCE"
+)
+
+type csvWriter struct {
+	out     *csv.Writer
+	st      Statement
+	balance float64
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{out: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) Begin(st Statement) error {
+	w.st, w.balance = st, st.Opening
+	if err := w.out.Write(CSVHeader); err != nil {
+		return err
+	}
+
+	return w.balanceRow(st.From, RowOpeningBalance)
+}
+
+func (w *csvWriter) Entry(trx domainBank.BankTransactionOrm) error {
+	currency := w.st.Account.Currency
+	w.balance += Signed(trx)
+
+	return w.out.Write([]string{
+		trx.TransactionTimestamp.UTC().Format(time.RFC3339),
+		trx.TransactionUuid.String(),
+		trx.TransactionType,
+		FormatAmount(Signed(trx), currency),
+		FormatAmount(w.balance, currency),
+		currency,
+		trx.Notes,
+	})
+}
+
+func (w *csvWriter) End() error {
+	if err := w.balanceRow(w.st.To, RowClosingBalance); err != nil {
+		return err
+	}
+
+	w.out.Flush()
+	return w.out.Error()
+}
+
+func (w *csvWriter) balanceRow(at time.Time, rowType string) error {
+	balance := w.st.Opening
+	if rowType == RowClosingBalance {
+		balance = w.st.Closing
+	}
+
+	return w.out.Write([]string{
+		at.UTC().Format(time.RFC3339),
+		"",
+		rowType,
+		"",
+		FormatAmount(balance, w.st.Account.Currency),
+		w.st.Account.Currency,
+		"",
+	})
+}
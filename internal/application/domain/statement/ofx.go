@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+)
+
+// ofxHeader is the processing instruction of OFX 2.2 files.
+const ofxHeader = `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignOn struct {
+	Status   ofxStatus `xml:"STATUS"`
+	DTServer string    `xml:"DTSERVER"`
+	Language string    `xml:"LANGUAGE"`
+}
+
+type ofxAccount struct {
+	BankId   string `xml:"BANKID"`
+	AcctId   string `xml:"ACCTID"`
+	AcctType string `xml:"ACCTTYPE"`
+}
+
+type ofxTransaction struct {
+	TrnType  string `xml:"TRNTYPE"`
+	DTPosted string `xml:"DTPOSTED"`
+	TrnAmt   string `xml:"TRNAMT"`
+	FitId    string `xml:"FITID"`
+	Memo     string `xml:"MEMO,omitempty"`
+}
+
+type ofxBalance struct {
+	BalAmt string `xml:"BALAMT"`
+	DTAsOf string `xml:"DTASOF"`
+}
+
+type ofxWriter struct {
+	w   io.Writer
+	enc *xml.Encoder
+	st  Statement
+}
+
+func newOFXWriter(w io.Writer) *ofxWriter {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	return &ofxWriter{w: w, enc: enc}
+}
+
+// ofxTime writes t as an OFX datetime in UTC.
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
+
+func (w *ofxWriter) element(name string) xml.StartElement {
+	return xml.StartElement{Name: xml.Name{Local: name}}
+}
+
+func (w *ofxWriter) Begin(st Statement) error {
+	w.st = st
+	if _, err := io.WriteString(w.w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`+"\n"+ofxHeader); err != nil {
+		return err
+	}
+
+	ok := ofxStatus{Code: 0, Severity: "INFO"}
+	for _, name := range []string{"OFX", "SIGNONMSGSRSV1"} {
+		if err := w.enc.EncodeToken(w.element(name)); err != nil {
+			return err
+		}
+	}
+	signOn := ofxSignOn{Status: ok, DTServer: ofxTime(st.CreatedAt), Language: "ENG"}
+	if err := w.enc.EncodeElement(signOn, w.element("SONRS")); err != nil {
+		return err
+	}
+	if err := w.enc.EncodeToken(w.element("SIGNONMSGSRSV1").End()); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"BANKMSGSRSV1", "STMTTRNRS"} {
+		if err := w.enc.EncodeToken(w.element(name)); err != nil {
+			return err
+		}
+	}
+	if err := w.enc.EncodeElement(st.Id, w.element("TRNUID")); err != nil {
+		return err
+	}
+	if err := w.enc.EncodeElement(ok, w.element("STATUS")); err != nil {
+		return err
+	}
+	if err := w.enc.EncodeToken(w.element("STMTRS")); err != nil {
+		return err
+	}
+	if err := w.enc.EncodeElement(st.Account.Currency, w.element("CURDEF")); err != nil {
+		return err
+	}
+	account := ofxAccount{BankId: st.BankId, AcctId: st.Account.AccountNumber, AcctType: "CHECKING"}
+	if err := w.enc.EncodeElement(account, w.element("BANKACCTFROM")); err != nil {
+		return err
+	}
+	if err := w.enc.EncodeToken(w.element("BANKTRANLIST")); err != nil {
+		return err
+	}
+	if err := w.enc.EncodeElement(ofxTime(st.From), w.element("DTSTART")); err != nil {
+		return err
+	}
+
+	return w.enc.EncodeElement(ofxTime(st.To), w.element("DTEND"))
+}
+
+func (w *ofxWriter) Entry(trx domainBank.BankTransactionOrm) error {
+	trnType := "CREDIT"
+	if trx.TransactionType == domainBank.TransactionTypeOut {
+		trnType = "DEBIT"
+	}
+
+	return w.enc.EncodeElement(ofxTransaction{
+		TrnType:  trnType,
+		DTPosted: ofxTime(trx.TransactionTimestamp),
+		TrnAmt:   FormatAmount(Signed(trx), w.st.Account.Currency),
+		FitId:    trx.TransactionUuid.String(),
+		Memo:     trx.Notes,
+	}, w.element("STMTTRN"))
+}
+
+func (w *ofxWriter) End() error {
+	if err := w.enc.EncodeToken(w.element("BANKTRANLIST").End()); err != nil {
+		return err
+	}
+	balance := ofxBalance{BalAmt: FormatAmount(w.st.Closing, w.st.Account.Currency), DTAsOf: ofxTime(w.st.To)}
+	if err := w.enc.EncodeElement(balance, w.element("LEDGERBAL")); err != nil {
+		return err
+	}
+	for _, name := range []string{"STMTRS", "STMTTRNRS", "BANKMSGSRSV1", "OFX"} {
+		if err := w.enc.EncodeToken(w.element(name).End()); err != nil {
+			return err
+		}
+	}
+	if err := w.enc.Close(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w.w, "\n")
+	return err
+}
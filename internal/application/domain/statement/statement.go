@@ -0,0 +1,132 @@
+// Package domain defines account statements: the opening and closing balance
+// of an account over a period and every transaction posted in between,
+// rendered as CSV, OFX, camt.053 or PDF one transaction at a time.
+package domain
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+const (
+	FormatCSV     string = "CSV"
+	FormatOFX     string = "OFX"
+	FormatCamt053 string = "CAMT_053"
+	// FormatPDF is the printable statement, whose Writer is in the pdf
+	// package.
+	FormatPDF string = "PDF"
+)
+
+var ErrFormatInvalid = errors.New("statement format must be CSV, OFX, CAMT_053 or PDF")
+var ErrPeriodInvalid = errors.New("a statement needs a period starting before it ends and before now")
+
+// Statement is what is rendered before the transactions of the period, From
+// inclusive to To exclusive.
+type Statement struct {
+	Id      string
+	BankId  string
+	Account domainBank.BankAccountOrm
+	From    time.Time
+	To      time.Time
+	// Opening is the balance at From, Closing the one at To.
+	Opening   float64
+	Closing   float64
+	CreatedAt time.Time
+}
+
+// Cursor is the position of a transaction in a statement, which orders
+// transactions oldest first.
+type Cursor struct {
+	Timestamp       time.Time
+	TransactionUuid uuid.UUID
+}
+
+// CursorOf is the position of trx in a statement.
+func CursorOf(trx domainBank.BankTransactionOrm) Cursor {
+	return Cursor{Timestamp: trx.TransactionTimestamp, TransactionUuid: trx.TransactionUuid}
+}
+
+// statementNamespace derives the ids of statements.
+var statementNamespace = uuid.MustParse("6f1d4a52-2a4b-4f0e-9a0c-3d2b8e5c7a91")
+
+// StatementId identifies the statement of accountUuid for a period, the same
+// one every time it is generated. It is 32 characters, within the 35 of
+// camt.053.
+func StatementId(accountUuid uuid.UUID, from time.Time, to time.Time) string {
+	name := accountUuid.String() + "/" + from.UTC().Format(time.RFC3339Nano) + "/" + to.UTC().Format(time.RFC3339Nano)
+	return strings.ReplaceAll(uuid.NewSHA1(statementNamespace, []byte(name)).String(), "-", "")
+}
+
+// Writer renders a statement: Begin once, Entry for every transaction of the
+// period oldest first, then End.
+type Writer interface {
+	Begin(st Statement) error
+	Entry(trx domainBank.BankTransactionOrm) error
+	End() error
+}
+
+// NewWriter returns the Writer of format writing to w, for every format but
+// FormatPDF.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatOFX:
+		return newOFXWriter(w), nil
+	case FormatCamt053:
+		return newCamtWriter(w), nil
+	}
+
+	return nil, ErrFormatInvalid
+}
+
+// Signed is the amount of trx as it changed the balance, negative for a
+// debit.
+func Signed(trx domainBank.BankTransactionOrm) float64 {
+	if trx.TransactionType == domainBank.TransactionTypeOut {
+		return -trx.Amount
+	}
+
+	return trx.Amount
+}
+
+// minorUnits are the ISO 4217 decimals of the currencies with other than 2.
+var minorUnits = map[string]int{
+	"BHD": 3, "CLP": 0, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0, "KWD": 3,
+	"LYD": 3, "OMR": 3, "PYG": 0, "TND": 3, "UGX": 0, "VND": 0, "XAF": 0, "XOF": 0,
+}
+
+// storedDecimals is the scale amounts are stored at, NUMERIC(15,2). A
+// currency with more minor units than that is written with no more decimals
+// than there are, so a dinar amount doesn't end in a padded zero.
+const storedDecimals = 2
+
+// MinorUnits is the number of decimals amounts in currency are written with:
+// its minor units, at most storedDecimals.
+func MinorUnits(currency string) int {
+	if units, ok := minorUnits[strings.ToUpper(currency)]; ok {
+		return min(units, storedDecimals)
+	}
+
+	return storedDecimals
+}
+
+// FormatAmount writes v rounded to the minor units of currency.
+func FormatAmount(v float64, currency string) string {
+	units := MinorUnits(currency)
+	scale := math.Pow10(units)
+	v = math.Round(v*scale) / scale
+	if v == 0 {
+		// no -0.00
+		v = 0
+	}
+
+	return strconv.FormatFloat(v, 'f', units, 64)
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+var (
+	from = time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	to   = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func statement() Statement {
+	return Statement{
+		Id:        "0123456789abcdef0123456789abcdef",
+		BankId:    "MICROBANK",
+		Account:   domainBank.BankAccountOrm{AccountNumber: "7835697001", AccountName: "Kate", Currency: "USD"},
+		From:      from,
+		To:        to,
+		Opening:   10,
+		Closing:   -2.5,
+		CreatedAt: to.Add(time.Hour),
+	}
+}
+
+func transactions() []domainBank.BankTransactionOrm {
+	return []domainBank.BankTransactionOrm{
+		{TransactionUuid: uuid.MustParse("00000000-0000-0000-0000-000000000001"), TransactionTimestamp: from.Add(time.Hour),
+			Amount: 15, TransactionType: domainBank.TransactionTypeOut, Notes: "rent, May"},
+		{TransactionUuid: uuid.MustParse("00000000-0000-0000-0000-000000000002"), TransactionTimestamp: from.Add(48 * time.Hour),
+			Amount: 2.5, TransactionType: domainBank.TransactionTypeIn},
+	}
+}
+
+func render(t *testing.T, format string, st Statement) string {
+	t.Helper()
+
+	var out strings.Builder
+	w, err := NewWriter(format, &out)
+	if err != nil {
+		t.Fatalf("NewWriter(%v): %v", format, err)
+	}
+	if err := w.Begin(st); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	for _, trx := range transactions() {
+		if err := w.Entry(trx); err != nil {
+			t.Fatalf("Entry: %v", err)
+		}
+	}
+	if err := w.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+
+	return out.String()
+}
+
+func TestFormatAmount(t *testing.T) {
+	for _, tt := range []struct {
+		v        float64
+		currency string
+		want     string
+	}{
+		{12.5, "USD", "12.50"},
+		{-0.004, "USD", "0.00"},
+		{1234.567, "IDR", "1234.57"},
+		{1234.5, "jpy", "1235"},
+		// stored with 2 decimals, not the 3 of the dinar
+		{1.2345, "KWD", "1.23"},
+	} {
+		if got := FormatAmount(tt.v, tt.currency); got != tt.want {
+			t.Errorf("FormatAmount(%v, %v) = %v, want %v", tt.v, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestStatementId(t *testing.T) {
+	account := uuid.New()
+	id := StatementId(account, from, to)
+	if len(id) != 32 || id != StatementId(account, from, to) {
+		t.Errorf("StatementId = %v, want the same 32 characters every time", id)
+	}
+	if id == StatementId(account, from, to.Add(time.Second)) {
+		t.Error("StatementId of another period is the same")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	want := "timestamp,transaction_id,type,amount,balance,currency,description\n" +
+		"2024-05-01T00:00:00Z,,OPENING_BALANCE,,10.00,USD,\n" +
+		"2024-05-01T01:00:00Z,00000000-0000-0000-0000-000000000001,OUT,-15.00,-5.00,USD,\"rent, May\"\n" +
+		"2024-05-03T00:00:00Z,00000000-0000-0000-0000-000000000002,IN,2.50,-2.50,USD,\n" +
+		"2024-06-01T00:00:00Z,,CLOSING_BALANCE,,-2.50,USD,\n"
+	if got := render(t, FormatCSV, statement()); got != want {
+		t.Errorf("CSV statement =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestWriteOFX(t *testing.T) {
+	out := render(t, FormatOFX, statement())
+	if !strings.Contains(out, `<?OFX OFXHEADER="200" VERSION="220"`) {
+		t.Errorf("OFX statement has no OFX header:\n%v", out)
+	}
+
+	var ofx struct {
+		Currency     string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>CURDEF"`
+		BankId       string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKACCTFROM>BANKID"`
+		AccountId    string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKACCTFROM>ACCTID"`
+		Start        string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKTRANLIST>DTSTART"`
+		Transactions []struct {
+			Type   string `xml:"TRNTYPE"`
+			Posted string `xml:"DTPOSTED"`
+			Amount string `xml:"TRNAMT"`
+			FitId  string `xml:"FITID"`
+		} `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKTRANLIST>STMTTRN"`
+		Balance string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>LEDGERBAL>BALAMT"`
+	}
+	if err := xml.Unmarshal([]byte(out), &ofx); err != nil {
+		t.Fatalf("OFX statement doesn't parse: %v\n%v", err, out)
+	}
+	if ofx.Currency != "USD" || ofx.BankId != "MICROBANK" || ofx.AccountId != "7835697001" || ofx.Start != "20240501000000.000[0:GMT]" ||
+		ofx.Balance != "-2.50" || len(ofx.Transactions) != 2 {
+		t.Fatalf("OFX statement = %+v", ofx)
+	}
+	if trn := ofx.Transactions[0]; trn.Type != "DEBIT" || trn.Amount != "-15.00" || trn.Posted != "20240501010000.000[0:GMT]" ||
+		trn.FitId != "00000000-0000-0000-0000-000000000001" {
+		t.Errorf("first OFX transaction = %+v", trn)
+	}
+	if trn := ofx.Transactions[1]; trn.Type != "CREDIT" || trn.Amount != "2.50" {
+		t.Errorf("second OFX transaction = %+v", trn)
+	}
+}
+
+func TestWriteCamt053(t *testing.T) {
+	out := render(t, FormatCamt053, statement())
+
+	type amount struct {
+		Currency string `xml:"Ccy,attr"`
+		Value    string `xml:",chardata"`
+	}
+	var camt struct {
+		XMLName  xml.Name
+		Id       string `xml:"BkToCstmrStmt>Stmt>Id"`
+		Account  string `xml:"BkToCstmrStmt>Stmt>Acct>Id>Othr>Id"`
+		Balances []struct {
+			Type      string `xml:"Tp>CdOrPrtry>Cd"`
+			Amount    amount `xml:"Amt"`
+			Indicator string `xml:"CdtDbtInd"`
+		} `xml:"BkToCstmrStmt>Stmt>Bal"`
+		Entries []struct {
+			Ref       string `xml:"NtryRef"`
+			Amount    amount `xml:"Amt"`
+			Indicator string `xml:"CdtDbtInd"`
+			Booked    string `xml:"BookgDt>DtTm"`
+			Info      string `xml:"NtryDtls>TxDtls>RmtInf>Ustrd"`
+		} `xml:"BkToCstmrStmt>Stmt>Ntry"`
+	}
+	if err := xml.Unmarshal([]byte(out), &camt); err != nil {
+		t.Fatalf("camt.053 statement doesn't parse: %v\n%v", err, out)
+	}
+	if camt.XMLName.Space != CamtNamespace || camt.Id != statement().Id || camt.Account != "7835697001" {
+		t.Errorf("camt.053 statement = %+v", camt)
+	}
+	if len(camt.Balances) != 2 || camt.Balances[0].Type != "OPBD" || camt.Balances[0].Amount.Value != "10.00" || camt.Balances[0].Indicator != "CRDT" ||
+		camt.Balances[1].Type != "CLBD" || camt.Balances[1].Amount.Value != "2.50" || camt.Balances[1].Indicator != "DBIT" {
+		t.Errorf("camt.053 balances = %+v, want OPBD 10.00 CRDT and CLBD 2.50 DBIT", camt.Balances)
+	}
+	if len(camt.Entries) != 2 {
+		t.Fatalf("camt.053 entries = %+v, want 2", camt.Entries)
+	}
+	if e := camt.Entries[0]; e.Ref != "00000000000000000000000000000001" || e.Amount != (amount{"USD", "15.00"}) || e.Indicator != "DBIT" ||
+		e.Booked != "2024-05-01T01:00:00Z" || e.Info != "rent, May" {
+		t.Errorf("first camt.053 entry = %+v", e)
+	}
+	if e := camt.Entries[1]; e.Amount.Value != "2.50" || e.Indicator != "CRDT" || e.Info != "" {
+		t.Errorf("second camt.053 entry = %+v", e)
+	}
+}
+
+func TestNewWriterInvalidFormat(t *testing.T) {
+	if _, err := NewWriter("PDF", &strings.Builder{}); err != ErrFormatInvalid {
+		t.Errorf("NewWriter(PDF): err = %v, want ErrFormatInvalid", err)
+	}
+}
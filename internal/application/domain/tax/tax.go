@@ -0,0 +1,252 @@
+// Package domain defines the final income tax (PPh) withheld from the
+// interest accounts earn. When interest is posted to an account, the tax rule
+// of its currency in force that day decides what is withheld: nothing for an
+// exempt customer or one whose balance is at or below the threshold of the
+// rule, the rate of the rule on the whole interest otherwise. The balance of
+// a customer is the balances of its accounts in the currency added up; an
+// account without a customer id is a customer of its own. The tax is debited
+// from the account and credited to the tax payable account of the rule,
+// which the bank pays the tax office from.
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// What happened to the tax of an interest posting.
+const (
+	StatusWithheld       string = "WITHHELD"
+	StatusExempt         string = "EXEMPT"
+	StatusBelowThreshold string = "BELOW_THRESHOLD"
+	// StatusNoRule is an interest posting in a currency without a rule.
+	StatusNoRule string = "NO_RULE"
+)
+
+var ErrRuleInvalid = errors.New("a tax rule needs a currency, an effective date, a rate from 0 to 100, a non-negative threshold and a payable account in its currency")
+
+// TaxRuleOrm withholds Rate percent of the interest of the accounts in
+// Currency whose customer's balance is above MinBalance, from EffectiveFrom
+// until the next rule of the currency, and credits it to PayableAccountUuid.
+type TaxRuleOrm struct {
+	Currency           string    `gorm:"primaryKey"`
+	EffectiveFrom      time.Time `gorm:"primaryKey"`
+	Rate               float64
+	MinBalance         float64
+	PayableAccountUuid uuid.UUID
+	CreatedAt          time.Time
+}
+
+func (TaxRuleOrm) TableName() string {
+	return "bank_tax_rules"
+}
+
+// RuleDetail is a rule with its payable account.
+type RuleDetail struct {
+	Rule           TaxRuleOrm
+	PayableAccount domainBank.BankAccountOrm
+}
+
+// WithholdingOrm records the tax of one interest posting, withheld or not,
+// for the tax reports.
+type WithholdingOrm struct {
+	WithholdingUuid         uuid.UUID `gorm:"primaryKey"`
+	AccountUuid             uuid.UUID
+	InterestTransactionUuid uuid.UUID
+	// TaxTransactionUuid and PayableTransactionUuid are the debit of the
+	// account and the credit of the payable account, nil when nothing was
+	// withheld.
+	TaxTransactionUuid     *uuid.UUID
+	PayableTransactionUuid *uuid.UUID
+	Status                 string
+	// Balance is the balance of the customer of the account before the
+	// interest.
+	Balance        float64
+	InterestAmount float64
+	Rate           float64
+	TaxAmount      float64
+	WithheldAt     time.Time
+}
+
+func (WithholdingOrm) TableName() string {
+	return "bank_tax_withholdings"
+}
+
+// Withholding is what the store needs to withhold the tax of an interest
+// posting. Rule is nil when the currency has none; PayableAccount, and the
+// transactions of the tax, are only used when something is withheld. The
+// store sets Exempt, from the customer of the account.
+type Withholding struct {
+	Rule               *TaxRuleOrm
+	Exempt             bool
+	PayableAccount     domainBank.BankAccountOrm
+	WithholdingUuid    uuid.UUID
+	TaxTransaction     domainBank.BankTransactionOrm
+	PayableTransaction domainBank.BankTransactionOrm
+}
+
+// Compute returns the status, rate and tax of interest posted to an account
+// whose customer had balance before it.
+func (w Withholding) Compute(balance float64, interest float64) (status string, rate float64, tax float64) {
+	switch {
+	case w.Rule == nil:
+		return StatusNoRule, 0, 0
+	case w.Exempt:
+		return StatusExempt, 0, 0
+	case balance <= w.Rule.MinBalance:
+		return StatusBelowThreshold, 0, 0
+	}
+
+	return StatusWithheld, w.Rule.Rate, math.Round(interest*w.Rule.Rate) / 100
+}
+
+// Record is the row of the posting of interest by interestTrx.
+func (w Withholding) Record(interestTrx domainBank.BankTransactionOrm, balance float64) WithholdingOrm {
+	status, rate, tax := w.Compute(balance, interestTrx.Amount)
+	record := WithholdingOrm{
+		WithholdingUuid:         w.WithholdingUuid,
+		AccountUuid:             interestTrx.AccountUuid,
+		InterestTransactionUuid: interestTrx.TransactionUuid,
+		Status:                  status,
+		Balance:                 balance,
+		InterestAmount:          interestTrx.Amount,
+		Rate:                    rate,
+		TaxAmount:               tax,
+		WithheldAt:              interestTrx.TransactionTimestamp,
+	}
+	if tax > 0 {
+		taxId, payableId := w.TaxTransaction.TransactionUuid, w.PayableTransaction.TransactionUuid
+		record.TaxTransactionUuid = &taxId
+		record.PayableTransactionUuid = &payableId
+	}
+
+	return record
+}
+
+// RuleAt is the rule of currency in force on day.
+func RuleAt(rules []TaxRuleOrm, currency string, day time.Time) (TaxRuleOrm, bool) {
+	var found TaxRuleOrm
+	ok := false
+	for _, r := range rules {
+		if r.Currency == currency && !r.EffectiveFrom.After(day) && (!ok || r.EffectiveFrom.After(found.EffectiveFrom)) {
+			found, ok = r, true
+		}
+	}
+
+	return found, ok
+}
+
+// ReportRow sums the interest postings of one customer in one currency in a
+// month, with the accounts they were posted to by account number.
+// CustomerId is empty for an account that is a customer of its own.
+type ReportRow struct {
+	CustomerId     string
+	Currency       string
+	Accounts       []domainBank.BankAccountOrm
+	Postings       int
+	InterestAmount float64
+	TaxAmount      float64
+}
+
+// Summarize sums withholdings by customer and currency, ordered by customer
+// id and then by the first account number. accounts has to hold the account
+// of every withholding.
+func Summarize(withholdings []WithholdingOrm, accounts map[uuid.UUID]domainBank.BankAccountOrm) []ReportRow {
+	type customerKey struct {
+		customer string
+		currency string
+	}
+
+	byCustomer := map[customerKey]*ReportRow{}
+	for _, w := range withholdings {
+		account := accounts[w.AccountUuid]
+		key := customerKey{customer: account.CustomerId, currency: account.Currency}
+		if key.customer == "" {
+			key.customer = "account " + account.AccountUuid.String()
+		}
+
+		row, ok := byCustomer[key]
+		if !ok {
+			row = &ReportRow{CustomerId: account.CustomerId, Currency: account.Currency}
+			byCustomer[key] = row
+		}
+		if !slices.ContainsFunc(row.Accounts, func(a domainBank.BankAccountOrm) bool { return a.AccountUuid == account.AccountUuid }) {
+			row.Accounts = append(row.Accounts, account)
+		}
+		row.Postings++
+		row.InterestAmount = math.Round((row.InterestAmount+w.InterestAmount)*100) / 100
+		row.TaxAmount = math.Round((row.TaxAmount+w.TaxAmount)*100) / 100
+	}
+
+	rows := make([]ReportRow, 0, len(byCustomer))
+	for _, row := range byCustomer {
+		sort.Slice(row.Accounts, func(i, j int) bool {
+			return row.Accounts[i].AccountNumber < row.Accounts[j].AccountNumber
+		})
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].CustomerId != rows[j].CustomerId {
+			return rows[i].CustomerId < rows[j].CustomerId
+		}
+		return rows[i].Accounts[0].AccountNumber < rows[j].Accounts[0].AccountNumber
+	})
+
+	return rows
+}
+
+// AccountNumbers is the numbers of the accounts of r, separated by spaces.
+func (r ReportRow) AccountNumbers() string {
+	numbers := make([]string, 0, len(r.Accounts))
+	for _, a := range r.Accounts {
+		numbers = append(numbers, a.AccountNumber)
+	}
+
+	return strings.Join(numbers, " ")
+}
+
+// ReportHeader is the header of the CSV of WriteReport.
+var ReportHeader = []string{"period", "customer_id", "account_numbers", "currency", "postings", "gross_interest", "tax_withheld", "net_interest"}
+
+// WriteReport writes rows, the report of month, as CSV.
+func WriteReport(w io.Writer, month time.Time, rows []ReportRow) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(ReportHeader); err != nil {
+		return err
+	}
+
+	period := month.Format("2006-01")
+	for _, row := range rows {
+		err := out.Write([]string{
+			period,
+			row.CustomerId,
+			row.AccountNumbers(),
+			row.Currency,
+			strconv.Itoa(row.Postings),
+			formatAmount(row.InterestAmount),
+			formatAmount(row.TaxAmount),
+			formatAmount(row.InterestAmount - row.TaxAmount),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func formatAmount(v float64) string {
+	return fmt.Sprintf("%.2f", math.Round(v*100)/100)
+}
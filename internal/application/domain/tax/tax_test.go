@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCompute(t *testing.T) {
+	rule := &TaxRuleOrm{Currency: "IDR", Rate: 20, MinBalance: 7500000}
+
+	for _, tt := range []struct {
+		name       string
+		w          Withholding
+		balance    float64
+		wantStatus string
+		wantTax    float64
+	}{
+		{"above the threshold", Withholding{Rule: rule}, 7500000.01, StatusWithheld, 12345.67},
+		{"at the threshold", Withholding{Rule: rule}, 7500000, StatusBelowThreshold, 0},
+		{"exempt", Withholding{Rule: rule, Exempt: true}, 1e9, StatusExempt, 0},
+		{"no rule", Withholding{}, 1e9, StatusNoRule, 0},
+	} {
+		status, _, tax := tt.w.Compute(tt.balance, 61728.37)
+		if status != tt.wantStatus || tax != tt.wantTax {
+			t.Errorf("%s: Compute = %v, %v; want %v, %v", tt.name, status, tax, tt.wantStatus, tt.wantTax)
+		}
+	}
+}
+
+func TestRuleAt(t *testing.T) {
+	rules := []TaxRuleOrm{
+		{Currency: "IDR", EffectiveFrom: date(time.January, 1), Rate: 20},
+		{Currency: "IDR", EffectiveFrom: date(time.July, 1), Rate: 15},
+		{Currency: "USD", EffectiveFrom: date(time.March, 1), Rate: 10},
+	}
+
+	for _, tt := range []struct {
+		currency string
+		day      time.Time
+		found    bool
+		want     float64
+	}{
+		{"IDR", date(time.June, 30), true, 20},
+		{"IDR", date(time.July, 1), true, 15},
+		{"USD", date(time.February, 28), false, 0},
+		{"EUR", date(time.July, 1), false, 0},
+	} {
+		rule, found := RuleAt(rules, tt.currency, tt.day)
+		if found != tt.found || rule.Rate != tt.want {
+			t.Errorf("rule of %v on %v = %v, %v; want %v, %v", tt.currency, tt.day.Format(time.DateOnly), rule.Rate, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	// kate's accounts are one customer, riri's savings a customer of its own
+	kate := domainBank.BankAccountOrm{AccountUuid: uuid.New(), AccountNumber: "3", CustomerId: "Kate, Jr.", Currency: "IDR"}
+	kateSavings := domainBank.BankAccountOrm{AccountUuid: uuid.New(), AccountNumber: "2", CustomerId: "Kate, Jr.", Currency: "IDR"}
+	kateDollars := domainBank.BankAccountOrm{AccountUuid: uuid.New(), AccountNumber: "4", CustomerId: "Kate, Jr.", Currency: "USD"}
+	riri := domainBank.BankAccountOrm{AccountUuid: uuid.New(), AccountNumber: "1", Currency: "IDR"}
+	rows := Summarize([]WithholdingOrm{
+		{AccountUuid: kate.AccountUuid, InterestAmount: 100.1, TaxAmount: 20.02},
+		{AccountUuid: riri.AccountUuid, InterestAmount: 5},
+		{AccountUuid: kateSavings.AccountUuid, InterestAmount: 0.2, TaxAmount: 0.04},
+		{AccountUuid: kateDollars.AccountUuid, InterestAmount: 1, TaxAmount: 0.2},
+		{AccountUuid: kate.AccountUuid, InterestAmount: 0.1},
+	}, map[uuid.UUID]domainBank.BankAccountOrm{kate.AccountUuid: kate, kateSavings.AccountUuid: kateSavings, kateDollars.AccountUuid: kateDollars,
+		riri.AccountUuid: riri})
+
+	var out bytes.Buffer
+	if err := WriteReport(&out, date(time.May, 1), rows); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+
+	want := "period,customer_id,account_numbers,currency,postings,gross_interest,tax_withheld,net_interest\n" +
+		"2024-05,,1,IDR,1,5.00,0.00,5.00\n" +
+		"2024-05,\"Kate, Jr.\",2 3,IDR,3,100.40,20.06,80.34\n" +
+		"2024-05,\"Kate, Jr.\",4,USD,1,1.00,0.20,0.80\n"
+	if out.String() != want {
+		t.Errorf("WriteReport wrote\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the signature of a delivery, see Sign.
+const SignatureHeader = "Bank-Signature"
+
+// These headers identify a delivery. A receiver skips event IDs it already
+// processed, every delivery is sent at least once.
+const (
+	EventIDHeader    = "Bank-Event-Id"
+	EventTypeHeader  = "Bank-Event-Type"
+	DeliveryIDHeader = "Bank-Delivery-Id"
+)
+
+var ErrSignatureInvalid = errors.New("webhook signature does not match")
+var ErrSignatureExpired = errors.New("webhook signature timestamp is outside the tolerance")
+
+// Sign returns the SignatureHeader value for body sent at ts:
+//
+//	t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>" keyed with secret>
+//
+// The timestamp is part of the signed content, so a receiver that rejects old
+// timestamps can't be sent a captured request again.
+func Sign(secret string, ts time.Time, body []byte) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+
+	return "t=" + unix + ",v1=" + signature(secret, unix, body)
+}
+
+// Verify checks a SignatureHeader value against body and rejects timestamps
+// more than tolerance away from now.
+func Verify(secret string, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var unix string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return fmt.Errorf("%w : malformed header %q", ErrSignatureInvalid, header)
+	}
+
+	expected := signature(secret, unix, body)
+	matched := false
+	for _, s := range signatures {
+		if hmac.Equal([]byte(s), []byte(expected)) {
+			matched = true
+		}
+	}
+	if !matched {
+		return ErrSignatureInvalid
+	}
+
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+func signature(secret string, unix string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignature(t *testing.T) {
+	secret := "whsec_0123456789abcdef"
+	body := []byte(`{"type":"TransactionCreated"}`)
+	sentAt := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+
+	header := Sign(secret, sentAt, body)
+	// pins the algorithm, receivers implement it in their own language
+	want := "t=1714557600,v1=54216180f4c7ab9bd238ac406cf33b8c84958dfe65def4341425d9f538f31aa0"
+	if header != want {
+		t.Fatalf("Sign = %q, want %q", header, want)
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		body   string
+		now    time.Time
+		want   error
+	}{
+		{"valid", secret, header, string(body), sentAt.Add(time.Minute), nil},
+		{"rotated secret listed first", secret, "t=1714557600,v1=00ff," + header[len("t=1714557600,"):], string(body), sentAt, nil},
+		{"other secret", "whsec_other", header, string(body), sentAt, ErrSignatureInvalid},
+		{"changed body", secret, header, `{"type":"TransferFailed"}`, sentAt, ErrSignatureInvalid},
+		{"changed timestamp", secret, "t=1714557601" + header[len("t=1714557600"):], string(body), sentAt, ErrSignatureInvalid},
+		{"replayed", secret, header, string(body), sentAt.Add(6 * time.Minute), ErrSignatureExpired},
+		{"from the future", secret, header, string(body), sentAt.Add(-6 * time.Minute), ErrSignatureExpired},
+		{"malformed", secret, "v1=abc", string(body), sentAt, ErrSignatureInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.secret, tt.header, []byte(tt.body), tt.now, 5*time.Minute); !errors.Is(err, tt.want) {
+				t.Errorf("Verify: error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
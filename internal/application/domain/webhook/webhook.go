@@ -0,0 +1,110 @@
+// Package domain defines the webhook subscriptions of accounts and customers
+// and the deliveries of events to them. Every delivery is an HTTP POST of the event
+// envelope, signed with the secret of the subscription, see Sign.
+package domain
+
+import (
+	"errors"
+	"time"
+
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/google/uuid"
+)
+
+const (
+	// StatePending deliveries are sent when NextAttemptAt is reached.
+	StatePending   = "PENDING"
+	StateDelivered = "DELIVERED"
+	// StateDead deliveries ran out of attempts and are only sent again on
+	// request.
+	StateDead = "DEAD"
+)
+
+// EventTypes are the events that concern an account and can be subscribed
+// to. Money arriving in an account is a TransactionCreated of type IN, for
+// deposits and for the incoming leg of transfers alike.
+var EventTypes = []string{
+	domainEvent.TypeTransactionCreated,
+	domainEvent.TypeTransferCreated,
+	domainEvent.TypeTransferCompleted,
+	domainEvent.TypeTransferFailed,
+	domainEvent.TypeTransferReversed,
+}
+
+var ErrInvalidURL = errors.New("webhook url must be an absolute http or https url")
+var ErrUnknownEventType = errors.New("unknown webhook event type")
+var ErrSecretTooShort = errors.New("webhook secret must be at least 16 characters")
+var ErrScopeInvalid = errors.New("webhook subscription must be for either an account or a customer")
+
+// WebhookSubscriptionOrm is for the events of one account, AccountUuid is
+// set, or for those of every account of a customer, CustomerId is set.
+type WebhookSubscriptionOrm struct {
+	SubscriptionUuid uuid.UUID `gorm:"primaryKey"`
+	AccountUuid      *uuid.UUID
+	AccountNumber    string
+	CustomerId       string
+	Url              string
+	EventTypes       []string `gorm:"serializer:json"`
+	Secret           string
+	CreatedAt        time.Time
+}
+
+func (WebhookSubscriptionOrm) TableName() string {
+	return "bank_webhook_subscriptions"
+}
+
+// Subscribes reports whether events of eventType are sent to s.
+func (s WebhookSubscriptionOrm) Subscribes(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookDeliveryOrm is one event sent to one subscription. Payload is the
+// JSON envelope of the event and the body of every attempt.
+type WebhookDeliveryOrm struct {
+	DeliveryUuid     uuid.UUID `gorm:"primaryKey"`
+	SubscriptionUuid uuid.UUID
+	EventUuid        uuid.UUID
+	EventType        string
+	Payload          string
+	State            string
+	AttemptCount     int
+	NextAttemptAt    time.Time
+	LastError        string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+
+	Subscription WebhookSubscriptionOrm `gorm:"foreignKey:SubscriptionUuid;references:SubscriptionUuid"`
+	Attempts     []WebhookAttemptOrm    `gorm:"foreignKey:DeliveryUuid;references:DeliveryUuid"`
+}
+
+func (WebhookDeliveryOrm) TableName() string {
+	return "bank_webhook_deliveries"
+}
+
+type WebhookAttemptOrm struct {
+	AttemptId    int64 `gorm:"primaryKey;autoIncrement"`
+	DeliveryUuid uuid.UUID
+	AttemptedAt  time.Time
+	// StatusCode is 0 when no response was received.
+	StatusCode int
+	Error      string
+	DurationMs int64
+}
+
+func (WebhookAttemptOrm) TableName() string {
+	return "bank_webhook_attempts"
+}
+
+// DeliveryFilter selects deliveries for ListWebhookDeliveries, zero fields
+// match every delivery.
+type DeliveryFilter struct {
+	SubscriptionUuid uuid.UUID
+	State            string
+	Limit            int
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+)
+
+// EventRelay moves events from the outbox to the publisher. An event is
+// marked published only after the publisher accepted it, so a crash or a
+// failed mark publishes it again: delivery is at least once, in outbox order.
+type EventRelay struct {
+	outbox    port.OutboxPort
+	publisher port.EventPublisherPort
+	clock     clock.Clock
+	batchSize int
+}
+
+func NewEventRelay(outbox port.OutboxPort, publisher port.EventPublisherPort, clk clock.Clock, batchSize int) *EventRelay {
+	return &EventRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		clock:     clk,
+		batchSize: batchSize,
+	}
+}
+
+// Run relays the pending events every interval until ctx is done.
+func (r *EventRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := r.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Event relay stopped")
+			return
+		case <-ticker.C():
+		}
+
+		if _, err := r.Drain(ctx); err != nil && ctx.Err() == nil {
+			logErr := util.LogError(err.Error(), "", "EventRelay - Run")
+			log.Error().Msg(logErr)
+		}
+	}
+}
+
+// Drain relays batches until the outbox is empty and returns how many events
+// were published. It stops at the first event the publisher rejects, so later
+// events don't overtake it.
+func (r *EventRelay) Drain(ctx context.Context) (int, error) {
+	published := 0
+
+	for {
+		events, err := r.outbox.PendingEvents(ctx, r.batchSize)
+		if err != nil {
+			return published, fmt.Errorf("can't read the outbox : %v", err)
+		}
+
+		if len(events) == 0 {
+			metrics.OutboxLag.Set(0)
+			return published, nil
+		}
+		metrics.OutboxLag.Set(r.clock.Now().Sub(events[0].OccurredAt).Seconds())
+
+		for _, e := range events {
+			if err := r.publisher.Publish(ctx, e); err != nil {
+				metrics.EventPublishFailures.WithLabelValues(e.Type).Inc()
+				return published, fmt.Errorf("can't publish %v event %v : %v", e.Type, e.ID, err)
+			}
+			metrics.EventsPublished.WithLabelValues(e.Type).Inc()
+
+			if err := r.outbox.MarkEventPublished(ctx, e.ID, r.clock.Now()); err != nil {
+				return published, fmt.Errorf("can't mark event %v published : %v", e.ID, err)
+			}
+			published++
+		}
+
+		if len(events) < r.batchSize {
+			metrics.OutboxLag.Set(0)
+			return published, nil
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/memory"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port/porttest"
+)
+
+// flakyPublisher rejects the calls listed in failOn, counted from 1.
+type flakyPublisher struct {
+	mu     sync.Mutex
+	calls  int
+	failOn map[int]bool
+	events []domainEvent.Event
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, e domainEvent.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+	if p.failOn[p.calls] {
+		return errors.New("broker unavailable")
+	}
+	p.events = append(p.events, e)
+
+	return nil
+}
+
+func (p *flakyPublisher) Close() error {
+	return nil
+}
+
+func (p *flakyPublisher) published() []domainEvent.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]domainEvent.Event(nil), p.events...)
+}
+
+func TestEventRelayDrain(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC))
+	store := memory.NewMemoryAdapter()
+	bs := application.NewBankService(store, clk)
+
+	acc := porttest.NewAccount(0)
+	if err := store.Seed(acc); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		trx := domainBank.Transaction{Amount: float64(i + 1), TransactionType: domainBank.TransactionTypeIn}
+		if _, err := bs.CreateTransaction(ctx, acc.AccountNumber, trx); err != nil {
+			t.Fatalf("CreateTransaction: %v", err)
+		}
+	}
+	want, _ := store.PendingEvents(ctx, 10)
+
+	publisher := &flakyPublisher{failOn: map[int]bool{3: true}}
+	relay := application.NewEventRelay(store, publisher, clk, 2)
+
+	// the third event is rejected, nothing after it may go out
+	n, err := relay.Drain(ctx)
+	if err == nil || n != 2 {
+		t.Fatalf("first Drain = %d, %v; want 2 and the publish error", n, err)
+	}
+	if pending, _ := store.PendingEvents(ctx, 10); len(pending) != 3 || pending[0].ID != want[2].ID {
+		t.Fatalf("after the failed publish %d events are pending, want the last 3", len(pending))
+	}
+
+	n, err = relay.Drain(ctx)
+	if err != nil || n != 3 {
+		t.Fatalf("second Drain = %d, %v; want 3, nil", n, err)
+	}
+
+	got := publisher.published()
+	if len(got) != len(want) {
+		t.Fatalf("published %d events, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID {
+			t.Errorf("event %d is %v, want %v", i, got[i].ID, want[i].ID)
+		}
+	}
+	if pending, _ := store.PendingEvents(ctx, 10); len(pending) != 0 {
+		t.Errorf("%d events still pending", len(pending))
+	}
+}
+
+func TestEventRelayRun(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC))
+	store := memory.NewMemoryAdapter()
+	publisher := &flakyPublisher{}
+	relay := application.NewEventRelay(store, publisher, clk, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx, time.Second)
+	}()
+
+	r := porttest.NewExchangeRate("USD", "IDR", 15000, clk.Now(), clk.Now().Add(time.Minute))
+	if _, err := store.InsertExchangeRate(ctx, r); err != nil {
+		t.Fatalf("InsertExchangeRate: %v", err)
+	}
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Second)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(publisher.published()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no event published after a relay tick")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if e := publisher.published()[0]; e.Type != domainEvent.TypeExchangeRateCreated || e.AggregateID != r.ExchangeRateUuid {
+		t.Errorf("published %v of %v, want ExchangeRateCreated of %v", e.Type, e.AggregateID, r.ExchangeRateUuid)
+	}
+
+	cancel()
+	<-done
+}
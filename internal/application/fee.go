@@ -0,0 +1,175 @@
+package application
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// FeeService keeps the fee schedules of transfers and the customer segments
+// of accounts. BankService charges the fees.
+type FeeService struct {
+	store port.FeeStorePort
+	db    port.BankDatabasePort
+	clock clock.Clock
+	audit *Auditor
+}
+
+func NewFeeService(store port.FeeStorePort, dbPort port.BankDatabasePort, clk clock.Clock) *FeeService {
+	return &FeeService{
+		store: store,
+		db:    dbPort,
+		clock: clk,
+		audit: auditorOf(store, clk),
+	}
+}
+
+// SetFeeSchedule creates the schedule of the currency, channel and segment
+// of schedule, or replaces it and its tiers. The fees are credited to
+// feeAccountNum.
+func (s *FeeService) SetFeeSchedule(ctx context.Context, schedule domainFee.Schedule, feeAccountNum string) (detail domainFee.ScheduleDetail, err error) {
+	ctx, span := tracing.Start(ctx, "FeeService.SetFeeSchedule")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "SetFeeSchedule")
+	defer s.audit.End(ctx, scope, &err)
+
+	if err := normalizeSchedule(&schedule); err != nil {
+		return detail, err
+	}
+
+	feeAccount, err := s.db.GetDetailBankAccountByAccountNumber(ctx, feeAccountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Fee Service - SetFeeSchedule")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return detail, err
+	}
+	auditAffect(ctx, feeAccount.AccountUuid)
+
+	schedules, err := s.store.ListFeeSchedules(ctx)
+	if err != nil {
+		logErr := util.LogError("Error on ListFeeSchedules: "+err.Error(), "", "Fee Service - SetFeeSchedule")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return detail, err
+	}
+
+	now := s.clock.Now()
+	schedule.ScheduleUuid = uuid.New()
+	schedule.CreatedAt = now
+	for _, stored := range schedules {
+		if stored.Currency == schedule.Currency && stored.Channel == schedule.Channel && stored.CustomerSegment == schedule.CustomerSegment {
+			schedule.ScheduleUuid = stored.ScheduleUuid
+			schedule.CreatedAt = stored.CreatedAt
+		}
+	}
+	schedule.UpdatedAt = now
+	schedule.FeeAccountUuid = feeAccount.AccountUuid
+	for i := range schedule.Tiers {
+		schedule.Tiers[i].ScheduleUuid = schedule.ScheduleUuid
+	}
+	auditAffect(ctx, schedule.ScheduleUuid)
+
+	if err := s.store.SaveFeeSchedule(ctx, schedule); err != nil {
+		logErr := util.LogError("Error on SaveFeeSchedule: "+err.Error(), "", "Fee Service - SetFeeSchedule")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return detail, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Fee schedule of %v, channel %q, segment %q set", schedule.Currency, schedule.Channel, schedule.CustomerSegment)
+
+	return domainFee.ScheduleDetail{Schedule: schedule, FeeAccount: feeAccount}, nil
+}
+
+func normalizeSchedule(schedule *domainFee.Schedule) error {
+	schedule.Currency = strings.ToUpper(strings.TrimSpace(schedule.Currency))
+	schedule.Channel = strings.ToUpper(strings.TrimSpace(schedule.Channel))
+	schedule.CustomerSegment = strings.ToUpper(strings.TrimSpace(schedule.CustomerSegment))
+	schedule.MinFee = math.Round(schedule.MinFee*100) / 100
+	schedule.MaxFee = math.Round(schedule.MaxFee*100) / 100
+	if schedule.Currency == "" || len(schedule.Tiers) == 0 || schedule.MinFee < 0 || schedule.MaxFee < 0 ||
+		(schedule.MaxFee > 0 && schedule.MaxFee < schedule.MinFee) || schedule.FreePerMonth < 0 {
+		return domainFee.ErrScheduleInvalid
+	}
+
+	seen := map[float64]bool{}
+	for i := range schedule.Tiers {
+		t := &schedule.Tiers[i]
+		t.MinAmount = math.Round(t.MinAmount*100) / 100
+		t.FlatAmount = math.Round(t.FlatAmount*100) / 100
+		t.Rate = math.Round(t.Rate*1e4) / 1e4
+		if t.MinAmount < 0 || t.FlatAmount < 0 || t.Rate < 0 || t.Rate > 100 || seen[t.MinAmount] {
+			return domainFee.ErrScheduleInvalid
+		}
+		seen[t.MinAmount] = true
+	}
+	domainFee.SortTiers(schedule.Tiers)
+
+	return nil
+}
+
+// ListFeeSchedules returns the schedules by currency, channel and segment.
+func (s *FeeService) ListFeeSchedules(ctx context.Context) (details []domainFee.ScheduleDetail, err error) {
+	ctx, span := tracing.Start(ctx, "FeeService.ListFeeSchedules")
+	defer tracing.End(span, &err)
+
+	schedules, err := s.store.ListFeeSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	details = make([]domainFee.ScheduleDetail, 0, len(schedules))
+	feeAccounts := map[uuid.UUID]domainBank.BankAccountOrm{}
+	for _, schedule := range schedules {
+		feeAccount, ok := feeAccounts[schedule.FeeAccountUuid]
+		if !ok {
+			feeAccount, err = s.db.GetDetailBankAccountByUuid(ctx, schedule.FeeAccountUuid)
+			if err != nil {
+				logErr := util.LogError("Error on GetDetailBankAccountByUuid: "+err.Error(), "", "Fee Service - ListFeeSchedules")
+				log.Error().Ctx(ctx).Msg(logErr)
+				return nil, err
+			}
+			feeAccounts[schedule.FeeAccountUuid] = feeAccount
+		}
+		details = append(details, domainFee.ScheduleDetail{Schedule: schedule, FeeAccount: feeAccount})
+	}
+
+	return details, nil
+}
+
+// SetCustomerSegment moves accountNum to segment, "" to none. Its next
+// transfers are priced by the schedules of the segment.
+func (s *FeeService) SetCustomerSegment(ctx context.Context, accountNum string, segment string) (account domainBank.BankAccountOrm, err error) {
+	ctx, span := tracing.Start(ctx, "FeeService.SetCustomerSegment")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "SetCustomerSegment")
+	defer s.audit.End(ctx, scope, &err)
+
+	account, err = s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Fee Service - SetCustomerSegment")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return account, err
+	}
+	auditAffect(ctx, account.AccountUuid)
+
+	account.CustomerSegment = strings.ToUpper(strings.TrimSpace(segment))
+	account.UpdatedAt = s.clock.Now()
+
+	if err := s.store.SetCustomerSegment(ctx, account.AccountUuid, account.CustomerSegment, account.UpdatedAt); err != nil {
+		logErr := util.LogError("Error on SetCustomerSegment: "+err.Error(), "", "Fee Service - SetCustomerSegment")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return account, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Customer segment of %v set to %q", accountNum, account.CustomerSegment)
+
+	return account, nil
+}
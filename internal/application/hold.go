@@ -0,0 +1,189 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultHoldTTL = 7 * 24 * time.Hour
+	maxHoldTTL     = 30 * 24 * time.Hour
+)
+
+// CreateHold reserves h.Amount of accountNum, which stops being available to
+// spend until the hold is captured, released or expires.
+func (s *BankService) CreateHold(ctx context.Context, accountNum string, h domainBank.Hold) (hold domainBank.BankHoldOrm, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.CreateHold")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "CreateHold")
+	defer s.audit.End(ctx, scope, &err)
+
+	now := s.clock.Now()
+	expiresAt := h.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(defaultHoldTTL)
+	}
+	amount := math.Round(h.Amount*100) / 100
+	if amount <= 0 || !expiresAt.After(now) || expiresAt.After(now.Add(maxHoldTTL)) {
+		return hold, domainBank.ErrHoldInvalid
+	}
+
+	account, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Bank Service - CreateHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return hold, err
+	}
+	auditAffect(ctx, account.AccountUuid)
+
+	hold = domainBank.BankHoldOrm{
+		HoldUuid:      uuid.New(),
+		AccountUuid:   account.AccountUuid,
+		AccountNumber: account.AccountNumber,
+		Amount:        amount,
+		Status:        domainBank.HoldStatusActive,
+		Notes:         h.Notes,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	standing, err := s.accountStanding(ctx, account, now)
+	if err != nil {
+		return domainBank.BankHoldOrm{}, err
+	}
+	if err := standing.Limits.Check(account.AccountNumber, standing.Available, amount); err != nil {
+		metrics.InsufficientBalance.WithLabelValues("hold").Inc()
+		return domainBank.BankHoldOrm{}, err
+	}
+
+	// the store checks again, against the holds placed since
+	if err := s.db.CreateHold(ctx, hold, standing.Limits.Floor()); err != nil {
+		if errors.Is(err, domainBank.ErrInsufficientBalance) {
+			metrics.InsufficientBalance.WithLabelValues("hold").Inc()
+		}
+		logErr := util.LogError("Error on CreateHold: "+err.Error(), "", "Bank Service - CreateHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainBank.BankHoldOrm{}, err
+	}
+	auditAffect(ctx, hold.HoldUuid)
+
+	log.Info().Ctx(ctx).Msgf("Hold %v of %v placed on %v until %v", hold.HoldUuid, amount, accountNum, expiresAt)
+
+	return hold, nil
+}
+
+func (s *BankService) GetHold(ctx context.Context, holdUuid uuid.UUID) (hold domainBank.BankHoldOrm, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.GetHold")
+	defer tracing.End(span, &err)
+
+	return s.db.GetHold(ctx, holdUuid)
+}
+
+// CaptureHold debits amount of a hold from its account, everything left on
+// the hold when amount is zero. The hold stays active until all of it is
+// captured.
+func (s *BankService) CaptureHold(ctx context.Context, holdUuid uuid.UUID, amount float64, notes string) (hold domainBank.BankHoldOrm, trxUuid uuid.UUID, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.CaptureHold")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "CaptureHold")
+	defer s.audit.End(ctx, scope, &err)
+	auditAffect(ctx, holdUuid)
+
+	rounded := math.Round(amount*100) / 100
+	if amount < 0 || (amount > 0 && rounded == 0) {
+		return hold, uuid.Nil, domainBank.ErrHoldInvalid
+	}
+
+	now := s.clock.Now()
+	hold, err = s.db.GetHold(ctx, holdUuid)
+	if err != nil {
+		logErr := util.LogError("Error on GetHold: "+err.Error(), "", "Bank Service - CaptureHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return hold, uuid.Nil, err
+	}
+	if hold.StatusAt(now) != domainBank.HoldStatusActive {
+		return hold, uuid.Nil, domainBank.ErrHoldNotActive
+	}
+
+	left := hold.RemainingAmount()
+	switch {
+	case rounded == 0:
+		rounded = left
+	case rounded > left:
+		return hold, uuid.Nil, fmt.Errorf("%w: %v requested, %v left", domainBank.ErrHoldExceeded, rounded, left)
+	}
+
+	account, err := s.db.GetDetailBankAccountByUuid(ctx, hold.AccountUuid)
+	if err != nil {
+		return hold, uuid.Nil, err
+	}
+	auditAffect(ctx, account.AccountUuid)
+
+	if notes == "" {
+		notes = fmt.Sprintf("Capture of hold %v", hold.HoldUuid)
+	}
+	trx := domainBank.BankTransactionOrm{
+		TransactionUuid:      uuid.New(),
+		AccountUuid:          account.AccountUuid,
+		TransactionTimestamp: now,
+		Amount:               rounded,
+		TransactionType:      domainBank.TransactionTypeOut,
+		Notes:                notes,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+
+	hold, err = s.db.CaptureHold(ctx, domainBank.HoldCapture{Hold: hold, Account: account, Transaction: trx})
+	if err != nil {
+		logErr := util.LogError("Error on CaptureHold: "+err.Error(), "", "Bank Service - CaptureHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return hold, uuid.Nil, err
+	}
+	auditAffect(ctx, trx.TransactionUuid)
+
+	s.publishActivity(ctx, account, trx, uuid.Nil, "")
+
+	return hold, trx.TransactionUuid, nil
+}
+
+// ReleaseHold gives back to its account what is left of a hold.
+func (s *BankService) ReleaseHold(ctx context.Context, holdUuid uuid.UUID) (hold domainBank.BankHoldOrm, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.ReleaseHold")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "ReleaseHold")
+	defer s.audit.End(ctx, scope, &err)
+	auditAffect(ctx, holdUuid)
+
+	hold, err = s.db.ReleaseHold(ctx, holdUuid, s.clock.Now())
+	if err != nil {
+		logErr := util.LogError("Error on ReleaseHold: "+err.Error(), "", "Bank Service - ReleaseHold")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return hold, err
+	}
+
+	return hold, nil
+}
+
+// availableBalance is balance, the ledger balance of accountUuid, less what
+// its holds reserve at at.
+func (s *BankService) availableBalance(ctx context.Context, accountUuid uuid.UUID, balance float64, at time.Time) (float64, error) {
+	held, err := s.db.HeldAmount(ctx, accountUuid, at)
+	if err != nil {
+		logErr := util.LogError("Error on HeldAmount: "+err.Error(), "", "Bank Service - availableBalance")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return 0, err
+	}
+
+	return math.Round((balance-held)*100) / 100, nil
+}
@@ -0,0 +1,323 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// InterestService keeps the rates of products and accrues and capitalizes
+// the interest of accounts, less the tax the rules of taxes withhold.
+// Accrual resumes after the last day an account accrued for, so a run after
+// downtime backfills the days missed. Postings go to the activity stream of
+// bank.
+type InterestService struct {
+	store port.InterestStorePort
+	taxes port.TaxStorePort
+	bank  *BankService
+	db    port.BankDatabasePort
+	clock clock.Clock
+	audit *Auditor
+}
+
+func NewInterestService(store port.InterestStorePort, taxes port.TaxStorePort, bank *BankService, dbPort port.BankDatabasePort, clk clock.Clock) *InterestService {
+	return &InterestService{
+		store: store,
+		taxes: taxes,
+		bank:  bank,
+		db:    dbPort,
+		clock: clk,
+		audit: auditorOf(store, clk),
+	}
+}
+
+// Run accrues every interval until ctx is done.
+func (s *InterestService) Run(ctx context.Context, interval time.Duration) {
+	ticker := s.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Accrue(ctx); err != nil && ctx.Err() == nil {
+			logErr := util.LogError(err.Error(), "", "InterestService - Run")
+			log.Error().Msg(logErr)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Interest accrual stopped")
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// Accrue records the interest of every day up to yesterday each account
+// hasn't accrued for yet, of every month that ended for accounts accruing
+// monthly, and capitalizes what accrued in the periods that
+// ended. It returns how many accruals it recorded. An account that fails
+// doesn't stop the others.
+func (s *InterestService) Accrue(ctx context.Context) (recorded int, err error) {
+	ctx, span := tracing.Start(ctx, "InterestService.Accrue")
+	defer tracing.End(span, &err)
+
+	rates, err := s.store.ListInterestRates(ctx, "")
+	if err != nil {
+		return 0, fmt.Errorf("can't read interest rates : %v", err)
+	}
+	versions := map[string][]domainInterest.RateVersion{}
+	for _, v := range domainInterest.Versions(rates) {
+		versions[v.ProductCode] = append(versions[v.ProductCode], v)
+	}
+
+	taxRules, err := s.taxes.ListTaxRules(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't read tax rules : %v", err)
+	}
+
+	accounts, err := s.store.InterestAccounts(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't read the accounts earning interest : %v", err)
+	}
+
+	var errs []error
+	for _, acc := range accounts {
+		n, err := s.accrueAccount(ctx, acc, versions[acc.Account.ProductCode], taxRules)
+		recorded += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("account %v : %w", acc.Account.AccountNumber, err))
+		}
+	}
+
+	return recorded, errors.Join(errs...)
+}
+
+func (s *InterestService) accrueAccount(ctx context.Context, acc domainInterest.InterestAccount, versions []domainInterest.RateVersion, taxRules []domainTax.TaxRuleOrm) (int, error) {
+	if len(versions) == 0 {
+		return 0, nil
+	}
+	today := domainInterest.Day(s.clock.Now())
+	to := domainInterest.AccrualEnd(acc.Product.InterestAccrualFrequency, today)
+
+	last, err := s.store.LastAccrualDate(ctx, acc.Account.AccountUuid)
+	if err != nil {
+		return 0, err
+	}
+	from := domainInterest.Day(acc.Account.CreatedAt)
+	if !last.IsZero() && !last.Before(from) {
+		from = last.AddDate(0, 0, 1)
+	}
+	if from.Before(versions[0].EffectiveFrom) {
+		from = versions[0].EffectiveFrom
+	}
+
+	var accruals []domainInterest.InterestAccrualOrm
+	if from.Before(to) {
+		balances, err := s.store.ClosingBalances(ctx, acc.Account.AccountUuid, from, to)
+		if err != nil {
+			return 0, err
+		}
+
+		now := s.clock.Now()
+		for i, balance := range balances {
+			day := from.AddDate(0, 0, i)
+			version, _ := domainInterest.VersionAt(versions, day)
+			rate := version.RateFor(balance)
+			accruals = append(accruals, domainInterest.InterestAccrualOrm{
+				AccountUuid: acc.Account.AccountUuid,
+				AccrualDate: day,
+				ProductCode: acc.Account.ProductCode,
+				Balance:     balance,
+				AnnualRate:  rate,
+				Amount:      domainInterest.DailyInterest(balance, rate, acc.Product.InterestDayCount, day),
+				CreatedAt:   now,
+			})
+		}
+
+		if err := s.store.SaveInterestAccruals(ctx, accruals); err != nil {
+			return 0, err
+		}
+	}
+
+	// a period ends once its last day has accrued, so nothing is due
+	// without new accruals
+	if len(accruals) == 0 {
+		return 0, nil
+	}
+
+	return len(accruals), s.capitalize(ctx, acc, domainInterest.PeriodStart(acc.Product.InterestCapitalization, today), taxRules)
+}
+
+// capitalize posts what acc accrued before before, less the tax of the rule
+// of its currency in taxRules. Only a capitalization that posted something
+// is audited, most runs find nothing due.
+func (s *InterestService) capitalize(ctx context.Context, acc domainInterest.InterestAccount, before time.Time, taxRules []domainTax.TaxRuleOrm) (err error) {
+	now := s.clock.Now()
+	period := before.AddDate(0, 0, -1).Format(time.DateOnly)
+	withholding := domainTax.Withholding{
+		WithholdingUuid:    uuid.New(),
+		TaxTransaction:     newTransaction(acc.Account.AccountUuid, domainBank.TransactionTypeOut, "Interest tax to "+period, now),
+		PayableTransaction: newTransaction(uuid.Nil, domainBank.TransactionTypeIn, "Interest tax of "+acc.Account.AccountNumber+" to "+period, now),
+	}
+	if rule, ok := domainTax.RuleAt(taxRules, acc.Account.Currency, domainInterest.Day(now)); ok {
+		payable, err := s.db.GetDetailBankAccountByUuid(ctx, rule.PayableAccountUuid)
+		if err != nil {
+			logErr := util.LogError("Error on GetDetailBankAccountByUuid: "+err.Error(), "", "Interest Service - capitalize")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return err
+		}
+		withholding.Rule = &rule
+		withholding.PayableAccount = payable
+		withholding.PayableTransaction.AccountUuid = payable.AccountUuid
+	}
+
+	trx := newTransaction(acc.Account.AccountUuid, domainBank.TransactionTypeIn, "Interest to "+period, now)
+	posting, err := s.store.CapitalizeInterest(ctx, domainInterest.Capitalization{
+		Account:     acc.Account,
+		Before:      before,
+		Transaction: trx,
+		Withholding: withholding,
+	})
+	if err != nil {
+		logErr := util.LogError("Error on CapitalizeInterest: "+err.Error(), "", "Interest Service - capitalize")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+	if posting.Interest == 0 && posting.Tax == 0 {
+		return nil
+	}
+
+	ctx, scope := s.audit.Start(ctx, "CapitalizeInterest")
+	defer s.audit.End(ctx, scope, &err)
+	auditAffect(ctx, acc.Account.AccountUuid)
+
+	if posting.Interest > 0 {
+		metrics.InterestCapitalized.WithLabelValues(acc.Account.Currency).Add(posting.Interest)
+		log.Info().Ctx(ctx).Msgf("Interest of %.2f posted to %v", posting.Interest, acc.Account.AccountNumber)
+		trx.Amount = posting.Interest
+		s.bank.publishActivity(ctx, acc.Account, trx, uuid.Nil, "")
+	}
+	if posting.Tax > 0 {
+		auditAffect(ctx, withholding.PayableAccount.AccountUuid)
+		metrics.TaxWithheld.WithLabelValues(acc.Account.Currency).Add(posting.Tax)
+		log.Info().Ctx(ctx).Msgf("Tax of %.2f withheld from %v", posting.Tax, acc.Account.AccountNumber)
+		withholding.TaxTransaction.Amount = posting.Tax
+		withholding.PayableTransaction.Amount = posting.Tax
+		s.bank.publishActivity(ctx, acc.Account, withholding.TaxTransaction, uuid.Nil, withholding.PayableAccount.AccountNumber)
+		s.bank.publishActivity(ctx, withholding.PayableAccount, withholding.PayableTransaction, uuid.Nil, acc.Account.AccountNumber)
+	}
+
+	return nil
+}
+
+func newTransaction(accountUuid uuid.UUID, trxType string, notes string, at time.Time) domainBank.BankTransactionOrm {
+	return domainBank.BankTransactionOrm{
+		TransactionUuid:      uuid.New(),
+		AccountUuid:          accountUuid,
+		TransactionTimestamp: at,
+		TransactionType:      trxType,
+		Notes:                notes,
+		CreatedAt:            at,
+		UpdatedAt:            at,
+	}
+}
+
+// SetInterestRates saves version, replacing the version of its product
+// effective from the same day. Days already accrued keep the rates they
+// accrued at.
+func (s *InterestService) SetInterestRates(ctx context.Context, version domainInterest.RateVersion) (saved domainInterest.RateVersion, err error) {
+	ctx, span := tracing.Start(ctx, "InterestService.SetInterestRates")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "SetInterestRates")
+	defer s.audit.End(ctx, scope, &err)
+
+	version.ProductCode = strings.ToUpper(strings.TrimSpace(version.ProductCode))
+	version.EffectiveFrom = domainInterest.Day(version.EffectiveFrom)
+	if version.ProductCode == "" || version.EffectiveFrom.IsZero() || len(version.Tiers) == 0 {
+		return saved, domainInterest.ErrRatesInvalid
+	}
+
+	now := s.clock.Now()
+	minBalances := map[float64]bool{}
+	tiers := make([]domainInterest.InterestRateOrm, 0, len(version.Tiers))
+	for _, t := range version.Tiers {
+		minBalance := math.Round(t.MinBalance*100) / 100
+		if minBalance < 0 || t.AnnualRate < 0 || minBalances[minBalance] {
+			return saved, domainInterest.ErrRatesInvalid
+		}
+		minBalances[minBalance] = true
+
+		tiers = append(tiers, domainInterest.InterestRateOrm{
+			RateUuid:      uuid.New(),
+			ProductCode:   version.ProductCode,
+			EffectiveFrom: version.EffectiveFrom,
+			MinBalance:    minBalance,
+			AnnualRate:    math.Round(t.AnnualRate*1e6) / 1e6,
+			CreatedAt:     now,
+		})
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinBalance < tiers[j].MinBalance
+	})
+	version.Tiers = tiers
+
+	if _, err := s.db.GetProduct(ctx, version.ProductCode); err != nil {
+		logErr := util.LogError("Error on GetProduct: "+err.Error(), "", "Interest Service - SetInterestRates")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return saved, err
+	}
+
+	if err := s.store.SaveInterestRates(ctx, version); err != nil {
+		logErr := util.LogError("Error on SaveInterestRates: "+err.Error(), "", "Interest Service - SetInterestRates")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return saved, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Rates of %v effective from %v set", version.ProductCode, version.EffectiveFrom.Format(time.DateOnly))
+
+	return version, nil
+}
+
+// ListInterestRates returns the versions of the rates of productCode, of
+// every product for "", oldest first.
+func (s *InterestService) ListInterestRates(ctx context.Context, productCode string) (versions []domainInterest.RateVersion, err error) {
+	ctx, span := tracing.Start(ctx, "InterestService.ListInterestRates")
+	defer tracing.End(span, &err)
+
+	rates, err := s.store.ListInterestRates(ctx, strings.ToUpper(strings.TrimSpace(productCode)))
+	if err != nil {
+		return nil, err
+	}
+
+	return domainInterest.Versions(rates), nil
+}
+
+// ListInterestAccruals returns what an account accrued from from up to, not
+// including, to.
+func (s *InterestService) ListInterestAccruals(ctx context.Context, accountNum string, from time.Time, to time.Time) (accruals []domainInterest.InterestAccrualOrm, err error) {
+	ctx, span := tracing.Start(ctx, "InterestService.ListInterestAccruals")
+	defer tracing.End(span, &err)
+
+	account, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Interest Service - ListInterestAccruals")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return nil, err
+	}
+
+	return s.store.ListInterestAccruals(ctx, account.AccountUuid, from, to)
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+)
+
+// LeaderElection runs the jobs that must not run on two replicas at once,
+// like the exchange rate generator, on the replica holding the leader lock.
+// The others try to take the lock every interval and take over once the
+// leader releases it or loses its database session.
+type LeaderElection struct {
+	locker   port.LeaderLockPort
+	name     string
+	clock    clock.Clock
+	interval time.Duration
+	jobs     []func(ctx context.Context)
+	leading  atomic.Bool
+}
+
+func NewLeaderElection(locker port.LeaderLockPort, name string, clk clock.Clock, interval time.Duration) *LeaderElection {
+	return &LeaderElection{
+		locker:   locker,
+		name:     name,
+		clock:    clk,
+		interval: interval,
+	}
+}
+
+// Add registers a job to run while leading. Its ctx is done when the
+// leadership ends; the lock is released after every job returned.
+func (e *LeaderElection) Add(job func(ctx context.Context)) {
+	e.jobs = append(e.jobs, job)
+}
+
+// Leading reports whether this replica runs the jobs.
+func (e *LeaderElection) Leading() bool {
+	return e.leading.Load()
+}
+
+// Run campaigns for the lock until ctx is done, checking a held lock every
+// interval.
+func (e *LeaderElection) Run(ctx context.Context) {
+	ticker := e.clock.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	var lock port.HeldLock
+	var resign func()
+	for {
+		if lock == nil {
+			lock = e.campaign(ctx)
+			if lock != nil {
+				resign = e.lead(ctx)
+			}
+		} else if err := lock.Check(ctx); err != nil {
+			resign()
+			lock = nil
+			if ctx.Err() == nil {
+				logErr := util.LogError(err.Error(), "", "LeaderElection - Run")
+				log.Error().Msg(logErr)
+				log.Warn().Msgf("Lost the %v leader lock, background jobs stopped", e.name)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if lock != nil {
+				resign()
+				e.unlock(lock)
+			}
+			log.Info().Msg("Leader election stopped")
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+func (e *LeaderElection) campaign(ctx context.Context) port.HeldLock {
+	lock, err := e.locker.TryLock(ctx, e.name)
+	if err != nil {
+		if ctx.Err() == nil {
+			logErr := util.LogError(err.Error(), "", "LeaderElection - campaign")
+			log.Error().Msg(logErr)
+		}
+		return nil
+	}
+
+	return lock
+}
+
+// lead starts the jobs and returns the func that stops them and waits for
+// them to return.
+func (e *LeaderElection) lead(ctx context.Context) func() {
+	leadCtx, cancel := context.WithCancel(ctx)
+
+	e.leading.Store(true)
+	metrics.Leader.Set(1)
+
+	var jobs sync.WaitGroup
+	for _, job := range e.jobs {
+		job := job
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			job(leadCtx)
+		}()
+	}
+	log.Info().Msgf("Took the %v leader lock, running %d background jobs", e.name, len(e.jobs))
+
+	return func() {
+		cancel()
+		jobs.Wait()
+		e.leading.Store(false)
+		metrics.Leader.Set(0)
+	}
+}
+
+// unlock releases lock on shutdown so another replica takes over at once.
+func (e *LeaderElection) unlock(lock port.HeldLock) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := lock.Unlock(ctx); err != nil {
+		logErr := util.LogError(err.Error(), "", "LeaderElection - unlock")
+		log.Error().Msg(logErr)
+	}
+}
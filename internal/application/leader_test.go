@@ -0,0 +1,124 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/memory"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+)
+
+// replica is a LeaderElection whose job reports on started and stopped.
+type replica struct {
+	*application.LeaderElection
+	stop func()
+	done chan struct{}
+}
+
+func startReplica(t *testing.T, locker port.LeaderLockPort, clk clock.Clock, name string, started chan<- string, stopped chan<- string) *replica {
+	t.Helper()
+
+	election := application.NewLeaderElection(locker, "jobs", clk, time.Second)
+	election.Add(func(ctx context.Context) {
+		started <- name
+		<-ctx.Done()
+		stopped <- name
+	})
+
+	ctx, stop := context.WithCancel(context.Background())
+	r := &replica{LeaderElection: election, stop: stop, done: make(chan struct{})}
+	go func() {
+		defer close(r.done)
+		election.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		r.stop()
+		<-r.done
+	})
+
+	return r
+}
+
+func receive(t *testing.T, c <-chan string) string {
+	t.Helper()
+
+	select {
+	case name := <-c:
+		return name
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+		return ""
+	}
+}
+
+func TestLeaderElectionFailover(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC))
+	locker := memory.NewLocker()
+	started, stopped := make(chan string, 4), make(chan string, 4)
+
+	a := startReplica(t, locker, clk, "a", started, stopped)
+	if name := receive(t, started); name != "a" || !a.Leading() {
+		t.Fatalf("%v started, leading %v; want a to lead", name, a.Leading())
+	}
+	b := startReplica(t, locker, clk, "b", started, stopped)
+	clk.BlockUntil(2)
+	clk.Advance(time.Second)
+
+	// the leader shuts down and releases the lock
+	a.stop()
+	<-a.done
+	if name := receive(t, stopped); name != "a" || a.Leading() {
+		t.Errorf("%v stopped, a leading %v; want a to resign", name, a.Leading())
+	}
+
+	clk.Advance(time.Second)
+	if name := receive(t, started); name != "b" || !b.Leading() {
+		t.Errorf("%v started, leading %v; want b to take over", name, b.Leading())
+	}
+}
+
+// lostLock is a held lock whose session is gone.
+type lostLock struct {
+	port.HeldLock
+}
+
+func (l lostLock) Check(ctx context.Context) error {
+	l.HeldLock.Unlock(ctx)
+	return errors.New("connection reset")
+}
+
+type losingLocker struct {
+	*memory.Locker
+}
+
+func (l losingLocker) TryLock(ctx context.Context, name string) (port.HeldLock, error) {
+	lock, err := l.Locker.TryLock(ctx, name)
+	if lock == nil {
+		return nil, err
+	}
+
+	return lostLock{lock}, nil
+}
+
+func TestLeaderElectionLostLock(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC))
+	started, stopped := make(chan string, 4), make(chan string, 4)
+
+	r := startReplica(t, losingLocker{memory.NewLocker()}, clk, "a", started, stopped)
+	receive(t, started)
+	clk.BlockUntil(1)
+
+	// the next check finds the lock lost and stops the job, the tick after
+	// takes the lock again
+	clk.Advance(time.Second)
+	receive(t, stopped)
+	clk.Advance(time.Second)
+	receive(t, started)
+	if !r.Leading() {
+		t.Error("not leading after taking the lock again")
+	}
+}
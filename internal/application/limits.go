@@ -0,0 +1,208 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/rs/zerolog/log"
+)
+
+// SaveProduct creates a product or changes the defaults of an existing one,
+// for every account of it without limits of its own. Interest terms left
+// empty default to ACT/365 and monthly capitalization.
+func (s *BankService) SaveProduct(ctx context.Context, product domainBank.BankProductOrm) (saved domainBank.BankProductOrm, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.SaveProduct")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "SaveProduct")
+	defer s.audit.End(ctx, scope, &err)
+
+	product.ProductCode = strings.ToUpper(strings.TrimSpace(product.ProductCode))
+	product.Name = strings.TrimSpace(product.Name)
+	if product.ProductCode == "" || product.Name == "" || product.OverdraftLimit < 0 || product.MinimumBalance < 0 {
+		return saved, domainBank.ErrLimitsInvalid
+	}
+	if product.InterestDayCount == "" {
+		product.InterestDayCount = domainInterest.DayCountAct365
+	}
+	if product.InterestAccrualFrequency == "" {
+		product.InterestAccrualFrequency = domainInterest.AccrueDaily
+	}
+	if product.InterestCapitalization == "" {
+		product.InterestCapitalization = domainInterest.CapitalizeMonthly
+	}
+	if !slices.Contains(domainInterest.DayCounts, product.InterestDayCount) ||
+		!slices.Contains(domainInterest.AccrualFrequencies, product.InterestAccrualFrequency) ||
+		!slices.Contains(domainInterest.CapitalizationSchedules, product.InterestCapitalization) {
+		return saved, domainInterest.ErrTermsInvalid
+	}
+	product.OverdraftLimit = math.Round(product.OverdraftLimit*100) / 100
+	product.MinimumBalance = math.Round(product.MinimumBalance*100) / 100
+
+	now := s.clock.Now()
+	product.CreatedAt = now
+	product.UpdatedAt = now
+
+	saved, err = s.db.SaveProduct(ctx, product)
+	if err != nil {
+		logErr := util.LogError("Error on SaveProduct: "+err.Error(), "", "Bank Service - SaveProduct")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (s *BankService) ListProducts(ctx context.Context) (products []domainBank.BankProductOrm, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.ListProducts")
+	defer tracing.End(span, &err)
+
+	return s.db.ListProducts(ctx)
+}
+
+// GetAccountStanding returns the limits of an account and how much a debit
+// can take from it now.
+func (s *BankService) GetAccountStanding(ctx context.Context, accountNum string) (standing domainBank.AccountStanding, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.GetAccountStanding")
+	defer tracing.End(span, &err)
+
+	account, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Bank Service - GetAccountStanding")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return standing, err
+	}
+
+	return s.accountStanding(ctx, account, s.clock.Now())
+}
+
+// SetAccountLimits moves an account to another product and replaces the
+// limits it has of its own.
+func (s *BankService) SetAccountLimits(ctx context.Context, accountNum string, update domainBank.AccountLimitsUpdate) (standing domainBank.AccountStanding, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.SetAccountLimits")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "SetAccountLimits")
+	defer s.audit.End(ctx, scope, &err)
+
+	if (update.OverdraftLimit != nil && *update.OverdraftLimit < 0) || (update.MinimumBalance != nil && *update.MinimumBalance < 0) {
+		return standing, domainBank.ErrLimitsInvalid
+	}
+
+	account, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Bank Service - SetAccountLimits")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return standing, err
+	}
+	auditAffect(ctx, account.AccountUuid)
+
+	if code := strings.ToUpper(strings.TrimSpace(update.ProductCode)); code != "" {
+		account.ProductCode = code
+	}
+	account.OverdraftLimit = update.OverdraftLimit
+	account.MinimumBalance = update.MinimumBalance
+	account.UpdatedAt = s.clock.Now()
+
+	if err := s.db.UpdateAccountLimits(ctx, account); err != nil {
+		logErr := util.LogError("Error on UpdateAccountLimits: "+err.Error(), "", "Bank Service - SetAccountLimits")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return standing, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Limits of %v set, product %v", accountNum, account.ProductCode)
+
+	return s.accountStanding(ctx, account, account.UpdatedAt)
+}
+
+// accountLimits is the limits of account, without the defaults of a product
+// that has no row.
+// SetAccountCustomer moves accountNum to the customer customerId, "" to
+// none. Customer scoped webhook subscriptions cover every account of the
+// customer.
+func (s *BankService) SetAccountCustomer(ctx context.Context, accountNum string, customerId string) (account domainBank.BankAccountOrm, err error) {
+	ctx, span := tracing.Start(ctx, "BankService.SetAccountCustomer")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "SetAccountCustomer")
+	defer s.audit.End(ctx, scope, &err)
+
+	customerId = strings.TrimSpace(customerId)
+	if len(customerId) > 36 {
+		return account, domainBank.ErrCustomerIdInvalid
+	}
+
+	account, err = s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Bank Service - SetAccountCustomer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return account, err
+	}
+	auditAffect(ctx, account.AccountUuid)
+
+	account.CustomerId = customerId
+	account.UpdatedAt = s.clock.Now()
+
+	if err := s.db.SetAccountCustomer(ctx, account.AccountUuid, account.CustomerId, account.UpdatedAt); err != nil {
+		logErr := util.LogError("Error on SetAccountCustomer: "+err.Error(), "", "Bank Service - SetAccountCustomer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return account, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Customer of %v set to %q", accountNum, account.CustomerId)
+
+	return account, nil
+}
+
+func (s *BankService) accountLimits(ctx context.Context, account domainBank.BankAccountOrm) (domainBank.AccountLimits, error) {
+	product, err := s.db.GetProduct(ctx, account.ProductCode)
+	if err != nil && !errors.Is(err, domainBank.ErrRecordNotFound) {
+		logErr := util.LogError("Error on GetProduct: "+err.Error(), "", "Bank Service - accountLimits")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return domainBank.AccountLimits{}, err
+	}
+
+	return domainBank.LimitsOf(account, product), nil
+}
+
+func (s *BankService) accountStanding(ctx context.Context, account domainBank.BankAccountOrm, at time.Time) (domainBank.AccountStanding, error) {
+	limits, err := s.accountLimits(ctx, account)
+	if err != nil {
+		return domainBank.AccountStanding{}, err
+	}
+	available, err := s.availableBalance(ctx, account.AccountUuid, account.CurrentBalance, at)
+	if err != nil {
+		return domainBank.AccountStanding{}, err
+	}
+
+	return domainBank.AccountStanding{
+		Account:   account,
+		Limits:    limits,
+		Available: available,
+		Spendable: limits.Spendable(available),
+	}, nil
+}
+
+// checkDebit returns an InsufficientFundsError when the limits of account
+// don't let operation debit it amount at at.
+func (s *BankService) checkDebit(ctx context.Context, operation string, account domainBank.BankAccountOrm, amount float64, at time.Time) error {
+	standing, err := s.accountStanding(ctx, account, at)
+	if err != nil {
+		return err
+	}
+
+	if err := standing.Limits.Check(account.AccountNumber, standing.Available, amount); err != nil {
+		metrics.InsufficientBalance.WithLabelValues(operation).Inc()
+		logErr := util.LogError("Debit refused: "+err.Error(), "", "Bank Service - checkDebit")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	return nil
+}
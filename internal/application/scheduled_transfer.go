@@ -0,0 +1,460 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainSchedule "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/schedule"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ScheduleOptions tune the execution of scheduled transfers.
+type ScheduleOptions struct {
+	// BatchSize schedules are claimed at once.
+	BatchSize int
+	// Lease is how long a claimed schedule is left to one replica. It must
+	// outlast the transfers of a batch, a schedule whose run isn't recorded
+	// by then is claimed again.
+	Lease time.Duration
+}
+
+// ScheduleService keeps the scheduled transfers and executes the due ones
+// through BankService.Transfer.
+//
+// Every attempt at an occurrence books its transfer under a uuid derived from
+// the schedule, the occurrence and the attempt, so an attempt claimed again
+// after a crash finds the transfer of the first run instead of booking a
+// second one.
+type ScheduleService struct {
+	store   port.ScheduleStorePort
+	bank    port.BankServicePort
+	db      port.BankDatabasePort
+	clock   clock.Clock
+	options ScheduleOptions
+	audit   *Auditor
+}
+
+func NewScheduleService(store port.ScheduleStorePort, bank port.BankServicePort, dbPort port.BankDatabasePort, clk clock.Clock, options ScheduleOptions) *ScheduleService {
+	return &ScheduleService{
+		store:   store,
+		bank:    bank,
+		db:      dbPort,
+		clock:   clk,
+		options: options,
+		audit:   auditorOf(store, clk),
+	}
+}
+
+// CreateScheduledTransfer schedules the transfer of schedule from
+// fromAccountNum to toAccountNum. A zero StartAt starts now, an empty
+// Recurrence transfers once at StartAt.
+func (s *ScheduleService) CreateScheduledTransfer(ctx context.Context, schedule domainSchedule.ScheduledTransferOrm, fromAccountNum string, toAccountNum string) (detail domainSchedule.ScheduleDetail, err error) {
+	ctx, span := tracing.Start(ctx, "ScheduleService.CreateScheduledTransfer")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "CreateScheduledTransfer")
+	defer s.audit.End(ctx, scope, &err)
+
+	now := s.clock.Now()
+	rule, err := domainSchedule.ParseRule(schedule.Recurrence)
+	if err != nil {
+		return detail, err
+	}
+
+	schedule.Currency = strings.ToUpper(strings.TrimSpace(schedule.Currency))
+	schedule.Channel = strings.ToUpper(strings.TrimSpace(schedule.Channel))
+	schedule.Amount = math.Round(schedule.Amount*100) / 100
+	if schedule.StartAt.IsZero() {
+		schedule.StartAt = now
+	}
+	schedule.StartAt = schedule.StartAt.UTC()
+	switch {
+	case !supportedCurrencies[schedule.Currency]:
+		return detail, domainSchedule.ErrCurrencyNotSupported
+	case schedule.Amount <= 0:
+		return detail, domainSchedule.ErrAmountInvalid
+	// a start a moment ago is fine, clients send it by their own clock
+	case schedule.StartAt.Before(now.Add(-time.Minute)):
+		return detail, domainSchedule.ErrStartInPast
+	case schedule.MaxRetries < 0 || schedule.RetryIntervalSeconds < 0 || (schedule.MaxRetries > 0 && schedule.RetryIntervalSeconds == 0):
+		return detail, domainSchedule.ErrRetryInvalid
+	}
+	first, ok := rule.At(schedule.StartAt, 0)
+	if !ok {
+		return detail, fmt.Errorf("%w : it ends before its first occurrence", domainSchedule.ErrRuleInvalid)
+	}
+
+	from, err := s.db.GetDetailBankAccountByAccountNumber(ctx, fromAccountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber From: "+err.Error(), "", "Schedule Service - CreateScheduledTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return detail, domainBank.ErrTransferSourceAccountNotFound
+	}
+	to, err := s.db.GetDetailBankAccountByAccountNumber(ctx, toAccountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber To: "+err.Error(), "", "Schedule Service - CreateScheduledTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return detail, domainBank.ErrTransferDestinationAccountNotFound
+	}
+	auditAffect(ctx, from.AccountUuid, to.AccountUuid)
+
+	schedule.ScheduleUuid = uuid.New()
+	schedule.FromAccountUuid = from.AccountUuid
+	schedule.ToAccountUuid = to.AccountUuid
+	schedule.Recurrence = rule.String()
+	schedule.Status = domainSchedule.StatusActive
+	schedule.NextRunAt = &first
+	schedule.Occurrence = 0
+	schedule.Attempt = 0
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+
+	if err := s.store.CreateScheduledTransfer(ctx, schedule); err != nil {
+		logErr := util.LogError("Error on CreateScheduledTransfer: "+err.Error(), "", "Schedule Service - CreateScheduledTransfer")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return detail, err
+	}
+	auditAffect(ctx, schedule.ScheduleUuid)
+
+	log.Info().Ctx(ctx).Msgf("Transfer of %v %v from %v to %v scheduled as %v, first at %v", schedule.Amount, schedule.Currency, fromAccountNum, toAccountNum, schedule.ScheduleUuid, first)
+
+	return domainSchedule.ScheduleDetail{ScheduledTransferOrm: schedule, FromAccountNumber: from.AccountNumber, ToAccountNumber: to.AccountNumber}, nil
+}
+
+// ListScheduledTransfers returns the schedules sending from accountNum, of
+// every account when it is empty.
+func (s *ScheduleService) ListScheduledTransfers(ctx context.Context, accountNum string) (details []domainSchedule.ScheduleDetail, err error) {
+	ctx, span := tracing.Start(ctx, "ScheduleService.ListScheduledTransfers")
+	defer tracing.End(span, &err)
+
+	accountUuid := uuid.Nil
+	if accountNum != "" {
+		account, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+		if err != nil {
+			return nil, err
+		}
+		accountUuid = account.AccountUuid
+	}
+
+	schedules, err := s.store.ListScheduledTransfers(ctx, accountUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := map[uuid.UUID]string{}
+	details = make([]domainSchedule.ScheduleDetail, 0, len(schedules))
+	for _, schedule := range schedules {
+		detail, err := s.detail(ctx, schedule, numbers)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, detail)
+	}
+
+	return details, nil
+}
+
+// PauseScheduledTransfer stops an active schedule until it is resumed.
+func (s *ScheduleService) PauseScheduledTransfer(ctx context.Context, id uuid.UUID) (detail domainSchedule.ScheduleDetail, err error) {
+	ctx, span := tracing.Start(ctx, "ScheduleService.PauseScheduledTransfer")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "PauseScheduledTransfer")
+	defer s.audit.End(ctx, scope, &err)
+
+	return s.setStatus(ctx, id, []string{domainSchedule.StatusActive}, domainSchedule.StatusPaused)
+}
+
+// ResumeScheduledTransfer makes a paused schedule active again. The
+// occurrences and retries missed while it was paused are skipped, a schedule
+// without an occurrence left is completed.
+func (s *ScheduleService) ResumeScheduledTransfer(ctx context.Context, id uuid.UUID) (detail domainSchedule.ScheduleDetail, err error) {
+	ctx, span := tracing.Start(ctx, "ScheduleService.ResumeScheduledTransfer")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "ResumeScheduledTransfer")
+	defer s.audit.End(ctx, scope, &err)
+	auditAffect(ctx, id)
+
+	paused, err := s.store.GetScheduledTransfer(ctx, id)
+	if err != nil {
+		return detail, err
+	}
+	if paused.Status != domainSchedule.StatusPaused {
+		return detail, domainSchedule.ErrScheduleNotActive
+	}
+	rule, err := paused.Rule()
+	if err != nil {
+		return detail, err
+	}
+
+	now := s.clock.Now()
+	resumed := paused
+	resumed.Status = domainSchedule.StatusActive
+	resumed.UpdatedAt = now
+	if paused.NextRunAt == nil || paused.NextRunAt.Before(now) {
+		n, next, ok := rule.After(paused.StartAt, now, paused.Occurrence)
+		if ok {
+			resumed.NextRunAt = &next
+			resumed.Occurrence = n
+		} else {
+			resumed.Status = domainSchedule.StatusCompleted
+			resumed.NextRunAt = nil
+		}
+		if resumed.Occurrence != paused.Occurrence || !ok {
+			resumed.Attempt = 0
+		}
+	}
+
+	if err := s.store.ResumeScheduledTransfer(ctx, paused, resumed); err != nil {
+		return detail, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Scheduled transfer %v resumed, %v", id, resumed.Status)
+
+	return s.detail(ctx, resumed, map[uuid.UUID]string{})
+}
+
+// CancelScheduledTransfer stops an active or paused schedule for good.
+func (s *ScheduleService) CancelScheduledTransfer(ctx context.Context, id uuid.UUID) (detail domainSchedule.ScheduleDetail, err error) {
+	ctx, span := tracing.Start(ctx, "ScheduleService.CancelScheduledTransfer")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "CancelScheduledTransfer")
+	defer s.audit.End(ctx, scope, &err)
+
+	return s.setStatus(ctx, id, []string{domainSchedule.StatusActive, domainSchedule.StatusPaused}, domainSchedule.StatusCancelled)
+}
+
+func (s *ScheduleService) setStatus(ctx context.Context, id uuid.UUID, from []string, status string) (domainSchedule.ScheduleDetail, error) {
+	auditAffect(ctx, id)
+
+	if err := s.store.UpdateScheduledTransferStatus(ctx, id, from, status, s.clock.Now()); err != nil {
+		return domainSchedule.ScheduleDetail{}, err
+	}
+
+	schedule, err := s.store.GetScheduledTransfer(ctx, id)
+	if err != nil {
+		return domainSchedule.ScheduleDetail{}, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Scheduled transfer %v %v", id, strings.ToLower(status))
+
+	return s.detail(ctx, schedule, map[uuid.UUID]string{})
+}
+
+// ListScheduledTransferExecutions returns every attempt at the occurrences of
+// schedule id.
+func (s *ScheduleService) ListScheduledTransferExecutions(ctx context.Context, id uuid.UUID) (executions []domainSchedule.ExecutionOrm, err error) {
+	ctx, span := tracing.Start(ctx, "ScheduleService.ListScheduledTransferExecutions")
+	defer tracing.End(span, &err)
+
+	if _, err := s.store.GetScheduledTransfer(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.store.ListScheduledTransferExecutions(ctx, id)
+}
+
+// detail adds the account numbers to schedule, numbers caches them by uuid.
+func (s *ScheduleService) detail(ctx context.Context, schedule domainSchedule.ScheduledTransferOrm, numbers map[uuid.UUID]string) (domainSchedule.ScheduleDetail, error) {
+	detail := domainSchedule.ScheduleDetail{ScheduledTransferOrm: schedule}
+
+	for _, acc := range []struct {
+		uuid   uuid.UUID
+		number *string
+	}{{schedule.FromAccountUuid, &detail.FromAccountNumber}, {schedule.ToAccountUuid, &detail.ToAccountNumber}} {
+		number, ok := numbers[acc.uuid]
+		if !ok {
+			account, err := s.db.GetDetailBankAccountByUuid(ctx, acc.uuid)
+			if err != nil {
+				logErr := util.LogError("Error on GetDetailBankAccountByUuid: "+err.Error(), "", "Schedule Service - detail")
+				log.Error().Ctx(ctx).Msg(logErr)
+				return detail, err
+			}
+			number = account.AccountNumber
+			numbers[acc.uuid] = number
+		}
+		*acc.number = number
+	}
+
+	return detail, nil
+}
+
+// Run executes the due scheduled transfers every interval until ctx is done.
+func (s *ScheduleService) Run(ctx context.Context, interval time.Duration) {
+	ticker := s.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Scheduled transfers stopped")
+			return
+		case <-ticker.C():
+		}
+
+		if _, err := s.Execute(ctx); err != nil && ctx.Err() == nil {
+			logErr := util.LogError(err.Error(), "", "ScheduleService - Run")
+			log.Error().Msg(logErr)
+		}
+	}
+}
+
+// Execute runs every due occurrence once and returns how many transfers
+// succeeded. Occurrences missed while no replica ran are caught up one after
+// the other.
+func (s *ScheduleService) Execute(ctx context.Context) (int, error) {
+	succeeded := 0
+
+	for {
+		now := s.clock.Now()
+		schedules, err := s.store.ClaimScheduledTransfers(ctx, now, now.Add(s.options.Lease), s.options.BatchSize)
+		if err != nil {
+			return succeeded, fmt.Errorf("can't claim scheduled transfers : %v", err)
+		}
+
+		for _, schedule := range schedules {
+			if ctx.Err() != nil {
+				return succeeded, ctx.Err()
+			}
+			if s.run(ctx, schedule) {
+				succeeded++
+			}
+		}
+
+		// a run moves the next run past now or to the next occurrence,
+		// claiming again catches up missed occurrences until none is due
+		if len(schedules) == 0 {
+			return succeeded, nil
+		}
+	}
+}
+
+// run executes the current attempt at the current occurrence of schedule,
+// records it and reports whether the transfer succeeded.
+func (s *ScheduleService) run(ctx context.Context, schedule domainSchedule.ScheduledTransferOrm) bool {
+	rule, err := schedule.Rule()
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't parse the recurrence of scheduled transfer %v : %v", schedule.ScheduleUuid, err), "", "ScheduleService - run")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return false
+	}
+	scheduledFor, _ := rule.At(schedule.StartAt, schedule.Occurrence)
+
+	transferUuid := uuid.NewSHA1(schedule.ScheduleUuid, []byte(fmt.Sprintf("%d/%d", schedule.Occurrence, schedule.Attempt)))
+	execution := domainSchedule.ExecutionOrm{
+		ExecutionUuid: uuid.New(),
+		ScheduleUuid:  schedule.ScheduleUuid,
+		Occurrence:    schedule.Occurrence,
+		Attempt:       schedule.Attempt,
+		ScheduledFor:  scheduledFor,
+	}
+
+	// a transfer under the uuid of this attempt means an earlier claim ran
+	// it and didn't get to record it
+	booked, err := s.db.GetTransfer(ctx, transferUuid)
+	switch {
+	case err == nil:
+		execution.TransferUuid = &booked.TransferUuid
+		execution.Status = domainSchedule.ExecutionSucceeded
+		if !booked.TransferSuccess {
+			execution.Status = domainSchedule.ExecutionFailed
+			execution.Error = "transfer was interrupted"
+		}
+	case errors.Is(err, domainBank.ErrRecordNotFound):
+		fromAccount, toAccount, err := s.accountNumbers(ctx, schedule)
+		if err != nil {
+			return false
+		}
+
+		_, success, _, err := s.bank.Transfer(ctx, domainBank.TransferTransaction{
+			FromAccountNumber: fromAccount,
+			ToAccountNumber:   toAccount,
+			Currency:          schedule.Currency,
+			Amount:            schedule.Amount,
+			Notes:             schedule.Notes,
+			Channel:           schedule.Channel,
+			TransferUuid:      transferUuid,
+		})
+		if ctx.Err() != nil {
+			// shutting down, the claim expires and the attempt is resolved then
+			return false
+		}
+		if errors.Is(err, domainBank.ErrTransferRecordFailed) {
+			if _, lookupErr := s.db.GetTransfer(ctx, transferUuid); lookupErr == nil {
+				// booked by a replica whose claim expired, or not marked
+				// done, the next claim records its outcome
+				return false
+			}
+		}
+
+		switch {
+		case err == nil && success:
+			execution.Status = domainSchedule.ExecutionSucceeded
+		case err == nil:
+			execution.Status = domainSchedule.ExecutionFailed
+			execution.Error = "transfer did not succeed"
+		case errors.Is(err, domainBank.ErrInsufficientBalance) && schedule.Attempt < schedule.MaxRetries:
+			execution.Status = domainSchedule.ExecutionRetry
+			execution.Error = err.Error()
+		default:
+			execution.Status = domainSchedule.ExecutionFailed
+			execution.Error = err.Error()
+		}
+		if _, lookupErr := s.db.GetTransfer(ctx, transferUuid); lookupErr == nil {
+			execution.TransferUuid = &transferUuid
+		}
+	default:
+		logErr := util.LogError(fmt.Sprintf("Can't look up transfer %v of scheduled transfer %v : %v", transferUuid, schedule.ScheduleUuid, err), "", "ScheduleService - run")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return false
+	}
+
+	now := s.clock.Now()
+	execution.ExecutedAt = now
+	schedule.UpdatedAt = now
+	if execution.Status == domainSchedule.ExecutionRetry {
+		next := now.Add(schedule.RetryInterval())
+		schedule.NextRunAt = &next
+		schedule.Attempt++
+	} else {
+		schedule.Occurrence++
+		schedule.Attempt = 0
+		schedule.NextRunAt = nil
+		if next, ok := rule.At(schedule.StartAt, schedule.Occurrence); ok {
+			schedule.NextRunAt = &next
+		} else {
+			schedule.Status = domainSchedule.StatusCompleted
+		}
+	}
+
+	if err := s.store.RecordScheduledTransferRun(ctx, schedule, execution); err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't record the run of scheduled transfer %v : %v", schedule.ScheduleUuid, err), "", "ScheduleService - run")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return false
+	}
+	metrics.ScheduledTransferExecutions.WithLabelValues(execution.Status).Inc()
+
+	if execution.Status != domainSchedule.ExecutionSucceeded {
+		log.Warn().Ctx(ctx).Msgf("Occurrence %d of scheduled transfer %v, attempt %d : %v %v", execution.Occurrence, schedule.ScheduleUuid, execution.Attempt, execution.Status, execution.Error)
+	}
+
+	return execution.Status == domainSchedule.ExecutionSucceeded
+}
+
+func (s *ScheduleService) accountNumbers(ctx context.Context, schedule domainSchedule.ScheduledTransferOrm) (string, string, error) {
+	detail, err := s.detail(ctx, schedule, map[uuid.UUID]string{})
+	if err != nil {
+		return "", "", err
+	}
+
+	return detail.FromAccountNumber, detail.ToAccountNumber, nil
+}
@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"io"
+	"time"
+
+	domainStatement "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/statement"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/pdf"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// statementPageSize transactions are read at once while a statement is
+// written, so a long period isn't held in memory.
+const statementPageSize = 500
+
+// StatementOptions configure the StatementService.
+type StatementOptions struct {
+	// BankId identifies the bank in OFX statements.
+	BankId string
+	// Branding is the bank on PDF statements and receipts.
+	Branding pdf.Branding
+}
+
+// StatementService renders the statements of accounts and the receipts of
+// transfers.
+type StatementService struct {
+	store   port.StatementStorePort
+	db      port.BankDatabasePort
+	clock   clock.Clock
+	options StatementOptions
+}
+
+func NewStatementService(store port.StatementStorePort, dbPort port.BankDatabasePort, clk clock.Clock, options StatementOptions) *StatementService {
+	return &StatementService{
+		store:   store,
+		db:      dbPort,
+		clock:   clk,
+		options: options,
+	}
+}
+
+// GenerateStatement writes the statement of accountNum for the period from
+// from up to, not including, to, in format, to w. A period ending later than
+// now, or without an end, ends now. Nothing is written when the statement
+// can't be started.
+func (s *StatementService) GenerateStatement(ctx context.Context, accountNum string, format string, from time.Time, to time.Time, w io.Writer) (err error) {
+	ctx, span := tracing.Start(ctx, "StatementService.GenerateStatement")
+	defer tracing.End(span, &err)
+
+	var writer domainStatement.Writer
+	if format == domainStatement.FormatPDF {
+		writer = pdf.NewStatementWriter(w, s.options.Branding)
+	} else if writer, err = domainStatement.NewWriter(format, w); err != nil {
+		return err
+	}
+
+	now := s.clock.Now()
+	if to.IsZero() || to.After(now) {
+		to = now
+	}
+	if from.IsZero() || !from.Before(to) {
+		return domainStatement.ErrPeriodInvalid
+	}
+
+	account, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Statement Service - GenerateStatement")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+
+	opening, closing, err := s.store.StatementBalances(ctx, account, from, to)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Begin(domainStatement.Statement{
+		Id:        domainStatement.StatementId(account.AccountUuid, from, to),
+		BankId:    s.options.BankId,
+		Account:   account,
+		From:      from,
+		To:        to,
+		Opening:   opening,
+		Closing:   closing,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	var after *domainStatement.Cursor
+	for {
+		trxs, err := s.store.ListTransactions(ctx, account, from, to, after, statementPageSize)
+		if err != nil {
+			return err
+		}
+		for _, trx := range trxs {
+			if err := writer.Entry(trx); err != nil {
+				return err
+			}
+		}
+		if len(trxs) < statementPageSize {
+			break
+		}
+		cursor := domainStatement.CursorOf(trxs[len(trxs)-1])
+		after = &cursor
+	}
+
+	return writer.End()
+}
+
+// WriteTransferReceipt writes the PDF receipt of transferUuid, with the
+// names and numbers of its accounts, to w.
+func (s *StatementService) WriteTransferReceipt(ctx context.Context, transferUuid uuid.UUID, w io.Writer) (err error) {
+	ctx, span := tracing.Start(ctx, "StatementService.WriteTransferReceipt")
+	defer tracing.End(span, &err)
+
+	transfer, err := s.db.GetTransferDetail(ctx, transferUuid)
+	if err != nil {
+		logErr := util.LogError("Error on GetTransferDetail: "+err.Error(), "", "Statement Service - WriteTransferReceipt")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+	from, err := s.db.GetDetailBankAccountByUuid(ctx, transfer.FromAccountUuid)
+	if err != nil {
+		return err
+	}
+	to, err := s.db.GetDetailBankAccountByUuid(ctx, transfer.ToAccountUuid)
+	if err != nil {
+		return err
+	}
+
+	return pdf.WriteReceipt(w, s.options.Branding, pdf.Receipt{
+		TransferId:     transfer.TransferUuid.String(),
+		Time:           transfer.TransferTimestamp,
+		Success:        transfer.TransferSuccess,
+		Currency:       transfer.Currency,
+		Amount:         transfer.Amount,
+		FromName:       from.AccountName,
+		FromNumber:     from.AccountNumber,
+		ToName:         to.AccountName,
+		ToNumber:       to.AccountNumber,
+		ReversedAmount: transfer.ReversedAmount,
+		ReversalReason: transfer.ReversalReason,
+		PrintedAt:      s.clock.Now(),
+	})
+}
@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// TaxService keeps the rules of the tax withheld from interest and the
+// exemptions of accounts, and reports what was withheld. InterestService
+// withholds it.
+type TaxService struct {
+	store port.TaxStorePort
+	db    port.BankDatabasePort
+	clock clock.Clock
+	audit *Auditor
+}
+
+func NewTaxService(store port.TaxStorePort, dbPort port.BankDatabasePort, clk clock.Clock) *TaxService {
+	return &TaxService{
+		store: store,
+		db:    dbPort,
+		clock: clk,
+		audit: auditorOf(store, clk),
+	}
+}
+
+// SetTaxRule replaces the rule of the currency of rule effective from the
+// same day. The tax withheld is credited to payableAccountNum, an account in
+// the currency of the rule.
+func (s *TaxService) SetTaxRule(ctx context.Context, rule domainTax.TaxRuleOrm, payableAccountNum string) (detail domainTax.RuleDetail, err error) {
+	ctx, span := tracing.Start(ctx, "TaxService.SetTaxRule")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "SetTaxRule")
+	defer s.audit.End(ctx, scope, &err)
+
+	rule.Currency = strings.ToUpper(strings.TrimSpace(rule.Currency))
+	rule.EffectiveFrom = domainInterest.Day(rule.EffectiveFrom)
+	rule.Rate = math.Round(rule.Rate*1e4) / 1e4
+	rule.MinBalance = math.Round(rule.MinBalance*100) / 100
+	if rule.Currency == "" || rule.EffectiveFrom.IsZero() || rule.Rate < 0 || rule.Rate > 100 || rule.MinBalance < 0 {
+		return detail, domainTax.ErrRuleInvalid
+	}
+
+	payable, err := s.db.GetDetailBankAccountByAccountNumber(ctx, payableAccountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Tax Service - SetTaxRule")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return detail, err
+	}
+	if payable.Currency != rule.Currency {
+		return detail, domainTax.ErrRuleInvalid
+	}
+	auditAffect(ctx, payable.AccountUuid)
+
+	rule.PayableAccountUuid = payable.AccountUuid
+	rule.CreatedAt = s.clock.Now()
+
+	if err := s.store.SaveTaxRule(ctx, rule); err != nil {
+		logErr := util.LogError("Error on SaveTaxRule: "+err.Error(), "", "Tax Service - SetTaxRule")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return detail, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Tax rule of %v effective from %v set", rule.Currency, rule.EffectiveFrom.Format(time.DateOnly))
+
+	return domainTax.RuleDetail{Rule: rule, PayableAccount: payable}, nil
+}
+
+// ListTaxRules returns the rules by currency, oldest first.
+func (s *TaxService) ListTaxRules(ctx context.Context) (details []domainTax.RuleDetail, err error) {
+	ctx, span := tracing.Start(ctx, "TaxService.ListTaxRules")
+	defer tracing.End(span, &err)
+
+	rules, err := s.store.ListTaxRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	details = make([]domainTax.RuleDetail, 0, len(rules))
+	payables := map[uuid.UUID]domainBank.BankAccountOrm{}
+	for _, rule := range rules {
+		payable, ok := payables[rule.PayableAccountUuid]
+		if !ok {
+			payable, err = s.db.GetDetailBankAccountByUuid(ctx, rule.PayableAccountUuid)
+			if err != nil {
+				logErr := util.LogError("Error on GetDetailBankAccountByUuid: "+err.Error(), "", "Tax Service - ListTaxRules")
+				log.Error().Ctx(ctx).Msg(logErr)
+				return nil, err
+			}
+			payables[rule.PayableAccountUuid] = payable
+		}
+		details = append(details, domainTax.RuleDetail{Rule: rule, PayableAccount: payable})
+	}
+
+	return details, nil
+}
+
+// SetTaxExemption exempts the customer of accountNum, every account of it,
+// from the tax on its interest, or ends its exemption. Interest already
+// posted keeps its tax.
+func (s *TaxService) SetTaxExemption(ctx context.Context, accountNum string, exempt bool) (account domainBank.BankAccountOrm, err error) {
+	ctx, span := tracing.Start(ctx, "TaxService.SetTaxExemption")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "SetTaxExemption")
+	defer s.audit.End(ctx, scope, &err)
+
+	account, err = s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+	if err != nil {
+		logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Tax Service - SetTaxExemption")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return account, err
+	}
+	auditAffect(ctx, account.AccountUuid)
+
+	account.TaxExempt = exempt
+	account.UpdatedAt = s.clock.Now()
+
+	if err := s.store.SetTaxExempt(ctx, account.AccountUuid, exempt, account.UpdatedAt); err != nil {
+		logErr := util.LogError("Error on SetTaxExempt: "+err.Error(), "", "Tax Service - SetTaxExemption")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return account, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Tax exemption of the customer of %v set to %v", accountNum, exempt)
+
+	return account, nil
+}
+
+// TaxReport sums the interest posted and the tax withheld in the month of
+// month by customer, of customerId, of every customer for "".
+func (s *TaxService) TaxReport(ctx context.Context, month time.Time, customerId string) (rows []domainTax.ReportRow, err error) {
+	ctx, span := tracing.Start(ctx, "TaxService.TaxReport")
+	defer tracing.End(span, &err)
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	accounts := map[uuid.UUID]domainBank.BankAccountOrm{}
+	var withholdings []domainTax.WithholdingOrm
+	if customerId == "" {
+		withholdings, err = s.store.ListWithholdings(ctx, uuid.Nil, from, from.AddDate(0, 1, 0))
+		if err != nil {
+			logErr := util.LogError("Error on ListWithholdings: "+err.Error(), "", "Tax Service - TaxReport")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return nil, err
+		}
+	} else {
+		customer, err := s.db.ListAccountsOfCustomer(ctx, customerId)
+		if err != nil {
+			logErr := util.LogError("Error on ListAccountsOfCustomer: "+err.Error(), "", "Tax Service - TaxReport")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return nil, err
+		}
+		if len(customer) == 0 {
+			return nil, domainBank.ErrRecordNotFound
+		}
+		for _, account := range customer {
+			accounts[account.AccountUuid] = account
+			listed, err := s.store.ListWithholdings(ctx, account.AccountUuid, from, from.AddDate(0, 1, 0))
+			if err != nil {
+				logErr := util.LogError("Error on ListWithholdings: "+err.Error(), "", "Tax Service - TaxReport")
+				log.Error().Ctx(ctx).Msg(logErr)
+				return nil, err
+			}
+			withholdings = append(withholdings, listed...)
+		}
+	}
+
+	for _, w := range withholdings {
+		if _, ok := accounts[w.AccountUuid]; ok {
+			continue
+		}
+		account, err := s.db.GetDetailBankAccountByUuid(ctx, w.AccountUuid)
+		if err != nil {
+			logErr := util.LogError("Error on GetDetailBankAccountByUuid: "+err.Error(), "", "Tax Service - TaxReport")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return nil, err
+		}
+		accounts[w.AccountUuid] = account
+	}
+
+	return domainTax.Summarize(withholdings, accounts), nil
+}
@@ -0,0 +1,745 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainBatch "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/batch"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// BatchOptions tune the import and execution of transfer batches.
+type BatchOptions struct {
+	// BatchSize batches are claimed at once.
+	BatchSize int
+	// Lease is how long a claimed batch is left to one replica. It is
+	// renewed while the batch runs, a batch whose worker stops renewing it
+	// is claimed again.
+	Lease time.Duration
+	// MaxItems and MaxFileSize, in bytes, bound the files imported.
+	MaxItems    int
+	MaxFileSize int64
+}
+
+// maxIdLength is the length of the Max35Text message and end to end ids of
+// ISO 20022.
+const maxIdLength = 35
+
+// BatchService imports files of credit transfers, validates every line up
+// front and executes the accepted batches through BankService.Transfer.
+//
+// Every item books its transfer under its own uuid, so an item run again
+// after a crash finds the transfer of the first run instead of booking a
+// second one. A batch of ModeAllOrNothing stops at the first failed transfer
+// and reverses the ones done.
+type BatchService struct {
+	store   port.BatchStorePort
+	bank    port.BankServicePort
+	admin   port.AccountAdminServicePort
+	db      port.BankDatabasePort
+	clock   clock.Clock
+	options BatchOptions
+	audit   *Auditor
+}
+
+func NewBatchService(store port.BatchStorePort, bank port.BankServicePort, admin port.AccountAdminServicePort, dbPort port.BankDatabasePort, clk clock.Clock, options BatchOptions) *BatchService {
+	return &BatchService{
+		store:   store,
+		bank:    bank,
+		admin:   admin,
+		db:      dbPort,
+		clock:   clk,
+		options: options,
+		audit:   auditorOf(store, clk),
+	}
+}
+
+// sizeLimiter fails reads past n bytes with ErrFileTooLarge.
+type sizeLimiter struct {
+	r        io.Reader
+	n        int64
+	exceeded bool
+}
+
+func (l *sizeLimiter) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		l.exceeded = true
+		return 0, domainBatch.ErrFileTooLarge
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+
+	return n, err
+}
+
+// ImportTransferBatch reads the file of upload from r as it arrives and
+// stores it as a batch. A file that can't be read, is a duplicate or is too
+// large fails; a file whose lines or totals don't check out is stored as
+// rejected, with the reason of every line, so its report tells what to fix.
+func (s *BatchService) ImportTransferBatch(ctx context.Context, upload domainBatch.Upload, r io.Reader) (detail domainBatch.BatchDetail, err error) {
+	ctx, span := tracing.Start(ctx, "BatchService.ImportTransferBatch")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "ImportTransferBatch")
+	defer s.audit.End(ctx, scope, &err)
+
+	upload.MessageId = strings.TrimSpace(upload.MessageId)
+	upload.DebtorAccount = strings.TrimSpace(upload.DebtorAccount)
+	upload.Channel = strings.ToUpper(strings.TrimSpace(upload.Channel))
+	if upload.Mode == "" {
+		upload.Mode = domainBatch.ModeAllOrNothing
+	}
+	switch {
+	case upload.Format != domainBatch.FormatCSV && upload.Format != domainBatch.FormatPain001:
+		return detail, domainBatch.ErrFormatInvalid
+	case upload.Mode != domainBatch.ModeAllOrNothing && upload.Mode != domainBatch.ModeBestEffort:
+		return detail, domainBatch.ErrModeInvalid
+	case len(upload.MessageId) > maxIdLength:
+		return detail, domainBatch.ErrMessageIdInvalid
+	}
+
+	hash := sha256.New()
+	limiter := &sizeLimiter{r: r, n: s.options.MaxFileSize}
+	file, err := domainBatch.Parse(upload.Format, io.TeeReader(limiter, hash), s.options.MaxItems)
+	switch {
+	case limiter.exceeded:
+		return detail, fmt.Errorf("%w : more than %d bytes", domainBatch.ErrFileTooLarge, s.options.MaxFileSize)
+	case err != nil:
+		return detail, err
+	case len(file.Lines) == 0:
+		return detail, domainBatch.ErrBatchEmpty
+	}
+
+	messageId := upload.MessageId
+	if messageId == "" {
+		messageId = file.MessageId
+	}
+	if messageId == "" {
+		// a file without an id is recognised by its content
+		messageId = hex.EncodeToString(hash.Sum(nil))[:32]
+	}
+	if len(messageId) > maxIdLength {
+		return detail, domainBatch.ErrMessageIdInvalid
+	}
+	if err := s.checkDuplicate(ctx, messageId); err != nil {
+		return detail, err
+	}
+
+	now := s.clock.Now()
+	batch := domainBatch.BatchOrm{
+		BatchUuid:            uuid.New(),
+		MessageId:            messageId,
+		Format:               upload.Format,
+		Mode:                 upload.Mode,
+		Channel:              upload.Channel,
+		Status:               domainBatch.StatusAccepted,
+		NumberOfTransactions: len(file.Lines),
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	auditAffect(ctx, batch.BatchUuid)
+
+	items, err := s.validate(ctx, upload, file, now)
+	if err != nil {
+		return detail, err
+	}
+	for _, item := range items {
+		batch.ControlSum += item.Amount
+	}
+	batch.ControlSum = math.Round(batch.ControlSum*100) / 100
+
+	if upload.Mode == domainBatch.ModeAllOrNothing && rejected(items) == 0 {
+		if err := s.checkFunds(ctx, batch, items); err != nil {
+			return detail, err
+		}
+	}
+	s.decide(&batch, upload, file, items, now)
+
+	if err := s.store.CreateTransferBatch(ctx, batch, items); err != nil {
+		// a concurrent import of the same file may have won
+		if dupErr := s.checkDuplicate(ctx, messageId); dupErr != nil {
+			return detail, dupErr
+		}
+		logErr := util.LogError("Error on CreateTransferBatch: "+err.Error(), "", "Batch Service - ImportTransferBatch")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return detail, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Transfer batch %v imported as %v, %d transactions, %v", messageId, batch.BatchUuid, len(items), batch.Status)
+
+	return domainBatch.BatchDetail{BatchOrm: batch, Counts: countItems(items)}, nil
+}
+
+func (s *BatchService) checkDuplicate(ctx context.Context, messageId string) error {
+	existing, err := s.store.GetTransferBatchByMessageId(ctx, messageId)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w : %v is batch %v", domainBatch.ErrDuplicateMessage, messageId, existing.BatchUuid)
+	case errors.Is(err, domainBank.ErrRecordNotFound):
+		return nil
+	default:
+		logErr := util.LogError("Error on GetTransferBatchByMessageId: "+err.Error(), "", "Batch Service - checkDuplicate")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return err
+	}
+}
+
+// validate turns the lines of file into items, rejecting the ones that
+// can't be executed with the reason of the first problem found.
+func (s *BatchService) validate(ctx context.Context, upload domainBatch.Upload, file domainBatch.File, now time.Time) ([]domainBatch.ItemOrm, error) {
+	exists := map[string]bool{}
+	accountExists := func(number string) (bool, error) {
+		found, ok := exists[number]
+		if ok {
+			return found, nil
+		}
+		_, err := s.db.GetDetailBankAccountByAccountNumber(ctx, number)
+		switch {
+		case err == nil:
+			found = true
+		case errors.Is(err, domainBank.ErrRecordNotFound):
+			found = false
+		default:
+			logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Batch Service - validate")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return false, err
+		}
+		exists[number] = found
+		return found, nil
+	}
+
+	endToEndIds := map[string]int{}
+	items := make([]domainBatch.ItemOrm, 0, len(file.Lines))
+	for _, line := range file.Lines {
+		item := domainBatch.ItemOrm{
+			ItemUuid:              uuid.New(),
+			LineNumber:            line.Number,
+			EndToEndId:            line.EndToEndId,
+			DebtorAccount:         line.DebtorAccount,
+			CreditorAccount:       line.CreditorAccount,
+			Currency:              strings.ToUpper(line.Currency),
+			RemittanceInformation: line.RemittanceInformation,
+			Status:                domainBatch.ItemPending,
+			UpdatedAt:             now,
+		}
+		if item.DebtorAccount == "" {
+			item.DebtorAccount = upload.DebtorAccount
+		}
+		amount, amountValid := domainBatch.ParseAmount(line.Amount)
+		item.Amount = amount
+
+		reject := func(code string, reason string, args ...interface{}) {
+			item.Status = domainBatch.ItemRejected
+			item.ReasonCode = code
+			item.Reason = fmt.Sprintf(reason, args...)
+		}
+		first, duplicate := endToEndIds[item.EndToEndId]
+		switch {
+		case len(item.EndToEndId) > maxIdLength:
+			reject(domainBatch.ReasonNarrative, "end to end id is longer than %d characters", maxIdLength)
+		case item.EndToEndId != "" && duplicate:
+			reject(domainBatch.ReasonDuplicate, "end to end id %v is used by line %d too", item.EndToEndId, first)
+		case !amountValid:
+			reject(domainBatch.ReasonAmount, "amount %q must be positive with 2 decimals at most", line.Amount)
+		case !supportedCurrencies[item.Currency]:
+			reject(domainBatch.ReasonCurrency, "currency %q is not supported", line.Currency)
+		case item.DebtorAccount == "":
+			reject(domainBatch.ReasonDebtorAccount, "no debtor account")
+		case item.CreditorAccount == "":
+			reject(domainBatch.ReasonCreditorAccount, "no creditor account")
+		}
+		if item.EndToEndId != "" && !duplicate {
+			endToEndIds[item.EndToEndId] = item.LineNumber
+		}
+
+		if item.Status == domainBatch.ItemPending {
+			found, err := accountExists(item.DebtorAccount)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				reject(domainBatch.ReasonDebtorAccount, "debtor account %v not found", item.DebtorAccount)
+			}
+		}
+		if item.Status == domainBatch.ItemPending {
+			found, err := accountExists(item.CreditorAccount)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				reject(domainBatch.ReasonCreditorAccount, "creditor account %v not found", item.CreditorAccount)
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// checkFunds rejects the items of the debtors whose spendable balance
+// doesn't cover all their transfers and fees, as ModeAllOrNothing would
+// only roll them back later.
+func (s *BatchService) checkFunds(ctx context.Context, batch domainBatch.BatchOrm, items []domainBatch.ItemOrm) error {
+	debits := map[string]float64{}
+	var debtors []string
+	for _, item := range items {
+		booked, fee, err := s.bank.QuoteTransfer(ctx, domainBank.TransferTransaction{
+			FromAccountNumber: item.DebtorAccount,
+			ToAccountNumber:   item.CreditorAccount,
+			Currency:          item.Currency,
+			Amount:            item.Amount,
+			Channel:           batch.Channel,
+		})
+		if err != nil {
+			logErr := util.LogError(fmt.Sprintf("Error on QuoteTransfer of line %d: %v", item.LineNumber, err), "", "Batch Service - checkFunds")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return err
+		}
+		if _, ok := debits[item.DebtorAccount]; !ok {
+			debtors = append(debtors, item.DebtorAccount)
+		}
+		debits[item.DebtorAccount] += booked + fee.Charged
+	}
+
+	short := map[string]string{}
+	for _, debtor := range debtors {
+		standing, err := s.admin.GetAccountStanding(ctx, debtor)
+		if err != nil {
+			logErr := util.LogError("Error on GetAccountStanding: "+err.Error(), "", "Batch Service - checkFunds")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return err
+		}
+		if debit := math.Round(debits[debtor]*100) / 100; debit > standing.Spendable {
+			short[debtor] = fmt.Sprintf("debtor account %v can spend %.2f, the batch debits %.2f", debtor, standing.Spendable, debit)
+		}
+	}
+
+	for i := range items {
+		if reason, ok := short[items[i].DebtorAccount]; ok {
+			items[i].Status = domainBatch.ItemRejected
+			items[i].ReasonCode = domainBatch.ReasonInsufficientFunds
+			items[i].Reason = reason
+		}
+	}
+
+	return nil
+}
+
+// decide sets the status of batch from the totals and its items: a batch
+// whose totals don't match the file, or of ModeAllOrNothing with a rejected
+// item, is rejected and its valid items cancelled.
+func (s *BatchService) decide(batch *domainBatch.BatchOrm, upload domainBatch.Upload, file domainBatch.File, items []domainBatch.ItemOrm, now time.Time) {
+	for _, declared := range []*int{file.NumberOfTransactions, upload.NumberOfTransactions} {
+		if declared != nil && *declared != batch.NumberOfTransactions && batch.ReasonCode == "" {
+			batch.ReasonCode = domainBatch.ReasonNumberOfTransactions
+			batch.Reason = fmt.Sprintf("%d transactions declared, the file has %d", *declared, batch.NumberOfTransactions)
+		}
+	}
+	for _, declared := range []*float64{file.ControlSum, upload.ControlSum} {
+		if declared != nil && math.Round(*declared*100) != math.Round(batch.ControlSum*100) && batch.ReasonCode == "" {
+			batch.ReasonCode = domainBatch.ReasonControlSum
+			batch.Reason = fmt.Sprintf("control sum %.2f declared, the amounts of the file add up to %.2f", *declared, batch.ControlSum)
+		}
+	}
+
+	n := rejected(items)
+	switch {
+	case batch.ReasonCode != "":
+	case n == len(items):
+		batch.ReasonCode = domainBatch.ReasonNarrative
+		batch.Reason = "every transaction is rejected"
+	case n > 0 && batch.Mode == domainBatch.ModeAllOrNothing:
+		batch.ReasonCode = domainBatch.ReasonNarrative
+		batch.Reason = fmt.Sprintf("%d of %d transactions are rejected", n, len(items))
+	default:
+		return
+	}
+
+	batch.Status = domainBatch.StatusRejected
+	batch.CompletedAt = &now
+	for i := range items {
+		if items[i].Status == domainBatch.ItemPending {
+			items[i].Status = domainBatch.ItemCancelled
+			items[i].ReasonCode = domainBatch.ReasonNarrative
+			items[i].Reason = "batch is rejected"
+		}
+	}
+}
+
+func rejected(items []domainBatch.ItemOrm) int {
+	n := 0
+	for _, item := range items {
+		if item.Status == domainBatch.ItemRejected {
+			n++
+		}
+	}
+
+	return n
+}
+
+func countItems(items []domainBatch.ItemOrm) map[string]int {
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[item.Status]++
+	}
+
+	return counts
+}
+
+// GetTransferBatch returns batch id with the number of its items by status.
+func (s *BatchService) GetTransferBatch(ctx context.Context, id uuid.UUID) (detail domainBatch.BatchDetail, err error) {
+	ctx, span := tracing.Start(ctx, "BatchService.GetTransferBatch")
+	defer tracing.End(span, &err)
+
+	batch, err := s.store.GetTransferBatch(ctx, id)
+	if err != nil {
+		return detail, err
+	}
+	counts, err := s.store.CountTransferBatchItems(ctx, id)
+	if err != nil {
+		return detail, err
+	}
+
+	return domainBatch.BatchDetail{BatchOrm: batch, Counts: counts}, nil
+}
+
+// WriteTransferBatchReport writes the pain.002 status report of batch id as
+// it stands to w.
+func (s *BatchService) WriteTransferBatchReport(ctx context.Context, id uuid.UUID, w io.Writer) (err error) {
+	ctx, span := tracing.Start(ctx, "BatchService.WriteTransferBatchReport")
+	defer tracing.End(span, &err)
+
+	batch, err := s.store.GetTransferBatch(ctx, id)
+	if err != nil {
+		return err
+	}
+	items, err := s.store.ListTransferBatchItems(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return domainBatch.WriteReport(w, batch, items, s.clock.Now())
+}
+
+// Run executes the accepted batches every interval until ctx is done.
+func (s *BatchService) Run(ctx context.Context, interval time.Duration) {
+	ticker := s.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Transfer batches stopped")
+			return
+		case <-ticker.C():
+		}
+
+		if _, err := s.Execute(ctx); err != nil && ctx.Err() == nil {
+			logErr := util.LogError(err.Error(), "", "BatchService - Run")
+			log.Error().Msg(logErr)
+		}
+	}
+}
+
+// Execute runs the claimable batches and returns how many it finished. A
+// batch left unfinished, by a transfer that can't be resolved yet, is
+// claimed again once its lease ends.
+func (s *BatchService) Execute(ctx context.Context) (int, error) {
+	finished := 0
+
+	for {
+		now := s.clock.Now()
+		batches, err := s.store.ClaimTransferBatches(ctx, now, now.Add(s.options.Lease), s.options.BatchSize)
+		if err != nil {
+			return finished, fmt.Errorf("can't claim transfer batches : %v", err)
+		}
+		if len(batches) == 0 {
+			return finished, nil
+		}
+
+		for _, batch := range batches {
+			if ctx.Err() != nil {
+				return finished, ctx.Err()
+			}
+			if s.process(ctx, batch) {
+				finished++
+			}
+		}
+	}
+}
+
+// process executes the pending items of a claimed batch, rolls it back when
+// it has to, and finishes it. It reports whether the batch is finished.
+func (s *BatchService) process(ctx context.Context, batch domainBatch.BatchOrm) bool {
+	items, err := s.store.ListTransferBatchItems(ctx, batch.BatchUuid)
+	if err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't read the items of transfer batch %v : %v", batch.BatchUuid, err), "", "BatchService - process")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return false
+	}
+
+	if batch.Status == domainBatch.StatusProcessing {
+		for i := range items {
+			if items[i].Status != domainBatch.ItemPending {
+				continue
+			}
+			if ctx.Err() != nil || !s.renew(ctx, &batch) || !s.execute(ctx, batch, &items[i]) {
+				return false
+			}
+
+			if items[i].Status == domainBatch.ItemFailed && batch.Mode == domainBatch.ModeAllOrNothing {
+				rollingBack := batch
+				rollingBack.Status = domainBatch.StatusRollingBack
+				rollingBack.ReasonCode = items[i].ReasonCode
+				rollingBack.Reason = fmt.Sprintf("line %d : %v", items[i].LineNumber, items[i].Reason)
+				rollingBack.UpdatedAt = s.clock.Now()
+				if !s.update(ctx, rollingBack, batch.Status) {
+					return false
+				}
+				batch = rollingBack
+				log.Warn().Ctx(ctx).Msgf("Transfer batch %v rolling back, %v", batch.BatchUuid, batch.Reason)
+				break
+			}
+		}
+	}
+
+	if batch.Status == domainBatch.StatusRollingBack {
+		for i := range items {
+			if ctx.Err() != nil || !s.renew(ctx, &batch) {
+				return false
+			}
+
+			var ok bool
+			switch items[i].Status {
+			case domainBatch.ItemPending:
+				ok = s.cancel(ctx, &items[i])
+			case domainBatch.ItemSucceeded:
+				ok = s.reverse(ctx, batch, &items[i])
+			default:
+				continue
+			}
+			if !ok {
+				return false
+			}
+		}
+	}
+
+	return s.finish(ctx, batch, items)
+}
+
+// renew moves the lease of batch ahead once half of it is used, and reports
+// whether the batch is still this worker's.
+func (s *BatchService) renew(ctx context.Context, batch *domainBatch.BatchOrm) bool {
+	now := s.clock.Now()
+	if batch.LeaseUntil != nil && now.Add(s.options.Lease/2).Before(*batch.LeaseUntil) {
+		return true
+	}
+
+	renewed := *batch
+	until := now.Add(s.options.Lease)
+	renewed.LeaseUntil = &until
+	if !s.update(ctx, renewed, batch.Status) {
+		return false
+	}
+	*batch = renewed
+
+	return true
+}
+
+// update stores batch if it is still in status from and reports whether it
+// did.
+func (s *BatchService) update(ctx context.Context, batch domainBatch.BatchOrm, from string) bool {
+	if err := s.store.UpdateTransferBatch(ctx, batch, from); err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't update transfer batch %v : %v", batch.BatchUuid, err), "", "BatchService - update")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return false
+	}
+
+	return true
+}
+
+// execute books the transfer of a pending item and records its outcome. It
+// reports false when the outcome is unknown, the item is left pending then.
+func (s *BatchService) execute(ctx context.Context, batch domainBatch.BatchOrm, item *domainBatch.ItemOrm) bool {
+	done := *item
+	done.UpdatedAt = s.clock.Now()
+
+	// a transfer under the uuid of the item means an earlier claim ran it
+	// and didn't get to record it
+	booked, err := s.db.GetTransfer(ctx, item.ItemUuid)
+	switch {
+	case err == nil:
+		done.TransferUuid = &booked.TransferUuid
+		done.Status = domainBatch.ItemSucceeded
+		if !booked.TransferSuccess {
+			done.Status = domainBatch.ItemFailed
+			done.ReasonCode = domainBatch.ReasonNarrative
+			done.Reason = "transfer was interrupted"
+		}
+	case errors.Is(err, domainBank.ErrRecordNotFound):
+		_, success, _, err := s.bank.Transfer(ctx, domainBank.TransferTransaction{
+			FromAccountNumber: item.DebtorAccount,
+			ToAccountNumber:   item.CreditorAccount,
+			Currency:          item.Currency,
+			Amount:            item.Amount,
+			Notes:             item.RemittanceInformation,
+			Channel:           batch.Channel,
+			TransferUuid:      item.ItemUuid,
+		})
+		if ctx.Err() != nil {
+			// shutting down, the lease ends and the item is resolved then
+			return false
+		}
+		if errors.Is(err, domainBank.ErrTransferRecordFailed) {
+			if _, lookupErr := s.db.GetTransfer(ctx, item.ItemUuid); lookupErr == nil {
+				// booked by a replica whose lease ended, the next claim
+				// records its outcome
+				return false
+			}
+		}
+
+		switch {
+		case err == nil && success:
+			done.Status = domainBatch.ItemSucceeded
+		case err == nil:
+			done.Status = domainBatch.ItemFailed
+			done.ReasonCode = domainBatch.ReasonNarrative
+			done.Reason = "transfer did not succeed"
+		default:
+			done.Status = domainBatch.ItemFailed
+			done.ReasonCode = transferReason(err)
+			done.Reason = err.Error()
+		}
+		if _, lookupErr := s.db.GetTransfer(ctx, item.ItemUuid); lookupErr == nil {
+			done.TransferUuid = &done.ItemUuid
+		}
+	default:
+		logErr := util.LogError(fmt.Sprintf("Can't look up transfer of batch item %v : %v", item.ItemUuid, err), "", "BatchService - execute")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return false
+	}
+
+	return s.record(ctx, item, done)
+}
+
+func transferReason(err error) string {
+	switch {
+	case errors.Is(err, domainBank.ErrInsufficientBalance):
+		return domainBatch.ReasonInsufficientFunds
+	case errors.Is(err, domainBank.ErrTransferSourceAccountNotFound):
+		return domainBatch.ReasonDebtorAccount
+	case errors.Is(err, domainBank.ErrTransferDestinationAccountNotFound):
+		return domainBatch.ReasonCreditorAccount
+	default:
+		return domainBatch.ReasonNarrative
+	}
+}
+
+// cancel gives up a pending item of a batch rolling back.
+func (s *BatchService) cancel(ctx context.Context, item *domainBatch.ItemOrm) bool {
+	done := *item
+	done.Status = domainBatch.ItemCancelled
+	done.ReasonCode = domainBatch.ReasonNarrative
+	done.Reason = "batch is rolled back"
+	done.UpdatedAt = s.clock.Now()
+
+	return s.record(ctx, item, done)
+}
+
+// reverse refunds the transfer of a succeeded item of a batch rolling back.
+// The refund may overdraw the creditor, the batch is undone as a whole. An
+// item whose transfer can't be reversed is recorded as ItemReversalFailed,
+// with why.
+func (s *BatchService) reverse(ctx context.Context, batch domainBatch.BatchOrm, item *domainBatch.ItemOrm) bool {
+	_, _, err := s.bank.ReverseTransfer(ctx, domainBank.TransferReversal{
+		TransferUuid:   item.ItemUuid,
+		Reason:         fmt.Sprintf("transfer batch %v rolled back", batch.MessageId),
+		AllowOverdraft: true,
+	})
+	done := *item
+	done.UpdatedAt = s.clock.Now()
+	switch {
+	case err == nil || errors.Is(err, domainBank.ErrTransferAlreadyReversed):
+		done.Status = domainBatch.ItemReversed
+		done.ReasonCode = domainBatch.ReasonNarrative
+		done.Reason = "transfer is reversed, the batch is rolled back"
+	case errors.Is(err, domainBank.ErrTransferNotReversible):
+		logErr := util.LogError(fmt.Sprintf("Can't reverse transfer of batch item %v : %v", item.ItemUuid, err), "", "BatchService - reverse")
+		log.Error().Ctx(ctx).Msg(logErr)
+		done.Status = domainBatch.ItemReversalFailed
+		done.ReasonCode = domainBatch.ReasonNarrative
+		done.Reason = fmt.Sprintf("transfer can't be reversed : %v", err)
+	default:
+		if ctx.Err() == nil {
+			logErr := util.LogError(fmt.Sprintf("Can't reverse transfer of batch item %v : %v", item.ItemUuid, err), "", "BatchService - reverse")
+			log.Error().Ctx(ctx).Msg(logErr)
+		}
+		return false
+	}
+
+	return s.record(ctx, item, done)
+}
+
+// record stores done in place of item if item wasn't changed meanwhile.
+func (s *BatchService) record(ctx context.Context, item *domainBatch.ItemOrm, done domainBatch.ItemOrm) bool {
+	if err := s.store.UpdateTransferBatchItem(ctx, done, item.Status); err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't record batch item %v : %v", item.ItemUuid, err), "", "BatchService - record")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return false
+	}
+	metrics.TransferBatchItems.WithLabelValues(done.Status).Inc()
+	*item = done
+
+	return true
+}
+
+// finish sets the final status of batch from its items.
+func (s *BatchService) finish(ctx context.Context, batch domainBatch.BatchOrm, items []domainBatch.ItemOrm) bool {
+	counts := countItems(items)
+	now := s.clock.Now()
+
+	done := batch
+	done.LeaseUntil = nil
+	done.UpdatedAt = now
+	done.CompletedAt = &now
+	switch {
+	case batch.Status == domainBatch.StatusRollingBack:
+		done.Status = domainBatch.StatusFailed
+		if irreversible := counts[domainBatch.ItemReversalFailed]; irreversible > 0 {
+			done.Reason = fmt.Sprintf("%v, %d transfers can't be reversed", done.Reason, irreversible)
+		}
+	case counts[domainBatch.ItemSucceeded] == 0:
+		done.Status = domainBatch.StatusFailed
+		done.ReasonCode = domainBatch.ReasonNarrative
+		done.Reason = "no transfer succeeded"
+	case counts[domainBatch.ItemFailed] > 0 || counts[domainBatch.ItemRejected] > 0:
+		done.Status = domainBatch.StatusPartiallyCompleted
+	default:
+		done.Status = domainBatch.StatusCompleted
+	}
+
+	if !s.update(ctx, done, batch.Status) {
+		return false
+	}
+
+	log.Info().Ctx(ctx).Msgf("Transfer batch %v %v, %d of %d transfers succeeded", batch.BatchUuid, strings.ToLower(done.Status), counts[domainBatch.ItemSucceeded], len(items))
+
+	return true
+}
@@ -0,0 +1,440 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/metrics"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/tracing"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultDeliveryPageSize = 50
+	maxDeliveryPageSize     = 500
+)
+
+// WebhookOptions tune the delivery of webhooks.
+type WebhookOptions struct {
+	// Timeout bounds one attempt.
+	Timeout     time.Duration
+	MaxAttempts int
+	// BackoffBase is the wait after the first failed attempt, doubled after
+	// every further one up to BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// BatchSize deliveries are sent concurrently.
+	BatchSize int
+}
+
+// WebhookService manages the webhook subscriptions and delivers the events
+// of the subscribed accounts. It is an EventPublisherPort, so the event relay
+// enqueues the deliveries of every event it moves out of the outbox; Deliver
+// sends them.
+type WebhookService struct {
+	store   port.WebhookStorePort
+	db      port.BankDatabasePort
+	sender  port.WebhookSenderPort
+	clock   clock.Clock
+	options WebhookOptions
+	audit   *Auditor
+}
+
+func NewWebhookService(store port.WebhookStorePort, dbPort port.BankDatabasePort, sender port.WebhookSenderPort, clk clock.Clock, options WebhookOptions) *WebhookService {
+	return &WebhookService{
+		store:   store,
+		db:      dbPort,
+		sender:  sender,
+		clock:   clk,
+		options: options,
+		audit:   auditorOf(store, clk),
+	}
+}
+
+// CreateSubscription subscribes to the events of accountNum, or to those of
+// every account of customerId. Exactly one of them is given.
+func (s *WebhookService) CreateSubscription(ctx context.Context, accountNum string, customerId string, rawURL string, eventTypes []string, secret string) (sub domainWebhook.WebhookSubscriptionOrm, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.CreateSubscription")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "CreateWebhookSubscription")
+	defer s.audit.End(ctx, scope, &err)
+
+	customerId = strings.TrimSpace(customerId)
+	if (accountNum == "") == (customerId == "") {
+		return sub, domainWebhook.ErrScopeInvalid
+	}
+
+	if u, err := url.Parse(rawURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return sub, domainWebhook.ErrInvalidURL
+	}
+
+	types, err := normalizeEventTypes(eventTypes)
+	if err != nil {
+		return sub, err
+	}
+
+	if secret == "" {
+		if secret, err = newWebhookSecret(); err != nil {
+			return sub, err
+		}
+	} else if len(secret) < 16 {
+		return sub, domainWebhook.ErrSecretTooShort
+	}
+
+	sub = domainWebhook.WebhookSubscriptionOrm{
+		SubscriptionUuid: uuid.New(),
+		CustomerId:       customerId,
+		Url:              rawURL,
+		EventTypes:       types,
+		Secret:           secret,
+		CreatedAt:        s.clock.Now(),
+	}
+
+	if accountNum != "" {
+		account, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+		if err != nil {
+			logErr := util.LogError("Error on GetDetailBankAccountByAccountNumber: "+err.Error(), "", "Webhook Service - CreateSubscription")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return domainWebhook.WebhookSubscriptionOrm{}, err
+		}
+		auditAffect(ctx, account.AccountUuid)
+		sub.AccountUuid, sub.AccountNumber = &account.AccountUuid, account.AccountNumber
+	} else {
+		// a customer is known by its accounts only
+		accounts, err := s.db.ListAccountsOfCustomer(ctx, customerId)
+		if err != nil {
+			logErr := util.LogError("Error on ListAccountsOfCustomer: "+err.Error(), "", "Webhook Service - CreateSubscription")
+			log.Error().Ctx(ctx).Msg(logErr)
+			return domainWebhook.WebhookSubscriptionOrm{}, err
+		}
+		if len(accounts) == 0 {
+			return domainWebhook.WebhookSubscriptionOrm{}, domainBank.ErrRecordNotFound
+		}
+		for _, account := range accounts {
+			auditAffect(ctx, account.AccountUuid)
+		}
+	}
+
+	if err := s.store.CreateWebhookSubscription(ctx, sub); err != nil {
+		return domainWebhook.WebhookSubscriptionOrm{}, err
+	}
+	auditAffect(ctx, sub.SubscriptionUuid)
+
+	if customerId != "" {
+		log.Info().Ctx(ctx).Msgf("Webhook subscription %v created for customer %v", sub.SubscriptionUuid, customerId)
+	} else {
+		log.Info().Ctx(ctx).Msgf("Webhook subscription %v created for account %v", sub.SubscriptionUuid, accountNum)
+	}
+
+	return sub, nil
+}
+
+// ListSubscriptions returns the subscriptions of accountNum or of customerId,
+// every subscription when both are empty.
+func (s *WebhookService) ListSubscriptions(ctx context.Context, accountNum string, customerId string) (subs []domainWebhook.WebhookSubscriptionOrm, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.ListSubscriptions")
+	defer tracing.End(span, &err)
+
+	customerId = strings.TrimSpace(customerId)
+	if accountNum != "" && customerId != "" {
+		return nil, domainWebhook.ErrScopeInvalid
+	}
+
+	accountUuid := uuid.Nil
+	if accountNum != "" {
+		account, err := s.db.GetDetailBankAccountByAccountNumber(ctx, accountNum)
+		if err != nil {
+			return nil, err
+		}
+		accountUuid = account.AccountUuid
+	}
+
+	return s.store.ListWebhookSubscriptions(ctx, accountUuid, customerId)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.DeleteSubscription")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "DeleteWebhookSubscription")
+	defer s.audit.End(ctx, scope, &err)
+	auditAffect(ctx, id)
+
+	if err := s.store.DeleteWebhookSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Webhook subscription %v deleted", id)
+
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, filter domainWebhook.DeliveryFilter) (deliveries []domainWebhook.WebhookDeliveryOrm, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.ListDeliveries")
+	defer tracing.End(span, &err)
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeliveryPageSize
+	} else if filter.Limit > maxDeliveryPageSize {
+		filter.Limit = maxDeliveryPageSize
+	}
+
+	return s.store.ListWebhookDeliveries(ctx, filter)
+}
+
+// Redeliver sends a delivery again on the next Deliver run, with all its
+// attempts, whatever its state.
+func (s *WebhookService) Redeliver(ctx context.Context, id uuid.UUID) (delivery domainWebhook.WebhookDeliveryOrm, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Redeliver")
+	defer tracing.End(span, &err)
+	ctx, scope := s.audit.Start(ctx, "RedeliverWebhook")
+	defer s.audit.End(ctx, scope, &err)
+	auditAffect(ctx, id)
+
+	delivery, err = s.store.ScheduleWebhookDelivery(ctx, id, s.clock.Now())
+	if err != nil {
+		return delivery, err
+	}
+
+	log.Info().Ctx(ctx).Msgf("Webhook delivery %v scheduled again", id)
+
+	return delivery, nil
+}
+
+// Publish enqueues a delivery of e for every subscription of the accounts it
+// concerns and of their customers.
+func (s *WebhookService) Publish(ctx context.Context, e domainEvent.Event) error {
+	accounts, err := e.Accounts()
+	if err != nil || len(accounts) == 0 {
+		return err
+	}
+
+	subs, err := s.store.WebhookSubscriptionsOfAccounts(ctx, accounts)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	now := s.clock.Now()
+
+	var deliveries []domainWebhook.WebhookDeliveryOrm
+	for _, sub := range subs {
+		if !sub.Subscribes(e.Type) {
+			continue
+		}
+		deliveries = append(deliveries, domainWebhook.WebhookDeliveryOrm{
+			DeliveryUuid:     uuid.New(),
+			SubscriptionUuid: sub.SubscriptionUuid,
+			EventUuid:        e.ID,
+			EventType:        e.Type,
+			Payload:          string(body),
+			State:            domainWebhook.StatePending,
+			NextAttemptAt:    now,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		})
+	}
+
+	return s.store.EnqueueWebhookDeliveries(ctx, deliveries)
+}
+
+func (s *WebhookService) Close() error {
+	return nil
+}
+
+// Run sends the due deliveries every interval until ctx is done.
+func (s *WebhookService) Run(ctx context.Context, interval time.Duration) {
+	ticker := s.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Webhook delivery stopped")
+			return
+		case <-ticker.C():
+		}
+
+		if _, err := s.Deliver(ctx); err != nil && ctx.Err() == nil {
+			logErr := util.LogError(err.Error(), "", "WebhookService - Run")
+			log.Error().Msg(logErr)
+		}
+	}
+}
+
+// Deliver sends every due delivery once and returns how many succeeded.
+// Failed deliveries are due again after their backoff, or dead once they used
+// MaxAttempts.
+func (s *WebhookService) Deliver(ctx context.Context) (int, error) {
+	delivered := 0
+
+	for {
+		now := s.clock.Now()
+		// a claim outlives the attempts of the batch, which run concurrently,
+		// and is due again if this process dies before recording them
+		deliveries, err := s.store.ClaimWebhookDeliveries(ctx, now, now.Add(2*s.options.Timeout), s.options.BatchSize)
+		if err != nil {
+			return delivered, fmt.Errorf("can't claim webhook deliveries : %v", err)
+		}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, d := range deliveries {
+			d := d
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if s.attempt(ctx, d) {
+					mu.Lock()
+					delivered++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+
+		if len(deliveries) < s.options.BatchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// attempt sends d once, records the outcome and reports whether it was
+// delivered.
+func (s *WebhookService) attempt(ctx context.Context, d domainWebhook.WebhookDeliveryOrm) bool {
+	body := []byte(d.Payload)
+	sentAt := s.clock.Now()
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(domainWebhook.SignatureHeader, domainWebhook.Sign(d.Subscription.Secret, sentAt, body))
+	header.Set(domainWebhook.EventIDHeader, d.EventUuid.String())
+	header.Set(domainWebhook.EventTypeHeader, d.EventType)
+	header.Set(domainWebhook.DeliveryIDHeader, d.DeliveryUuid.String())
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.options.Timeout)
+	status, err := s.sender.Send(sendCtx, d.Subscription.Url, header, body)
+	cancel()
+
+	if ctx.Err() != nil {
+		// shutting down, the claim expires and the next run sends it again
+		return false
+	}
+
+	if err == nil && (status < 200 || status > 299) {
+		err = fmt.Errorf("unexpected response status %d", status)
+	}
+
+	finishedAt := s.clock.Now()
+	attempt := domainWebhook.WebhookAttemptOrm{
+		AttemptedAt: sentAt,
+		StatusCode:  status,
+		DurationMs:  finishedAt.Sub(sentAt).Milliseconds(),
+	}
+
+	d.AttemptCount++
+	d.UpdatedAt = finishedAt
+
+	var result string
+	switch {
+	case err == nil:
+		d.State = domainWebhook.StateDelivered
+		d.LastError = ""
+		result = metrics.WebhookResultDelivered
+	case d.AttemptCount >= s.options.MaxAttempts:
+		d.State = domainWebhook.StateDead
+		d.LastError = err.Error()
+		result = metrics.WebhookResultDead
+	default:
+		d.NextAttemptAt = finishedAt.Add(s.backoff(d.AttemptCount))
+		d.LastError = err.Error()
+		result = metrics.WebhookResultRetry
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	if err := s.store.RecordWebhookAttempt(ctx, d, attempt); err != nil {
+		logErr := util.LogError(fmt.Sprintf("Can't record attempt of webhook delivery %v : %v", d.DeliveryUuid, err), "", "WebhookService - attempt")
+		log.Error().Ctx(ctx).Msg(logErr)
+		return false
+	}
+	metrics.WebhookAttempts.WithLabelValues(result).Inc()
+
+	if result == metrics.WebhookResultDead {
+		log.Warn().Ctx(ctx).Msgf("Webhook delivery %v is dead after %d attempts : %v", d.DeliveryUuid, d.AttemptCount, d.LastError)
+	}
+
+	return result == metrics.WebhookResultDelivered
+}
+
+// backoff is the wait after the attempts-th failed attempt.
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	wait := s.options.BackoffBase
+	for i := 1; i < attempts && wait < s.options.BackoffMax; i++ {
+		wait *= 2
+	}
+
+	if wait > s.options.BackoffMax {
+		return s.options.BackoffMax
+	}
+
+	return wait
+}
+
+// normalizeEventTypes checks eventTypes against domainWebhook.EventTypes and
+// drops duplicates.
+func normalizeEventTypes(eventTypes []string) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("%w : at least one is required", domainWebhook.ErrUnknownEventType)
+	}
+
+	seen := map[string]bool{}
+	var types []string
+	for _, t := range eventTypes {
+		known := false
+		for _, k := range domainWebhook.EventTypes {
+			known = known || t == k
+		}
+		if !known {
+			return nil, fmt.Errorf("%w : %q", domainWebhook.ErrUnknownEventType, t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+
+	return types, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("can't generate webhook secret : %v", err)
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
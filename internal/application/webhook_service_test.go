@@ -0,0 +1,281 @@
+package application_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/memory"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/adapter/webhook"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/clock"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port/porttest"
+)
+
+// webhookReceiver verifies the signature of every request like a customer
+// endpoint would and answers with status.
+type webhookReceiver struct {
+	t      *testing.T
+	clk    clock.Clock
+	secret string
+
+	mu       sync.Mutex
+	status   int
+	received []domainEvent.Event
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	if err := domainWebhook.Verify(r.secret, req.Header.Get(domainWebhook.SignatureHeader), body, r.clk.Now(), 5*time.Minute); err != nil {
+		r.t.Errorf("Verify: %v", err)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var e domainEvent.Event
+	if err := json.Unmarshal(body, &e); err != nil {
+		r.t.Errorf("decode %s: %v", body, err)
+	}
+	if req.Header.Get(domainWebhook.EventIDHeader) != e.ID.String() || req.Header.Get(domainWebhook.EventTypeHeader) != e.Type {
+		r.t.Errorf("headers %v don't match event %v %v", req.Header, e.Type, e.ID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, e)
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookReceiver) respond(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *webhookReceiver) events() []domainEvent.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domainEvent.Event(nil), r.received...)
+}
+
+type webhookFixture struct {
+	clk      *clock.Fake
+	store    *memory.MemoryAdapter
+	bank     *application.BankService
+	webhooks *application.WebhookService
+	relay    *application.EventRelay
+	receiver *webhookReceiver
+	url      string
+	account  domainBank.BankAccountOrm
+}
+
+func newWebhookFixture(t *testing.T) *webhookFixture {
+	t.Helper()
+
+	clk := clock.NewFake(time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC))
+	store := memory.NewMemoryAdapter()
+	acc := porttest.NewAccount(100)
+	if err := store.Seed(acc); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	webhooks := application.NewWebhookService(store, store, webhook.NewHTTPSender(), clk, application.WebhookOptions{
+		Timeout:     5 * time.Second,
+		MaxAttempts: 3,
+		BackoffBase: 10 * time.Second,
+		BackoffMax:  15 * time.Second,
+		BatchSize:   10,
+	})
+
+	receiver := &webhookReceiver{t: t, clk: clk, secret: "whsec_0123456789abcdef", status: http.StatusNoContent}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	return &webhookFixture{
+		clk:      clk,
+		store:    store,
+		bank:     application.NewBankService(store, clk),
+		webhooks: webhooks,
+		relay:    application.NewEventRelay(store, webhooks, clk, 10),
+		receiver: receiver,
+		url:      server.URL,
+		account:  acc,
+	}
+}
+
+// deposit posts amount and relays its events to the webhooks.
+func (f *webhookFixture) deposit(t *testing.T, amount float64) {
+	t.Helper()
+
+	trx := domainBank.Transaction{Amount: amount, TransactionType: domainBank.TransactionTypeIn}
+	if _, err := f.bank.CreateTransaction(context.Background(), f.account.AccountNumber, trx); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+	if _, err := f.relay.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+}
+
+func (f *webhookFixture) deliveries(t *testing.T) []domainWebhook.WebhookDeliveryOrm {
+	t.Helper()
+
+	deliveries, err := f.webhooks.ListDeliveries(context.Background(), domainWebhook.DeliveryFilter{})
+	if err != nil {
+		t.Fatalf("ListDeliveries: %v", err)
+	}
+
+	return deliveries
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	f := newWebhookFixture(t)
+
+	if _, err := f.webhooks.CreateSubscription(ctx, f.account.AccountNumber, "", f.url,
+		[]string{domainEvent.TypeTransactionCreated}, f.receiver.secret); err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+
+	f.deposit(t, 25)
+	// nor are events of other types
+	e, _ := domainEvent.New(domainEvent.TypeTransferCompleted, f.account.AccountUuid, f.clk.Now(), domainEvent.Transfer{FromAccountUuid: f.account.AccountUuid})
+	if err := f.webhooks.Publish(ctx, e); err != nil {
+		t.Fatalf("Publish of an unsubscribed type: %v", err)
+	}
+
+	n, err := f.webhooks.Deliver(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("Deliver = %d, %v; want 1, nil", n, err)
+	}
+
+	got := f.receiver.events()
+	if len(got) != 1 || got[0].Type != domainEvent.TypeTransactionCreated {
+		t.Fatalf("received %+v, want one TransactionCreated", got)
+	}
+	var data domainEvent.TransactionCreated
+	if err := json.Unmarshal(got[0].Data, &data); err != nil || data.Amount != 25 || data.TransactionType != domainBank.TransactionTypeIn {
+		t.Errorf("received data %s, want the deposit of 25", got[0].Data)
+	}
+
+	deliveries := f.deliveries(t)
+	if len(deliveries) != 1 || deliveries[0].State != domainWebhook.StateDelivered || len(deliveries[0].Attempts) != 1 ||
+		deliveries[0].Attempts[0].StatusCode != http.StatusNoContent {
+		t.Errorf("deliveries = %+v, want one DELIVERED after one 204", deliveries)
+	}
+
+	// an event relayed again is not delivered twice
+	if err := f.webhooks.Publish(ctx, got[0]); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if n, err := f.webhooks.Deliver(ctx); err != nil || n != 0 {
+		t.Errorf("second Deliver = %d, %v; want nothing left", n, err)
+	}
+}
+
+func TestWebhookRetriesUntilDead(t *testing.T) {
+	ctx := context.Background()
+	f := newWebhookFixture(t)
+	f.receiver.respond(http.StatusServiceUnavailable)
+
+	if _, err := f.webhooks.CreateSubscription(ctx, f.account.AccountNumber, "", f.url,
+		[]string{domainEvent.TypeTransactionCreated}, f.receiver.secret); err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+	f.deposit(t, 10)
+
+	// attempts at 0s, 10s and 10s+15s (the backoff is capped)
+	for i, wait := range []time.Duration{0, 10 * time.Second, 15 * time.Second} {
+		f.clk.Advance(wait - time.Millisecond)
+		if _, err := f.webhooks.Deliver(ctx); err != nil {
+			t.Fatalf("Deliver: %v", err)
+		}
+		if got := len(f.receiver.events()); got != i {
+			t.Fatalf("%d attempts before the backoff of attempt %d passed, want %d", got, i+1, i)
+		}
+
+		f.clk.Advance(time.Millisecond)
+		if _, err := f.webhooks.Deliver(ctx); err != nil {
+			t.Fatalf("Deliver: %v", err)
+		}
+		if got := len(f.receiver.events()); got != i+1 {
+			t.Fatalf("%d attempts, want %d", got, i+1)
+		}
+	}
+
+	deliveries := f.deliveries(t)
+	if len(deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(deliveries))
+	}
+	dead := deliveries[0]
+	if dead.State != domainWebhook.StateDead || dead.AttemptCount != 3 || len(dead.Attempts) != 3 || !strings.Contains(dead.LastError, "503") {
+		t.Fatalf("delivery = %+v, want DEAD after 3 attempts answered with 503", dead)
+	}
+
+	f.clk.Advance(time.Hour)
+	if _, err := f.webhooks.Deliver(ctx); err != nil || len(f.receiver.events()) != 3 {
+		t.Fatalf("a dead delivery was sent again, %d attempts, %v", len(f.receiver.events()), err)
+	}
+
+	f.receiver.respond(http.StatusOK)
+	redelivered, err := f.webhooks.Redeliver(ctx, dead.DeliveryUuid)
+	if err != nil || redelivered.State != domainWebhook.StatePending || redelivered.AttemptCount != 0 {
+		t.Fatalf("Redeliver = %+v, %v; want a PENDING delivery", redelivered, err)
+	}
+	if n, err := f.webhooks.Deliver(ctx); err != nil || n != 1 {
+		t.Fatalf("Deliver after Redeliver = %d, %v; want 1, nil", n, err)
+	}
+	if d := f.deliveries(t)[0]; d.State != domainWebhook.StateDelivered || len(d.Attempts) != 4 {
+		t.Errorf("redelivered delivery = %+v, want DELIVERED with all 4 attempts", d)
+	}
+}
+
+func TestWebhookCreateSubscription(t *testing.T) {
+	ctx := context.Background()
+	f := newWebhookFixture(t)
+	types := []string{domainEvent.TypeTransactionCreated}
+
+	tests := []struct {
+		name       string
+		account    string
+		customer   string
+		url        string
+		eventTypes []string
+		secret     string
+		want       error
+	}{
+		{"relative url", f.account.AccountNumber, "", "/hook", types, "", domainWebhook.ErrInvalidURL},
+		{"ftp url", f.account.AccountNumber, "", "ftp://example.com/hook", types, "", domainWebhook.ErrInvalidURL},
+		{"no event types", f.account.AccountNumber, "", f.url, nil, "", domainWebhook.ErrUnknownEventType},
+		{"exchange rates", f.account.AccountNumber, "", f.url, []string{domainEvent.TypeExchangeRateCreated}, "", domainWebhook.ErrUnknownEventType},
+		{"short secret", f.account.AccountNumber, "", f.url, types, "secret", domainWebhook.ErrSecretTooShort},
+		{"unknown account", "0000000000", "", f.url, types, "", domainBank.ErrRecordNotFound},
+		{"no scope", "", "", f.url, types, "", domainWebhook.ErrScopeInvalid},
+		{"account and customer", f.account.AccountNumber, "CUST-1", f.url, types, "", domainWebhook.ErrScopeInvalid},
+		{"customer without accounts", "", "CUST-NONE", f.url, types, "", domainBank.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.webhooks.CreateSubscription(ctx, tt.account, tt.customer, tt.url, tt.eventTypes, tt.secret); !errors.Is(err, tt.want) {
+				t.Errorf("CreateSubscription: error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	sub, err := f.webhooks.CreateSubscription(ctx, f.account.AccountNumber, "", f.url, append(types, types...), "")
+	if err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+	if !strings.HasPrefix(sub.Secret, "whsec_") || len(sub.EventTypes) != 1 {
+		t.Errorf("subscription = %+v, want a generated secret and the event type once", sub)
+	}
+}
@@ -0,0 +1,75 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestFakeAfter(t *testing.T) {
+	f := NewFake(epoch)
+	c := f.After(3 * time.Second)
+
+	f.Advance(2 * time.Second)
+	select {
+	case <-c:
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	f.Advance(time.Second)
+	select {
+	case got := <-c:
+		if want := epoch.Add(3 * time.Second); !got.Equal(want) {
+			t.Errorf("fired at %v, want %v", got, want)
+		}
+	default:
+		t.Fatal("timer didn't fire")
+	}
+
+	if got := f.Now(); !got.Equal(epoch.Add(3 * time.Second)) {
+		t.Errorf("Now() = %v", got)
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	f := NewFake(epoch)
+	ticker := f.NewTicker(time.Second)
+
+	for i := 1; i <= 3; i++ {
+		f.Advance(time.Second)
+		got := <-ticker.C()
+		if want := epoch.Add(time.Duration(i) * time.Second); !got.Equal(want) {
+			t.Errorf("tick %d at %v, want %v", i, got, want)
+		}
+	}
+
+	// ticks that aren't received are dropped, like time.Ticker
+	f.Advance(5 * time.Second)
+	if got := <-ticker.C(); !got.Equal(epoch.Add(4 * time.Second)) {
+		t.Errorf("buffered tick at %v, want the first missed one", got)
+	}
+
+	ticker.Stop()
+	f.Advance(time.Second)
+	select {
+	case <-ticker.C():
+		t.Error("stopped ticker ticked")
+	default:
+	}
+}
+
+func TestFakeBlockUntil(t *testing.T) {
+	f := NewFake(epoch)
+	done := make(chan struct{})
+
+	go func() {
+		<-f.After(time.Minute)
+		close(done)
+	}()
+
+	f.BlockUntil(1)
+	f.Advance(time.Minute)
+	<-done
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	rpcTypeUnary        = "unary"
+	rpcTypeClientStream = "client_stream"
+	rpcTypeServerStream = "server_stream"
+	rpcTypeBidiStream   = "bidi_stream"
+)
+
+// UnaryServerInterceptor counts and times unary RPCs.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe(info.FullMethod, rpcTypeUnary, err, start)
+
+	return resp, err
+}
+
+// StreamServerInterceptor counts and times streaming RPCs and tracks how many
+// of them are open.
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	rpcType := rpcTypeBidiStream
+	switch {
+	case info.IsClientStream && !info.IsServerStream:
+		rpcType = rpcTypeClientStream
+	case !info.IsClientStream && info.IsServerStream:
+		rpcType = rpcTypeServerStream
+	}
+
+	active := ActiveStreams.WithLabelValues(info.FullMethod)
+	active.Inc()
+	defer active.Dec()
+
+	start := time.Now()
+	err := handler(srv, ss)
+	observe(info.FullMethod, rpcType, err, start)
+
+	return err
+}
+
+func observe(method, rpcType string, err error, start time.Time) {
+	code := status.Code(err).String()
+
+	RPCRequests.WithLabelValues(method, rpcType, code).Inc()
+	RPCDuration.WithLabelValues(method, rpcType, code).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,194 @@
+// Package metrics holds the Prometheus collectors of the bank server and the
+// HTTP endpoint that exposes them.
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "bank"
+
+var (
+	// Registry is the registry served on /metrics. A dedicated registry keeps
+	// the exposition free of collectors registered by third-party packages.
+	Registry = prometheus.NewRegistry()
+
+	RPCRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "gRPC requests handled, by method, RPC type and status code.",
+	}, []string{"method", "type", "code"})
+
+	RPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC request latency, by method, RPC type and status code. Streams are measured from open to close.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300},
+	}, []string{"method", "type", "code"})
+
+	ActiveStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "active_streams",
+		Help:      "Streams currently open, by method.",
+	}, []string{"method"})
+
+	Transfers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Transfers processed, by currency and status.",
+	}, []string{"currency", "status"})
+
+	TransferAmount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_amount_total",
+		Help:      "Sum of transferred amounts in the requested currency, by currency and status.",
+	}, []string{"currency", "status"})
+
+	TransferFees = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_fee_amount_total",
+		Help:      "Sum of fees charged on transfers in the requested currency, by currency.",
+	}, []string{"currency"})
+
+	InsufficientBalance = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "insufficient_balance_rejections_total",
+		Help:      "Debits rejected because the account balance was too low, by operation.",
+	}, []string{"operation"})
+
+	ExchangeRate = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "exchange_rate",
+		Help:      "Latest exchange rate stored for a currency pair.",
+	}, []string{"from_currency", "to_currency"})
+
+	RateGeneratorLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "exchange_rate_generator_lag_seconds",
+		Help:      "Time between a rate generator tick and the rate being stored.",
+	}, []string{"from_currency", "to_currency"})
+
+	EventsPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "events_published_total",
+		Help:      "Outbox events accepted by the publisher, by event type.",
+	}, []string{"type"})
+
+	EventPublishFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "publish_failures_total",
+		Help:      "Outbox events the publisher rejected, by event type. They are retried on the next relay run.",
+	}, []string{"type"})
+
+	OutboxLag = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "lag_seconds",
+		Help:      "Age of the oldest unpublished event seen by the last relay run.",
+	})
+
+	WebhookAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "attempts_total",
+		Help:      "Webhook delivery attempts, by result (delivered, retry, dead).",
+	}, []string{"result"})
+
+	ScheduledTransferExecutions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduled_transfer",
+		Name:      "executions_total",
+		Help:      "Executions of scheduled transfers, by status (SUCCEEDED, RETRY, FAILED).",
+	}, []string{"status"})
+
+	TransferBatchItems = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "transfer_batch",
+		Name:      "items_total",
+		Help:      "Executed items of transfer batches, by status (SUCCEEDED, FAILED, CANCELLED, REVERSED).",
+	}, []string{"status"})
+
+	Leader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "leader",
+		Help:      "1 while this replica holds the leader lock and runs the singleton background jobs, 0 otherwise.",
+	})
+
+	InterestCapitalized = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "interest",
+		Name:      "capitalized_amount_total",
+		Help:      "Interest posted to accounts, by currency.",
+	}, []string{"currency"})
+
+	TaxWithheld = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "interest",
+		Name:      "tax_withheld_amount_total",
+		Help:      "Tax withheld from posted interest, by currency.",
+	}, []string{"currency"})
+
+	AuditFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "audit",
+		Name:      "append_failures_total",
+		Help:      "Operations whose audit entry could not be written.",
+	})
+)
+
+const (
+	TransferStatusSuccess = "success"
+	TransferStatusFailed  = "failed"
+)
+
+const (
+	WebhookResultDelivered = "delivered"
+	WebhookResultRetry     = "retry"
+	WebhookResultDead      = "dead"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		RPCRequests,
+		RPCDuration,
+		ActiveStreams,
+		Transfers,
+		TransferAmount,
+		TransferFees,
+		InsufficientBalance,
+		ExchangeRate,
+		RateGeneratorLag,
+		EventsPublished,
+		EventPublishFailures,
+		OutboxLag,
+		WebhookAttempts,
+		ScheduledTransferExecutions,
+		TransferBatchItems,
+		Leader,
+		InterestCapitalized,
+		TaxWithheld,
+		AuditFailures,
+	)
+}
+
+// RegisterDB exposes the connection pool statistics of db (sql.DB.Stats) as
+// go_sql_* metrics labelled with dbName. The returned func removes them again
+// before the pool is closed.
+func RegisterDB(db *sql.DB, dbName string) (func(), error) {
+	collector := collectors.NewDBStatsCollector(db, dbName)
+	if err := Registry.Register(collector); err != nil {
+		return nil, err
+	}
+
+	return func() { Registry.Unregister(collector) }, nil
+}
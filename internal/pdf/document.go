@@ -0,0 +1,275 @@
+// Package pdf renders the printable documents of the bank, statements and
+// transfer receipts, as PDF. It writes PDF 1.4 itself, with the standard
+// Helvetica fonts so nothing is embedded, and no compression so the output
+// is the same on every run.
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A4 in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Object numbers of the objects every document has, the ones of the pages
+// follow.
+const (
+	catalogObject = iota + 1
+	pagesObject
+	regularFontObject
+	boldFontObject
+	pageCountObject
+	infoObject
+	firstPageObject
+)
+
+// Info describes a document in its metadata.
+type Info struct {
+	Title     string
+	CreatedAt time.Time
+}
+
+// Color is an RGB color, every component from 0 to 1.
+type Color struct {
+	R, G, B float64
+}
+
+var (
+	Black = Color{0, 0, 0}
+	White = Color{1, 1, 1}
+	Gray  = Color{0.45, 0.45, 0.45}
+)
+
+// ParseColor reads a color written #rrggbb.
+func ParseColor(s string) (Color, error) {
+	if len(s) != 7 || s[0] != '#' {
+		return Color{}, fmt.Errorf("color %q must be written #rrggbb", s)
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("color %q must be written #rrggbb", s)
+	}
+
+	return Color{float64(v>>16) / 255, float64(v>>8&0xff) / 255, float64(v&0xff) / 255}, nil
+}
+
+// countingWriter knows the offset of what is written next.
+type countingWriter struct {
+	w      io.Writer
+	offset int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.offset += int64(n)
+	return n, err
+}
+
+// Document writes a PDF page by page: a page is written once the next one
+// is added, so only the page being drawn is held in memory. Coordinates are
+// in points from the bottom left corner of the page. The first error
+// writing sticks and is returned by Close.
+type Document struct {
+	w       *countingWriter
+	info    Info
+	offsets map[int]int64
+	pages   []int
+	content *bytes.Buffer
+	// pageCount is the style PageCount draws the number of pages with.
+	pageCount *textStyle
+	err       error
+}
+
+type textStyle struct {
+	font Font
+	size float64
+}
+
+// NewDocument starts a document written to w.
+func NewDocument(w io.Writer, info Info) *Document {
+	d := &Document{w: &countingWriter{w: w}, info: info, offsets: map[int]int64{}}
+
+	// the binary comment marks the file as binary to transfer programs
+	d.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+	d.object(regularFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	d.object(boldFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	return d
+}
+
+func (d *Document) printf(format string, args ...interface{}) {
+	if d.err != nil {
+		return
+	}
+	_, d.err = fmt.Fprintf(d.w, format, args...)
+}
+
+func (d *Document) object(num int, body string) {
+	d.offsets[num] = d.w.offset
+	d.printf("%d 0 obj\n%s\nendobj\n", num, body)
+}
+
+func (d *Document) stream(num int, dict string, data []byte) {
+	d.offsets[num] = d.w.offset
+	d.printf("%d 0 obj\n<< %s/Length %d >>\nstream\n", num, dict, len(data))
+	if d.err == nil {
+		_, d.err = d.w.Write(data)
+	}
+	d.printf("\nendstream\nendobj\n")
+}
+
+// AddPage writes the page drawn so far, if any, and starts a blank one.
+func (d *Document) AddPage() {
+	d.flushPage()
+	d.content = &bytes.Buffer{}
+}
+
+// Pages is the number of pages added so far.
+func (d *Document) Pages() int {
+	return len(d.pages) + boolInt(d.content != nil)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (d *Document) flushPage() {
+	if d.content == nil {
+		return
+	}
+
+	contents := firstPageObject + 2*len(d.pages)
+	page := contents + 1
+	d.stream(contents, "", bytes.TrimSuffix(d.content.Bytes(), []byte("\n")))
+	d.object(page, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] "+
+		"/Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> /XObject << /PageCount %d 0 R >> >> /Contents %d 0 R >>",
+		pagesObject, num(PageWidth), num(PageHeight), regularFontObject, boldFontObject, pageCountObject, contents))
+	d.pages = append(d.pages, page)
+	d.content = nil
+}
+
+// num writes v with 2 decimals at most.
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func (d *Document) draw(format string, args ...interface{}) {
+	if d.content == nil {
+		d.err = errors.Join(d.err, errors.New("pdf: drawing before AddPage"))
+		return
+	}
+	fmt.Fprintf(d.content, format+"\n", args...)
+}
+
+func (c Color) fill() string {
+	return num(c.R) + " " + num(c.G) + " " + num(c.B) + " rg"
+}
+
+func (c Color) stroke() string {
+	return num(c.R) + " " + num(c.G) + " " + num(c.B) + " RG"
+}
+
+// Text draws s starting at x, on the baseline at y.
+func (d *Document) Text(x float64, y float64, font Font, size float64, c Color, s string) {
+	d.draw("BT /%s %s Tf %s %s %s Td %s Tj ET", font.resource(), num(size), c.fill(), num(x), num(y), literal(s))
+}
+
+// TextRight draws s ending at x.
+func (d *Document) TextRight(x float64, y float64, font Font, size float64, c Color, s string) {
+	d.Text(x-font.Width(s, size), y, font, size, c, s)
+}
+
+// Line draws a line from x1, y1 to x2, y2.
+func (d *Document) Line(x1 float64, y1 float64, x2 float64, y2 float64, width float64, c Color) {
+	d.draw("%s %s w %s %s m %s %s l S", c.stroke(), num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+// Rect fills the rectangle of width w and height h whose bottom left corner
+// is at x, y.
+func (d *Document) Rect(x float64, y float64, w float64, h float64, c Color) {
+	d.draw("%s %s %s %s %s re f", c.fill(), num(x), num(y), num(w), num(h))
+}
+
+// PageCount draws the number of pages of the document, known only once it
+// is closed, starting at x. Every page count of a document is drawn with
+// the font and size of the first one.
+func (d *Document) PageCount(x float64, y float64, font Font, size float64, c Color) {
+	if d.pageCount == nil {
+		d.pageCount = &textStyle{font: font, size: size}
+	}
+	d.draw("q %s 1 0 0 1 %s %s cm /PageCount Do Q", c.fill(), num(x), num(y))
+}
+
+// Close writes the last page and what describes the document.
+func (d *Document) Close() error {
+	d.flushPage()
+	if len(d.pages) == 0 {
+		return errors.Join(d.err, errors.New("pdf: a document needs a page"))
+	}
+
+	kids := make([]string, len(d.pages))
+	for i, page := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+	d.object(pagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	style := textStyle{font: Regular, size: 10}
+	if d.pageCount != nil {
+		style = *d.pageCount
+	}
+	count := strconv.Itoa(len(d.pages))
+	d.stream(pageCountObject, fmt.Sprintf("/Type /XObject /Subtype /Form /BBox [0 %s %s %s] /Resources << /Font << /%s %d 0 R >> >> ",
+		num(-style.size/2), num(style.font.Width(count, style.size)), num(style.size), style.font.resource(), style.font.object()),
+		[]byte(fmt.Sprintf("BT /%s %s Tf %s Tj ET", style.font.resource(), num(style.size), literal(count))))
+
+	created := d.info.CreatedAt.UTC().Format("20060102150405")
+	d.object(infoObject, fmt.Sprintf("<< /Title %s /Producer (go-grpc-micro-bank-server) /CreationDate (D:%sZ) >>", literal(d.info.Title), created))
+	d.object(catalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObject))
+
+	size := firstPageObject + 2*len(d.pages)
+	xref := d.w.offset
+	d.printf("xref\n0 %d\n0000000000 65535 f \n", size)
+	for i := 1; i < size; i++ {
+		d.printf("%010d 00000 n \n", d.offsets[i])
+	}
+	d.printf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, catalogObject, infoObject, xref)
+
+	return d.err
+}
+
+// literal writes s as a PDF string in WinAnsiEncoding, characters it has
+// none for as ?.
+func literal(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			// WinAnsiEncoding is Latin-1 from 0xa0 on
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package pdf
+
+// Font is one of the standard fonts every PDF reader has.
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+// widths of the printable ASCII characters, from the space on, in
+// thousandths of the font size, from the Adobe font metrics.
+var widths = map[Font][95]int{
+	Regular: {
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	Bold: {
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+}
+
+// defaultWidth is the width of the characters outside printable ASCII, close
+// enough for the accented letters of Latin-1.
+const defaultWidth = 556
+
+func (f Font) resource() string {
+	if f == Bold {
+		return "F2"
+	}
+	return "F1"
+}
+
+func (f Font) object() int {
+	if f == Bold {
+		return boldFontObject
+	}
+	return regularFontObject
+}
+
+// Width is the width of s drawn in f at size.
+func (f Font) Width(s string, size float64) float64 {
+	table := widths[f]
+	total := 0
+	for _, r := range s {
+		if r >= 32 && r < 127 {
+			total += table[r-32]
+		} else {
+			total += defaultWidth
+		}
+	}
+
+	return float64(total) * size / 1000
+}
+
+// Truncate shortens s, ending it with ..., until it is at most width wide
+// drawn in f at size.
+func (f Font) Truncate(s string, size float64, width float64) string {
+	if f.Width(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if t := string(runes) + "..."; f.Width(t, size) <= width {
+			return t
+		}
+	}
+
+	return ""
+}
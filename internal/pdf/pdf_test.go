@@ -0,0 +1,161 @@
+package pdf_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainStatement "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/statement"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/pdf"
+	"github.com/google/uuid"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the output")
+
+var brand = pdf.Branding{BankName: "Micro Bank", Address: "Jl. Jend. Sudirman No. 1, Jakarta", Color: pdf.Color{R: 0.04, G: 0.36, B: 0.68}}
+
+// golden compares out to testdata/name, rewriting it under -update.
+func golden(t *testing.T, name string, out []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, out, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v, run the tests with -update to write it", err)
+	}
+	if !bytes.Equal(out, want) {
+		t.Errorf("%v differs from the output, run the tests with -update and review the diff", path)
+	}
+}
+
+// checkXref checks the cross-reference table points at every object of out.
+func checkXref(t *testing.T, out []byte) {
+	t.Helper()
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(out)
+	if m == nil {
+		t.Fatal("no startxref at the end")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d isn't the cross-reference table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("object %d isn't at %d", i+1, offset)
+		}
+	}
+}
+
+func TestStatement(t *testing.T) {
+	created := time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC)
+	account := domainBank.BankAccountOrm{AccountUuid: uuid.MustParse("2a1f9a9e-6c53-4e58-8f43-7a3c1b5f0d11"),
+		AccountNumber: "7835697001", AccountName: "Kate Bishop", Currency: "IDR"}
+	st := domainStatement.Statement{
+		Id:        "statement",
+		Account:   account,
+		From:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Opening:   1500000,
+		CreatedAt: created,
+	}
+
+	// enough transactions for 2 pages
+	var trxs []domainBank.BankTransactionOrm
+	balance := st.Opening
+	for i := 0; i < 60; i++ {
+		trx := domainBank.BankTransactionOrm{
+			TransactionUuid:      uuid.NewSHA1(uuid.Nil, []byte{byte(i)}),
+			TransactionTimestamp: st.From.Add(time.Duration(i) * 11 * time.Hour),
+			TransactionType:      domainBank.TransactionTypeIn,
+			Amount:               250000.5,
+		}
+		if i%3 == 0 {
+			trx.TransactionType, trx.Amount, trx.Notes = domainBank.TransactionTypeOut, 125000, "Pembayaran (listrik) bulan Mei untuk rumah di Jl. Kemang Raya"
+		}
+		trxs = append(trxs, trx)
+		balance += domainStatement.Signed(trx)
+	}
+	st.Closing = balance
+
+	var out bytes.Buffer
+	w := pdf.NewStatementWriter(&out, brand)
+	if err := w.Begin(st); err != nil {
+		t.Fatal(err)
+	}
+	for _, trx := range trxs {
+		if err := w.Entry(trx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	checkXref(t, out.Bytes())
+	s := out.String()
+	for _, want := range []string{"/Count 2", "(Halaman 2 dari )", "(Rp 1.500.000,00)", "(Rp 9.000.020,00)", "(Pembayaran \\(listrik\\) bulan Mei untuk rumah di...)"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("statement has no %v", want)
+		}
+	}
+	golden(t, "statement.golden.pdf", out.Bytes())
+}
+
+func TestReceipt(t *testing.T) {
+	r := pdf.Receipt{
+		TransferId:     "0b1c2d3e-4f50-4617-8293-a4b5c6d7e8f9",
+		Time:           time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Success:        true,
+		Currency:       "USD",
+		Amount:         1234.5,
+		FromName:       "Kate Bishop",
+		FromNumber:     "7835697001",
+		ToName:         "Riri Williams",
+		ToNumber:       "7835697002",
+		ReversedAmount: 34.5,
+		ReversalReason: "Salah nominal",
+		PrintedAt:      time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC),
+	}
+
+	var out bytes.Buffer
+	if err := pdf.WriteReceipt(&out, brand, r); err != nil {
+		t.Fatal(err)
+	}
+
+	checkXref(t, out.Bytes())
+	s := out.String()
+	for _, want := range []string{"/Count 1", "(USD 1.234,50)", "(01 Mei 2024 17:00 WIB)", "(TRANSFER BERHASIL)", "(USD 34,50)"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("receipt has no %v", want)
+		}
+	}
+	golden(t, "receipt.golden.pdf", out.Bytes())
+}
+
+func TestParseColor(t *testing.T) {
+	c, err := pdf.ParseColor("#0b5cad")
+	if err != nil || c != (pdf.Color{R: 11.0 / 255, G: 92.0 / 255, B: 173.0 / 255}) {
+		t.Errorf("ParseColor(#0b5cad) = %v, %v", c, err)
+	}
+	for _, s := range []string{"", "0b5cad", "#0b5ca", "#0b5cag"} {
+		if _, err := pdf.ParseColor(s); err == nil {
+			t.Errorf("ParseColor(%q) succeeded", s)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package pdf
+
+import (
+	"io"
+	"time"
+)
+
+// Receipt is what a transfer receipt shows of a transfer.
+type Receipt struct {
+	TransferId string
+	Time       time.Time
+	Success    bool
+	Currency   string
+	Amount     float64
+	FromName   string
+	FromNumber string
+	ToName     string
+	ToNumber   string
+	// ReversedAmount is how much of the transfer was refunded since.
+	ReversedAmount float64
+	// ReversalReason is why the transfer, a reversal, refunds another.
+	ReversalReason string
+	PrintedAt      time.Time
+}
+
+// WriteReceipt renders r as a one page PDF to w.
+func WriteReceipt(w io.Writer, brand Branding, r Receipt) error {
+	doc := NewDocument(w, Info{Title: "Bukti Transfer " + r.TransferId, CreatedAt: r.PrintedAt})
+	page := newLayout(doc, brand, "BUKTI TRANSFER")
+
+	status, color := "TRANSFER BERHASIL", brand.Color
+	if !r.Success {
+		status, color = "TRANSFER GAGAL", Color{0.75, 0.1, 0.1}
+	}
+	doc.Text(margin, page.y, Bold, 14, color, status)
+	page.y -= 24
+	doc.Text(margin, page.y, Regular, 9, Gray, "Jumlah")
+	page.y -= 22
+	doc.Text(margin, page.y, Bold, 20, Black, formatMoney(r.Amount, r.Currency))
+	page.y -= 20
+	doc.Line(margin, page.y, PageWidth-margin, page.y, 0.5, Gray)
+	page.y -= 22
+
+	for _, f := range [][2]string{
+		{"Tanggal", formatDateTime(r.Time)},
+		{"Nomor Referensi", r.TransferId},
+		{"Dari", r.FromName},
+		{"", r.FromNumber},
+		{"Ke", r.ToName},
+		{"", r.ToNumber},
+	} {
+		page.field(margin, f[0], f[1])
+		page.y -= 16
+	}
+	if r.ReversedAmount > 0 {
+		page.field(margin, "Dikembalikan", formatMoney(r.ReversedAmount, r.Currency))
+		page.y -= 16
+	}
+	if r.ReversalReason != "" {
+		page.field(margin, "Alasan Pengembalian", r.ReversalReason)
+		page.y -= 16
+	}
+
+	page.y -= 8
+	doc.Line(margin, page.y, PageWidth-margin, page.y, 0.5, Gray)
+	page.y -= 18
+	doc.Text(margin, page.y, Regular, 8, Gray, "Dicetak "+formatDateTime(r.PrintedAt)+
+		". Bukti transfer ini dibuat oleh sistem dan sah tanpa tanda tangan.")
+
+	return doc.Close()
+}
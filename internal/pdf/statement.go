@@ -0,0 +1,125 @@
+package pdf
+
+import (
+	"io"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainStatement "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/statement"
+)
+
+// The columns of the transactions of a statement, amounts right aligned on
+// the x of their column.
+const (
+	columnDate        = margin
+	columnDescription = 120
+	columnDebit       = 365
+	columnCredit      = 460
+	columnBalance     = PageWidth - margin
+	rowHeight         = 16
+)
+
+// statementWriter renders a statement as a table of its transactions with a
+// debit, a credit and a balance column.
+type statementWriter struct {
+	w       io.Writer
+	brand   Branding
+	doc     *Document
+	page    *layout
+	st      domainStatement.Statement
+	balance float64
+	debit   float64
+	credit  float64
+}
+
+// NewStatementWriter returns the domainStatement.Writer rendering
+// statements as PDF to w.
+func NewStatementWriter(w io.Writer, brand Branding) domainStatement.Writer {
+	return &statementWriter{w: w, brand: brand}
+}
+
+func (w *statementWriter) Begin(st domainStatement.Statement) error {
+	w.st, w.balance = st, st.Opening
+	w.doc = NewDocument(w.w, Info{Title: "Rekening Koran " + st.Account.AccountNumber, CreatedAt: st.CreatedAt})
+	w.page = newLayout(w.doc, w.brand, "REKENING KORAN")
+
+	currency := st.Account.Currency
+	right := PageWidth / 2
+	w.page.field(margin, "Nama", st.Account.AccountName)
+	w.page.field(right, "Saldo Awal", w.money(st.Opening))
+	w.page.y -= 14
+	w.page.field(margin, "Nomor Rekening", st.Account.AccountNumber)
+	w.page.field(right, "Saldo Akhir", w.money(st.Closing))
+	w.page.y -= 14
+	w.page.field(margin, "Mata Uang", currency)
+	w.page.field(right, "Dicetak", formatDateTime(st.CreatedAt))
+	w.page.y -= 14
+	w.page.field(margin, "Periode", formatDateTime(st.From)+" s.d. "+formatDateTime(st.To))
+	w.page.y -= 26
+
+	w.page.onNewPage = w.tableHeader
+	w.tableHeader()
+
+	return w.doc.err
+}
+
+func (w *statementWriter) tableHeader() {
+	doc, y := w.doc, w.page.y
+	doc.Text(columnDate, y, Bold, 8, Black, "Tanggal")
+	doc.Text(columnDescription, y, Bold, 8, Black, "Keterangan")
+	doc.TextRight(columnDebit, y, Bold, 8, Black, "Debit")
+	doc.TextRight(columnCredit, y, Bold, 8, Black, "Kredit")
+	doc.TextRight(columnBalance, y, Bold, 8, Black, "Saldo")
+	doc.Line(margin, y-5, PageWidth-margin, y-5, 0.75, w.brand.Color)
+	w.page.y -= rowHeight + 2
+}
+
+func (w *statementWriter) Entry(trx domainBank.BankTransactionOrm) error {
+	w.page.ensure(rowHeight)
+
+	amount := domainStatement.Signed(trx)
+	w.balance += amount
+
+	doc, y := w.doc, w.page.y
+	doc.Text(columnDate, y, Regular, 8, Black, trx.TransactionTimestamp.In(wib).Format("02/01/2006 15:04"))
+	doc.Text(columnDescription, y, Regular, 8, Black, Regular.Truncate(description(trx), 8, columnDebit-columnDescription-75))
+	if amount < 0 {
+		w.debit -= amount
+		doc.TextRight(columnDebit, y, Regular, 8, Black, w.money(-amount))
+	} else {
+		w.credit += amount
+		doc.TextRight(columnCredit, y, Regular, 8, Black, w.money(amount))
+	}
+	doc.TextRight(columnBalance, y, Regular, 8, Black, w.money(w.balance))
+	w.page.y -= rowHeight
+
+	return w.doc.err
+}
+
+// description is the notes of trx, or what it is for a transaction without.
+func description(trx domainBank.BankTransactionOrm) string {
+	switch {
+	case trx.Notes != "":
+		return trx.Notes
+	case trx.TransactionType == domainBank.TransactionTypeOut:
+		return "Dana keluar"
+	}
+
+	return "Dana masuk"
+}
+
+func (w *statementWriter) End() error {
+	w.page.ensure(rowHeight * 2)
+
+	doc, y := w.doc, w.page.y
+	doc.Line(margin, y+rowHeight-5, PageWidth-margin, y+rowHeight-5, 0.75, w.brand.Color)
+	doc.Text(columnDescription, y, Bold, 8, Black, "Total")
+	doc.TextRight(columnDebit, y, Bold, 8, Black, w.money(w.debit))
+	doc.TextRight(columnCredit, y, Bold, 8, Black, w.money(w.credit))
+	doc.TextRight(columnBalance, y, Bold, 8, Black, w.money(w.st.Closing))
+
+	return w.doc.Close()
+}
+
+func (w *statementWriter) money(v float64) string {
+	return formatMoney(v, w.st.Account.Currency)
+}
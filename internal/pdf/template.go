@@ -0,0 +1,112 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	domainStatement "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/statement"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/util"
+)
+
+// Branding is what every page of a document shows of the bank.
+type Branding struct {
+	BankName string
+	Address  string
+	// Color fills the header band of the pages.
+	Color Color
+}
+
+const (
+	margin       = 40
+	headerHeight = 70
+	// footerTop is how low content goes on a page.
+	footerTop = 60
+)
+
+// wib is the time zone documents are dated in, Western Indonesian Time.
+var wib = time.FixedZone("WIB", 7*60*60)
+
+var months = [...]string{"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"}
+
+// formatDate writes t the Indonesian way in WIB, e.g. 01 Mei 2024.
+func formatDate(t time.Time) string {
+	t = t.In(wib)
+	return t.Format("02") + " " + months[t.Month()-1] + " " + strconv.Itoa(t.Year())
+}
+
+// formatDateTime writes t like formatDate with the time, e.g.
+// 01 Mei 2024 17:00 WIB.
+func formatDateTime(t time.Time) string {
+	return formatDate(t) + " " + t.In(wib).Format("15:04") + " WIB"
+}
+
+// formatMoney writes v in currency with Indonesian number formatting,
+// rupiah as Rp.
+func formatMoney(v float64, currency string) string {
+	if strings.EqualFold(currency, "IDR") {
+		return util.FormatRupiah(v)
+	}
+
+	return strings.ToUpper(currency) + " " + util.FormatNumber(v, domainStatement.MinorUnits(currency))
+}
+
+// layout draws the pages of a document with the branding of the bank: a
+// header band with the bank name and the title of the document, and a footer
+// with the address and the page number. y is where the content of the page
+// goes next, going down.
+type layout struct {
+	doc   *Document
+	brand Branding
+	title string
+	y     float64
+	// onNewPage draws what a page started by ensure repeats, e.g. the
+	// header of a table.
+	onNewPage func()
+}
+
+func newLayout(doc *Document, brand Branding, title string) *layout {
+	l := &layout{doc: doc, brand: brand, title: title}
+	l.newPage()
+	return l
+}
+
+func (l *layout) newPage() {
+	l.doc.AddPage()
+
+	top := PageHeight - headerHeight
+	l.doc.Rect(0, top, PageWidth, headerHeight, l.brand.Color)
+	l.doc.Text(margin, top+28, Bold, 18, White, l.brand.BankName)
+	l.doc.TextRight(PageWidth-margin, top+28, Bold, 12, White, l.title)
+
+	l.doc.Line(margin, footerTop-10, PageWidth-margin, footerTop-10, 0.5, Gray)
+	l.doc.Text(margin, footerTop-24, Regular, 8, Gray, l.brand.Address)
+	// the number of pages is known once the document is closed, it is drawn
+	// after the page number
+	page := "Halaman " + strconv.Itoa(l.doc.Pages()) + " dari "
+	x := PageWidth - margin - Regular.Width(page, 8) - Regular.Width("000", 8)
+	l.doc.Text(x, footerTop-24, Regular, 8, Gray, page)
+	l.doc.PageCount(x+Regular.Width(page, 8), footerTop-24, Regular, 8, Gray)
+
+	l.y = top - 30
+}
+
+// ensure starts a new page unless height fits above the footer of this one.
+func (l *layout) ensure(height float64) {
+	if l.y-height >= footerTop {
+		return
+	}
+	l.newPage()
+	if l.onNewPage != nil {
+		l.onNewPage()
+	}
+}
+
+// field draws a label and its value, a value continuing the one above
+// without a label.
+func (l *layout) field(x float64, label string, value string) {
+	if label != "" {
+		l.doc.Text(x, l.y, Regular, 9, Gray, label)
+	}
+	l.doc.Text(x+110, l.y, Bold, 9, Black, value)
+}
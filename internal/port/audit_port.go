@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+)
+
+// AuditLogPort keeps the append-only audit log.
+type AuditLogPort interface {
+	// AppendAuditEntry seals entry after the last entry and stores it;
+	// concurrent appends are serialized.
+	AppendAuditEntry(ctx context.Context, entry domainAudit.AuditEntryOrm) (domainAudit.AuditEntryOrm, error)
+	// AuditEntries returns up to limit entries after afterSeq, in order.
+	AuditEntries(ctx context.Context, afterSeq int64, limit int) ([]domainAudit.AuditEntryOrm, error)
+	// AuditHead returns the seq and hash of the last entry, 0 and
+	// domainAudit.GenesisHash for an empty log.
+	AuditHead(ctx context.Context) (int64, string, error)
+}
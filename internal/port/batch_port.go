@@ -0,0 +1,45 @@
+package port
+
+import (
+	"context"
+	"io"
+	"time"
+
+	domainBatch "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/batch"
+	"github.com/google/uuid"
+)
+
+// BatchStorePort keeps the transfer batches and their items.
+type BatchStorePort interface {
+	// CreateTransferBatch stores batch with its items at once.
+	CreateTransferBatch(ctx context.Context, batch domainBatch.BatchOrm, items []domainBatch.ItemOrm) error
+	GetTransferBatch(ctx context.Context, id uuid.UUID) (domainBatch.BatchOrm, error)
+	GetTransferBatchByMessageId(ctx context.Context, messageId string) (domainBatch.BatchOrm, error)
+	// CountTransferBatchItems returns the number of items of batchUuid by
+	// status.
+	CountTransferBatchItems(ctx context.Context, batchUuid uuid.UUID) (map[string]int, error)
+	// ListTransferBatchItems returns the items of batchUuid by line.
+	ListTransferBatchItems(ctx context.Context, batchUuid uuid.UUID) ([]domainBatch.ItemOrm, error)
+	// ClaimTransferBatches returns up to limit batches accepted, processing
+	// or rolling back whose lease is unset or ended at now, moves their lease
+	// to until and accepted ones to processing. A batch is claimed by one
+	// caller only until its lease ends.
+	ClaimTransferBatches(ctx context.Context, now time.Time, until time.Time, limit int) ([]domainBatch.BatchOrm, error)
+	// UpdateTransferBatch stores the status, reason, lease and completion of
+	// batch if it is still in status from, and returns ErrBatchChanged
+	// otherwise.
+	UpdateTransferBatch(ctx context.Context, batch domainBatch.BatchOrm, from string) error
+	// UpdateTransferBatchItem stores the status, reason and transfer of item
+	// if it is still in status from, and returns ErrBatchChanged otherwise.
+	UpdateTransferBatchItem(ctx context.Context, item domainBatch.ItemOrm, from string) error
+}
+
+type BatchServicePort interface {
+	// ImportTransferBatch reads the file of upload from r, validates its
+	// lines and stores it as a batch to execute, or as rejected.
+	ImportTransferBatch(ctx context.Context, upload domainBatch.Upload, r io.Reader) (domainBatch.BatchDetail, error)
+	GetTransferBatch(ctx context.Context, id uuid.UUID) (domainBatch.BatchDetail, error)
+	// WriteTransferBatchReport writes the pain.002 status report of batch id
+	// to w.
+	WriteTransferBatchReport(ctx context.Context, id uuid.UUID, w io.Writer) error
+}
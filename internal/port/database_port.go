@@ -1,6 +1,7 @@
 package port
 
 import (
+	"context"
 	"time"
 
 	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
@@ -8,13 +9,58 @@ import (
 )
 
 type BankDatabasePort interface {
-	GetDetailBankAccountByAccountNumber(accountNum string) (domainBank.BankAccountOrm, error)
-	GetBalanceBankAccountByAccountNumber(acct string) (domainBank.BalanceAccountOrm, error)
-	InsertExchangeRate(r domainBank.BankExchangeRateOrm) (uuid.UUID, error)
-	GetExchangeRateAtTimestamp(fromCurrency string, toCurrency string, ts time.Time) (domainBank.BankExchangeRateOrm, error)
-	CreateTransaction(account domainBank.BankAccountOrm, trx domainBank.BankTransactionOrm) (uuid.UUID, error)
-	CreateTransfer(trf domainBank.BankTransferOrm) (uuid.UUID, error)
-	CreateTransferTransactionPair(fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
+	GetDetailBankAccountByAccountNumber(ctx context.Context, accountNum string) (domainBank.BankAccountOrm, error)
+	GetBalanceBankAccountByAccountNumber(ctx context.Context, acct string) (domainBank.BalanceAccountOrm, error)
+	GetDetailBankAccountByUuid(ctx context.Context, accountUuid uuid.UUID) (domainBank.BankAccountOrm, error)
+	InsertExchangeRate(ctx context.Context, r domainBank.BankExchangeRateOrm) (uuid.UUID, error)
+	GetExchangeRateAtTimestamp(ctx context.Context, fromCurrency string, toCurrency string, ts time.Time) (domainBank.BankExchangeRateOrm, error)
+	// CreateTransaction and CreateTransferTransactionPair fail with the
+	// InsufficientFundsError of the limits of the debited account when its
+	// balance less its holds at the time of the transaction can't cover the
+	// debit, checked under the lock of the account.
+	CreateTransaction(ctx context.Context, account domainBank.BankAccountOrm, trx domainBank.BankTransactionOrm) (uuid.UUID, error)
+	CreateTransfer(ctx context.Context, trf domainBank.BankTransferOrm) (uuid.UUID, error)
+	CreateTransferTransactionPair(ctx context.Context, fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
 		fromTransactionOrm domainBank.BankTransactionOrm, toTransactionOrm domainBank.BankTransactionOrm) (bool, error)
-	UpdateTransferStatus(transfer domainBank.BankTransferOrm, status bool) error
+	UpdateTransferStatus(ctx context.Context, transfer domainBank.BankTransferOrm, status bool) error
+	GetTransfer(ctx context.Context, transferUuid uuid.UUID) (domainBank.BankTransferOrm, error)
+	GetTransferDetail(ctx context.Context, transferUuid uuid.UUID) (domainBank.TransferDetail, error)
+	// ListTransfers returns up to filter.Limit transfers matching filter,
+	// newest first, ties broken by descending uuid.
+	ListTransfers(ctx context.Context, filter domainBank.TransferFilter) ([]domainBank.TransferDetail, error)
+	// ReverseTransfer writes booking all or nothing. It fails with
+	// ErrReversalExceedsTransfer when the original has less left to refund
+	// than the reversal and, unless booking allows an overdraft, with the
+	// InsufficientFundsError of the limits of the recipient when its balance
+	// less its holds can't cover the debit.
+	ReverseTransfer(ctx context.Context, booking domainBank.TransferReversalBooking) error
+	// HeldAmount is what the holds on accountUuid active at at reserve.
+	HeldAmount(ctx context.Context, accountUuid uuid.UUID, at time.Time) (float64, error)
+	// CreateHold fails with ErrInsufficientBalance when hold takes the
+	// balance of its account less its other holds at hold.CreatedAt below
+	// floor.
+	CreateHold(ctx context.Context, hold domainBank.BankHoldOrm, floor float64) error
+	GetHold(ctx context.Context, holdUuid uuid.UUID) (domainBank.BankHoldOrm, error)
+	// CaptureHold writes capture all or nothing and returns the hold after
+	// it. It fails with ErrHoldNotActive when the hold isn't active at the
+	// time of the transaction, and with ErrHoldExceeded when less than its
+	// amount is left on the hold.
+	CaptureHold(ctx context.Context, capture domainBank.HoldCapture) (domainBank.BankHoldOrm, error)
+	// ReleaseHold fails with ErrHoldNotActive when the hold isn't active at
+	// at.
+	ReleaseHold(ctx context.Context, holdUuid uuid.UUID, at time.Time) (domainBank.BankHoldOrm, error)
+	// GetProduct fails with ErrRecordNotFound for a product without a row.
+	GetProduct(ctx context.Context, productCode string) (domainBank.BankProductOrm, error)
+	ListProducts(ctx context.Context) ([]domainBank.BankProductOrm, error)
+	// SaveProduct creates product, or replaces the one with its code but
+	// keeps when that was created, and returns it as stored.
+	SaveProduct(ctx context.Context, product domainBank.BankProductOrm) (domainBank.BankProductOrm, error)
+	// UpdateAccountLimits writes the product and the limits of account.
+	UpdateAccountLimits(ctx context.Context, account domainBank.BankAccountOrm) error
+	// SetAccountCustomer moves accountUuid to the customer customerId, ""
+	// for none.
+	SetAccountCustomer(ctx context.Context, accountUuid uuid.UUID, customerId string, at time.Time) error
+	// ListAccountsOfCustomer returns the accounts of customerId by account
+	// number.
+	ListAccountsOfCustomer(ctx context.Context, customerId string) ([]domainBank.BankAccountOrm, error)
 }
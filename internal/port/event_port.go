@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/google/uuid"
+)
+
+// OutboxPort reads the events that BankDatabasePort implementations write
+// together with each state change.
+type OutboxPort interface {
+	// PendingEvents returns up to limit unpublished events, oldest first.
+	PendingEvents(ctx context.Context, limit int) ([]domainEvent.Event, error)
+	MarkEventPublished(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type EventPublisherPort interface {
+	// Publish returns once the broker or file has accepted e.
+	Publish(ctx context.Context, e domainEvent.Event) error
+	Close() error
+}
@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
+	"github.com/google/uuid"
+)
+
+// FeeStorePort keeps the fee schedules and the customer segments of
+// accounts, and books transfers with their fee.
+type FeeStorePort interface {
+	// SaveFeeSchedule creates schedule, or replaces the one with its uuid
+	// and its tiers.
+	SaveFeeSchedule(ctx context.Context, schedule domainFee.Schedule) error
+	// ListFeeSchedules returns the schedules by currency, channel and
+	// segment, their tiers by minimum amount.
+	ListFeeSchedules(ctx context.Context) ([]domainFee.Schedule, error)
+	// CountTransferFees counts the fees accountUuid was charged or spared
+	// under scheduleUuid since since.
+	CountTransferFees(ctx context.Context, accountUuid uuid.UUID, scheduleUuid uuid.UUID, since time.Time) (int, error)
+	// CreateChargedTransferTransactionPair books the transfer pair like
+	// CreateTransferTransactionPair and the fee of charge with it, all or
+	// nothing, and returns the breakdown of the fee with the free transfers
+	// of the sender applied. It fails with an InsufficientFundsError when
+	// the sender can't cover the fee on top of the amount.
+	CreateChargedTransferTransactionPair(ctx context.Context, fromAccountOrm domainBank.BankAccountOrm, toAccountOrm domainBank.BankAccountOrm,
+		fromTransactionOrm domainBank.BankTransactionOrm, toTransactionOrm domainBank.BankTransactionOrm, charge domainFee.Charge) (domainFee.Breakdown, error)
+	SetCustomerSegment(ctx context.Context, accountUuid uuid.UUID, segment string, at time.Time) error
+}
+
+type FeeServicePort interface {
+	SetFeeSchedule(ctx context.Context, schedule domainFee.Schedule, feeAccountNum string) (domainFee.ScheduleDetail, error)
+	ListFeeSchedules(ctx context.Context) ([]domainFee.ScheduleDetail, error)
+	SetCustomerSegment(ctx context.Context, accountNum string, segment string) (domainBank.BankAccountOrm, error)
+}
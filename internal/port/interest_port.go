@@ -0,0 +1,45 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	"github.com/google/uuid"
+)
+
+// InterestStorePort keeps the interest rates of products and what accounts
+// accrue.
+type InterestStorePort interface {
+	// SaveInterestRates replaces the version of the rates of its product
+	// effective from the same day as version.
+	SaveInterestRates(ctx context.Context, version domainInterest.RateVersion) error
+	// ListInterestRates returns the rates of productCode, of every product
+	// for "".
+	ListInterestRates(ctx context.Context, productCode string) ([]domainInterest.InterestRateOrm, error)
+	// InterestAccounts returns the accounts whose product has rates.
+	InterestAccounts(ctx context.Context) ([]domainInterest.InterestAccount, error)
+	// LastAccrualDate is the latest day accountUuid accrued interest for,
+	// the zero time when it never did.
+	LastAccrualDate(ctx context.Context, accountUuid uuid.UUID) (time.Time, error)
+	// ClosingBalances returns the balance of accountUuid at the end of every
+	// day from from up to, not including, to.
+	ClosingBalances(ctx context.Context, accountUuid uuid.UUID, from time.Time, to time.Time) ([]float64, error)
+	// SaveInterestAccruals skips the accruals of a day an account already
+	// accrued for.
+	SaveInterestAccruals(ctx context.Context, accruals []domainInterest.InterestAccrualOrm) error
+	// CapitalizeInterest posts capitalization, withholds its tax, records
+	// the withholding and marks the accruals it covers, all or nothing, and
+	// returns what it posted. Accruals that add up to less than a cent are
+	// left for the next period.
+	CapitalizeInterest(ctx context.Context, capitalization domainInterest.Capitalization) (domainInterest.Posting, error)
+	// ListInterestAccruals returns the accruals of accountUuid from from up
+	// to, not including, to, oldest first. A zero bound leaves that end open.
+	ListInterestAccruals(ctx context.Context, accountUuid uuid.UUID, from time.Time, to time.Time) ([]domainInterest.InterestAccrualOrm, error)
+}
+
+type InterestServicePort interface {
+	SetInterestRates(ctx context.Context, version domainInterest.RateVersion) (domainInterest.RateVersion, error)
+	ListInterestRates(ctx context.Context, productCode string) ([]domainInterest.RateVersion, error)
+	ListInterestAccruals(ctx context.Context, accountNum string, from time.Time, to time.Time) ([]domainInterest.InterestAccrualOrm, error)
+}
@@ -0,0 +1,18 @@
+package port
+
+import "context"
+
+// LeaderLockPort takes named locks that one replica at a time can hold.
+type LeaderLockPort interface {
+	// TryLock takes the lock name without waiting. It returns nil when
+	// another replica holds it.
+	TryLock(ctx context.Context, name string) (HeldLock, error)
+}
+
+// HeldLock is a lock taken by LeaderLockPort.TryLock.
+type HeldLock interface {
+	// Check returns an error when the lock may have been lost, e.g. with the
+	// database session that held it. The lock is released then.
+	Check(ctx context.Context) error
+	Unlock(ctx context.Context) error
+}
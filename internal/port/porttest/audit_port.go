@@ -0,0 +1,87 @@
+package porttest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	domainAudit "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/audit"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+)
+
+func testAuditLog(t *testing.T, h Harness) {
+	log, ok := h.DB.(port.AuditLogPort)
+	if !ok {
+		t.Skip("adapter has no audit log")
+	}
+	ctx := context.Background()
+
+	startSeq, startHash, err := log.AuditHead(ctx)
+	if err != nil {
+		t.Fatalf("AuditHead: %v", err)
+	}
+
+	first := domainAudit.AuditEntryOrm{
+		OccurredAt:  time.Now(),
+		Principal:   "cert:teller",
+		RemoteAddr:  "10.0.0.1:5000",
+		Method:      "/bank.BankService/Transfer",
+		Action:      "Transfer",
+		Fingerprint: "ab12",
+		Outcome:     domainAudit.OutcomeFailed,
+		Error:       "insufficient balance",
+		Entities:    []uuid.UUID{uuid.New(), uuid.New()},
+	}
+	sealed, err := log.AppendAuditEntry(ctx, first)
+	if err != nil {
+		t.Fatalf("AppendAuditEntry: %v", err)
+	}
+	if sealed.Seq != startSeq+1 || sealed.PrevHash != startHash || sealed.Hash != sealed.ComputeHash() {
+		t.Errorf("sealed entry = %+v, want it after seq %d, hash %s", sealed, startSeq, startHash)
+	}
+
+	// concurrent appends must still form one chain
+	const appends = 8
+	var wg sync.WaitGroup
+	for i := 0; i < appends; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := log.AppendAuditEntry(ctx, domainAudit.AuditEntryOrm{OccurredAt: time.Now(), Principal: "system", Action: "CreateExchangeRate", Outcome: domainAudit.OutcomeOK}); err != nil {
+				t.Errorf("AppendAuditEntry: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, err := log.AuditEntries(ctx, startSeq, 100)
+	if err != nil || len(entries) != appends+1 {
+		t.Fatalf("AuditEntries = %d entries, %v; want %d", len(entries), err, appends+1)
+	}
+
+	got := entries[0]
+	if got.Principal != first.Principal || got.RemoteAddr != first.RemoteAddr || got.Method != first.Method || got.Error != first.Error ||
+		!got.OccurredAt.Equal(sealed.OccurredAt) || len(got.Entities) != 2 || got.Entities[1] != first.Entities[1] {
+		t.Errorf("stored entry = %+v, want %+v", got, sealed)
+	}
+
+	seq, prevHash := startSeq, startHash
+	for _, e := range entries {
+		if err := e.VerifyLink(seq+1, prevHash); err != nil {
+			t.Fatalf("stored chain: %v", err)
+		}
+		seq, prevHash = e.Seq, e.Hash
+	}
+
+	headSeq, headHash, err := log.AuditHead(ctx)
+	if err != nil || headSeq != seq || headHash != prevHash {
+		t.Errorf("AuditHead = %d, %s, %v; want %d, %s", headSeq, headHash, err, seq, prevHash)
+	}
+
+	page, err := log.AuditEntries(ctx, startSeq+1, 2)
+	if err != nil || len(page) != 2 || page[0].Seq != startSeq+2 {
+		t.Errorf("AuditEntries page = %+v, %v; want 2 entries from %d", page, err, startSeq+2)
+	}
+}
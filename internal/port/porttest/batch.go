@@ -0,0 +1,118 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainBatch "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/batch"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+)
+
+func testTransferBatches(t *testing.T, h Harness) {
+	store, ok := h.DB.(port.BatchStorePort)
+	if !ok {
+		t.Skip("adapter has no transfer batch store")
+	}
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	batch := domainBatch.BatchOrm{BatchUuid: uuid.New(), MessageId: uuid.NewString()[:35], Format: domainBatch.FormatCSV,
+		Mode: domainBatch.ModeAllOrNothing, Status: domainBatch.StatusAccepted, NumberOfTransactions: 2, ControlSum: 3.5,
+		CreatedAt: now, UpdatedAt: now}
+	items := []domainBatch.ItemOrm{
+		{ItemUuid: uuid.New(), LineNumber: 3, EndToEndId: "B", DebtorAccount: "1", CreditorAccount: "2", Currency: "USD", Amount: 2.5,
+			Status: domainBatch.ItemPending, UpdatedAt: now},
+		{ItemUuid: uuid.New(), LineNumber: 2, EndToEndId: "A", DebtorAccount: "1", CreditorAccount: "3", Currency: "USD", Amount: 1,
+			Status: domainBatch.ItemPending, UpdatedAt: now},
+	}
+	if err := store.CreateTransferBatch(ctx, batch, items); err != nil {
+		t.Fatalf("CreateTransferBatch: %v", err)
+	}
+	duplicate := batch
+	duplicate.BatchUuid = uuid.New()
+	if err := store.CreateTransferBatch(ctx, duplicate, nil); err == nil {
+		t.Error("CreateTransferBatch of a message id imported already succeeded")
+	}
+
+	if got, err := store.GetTransferBatchByMessageId(ctx, batch.MessageId); err != nil || got.BatchUuid != batch.BatchUuid {
+		t.Errorf("GetTransferBatchByMessageId = %v, %v; want %v", got.BatchUuid, err, batch.BatchUuid)
+	}
+	if _, err := store.GetTransferBatch(ctx, uuid.New()); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("GetTransferBatch of an unknown batch: err = %v, want ErrRecordNotFound", err)
+	}
+	stored, err := store.ListTransferBatchItems(ctx, batch.BatchUuid)
+	if err != nil || len(stored) != 2 || stored[0].EndToEndId != "A" || stored[1].BatchUuid != batch.BatchUuid {
+		t.Fatalf("ListTransferBatchItems = %+v, %v; want both items by line", stored, err)
+	}
+
+	// only one of two claims gets the batch, and starts processing it
+	lease := now.Add(time.Minute)
+	claimed := func(at time.Time) []domainBatch.BatchOrm {
+		t.Helper()
+		batches, err := store.ClaimTransferBatches(ctx, at, at.Add(time.Minute), 1000)
+		if err != nil {
+			t.Fatalf("ClaimTransferBatches: %v", err)
+		}
+		var ours []domainBatch.BatchOrm
+		for _, b := range batches {
+			if b.BatchUuid == batch.BatchUuid {
+				ours = append(ours, b)
+			}
+		}
+		return ours
+	}
+	first := claimed(now)
+	if len(first) != 1 || first[0].Status != domainBatch.StatusProcessing || first[0].LeaseUntil == nil || !first[0].LeaseUntil.Equal(lease) {
+		t.Fatalf("ClaimTransferBatches = %+v, want the batch processing until the end of the lease", first)
+	}
+	if second := claimed(now); len(second) != 0 {
+		t.Errorf("second ClaimTransferBatches = %+v, want none", second)
+	}
+
+	// items and batches change only from the status their worker read
+	trf := domainBank.BankTransferOrm{TransferUuid: stored[0].ItemUuid, FromAccountUuid: uuid.Nil, Currency: "USD", Amount: 1,
+		TransferTimestamp: now, CreatedAt: now, UpdatedAt: now}
+	from, to := NewAccount(10), NewAccount(0)
+	h.Seed(t, from, to)
+	trf.FromAccountUuid, trf.ToAccountUuid = from.AccountUuid, to.AccountUuid
+	if _, err := h.DB.CreateTransfer(ctx, trf); err != nil {
+		t.Fatalf("CreateTransfer: %v", err)
+	}
+	done := stored[0]
+	done.Status, done.TransferUuid, done.UpdatedAt = domainBatch.ItemSucceeded, &trf.TransferUuid, now
+	if err := store.UpdateTransferBatchItem(ctx, done, domainBatch.ItemPending); err != nil {
+		t.Fatalf("UpdateTransferBatchItem: %v", err)
+	}
+	if err := store.UpdateTransferBatchItem(ctx, done, domainBatch.ItemPending); !errors.Is(err, domainBatch.ErrBatchChanged) {
+		t.Errorf("second UpdateTransferBatchItem: err = %v, want ErrBatchChanged", err)
+	}
+	counts, err := store.CountTransferBatchItems(ctx, batch.BatchUuid)
+	if err != nil || counts[domainBatch.ItemSucceeded] != 1 || counts[domainBatch.ItemPending] != 1 {
+		t.Errorf("CountTransferBatchItems = %v, %v; want 1 succeeded and 1 pending", counts, err)
+	}
+
+	// a lease that ended lets another worker take over
+	if again := claimed(lease); len(again) != 1 || again[0].Status != domainBatch.StatusProcessing {
+		t.Errorf("ClaimTransferBatches after the lease = %+v, want the batch still processing", again)
+	}
+
+	finished := first[0]
+	finished.Status, finished.LeaseUntil, finished.CompletedAt = domainBatch.StatusCompleted, nil, &now
+	if err := store.UpdateTransferBatch(ctx, finished, domainBatch.StatusProcessing); err != nil {
+		t.Fatalf("UpdateTransferBatch: %v", err)
+	}
+	if err := store.UpdateTransferBatch(ctx, finished, domainBatch.StatusProcessing); !errors.Is(err, domainBatch.ErrBatchChanged) {
+		t.Errorf("second UpdateTransferBatch: err = %v, want ErrBatchChanged", err)
+	}
+	got, err := store.GetTransferBatch(ctx, batch.BatchUuid)
+	if err != nil || got.Status != domainBatch.StatusCompleted || got.LeaseUntil != nil || got.CompletedAt == nil {
+		t.Errorf("GetTransferBatch = %+v, %v; want it completed", got, err)
+	}
+	if again := claimed(lease.Add(time.Hour)); len(again) != 0 {
+		t.Errorf("ClaimTransferBatches of a completed batch = %+v, want none", again)
+	}
+}
@@ -0,0 +1,522 @@
+// Package porttest holds the contract tests every BankDatabasePort
+// implementation must pass, so the adapters can't drift apart.
+package porttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/db/seed"
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+)
+
+// Harness is the adapter under test plus what the suite needs to set it up.
+type Harness struct {
+	DB port.BankDatabasePort
+	// Seed inserts accounts directly, the port has no method for it.
+	Seed func(t *testing.T, accounts ...domainBank.BankAccountOrm)
+}
+
+// RunBankDatabasePortTests runs the contract suite. newHarness is called once
+// per subtest; the suite only uses fresh account numbers and currency pairs,
+// so a harness may share its store between subtests.
+func RunBankDatabasePortTests(t *testing.T, newHarness func(t *testing.T) Harness) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, h Harness)
+	}{
+		{"AccountLookup", testAccountLookup},
+		{"AccountNotFound", testAccountNotFound},
+		{"ExchangeRateWindow", testExchangeRateWindow},
+		{"ExchangeRateDuplicate", testExchangeRateDuplicate},
+		{"CreateTransaction", testCreateTransaction},
+		{"CreateTransactionUsesStoredBalance", testCreateTransactionUsesStoredBalance},
+		{"CreateTransactionIsAtomic", testCreateTransactionIsAtomic},
+		{"TransferTransactionPair", testTransferTransactionPair},
+		{"TransferTransactionPairIsAtomic", testTransferTransactionPairIsAtomic},
+		{"Transfer", testTransfer},
+		{"ReverseTransfer", testReverseTransfer},
+		{"ReverseTransferLimits", testReverseTransferLimits},
+		{"AccountByUuid", testAccountByUuid},
+		{"ListTransfers", testListTransfers},
+		{"Holds", testHolds},
+		{"DebitsRespectHolds", testDebitsRespectHolds},
+		{"Limits", testLimits},
+		{"ConcurrentDebitsRespectLimits", testConcurrentDebitsRespectLimits},
+		{"Interest", testInterest},
+		{"Tax", testTax},
+		{"Fees", testFees},
+		{"ScheduledTransfers", testScheduledTransfers},
+		{"TransferBatches", testTransferBatches},
+		{"Statements", testStatements},
+		{"ApplySeed", testApplySeed},
+		{"Outbox", testOutbox},
+		{"Webhooks", testWebhooks},
+		{"WebhookCustomers", testWebhookCustomers},
+		{"AuditLog", testAuditLog},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newHarness(t))
+		})
+	}
+}
+
+func testAccountLookup(t *testing.T, h Harness) {
+	ctx := context.Background()
+	acc := NewAccount(125.5)
+	h.Seed(t, acc)
+
+	detail, err := h.DB.GetDetailBankAccountByAccountNumber(ctx, acc.AccountNumber)
+	if err != nil {
+		t.Fatalf("GetDetailBankAccountByAccountNumber: %v", err)
+	}
+	if detail.AccountUuid != acc.AccountUuid || detail.AccountName != acc.AccountName || detail.Currency != acc.Currency {
+		t.Errorf("detail = %+v, want account %v", detail, acc.AccountUuid)
+	}
+	if detail.CurrentBalance != 125.5 {
+		t.Errorf("detail balance = %v, want 125.5", detail.CurrentBalance)
+	}
+
+	balance, err := h.DB.GetBalanceBankAccountByAccountNumber(ctx, acc.AccountNumber)
+	if err != nil {
+		t.Fatalf("GetBalanceBankAccountByAccountNumber: %v", err)
+	}
+	if balance.AccountUuid != acc.AccountUuid || balance.AccountNumber != acc.AccountNumber || balance.CurrentBalance != 125.5 {
+		t.Errorf("balance = %+v, want 125.5 on %v", balance, acc.AccountNumber)
+	}
+}
+
+func testAccountNotFound(t *testing.T, h Harness) {
+	ctx := context.Background()
+	missing := NewAccount(0).AccountNumber
+
+	if _, err := h.DB.GetDetailBankAccountByAccountNumber(ctx, missing); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("GetDetailBankAccountByAccountNumber error = %v, want ErrRecordNotFound", err)
+	}
+
+	if _, err := h.DB.GetBalanceBankAccountByAccountNumber(ctx, missing); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("GetBalanceBankAccountByAccountNumber error = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func testExchangeRateWindow(t *testing.T, h Harness) {
+	ctx := context.Background()
+	from, to := NewCurrencyPair()
+	validFrom := time.Now().UTC().Truncate(time.Second)
+	validTo := validFrom.Add(5 * time.Second).Add(-time.Millisecond)
+
+	rate := NewExchangeRate(from, to, 2150.25, validFrom, validTo)
+	id, err := h.DB.InsertExchangeRate(ctx, rate)
+	if err != nil {
+		t.Fatalf("InsertExchangeRate: %v", err)
+	}
+	if id != rate.ExchangeRateUuid {
+		t.Errorf("InsertExchangeRate returned %v, want %v", id, rate.ExchangeRateUuid)
+	}
+
+	for _, ts := range []time.Time{validFrom, validFrom.Add(2 * time.Second), validTo} {
+		got, err := h.DB.GetExchangeRateAtTimestamp(ctx, from, to, ts)
+		if err != nil {
+			t.Fatalf("GetExchangeRateAtTimestamp(%v): %v", ts, err)
+		}
+		if got.ExchangeRateUuid != rate.ExchangeRateUuid || got.Rate != 2150.25 {
+			t.Errorf("GetExchangeRateAtTimestamp(%v) = %+v, want rate %v", ts, got, rate.ExchangeRateUuid)
+		}
+	}
+
+	for _, ts := range []time.Time{validFrom.Add(-time.Second), validTo.Add(time.Second)} {
+		if _, err := h.DB.GetExchangeRateAtTimestamp(ctx, from, to, ts); !errors.Is(err, domainBank.ErrRecordNotFound) {
+			t.Errorf("GetExchangeRateAtTimestamp(%v) error = %v, want ErrRecordNotFound", ts, err)
+		}
+	}
+
+	if _, err := h.DB.GetExchangeRateAtTimestamp(ctx, to, from, validFrom); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("reverse pair error = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func testExchangeRateDuplicate(t *testing.T, h Harness) {
+	ctx := context.Background()
+	from, to := NewCurrencyPair()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	rate := NewExchangeRate(from, to, 1, now, now.Add(time.Second))
+	if _, err := h.DB.InsertExchangeRate(ctx, rate); err != nil {
+		t.Fatalf("InsertExchangeRate: %v", err)
+	}
+
+	if _, err := h.DB.InsertExchangeRate(ctx, rate); err == nil {
+		t.Error("inserting the same exchange rate uuid twice succeeded")
+	}
+}
+
+func testCreateTransaction(t *testing.T, h Harness) {
+	ctx := context.Background()
+	acc := NewAccount(100)
+	h.Seed(t, acc)
+
+	in := NewTransaction(acc, domainBank.TransactionTypeIn, 25.75)
+	id, err := h.DB.CreateTransaction(ctx, acc, in)
+	if err != nil {
+		t.Fatalf("CreateTransaction IN: %v", err)
+	}
+	if id != in.TransactionUuid {
+		t.Errorf("CreateTransaction returned %v, want %v", id, in.TransactionUuid)
+	}
+	assertBalance(t, h, acc, 125.75)
+
+	out := NewTransaction(acc, domainBank.TransactionTypeOut, 50.5)
+	if _, err := h.DB.CreateTransaction(ctx, acc, out); err != nil {
+		t.Fatalf("CreateTransaction OUT: %v", err)
+	}
+	assertBalance(t, h, acc, 75.25)
+}
+
+func testCreateTransactionUsesStoredBalance(t *testing.T, h Harness) {
+	ctx := context.Background()
+	acc := NewAccount(100)
+	h.Seed(t, acc)
+
+	// both postings carry the account as read before either of them
+	if _, err := h.DB.CreateTransaction(ctx, acc, NewTransaction(acc, domainBank.TransactionTypeIn, 10)); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+	if _, err := h.DB.CreateTransaction(ctx, acc, NewTransaction(acc, domainBank.TransactionTypeIn, 20)); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	assertBalance(t, h, acc, 130)
+}
+
+func testCreateTransactionIsAtomic(t *testing.T, h Harness) {
+	ctx := context.Background()
+	acc := NewAccount(100)
+	h.Seed(t, acc)
+
+	trx := NewTransaction(acc, domainBank.TransactionTypeIn, 10)
+	if _, err := h.DB.CreateTransaction(ctx, acc, trx); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	if _, err := h.DB.CreateTransaction(ctx, acc, trx); err == nil {
+		t.Fatal("CreateTransaction with a duplicate uuid succeeded")
+	}
+	assertBalance(t, h, acc, 110)
+
+	orphan := NewTransaction(NewAccount(0), domainBank.TransactionTypeIn, 10)
+	if _, err := h.DB.CreateTransaction(ctx, acc, orphan); err == nil {
+		t.Fatal("CreateTransaction for an unknown account succeeded")
+	}
+	assertBalance(t, h, acc, 110)
+}
+
+func testTransferTransactionPair(t *testing.T, h Harness) {
+	ctx := context.Background()
+	from, to := NewAccount(100), NewAccount(5)
+	h.Seed(t, from, to)
+
+	ok, err := h.DB.CreateTransferTransactionPair(ctx, from, to,
+		NewTransaction(from, domainBank.TransactionTypeOut, 40.4),
+		NewTransaction(to, domainBank.TransactionTypeIn, 40.4))
+	if err != nil || !ok {
+		t.Fatalf("CreateTransferTransactionPair = %v, %v", ok, err)
+	}
+
+	assertBalance(t, h, from, 59.6)
+	assertBalance(t, h, to, 45.4)
+}
+
+func testTransferTransactionPairIsAtomic(t *testing.T, h Harness) {
+	ctx := context.Background()
+	from, to := NewAccount(100), NewAccount(5)
+	h.Seed(t, from, to)
+
+	existing := NewTransaction(to, domainBank.TransactionTypeIn, 1)
+	if _, err := h.DB.CreateTransaction(ctx, to, existing); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	// the credit leg collides with an existing transaction, so the debit leg
+	// must be rolled back too
+	ok, err := h.DB.CreateTransferTransactionPair(ctx, from, to,
+		NewTransaction(from, domainBank.TransactionTypeOut, 40),
+		existing)
+	if err == nil || ok {
+		t.Fatalf("CreateTransferTransactionPair = %v, %v; want failure", ok, err)
+	}
+
+	assertBalance(t, h, from, 100)
+	assertBalance(t, h, to, 6)
+}
+
+func testTransfer(t *testing.T, h Harness) {
+	ctx := context.Background()
+	from, to := NewAccount(100), NewAccount(5)
+	h.Seed(t, from, to)
+
+	now := time.Now().UTC()
+	trf := domainBank.BankTransferOrm{
+		TransferUuid:      uuid.New(),
+		FromAccountUuid:   from.AccountUuid,
+		ToAccountUuid:     to.AccountUuid,
+		Currency:          "USD",
+		Amount:            12.5,
+		TransferTimestamp: now,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	id, err := h.DB.CreateTransfer(ctx, trf)
+	if err != nil {
+		t.Fatalf("CreateTransfer: %v", err)
+	}
+	if id != trf.TransferUuid {
+		t.Errorf("CreateTransfer returned %v, want %v", id, trf.TransferUuid)
+	}
+
+	if err := h.DB.UpdateTransferStatus(ctx, trf, true); err != nil {
+		t.Errorf("UpdateTransferStatus: %v", err)
+	}
+
+	if _, err := h.DB.CreateTransfer(ctx, trf); err == nil {
+		t.Error("CreateTransfer with a duplicate uuid succeeded")
+	}
+
+	orphan := trf
+	orphan.TransferUuid = uuid.New()
+	orphan.ToAccountUuid = uuid.New()
+	if _, err := h.DB.CreateTransfer(ctx, orphan); err == nil {
+		t.Error("CreateTransfer to an unknown account succeeded")
+	}
+
+	// a transfer doesn't move money by itself
+	assertBalance(t, h, from, 100)
+	assertBalance(t, h, to, 5)
+}
+
+func testApplySeed(t *testing.T, h Harness) {
+	store, ok := h.DB.(seed.Store)
+	if !ok {
+		t.Skip("adapter can't be seeded")
+	}
+	ctx := context.Background()
+
+	acc := NewAccount(10)
+	deposit := NewTransaction(acc, domainBank.TransactionTypeIn, 10)
+	for i := 0; i < 2; i++ {
+		if err := store.ApplySeed(ctx, []domainBank.BankAccountOrm{acc}, []domainBank.BankTransactionOrm{deposit}); err != nil {
+			t.Fatalf("ApplySeed #%d: %v", i+1, err)
+		}
+	}
+	assertBalance(t, h, acc, 10)
+
+	// an existing account is kept as it is, not overwritten
+	changed := acc
+	changed.CurrentBalance = 99
+	if err := store.ApplySeed(ctx, []domainBank.BankAccountOrm{changed}, nil); err != nil {
+		t.Fatalf("ApplySeed: %v", err)
+	}
+	assertBalance(t, h, acc, 10)
+
+	// a transaction of an unknown account fails the whole seed
+	fresh := NewAccount(5)
+	orphan := NewTransaction(NewAccount(0), domainBank.TransactionTypeIn, 1)
+	if err := store.ApplySeed(ctx, []domainBank.BankAccountOrm{fresh}, []domainBank.BankTransactionOrm{orphan}); err == nil {
+		t.Fatal("ApplySeed with a transaction of an unknown account succeeded")
+	}
+	if _, err := h.DB.GetBalanceBankAccountByAccountNumber(ctx, fresh.AccountNumber); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("account of the failed seed: error = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func testOutbox(t *testing.T, h Harness) {
+	outbox, ok := h.DB.(port.OutboxPort)
+	if !ok {
+		t.Skip("adapter has no outbox")
+	}
+	ctx := context.Background()
+
+	// start from an empty outbox, the store may be shared with other subtests
+	for {
+		pending, err := outbox.PendingEvents(ctx, 100)
+		if err != nil {
+			t.Fatalf("PendingEvents: %v", err)
+		}
+		if len(pending) == 0 {
+			break
+		}
+		for _, e := range pending {
+			if err := outbox.MarkEventPublished(ctx, e.ID, time.Now()); err != nil {
+				t.Fatalf("MarkEventPublished: %v", err)
+			}
+		}
+	}
+
+	from, to := NewAccount(100), NewAccount(5)
+	h.Seed(t, from, to)
+	fromCurrency, toCurrency := NewCurrencyPair()
+	now := time.Now().UTC()
+
+	rate := NewExchangeRate(fromCurrency, toCurrency, 1.5, now, now.Add(time.Minute))
+	if _, err := h.DB.InsertExchangeRate(ctx, rate); err != nil {
+		t.Fatalf("InsertExchangeRate: %v", err)
+	}
+	deposit := NewTransaction(from, domainBank.TransactionTypeIn, 10.005)
+	if _, err := h.DB.CreateTransaction(ctx, from, deposit); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+	// a failed change leaves no event behind
+	if _, err := h.DB.CreateTransaction(ctx, from, deposit); err == nil {
+		t.Fatal("CreateTransaction with a duplicate uuid succeeded")
+	}
+	trf := domainBank.BankTransferOrm{
+		TransferUuid:      uuid.New(),
+		FromAccountUuid:   from.AccountUuid,
+		ToAccountUuid:     to.AccountUuid,
+		Currency:          "USD",
+		Amount:            20,
+		TransferTimestamp: now,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if _, err := h.DB.CreateTransfer(ctx, trf); err != nil {
+		t.Fatalf("CreateTransfer: %v", err)
+	}
+	if _, err := h.DB.CreateTransferTransactionPair(ctx, from, to,
+		NewTransaction(from, domainBank.TransactionTypeOut, 20),
+		NewTransaction(to, domainBank.TransactionTypeIn, 20)); err != nil {
+		t.Fatalf("CreateTransferTransactionPair: %v", err)
+	}
+	if err := h.DB.UpdateTransferStatus(ctx, trf, true); err != nil {
+		t.Fatalf("UpdateTransferStatus: %v", err)
+	}
+
+	pending, err := outbox.PendingEvents(ctx, 100)
+	if err != nil {
+		t.Fatalf("PendingEvents: %v", err)
+	}
+	want := []struct {
+		eventType string
+		aggregate uuid.UUID
+	}{
+		{domainEvent.TypeExchangeRateCreated, rate.ExchangeRateUuid},
+		{domainEvent.TypeTransactionCreated, from.AccountUuid},
+		{domainEvent.TypeTransferCreated, trf.TransferUuid},
+		{domainEvent.TypeTransactionCreated, from.AccountUuid},
+		{domainEvent.TypeTransactionCreated, to.AccountUuid},
+		{domainEvent.TypeTransferCompleted, trf.TransferUuid},
+	}
+	if len(pending) != len(want) {
+		t.Fatalf("got %d pending events, want %d: %+v", len(pending), len(want), pending)
+	}
+	for i, w := range want {
+		if pending[i].Type != w.eventType || pending[i].AggregateID != w.aggregate || pending[i].Version != domainEvent.Version {
+			t.Errorf("event %d = %v v%d of %v, want %v v%d of %v", i,
+				pending[i].Type, pending[i].Version, pending[i].AggregateID, w.eventType, domainEvent.Version, w.aggregate)
+		}
+	}
+
+	var created domainEvent.TransactionCreated
+	if err := json.Unmarshal(pending[1].Data, &created); err != nil {
+		t.Fatalf("decode %s: %v", pending[1].Data, err)
+	}
+	if created.TransactionUuid != deposit.TransactionUuid || created.AccountNumber != from.AccountNumber || created.Amount != 10.01 {
+		t.Errorf("TransactionCreated = %+v, want %v of 10.01 on %v", created, deposit.TransactionUuid, from.AccountNumber)
+	}
+
+	first, err := outbox.PendingEvents(ctx, 2)
+	if err != nil || len(first) != 2 || first[0].ID != pending[0].ID || first[1].ID != pending[1].ID {
+		t.Fatalf("PendingEvents(2) = %+v, %v; want the two oldest events", first, err)
+	}
+
+	if err := outbox.MarkEventPublished(ctx, pending[0].ID, time.Now()); err != nil {
+		t.Fatalf("MarkEventPublished: %v", err)
+	}
+	rest, err := outbox.PendingEvents(ctx, 100)
+	if err != nil || len(rest) != len(want)-1 || rest[0].ID != pending[1].ID {
+		t.Errorf("after marking the oldest event, PendingEvents = %+v, %v", rest, err)
+	}
+
+	if err := outbox.MarkEventPublished(ctx, uuid.New(), time.Now()); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("MarkEventPublished of an unknown event: error = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func assertBalance(t *testing.T, h Harness, acc domainBank.BankAccountOrm, want float64) {
+	t.Helper()
+
+	got, err := h.DB.GetBalanceBankAccountByAccountNumber(context.Background(), acc.AccountNumber)
+	if err != nil {
+		t.Fatalf("GetBalanceBankAccountByAccountNumber(%v): %v", acc.AccountNumber, err)
+	}
+
+	if got.CurrentBalance != want {
+		t.Errorf("balance of %v = %v, want %v", acc.AccountNumber, got.CurrentBalance, want)
+	}
+}
+
+// NewAccount returns an account with a random, unused account number.
+func NewAccount(balance float64) domainBank.BankAccountOrm {
+	now := time.Now().UTC()
+	id := uuid.New()
+
+	return domainBank.BankAccountOrm{
+		AccountUuid:    id,
+		AccountNumber:  fmt.Sprintf("T%019d", rand.Int63()),
+		AccountName:    "Contract " + id.String()[:8],
+		Currency:       "USD",
+		CurrentBalance: balance,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// NewTransaction returns a transaction of acc that hasn't been stored yet.
+func NewTransaction(acc domainBank.BankAccountOrm, trxType string, amount float64) domainBank.BankTransactionOrm {
+	now := time.Now().UTC()
+
+	return domainBank.BankTransactionOrm{
+		TransactionUuid:      uuid.New(),
+		AccountUuid:          acc.AccountUuid,
+		TransactionTimestamp: now,
+		Amount:               amount,
+		TransactionType:      trxType,
+		Notes:                "contract test",
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+}
+
+func NewExchangeRate(from, to string, rate float64, validFrom, validTo time.Time) domainBank.BankExchangeRateOrm {
+	now := time.Now().UTC()
+
+	return domainBank.BankExchangeRateOrm{
+		ExchangeRateUuid:   uuid.New(),
+		FromCurrency:       from,
+		ToCurrency:         to,
+		Rate:               rate,
+		ValidFromTimestamp: validFrom,
+		ValidToTimestamp:   validTo,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+}
+
+// NewCurrencyPair returns made-up currency codes (VARCHAR(5)) so rates of
+// different runs against a shared database don't overlap.
+func NewCurrencyPair() (string, string) {
+	n := rand.Intn(26 * 26 * 26)
+	code := string(rune('A'+n%26)) + string(rune('A'+n/26%26)) + string(rune('A'+n/676))
+
+	return "X" + code, "Y" + code
+}
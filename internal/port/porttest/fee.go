@@ -0,0 +1,127 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+)
+
+func testFees(t *testing.T, h Harness) {
+	store, ok := h.DB.(port.FeeStorePort)
+	if !ok {
+		t.Skip("adapter has no fee store")
+	}
+	ctx := context.Background()
+	now := time.Now().UTC()
+	currency := fmt.Sprintf("X%04d", rand.Intn(1e4))
+
+	from, to, income := NewAccount(100), NewAccount(0), NewAccount(0)
+	h.Seed(t, from, to, income)
+
+	schedule := domainFee.Schedule{
+		FeeScheduleOrm: domainFee.FeeScheduleOrm{ScheduleUuid: uuid.New(), Currency: currency, MinFee: 1, FreePerMonth: 1,
+			FeeAccountUuid: income.AccountUuid, CreatedAt: now, UpdatedAt: now},
+		Tiers: []domainFee.FeeTierOrm{{MinAmount: 100, Rate: 1}, {MinAmount: 0, FlatAmount: 2}, {MinAmount: 50, FlatAmount: 1.5}},
+	}
+	for i := range schedule.Tiers {
+		schedule.Tiers[i].ScheduleUuid = schedule.ScheduleUuid
+	}
+	if err := store.SaveFeeSchedule(ctx, schedule); err != nil {
+		t.Fatalf("SaveFeeSchedule: %v", err)
+	}
+	// replaces the schedule and its tiers
+	schedule.Tiers = schedule.Tiers[1:]
+	if err := store.SaveFeeSchedule(ctx, schedule); err != nil {
+		t.Fatalf("SaveFeeSchedule again: %v", err)
+	}
+	clash := schedule
+	clash.ScheduleUuid = uuid.New()
+	clash.Tiers = nil
+	if err := store.SaveFeeSchedule(ctx, clash); err == nil {
+		t.Error("SaveFeeSchedule of a second schedule of the same currency, channel and segment succeeded")
+	}
+	orphan := clash
+	orphan.Channel = "MOBILE"
+	orphan.FeeAccountUuid = uuid.New()
+	if err := store.SaveFeeSchedule(ctx, orphan); err == nil {
+		t.Error("SaveFeeSchedule with an unknown fee account succeeded")
+	}
+
+	schedules, err := store.ListFeeSchedules(ctx)
+	if err != nil {
+		t.Fatalf("ListFeeSchedules: %v", err)
+	}
+	stored, ok := domainFee.Match(schedules, currency, "", "")
+	if !ok || stored.ScheduleUuid != schedule.ScheduleUuid || stored.MinFee != 1 || stored.FreePerMonth != 1 ||
+		len(stored.Tiers) != 2 || stored.Tiers[0].MinAmount != 0 || stored.Tiers[1].MinAmount != 50 || stored.Tiers[1].FlatAmount != 1.5 {
+		t.Fatalf("ListFeeSchedules = %+v, want the replaced schedule with its tiers by minimum amount", stored)
+	}
+
+	book := func(amount float64) (domainFee.Breakdown, error) {
+		t.Helper()
+		trf := domainBank.BankTransferOrm{TransferUuid: uuid.New(), FromAccountUuid: from.AccountUuid, ToAccountUuid: to.AccountUuid,
+			Currency: currency, Amount: amount, TransferTimestamp: now, CreatedAt: now, UpdatedAt: now}
+		if _, err := h.DB.CreateTransfer(ctx, trf); err != nil {
+			t.Fatalf("CreateTransfer: %v", err)
+		}
+		return store.CreateChargedTransferTransactionPair(ctx, from, to,
+			NewTransaction(from, domainBank.TransactionTypeOut, amount), NewTransaction(to, domainBank.TransactionTypeIn, amount),
+			domainFee.Charge{TransferUuid: trf.TransferUuid, Currency: currency, Breakdown: stored.Evaluate(amount),
+				FreePerMonth: stored.FreePerMonth, Rate: 1, MonthStart: domainFee.MonthStart(now), FeeAccount: income,
+				FeeTransaction:    NewTransaction(from, domainBank.TransactionTypeOut, 0),
+				IncomeTransaction: NewTransaction(income, domainBank.TransactionTypeIn, 0)})
+	}
+	transfer := func(amount float64) domainFee.Breakdown {
+		t.Helper()
+		fee, err := book(amount)
+		if err != nil {
+			t.Fatalf("CreateChargedTransferTransactionPair: %v", err)
+		}
+		return fee
+	}
+
+	if fee := transfer(10); !fee.Waived || fee.Charged != 0 || fee.FreeRemaining != 0 {
+		t.Errorf("fee of the first transfer of the month = %+v, want it waived", fee)
+	}
+	assertBalance(t, h, from, 90)
+	assertBalance(t, h, income, 0)
+	if fee := transfer(60); fee.Waived || fee.Fee != 1.5 || fee.Charged != 1.5 {
+		t.Errorf("fee of the second transfer of the month = %+v, want 1.5 charged", fee)
+	}
+	assertBalance(t, h, from, 28.5)
+	assertBalance(t, h, to, 70)
+	assertBalance(t, h, income, 1.5)
+
+	// the amount is covered, the fee of 2 on top of it isn't
+	var breach *domainBank.InsufficientFundsError
+	if _, err := book(27.5); !errors.As(err, &breach) || breach.Available != 1 {
+		t.Errorf("transfer leaving too little for its fee = %v, want a breach with 1 available", err)
+	}
+	assertBalance(t, h, from, 28.5)
+	assertBalance(t, h, income, 1.5)
+
+	if n, err := store.CountTransferFees(ctx, from.AccountUuid, stored.ScheduleUuid, domainFee.MonthStart(now)); err != nil || n != 2 {
+		t.Errorf("CountTransferFees = %v, %v; want 2", n, err)
+	}
+	if n, err := store.CountTransferFees(ctx, from.AccountUuid, stored.ScheduleUuid, now.Add(time.Hour)); err != nil || n != 0 {
+		t.Errorf("CountTransferFees since a later time = %v, %v; want 0", n, err)
+	}
+
+	if err := store.SetCustomerSegment(ctx, from.AccountUuid, "PREMIUM", now); err != nil {
+		t.Fatalf("SetCustomerSegment: %v", err)
+	}
+	if got, err := h.DB.GetDetailBankAccountByUuid(ctx, from.AccountUuid); err != nil || got.CustomerSegment != "PREMIUM" {
+		t.Errorf("account after SetCustomerSegment = %+v, %v; want it PREMIUM", got, err)
+	}
+	if err := store.SetCustomerSegment(ctx, uuid.New(), "PREMIUM", now); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("SetCustomerSegment of an unknown account = %v, want ErrRecordNotFound", err)
+	}
+}
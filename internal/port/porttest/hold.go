@@ -0,0 +1,230 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// NewHold returns an active hold of amount on acc that hasn't been stored
+// yet, expiring an hour from now.
+func NewHold(acc domainBank.BankAccountOrm, amount float64) domainBank.BankHoldOrm {
+	now := time.Now().UTC()
+
+	return domainBank.BankHoldOrm{
+		HoldUuid:      uuid.New(),
+		AccountUuid:   acc.AccountUuid,
+		AccountNumber: acc.AccountNumber,
+		Amount:        amount,
+		Status:        domainBank.HoldStatusActive,
+		Notes:         "contract test",
+		ExpiresAt:     now.Add(time.Hour),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func assertHeld(t *testing.T, h Harness, acc domainBank.BankAccountOrm, at time.Time, want float64) {
+	t.Helper()
+
+	got, err := h.DB.HeldAmount(context.Background(), acc.AccountUuid, at)
+	if err != nil {
+		t.Fatalf("HeldAmount(%v): %v", acc.AccountNumber, err)
+	}
+	if got != want {
+		t.Errorf("held on %v = %v, want %v", acc.AccountNumber, got, want)
+	}
+}
+
+func testHolds(t *testing.T, h Harness) {
+	ctx := context.Background()
+	acc := NewAccount(100)
+	h.Seed(t, acc)
+	now := time.Now().UTC()
+
+	hold := NewHold(acc, 60)
+	if err := h.DB.CreateHold(ctx, hold, 0); err != nil {
+		t.Fatalf("CreateHold: %v", err)
+	}
+	assertHeld(t, h, acc, now, 60)
+	// an expired hold reserves nothing
+	assertHeld(t, h, acc, hold.ExpiresAt, 0)
+
+	// holds can't reserve more than the balance less the other holds
+	if err := h.DB.CreateHold(ctx, NewHold(acc, 40.01), 0); !errors.Is(err, domainBank.ErrInsufficientBalance) {
+		t.Errorf("CreateHold beyond the available balance = %v, want ErrInsufficientBalance", err)
+	}
+	// down to the floor, below zero for an overdraft
+	overdraft := NewHold(acc, 45)
+	if err := h.DB.CreateHold(ctx, overdraft, -5); err != nil {
+		t.Errorf("CreateHold within the overdraft = %v", err)
+	}
+	if err := h.DB.CreateHold(ctx, NewHold(acc, 0.01), -5); !errors.Is(err, domainBank.ErrInsufficientBalance) {
+		t.Errorf("CreateHold beyond the overdraft = %v, want ErrInsufficientBalance", err)
+	}
+	if _, err := h.DB.ReleaseHold(ctx, overdraft.HoldUuid, now); err != nil {
+		t.Fatalf("ReleaseHold: %v", err)
+	}
+	if err := h.DB.CreateHold(ctx, NewHold(domainBank.BankAccountOrm{AccountUuid: uuid.New()}, 1), 0); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("CreateHold on an unknown account = %v, want ErrRecordNotFound", err)
+	}
+
+	got, err := h.DB.GetHold(ctx, hold.HoldUuid)
+	if err != nil || got.Amount != 60 || got.Status != domainBank.HoldStatusActive || got.AccountNumber != acc.AccountNumber {
+		t.Errorf("GetHold = %+v, %v", got, err)
+	}
+	if _, err := h.DB.GetHold(ctx, uuid.New()); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("GetHold of an unknown hold = %v, want ErrRecordNotFound", err)
+	}
+
+	capture := func(amount float64) (domainBank.BankHoldOrm, error) {
+		return h.DB.CaptureHold(ctx, domainBank.HoldCapture{
+			Hold:        hold,
+			Account:     acc,
+			Transaction: NewTransaction(acc, domainBank.TransactionTypeOut, amount),
+		})
+	}
+
+	got, err = capture(25)
+	if err != nil {
+		t.Fatalf("CaptureHold: %v", err)
+	}
+	if got.CapturedAmount != 25 || got.Status != domainBank.HoldStatusActive {
+		t.Errorf("hold after a partial capture = %+v", got)
+	}
+	assertBalance(t, h, acc, 75)
+	assertHeld(t, h, acc, now, 35)
+
+	if _, err := capture(35.01); !errors.Is(err, domainBank.ErrHoldExceeded) {
+		t.Errorf("CaptureHold beyond the hold = %v, want ErrHoldExceeded", err)
+	}
+	assertBalance(t, h, acc, 75)
+
+	// concurrent captures of the rest add up to the hold at most
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = capture(35)
+		}()
+	}
+	wg.Wait()
+	captured := 0
+	for _, err := range errs {
+		if err == nil {
+			captured++
+		} else if !errors.Is(err, domainBank.ErrHoldExceeded) && !errors.Is(err, domainBank.ErrHoldNotActive) {
+			t.Errorf("concurrent CaptureHold: %v", err)
+		}
+	}
+	if captured != 1 {
+		t.Errorf("%d concurrent captures of the rest succeeded, want 1", captured)
+	}
+	assertBalance(t, h, acc, 40)
+	assertHeld(t, h, acc, now, 0)
+
+	got, _ = h.DB.GetHold(ctx, hold.HoldUuid)
+	if got.Status != domainBank.HoldStatusCaptured || got.CapturedAmount != 60 {
+		t.Errorf("fully captured hold = %+v", got)
+	}
+	if _, err := h.DB.ReleaseHold(ctx, hold.HoldUuid, now); !errors.Is(err, domainBank.ErrHoldNotActive) {
+		t.Errorf("ReleaseHold of a captured hold = %v, want ErrHoldNotActive", err)
+	}
+
+	released := NewHold(acc, 30)
+	if err := h.DB.CreateHold(ctx, released, 0); err != nil {
+		t.Fatalf("CreateHold: %v", err)
+	}
+	got, err = h.DB.ReleaseHold(ctx, released.HoldUuid, now)
+	if err != nil || got.Status != domainBank.HoldStatusReleased {
+		t.Errorf("ReleaseHold = %+v, %v", got, err)
+	}
+	assertHeld(t, h, acc, now, 0)
+	hold = released
+	if _, err := capture(1); !errors.Is(err, domainBank.ErrHoldNotActive) {
+		t.Errorf("CaptureHold of a released hold = %v, want ErrHoldNotActive", err)
+	}
+
+	expired := NewHold(acc, 10)
+	if err := h.DB.CreateHold(ctx, expired, 0); err != nil {
+		t.Fatalf("CreateHold: %v", err)
+	}
+	if _, err := h.DB.ReleaseHold(ctx, expired.HoldUuid, expired.ExpiresAt); !errors.Is(err, domainBank.ErrHoldNotActive) {
+		t.Errorf("ReleaseHold of an expired hold = %v, want ErrHoldNotActive", err)
+	}
+	if _, err := h.DB.ReleaseHold(ctx, uuid.New(), now); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("ReleaseHold of an unknown hold = %v, want ErrRecordNotFound", err)
+	}
+	assertBalance(t, h, acc, 40)
+}
+
+func testDebitsRespectHolds(t *testing.T, h Harness) {
+	ctx := context.Background()
+	acc, to := NewAccount(100), NewAccount(0)
+	h.Seed(t, acc, to)
+
+	if err := h.DB.CreateHold(ctx, NewHold(acc, 70), 0); err != nil {
+		t.Fatalf("CreateHold: %v", err)
+	}
+	var breach *domainBank.InsufficientFundsError
+	_, err := h.DB.CreateTransaction(ctx, acc, NewTransaction(acc, domainBank.TransactionTypeOut, 30.01))
+	if !errors.As(err, &breach) || breach.Available != 30 {
+		t.Errorf("CreateTransaction of held money = %v, want a breach with 30 available", err)
+	}
+	_, err = h.DB.CreateTransferTransactionPair(ctx, acc, to,
+		NewTransaction(acc, domainBank.TransactionTypeOut, 31),
+		NewTransaction(to, domainBank.TransactionTypeIn, 31))
+	if !errors.As(err, &breach) || breach.Available != 30 {
+		t.Errorf("CreateTransferTransactionPair of held money = %v, want a breach with 30 available", err)
+	}
+	assertBalance(t, h, acc, 100)
+	assertBalance(t, h, to, 0)
+
+	// holds and debits racing for the rest take it once
+	var wg sync.WaitGroup
+	errs := make([]error, 6)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch i % 3 {
+			case 0:
+				errs[i] = h.DB.CreateHold(ctx, NewHold(acc, 30), 0)
+			case 1:
+				_, errs[i] = h.DB.CreateTransaction(ctx, acc, NewTransaction(acc, domainBank.TransactionTypeOut, 30))
+			default:
+				_, errs[i] = h.DB.CreateTransferTransactionPair(ctx, acc, to,
+					NewTransaction(acc, domainBank.TransactionTypeOut, 30),
+					NewTransaction(to, domainBank.TransactionTypeIn, 30))
+			}
+		}()
+	}
+	wg.Wait()
+	took := 0
+	for _, err := range errs {
+		if err == nil {
+			took++
+		} else if !errors.Is(err, domainBank.ErrInsufficientBalance) {
+			t.Errorf("concurrent hold or debit: %v", err)
+		}
+	}
+	if took != 1 {
+		t.Errorf("%d concurrent holds and debits of the rest succeeded, want 1", took)
+	}
+
+	got, err := h.DB.GetDetailBankAccountByAccountNumber(ctx, acc.AccountNumber)
+	if err != nil {
+		t.Fatalf("GetDetailBankAccountByAccountNumber: %v", err)
+	}
+	held, err := h.DB.HeldAmount(ctx, acc.AccountUuid, time.Now().UTC())
+	if err != nil || got.CurrentBalance-held != 0 {
+		t.Errorf("balance %v less held %v, %v; want nothing left", got.CurrentBalance, held, err)
+	}
+}
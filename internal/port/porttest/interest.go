@@ -0,0 +1,158 @@
+package porttest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+)
+
+func testInterest(t *testing.T, h Harness) {
+	store, ok := h.DB.(port.InterestStorePort)
+	if !ok {
+		t.Skip("adapter has no interest store")
+	}
+	ctx := context.Background()
+	now := time.Now().UTC()
+	today := domainInterest.Day(now)
+	acc := NewAccount(10)
+	acc.CreatedAt = today.AddDate(0, 0, -3)
+	h.Seed(t, acc)
+
+	code := fmt.Sprintf("I%d", rand.Int63n(1e15))
+	product := domainBank.BankProductOrm{ProductCode: code, Name: "Saver", InterestDayCount: domainInterest.DayCountAct360,
+		InterestAccrualFrequency: domainInterest.AccrueMonthly, InterestCapitalization: domainInterest.CapitalizeQuarterly, CreatedAt: now, UpdatedAt: now}
+	if _, err := h.DB.SaveProduct(ctx, product); err != nil {
+		t.Fatalf("SaveProduct: %v", err)
+	}
+
+	version := func(from time.Time, rates ...float64) domainInterest.RateVersion {
+		v := domainInterest.RateVersion{ProductCode: code, EffectiveFrom: from}
+		for i, rate := range rates {
+			v.Tiers = append(v.Tiers, domainInterest.InterestRateOrm{RateUuid: uuid.New(), ProductCode: code,
+				EffectiveFrom: from, MinBalance: float64(i * 1000), AnnualRate: rate, CreatedAt: now})
+		}
+		return v
+	}
+	if err := store.SaveInterestRates(ctx, version(today.AddDate(0, 0, -10), 1, 2)); err != nil {
+		t.Fatalf("SaveInterestRates: %v", err)
+	}
+	if err := store.SaveInterestRates(ctx, version(today, 3)); err != nil {
+		t.Fatalf("SaveInterestRates of a later version: %v", err)
+	}
+	// replaces the version effective from the same day
+	if err := store.SaveInterestRates(ctx, version(today.AddDate(0, 0, -10), 1.5, 2.5, 3.5)); err != nil {
+		t.Fatalf("SaveInterestRates again: %v", err)
+	}
+	if err := store.SaveInterestRates(ctx, domainInterest.RateVersion{ProductCode: "NOPE" + code, EffectiveFrom: today,
+		Tiers: []domainInterest.InterestRateOrm{{RateUuid: uuid.New(), ProductCode: "NOPE" + code, EffectiveFrom: today, CreatedAt: now}}}); err == nil {
+		t.Error("SaveInterestRates of an unknown product succeeded")
+	}
+
+	rates, err := store.ListInterestRates(ctx, code)
+	if err != nil {
+		t.Fatalf("ListInterestRates: %v", err)
+	}
+	versions := domainInterest.Versions(rates)
+	if len(versions) != 2 || len(versions[0].Tiers) != 3 || versions[0].Tiers[2].AnnualRate != 3.5 ||
+		!versions[0].EffectiveFrom.Equal(today.AddDate(0, 0, -10)) || len(versions[1].Tiers) != 1 {
+		t.Errorf("ListInterestRates = %+v, want the replaced version and the later one", rates)
+	}
+
+	listed := func() bool {
+		accounts, err := store.InterestAccounts(ctx)
+		if err != nil {
+			t.Fatalf("InterestAccounts: %v", err)
+		}
+		for _, a := range accounts {
+			if a.Account.AccountUuid == acc.AccountUuid {
+				if a.Product.InterestDayCount != domainInterest.DayCountAct360 || a.Product.InterestAccrualFrequency != domainInterest.AccrueMonthly ||
+					a.Product.InterestCapitalization != domainInterest.CapitalizeQuarterly {
+					t.Errorf("product of the interest account = %+v", a.Product)
+				}
+				return true
+			}
+		}
+		return false
+	}
+	if listed() {
+		t.Error("InterestAccounts lists an account of a product without rates")
+	}
+	acc.ProductCode = code
+	if err := h.DB.UpdateAccountLimits(ctx, acc); err != nil {
+		t.Fatalf("UpdateAccountLimits: %v", err)
+	}
+	if !listed() {
+		t.Error("InterestAccounts doesn't list the account of a product with rates")
+	}
+
+	// the deposit is part of the closing balance of today only
+	deposit := NewTransaction(acc, domainBank.TransactionTypeIn, 5)
+	if _, err := h.DB.CreateTransaction(ctx, acc, deposit); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+	balances, err := store.ClosingBalances(ctx, acc.AccountUuid, today.AddDate(0, 0, -2), today.AddDate(0, 0, 1))
+	if err != nil || len(balances) != 3 || balances[0] != 10 || balances[1] != 10 || balances[2] != 15 {
+		t.Errorf("ClosingBalances = %v, %v; want [10 10 15]", balances, err)
+	}
+
+	if last, err := store.LastAccrualDate(ctx, acc.AccountUuid); err != nil || !last.IsZero() {
+		t.Errorf("LastAccrualDate before any accrual = %v, %v; want the zero time", last, err)
+	}
+	accrual := func(daysAgo int, amount float64) domainInterest.InterestAccrualOrm {
+		return domainInterest.InterestAccrualOrm{AccountUuid: acc.AccountUuid, AccrualDate: today.AddDate(0, 0, -daysAgo),
+			ProductCode: code, Balance: 10, AnnualRate: 1.5, Amount: amount, CreatedAt: now}
+	}
+	if err := store.SaveInterestAccruals(ctx, []domainInterest.InterestAccrualOrm{accrual(3, 0.004), accrual(2, 0.003), accrual(1, 0.002)}); err != nil {
+		t.Fatalf("SaveInterestAccruals: %v", err)
+	}
+	// a day accrued already is skipped
+	if err := store.SaveInterestAccruals(ctx, []domainInterest.InterestAccrualOrm{accrual(1, 1), accrual(0, 0.0005)}); err != nil {
+		t.Fatalf("SaveInterestAccruals again: %v", err)
+	}
+	if last, err := store.LastAccrualDate(ctx, acc.AccountUuid); err != nil || !last.Equal(today) {
+		t.Errorf("LastAccrualDate = %v, %v; want %v", last, err, today)
+	}
+
+	capitalization := domainInterest.Capitalization{Account: acc, Before: today, Transaction: NewTransaction(acc, domainBank.TransactionTypeIn, 0),
+		Withholding: domainTax.Withholding{WithholdingUuid: uuid.New()}}
+	posted, err := store.CapitalizeInterest(ctx, capitalization)
+	if err != nil || posted.Interest != 0.01 || posted.Tax != 0 {
+		t.Fatalf("CapitalizeInterest = %v, %v; want 0.01", posted, err)
+	}
+	assertBalance(t, h, acc, 15.01)
+	posting := capitalization.Transaction.TransactionUuid
+
+	capitalization.Transaction = NewTransaction(acc, domainBank.TransactionTypeIn, 0)
+	if posted, err := store.CapitalizeInterest(ctx, capitalization); err != nil || posted.Interest != 0 {
+		t.Errorf("CapitalizeInterest of capitalized accruals = %v, %v; want 0", posted, err)
+	}
+	// less than a cent waits for the next period
+	capitalization.Before = today.AddDate(0, 0, 1)
+	if posted, err := store.CapitalizeInterest(ctx, capitalization); err != nil || posted.Interest != 0 {
+		t.Errorf("CapitalizeInterest of a fraction of a cent = %v, %v; want 0", posted, err)
+	}
+	assertBalance(t, h, acc, 15.01)
+
+	accruals, err := store.ListInterestAccruals(ctx, acc.AccountUuid, today.AddDate(0, 0, -2), today.AddDate(0, 0, 1))
+	if err != nil || len(accruals) != 3 {
+		t.Fatalf("ListInterestAccruals = %+v, %v; want 3 accruals", accruals, err)
+	}
+	if a := accruals[1]; !a.AccrualDate.Equal(today.AddDate(0, 0, -1)) || a.Amount != 0.002 || a.TransactionUuid == nil ||
+		*a.TransactionUuid != posting || a.CapitalizedAt == nil {
+		t.Errorf("capitalized accrual = %+v", a)
+	}
+	if a := accruals[2]; a.Amount != 0.0005 || a.TransactionUuid != nil || a.CapitalizedAt != nil {
+		t.Errorf("pending accrual = %+v", a)
+	}
+	if all, err := store.ListInterestAccruals(ctx, acc.AccountUuid, time.Time{}, time.Time{}); err != nil || len(all) != 4 {
+		t.Errorf("ListInterestAccruals without bounds = %d accruals, %v; want 4", len(all), err)
+	}
+}
@@ -0,0 +1,159 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+func testLimits(t *testing.T, h Harness) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	acc := NewAccount(10)
+	h.Seed(t, acc)
+
+	got, err := h.DB.GetDetailBankAccountByAccountNumber(ctx, acc.AccountNumber)
+	if err != nil {
+		t.Fatalf("GetDetailBankAccountByAccountNumber: %v", err)
+	}
+	if got.ProductCode != domainBank.DefaultProductCode || got.OverdraftLimit != nil || got.MinimumBalance != nil || got.OverdraftUsed != 0 {
+		t.Errorf("limits of a new account = %v, %v, %v, %v", got.ProductCode, got.OverdraftLimit, got.MinimumBalance, got.OverdraftUsed)
+	}
+
+	code := fmt.Sprintf("P%d", rand.Int63n(1e15))
+	if _, err := h.DB.GetProduct(ctx, code); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("GetProduct of an unknown product = %v, want ErrRecordNotFound", err)
+	}
+	product := domainBank.BankProductOrm{ProductCode: code, Name: "Gold", OverdraftLimit: 50, CreatedAt: now, UpdatedAt: now}
+	if _, err := h.DB.SaveProduct(ctx, product); err != nil {
+		t.Fatalf("SaveProduct: %v", err)
+	}
+	product.Name = "Gold plus"
+	product.MinimumBalance = 5
+	product.CreatedAt = now.Add(time.Hour)
+	product.UpdatedAt = now.Add(time.Hour)
+	saved, err := h.DB.SaveProduct(ctx, product)
+	if err != nil {
+		t.Fatalf("SaveProduct again: %v", err)
+	}
+	if saved.Name != "Gold plus" || saved.OverdraftLimit != 50 || saved.MinimumBalance != 5 || !saved.CreatedAt.Equal(now) {
+		t.Errorf("saved product = %+v, want the new defaults created at %v", saved, now)
+	}
+	products, err := h.DB.ListProducts(ctx)
+	if err != nil {
+		t.Fatalf("ListProducts: %v", err)
+	}
+	listed := false
+	for _, p := range products {
+		listed = listed || p.ProductCode == code
+	}
+	if !listed {
+		t.Errorf("ListProducts = %v, want %v in it", products, code)
+	}
+
+	overdraft := 25.0
+	got.ProductCode = code
+	got.OverdraftLimit = &overdraft
+	if err := h.DB.UpdateAccountLimits(ctx, got); err != nil {
+		t.Fatalf("UpdateAccountLimits: %v", err)
+	}
+	if err := h.DB.UpdateAccountLimits(ctx, domainBank.BankAccountOrm{AccountUuid: uuid.New(), ProductCode: code}); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("UpdateAccountLimits of an unknown account = %v, want ErrRecordNotFound", err)
+	}
+
+	// the overdraft used follows the balance below zero
+	assertOverdraftUsed := func(want float64) {
+		t.Helper()
+
+		got, err := h.DB.GetDetailBankAccountByAccountNumber(ctx, acc.AccountNumber)
+		if err != nil {
+			t.Fatalf("GetDetailBankAccountByAccountNumber: %v", err)
+		}
+		if got.OverdraftUsed != want {
+			t.Errorf("overdraft used = %v, want %v", got.OverdraftUsed, want)
+		}
+		if got.ProductCode != code || got.OverdraftLimit == nil || *got.OverdraftLimit != 25 || got.MinimumBalance != nil {
+			t.Errorf("limits = %v, %v, %v; want %v, 25, nil", got.ProductCode, got.OverdraftLimit, got.MinimumBalance, code)
+		}
+	}
+	for _, step := range []struct {
+		trxType string
+		amount  float64
+		used    float64
+	}{
+		{domainBank.TransactionTypeOut, 12.5, 2.5},
+		{domainBank.TransactionTypeOut, 0.25, 2.75},
+		{domainBank.TransactionTypeIn, 1, 1.75},
+		{domainBank.TransactionTypeIn, 5, 0},
+	} {
+		if _, err := h.DB.CreateTransaction(ctx, got, NewTransaction(got, step.trxType, step.amount)); err != nil {
+			t.Fatalf("CreateTransaction: %v", err)
+		}
+		assertOverdraftUsed(step.used)
+	}
+	assertBalance(t, h, acc, 3.25)
+}
+
+func testConcurrentDebitsRespectLimits(t *testing.T, h Harness) {
+	ctx := context.Background()
+	overdraft, minimum := 25.0, 20.0
+
+	for _, tt := range []struct {
+		name    string
+		balance float64
+		limits  func(*domainBank.BankAccountOrm)
+		rule    string
+		left    float64
+	}{
+		{"overdraft", 10, func(a *domainBank.BankAccountOrm) { a.OverdraftLimit = &overdraft }, domainBank.LimitRuleOverdraft, -20},
+		{"minimum balance", 50, func(a *domainBank.BankAccountOrm) { a.MinimumBalance = &minimum }, domainBank.LimitRuleMinimumBalance, 20},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			acc, to := NewAccount(tt.balance), NewAccount(0)
+			h.Seed(t, acc, to)
+			tt.limits(&acc)
+			if err := h.DB.UpdateAccountLimits(ctx, acc); err != nil {
+				t.Fatalf("UpdateAccountLimits: %v", err)
+			}
+
+			// debits of 10 racing past the floor, three of them fit
+			var wg sync.WaitGroup
+			errs := make([]error, 6)
+			for i := range errs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if i%2 == 0 {
+						_, errs[i] = h.DB.CreateTransaction(ctx, acc, NewTransaction(acc, domainBank.TransactionTypeOut, 10))
+						return
+					}
+					_, errs[i] = h.DB.CreateTransferTransactionPair(ctx, acc, to,
+						NewTransaction(acc, domainBank.TransactionTypeOut, 10),
+						NewTransaction(to, domainBank.TransactionTypeIn, 10))
+				}()
+			}
+			wg.Wait()
+			took := 0
+			for _, err := range errs {
+				var breach *domainBank.InsufficientFundsError
+				switch {
+				case err == nil:
+					took++
+				case !errors.As(err, &breach) || breach.Rule != tt.rule:
+					t.Errorf("concurrent debit = %v, want a breach of %v", err, tt.rule)
+				}
+			}
+			if took != 3 {
+				t.Errorf("%d concurrent debits succeeded, want 3", took)
+			}
+			assertBalance(t, h, acc, tt.left)
+		})
+	}
+}
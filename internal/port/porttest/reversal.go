@@ -0,0 +1,167 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+// NewTransfer returns a completed transfer of amount from from to to that
+// hasn't been stored yet.
+func NewTransfer(from, to domainBank.BankAccountOrm, amount float64) domainBank.BankTransferOrm {
+	now := time.Now().UTC()
+
+	return domainBank.BankTransferOrm{
+		TransferUuid:      uuid.New(),
+		FromAccountUuid:   from.AccountUuid,
+		ToAccountUuid:     to.AccountUuid,
+		Currency:          "USD",
+		Amount:            amount,
+		TransferTimestamp: now,
+		TransferSuccess:   true,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+}
+
+// NewReversalBooking returns the booking of a reversal of amount of original,
+// sent by sender to recipient.
+func NewReversalBooking(original domainBank.BankTransferOrm, sender, recipient domainBank.BankAccountOrm, amount float64) domainBank.TransferReversalBooking {
+	reversal := NewTransfer(recipient, sender, amount)
+	reversal.ReversalOfUuid = &original.TransferUuid
+	reversal.ReversalReason = "contract test"
+
+	return domainBank.TransferReversalBooking{
+		Original:  original,
+		Reversal:  reversal,
+		Recipient: recipient,
+		Sender:    sender,
+		Debit:     NewTransaction(recipient, domainBank.TransactionTypeOut, amount),
+		Credit:    NewTransaction(sender, domainBank.TransactionTypeIn, amount),
+	}
+}
+
+func testAccountByUuid(t *testing.T, h Harness) {
+	ctx := context.Background()
+	acc := NewAccount(7)
+	h.Seed(t, acc)
+
+	got, err := h.DB.GetDetailBankAccountByUuid(ctx, acc.AccountUuid)
+	if err != nil || got.AccountNumber != acc.AccountNumber || got.CurrentBalance != 7 {
+		t.Errorf("GetDetailBankAccountByUuid = %+v, %v; want %+v", got, err, acc)
+	}
+
+	if _, err := h.DB.GetDetailBankAccountByUuid(ctx, uuid.New()); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("GetDetailBankAccountByUuid of an unknown account = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func testReverseTransfer(t *testing.T, h Harness) {
+	ctx := context.Background()
+	sender, recipient := NewAccount(100), NewAccount(5)
+	h.Seed(t, sender, recipient)
+
+	original := NewTransfer(sender, recipient, 12.5)
+	if _, err := h.DB.CreateTransfer(ctx, original); err != nil {
+		t.Fatalf("CreateTransfer: %v", err)
+	}
+
+	// refunds add up to the amount of the transfer at most
+	first := NewReversalBooking(original, sender, recipient, 4.2)
+	if err := h.DB.ReverseTransfer(ctx, first); err != nil {
+		t.Fatalf("ReverseTransfer: %v", err)
+	}
+	assertBalance(t, h, sender, 104.2)
+	assertBalance(t, h, recipient, 0.8)
+
+	tooMuch := NewReversalBooking(original, sender, recipient, 8.31)
+	tooMuch.AllowOverdraft = true
+	if err := h.DB.ReverseTransfer(ctx, tooMuch); !errors.Is(err, domainBank.ErrReversalExceedsTransfer) {
+		t.Errorf("ReverseTransfer beyond the transfer = %v, want ErrReversalExceedsTransfer", err)
+	}
+
+	overdraft := NewReversalBooking(original, sender, recipient, 8.3)
+	if err := h.DB.ReverseTransfer(ctx, overdraft); !errors.Is(err, domainBank.ErrInsufficientBalance) {
+		t.Errorf("ReverseTransfer overdrawing the recipient = %v, want ErrInsufficientBalance", err)
+	}
+	// neither touched anything
+	assertBalance(t, h, sender, 104.2)
+	assertBalance(t, h, recipient, 0.8)
+
+	overdraft.AllowOverdraft = true
+	if err := h.DB.ReverseTransfer(ctx, overdraft); err != nil {
+		t.Fatalf("ReverseTransfer with overdraft: %v", err)
+	}
+	assertBalance(t, h, sender, 112.5)
+	assertBalance(t, h, recipient, -7.5)
+
+	stored, err := h.DB.GetTransfer(ctx, original.TransferUuid)
+	if err != nil || stored.ReversedAmount != 12.5 || stored.RemainingAmount() != 0 || stored.ReversalOfUuid != nil {
+		t.Errorf("reversed transfer = %+v, %v; want it fully reversed", stored, err)
+	}
+	reversal, err := h.DB.GetTransfer(ctx, first.Reversal.TransferUuid)
+	if err != nil || reversal.ReversalOfUuid == nil || *reversal.ReversalOfUuid != original.TransferUuid ||
+		reversal.ReversalReason != "contract test" || reversal.Amount != 4.2 || reversal.FromAccountUuid != recipient.AccountUuid {
+		t.Errorf("reversal = %+v, %v; want the refund of %v", reversal, err, original.TransferUuid)
+	}
+
+	// a reversal is not reversible itself
+	if err := h.DB.ReverseTransfer(ctx, NewReversalBooking(reversal, recipient, sender, 1)); !errors.Is(err, domainBank.ErrReversalExceedsTransfer) {
+		t.Errorf("ReverseTransfer of a reversal = %v, want ErrReversalExceedsTransfer", err)
+	}
+
+	if _, err := h.DB.GetTransfer(ctx, uuid.New()); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("GetTransfer of an unknown transfer = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func testReverseTransferLimits(t *testing.T, h Harness) {
+	ctx := context.Background()
+	sender, held, overdrawn := NewAccount(100), NewAccount(10), NewAccount(5)
+	h.Seed(t, sender, held, overdrawn)
+
+	// the holds of the recipient are not refunded
+	toHeld := NewTransfer(sender, held, 10)
+	if _, err := h.DB.CreateTransfer(ctx, toHeld); err != nil {
+		t.Fatalf("CreateTransfer: %v", err)
+	}
+	if err := h.DB.CreateHold(ctx, NewHold(held, 6), 0); err != nil {
+		t.Fatalf("CreateHold: %v", err)
+	}
+	var breach *domainBank.InsufficientFundsError
+	err := h.DB.ReverseTransfer(ctx, NewReversalBooking(toHeld, sender, held, 5))
+	if !errors.As(err, &breach) || breach.Rule != domainBank.LimitRuleBalance || breach.Available != 4 {
+		t.Errorf("ReverseTransfer of held money = %v, want an INSUFFICIENT_BALANCE breach with 4 available", err)
+	}
+	if err := h.DB.ReverseTransfer(ctx, NewReversalBooking(toHeld, sender, held, 4)); err != nil {
+		t.Fatalf("ReverseTransfer of what isn't held: %v", err)
+	}
+	assertBalance(t, h, held, 6)
+
+	// the overdraft of the recipient is, up to its limit
+	limit := 20.0
+	overdrawn.OverdraftLimit = &limit
+	if err := h.DB.UpdateAccountLimits(ctx, overdrawn); err != nil {
+		t.Fatalf("UpdateAccountLimits: %v", err)
+	}
+	toOverdrawn := NewTransfer(sender, overdrawn, 40)
+	if _, err := h.DB.CreateTransfer(ctx, toOverdrawn); err != nil {
+		t.Fatalf("CreateTransfer: %v", err)
+	}
+	if err := h.DB.ReverseTransfer(ctx, NewReversalBooking(toOverdrawn, sender, overdrawn, 20)); err != nil {
+		t.Fatalf("ReverseTransfer within the overdraft: %v", err)
+	}
+	err = h.DB.ReverseTransfer(ctx, NewReversalBooking(toOverdrawn, sender, overdrawn, 10))
+	if !errors.As(err, &breach) || breach.Rule != domainBank.LimitRuleOverdraft || breach.Available != 5 {
+		t.Errorf("ReverseTransfer beyond the overdraft = %v, want an OVERDRAFT_LIMIT breach with 5 available", err)
+	}
+	assertBalance(t, h, overdrawn, -15)
+	assertBalance(t, h, sender, 124)
+	if stored, err := h.DB.GetTransfer(ctx, toOverdrawn.TransferUuid); err != nil || stored.ReversedAmount != 20 {
+		t.Errorf("reversed transfer = %+v, %v; want 20 reversed", stored, err)
+	}
+}
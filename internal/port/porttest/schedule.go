@@ -0,0 +1,155 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainSchedule "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/schedule"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+)
+
+func testScheduledTransfers(t *testing.T, h Harness) {
+	store, ok := h.DB.(port.ScheduleStorePort)
+	if !ok {
+		t.Skip("adapter has no schedule store")
+	}
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	from, to := NewAccount(100), NewAccount(0)
+	h.Seed(t, from, to)
+
+	newSchedule := func(next time.Time) domainSchedule.ScheduledTransferOrm {
+		t.Helper()
+		s := domainSchedule.ScheduledTransferOrm{ScheduleUuid: uuid.New(), FromAccountUuid: from.AccountUuid, ToAccountUuid: to.AccountUuid,
+			Currency: "USD", Amount: 1, Recurrence: "FREQ=DAILY", StartAt: next, Status: domainSchedule.StatusActive, NextRunAt: &next,
+			MaxRetries: 1, RetryIntervalSeconds: 60, CreatedAt: now, UpdatedAt: now}
+		if err := store.CreateScheduledTransfer(ctx, s); err != nil {
+			t.Fatalf("CreateScheduledTransfer: %v", err)
+		}
+		return s
+	}
+	due, later := newSchedule(now.Add(-time.Hour)), newSchedule(now.Add(time.Hour))
+
+	orphan := due
+	orphan.ScheduleUuid = uuid.New()
+	orphan.ToAccountUuid = uuid.New()
+	if err := store.CreateScheduledTransfer(ctx, orphan); err == nil {
+		t.Error("CreateScheduledTransfer to an unknown account succeeded")
+	}
+
+	schedules, err := store.ListScheduledTransfers(ctx, from.AccountUuid)
+	if err != nil || len(schedules) != 2 || schedules[0].ScheduleUuid == schedules[1].ScheduleUuid {
+		t.Fatalf("ListScheduledTransfers = %+v, %v; want both schedules", schedules, err)
+	}
+	if schedules, err := store.ListScheduledTransfers(ctx, to.AccountUuid); err != nil || len(schedules) != 0 {
+		t.Errorf("ListScheduledTransfers of the receiver = %+v, %v; want none", schedules, err)
+	}
+
+	// only one of two claims gets the due schedule
+	lease := now.Add(5 * time.Minute)
+	claimed := func() []domainSchedule.ScheduledTransferOrm {
+		t.Helper()
+		schedules, err := store.ClaimScheduledTransfers(ctx, now, lease, 1000)
+		if err != nil {
+			t.Fatalf("ClaimScheduledTransfers: %v", err)
+		}
+		var ours []domainSchedule.ScheduledTransferOrm
+		for _, s := range schedules {
+			if s.FromAccountUuid == from.AccountUuid {
+				ours = append(ours, s)
+			}
+		}
+		return ours
+	}
+	first := claimed()
+	if len(first) != 1 || first[0].ScheduleUuid != due.ScheduleUuid || first[0].NextRunAt == nil || !first[0].NextRunAt.Equal(lease) {
+		t.Fatalf("ClaimScheduledTransfers = %+v, want the due schedule with its next run at the end of the lease", first)
+	}
+	if second := claimed(); len(second) != 0 {
+		t.Errorf("second ClaimScheduledTransfers = %+v, want none", second)
+	}
+
+	// a retry of occurrence 0
+	next := now.Add(time.Minute)
+	run := first[0]
+	run.NextRunAt, run.Attempt, run.UpdatedAt = &next, 1, now
+	execution := domainSchedule.ExecutionOrm{ExecutionUuid: uuid.New(), Occurrence: 0, Attempt: 0, ScheduledFor: due.StartAt,
+		ExecutedAt: now, Status: domainSchedule.ExecutionRetry, Error: "insufficient balance"}
+	if err := store.RecordScheduledTransferRun(ctx, run, execution); err != nil {
+		t.Fatalf("RecordScheduledTransferRun: %v", err)
+	}
+	duplicate := execution
+	duplicate.ExecutionUuid = uuid.New()
+	if err := store.RecordScheduledTransferRun(ctx, run, duplicate); err == nil {
+		t.Error("RecordScheduledTransferRun of the same attempt twice succeeded")
+	}
+	got, err := store.GetScheduledTransfer(ctx, due.ScheduleUuid)
+	if err != nil || got.Attempt != 1 || got.Occurrence != 0 || got.NextRunAt == nil || !got.NextRunAt.Equal(next) {
+		t.Fatalf("schedule after a retry = %+v, %v; want attempt 1 next at %v", got, err, next)
+	}
+
+	// the last attempt succeeds and completes the schedule
+	trf := domainBank.BankTransferOrm{TransferUuid: uuid.New(), FromAccountUuid: from.AccountUuid, ToAccountUuid: to.AccountUuid,
+		Currency: "USD", Amount: 1, TransferTimestamp: now, TransferSuccess: true, CreatedAt: now, UpdatedAt: now}
+	if _, err := h.DB.CreateTransfer(ctx, trf); err != nil {
+		t.Fatalf("CreateTransfer: %v", err)
+	}
+	run = got
+	run.NextRunAt, run.Occurrence, run.Attempt, run.Status = nil, 1, 0, domainSchedule.StatusCompleted
+	execution = domainSchedule.ExecutionOrm{ExecutionUuid: uuid.New(), Occurrence: 0, Attempt: 1, ScheduledFor: due.StartAt,
+		ExecutedAt: now, Status: domainSchedule.ExecutionSucceeded, TransferUuid: &trf.TransferUuid}
+	if err := store.RecordScheduledTransferRun(ctx, run, execution); err != nil {
+		t.Fatalf("RecordScheduledTransferRun: %v", err)
+	}
+	// a stale run of an attempt the schedule is past is history only
+	stale := got
+	stale.Occurrence = 5
+	execution = domainSchedule.ExecutionOrm{ExecutionUuid: uuid.New(), Occurrence: 0, Attempt: 2, ScheduledFor: due.StartAt,
+		ExecutedAt: now, Status: domainSchedule.ExecutionFailed}
+	if err := store.RecordScheduledTransferRun(ctx, stale, execution); err != nil {
+		t.Fatalf("RecordScheduledTransferRun of a stale attempt: %v", err)
+	}
+	got, err = store.GetScheduledTransfer(ctx, due.ScheduleUuid)
+	if err != nil || got.Status != domainSchedule.StatusCompleted || got.Occurrence != 1 || got.NextRunAt != nil {
+		t.Errorf("schedule after its last run = %+v, %v; want it COMPLETED after occurrence 1", got, err)
+	}
+
+	executions, err := store.ListScheduledTransferExecutions(ctx, due.ScheduleUuid)
+	if err != nil || len(executions) != 3 {
+		t.Fatalf("ListScheduledTransferExecutions = %+v, %v; want 3", executions, err)
+	}
+	if e := executions[1]; e.Attempt != 1 || e.Status != domainSchedule.ExecutionSucceeded || e.TransferUuid == nil || *e.TransferUuid != trf.TransferUuid {
+		t.Errorf("second execution = %+v, want the succeeded attempt with its transfer", e)
+	}
+
+	// status changes only from the given statuses
+	if err := store.UpdateScheduledTransferStatus(ctx, due.ScheduleUuid, []string{domainSchedule.StatusActive}, domainSchedule.StatusPaused, now); !errors.Is(err, domainSchedule.ErrScheduleNotActive) {
+		t.Errorf("pausing a completed schedule = %v, want ErrScheduleNotActive", err)
+	}
+	if err := store.UpdateScheduledTransferStatus(ctx, uuid.New(), []string{domainSchedule.StatusActive}, domainSchedule.StatusPaused, now); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("pausing an unknown schedule = %v, want ErrRecordNotFound", err)
+	}
+	if err := store.UpdateScheduledTransferStatus(ctx, later.ScheduleUuid, []string{domainSchedule.StatusActive}, domainSchedule.StatusPaused, now); err != nil {
+		t.Fatalf("UpdateScheduledTransferStatus: %v", err)
+	}
+
+	resumed := later
+	resumed.Status, resumed.Occurrence = domainSchedule.StatusActive, 1
+	if err := store.ResumeScheduledTransfer(ctx, later, resumed); err != nil {
+		t.Fatalf("ResumeScheduledTransfer: %v", err)
+	}
+	if err := store.ResumeScheduledTransfer(ctx, later, resumed); !errors.Is(err, domainSchedule.ErrScheduleNotActive) {
+		t.Errorf("resuming twice = %v, want ErrScheduleNotActive", err)
+	}
+	if got, err := store.GetScheduledTransfer(ctx, later.ScheduleUuid); err != nil || got.Status != domainSchedule.StatusActive || got.Occurrence != 1 {
+		t.Errorf("schedule after ResumeScheduledTransfer = %+v, %v; want it ACTIVE at occurrence 1", got, err)
+	}
+	if _, err := store.GetScheduledTransfer(ctx, uuid.New()); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("GetScheduledTransfer of an unknown schedule = %v, want ErrRecordNotFound", err)
+	}
+}
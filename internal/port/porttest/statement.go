@@ -0,0 +1,79 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainStatement "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/statement"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+)
+
+func testStatements(t *testing.T, h Harness) {
+	store, ok := h.DB.(port.StatementStorePort)
+	if !ok {
+		t.Skip("adapter has no statement store")
+	}
+	ctx := context.Background()
+	start := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+
+	acc := NewAccount(100)
+	h.Seed(t, acc)
+
+	// before, twice within and after the period, two at the same time
+	var posted []domainBank.BankTransactionOrm
+	for _, p := range []struct {
+		trxType string
+		amount  float64
+		at      time.Duration
+	}{
+		{domainBank.TransactionTypeIn, 5, 0},
+		{domainBank.TransactionTypeOut, 20, 10 * time.Minute},
+		{domainBank.TransactionTypeIn, 7.5, 10 * time.Minute},
+		{domainBank.TransactionTypeOut, 1, 30 * time.Minute},
+	} {
+		trx := NewTransaction(acc, p.trxType, p.amount)
+		trx.TransactionTimestamp = start.Add(p.at)
+		if _, err := h.DB.CreateTransaction(ctx, acc, trx); err != nil {
+			t.Fatalf("CreateTransaction: %v", err)
+		}
+		posted = append(posted, trx)
+	}
+	from, to := start.Add(time.Minute), start.Add(20*time.Minute)
+
+	opening, closing, err := store.StatementBalances(ctx, acc, from, to)
+	if err != nil || opening != 105 || closing != 92.5 {
+		t.Errorf("StatementBalances = %v, %v, %v; want 105, 92.5", opening, closing, err)
+	}
+	if _, _, err := store.StatementBalances(ctx, NewAccount(0), from, to); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("StatementBalances of an unknown account: err = %v, want ErrRecordNotFound", err)
+	}
+
+	// one at a time, the two at the same time by uuid
+	var listed []domainBank.BankTransactionOrm
+	var after *domainStatement.Cursor
+	for page := 0; page < 4; page++ {
+		trxs, err := store.ListTransactions(ctx, acc, from, to, after, 1)
+		if err != nil {
+			t.Fatalf("ListTransactions: %v", err)
+		}
+		if len(trxs) == 0 {
+			break
+		}
+		listed = append(listed, trxs...)
+		cursor := domainStatement.CursorOf(trxs[0])
+		after = &cursor
+	}
+	within := []domainBank.BankTransactionOrm{posted[1], posted[2]}
+	if within[1].TransactionUuid.String() < within[0].TransactionUuid.String() {
+		within[0], within[1] = within[1], within[0]
+	}
+	if len(listed) != 2 || listed[0].TransactionUuid != within[0].TransactionUuid || listed[1].TransactionUuid != within[1].TransactionUuid {
+		t.Fatalf("ListTransactions = %+v, want the two of the period by uuid", listed)
+	}
+	if !listed[0].TransactionTimestamp.Equal(start.Add(10 * time.Minute)) {
+		t.Errorf("ListTransactions timestamp = %v, want %v", listed[0].TransactionTimestamp, start.Add(10*time.Minute))
+	}
+}
@@ -0,0 +1,170 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainInterest "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/interest"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+)
+
+func testTax(t *testing.T, h Harness) {
+	store, ok := h.DB.(port.TaxStorePort)
+	if !ok {
+		t.Skip("adapter has no tax store")
+	}
+	interest := h.DB.(port.InterestStorePort)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	today := domainInterest.Day(now)
+	currency := fmt.Sprintf("X%04d", rand.Intn(1e4))
+
+	rich, poor, payable := NewAccount(2000), NewAccount(500), NewAccount(0)
+	rich.Currency, poor.Currency, payable.Currency = currency, currency, currency
+	h.Seed(t, rich, poor, payable)
+
+	rule := func(from time.Time, rate float64) domainTax.TaxRuleOrm {
+		return domainTax.TaxRuleOrm{Currency: currency, EffectiveFrom: from, Rate: rate, MinBalance: 1000,
+			PayableAccountUuid: payable.AccountUuid, CreatedAt: now}
+	}
+	if err := store.SaveTaxRule(ctx, rule(today.AddDate(0, 0, -10), 20)); err != nil {
+		t.Fatalf("SaveTaxRule: %v", err)
+	}
+	if err := store.SaveTaxRule(ctx, rule(today.AddDate(0, 0, 10), 25)); err != nil {
+		t.Fatalf("SaveTaxRule of a later rule: %v", err)
+	}
+	// replaces the rule effective from the same day
+	current := rule(today.AddDate(0, 0, -10), 10)
+	if err := store.SaveTaxRule(ctx, current); err != nil {
+		t.Fatalf("SaveTaxRule again: %v", err)
+	}
+	unknown := rule(today, 10)
+	unknown.PayableAccountUuid = uuid.New()
+	if err := store.SaveTaxRule(ctx, unknown); err == nil {
+		t.Error("SaveTaxRule with an unknown payable account succeeded")
+	}
+
+	rules, err := store.ListTaxRules(ctx)
+	if err != nil {
+		t.Fatalf("ListTaxRules: %v", err)
+	}
+	var ours []domainTax.TaxRuleOrm
+	for _, r := range rules {
+		if r.Currency == currency {
+			ours = append(ours, r)
+		}
+	}
+	if len(ours) != 2 || ours[0].Rate != 10 || ours[0].MinBalance != 1000 || !ours[0].EffectiveFrom.Equal(today.AddDate(0, 0, -10)) ||
+		ours[0].PayableAccountUuid != payable.AccountUuid || ours[1].Rate != 25 {
+		t.Errorf("ListTaxRules = %+v, want the replaced rule and the later one", ours)
+	}
+
+	if err := store.SetTaxExempt(ctx, poor.AccountUuid, true, now); err != nil {
+		t.Fatalf("SetTaxExempt: %v", err)
+	}
+	if got, err := h.DB.GetDetailBankAccountByUuid(ctx, poor.AccountUuid); err != nil || !got.TaxExempt {
+		t.Errorf("account after SetTaxExempt = %+v, %v; want it exempt", got, err)
+	}
+	if err := store.SetTaxExempt(ctx, poor.AccountUuid, false, now); err != nil {
+		t.Fatalf("SetTaxExempt of false: %v", err)
+	}
+	if err := store.SetTaxExempt(ctx, uuid.New(), true, now); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("SetTaxExempt of an unknown account = %v, want ErrRecordNotFound", err)
+	}
+
+	capitalize := func(acc domainBank.BankAccountOrm) (domainInterest.Posting, domainTax.Withholding) {
+		t.Helper()
+		err := interest.SaveInterestAccruals(ctx, []domainInterest.InterestAccrualOrm{{AccountUuid: acc.AccountUuid,
+			AccrualDate: today.AddDate(0, 0, -1), ProductCode: "SAVINGS", Balance: acc.CurrentBalance, AnnualRate: 5, Amount: 10, CreatedAt: now}})
+		if err != nil {
+			t.Fatalf("SaveInterestAccruals: %v", err)
+		}
+		withholding := domainTax.Withholding{Rule: &current, PayableAccount: payable, WithholdingUuid: uuid.New(),
+			TaxTransaction:     NewTransaction(acc, domainBank.TransactionTypeOut, 0),
+			PayableTransaction: NewTransaction(payable, domainBank.TransactionTypeIn, 0)}
+		posted, err := interest.CapitalizeInterest(ctx, domainInterest.Capitalization{Account: acc, Before: today,
+			Transaction: NewTransaction(acc, domainBank.TransactionTypeIn, 0), Withholding: withholding})
+		if err != nil {
+			t.Fatalf("CapitalizeInterest: %v", err)
+		}
+		return posted, withholding
+	}
+
+	if posted, _ := capitalize(rich); posted.Interest != 10 || posted.Tax != 1 {
+		t.Errorf("CapitalizeInterest above the threshold = %+v, want 10 posted and 1 withheld", posted)
+	}
+	assertBalance(t, h, rich, 2009)
+	assertBalance(t, h, payable, 1)
+	// the threshold is compared with the balance before the interest
+	if posted, _ := capitalize(poor); posted.Interest != 10 || posted.Tax != 0 {
+		t.Errorf("CapitalizeInterest below the threshold = %+v, want 10 posted and nothing withheld", posted)
+	}
+	assertBalance(t, h, poor, 510)
+	assertBalance(t, h, payable, 1)
+
+	withholdings, err := store.ListWithholdings(ctx, rich.AccountUuid, today.AddDate(0, 0, -1), today.AddDate(0, 0, 1))
+	if err != nil || len(withholdings) != 1 {
+		t.Fatalf("ListWithholdings = %+v, %v; want 1 withholding", withholdings, err)
+	}
+	if w := withholdings[0]; w.Status != domainTax.StatusWithheld || w.Balance != 2000 || w.InterestAmount != 10 || w.Rate != 10 ||
+		w.TaxAmount != 1 || w.TaxTransactionUuid == nil || w.PayableTransactionUuid == nil {
+		t.Errorf("withholding above the threshold = %+v", w)
+	}
+
+	all, err := store.ListWithholdings(ctx, uuid.Nil, today.AddDate(0, 0, -1), today.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("ListWithholdings of every account: %v", err)
+	}
+	var below *domainTax.WithholdingOrm
+	for i, w := range all {
+		if w.AccountUuid == poor.AccountUuid {
+			below = &all[i]
+		}
+	}
+	if below == nil || below.Status != domainTax.StatusBelowThreshold || below.TaxAmount != 0 || below.TaxTransactionUuid != nil {
+		t.Errorf("withholding below the threshold = %+v", below)
+	}
+	if later, err := store.ListWithholdings(ctx, rich.AccountUuid, today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)); err != nil || len(later) != 0 {
+		t.Errorf("ListWithholdings of a later day = %+v, %v; want none", later, err)
+	}
+
+	// the threshold and the exemption are the customer's, of its accounts in
+	// the currency
+	customer := fmt.Sprintf("C%d", rand.Int63n(1e15))
+	saver, sibling, elsewhere := NewAccount(600), NewAccount(500), NewAccount(5000)
+	saver.Currency, sibling.Currency = currency, currency
+	h.Seed(t, saver, sibling, elsewhere)
+	for _, acc := range []domainBank.BankAccountOrm{saver, sibling, elsewhere} {
+		if err := h.DB.SetAccountCustomer(ctx, acc.AccountUuid, customer, now); err != nil {
+			t.Fatalf("SetAccountCustomer: %v", err)
+		}
+	}
+	if posted, _ := capitalize(saver); posted.Tax != 1 {
+		t.Errorf("CapitalizeInterest of a customer above the threshold = %+v, want 1 withheld", posted)
+	}
+	assertBalance(t, h, saver, 609)
+
+	if err := store.SetTaxExempt(ctx, sibling.AccountUuid, true, now); err != nil {
+		t.Fatalf("SetTaxExempt of the customer: %v", err)
+	}
+	if got, err := h.DB.GetDetailBankAccountByUuid(ctx, saver.AccountUuid); err != nil || !got.TaxExempt {
+		t.Errorf("other account of the customer after SetTaxExempt = %+v, %v; want it exempt", got, err)
+	}
+	if got, err := h.DB.GetDetailBankAccountByUuid(ctx, rich.AccountUuid); err != nil || got.TaxExempt {
+		t.Errorf("account of another customer after SetTaxExempt = %+v, %v; want it taxed", got, err)
+	}
+	if posted, _ := capitalize(sibling); posted.Interest != 10 || posted.Tax != 0 {
+		t.Errorf("CapitalizeInterest of an exempt customer = %+v, want nothing withheld", posted)
+	}
+	withholdings, err = store.ListWithholdings(ctx, sibling.AccountUuid, today.AddDate(0, 0, -1), today.AddDate(0, 0, 1))
+	if err != nil || len(withholdings) != 1 || withholdings[0].Status != domainTax.StatusExempt || withholdings[0].Balance != 1109 {
+		t.Errorf("withholding of an exempt customer = %+v, %v; want it exempt with the balance of both accounts", withholdings, err)
+	}
+}
@@ -0,0 +1,102 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	"github.com/google/uuid"
+)
+
+func testListTransfers(t *testing.T, h Harness) {
+	ctx := context.Background()
+	a, b, c := NewAccount(0), NewAccount(0), NewAccount(0)
+	h.Seed(t, a, b, c)
+
+	// far from the transfers of the other tests, which share the store
+	base := time.Date(2091, 3, 4, 10, 0, 0, 0, time.UTC)
+	create := func(from, to domainBank.BankAccountOrm, amount float64, at time.Duration, success bool) domainBank.BankTransferOrm {
+		t.Helper()
+
+		trf := NewTransfer(from, to, amount)
+		trf.TransferTimestamp = base.Add(at)
+		trf.TransferSuccess = success
+		if _, err := h.DB.CreateTransfer(ctx, trf); err != nil {
+			t.Fatalf("CreateTransfer: %v", err)
+		}
+
+		return trf
+	}
+	t1 := create(a, b, 1, 0, true)
+	t2 := create(b, a, 2, time.Minute, false)
+	t3 := create(a, c, 3, 2*time.Minute, true)
+	t4 := create(c, a, 4, 2*time.Minute, true)
+	t5 := create(b, c, 5, 3*time.Minute, true)
+
+	// transfers at the same time list by descending uuid
+	tie1, tie2 := newerFirst(t3, t4)
+
+	got, err := h.DB.GetTransferDetail(ctx, t1.TransferUuid)
+	if err != nil {
+		t.Fatalf("GetTransferDetail: %v", err)
+	}
+	if got.FromAccountNumber != a.AccountNumber || got.ToAccountNumber != b.AccountNumber || got.Amount != 1 || !got.TransferSuccess {
+		t.Errorf("GetTransferDetail = %+v", got)
+	}
+	if _, err := h.DB.GetTransferDetail(ctx, uuid.New()); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("GetTransferDetail of an unknown transfer = %v, want ErrRecordNotFound", err)
+	}
+
+	failed := false
+	tests := []struct {
+		name   string
+		filter domainBank.TransferFilter
+		want   []domainBank.BankTransferOrm
+	}{
+		{"account", domainBank.TransferFilter{AccountUuid: a.AccountUuid}, []domainBank.BankTransferOrm{tie1, tie2, t2, t1}},
+		{"outgoing", domainBank.TransferFilter{AccountUuid: a.AccountUuid, Direction: domainBank.TransferDirectionOutgoing}, []domainBank.BankTransferOrm{t3, t1}},
+		{"incoming", domainBank.TransferFilter{AccountUuid: a.AccountUuid, Direction: domainBank.TransferDirectionIncoming}, []domainBank.BankTransferOrm{t4, t2}},
+		{"failed", domainBank.TransferFilter{AccountUuid: a.AccountUuid, Success: &failed}, []domainBank.BankTransferOrm{t2}},
+		{"range", domainBank.TransferFilter{AccountUuid: a.AccountUuid, From: base.Add(time.Minute), To: base.Add(2 * time.Minute)}, []domainBank.BankTransferOrm{t2}},
+		{"every account", domainBank.TransferFilter{From: base, To: base.Add(time.Hour)}, []domainBank.BankTransferOrm{t5, tie1, tie2, t2, t1}},
+		{"limit", domainBank.TransferFilter{AccountUuid: a.AccountUuid, Limit: 2}, []domainBank.BankTransferOrm{tie1, tie2}},
+		{"after", domainBank.TransferFilter{AccountUuid: a.AccountUuid, After: &domainBank.TransferCursor{Timestamp: tie1.TransferTimestamp, TransferUuid: tie1.TransferUuid}}, []domainBank.BankTransferOrm{tie2, t2, t1}},
+	}
+	for _, tt := range tests {
+		got, err := h.DB.ListTransfers(ctx, tt.filter)
+		if err != nil {
+			t.Errorf("ListTransfers %v: %v", tt.name, err)
+			continue
+		}
+		if ids, want := transferUuids(got), orderedUuids(tt.want); !slices.Equal(ids, want) {
+			t.Errorf("ListTransfers %v = %v, want %v", tt.name, ids, want)
+		}
+	}
+}
+
+// newerFirst orders two transfers at the same time like a listing does.
+func newerFirst(x, y domainBank.BankTransferOrm) (domainBank.BankTransferOrm, domainBank.BankTransferOrm) {
+	if x.TransferUuid.String() < y.TransferUuid.String() {
+		return y, x
+	}
+	return x, y
+}
+
+func transferUuids(transfers []domainBank.TransferDetail) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, t := range transfers {
+		ids = append(ids, t.TransferUuid)
+	}
+	return ids
+}
+
+func orderedUuids(transfers []domainBank.BankTransferOrm) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, t := range transfers {
+		ids = append(ids, t.TransferUuid)
+	}
+	return ids
+}
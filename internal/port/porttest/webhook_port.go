@@ -0,0 +1,289 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainEvent "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/event"
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+	"github.com/fajaramaulana/go-grpc-micro-bank-server/internal/port"
+	"github.com/google/uuid"
+)
+
+func testWebhooks(t *testing.T, h Harness) {
+	store, ok := h.DB.(port.WebhookStorePort)
+	if !ok {
+		t.Skip("adapter has no webhook store")
+	}
+	ctx := context.Background()
+
+	acc := NewAccount(0)
+	h.Seed(t, acc)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	sub := domainWebhook.WebhookSubscriptionOrm{
+		SubscriptionUuid: uuid.New(),
+		AccountUuid:      &acc.AccountUuid,
+		AccountNumber:    acc.AccountNumber,
+		Url:              "https://example.com/hook",
+		EventTypes:       []string{domainEvent.TypeTransactionCreated, domainEvent.TypeTransferCompleted},
+		Secret:           "0123456789abcdef",
+		CreatedAt:        now,
+	}
+	if err := store.CreateWebhookSubscription(ctx, sub); err != nil {
+		t.Fatalf("CreateWebhookSubscription: %v", err)
+	}
+	if err := store.CreateWebhookSubscription(ctx, sub); err == nil {
+		t.Error("CreateWebhookSubscription with a duplicate uuid succeeded")
+	}
+	orphan := sub
+	unknown := uuid.New()
+	orphan.SubscriptionUuid, orphan.AccountUuid = uuid.New(), &unknown
+	if err := store.CreateWebhookSubscription(ctx, orphan); err == nil {
+		t.Error("CreateWebhookSubscription of an unknown account succeeded")
+	}
+	both := sub
+	both.SubscriptionUuid, both.CustomerId = uuid.New(), uuid.NewString()
+	if err := store.CreateWebhookSubscription(ctx, both); err == nil {
+		t.Error("CreateWebhookSubscription for an account and a customer succeeded")
+	}
+
+	subs, err := store.ListWebhookSubscriptions(ctx, acc.AccountUuid, "")
+	if err != nil || len(subs) != 1 {
+		t.Fatalf("ListWebhookSubscriptions = %+v, %v; want the subscription", subs, err)
+	}
+	if got := subs[0]; got.SubscriptionUuid != sub.SubscriptionUuid || got.Url != sub.Url || got.Secret != sub.Secret ||
+		len(got.EventTypes) != 2 || got.EventTypes[1] != domainEvent.TypeTransferCompleted {
+		t.Errorf("listed subscription = %+v, want %+v", got, sub)
+	}
+	subs, err = store.WebhookSubscriptionsOfAccounts(ctx, []uuid.UUID{uuid.New(), acc.AccountUuid})
+	if err != nil || len(subs) != 1 || subs[0].SubscriptionUuid != sub.SubscriptionUuid {
+		t.Errorf("WebhookSubscriptionsOfAccounts = %+v, %v; want the subscription", subs, err)
+	}
+
+	due := newDelivery(sub, now.Add(-time.Minute))
+	later := newDelivery(sub, now.Add(time.Hour))
+	again := newDelivery(sub, now.Add(-time.Minute))
+	again.EventUuid = due.EventUuid
+	if err := store.EnqueueWebhookDeliveries(ctx, []domainWebhook.WebhookDeliveryOrm{due, later}); err != nil {
+		t.Fatalf("EnqueueWebhookDeliveries: %v", err)
+	}
+	// the event relayed a second time
+	if err := store.EnqueueWebhookDeliveries(ctx, []domainWebhook.WebhookDeliveryOrm{again}); err != nil {
+		t.Fatalf("EnqueueWebhookDeliveries of a delivered event: %v", err)
+	}
+
+	claimed := claimOwn(t, store, sub, now, now.Add(time.Minute))
+	if len(claimed) != 1 || claimed[0].DeliveryUuid != due.DeliveryUuid {
+		t.Fatalf("claimed %+v, want only the due delivery", claimed)
+	}
+	if claimed[0].Subscription.Url != sub.Url || claimed[0].Subscription.Secret != sub.Secret || claimed[0].Payload != due.Payload {
+		t.Errorf("claimed delivery = %+v, want it with payload and subscription", claimed[0])
+	}
+	if again := claimOwn(t, store, sub, now, now.Add(time.Minute)); len(again) != 0 {
+		t.Errorf("claimed %+v a second time within the claim", again)
+	}
+
+	failed := claimed[0]
+	failed.AttemptCount = 1
+	failed.NextAttemptAt = now.Add(30 * time.Second)
+	failed.LastError = "unexpected response status 500"
+	failed.UpdatedAt = now
+	attempt := domainWebhook.WebhookAttemptOrm{AttemptedAt: now, StatusCode: 500, Error: failed.LastError, DurationMs: 12}
+	if err := store.RecordWebhookAttempt(ctx, failed, attempt); err != nil {
+		t.Fatalf("RecordWebhookAttempt: %v", err)
+	}
+	failed.State = domainWebhook.StateDead
+	failed.AttemptCount = 2
+	if err := store.RecordWebhookAttempt(ctx, failed, attempt); err != nil {
+		t.Fatalf("RecordWebhookAttempt: %v", err)
+	}
+
+	deliveries, err := store.ListWebhookDeliveries(ctx, domainWebhook.DeliveryFilter{SubscriptionUuid: sub.SubscriptionUuid})
+	if err != nil || len(deliveries) != 2 {
+		t.Fatalf("ListWebhookDeliveries = %+v, %v; want 2 deliveries", deliveries, err)
+	}
+	if deliveries[0].DeliveryUuid != later.DeliveryUuid {
+		t.Errorf("first listed delivery = %v, want the newest %v", deliveries[0].DeliveryUuid, later.DeliveryUuid)
+	}
+	dead := deliveries[1]
+	if dead.State != domainWebhook.StateDead || dead.AttemptCount != 2 || dead.LastError != failed.LastError || len(dead.Attempts) != 2 {
+		t.Errorf("dead delivery = %+v, want DEAD after 2 attempts", dead)
+	} else if a := dead.Attempts[0]; a.StatusCode != 500 || a.Error != failed.LastError || a.DurationMs != 12 || !a.AttemptedAt.Equal(now) {
+		t.Errorf("attempt = %+v, want %+v", a, attempt)
+	}
+
+	onlyDead, err := store.ListWebhookDeliveries(ctx, domainWebhook.DeliveryFilter{SubscriptionUuid: sub.SubscriptionUuid, State: domainWebhook.StateDead, Limit: 5})
+	if err != nil || len(onlyDead) != 1 || onlyDead[0].DeliveryUuid != due.DeliveryUuid {
+		t.Errorf("dead deliveries = %+v, %v; want the dead one", onlyDead, err)
+	}
+
+	scheduled, err := store.ScheduleWebhookDelivery(ctx, due.DeliveryUuid, now)
+	if err != nil {
+		t.Fatalf("ScheduleWebhookDelivery: %v", err)
+	}
+	if scheduled.State != domainWebhook.StatePending || scheduled.AttemptCount != 0 || len(scheduled.Attempts) != 2 {
+		t.Errorf("scheduled delivery = %+v, want PENDING with its 2 attempts kept", scheduled)
+	}
+	if claimed := claimOwn(t, store, sub, now, now.Add(time.Minute)); len(claimed) != 1 || claimed[0].DeliveryUuid != due.DeliveryUuid {
+		t.Errorf("claimed %+v after scheduling, want the scheduled delivery", claimed)
+	}
+	if _, err := store.ScheduleWebhookDelivery(ctx, uuid.New(), now); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("ScheduleWebhookDelivery of an unknown delivery: error = %v, want ErrRecordNotFound", err)
+	}
+
+	if err := store.DeleteWebhookSubscription(ctx, sub.SubscriptionUuid); err != nil {
+		t.Fatalf("DeleteWebhookSubscription: %v", err)
+	}
+	if deliveries, err := store.ListWebhookDeliveries(ctx, domainWebhook.DeliveryFilter{SubscriptionUuid: sub.SubscriptionUuid}); err != nil || len(deliveries) != 0 {
+		t.Errorf("deliveries of the deleted subscription = %+v, %v; want none", deliveries, err)
+	}
+	if err := store.DeleteWebhookSubscription(ctx, sub.SubscriptionUuid); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("DeleteWebhookSubscription twice: error = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func testWebhookCustomers(t *testing.T, h Harness) {
+	store, ok := h.DB.(port.WebhookStorePort)
+	if !ok {
+		t.Skip("adapter has no webhook store")
+	}
+	ctx := context.Background()
+
+	first, second, other := NewAccount(0), NewAccount(0), NewAccount(0)
+	h.Seed(t, first, second, other)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	customerId := uuid.NewString()
+	for _, acc := range []domainBank.BankAccountOrm{second, first} {
+		if err := h.DB.SetAccountCustomer(ctx, acc.AccountUuid, customerId, now); err != nil {
+			t.Fatalf("SetAccountCustomer: %v", err)
+		}
+	}
+	if err := h.DB.SetAccountCustomer(ctx, uuid.New(), customerId, now); !errors.Is(err, domainBank.ErrRecordNotFound) {
+		t.Errorf("SetAccountCustomer of an unknown account: error = %v, want ErrRecordNotFound", err)
+	}
+	accounts, err := h.DB.ListAccountsOfCustomer(ctx, customerId)
+	if err != nil || len(accounts) != 2 || accounts[0].AccountNumber > accounts[1].AccountNumber || accounts[0].CustomerId != customerId {
+		t.Fatalf("ListAccountsOfCustomer = %+v, %v; want both accounts by number", accounts, err)
+	}
+	if accounts, err := h.DB.ListAccountsOfCustomer(ctx, ""); err != nil || len(accounts) != 0 {
+		t.Errorf("ListAccountsOfCustomer of no customer = %+v, %v; want none", accounts, err)
+	}
+
+	sub := domainWebhook.WebhookSubscriptionOrm{
+		SubscriptionUuid: uuid.New(),
+		CustomerId:       customerId,
+		Url:              "https://example.com/hook",
+		EventTypes:       []string{domainEvent.TypeTransactionCreated},
+		Secret:           "0123456789abcdef",
+		CreatedAt:        now,
+	}
+	if err := store.CreateWebhookSubscription(ctx, sub); err != nil {
+		t.Fatalf("CreateWebhookSubscription: %v", err)
+	}
+	own := domainWebhook.WebhookSubscriptionOrm{
+		SubscriptionUuid: uuid.New(),
+		AccountUuid:      &first.AccountUuid,
+		AccountNumber:    first.AccountNumber,
+		Url:              "https://example.com/own",
+		EventTypes:       []string{domainEvent.TypeTransactionCreated},
+		Secret:           "0123456789abcdef",
+		CreatedAt:        now.Add(time.Second),
+	}
+	if err := store.CreateWebhookSubscription(ctx, own); err != nil {
+		t.Fatalf("CreateWebhookSubscription: %v", err)
+	}
+	unscoped := sub
+	unscoped.SubscriptionUuid, unscoped.CustomerId = uuid.New(), ""
+	if err := store.CreateWebhookSubscription(ctx, unscoped); err == nil {
+		t.Error("CreateWebhookSubscription without an account or a customer succeeded")
+	}
+
+	subs, err := store.ListWebhookSubscriptions(ctx, uuid.Nil, customerId)
+	if err != nil || len(subs) != 1 || subs[0].SubscriptionUuid != sub.SubscriptionUuid || subs[0].AccountUuid != nil {
+		t.Errorf("ListWebhookSubscriptions of the customer = %+v, %v; want the customer subscription", subs, err)
+	}
+
+	tests := []struct {
+		name     string
+		accounts []uuid.UUID
+		want     []uuid.UUID
+	}{
+		{"account of the customer", []uuid.UUID{second.AccountUuid}, []uuid.UUID{sub.SubscriptionUuid}},
+		{"account with its own", []uuid.UUID{first.AccountUuid}, []uuid.UUID{sub.SubscriptionUuid, own.SubscriptionUuid}},
+		{"both accounts", []uuid.UUID{first.AccountUuid, second.AccountUuid}, []uuid.UUID{sub.SubscriptionUuid, own.SubscriptionUuid}},
+		{"other customer", []uuid.UUID{other.AccountUuid}, nil},
+	}
+	for _, tt := range tests {
+		subs, err := store.WebhookSubscriptionsOfAccounts(ctx, tt.accounts)
+		if err != nil || len(subs) != len(tt.want) {
+			t.Errorf("%v: WebhookSubscriptionsOfAccounts = %+v, %v; want %v", tt.name, subs, err, tt.want)
+			continue
+		}
+		for i, want := range tt.want {
+			if subs[i].SubscriptionUuid != want {
+				t.Errorf("%v: subscription %d = %v, want %v", tt.name, i, subs[i].SubscriptionUuid, want)
+			}
+		}
+	}
+
+	delivery := newDelivery(sub, now.Add(-time.Minute))
+	if err := store.EnqueueWebhookDeliveries(ctx, []domainWebhook.WebhookDeliveryOrm{delivery}); err != nil {
+		t.Fatalf("EnqueueWebhookDeliveries: %v", err)
+	}
+	claimed := claimOwn(t, store, sub, now, now.Add(time.Minute))
+	if len(claimed) != 1 || claimed[0].Subscription.CustomerId != customerId || claimed[0].Subscription.Url != sub.Url {
+		t.Errorf("claimed %+v, want the delivery with its customer subscription", claimed)
+	}
+
+	// leaving the customer leaves its subscriptions
+	if err := h.DB.SetAccountCustomer(ctx, second.AccountUuid, "", now); err != nil {
+		t.Fatalf("SetAccountCustomer: %v", err)
+	}
+	if subs, err := store.WebhookSubscriptionsOfAccounts(ctx, []uuid.UUID{second.AccountUuid}); err != nil || len(subs) != 0 {
+		t.Errorf("subscriptions after leaving the customer = %+v, %v; want none", subs, err)
+	}
+}
+
+func newDelivery(sub domainWebhook.WebhookSubscriptionOrm, nextAttemptAt time.Time) domainWebhook.WebhookDeliveryOrm {
+	created := nextAttemptAt
+	if created.After(time.Now()) {
+		created = time.Now().UTC().Add(time.Second).Truncate(time.Second)
+	}
+
+	return domainWebhook.WebhookDeliveryOrm{
+		DeliveryUuid:     uuid.New(),
+		SubscriptionUuid: sub.SubscriptionUuid,
+		EventUuid:        uuid.New(),
+		EventType:        domainEvent.TypeTransactionCreated,
+		Payload:          `{"type":"TransactionCreated"}`,
+		State:            domainWebhook.StatePending,
+		NextAttemptAt:    nextAttemptAt,
+		CreatedAt:        created,
+		UpdatedAt:        created,
+	}
+}
+
+// claimOwn claims the due deliveries and returns those of sub, the store may
+// be shared with other runs.
+func claimOwn(t *testing.T, store port.WebhookStorePort, sub domainWebhook.WebhookSubscriptionOrm, now, until time.Time) []domainWebhook.WebhookDeliveryOrm {
+	t.Helper()
+
+	claimed, err := store.ClaimWebhookDeliveries(context.Background(), now, until, 100)
+	if err != nil {
+		t.Fatalf("ClaimWebhookDeliveries: %v", err)
+	}
+
+	var own []domainWebhook.WebhookDeliveryOrm
+	for _, d := range claimed {
+		if d.SubscriptionUuid == sub.SubscriptionUuid {
+			own = append(own, d)
+		}
+	}
+
+	return own
+}
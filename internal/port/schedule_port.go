@@ -0,0 +1,47 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	domainSchedule "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/schedule"
+	"github.com/google/uuid"
+)
+
+// ScheduleStorePort keeps the scheduled transfers and their executions.
+type ScheduleStorePort interface {
+	CreateScheduledTransfer(ctx context.Context, s domainSchedule.ScheduledTransferOrm) error
+	GetScheduledTransfer(ctx context.Context, id uuid.UUID) (domainSchedule.ScheduledTransferOrm, error)
+	// ListScheduledTransfers returns the schedules sending from accountUuid,
+	// of every account for uuid.Nil, oldest first.
+	ListScheduledTransfers(ctx context.Context, accountUuid uuid.UUID) ([]domainSchedule.ScheduledTransferOrm, error)
+	// UpdateScheduledTransferStatus moves schedule id to status if it is in
+	// one of the statuses from, and returns ErrScheduleNotActive otherwise.
+	UpdateScheduledTransferStatus(ctx context.Context, id uuid.UUID, from []string, status string, at time.Time) error
+	// ResumeScheduledTransfer makes paused active again with the next run,
+	// occurrence and attempt of resumed. It returns ErrScheduleNotActive
+	// unless the schedule is still paused at the occurrence and attempt of
+	// paused.
+	ResumeScheduledTransfer(ctx context.Context, paused domainSchedule.ScheduledTransferOrm, resumed domainSchedule.ScheduledTransferOrm) error
+	// ClaimScheduledTransfers returns up to limit active schedules due at
+	// now and moves their next run to until. A schedule is claimed by one
+	// caller only, and is due again at until if its run is never recorded.
+	ClaimScheduledTransfers(ctx context.Context, now time.Time, until time.Time, limit int) ([]domainSchedule.ScheduledTransferOrm, error)
+	// RecordScheduledTransferRun stores execution and moves the schedule to
+	// the next run, occurrence and attempt of s, unless it moved since the
+	// occurrence and attempt of execution. The status of s replaces ACTIVE
+	// only. An attempt is recorded once, a second time fails.
+	RecordScheduledTransferRun(ctx context.Context, s domainSchedule.ScheduledTransferOrm, execution domainSchedule.ExecutionOrm) error
+	// ListScheduledTransferExecutions returns the executions of scheduleUuid
+	// by occurrence and attempt.
+	ListScheduledTransferExecutions(ctx context.Context, scheduleUuid uuid.UUID) ([]domainSchedule.ExecutionOrm, error)
+}
+
+type ScheduleServicePort interface {
+	CreateScheduledTransfer(ctx context.Context, s domainSchedule.ScheduledTransferOrm, fromAccountNum string, toAccountNum string) (domainSchedule.ScheduleDetail, error)
+	ListScheduledTransfers(ctx context.Context, accountNum string) ([]domainSchedule.ScheduleDetail, error)
+	PauseScheduledTransfer(ctx context.Context, id uuid.UUID) (domainSchedule.ScheduleDetail, error)
+	ResumeScheduledTransfer(ctx context.Context, id uuid.UUID) (domainSchedule.ScheduleDetail, error)
+	CancelScheduledTransfer(ctx context.Context, id uuid.UUID) (domainSchedule.ScheduleDetail, error)
+	ListScheduledTransferExecutions(ctx context.Context, id uuid.UUID) ([]domainSchedule.ExecutionOrm, error)
+}
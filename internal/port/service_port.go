@@ -1,17 +1,53 @@
 package port
 
 import (
+	"context"
 	"time"
 
 	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainFee "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/fee"
 	"github.com/google/uuid"
 )
 
 type BankServicePort interface {
-	GetCurrentBalance(account string) (float64, error)
-	CreateExchangeRate(r domainBank.ExchangeRate) (uuid.UUID, error)
-	FindExchangeRate(fromCurrency string, toCurrency string, ts time.Time) (float64, error)
-	CreateTransaction(accountNum string, trx domainBank.Transaction) (uuid.UUID, error)
+	// GetCurrentBalance returns the ledger and the available balance of
+	// account.
+	GetCurrentBalance(ctx context.Context, account string) (float64, float64, error)
+	CreateExchangeRate(ctx context.Context, r domainBank.ExchangeRate) (uuid.UUID, error)
+	FindExchangeRate(ctx context.Context, fromCurrency string, toCurrency string, ts time.Time) (float64, error)
+	CreateTransaction(ctx context.Context, accountNum string, trx domainBank.Transaction) (uuid.UUID, error)
 	CalculateTransactionSummary(trxSum *domainBank.TransactionSummary, trx domainBank.Transaction) error
-	Transfer(trf domainBank.TransferTransaction) (uuid.UUID, bool, error)
+	// Transfer returns the uuid of the transfer and the breakdown of its fee.
+	Transfer(ctx context.Context, trf domainBank.TransferTransaction) (uuid.UUID, bool, domainFee.Breakdown, error)
+	// QuoteTransfer returns the amount trf would book and its fee.
+	QuoteTransfer(ctx context.Context, trf domainBank.TransferTransaction) (float64, domainFee.Breakdown, error)
+	ReverseTransfer(ctx context.Context, r domainBank.TransferReversal) (domainBank.BankTransferOrm, float64, error)
+	GetTransfer(ctx context.Context, transferUuid uuid.UUID) (domainBank.TransferDetail, error)
+	ListTransfers(ctx context.Context, accountNum string, filter domainBank.TransferFilter) ([]domainBank.TransferDetail, *domainBank.TransferCursor, error)
+	CreateHold(ctx context.Context, accountNum string, h domainBank.Hold) (domainBank.BankHoldOrm, error)
+	GetHold(ctx context.Context, holdUuid uuid.UUID) (domainBank.BankHoldOrm, error)
+	CaptureHold(ctx context.Context, holdUuid uuid.UUID, amount float64, notes string) (domainBank.BankHoldOrm, uuid.UUID, error)
+	ReleaseHold(ctx context.Context, holdUuid uuid.UUID) (domainBank.BankHoldOrm, error)
+	SubscribeAccountActivity(ctx context.Context, accountNum string, lastEventID string) (ActivitySubscription, error)
+}
+
+// ActivitySubscription delivers the activities of one account. Activities is
+// closed when the subscriber falls too far behind; it resumes by subscribing
+// again from the last event it received.
+type ActivitySubscription interface {
+	// Replay returns the activities after the event the subscription resumed
+	// from, to be delivered before the live ones.
+	Replay() []domainBank.AccountActivity
+	Activities() <-chan domainBank.AccountActivity
+	Close()
+}
+
+// AccountAdminServicePort configures the products accounts belong to, the
+// limits their debits have to respect and the customers they belong to.
+type AccountAdminServicePort interface {
+	SaveProduct(ctx context.Context, product domainBank.BankProductOrm) (domainBank.BankProductOrm, error)
+	ListProducts(ctx context.Context) ([]domainBank.BankProductOrm, error)
+	GetAccountStanding(ctx context.Context, accountNum string) (domainBank.AccountStanding, error)
+	SetAccountLimits(ctx context.Context, accountNum string, update domainBank.AccountLimitsUpdate) (domainBank.AccountStanding, error)
+	SetAccountCustomer(ctx context.Context, accountNum string, customerId string) (domainBank.BankAccountOrm, error)
 }
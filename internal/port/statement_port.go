@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"io"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainStatement "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/statement"
+	"github.com/google/uuid"
+)
+
+// StatementStorePort reads what the statements of accounts are made of.
+type StatementStorePort interface {
+	// StatementBalances returns the balance of account at from and at to,
+	// read together so they agree.
+	StatementBalances(ctx context.Context, account domainBank.BankAccountOrm, from time.Time, to time.Time) (float64, float64, error)
+	// ListTransactions returns up to limit transactions of account from
+	// from up to, not including, to, oldest first, ties broken by ascending
+	// uuid. after resumes the listing past the transaction it points at.
+	ListTransactions(ctx context.Context, account domainBank.BankAccountOrm, from time.Time, to time.Time, after *domainStatement.Cursor,
+		limit int) ([]domainBank.BankTransactionOrm, error)
+}
+
+type StatementServicePort interface {
+	// GenerateStatement writes the statement of accountNum for the period
+	// from from up to, not including, to, in format, to w.
+	GenerateStatement(ctx context.Context, accountNum string, format string, from time.Time, to time.Time, w io.Writer) error
+	// WriteTransferReceipt writes the PDF receipt of transferUuid to w.
+	WriteTransferReceipt(ctx context.Context, transferUuid uuid.UUID, w io.Writer) error
+}
@@ -0,0 +1,34 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	domainBank "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/bank"
+	domainTax "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/tax"
+	"github.com/google/uuid"
+)
+
+// TaxStorePort keeps the tax rules, the exemptions of customers and the
+// withholdings CapitalizeInterest records. CapitalizeInterest compares the
+// threshold of a rule with the balance of the customer of the account.
+type TaxStorePort interface {
+	// SaveTaxRule replaces the rule of the currency of rule effective from
+	// the same day.
+	SaveTaxRule(ctx context.Context, rule domainTax.TaxRuleOrm) error
+	// ListTaxRules returns the rules by currency, oldest first.
+	ListTaxRules(ctx context.Context) ([]domainTax.TaxRuleOrm, error)
+	// SetTaxExempt sets the exemption of the customer of accountUuid, on
+	// every account of it.
+	SetTaxExempt(ctx context.Context, accountUuid uuid.UUID, exempt bool, at time.Time) error
+	// ListWithholdings returns the withholdings from from up to, not
+	// including, to, of accountUuid, of every account for uuid.Nil, oldest
+	// first.
+	ListWithholdings(ctx context.Context, accountUuid uuid.UUID, from time.Time, to time.Time) ([]domainTax.WithholdingOrm, error)
+}
+
+type TaxServicePort interface {
+	SetTaxRule(ctx context.Context, rule domainTax.TaxRuleOrm, payableAccountNum string) (domainTax.RuleDetail, error)
+	ListTaxRules(ctx context.Context) ([]domainTax.RuleDetail, error)
+	SetTaxExemption(ctx context.Context, accountNum string, exempt bool) (domainBank.BankAccountOrm, error)
+}
@@ -0,0 +1,54 @@
+package port
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	domainWebhook "github.com/fajaramaulana/go-grpc-micro-bank-server/internal/application/domain/webhook"
+	"github.com/google/uuid"
+)
+
+// WebhookStorePort keeps the webhook subscriptions and their deliveries.
+type WebhookStorePort interface {
+	CreateWebhookSubscription(ctx context.Context, sub domainWebhook.WebhookSubscriptionOrm) error
+	// ListWebhookSubscriptions returns the subscriptions of accountUuid or
+	// of customerId, oldest first. Both zero match every subscription.
+	ListWebhookSubscriptions(ctx context.Context, accountUuid uuid.UUID, customerId string) ([]domainWebhook.WebhookSubscriptionOrm, error)
+	// DeleteWebhookSubscription deletes the subscription with its deliveries.
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error
+	// WebhookSubscriptionsOfAccounts returns the subscriptions of the
+	// accounts and of the customers they belong to.
+	WebhookSubscriptionsOfAccounts(ctx context.Context, accountUuids []uuid.UUID) ([]domainWebhook.WebhookSubscriptionOrm, error)
+	// EnqueueWebhookDeliveries skips the deliveries of an event to a
+	// subscription that already has one, so an event relayed twice is
+	// delivered once.
+	EnqueueWebhookDeliveries(ctx context.Context, deliveries []domainWebhook.WebhookDeliveryOrm) error
+	// ClaimWebhookDeliveries returns up to limit pending deliveries due at
+	// now, with their subscription, and moves their next attempt to until.
+	// A delivery is claimed by one caller only, and is due again at until
+	// if its attempt is never recorded.
+	ClaimWebhookDeliveries(ctx context.Context, now time.Time, until time.Time, limit int) ([]domainWebhook.WebhookDeliveryOrm, error)
+	// RecordWebhookAttempt stores the state, attempt count, next attempt and
+	// last error of delivery together with attempt.
+	RecordWebhookAttempt(ctx context.Context, delivery domainWebhook.WebhookDeliveryOrm, attempt domainWebhook.WebhookAttemptOrm) error
+	// ListWebhookDeliveries returns the deliveries matching filter with their
+	// attempts, newest first.
+	ListWebhookDeliveries(ctx context.Context, filter domainWebhook.DeliveryFilter) ([]domainWebhook.WebhookDeliveryOrm, error)
+	// ScheduleWebhookDelivery makes a delivery pending again with a fresh
+	// attempt count, due at at.
+	ScheduleWebhookDelivery(ctx context.Context, id uuid.UUID, at time.Time) (domainWebhook.WebhookDeliveryOrm, error)
+}
+
+type WebhookSenderPort interface {
+	// Send posts body to url and returns the response status code.
+	Send(ctx context.Context, url string, header http.Header, body []byte) (int, error)
+}
+
+type WebhookServicePort interface {
+	CreateSubscription(ctx context.Context, accountNum string, customerId string, url string, eventTypes []string, secret string) (domainWebhook.WebhookSubscriptionOrm, error)
+	ListSubscriptions(ctx context.Context, accountNum string, customerId string) ([]domainWebhook.WebhookSubscriptionOrm, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, filter domainWebhook.DeliveryFilter) ([]domainWebhook.WebhookDeliveryOrm, error)
+	Redeliver(ctx context.Context, id uuid.UUID) (domainWebhook.WebhookDeliveryOrm, error)
+}
@@ -0,0 +1,25 @@
+package tracing
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ZerologHook adds trace_id and span_id to every log event that carries a
+// context with a recording span, e.g. log.Error().Ctx(ctx).
+type ZerologHook struct{}
+
+func (ZerologHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	ctx := e.GetCtx()
+	if ctx == nil {
+		return
+	}
+
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return
+	}
+
+	e.Str("trace_id", spanCtx.TraceID().String()).
+		Str("span_id", spanCtx.SpanID().String())
+}
@@ -0,0 +1,16 @@
+module github.com/fajaramaulana/go-grpc-micro-bank-proto
+
+go 1.23.0
+
+require (
+	google.golang.org/genproto v0.0.0-20240826202546-f6391c0de4c7
+	google.golang.org/grpc v1.66.0
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	golang.org/x/net v0.28.0 // indirect
+	golang.org/x/sys v0.24.0 // indirect
+	golang.org/x/text v0.17.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240823204242-4ba0660f739c // indirect
+)
@@ -0,0 +1,2577 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.12.4
+// source: bank/account_admin.proto
+
+package bank
+
+import (
+	date "google.golang.org/genproto/googleapis/type/date"
+	datetime "google.golang.org/genproto/googleapis/type/datetime"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DayCountConvention int32
+
+const (
+	DayCountConvention_DAY_COUNT_CONVENTION_UNSPECIFIED DayCountConvention = 0
+	DayCountConvention_ACT_365                          DayCountConvention = 1
+	DayCountConvention_ACT_360                          DayCountConvention = 2
+	// the days of the calendar year, 365 or 366
+	DayCountConvention_ACT_ACT DayCountConvention = 3
+)
+
+// Enum value maps for DayCountConvention.
+var (
+	DayCountConvention_name = map[int32]string{
+		0: "DAY_COUNT_CONVENTION_UNSPECIFIED",
+		1: "ACT_365",
+		2: "ACT_360",
+		3: "ACT_ACT",
+	}
+	DayCountConvention_value = map[string]int32{
+		"DAY_COUNT_CONVENTION_UNSPECIFIED": 0,
+		"ACT_365":                          1,
+		"ACT_360":                          2,
+		"ACT_ACT":                          3,
+	}
+)
+
+func (x DayCountConvention) Enum() *DayCountConvention {
+	p := new(DayCountConvention)
+	*p = x
+	return p
+}
+
+func (x DayCountConvention) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DayCountConvention) Descriptor() protoreflect.EnumDescriptor {
+	return file_bank_account_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (DayCountConvention) Type() protoreflect.EnumType {
+	return &file_bank_account_admin_proto_enumTypes[0]
+}
+
+func (x DayCountConvention) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DayCountConvention.Descriptor instead.
+func (DayCountConvention) EnumDescriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// values are prefixed, MONTHLY is a capitalization schedule already
+type AccrualFrequency int32
+
+const (
+	AccrualFrequency_ACCRUAL_FREQUENCY_UNSPECIFIED AccrualFrequency = 0
+	AccrualFrequency_ACCRUAL_DAILY                 AccrualFrequency = 1
+	// the days of a month accrue once it ended
+	AccrualFrequency_ACCRUAL_MONTHLY AccrualFrequency = 2
+)
+
+// Enum value maps for AccrualFrequency.
+var (
+	AccrualFrequency_name = map[int32]string{
+		0: "ACCRUAL_FREQUENCY_UNSPECIFIED",
+		1: "ACCRUAL_DAILY",
+		2: "ACCRUAL_MONTHLY",
+	}
+	AccrualFrequency_value = map[string]int32{
+		"ACCRUAL_FREQUENCY_UNSPECIFIED": 0,
+		"ACCRUAL_DAILY":                 1,
+		"ACCRUAL_MONTHLY":               2,
+	}
+)
+
+func (x AccrualFrequency) Enum() *AccrualFrequency {
+	p := new(AccrualFrequency)
+	*p = x
+	return p
+}
+
+func (x AccrualFrequency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccrualFrequency) Descriptor() protoreflect.EnumDescriptor {
+	return file_bank_account_admin_proto_enumTypes[1].Descriptor()
+}
+
+func (AccrualFrequency) Type() protoreflect.EnumType {
+	return &file_bank_account_admin_proto_enumTypes[1]
+}
+
+func (x AccrualFrequency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccrualFrequency.Descriptor instead.
+func (AccrualFrequency) EnumDescriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type CapitalizationSchedule int32
+
+const (
+	CapitalizationSchedule_CAPITALIZATION_SCHEDULE_UNSPECIFIED CapitalizationSchedule = 0
+	CapitalizationSchedule_MONTHLY                             CapitalizationSchedule = 1
+	CapitalizationSchedule_QUARTERLY                           CapitalizationSchedule = 2
+	CapitalizationSchedule_YEARLY                              CapitalizationSchedule = 3
+)
+
+// Enum value maps for CapitalizationSchedule.
+var (
+	CapitalizationSchedule_name = map[int32]string{
+		0: "CAPITALIZATION_SCHEDULE_UNSPECIFIED",
+		1: "MONTHLY",
+		2: "QUARTERLY",
+		3: "YEARLY",
+	}
+	CapitalizationSchedule_value = map[string]int32{
+		"CAPITALIZATION_SCHEDULE_UNSPECIFIED": 0,
+		"MONTHLY":                             1,
+		"QUARTERLY":                           2,
+		"YEARLY":                              3,
+	}
+)
+
+func (x CapitalizationSchedule) Enum() *CapitalizationSchedule {
+	p := new(CapitalizationSchedule)
+	*p = x
+	return p
+}
+
+func (x CapitalizationSchedule) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CapitalizationSchedule) Descriptor() protoreflect.EnumDescriptor {
+	return file_bank_account_admin_proto_enumTypes[2].Descriptor()
+}
+
+func (CapitalizationSchedule) Type() protoreflect.EnumType {
+	return &file_bank_account_admin_proto_enumTypes[2]
+}
+
+func (x CapitalizationSchedule) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CapitalizationSchedule.Descriptor instead.
+func (CapitalizationSchedule) EnumDescriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{2}
+}
+
+// Product holds the defaults of the accounts that belong to it. An account
+// of a product that was never saved has neither an overdraft nor a minimum
+// balance.
+type Product struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProductCode string `protobuf:"bytes,1,opt,name=product_code,proto3" json:"product_code,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// how far below zero debits may take the balance
+	OverdraftLimit float64 `protobuf:"fixed64,3,opt,name=overdraft_limit,proto3" json:"overdraft_limit,omitempty"`
+	// the balance debits have to leave, unless there is an overdraft
+	MinimumBalance float64 `protobuf:"fixed64,4,opt,name=minimum_balance,proto3" json:"minimum_balance,omitempty"`
+	// ACT_365 when unspecified
+	InterestDayCount DayCountConvention `protobuf:"varint,5,opt,name=interest_day_count,proto3,enum=bank.DayCountConvention" json:"interest_day_count,omitempty"`
+	// MONTHLY when unspecified
+	InterestCapitalization CapitalizationSchedule `protobuf:"varint,6,opt,name=interest_capitalization,proto3,enum=bank.CapitalizationSchedule" json:"interest_capitalization,omitempty"`
+	// ACCRUAL_DAILY when unspecified
+	InterestAccrualFrequency AccrualFrequency `protobuf:"varint,7,opt,name=interest_accrual_frequency,proto3,enum=bank.AccrualFrequency" json:"interest_accrual_frequency,omitempty"`
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Product) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetOverdraftLimit() float64 {
+	if x != nil {
+		return x.OverdraftLimit
+	}
+	return 0
+}
+
+func (x *Product) GetMinimumBalance() float64 {
+	if x != nil {
+		return x.MinimumBalance
+	}
+	return 0
+}
+
+func (x *Product) GetInterestDayCount() DayCountConvention {
+	if x != nil {
+		return x.InterestDayCount
+	}
+	return DayCountConvention_DAY_COUNT_CONVENTION_UNSPECIFIED
+}
+
+func (x *Product) GetInterestCapitalization() CapitalizationSchedule {
+	if x != nil {
+		return x.InterestCapitalization
+	}
+	return CapitalizationSchedule_CAPITALIZATION_SCHEDULE_UNSPECIFIED
+}
+
+func (x *Product) GetInterestAccrualFrequency() AccrualFrequency {
+	if x != nil {
+		return x.InterestAccrualFrequency
+	}
+	return AccrualFrequency_ACCRUAL_FREQUENCY_UNSPECIFIED
+}
+
+type ListProductsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Products []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type GetAccountLimitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+}
+
+func (x *GetAccountLimitsRequest) Reset() {
+	*x = GetAccountLimitsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountLimitsRequest) ProtoMessage() {}
+
+func (x *GetAccountLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountLimitsRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAccountLimitsRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+// SetAccountLimitsRequest replaces the limits an account has of its own, an
+// unset limit falls back to the product.
+type SetAccountLimitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	// keeps the product of the account when empty
+	ProductCode    string   `protobuf:"bytes,2,opt,name=product_code,proto3" json:"product_code,omitempty"`
+	OverdraftLimit *float64 `protobuf:"fixed64,3,opt,name=overdraft_limit,proto3,oneof" json:"overdraft_limit,omitempty"`
+	MinimumBalance *float64 `protobuf:"fixed64,4,opt,name=minimum_balance,proto3,oneof" json:"minimum_balance,omitempty"`
+}
+
+func (x *SetAccountLimitsRequest) Reset() {
+	*x = SetAccountLimitsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetAccountLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAccountLimitsRequest) ProtoMessage() {}
+
+func (x *SetAccountLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAccountLimitsRequest.ProtoReflect.Descriptor instead.
+func (*SetAccountLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetAccountLimitsRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *SetAccountLimitsRequest) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+func (x *SetAccountLimitsRequest) GetOverdraftLimit() float64 {
+	if x != nil && x.OverdraftLimit != nil {
+		return *x.OverdraftLimit
+	}
+	return 0
+}
+
+func (x *SetAccountLimitsRequest) GetMinimumBalance() float64 {
+	if x != nil && x.MinimumBalance != nil {
+		return *x.MinimumBalance
+	}
+	return 0
+}
+
+type AccountLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber  string  `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	ProductCode    string  `protobuf:"bytes,2,opt,name=product_code,proto3" json:"product_code,omitempty"`
+	OverdraftLimit float64 `protobuf:"fixed64,3,opt,name=overdraft_limit,proto3" json:"overdraft_limit,omitempty"`
+	MinimumBalance float64 `protobuf:"fixed64,4,opt,name=minimum_balance,proto3" json:"minimum_balance,omitempty"`
+	// whether the limit is the account's own rather than the product's
+	OverdraftLimitOverridden bool `protobuf:"varint,5,opt,name=overdraft_limit_overridden,proto3" json:"overdraft_limit_overridden,omitempty"`
+	MinimumBalanceOverridden bool `protobuf:"varint,6,opt,name=minimum_balance_overridden,proto3" json:"minimum_balance_overridden,omitempty"`
+	// how far the balance is below zero
+	OverdraftUsed float64 `protobuf:"fixed64,7,opt,name=overdraft_used,proto3" json:"overdraft_used,omitempty"`
+	// the balance less what holds reserve
+	AvailableAmount float64 `protobuf:"fixed64,8,opt,name=available_amount,proto3" json:"available_amount,omitempty"`
+	// what a debit can take from the account now
+	SpendableAmount float64 `protobuf:"fixed64,9,opt,name=spendable_amount,proto3" json:"spendable_amount,omitempty"`
+}
+
+func (x *AccountLimits) Reset() {
+	*x = AccountLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountLimits) ProtoMessage() {}
+
+func (x *AccountLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountLimits.ProtoReflect.Descriptor instead.
+func (*AccountLimits) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AccountLimits) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *AccountLimits) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+func (x *AccountLimits) GetOverdraftLimit() float64 {
+	if x != nil {
+		return x.OverdraftLimit
+	}
+	return 0
+}
+
+func (x *AccountLimits) GetMinimumBalance() float64 {
+	if x != nil {
+		return x.MinimumBalance
+	}
+	return 0
+}
+
+func (x *AccountLimits) GetOverdraftLimitOverridden() bool {
+	if x != nil {
+		return x.OverdraftLimitOverridden
+	}
+	return false
+}
+
+func (x *AccountLimits) GetMinimumBalanceOverridden() bool {
+	if x != nil {
+		return x.MinimumBalanceOverridden
+	}
+	return false
+}
+
+func (x *AccountLimits) GetOverdraftUsed() float64 {
+	if x != nil {
+		return x.OverdraftUsed
+	}
+	return 0
+}
+
+func (x *AccountLimits) GetAvailableAmount() float64 {
+	if x != nil {
+		return x.AvailableAmount
+	}
+	return 0
+}
+
+func (x *AccountLimits) GetSpendableAmount() float64 {
+	if x != nil {
+		return x.SpendableAmount
+	}
+	return 0
+}
+
+// InterestRates is a version of the rates of a product, in force from
+// effective_from until the next version. Saving a version replaces the one
+// of the product effective from the same day.
+type InterestRates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProductCode   string              `protobuf:"bytes,1,opt,name=product_code,proto3" json:"product_code,omitempty"`
+	EffectiveFrom *date.Date          `protobuf:"bytes,2,opt,name=effective_from,proto3" json:"effective_from,omitempty"`
+	Tiers         []*InterestRateTier `protobuf:"bytes,3,rep,name=tiers,proto3" json:"tiers,omitempty"`
+}
+
+func (x *InterestRates) Reset() {
+	*x = InterestRates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InterestRates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterestRates) ProtoMessage() {}
+
+func (x *InterestRates) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterestRates.ProtoReflect.Descriptor instead.
+func (*InterestRates) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InterestRates) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+func (x *InterestRates) GetEffectiveFrom() *date.Date {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *InterestRates) GetTiers() []*InterestRateTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+// InterestRateTier pays annual_rate percent a year on a whole closing
+// balance of min_balance or more, unless a tier with a higher min_balance
+// applies.
+type InterestRateTier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinBalance float64 `protobuf:"fixed64,1,opt,name=min_balance,proto3" json:"min_balance,omitempty"`
+	AnnualRate float64 `protobuf:"fixed64,2,opt,name=annual_rate,proto3" json:"annual_rate,omitempty"`
+}
+
+func (x *InterestRateTier) Reset() {
+	*x = InterestRateTier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InterestRateTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterestRateTier) ProtoMessage() {}
+
+func (x *InterestRateTier) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterestRateTier.ProtoReflect.Descriptor instead.
+func (*InterestRateTier) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *InterestRateTier) GetMinBalance() float64 {
+	if x != nil {
+		return x.MinBalance
+	}
+	return 0
+}
+
+func (x *InterestRateTier) GetAnnualRate() float64 {
+	if x != nil {
+		return x.AnnualRate
+	}
+	return 0
+}
+
+type ListInterestRatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// every product when empty
+	ProductCode string `protobuf:"bytes,1,opt,name=product_code,proto3" json:"product_code,omitempty"`
+}
+
+func (x *ListInterestRatesRequest) Reset() {
+	*x = ListInterestRatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInterestRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterestRatesRequest) ProtoMessage() {}
+
+func (x *ListInterestRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterestRatesRequest.ProtoReflect.Descriptor instead.
+func (*ListInterestRatesRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListInterestRatesRequest) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+type ListInterestRatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rates []*InterestRates `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+}
+
+func (x *ListInterestRatesResponse) Reset() {
+	*x = ListInterestRatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInterestRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterestRatesResponse) ProtoMessage() {}
+
+func (x *ListInterestRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterestRatesResponse.ProtoReflect.Descriptor instead.
+func (*ListInterestRatesResponse) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListInterestRatesResponse) GetRates() []*InterestRates {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+// ListInterestAccrualsRequest selects the accruals of an account from from
+// up to, not including, to. An unset date leaves that end open.
+type ListInterestAccrualsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string     `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	From          *date.Date `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *date.Date `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *ListInterestAccrualsRequest) Reset() {
+	*x = ListInterestAccrualsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInterestAccrualsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterestAccrualsRequest) ProtoMessage() {}
+
+func (x *ListInterestAccrualsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterestAccrualsRequest.ProtoReflect.Descriptor instead.
+func (*ListInterestAccrualsRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListInterestAccrualsRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *ListInterestAccrualsRequest) GetFrom() *date.Date {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListInterestAccrualsRequest) GetTo() *date.Date {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type ListInterestAccrualsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accruals []*InterestAccrual `protobuf:"bytes,1,rep,name=accruals,proto3" json:"accruals,omitempty"`
+}
+
+func (x *ListInterestAccrualsResponse) Reset() {
+	*x = ListInterestAccrualsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInterestAccrualsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterestAccrualsResponse) ProtoMessage() {}
+
+func (x *ListInterestAccrualsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterestAccrualsResponse.ProtoReflect.Descriptor instead.
+func (*ListInterestAccrualsResponse) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListInterestAccrualsResponse) GetAccruals() []*InterestAccrual {
+	if x != nil {
+		return x.Accruals
+	}
+	return nil
+}
+
+// InterestAccrual is the interest an account earned on a day on its closing
+// balance.
+type InterestAccrual struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccrualDate *date.Date `protobuf:"bytes,1,opt,name=accrual_date,proto3" json:"accrual_date,omitempty"`
+	ProductCode string     `protobuf:"bytes,2,opt,name=product_code,proto3" json:"product_code,omitempty"`
+	Balance     float64    `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	AnnualRate  float64    `protobuf:"fixed64,4,opt,name=annual_rate,proto3" json:"annual_rate,omitempty"`
+	// not rounded to cents
+	Amount float64 `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	// whether the accrual was posted, and when
+	Capitalized     bool               `protobuf:"varint,6,opt,name=capitalized,proto3" json:"capitalized,omitempty"`
+	TransactionUuid string             `protobuf:"bytes,7,opt,name=transaction_uuid,proto3" json:"transaction_uuid,omitempty"`
+	CapitalizedAt   *datetime.DateTime `protobuf:"bytes,8,opt,name=capitalized_at,proto3" json:"capitalized_at,omitempty"`
+}
+
+func (x *InterestAccrual) Reset() {
+	*x = InterestAccrual{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InterestAccrual) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterestAccrual) ProtoMessage() {}
+
+func (x *InterestAccrual) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterestAccrual.ProtoReflect.Descriptor instead.
+func (*InterestAccrual) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *InterestAccrual) GetAccrualDate() *date.Date {
+	if x != nil {
+		return x.AccrualDate
+	}
+	return nil
+}
+
+func (x *InterestAccrual) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+func (x *InterestAccrual) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *InterestAccrual) GetAnnualRate() float64 {
+	if x != nil {
+		return x.AnnualRate
+	}
+	return 0
+}
+
+func (x *InterestAccrual) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *InterestAccrual) GetCapitalized() bool {
+	if x != nil {
+		return x.Capitalized
+	}
+	return false
+}
+
+func (x *InterestAccrual) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *InterestAccrual) GetCapitalizedAt() *datetime.DateTime {
+	if x != nil {
+		return x.CapitalizedAt
+	}
+	return nil
+}
+
+// TaxRule withholds rate percent of the interest posted to the accounts in
+// currency whose balance before it is above min_balance, from effective_from
+// until the next rule of the currency, and credits it to
+// payable_account_number. Saving a rule replaces the one of the currency
+// effective from the same day.
+type TaxRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Currency      string     `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	EffectiveFrom *date.Date `protobuf:"bytes,2,opt,name=effective_from,proto3" json:"effective_from,omitempty"`
+	Rate          float64    `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	MinBalance    float64    `protobuf:"fixed64,4,opt,name=min_balance,proto3" json:"min_balance,omitempty"`
+	// an account in currency
+	PayableAccountNumber string `protobuf:"bytes,5,opt,name=payable_account_number,proto3" json:"payable_account_number,omitempty"`
+}
+
+func (x *TaxRule) Reset() {
+	*x = TaxRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaxRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxRule) ProtoMessage() {}
+
+func (x *TaxRule) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxRule.ProtoReflect.Descriptor instead.
+func (*TaxRule) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TaxRule) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TaxRule) GetEffectiveFrom() *date.Date {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *TaxRule) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *TaxRule) GetMinBalance() float64 {
+	if x != nil {
+		return x.MinBalance
+	}
+	return 0
+}
+
+func (x *TaxRule) GetPayableAccountNumber() string {
+	if x != nil {
+		return x.PayableAccountNumber
+	}
+	return ""
+}
+
+type ListTaxRulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListTaxRulesRequest) Reset() {
+	*x = ListTaxRulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTaxRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaxRulesRequest) ProtoMessage() {}
+
+func (x *ListTaxRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaxRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListTaxRulesRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{14}
+}
+
+type ListTaxRulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*TaxRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *ListTaxRulesResponse) Reset() {
+	*x = ListTaxRulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTaxRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaxRulesResponse) ProtoMessage() {}
+
+func (x *ListTaxRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaxRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListTaxRulesResponse) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListTaxRulesResponse) GetRules() []*TaxRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type SetTaxExemptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	Exempt        bool   `protobuf:"varint,2,opt,name=exempt,proto3" json:"exempt,omitempty"`
+}
+
+func (x *SetTaxExemptionRequest) Reset() {
+	*x = SetTaxExemptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetTaxExemptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTaxExemptionRequest) ProtoMessage() {}
+
+func (x *SetTaxExemptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTaxExemptionRequest.ProtoReflect.Descriptor instead.
+func (*SetTaxExemptionRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetTaxExemptionRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *SetTaxExemptionRequest) GetExempt() bool {
+	if x != nil {
+		return x.Exempt
+	}
+	return false
+}
+
+type TaxExemption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	Exempt        bool   `protobuf:"varint,2,opt,name=exempt,proto3" json:"exempt,omitempty"`
+}
+
+func (x *TaxExemption) Reset() {
+	*x = TaxExemption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaxExemption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxExemption) ProtoMessage() {}
+
+func (x *TaxExemption) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxExemption.ProtoReflect.Descriptor instead.
+func (*TaxExemption) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TaxExemption) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *TaxExemption) GetExempt() bool {
+	if x != nil {
+		return x.Exempt
+	}
+	return false
+}
+
+// FeeSchedule prices the transfers in currency. An empty channel or
+// customer_segment matches every channel or segment; a schedule of the
+// segment of the sender beats one of the channel, which beats one of neither.
+type FeeSchedule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// set by SetFeeSchedule, which replaces the schedule of the same
+	// currency, channel and customer_segment
+	ScheduleId      string     `protobuf:"bytes,1,opt,name=schedule_id,proto3" json:"schedule_id,omitempty"`
+	Currency        string     `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Channel         string     `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	CustomerSegment string     `protobuf:"bytes,4,opt,name=customer_segment,proto3" json:"customer_segment,omitempty"`
+	Tiers           []*FeeTier `protobuf:"bytes,5,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	MinFee          float64    `protobuf:"fixed64,6,opt,name=min_fee,proto3" json:"min_fee,omitempty"`
+	// 0 leaves the fee uncapped
+	MaxFee                float64 `protobuf:"fixed64,7,opt,name=max_fee,proto3" json:"max_fee,omitempty"`
+	FreeTransfersPerMonth int32   `protobuf:"varint,8,opt,name=free_transfers_per_month,proto3" json:"free_transfers_per_month,omitempty"`
+	// the account credited with the fees
+	FeeAccountNumber string `protobuf:"bytes,9,opt,name=fee_account_number,proto3" json:"fee_account_number,omitempty"`
+}
+
+func (x *FeeSchedule) Reset() {
+	*x = FeeSchedule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeeSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeSchedule) ProtoMessage() {}
+
+func (x *FeeSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeSchedule.ProtoReflect.Descriptor instead.
+func (*FeeSchedule) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FeeSchedule) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetTiers() []*FeeTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *FeeSchedule) GetMinFee() float64 {
+	if x != nil {
+		return x.MinFee
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetMaxFee() float64 {
+	if x != nil {
+		return x.MaxFee
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetFreeTransfersPerMonth() int32 {
+	if x != nil {
+		return x.FreeTransfersPerMonth
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetFeeAccountNumber() string {
+	if x != nil {
+		return x.FeeAccountNumber
+	}
+	return ""
+}
+
+// FeeTier charges flat_amount plus rate percent of the transfers of
+// min_amount or more, unless a tier with a higher min_amount applies.
+type FeeTier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinAmount  float64 `protobuf:"fixed64,1,opt,name=min_amount,proto3" json:"min_amount,omitempty"`
+	FlatAmount float64 `protobuf:"fixed64,2,opt,name=flat_amount,proto3" json:"flat_amount,omitempty"`
+	Rate       float64 `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`
+}
+
+func (x *FeeTier) Reset() {
+	*x = FeeTier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeeTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeTier) ProtoMessage() {}
+
+func (x *FeeTier) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeTier.ProtoReflect.Descriptor instead.
+func (*FeeTier) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FeeTier) GetMinAmount() float64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *FeeTier) GetFlatAmount() float64 {
+	if x != nil {
+		return x.FlatAmount
+	}
+	return 0
+}
+
+func (x *FeeTier) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type ListFeeSchedulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListFeeSchedulesRequest) Reset() {
+	*x = ListFeeSchedulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFeeSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeeSchedulesRequest) ProtoMessage() {}
+
+func (x *ListFeeSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeeSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListFeeSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{20}
+}
+
+type ListFeeSchedulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedules []*FeeSchedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+}
+
+func (x *ListFeeSchedulesResponse) Reset() {
+	*x = ListFeeSchedulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFeeSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeeSchedulesResponse) ProtoMessage() {}
+
+func (x *ListFeeSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeeSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListFeeSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListFeeSchedulesResponse) GetSchedules() []*FeeSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type SetCustomerSegmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	// empty for none
+	CustomerSegment string `protobuf:"bytes,2,opt,name=customer_segment,proto3" json:"customer_segment,omitempty"`
+}
+
+func (x *SetCustomerSegmentRequest) Reset() {
+	*x = SetCustomerSegmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetCustomerSegmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCustomerSegmentRequest) ProtoMessage() {}
+
+func (x *SetCustomerSegmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCustomerSegmentRequest.ProtoReflect.Descriptor instead.
+func (*SetCustomerSegmentRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetCustomerSegmentRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *SetCustomerSegmentRequest) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+type CustomerSegment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber   string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	CustomerSegment string `protobuf:"bytes,2,opt,name=customer_segment,proto3" json:"customer_segment,omitempty"`
+}
+
+func (x *CustomerSegment) Reset() {
+	*x = CustomerSegment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CustomerSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerSegment) ProtoMessage() {}
+
+func (x *CustomerSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerSegment.ProtoReflect.Descriptor instead.
+func (*CustomerSegment) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CustomerSegment) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *CustomerSegment) GetCustomerSegment() string {
+	if x != nil {
+		return x.CustomerSegment
+	}
+	return ""
+}
+
+// The accounts of a customer share its id. Customer scoped webhook
+// subscriptions are sent the events of all of them.
+type SetAccountCustomerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	// at most 36 characters, empty for none
+	CustomerId string `protobuf:"bytes,2,opt,name=customer_id,proto3" json:"customer_id,omitempty"`
+}
+
+func (x *SetAccountCustomerRequest) Reset() {
+	*x = SetAccountCustomerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetAccountCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAccountCustomerRequest) ProtoMessage() {}
+
+func (x *SetAccountCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAccountCustomerRequest.ProtoReflect.Descriptor instead.
+func (*SetAccountCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetAccountCustomerRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *SetAccountCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type AccountCustomer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	CustomerId    string `protobuf:"bytes,2,opt,name=customer_id,proto3" json:"customer_id,omitempty"`
+}
+
+func (x *AccountCustomer) Reset() {
+	*x = AccountCustomer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_account_admin_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountCustomer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountCustomer) ProtoMessage() {}
+
+func (x *AccountCustomer) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_account_admin_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountCustomer.ProtoReflect.Descriptor instead.
+func (*AccountCustomer) Descriptor() ([]byte, []int) {
+	return file_bank_account_admin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AccountCustomer) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *AccountCustomer) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+var File_bank_account_admin_proto protoreflect.FileDescriptor
+
+var file_bank_account_admin_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x62, 0x61, 0x6e, 0x6b,
+	0x1a, 0x16, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61,
+	0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8f, 0x03, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x6f, 0x76, 0x65, 0x72,
+	0x64, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0f, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6d, 0x69, 0x6e,
+	0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x12,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x44, 0x61, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x12, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x79,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x56, 0x0a, 0x17, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65,
+	0x73, 0x74, 0x5f, 0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x43,
+	0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x17, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x5f,
+	0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x56,
+	0x0a, 0x1a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x72, 0x75,
+	0x61, 0x6c, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x16, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x41, 0x63, 0x63, 0x72, 0x75, 0x61,
+	0x6c, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x1a, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x72, 0x75, 0x61, 0x6c, 0x5f, 0x66, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x41, 0x0a,
+	0x14, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73,
+	0x22, 0x41, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x22, 0xeb, 0x01, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x2d, 0x0a, 0x0f, 0x6f,
+	0x76, 0x65, 0x72, 0x64, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0f, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x72, 0x61, 0x66,
+	0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x6d, 0x69,
+	0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6f, 0x76,
+	0x65, 0x72, 0x64, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x12, 0x0a,
+	0x10, 0x5f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x22, 0xaf, 0x03, 0x0a, 0x0d, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x28, 0x0a, 0x0f, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x72,
+	0x61, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x6d, 0x69, 0x6e,
+	0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x1a, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x72, 0x61, 0x66, 0x74,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x72, 0x61,
+	0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x12, 0x3e, 0x0a, 0x1a, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d,
+	0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x72, 0x61, 0x66, 0x74,
+	0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x6f, 0x76, 0x65,
+	0x72, 0x64, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x70, 0x65, 0x6e, 0x64,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x10, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x9c, 0x01, 0x0a, 0x0d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74,
+	0x52, 0x61, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x39, 0x0a, 0x0e, 0x65, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e,
+	0x44, 0x61, 0x74, 0x65, 0x52, 0x0e, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2c, 0x0a, 0x05, 0x74, 0x69, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x65, 0x73, 0x74, 0x52, 0x61, 0x74, 0x65, 0x54, 0x69, 0x65, 0x72, 0x52, 0x05, 0x74, 0x69, 0x65,
+	0x72, 0x73, 0x22, 0x56, 0x0a, 0x10, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61,
+	0x74, 0x65, 0x54, 0x69, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x69, 0x6e,
+	0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x75,
+	0x61, 0x6c, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x61,
+	0x6e, 0x6e, 0x75, 0x61, 0x6c, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x22, 0x3e, 0x0a, 0x18, 0x4c, 0x69,
+	0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x46, 0x0a, 0x19, 0x4c, 0x69,
+	0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x05, 0x72, 0x61, 0x74,
+	0x65, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x65, 0x73, 0x74, 0x41, 0x63, 0x63, 0x72, 0x75, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x21, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65,
+	0x52, 0x02, 0x74, 0x6f, 0x22, 0x51, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x65, 0x73, 0x74, 0x41, 0x63, 0x63, 0x72, 0x75, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x72, 0x75, 0x61, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x41, 0x63, 0x63, 0x72, 0x75, 0x61, 0x6c, 0x52, 0x08, 0x61,
+	0x63, 0x63, 0x72, 0x75, 0x61, 0x6c, 0x73, 0x22, 0xcd, 0x02, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x65, 0x73, 0x74, 0x41, 0x63, 0x63, 0x72, 0x75, 0x61, 0x6c, 0x12, 0x35, 0x0a, 0x0c, 0x61,
+	0x63, 0x63, 0x72, 0x75, 0x61, 0x6c, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e,
+	0x44, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x61, 0x63, 0x63, 0x72, 0x75, 0x61, 0x6c, 0x5f, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x75, 0x61, 0x6c, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x75, 0x61, 0x6c, 0x5f, 0x72, 0x61,
+	0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61,
+	0x70, 0x69, 0x74, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x75, 0x69, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x12, 0x3d, 0x0a, 0x0e, 0x63, 0x61, 0x70, 0x69,
+	0x74, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44,
+	0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x0e, 0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x22, 0xce, 0x01, 0x0a, 0x07, 0x54, 0x61, 0x78, 0x52,
+	0x75, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12,
+	0x39, 0x0a, 0x0e, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x0e, 0x65, 0x66, 0x66, 0x65,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x36, 0x0a, 0x16, 0x70, 0x61, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x16, 0x70, 0x61, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x61, 0x78, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x3b, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x78, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x61,
+	0x78, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x58, 0x0a, 0x16,
+	0x53, 0x65, 0x74, 0x54, 0x61, 0x78, 0x45, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x65, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x65, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x22, 0x4e, 0x0a, 0x0c, 0x54, 0x61, 0x78, 0x45, 0x78, 0x65,
+	0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x65, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x65, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x22, 0xd6, 0x02, 0x0a, 0x0b, 0x46, 0x65, 0x65, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x2a,
+	0x0a, 0x10, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x05, 0x74, 0x69,
+	0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x61, 0x6e, 0x6b,
+	0x2e, 0x46, 0x65, 0x65, 0x54, 0x69, 0x65, 0x72, 0x52, 0x05, 0x74, 0x69, 0x65, 0x72, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x78,
+	0x5f, 0x66, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x5f,
+	0x66, 0x65, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x18, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x12,
+	0x2e, 0x0a, 0x12, 0x66, 0x65, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x66, 0x65, 0x65,
+	0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22,
+	0x5f, 0x0a, 0x07, 0x46, 0x65, 0x65, 0x54, 0x69, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x69,
+	0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x6c,
+	0x61, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0b, 0x66, 0x6c, 0x61, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65,
+	0x22, 0x19, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4b, 0x0a, 0x18, 0x4c,
+	0x69, 0x73, 0x74, 0x46, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x46, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x09, 0x73,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x6f, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2a, 0x0a,
+	0x10, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x65, 0x0a, 0x0f, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x22, 0x65, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a,
+	0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x22, 0x5b, 0x0a, 0x0f, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x2a, 0x61, 0x0a, 0x12, 0x44, 0x61, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x20, 0x44, 0x41,
+	0x59, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x43, 0x4f, 0x4e, 0x56, 0x45, 0x4e, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x0b, 0x0a, 0x07, 0x41, 0x43, 0x54, 0x5f, 0x33, 0x36, 0x35, 0x10, 0x01, 0x12, 0x0b, 0x0a,
+	0x07, 0x41, 0x43, 0x54, 0x5f, 0x33, 0x36, 0x30, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x43,
+	0x54, 0x5f, 0x41, 0x43, 0x54, 0x10, 0x03, 0x2a, 0x5d, 0x0a, 0x10, 0x41, 0x63, 0x63, 0x72, 0x75,
+	0x61, 0x6c, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x21, 0x0a, 0x1d, 0x41,
+	0x43, 0x43, 0x52, 0x55, 0x41, 0x4c, 0x5f, 0x46, 0x52, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x43, 0x59,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11,
+	0x0a, 0x0d, 0x41, 0x43, 0x43, 0x52, 0x55, 0x41, 0x4c, 0x5f, 0x44, 0x41, 0x49, 0x4c, 0x59, 0x10,
+	0x01, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x43, 0x43, 0x52, 0x55, 0x41, 0x4c, 0x5f, 0x4d, 0x4f, 0x4e,
+	0x54, 0x48, 0x4c, 0x59, 0x10, 0x02, 0x2a, 0x69, 0x0a, 0x16, 0x43, 0x61, 0x70, 0x69, 0x74, 0x61,
+	0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x12, 0x27, 0x0a, 0x23, 0x43, 0x41, 0x50, 0x49, 0x54, 0x41, 0x4c, 0x49, 0x5a, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x4f, 0x4e,
+	0x54, 0x48, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x51, 0x55, 0x41, 0x52, 0x54, 0x45,
+	0x52, 0x4c, 0x59, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x59, 0x45, 0x41, 0x52, 0x4c, 0x59, 0x10,
+	0x03, 0x32, 0x87, 0x08, 0x0a, 0x13, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x64, 0x6d,
+	0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x0b, 0x53, 0x61, 0x76,
+	0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x0d, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x1a, 0x0d, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x48, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x47, 0x65, 0x74,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x10, 0x53,
+	0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12,
+	0x1d, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x65, 0x73, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x12, 0x13, 0x2e, 0x62, 0x61, 0x6e, 0x6b,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x1a, 0x13,
+	0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61,
+	0x74, 0x65, 0x73, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5f, 0x0a,
+	0x14, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x41, 0x63, 0x63,
+	0x72, 0x75, 0x61, 0x6c, 0x73, 0x12, 0x21, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x41, 0x63, 0x63, 0x72, 0x75, 0x61, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x41, 0x63, 0x63, 0x72,
+	0x75, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2c,
+	0x0a, 0x0a, 0x53, 0x65, 0x74, 0x54, 0x61, 0x78, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x0d, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x61, 0x78, 0x52, 0x75, 0x6c, 0x65, 0x1a, 0x0d, 0x2e, 0x62, 0x61,
+	0x6e, 0x6b, 0x2e, 0x54, 0x61, 0x78, 0x52, 0x75, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x78, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x78, 0x52, 0x75, 0x6c, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x61, 0x78, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x54, 0x61, 0x78, 0x45,
+	0x78, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x53, 0x65, 0x74, 0x54, 0x61, 0x78, 0x45, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x61,
+	0x78, 0x45, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0e,
+	0x53, 0x65, 0x74, 0x46, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x11,
+	0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x46, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x1a, 0x11, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x46, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65,
+	0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x61, 0x6e, 0x6b,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x12, 0x53,
+	0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x1f, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x12, 0x53,
+	0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x12, 0x1f, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x22, 0x00, 0x42, 0x44, 0x5a, 0x42, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x6a, 0x61, 0x72, 0x61,
+	0x6d, 0x61, 0x75, 0x6c, 0x61, 0x6e, 0x61, 0x2f, 0x67, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d,
+	0x6d, 0x69, 0x63, 0x72, 0x6f, 0x2d, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x62, 0x61, 0x6e,
+	0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bank_account_admin_proto_rawDescOnce sync.Once
+	file_bank_account_admin_proto_rawDescData = file_bank_account_admin_proto_rawDesc
+)
+
+func file_bank_account_admin_proto_rawDescGZIP() []byte {
+	file_bank_account_admin_proto_rawDescOnce.Do(func() {
+		file_bank_account_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_bank_account_admin_proto_rawDescData)
+	})
+	return file_bank_account_admin_proto_rawDescData
+}
+
+var file_bank_account_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_bank_account_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_bank_account_admin_proto_goTypes = []any{
+	(DayCountConvention)(0),              // 0: bank.DayCountConvention
+	(AccrualFrequency)(0),                // 1: bank.AccrualFrequency
+	(CapitalizationSchedule)(0),          // 2: bank.CapitalizationSchedule
+	(*Product)(nil),                      // 3: bank.Product
+	(*ListProductsRequest)(nil),          // 4: bank.ListProductsRequest
+	(*ListProductsResponse)(nil),         // 5: bank.ListProductsResponse
+	(*GetAccountLimitsRequest)(nil),      // 6: bank.GetAccountLimitsRequest
+	(*SetAccountLimitsRequest)(nil),      // 7: bank.SetAccountLimitsRequest
+	(*AccountLimits)(nil),                // 8: bank.AccountLimits
+	(*InterestRates)(nil),                // 9: bank.InterestRates
+	(*InterestRateTier)(nil),             // 10: bank.InterestRateTier
+	(*ListInterestRatesRequest)(nil),     // 11: bank.ListInterestRatesRequest
+	(*ListInterestRatesResponse)(nil),    // 12: bank.ListInterestRatesResponse
+	(*ListInterestAccrualsRequest)(nil),  // 13: bank.ListInterestAccrualsRequest
+	(*ListInterestAccrualsResponse)(nil), // 14: bank.ListInterestAccrualsResponse
+	(*InterestAccrual)(nil),              // 15: bank.InterestAccrual
+	(*TaxRule)(nil),                      // 16: bank.TaxRule
+	(*ListTaxRulesRequest)(nil),          // 17: bank.ListTaxRulesRequest
+	(*ListTaxRulesResponse)(nil),         // 18: bank.ListTaxRulesResponse
+	(*SetTaxExemptionRequest)(nil),       // 19: bank.SetTaxExemptionRequest
+	(*TaxExemption)(nil),                 // 20: bank.TaxExemption
+	(*FeeSchedule)(nil),                  // 21: bank.FeeSchedule
+	(*FeeTier)(nil),                      // 22: bank.FeeTier
+	(*ListFeeSchedulesRequest)(nil),      // 23: bank.ListFeeSchedulesRequest
+	(*ListFeeSchedulesResponse)(nil),     // 24: bank.ListFeeSchedulesResponse
+	(*SetCustomerSegmentRequest)(nil),    // 25: bank.SetCustomerSegmentRequest
+	(*CustomerSegment)(nil),              // 26: bank.CustomerSegment
+	(*SetAccountCustomerRequest)(nil),    // 27: bank.SetAccountCustomerRequest
+	(*AccountCustomer)(nil),              // 28: bank.AccountCustomer
+	(*date.Date)(nil),                    // 29: google.type.Date
+	(*datetime.DateTime)(nil),            // 30: google.type.DateTime
+}
+var file_bank_account_admin_proto_depIdxs = []int32{
+	0,  // 0: bank.Product.interest_day_count:type_name -> bank.DayCountConvention
+	2,  // 1: bank.Product.interest_capitalization:type_name -> bank.CapitalizationSchedule
+	1,  // 2: bank.Product.interest_accrual_frequency:type_name -> bank.AccrualFrequency
+	3,  // 3: bank.ListProductsResponse.products:type_name -> bank.Product
+	29, // 4: bank.InterestRates.effective_from:type_name -> google.type.Date
+	10, // 5: bank.InterestRates.tiers:type_name -> bank.InterestRateTier
+	9,  // 6: bank.ListInterestRatesResponse.rates:type_name -> bank.InterestRates
+	29, // 7: bank.ListInterestAccrualsRequest.from:type_name -> google.type.Date
+	29, // 8: bank.ListInterestAccrualsRequest.to:type_name -> google.type.Date
+	15, // 9: bank.ListInterestAccrualsResponse.accruals:type_name -> bank.InterestAccrual
+	29, // 10: bank.InterestAccrual.accrual_date:type_name -> google.type.Date
+	30, // 11: bank.InterestAccrual.capitalized_at:type_name -> google.type.DateTime
+	29, // 12: bank.TaxRule.effective_from:type_name -> google.type.Date
+	16, // 13: bank.ListTaxRulesResponse.rules:type_name -> bank.TaxRule
+	22, // 14: bank.FeeSchedule.tiers:type_name -> bank.FeeTier
+	21, // 15: bank.ListFeeSchedulesResponse.schedules:type_name -> bank.FeeSchedule
+	3,  // 16: bank.AccountAdminService.SaveProduct:input_type -> bank.Product
+	4,  // 17: bank.AccountAdminService.ListProducts:input_type -> bank.ListProductsRequest
+	6,  // 18: bank.AccountAdminService.GetAccountLimits:input_type -> bank.GetAccountLimitsRequest
+	7,  // 19: bank.AccountAdminService.SetAccountLimits:input_type -> bank.SetAccountLimitsRequest
+	9,  // 20: bank.AccountAdminService.SetInterestRates:input_type -> bank.InterestRates
+	11, // 21: bank.AccountAdminService.ListInterestRates:input_type -> bank.ListInterestRatesRequest
+	13, // 22: bank.AccountAdminService.ListInterestAccruals:input_type -> bank.ListInterestAccrualsRequest
+	16, // 23: bank.AccountAdminService.SetTaxRule:input_type -> bank.TaxRule
+	17, // 24: bank.AccountAdminService.ListTaxRules:input_type -> bank.ListTaxRulesRequest
+	19, // 25: bank.AccountAdminService.SetTaxExemption:input_type -> bank.SetTaxExemptionRequest
+	21, // 26: bank.AccountAdminService.SetFeeSchedule:input_type -> bank.FeeSchedule
+	23, // 27: bank.AccountAdminService.ListFeeSchedules:input_type -> bank.ListFeeSchedulesRequest
+	25, // 28: bank.AccountAdminService.SetCustomerSegment:input_type -> bank.SetCustomerSegmentRequest
+	27, // 29: bank.AccountAdminService.SetAccountCustomer:input_type -> bank.SetAccountCustomerRequest
+	3,  // 30: bank.AccountAdminService.SaveProduct:output_type -> bank.Product
+	5,  // 31: bank.AccountAdminService.ListProducts:output_type -> bank.ListProductsResponse
+	8,  // 32: bank.AccountAdminService.GetAccountLimits:output_type -> bank.AccountLimits
+	8,  // 33: bank.AccountAdminService.SetAccountLimits:output_type -> bank.AccountLimits
+	9,  // 34: bank.AccountAdminService.SetInterestRates:output_type -> bank.InterestRates
+	12, // 35: bank.AccountAdminService.ListInterestRates:output_type -> bank.ListInterestRatesResponse
+	14, // 36: bank.AccountAdminService.ListInterestAccruals:output_type -> bank.ListInterestAccrualsResponse
+	16, // 37: bank.AccountAdminService.SetTaxRule:output_type -> bank.TaxRule
+	18, // 38: bank.AccountAdminService.ListTaxRules:output_type -> bank.ListTaxRulesResponse
+	20, // 39: bank.AccountAdminService.SetTaxExemption:output_type -> bank.TaxExemption
+	21, // 40: bank.AccountAdminService.SetFeeSchedule:output_type -> bank.FeeSchedule
+	24, // 41: bank.AccountAdminService.ListFeeSchedules:output_type -> bank.ListFeeSchedulesResponse
+	26, // 42: bank.AccountAdminService.SetCustomerSegment:output_type -> bank.CustomerSegment
+	28, // 43: bank.AccountAdminService.SetAccountCustomer:output_type -> bank.AccountCustomer
+	30, // [30:44] is the sub-list for method output_type
+	16, // [16:30] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_bank_account_admin_proto_init() }
+func file_bank_account_admin_proto_init() {
+	if File_bank_account_admin_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_bank_account_admin_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Product); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListProductsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListProductsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAccountLimitsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*SetAccountLimitsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*AccountLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*InterestRates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*InterestRateTier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ListInterestRatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ListInterestRatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ListInterestAccrualsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*ListInterestAccrualsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*InterestAccrual); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*TaxRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTaxRulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTaxRulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*SetTaxExemptionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*TaxExemption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*FeeSchedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*FeeTier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*ListFeeSchedulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*ListFeeSchedulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*SetCustomerSegmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*CustomerSegment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*SetAccountCustomerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_account_admin_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*AccountCustomer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_bank_account_admin_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bank_account_admin_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bank_account_admin_proto_goTypes,
+		DependencyIndexes: file_bank_account_admin_proto_depIdxs,
+		EnumInfos:         file_bank_account_admin_proto_enumTypes,
+		MessageInfos:      file_bank_account_admin_proto_msgTypes,
+	}.Build()
+	File_bank_account_admin_proto = out.File
+	file_bank_account_admin_proto_rawDesc = nil
+	file_bank_account_admin_proto_goTypes = nil
+	file_bank_account_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,625 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: bank/account_admin.proto
+
+package bank
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AccountAdminService_SaveProduct_FullMethodName          = "/bank.AccountAdminService/SaveProduct"
+	AccountAdminService_ListProducts_FullMethodName         = "/bank.AccountAdminService/ListProducts"
+	AccountAdminService_GetAccountLimits_FullMethodName     = "/bank.AccountAdminService/GetAccountLimits"
+	AccountAdminService_SetAccountLimits_FullMethodName     = "/bank.AccountAdminService/SetAccountLimits"
+	AccountAdminService_SetInterestRates_FullMethodName     = "/bank.AccountAdminService/SetInterestRates"
+	AccountAdminService_ListInterestRates_FullMethodName    = "/bank.AccountAdminService/ListInterestRates"
+	AccountAdminService_ListInterestAccruals_FullMethodName = "/bank.AccountAdminService/ListInterestAccruals"
+	AccountAdminService_SetTaxRule_FullMethodName           = "/bank.AccountAdminService/SetTaxRule"
+	AccountAdminService_ListTaxRules_FullMethodName         = "/bank.AccountAdminService/ListTaxRules"
+	AccountAdminService_SetTaxExemption_FullMethodName      = "/bank.AccountAdminService/SetTaxExemption"
+	AccountAdminService_SetFeeSchedule_FullMethodName       = "/bank.AccountAdminService/SetFeeSchedule"
+	AccountAdminService_ListFeeSchedules_FullMethodName     = "/bank.AccountAdminService/ListFeeSchedules"
+	AccountAdminService_SetCustomerSegment_FullMethodName   = "/bank.AccountAdminService/SetCustomerSegment"
+	AccountAdminService_SetAccountCustomer_FullMethodName   = "/bank.AccountAdminService/SetAccountCustomer"
+)
+
+// AccountAdminServiceClient is the client API for AccountAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AccountAdminService configures the products accounts belong to, the
+// limits a debit of an account has to respect, the interest products pay,
+// the tax withheld from it, the fees of transfers and the customers accounts
+// belong to.
+type AccountAdminServiceClient interface {
+	SaveProduct(ctx context.Context, in *Product, opts ...grpc.CallOption) (*Product, error)
+	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	GetAccountLimits(ctx context.Context, in *GetAccountLimitsRequest, opts ...grpc.CallOption) (*AccountLimits, error)
+	SetAccountLimits(ctx context.Context, in *SetAccountLimitsRequest, opts ...grpc.CallOption) (*AccountLimits, error)
+	SetInterestRates(ctx context.Context, in *InterestRates, opts ...grpc.CallOption) (*InterestRates, error)
+	ListInterestRates(ctx context.Context, in *ListInterestRatesRequest, opts ...grpc.CallOption) (*ListInterestRatesResponse, error)
+	ListInterestAccruals(ctx context.Context, in *ListInterestAccrualsRequest, opts ...grpc.CallOption) (*ListInterestAccrualsResponse, error)
+	SetTaxRule(ctx context.Context, in *TaxRule, opts ...grpc.CallOption) (*TaxRule, error)
+	ListTaxRules(ctx context.Context, in *ListTaxRulesRequest, opts ...grpc.CallOption) (*ListTaxRulesResponse, error)
+	SetTaxExemption(ctx context.Context, in *SetTaxExemptionRequest, opts ...grpc.CallOption) (*TaxExemption, error)
+	SetFeeSchedule(ctx context.Context, in *FeeSchedule, opts ...grpc.CallOption) (*FeeSchedule, error)
+	ListFeeSchedules(ctx context.Context, in *ListFeeSchedulesRequest, opts ...grpc.CallOption) (*ListFeeSchedulesResponse, error)
+	SetCustomerSegment(ctx context.Context, in *SetCustomerSegmentRequest, opts ...grpc.CallOption) (*CustomerSegment, error)
+	SetAccountCustomer(ctx context.Context, in *SetAccountCustomerRequest, opts ...grpc.CallOption) (*AccountCustomer, error)
+}
+
+type accountAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccountAdminServiceClient(cc grpc.ClientConnInterface) AccountAdminServiceClient {
+	return &accountAdminServiceClient{cc}
+}
+
+func (c *accountAdminServiceClient) SaveProduct(ctx context.Context, in *Product, opts ...grpc.CallOption) (*Product, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, AccountAdminService_SaveProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, AccountAdminService_ListProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) GetAccountLimits(ctx context.Context, in *GetAccountLimitsRequest, opts ...grpc.CallOption) (*AccountLimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountLimits)
+	err := c.cc.Invoke(ctx, AccountAdminService_GetAccountLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) SetAccountLimits(ctx context.Context, in *SetAccountLimitsRequest, opts ...grpc.CallOption) (*AccountLimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountLimits)
+	err := c.cc.Invoke(ctx, AccountAdminService_SetAccountLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) SetInterestRates(ctx context.Context, in *InterestRates, opts ...grpc.CallOption) (*InterestRates, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InterestRates)
+	err := c.cc.Invoke(ctx, AccountAdminService_SetInterestRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) ListInterestRates(ctx context.Context, in *ListInterestRatesRequest, opts ...grpc.CallOption) (*ListInterestRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInterestRatesResponse)
+	err := c.cc.Invoke(ctx, AccountAdminService_ListInterestRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) ListInterestAccruals(ctx context.Context, in *ListInterestAccrualsRequest, opts ...grpc.CallOption) (*ListInterestAccrualsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInterestAccrualsResponse)
+	err := c.cc.Invoke(ctx, AccountAdminService_ListInterestAccruals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) SetTaxRule(ctx context.Context, in *TaxRule, opts ...grpc.CallOption) (*TaxRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaxRule)
+	err := c.cc.Invoke(ctx, AccountAdminService_SetTaxRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) ListTaxRules(ctx context.Context, in *ListTaxRulesRequest, opts ...grpc.CallOption) (*ListTaxRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaxRulesResponse)
+	err := c.cc.Invoke(ctx, AccountAdminService_ListTaxRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) SetTaxExemption(ctx context.Context, in *SetTaxExemptionRequest, opts ...grpc.CallOption) (*TaxExemption, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaxExemption)
+	err := c.cc.Invoke(ctx, AccountAdminService_SetTaxExemption_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) SetFeeSchedule(ctx context.Context, in *FeeSchedule, opts ...grpc.CallOption) (*FeeSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeeSchedule)
+	err := c.cc.Invoke(ctx, AccountAdminService_SetFeeSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) ListFeeSchedules(ctx context.Context, in *ListFeeSchedulesRequest, opts ...grpc.CallOption) (*ListFeeSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeeSchedulesResponse)
+	err := c.cc.Invoke(ctx, AccountAdminService_ListFeeSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) SetCustomerSegment(ctx context.Context, in *SetCustomerSegmentRequest, opts ...grpc.CallOption) (*CustomerSegment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomerSegment)
+	err := c.cc.Invoke(ctx, AccountAdminService_SetCustomerSegment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountAdminServiceClient) SetAccountCustomer(ctx context.Context, in *SetAccountCustomerRequest, opts ...grpc.CallOption) (*AccountCustomer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountCustomer)
+	err := c.cc.Invoke(ctx, AccountAdminService_SetAccountCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccountAdminServiceServer is the server API for AccountAdminService service.
+// All implementations must embed UnimplementedAccountAdminServiceServer
+// for forward compatibility.
+//
+// AccountAdminService configures the products accounts belong to, the
+// limits a debit of an account has to respect, the interest products pay,
+// the tax withheld from it, the fees of transfers and the customers accounts
+// belong to.
+type AccountAdminServiceServer interface {
+	SaveProduct(context.Context, *Product) (*Product, error)
+	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	GetAccountLimits(context.Context, *GetAccountLimitsRequest) (*AccountLimits, error)
+	SetAccountLimits(context.Context, *SetAccountLimitsRequest) (*AccountLimits, error)
+	SetInterestRates(context.Context, *InterestRates) (*InterestRates, error)
+	ListInterestRates(context.Context, *ListInterestRatesRequest) (*ListInterestRatesResponse, error)
+	ListInterestAccruals(context.Context, *ListInterestAccrualsRequest) (*ListInterestAccrualsResponse, error)
+	SetTaxRule(context.Context, *TaxRule) (*TaxRule, error)
+	ListTaxRules(context.Context, *ListTaxRulesRequest) (*ListTaxRulesResponse, error)
+	SetTaxExemption(context.Context, *SetTaxExemptionRequest) (*TaxExemption, error)
+	SetFeeSchedule(context.Context, *FeeSchedule) (*FeeSchedule, error)
+	ListFeeSchedules(context.Context, *ListFeeSchedulesRequest) (*ListFeeSchedulesResponse, error)
+	SetCustomerSegment(context.Context, *SetCustomerSegmentRequest) (*CustomerSegment, error)
+	SetAccountCustomer(context.Context, *SetAccountCustomerRequest) (*AccountCustomer, error)
+	mustEmbedUnimplementedAccountAdminServiceServer()
+}
+
+// UnimplementedAccountAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAccountAdminServiceServer struct{}
+
+func (UnimplementedAccountAdminServiceServer) SaveProduct(context.Context, *Product) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveProduct not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) GetAccountLimits(context.Context, *GetAccountLimitsRequest) (*AccountLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountLimits not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) SetAccountLimits(context.Context, *SetAccountLimitsRequest) (*AccountLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountLimits not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) SetInterestRates(context.Context, *InterestRates) (*InterestRates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInterestRates not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) ListInterestRates(context.Context, *ListInterestRatesRequest) (*ListInterestRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInterestRates not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) ListInterestAccruals(context.Context, *ListInterestAccrualsRequest) (*ListInterestAccrualsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInterestAccruals not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) SetTaxRule(context.Context, *TaxRule) (*TaxRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTaxRule not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) ListTaxRules(context.Context, *ListTaxRulesRequest) (*ListTaxRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaxRules not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) SetTaxExemption(context.Context, *SetTaxExemptionRequest) (*TaxExemption, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTaxExemption not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) SetFeeSchedule(context.Context, *FeeSchedule) (*FeeSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeeSchedule not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) ListFeeSchedules(context.Context, *ListFeeSchedulesRequest) (*ListFeeSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeeSchedules not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) SetCustomerSegment(context.Context, *SetCustomerSegmentRequest) (*CustomerSegment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCustomerSegment not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) SetAccountCustomer(context.Context, *SetAccountCustomerRequest) (*AccountCustomer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountCustomer not implemented")
+}
+func (UnimplementedAccountAdminServiceServer) mustEmbedUnimplementedAccountAdminServiceServer() {}
+func (UnimplementedAccountAdminServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeAccountAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccountAdminServiceServer will
+// result in compilation errors.
+type UnsafeAccountAdminServiceServer interface {
+	mustEmbedUnimplementedAccountAdminServiceServer()
+}
+
+func RegisterAccountAdminServiceServer(s grpc.ServiceRegistrar, srv AccountAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAccountAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AccountAdminService_ServiceDesc, srv)
+}
+
+func _AccountAdminService_SaveProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Product)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).SaveProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_SaveProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).SaveProduct(ctx, req.(*Product))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_ListProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).ListProducts(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_GetAccountLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).GetAccountLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_GetAccountLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).GetAccountLimits(ctx, req.(*GetAccountLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_SetAccountLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccountLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).SetAccountLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_SetAccountLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).SetAccountLimits(ctx, req.(*SetAccountLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_SetInterestRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterestRates)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).SetInterestRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_SetInterestRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).SetInterestRates(ctx, req.(*InterestRates))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_ListInterestRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInterestRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).ListInterestRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_ListInterestRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).ListInterestRates(ctx, req.(*ListInterestRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_ListInterestAccruals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInterestAccrualsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).ListInterestAccruals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_ListInterestAccruals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).ListInterestAccruals(ctx, req.(*ListInterestAccrualsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_SetTaxRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaxRule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).SetTaxRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_SetTaxRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).SetTaxRule(ctx, req.(*TaxRule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_ListTaxRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaxRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).ListTaxRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_ListTaxRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).ListTaxRules(ctx, req.(*ListTaxRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_SetTaxExemption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTaxExemptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).SetTaxExemption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_SetTaxExemption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).SetTaxExemption(ctx, req.(*SetTaxExemptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_SetFeeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeeSchedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).SetFeeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_SetFeeSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).SetFeeSchedule(ctx, req.(*FeeSchedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_ListFeeSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeeSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).ListFeeSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_ListFeeSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).ListFeeSchedules(ctx, req.(*ListFeeSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_SetCustomerSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCustomerSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).SetCustomerSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_SetCustomerSegment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).SetCustomerSegment(ctx, req.(*SetCustomerSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountAdminService_SetAccountCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccountCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountAdminServiceServer).SetAccountCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountAdminService_SetAccountCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountAdminServiceServer).SetAccountCustomer(ctx, req.(*SetAccountCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AccountAdminService_ServiceDesc is the grpc.ServiceDesc for AccountAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AccountAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bank.AccountAdminService",
+	HandlerType: (*AccountAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SaveProduct",
+			Handler:    _AccountAdminService_SaveProduct_Handler,
+		},
+		{
+			MethodName: "ListProducts",
+			Handler:    _AccountAdminService_ListProducts_Handler,
+		},
+		{
+			MethodName: "GetAccountLimits",
+			Handler:    _AccountAdminService_GetAccountLimits_Handler,
+		},
+		{
+			MethodName: "SetAccountLimits",
+			Handler:    _AccountAdminService_SetAccountLimits_Handler,
+		},
+		{
+			MethodName: "SetInterestRates",
+			Handler:    _AccountAdminService_SetInterestRates_Handler,
+		},
+		{
+			MethodName: "ListInterestRates",
+			Handler:    _AccountAdminService_ListInterestRates_Handler,
+		},
+		{
+			MethodName: "ListInterestAccruals",
+			Handler:    _AccountAdminService_ListInterestAccruals_Handler,
+		},
+		{
+			MethodName: "SetTaxRule",
+			Handler:    _AccountAdminService_SetTaxRule_Handler,
+		},
+		{
+			MethodName: "ListTaxRules",
+			Handler:    _AccountAdminService_ListTaxRules_Handler,
+		},
+		{
+			MethodName: "SetTaxExemption",
+			Handler:    _AccountAdminService_SetTaxExemption_Handler,
+		},
+		{
+			MethodName: "SetFeeSchedule",
+			Handler:    _AccountAdminService_SetFeeSchedule_Handler,
+		},
+		{
+			MethodName: "ListFeeSchedules",
+			Handler:    _AccountAdminService_ListFeeSchedules_Handler,
+		},
+		{
+			MethodName: "SetCustomerSegment",
+			Handler:    _AccountAdminService_SetCustomerSegment_Handler,
+		},
+		{
+			MethodName: "SetAccountCustomer",
+			Handler:    _AccountAdminService_SetAccountCustomer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bank/account_admin.proto",
+}
@@ -0,0 +1,320 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: bank/scheduled_transfer.proto
+
+package bank
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScheduledTransferService_CreateScheduledTransfer_FullMethodName         = "/bank.ScheduledTransferService/CreateScheduledTransfer"
+	ScheduledTransferService_ListScheduledTransfers_FullMethodName          = "/bank.ScheduledTransferService/ListScheduledTransfers"
+	ScheduledTransferService_PauseScheduledTransfer_FullMethodName          = "/bank.ScheduledTransferService/PauseScheduledTransfer"
+	ScheduledTransferService_ResumeScheduledTransfer_FullMethodName         = "/bank.ScheduledTransferService/ResumeScheduledTransfer"
+	ScheduledTransferService_CancelScheduledTransfer_FullMethodName         = "/bank.ScheduledTransferService/CancelScheduledTransfer"
+	ScheduledTransferService_ListScheduledTransferExecutions_FullMethodName = "/bank.ScheduledTransferService/ListScheduledTransferExecutions"
+)
+
+// ScheduledTransferServiceClient is the client API for ScheduledTransferService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScheduledTransferService manages future-dated transfers and standing
+// orders. The server executes them when they are due.
+type ScheduledTransferServiceClient interface {
+	CreateScheduledTransfer(ctx context.Context, in *CreateScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	ListScheduledTransfers(ctx context.Context, in *ListScheduledTransfersRequest, opts ...grpc.CallOption) (*ListScheduledTransfersResponse, error)
+	PauseScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	// skips the occurrences missed while paused
+	ResumeScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	CancelScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error)
+	ListScheduledTransferExecutions(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ListScheduledTransferExecutionsResponse, error)
+}
+
+type scheduledTransferServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScheduledTransferServiceClient(cc grpc.ClientConnInterface) ScheduledTransferServiceClient {
+	return &scheduledTransferServiceClient{cc}
+}
+
+func (c *scheduledTransferServiceClient) CreateScheduledTransfer(ctx context.Context, in *CreateScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_CreateScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledTransferServiceClient) ListScheduledTransfers(ctx context.Context, in *ListScheduledTransfersRequest, opts ...grpc.CallOption) (*ListScheduledTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledTransfersResponse)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_ListScheduledTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledTransferServiceClient) PauseScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_PauseScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledTransferServiceClient) ResumeScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_ResumeScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledTransferServiceClient) CancelScheduledTransfer(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ScheduledTransfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTransfer)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_CancelScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledTransferServiceClient) ListScheduledTransferExecutions(ctx context.Context, in *ScheduledTransferRequest, opts ...grpc.CallOption) (*ListScheduledTransferExecutionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledTransferExecutionsResponse)
+	err := c.cc.Invoke(ctx, ScheduledTransferService_ListScheduledTransferExecutions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScheduledTransferServiceServer is the server API for ScheduledTransferService service.
+// All implementations must embed UnimplementedScheduledTransferServiceServer
+// for forward compatibility.
+//
+// ScheduledTransferService manages future-dated transfers and standing
+// orders. The server executes them when they are due.
+type ScheduledTransferServiceServer interface {
+	CreateScheduledTransfer(context.Context, *CreateScheduledTransferRequest) (*ScheduledTransfer, error)
+	ListScheduledTransfers(context.Context, *ListScheduledTransfersRequest) (*ListScheduledTransfersResponse, error)
+	PauseScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error)
+	// skips the occurrences missed while paused
+	ResumeScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error)
+	CancelScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error)
+	ListScheduledTransferExecutions(context.Context, *ScheduledTransferRequest) (*ListScheduledTransferExecutionsResponse, error)
+	mustEmbedUnimplementedScheduledTransferServiceServer()
+}
+
+// UnimplementedScheduledTransferServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScheduledTransferServiceServer struct{}
+
+func (UnimplementedScheduledTransferServiceServer) CreateScheduledTransfer(context.Context, *CreateScheduledTransferRequest) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScheduledTransfer not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) ListScheduledTransfers(context.Context, *ListScheduledTransfersRequest) (*ListScheduledTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTransfers not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) PauseScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseScheduledTransfer not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) ResumeScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeScheduledTransfer not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) CancelScheduledTransfer(context.Context, *ScheduledTransferRequest) (*ScheduledTransfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledTransfer not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) ListScheduledTransferExecutions(context.Context, *ScheduledTransferRequest) (*ListScheduledTransferExecutionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTransferExecutions not implemented")
+}
+func (UnimplementedScheduledTransferServiceServer) mustEmbedUnimplementedScheduledTransferServiceServer() {
+}
+func (UnimplementedScheduledTransferServiceServer) testEmbeddedByValue() {}
+
+// UnsafeScheduledTransferServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScheduledTransferServiceServer will
+// result in compilation errors.
+type UnsafeScheduledTransferServiceServer interface {
+	mustEmbedUnimplementedScheduledTransferServiceServer()
+}
+
+func RegisterScheduledTransferServiceServer(s grpc.ServiceRegistrar, srv ScheduledTransferServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScheduledTransferServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScheduledTransferService_ServiceDesc, srv)
+}
+
+func _ScheduledTransferService_CreateScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).CreateScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_CreateScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).CreateScheduledTransfer(ctx, req.(*CreateScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledTransferService_ListScheduledTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).ListScheduledTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_ListScheduledTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).ListScheduledTransfers(ctx, req.(*ListScheduledTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledTransferService_PauseScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).PauseScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_PauseScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).PauseScheduledTransfer(ctx, req.(*ScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledTransferService_ResumeScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).ResumeScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_ResumeScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).ResumeScheduledTransfer(ctx, req.(*ScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledTransferService_CancelScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).CancelScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_CancelScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).CancelScheduledTransfer(ctx, req.(*ScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledTransferService_ListScheduledTransferExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledTransferServiceServer).ListScheduledTransferExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledTransferService_ListScheduledTransferExecutions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledTransferServiceServer).ListScheduledTransferExecutions(ctx, req.(*ScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScheduledTransferService_ServiceDesc is the grpc.ServiceDesc for ScheduledTransferService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScheduledTransferService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bank.ScheduledTransferService",
+	HandlerType: (*ScheduledTransferServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateScheduledTransfer",
+			Handler:    _ScheduledTransferService_CreateScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "ListScheduledTransfers",
+			Handler:    _ScheduledTransferService_ListScheduledTransfers_Handler,
+		},
+		{
+			MethodName: "PauseScheduledTransfer",
+			Handler:    _ScheduledTransferService_PauseScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "ResumeScheduledTransfer",
+			Handler:    _ScheduledTransferService_ResumeScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "CancelScheduledTransfer",
+			Handler:    _ScheduledTransferService_CancelScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "ListScheduledTransferExecutions",
+			Handler:    _ScheduledTransferService_ListScheduledTransferExecutions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bank/scheduled_transfer.proto",
+}
@@ -0,0 +1,188 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.12.4
+// source: bank/service.proto
+
+package bank
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_bank_service_proto protoreflect.FileDescriptor
+
+var file_bank_service_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x62, 0x61, 0x6e, 0x6b, 0x1a, 0x17, 0x62, 0x61, 0x6e, 0x6b,
+	0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x62,
+	0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79,
+	0x70, 0x65, 0x2f, 0x68, 0x6f, 0x6c, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x62,
+	0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x62, 0x61, 0x6e, 0x6b,
+	0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x32, 0x91, 0x07, 0x0a, 0x0b, 0x42, 0x61, 0x6e, 0x6b, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x2e, 0x62, 0x61, 0x6e, 0x6b,
+	0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x12, 0x46, 0x65, 0x74, 0x63, 0x68, 0x45,
+	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x45,
+	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x15, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x69, 0x7a, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x11, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x1a, 0x18, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x22, 0x00, 0x28,
+	0x01, 0x12, 0x47, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3d, 0x0a, 0x0d, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x62, 0x61,
+	0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x18, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x50,
+	0x0a, 0x0f, 0x52, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x12, 0x1c, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x39, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12,
+	0x18, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x62, 0x61, 0x6e, 0x6b,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0d, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x48, 0x6f, 0x6c, 0x64, 0x12, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a,
+	0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x07,
+	0x47, 0x65, 0x74, 0x48, 0x6f, 0x6c, 0x64, 0x12, 0x14, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x47,
+	0x65, 0x74, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e,
+	0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0b, 0x43,
+	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x48, 0x6f, 0x6c, 0x64, 0x12, 0x18, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x43, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x35, 0x0a, 0x0b, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x48, 0x6f, 0x6c, 0x64,
+	0x12, 0x18, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x48,
+	0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x22, 0x00, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x6a, 0x61, 0x72, 0x61, 0x6d, 0x61, 0x75,
+	0x6c, 0x61, 0x6e, 0x61, 0x2f, 0x67, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x6d, 0x69, 0x63,
+	0x72, 0x6f, 0x2d, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_bank_service_proto_goTypes = []any{
+	(*CurrentBalanceRequest)(nil),   // 0: bank.CurrentBalanceRequest
+	(*ExchangeRateRequest)(nil),     // 1: bank.ExchangeRateRequest
+	(*Transaction)(nil),             // 2: bank.Transaction
+	(*TransferRequest)(nil),         // 3: bank.TransferRequest
+	(*AccountActivityRequest)(nil),  // 4: bank.AccountActivityRequest
+	(*ReverseTransferRequest)(nil),  // 5: bank.ReverseTransferRequest
+	(*GetTransferRequest)(nil),      // 6: bank.GetTransferRequest
+	(*ListTransfersRequest)(nil),    // 7: bank.ListTransfersRequest
+	(*CreateHoldRequest)(nil),       // 8: bank.CreateHoldRequest
+	(*GetHoldRequest)(nil),          // 9: bank.GetHoldRequest
+	(*CaptureHoldRequest)(nil),      // 10: bank.CaptureHoldRequest
+	(*ReleaseHoldRequest)(nil),      // 11: bank.ReleaseHoldRequest
+	(*CurrentBalanceResponse)(nil),  // 12: bank.CurrentBalanceResponse
+	(*ExchangeRateResponse)(nil),    // 13: bank.ExchangeRateResponse
+	(*TransactionSummary)(nil),      // 14: bank.TransactionSummary
+	(*TransferResponse)(nil),        // 15: bank.TransferResponse
+	(*TransferQuote)(nil),           // 16: bank.TransferQuote
+	(*AccountActivity)(nil),         // 17: bank.AccountActivity
+	(*ReverseTransferResponse)(nil), // 18: bank.ReverseTransferResponse
+	(*Transfer)(nil),                // 19: bank.Transfer
+	(*ListTransfersResponse)(nil),   // 20: bank.ListTransfersResponse
+	(*Hold)(nil),                    // 21: bank.Hold
+	(*CaptureHoldResponse)(nil),     // 22: bank.CaptureHoldResponse
+}
+var file_bank_service_proto_depIdxs = []int32{
+	0,  // 0: bank.BankService.GetCurrentBalance:input_type -> bank.CurrentBalanceRequest
+	1,  // 1: bank.BankService.FetchExchangeRates:input_type -> bank.ExchangeRateRequest
+	2,  // 2: bank.BankService.SummarizeTransactions:input_type -> bank.Transaction
+	3,  // 3: bank.BankService.TransferMultiple:input_type -> bank.TransferRequest
+	3,  // 4: bank.BankService.QuoteTransfer:input_type -> bank.TransferRequest
+	4,  // 5: bank.BankService.SubscribeAccountActivity:input_type -> bank.AccountActivityRequest
+	5,  // 6: bank.BankService.ReverseTransfer:input_type -> bank.ReverseTransferRequest
+	6,  // 7: bank.BankService.GetTransfer:input_type -> bank.GetTransferRequest
+	7,  // 8: bank.BankService.ListTransfers:input_type -> bank.ListTransfersRequest
+	8,  // 9: bank.BankService.CreateHold:input_type -> bank.CreateHoldRequest
+	9,  // 10: bank.BankService.GetHold:input_type -> bank.GetHoldRequest
+	10, // 11: bank.BankService.CaptureHold:input_type -> bank.CaptureHoldRequest
+	11, // 12: bank.BankService.ReleaseHold:input_type -> bank.ReleaseHoldRequest
+	12, // 13: bank.BankService.GetCurrentBalance:output_type -> bank.CurrentBalanceResponse
+	13, // 14: bank.BankService.FetchExchangeRates:output_type -> bank.ExchangeRateResponse
+	14, // 15: bank.BankService.SummarizeTransactions:output_type -> bank.TransactionSummary
+	15, // 16: bank.BankService.TransferMultiple:output_type -> bank.TransferResponse
+	16, // 17: bank.BankService.QuoteTransfer:output_type -> bank.TransferQuote
+	17, // 18: bank.BankService.SubscribeAccountActivity:output_type -> bank.AccountActivity
+	18, // 19: bank.BankService.ReverseTransfer:output_type -> bank.ReverseTransferResponse
+	19, // 20: bank.BankService.GetTransfer:output_type -> bank.Transfer
+	20, // 21: bank.BankService.ListTransfers:output_type -> bank.ListTransfersResponse
+	21, // 22: bank.BankService.CreateHold:output_type -> bank.Hold
+	21, // 23: bank.BankService.GetHold:output_type -> bank.Hold
+	22, // 24: bank.BankService.CaptureHold:output_type -> bank.CaptureHoldResponse
+	21, // 25: bank.BankService.ReleaseHold:output_type -> bank.Hold
+	13, // [13:26] is the sub-list for method output_type
+	0,  // [0:13] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_bank_service_proto_init() }
+func file_bank_service_proto_init() {
+	if File_bank_service_proto != nil {
+		return
+	}
+	file_bank_type_account_proto_init()
+	file_bank_type_activity_proto_init()
+	file_bank_type_exchange_proto_init()
+	file_bank_type_hold_proto_init()
+	file_bank_type_transaction_proto_init()
+	file_bank_type_transfer_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bank_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bank_service_proto_goTypes,
+		DependencyIndexes: file_bank_service_proto_depIdxs,
+	}.Build()
+	File_bank_service_proto = out.File
+	file_bank_service_proto_rawDesc = nil
+	file_bank_service_proto_goTypes = nil
+	file_bank_service_proto_depIdxs = nil
+}
@@ -0,0 +1,566 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.12.4
+// source: bank/statement.proto
+
+package bank
+
+import (
+	datetime "google.golang.org/genproto/googleapis/type/datetime"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StatementFormat int32
+
+const (
+	StatementFormat_STATEMENT_FORMAT_UNSPECIFIED StatementFormat = 0
+	// a row per transaction with the balance after it, between an opening
+	// and a closing balance row
+	StatementFormat_STATEMENT_FORMAT_CSV StatementFormat = 1
+	// OFX 2.2 bank statement response
+	StatementFormat_STATEMENT_FORMAT_OFX StatementFormat = 2
+	// ISO 20022 BankToCustomerStatement, camt.053.001.02
+	StatementFormat_STATEMENT_FORMAT_CAMT_053 StatementFormat = 3
+)
+
+// Enum value maps for StatementFormat.
+var (
+	StatementFormat_name = map[int32]string{
+		0: "STATEMENT_FORMAT_UNSPECIFIED",
+		1: "STATEMENT_FORMAT_CSV",
+		2: "STATEMENT_FORMAT_OFX",
+		3: "STATEMENT_FORMAT_CAMT_053",
+	}
+	StatementFormat_value = map[string]int32{
+		"STATEMENT_FORMAT_UNSPECIFIED": 0,
+		"STATEMENT_FORMAT_CSV":         1,
+		"STATEMENT_FORMAT_OFX":         2,
+		"STATEMENT_FORMAT_CAMT_053":    3,
+	}
+)
+
+func (x StatementFormat) Enum() *StatementFormat {
+	p := new(StatementFormat)
+	*p = x
+	return p
+}
+
+func (x StatementFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatementFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_bank_statement_proto_enumTypes[0].Descriptor()
+}
+
+func (StatementFormat) Type() protoreflect.EnumType {
+	return &file_bank_statement_proto_enumTypes[0]
+}
+
+func (x StatementFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatementFormat.Descriptor instead.
+func (StatementFormat) EnumDescriptor() ([]byte, []int) {
+	return file_bank_statement_proto_rawDescGZIP(), []int{0}
+}
+
+type StatementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string          `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	Format        StatementFormat `protobuf:"varint,2,opt,name=format,proto3,enum=bank.StatementFormat" json:"format,omitempty"`
+	// the period, from_time inclusive and to_time exclusive. A period
+	// without an end or ending later than now ends now.
+	FromTime *datetime.DateTime `protobuf:"bytes,3,opt,name=from_time,proto3" json:"from_time,omitempty"`
+	ToTime   *datetime.DateTime `protobuf:"bytes,4,opt,name=to_time,proto3" json:"to_time,omitempty"`
+}
+
+func (x *StatementRequest) Reset() {
+	*x = StatementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_statement_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementRequest) ProtoMessage() {}
+
+func (x *StatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_statement_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementRequest.ProtoReflect.Descriptor instead.
+func (*StatementRequest) Descriptor() ([]byte, []int) {
+	return file_bank_statement_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StatementRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *StatementRequest) GetFormat() StatementFormat {
+	if x != nil {
+		return x.Format
+	}
+	return StatementFormat_STATEMENT_FORMAT_UNSPECIFIED
+}
+
+func (x *StatementRequest) GetFromTime() *datetime.DateTime {
+	if x != nil {
+		return x.FromTime
+	}
+	return nil
+}
+
+func (x *StatementRequest) GetToTime() *datetime.DateTime {
+	if x != nil {
+		return x.ToTime
+	}
+	return nil
+}
+
+type StatementChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *StatementChunk) Reset() {
+	*x = StatementChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_statement_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatementChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementChunk) ProtoMessage() {}
+
+func (x *StatementChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_statement_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementChunk.ProtoReflect.Descriptor instead.
+func (*StatementChunk) Descriptor() ([]byte, []int) {
+	return file_bank_statement_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StatementChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type StatementPdfRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountNumber string `protobuf:"bytes,1,opt,name=account_number,proto3" json:"account_number,omitempty"`
+	// the period, as in StatementRequest
+	FromTime *datetime.DateTime `protobuf:"bytes,2,opt,name=from_time,proto3" json:"from_time,omitempty"`
+	ToTime   *datetime.DateTime `protobuf:"bytes,3,opt,name=to_time,proto3" json:"to_time,omitempty"`
+}
+
+func (x *StatementPdfRequest) Reset() {
+	*x = StatementPdfRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_statement_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatementPdfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementPdfRequest) ProtoMessage() {}
+
+func (x *StatementPdfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_statement_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementPdfRequest.ProtoReflect.Descriptor instead.
+func (*StatementPdfRequest) Descriptor() ([]byte, []int) {
+	return file_bank_statement_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StatementPdfRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *StatementPdfRequest) GetFromTime() *datetime.DateTime {
+	if x != nil {
+		return x.FromTime
+	}
+	return nil
+}
+
+func (x *StatementPdfRequest) GetToTime() *datetime.DateTime {
+	if x != nil {
+		return x.ToTime
+	}
+	return nil
+}
+
+type TransferReceiptRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferId string `protobuf:"bytes,1,opt,name=transfer_id,proto3" json:"transfer_id,omitempty"`
+}
+
+func (x *TransferReceiptRequest) Reset() {
+	*x = TransferReceiptRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_statement_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferReceiptRequest) ProtoMessage() {}
+
+func (x *TransferReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_statement_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferReceiptRequest.ProtoReflect.Descriptor instead.
+func (*TransferReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_bank_statement_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransferReceiptRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+type TransferReceipt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferId string `protobuf:"bytes,1,opt,name=transfer_id,proto3" json:"transfer_id,omitempty"`
+	Pdf        []byte `protobuf:"bytes,2,opt,name=pdf,proto3" json:"pdf,omitempty"`
+}
+
+func (x *TransferReceipt) Reset() {
+	*x = TransferReceipt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_statement_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferReceipt) ProtoMessage() {}
+
+func (x *TransferReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_statement_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferReceipt.ProtoReflect.Descriptor instead.
+func (*TransferReceipt) Descriptor() ([]byte, []int) {
+	return file_bank_statement_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransferReceipt) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *TransferReceipt) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+var File_bank_statement_proto protoreflect.FileDescriptor
+
+var file_bank_statement_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x62, 0x61, 0x6e, 0x6b, 0x1a, 0x1a, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x74, 0x69,
+	0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcf, 0x01, 0x0a, 0x10, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a,
+	0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x33, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x09,
+	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x74, 0x6f, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x52, 0x07, 0x74, 0x6f, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x24, 0x0a, 0x0e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0xa3, 0x01, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x64,
+	0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x33, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x07, 0x74,
+	0x6f, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x3a, 0x0a, 0x16, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x22, 0x45, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x64, 0x66, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x70, 0x64, 0x66, 0x2a, 0x86, 0x01, 0x0a, 0x0f, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x20, 0x0a,
+	0x1c, 0x53, 0x54, 0x41, 0x54, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41,
+	0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x18, 0x0a, 0x14, 0x53, 0x54, 0x41, 0x54, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x46, 0x4f, 0x52,
+	0x4d, 0x41, 0x54, 0x5f, 0x43, 0x53, 0x56, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x4f, 0x46,
+	0x58, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x54, 0x41, 0x54, 0x45, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x43, 0x41, 0x4d, 0x54, 0x5f, 0x30, 0x35, 0x33,
+	0x10, 0x03, 0x32, 0xee, 0x01, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x46,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x64,
+	0x66, 0x12, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x50, 0x64, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4b, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x1c, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x61, 0x6e,
+	0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70,
+	0x74, 0x22, 0x00, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x66, 0x61, 0x6a, 0x61, 0x72, 0x61, 0x6d, 0x61, 0x75, 0x6c, 0x61, 0x6e, 0x61, 0x2f,
+	0x67, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x2d, 0x62, 0x61,
+	0x6e, 0x6b, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65,
+	0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_bank_statement_proto_rawDescOnce sync.Once
+	file_bank_statement_proto_rawDescData = file_bank_statement_proto_rawDesc
+)
+
+func file_bank_statement_proto_rawDescGZIP() []byte {
+	file_bank_statement_proto_rawDescOnce.Do(func() {
+		file_bank_statement_proto_rawDescData = protoimpl.X.CompressGZIP(file_bank_statement_proto_rawDescData)
+	})
+	return file_bank_statement_proto_rawDescData
+}
+
+var file_bank_statement_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_bank_statement_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_bank_statement_proto_goTypes = []any{
+	(StatementFormat)(0),           // 0: bank.StatementFormat
+	(*StatementRequest)(nil),       // 1: bank.StatementRequest
+	(*StatementChunk)(nil),         // 2: bank.StatementChunk
+	(*StatementPdfRequest)(nil),    // 3: bank.StatementPdfRequest
+	(*TransferReceiptRequest)(nil), // 4: bank.TransferReceiptRequest
+	(*TransferReceipt)(nil),        // 5: bank.TransferReceipt
+	(*datetime.DateTime)(nil),      // 6: google.type.DateTime
+}
+var file_bank_statement_proto_depIdxs = []int32{
+	0, // 0: bank.StatementRequest.format:type_name -> bank.StatementFormat
+	6, // 1: bank.StatementRequest.from_time:type_name -> google.type.DateTime
+	6, // 2: bank.StatementRequest.to_time:type_name -> google.type.DateTime
+	6, // 3: bank.StatementPdfRequest.from_time:type_name -> google.type.DateTime
+	6, // 4: bank.StatementPdfRequest.to_time:type_name -> google.type.DateTime
+	1, // 5: bank.StatementService.GenerateStatement:input_type -> bank.StatementRequest
+	3, // 6: bank.StatementService.GetStatementPdf:input_type -> bank.StatementPdfRequest
+	4, // 7: bank.StatementService.GetTransferReceipt:input_type -> bank.TransferReceiptRequest
+	2, // 8: bank.StatementService.GenerateStatement:output_type -> bank.StatementChunk
+	2, // 9: bank.StatementService.GetStatementPdf:output_type -> bank.StatementChunk
+	5, // 10: bank.StatementService.GetTransferReceipt:output_type -> bank.TransferReceipt
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_bank_statement_proto_init() }
+func file_bank_statement_proto_init() {
+	if File_bank_statement_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_bank_statement_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*StatementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_statement_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*StatementChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_statement_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*StatementPdfRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_statement_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*TransferReceiptRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_statement_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*TransferReceipt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bank_statement_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bank_statement_proto_goTypes,
+		DependencyIndexes: file_bank_statement_proto_depIdxs,
+		EnumInfos:         file_bank_statement_proto_enumTypes,
+		MessageInfos:      file_bank_statement_proto_msgTypes,
+	}.Build()
+	File_bank_statement_proto = out.File
+	file_bank_statement_proto_rawDesc = nil
+	file_bank_statement_proto_goTypes = nil
+	file_bank_statement_proto_depIdxs = nil
+}
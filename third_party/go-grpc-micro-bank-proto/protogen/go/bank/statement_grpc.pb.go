@@ -0,0 +1,216 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: bank/statement.proto
+
+package bank
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StatementService_GenerateStatement_FullMethodName  = "/bank.StatementService/GenerateStatement"
+	StatementService_GetStatementPdf_FullMethodName    = "/bank.StatementService/GetStatementPdf"
+	StatementService_GetTransferReceipt_FullMethodName = "/bank.StatementService/GetTransferReceipt"
+)
+
+// StatementServiceClient is the client API for StatementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StatementService renders the statements of accounts.
+type StatementServiceClient interface {
+	// the statement of an account for a period in chunks, with the opening
+	// and closing balances and every transaction posted in between
+	GenerateStatement(ctx context.Context, in *StatementRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatementChunk], error)
+	// the printable statement of an account for a period in chunks of a PDF
+	GetStatementPdf(ctx context.Context, in *StatementPdfRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatementChunk], error)
+	// the printable receipt of a transfer, a one page PDF
+	GetTransferReceipt(ctx context.Context, in *TransferReceiptRequest, opts ...grpc.CallOption) (*TransferReceipt, error)
+}
+
+type statementServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatementServiceClient(cc grpc.ClientConnInterface) StatementServiceClient {
+	return &statementServiceClient{cc}
+}
+
+func (c *statementServiceClient) GenerateStatement(ctx context.Context, in *StatementRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatementChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StatementService_ServiceDesc.Streams[0], StatementService_GenerateStatement_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StatementRequest, StatementChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatementService_GenerateStatementClient = grpc.ServerStreamingClient[StatementChunk]
+
+func (c *statementServiceClient) GetStatementPdf(ctx context.Context, in *StatementPdfRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatementChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StatementService_ServiceDesc.Streams[1], StatementService_GetStatementPdf_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StatementPdfRequest, StatementChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatementService_GetStatementPdfClient = grpc.ServerStreamingClient[StatementChunk]
+
+func (c *statementServiceClient) GetTransferReceipt(ctx context.Context, in *TransferReceiptRequest, opts ...grpc.CallOption) (*TransferReceipt, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferReceipt)
+	err := c.cc.Invoke(ctx, StatementService_GetTransferReceipt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatementServiceServer is the server API for StatementService service.
+// All implementations must embed UnimplementedStatementServiceServer
+// for forward compatibility.
+//
+// StatementService renders the statements of accounts.
+type StatementServiceServer interface {
+	// the statement of an account for a period in chunks, with the opening
+	// and closing balances and every transaction posted in between
+	GenerateStatement(*StatementRequest, grpc.ServerStreamingServer[StatementChunk]) error
+	// the printable statement of an account for a period in chunks of a PDF
+	GetStatementPdf(*StatementPdfRequest, grpc.ServerStreamingServer[StatementChunk]) error
+	// the printable receipt of a transfer, a one page PDF
+	GetTransferReceipt(context.Context, *TransferReceiptRequest) (*TransferReceipt, error)
+	mustEmbedUnimplementedStatementServiceServer()
+}
+
+// UnimplementedStatementServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatementServiceServer struct{}
+
+func (UnimplementedStatementServiceServer) GenerateStatement(*StatementRequest, grpc.ServerStreamingServer[StatementChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateStatement not implemented")
+}
+func (UnimplementedStatementServiceServer) GetStatementPdf(*StatementPdfRequest, grpc.ServerStreamingServer[StatementChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetStatementPdf not implemented")
+}
+func (UnimplementedStatementServiceServer) GetTransferReceipt(context.Context, *TransferReceiptRequest) (*TransferReceipt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferReceipt not implemented")
+}
+func (UnimplementedStatementServiceServer) mustEmbedUnimplementedStatementServiceServer() {}
+func (UnimplementedStatementServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeStatementServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatementServiceServer will
+// result in compilation errors.
+type UnsafeStatementServiceServer interface {
+	mustEmbedUnimplementedStatementServiceServer()
+}
+
+func RegisterStatementServiceServer(s grpc.ServiceRegistrar, srv StatementServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStatementServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatementService_ServiceDesc, srv)
+}
+
+func _StatementService_GenerateStatement_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatementRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatementServiceServer).GenerateStatement(m, &grpc.GenericServerStream[StatementRequest, StatementChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatementService_GenerateStatementServer = grpc.ServerStreamingServer[StatementChunk]
+
+func _StatementService_GetStatementPdf_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatementPdfRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatementServiceServer).GetStatementPdf(m, &grpc.GenericServerStream[StatementPdfRequest, StatementChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatementService_GetStatementPdfServer = grpc.ServerStreamingServer[StatementChunk]
+
+func _StatementService_GetTransferReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).GetTransferReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_GetTransferReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).GetTransferReceipt(ctx, req.(*TransferReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatementService_ServiceDesc is the grpc.ServiceDesc for StatementService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatementService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bank.StatementService",
+	HandlerType: (*StatementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTransferReceipt",
+			Handler:    _StatementService_GetTransferReceipt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateStatement",
+			Handler:       _StatementService_GenerateStatement_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetStatementPdf",
+			Handler:       _StatementService_GetStatementPdf_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bank/statement.proto",
+}
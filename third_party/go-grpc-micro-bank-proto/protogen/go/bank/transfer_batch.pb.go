@@ -0,0 +1,944 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.12.4
+// source: bank/transfer_batch.proto
+
+package bank
+
+import (
+	datetime "google.golang.org/genproto/googleapis/type/datetime"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TransferBatchFormat int32
+
+const (
+	TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED TransferBatchFormat = 0
+	// a header naming the columns creditor_account, amount, currency and
+	// optionally end_to_end_id, debtor_account and remittance_information
+	TransferBatchFormat_TRANSFER_BATCH_FORMAT_CSV TransferBatchFormat = 1
+	// ISO 20022 CustomerCreditTransferInitiation
+	TransferBatchFormat_TRANSFER_BATCH_FORMAT_PAIN_001 TransferBatchFormat = 2
+)
+
+// Enum value maps for TransferBatchFormat.
+var (
+	TransferBatchFormat_name = map[int32]string{
+		0: "TRANSFER_BATCH_FORMAT_UNSPECIFIED",
+		1: "TRANSFER_BATCH_FORMAT_CSV",
+		2: "TRANSFER_BATCH_FORMAT_PAIN_001",
+	}
+	TransferBatchFormat_value = map[string]int32{
+		"TRANSFER_BATCH_FORMAT_UNSPECIFIED": 0,
+		"TRANSFER_BATCH_FORMAT_CSV":         1,
+		"TRANSFER_BATCH_FORMAT_PAIN_001":    2,
+	}
+)
+
+func (x TransferBatchFormat) Enum() *TransferBatchFormat {
+	p := new(TransferBatchFormat)
+	*p = x
+	return p
+}
+
+func (x TransferBatchFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferBatchFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_bank_transfer_batch_proto_enumTypes[0].Descriptor()
+}
+
+func (TransferBatchFormat) Type() protoreflect.EnumType {
+	return &file_bank_transfer_batch_proto_enumTypes[0]
+}
+
+func (x TransferBatchFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferBatchFormat.Descriptor instead.
+func (TransferBatchFormat) EnumDescriptor() ([]byte, []int) {
+	return file_bank_transfer_batch_proto_rawDescGZIP(), []int{0}
+}
+
+type TransferBatchMode int32
+
+const (
+	// ALL_OR_NOTHING
+	TransferBatchMode_TRANSFER_BATCH_MODE_UNSPECIFIED TransferBatchMode = 0
+	// executed only when every line is valid, the transfers done are
+	// reversed when one fails
+	TransferBatchMode_TRANSFER_BATCH_MODE_ALL_OR_NOTHING TransferBatchMode = 1
+	// the valid lines are executed, each failing on its own
+	TransferBatchMode_TRANSFER_BATCH_MODE_BEST_EFFORT TransferBatchMode = 2
+)
+
+// Enum value maps for TransferBatchMode.
+var (
+	TransferBatchMode_name = map[int32]string{
+		0: "TRANSFER_BATCH_MODE_UNSPECIFIED",
+		1: "TRANSFER_BATCH_MODE_ALL_OR_NOTHING",
+		2: "TRANSFER_BATCH_MODE_BEST_EFFORT",
+	}
+	TransferBatchMode_value = map[string]int32{
+		"TRANSFER_BATCH_MODE_UNSPECIFIED":    0,
+		"TRANSFER_BATCH_MODE_ALL_OR_NOTHING": 1,
+		"TRANSFER_BATCH_MODE_BEST_EFFORT":    2,
+	}
+)
+
+func (x TransferBatchMode) Enum() *TransferBatchMode {
+	p := new(TransferBatchMode)
+	*p = x
+	return p
+}
+
+func (x TransferBatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferBatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_bank_transfer_batch_proto_enumTypes[1].Descriptor()
+}
+
+func (TransferBatchMode) Type() protoreflect.EnumType {
+	return &file_bank_transfer_batch_proto_enumTypes[1]
+}
+
+func (x TransferBatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferBatchMode.Descriptor instead.
+func (TransferBatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_bank_transfer_batch_proto_rawDescGZIP(), []int{1}
+}
+
+type TransferBatchStatus int32
+
+const (
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_UNSPECIFIED TransferBatchStatus = 0
+	// failed validation, nothing was executed
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_REJECTED   TransferBatchStatus = 1
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_ACCEPTED   TransferBatchStatus = 2
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_PROCESSING TransferBatchStatus = 3
+	// a transfer of an all or nothing batch failed, the ones done are
+	// being reversed
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_ROLLING_BACK        TransferBatchStatus = 4
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_COMPLETED           TransferBatchStatus = 5
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_PARTIALLY_COMPLETED TransferBatchStatus = 6
+	TransferBatchStatus_TRANSFER_BATCH_STATUS_FAILED              TransferBatchStatus = 7
+)
+
+// Enum value maps for TransferBatchStatus.
+var (
+	TransferBatchStatus_name = map[int32]string{
+		0: "TRANSFER_BATCH_STATUS_UNSPECIFIED",
+		1: "TRANSFER_BATCH_STATUS_REJECTED",
+		2: "TRANSFER_BATCH_STATUS_ACCEPTED",
+		3: "TRANSFER_BATCH_STATUS_PROCESSING",
+		4: "TRANSFER_BATCH_STATUS_ROLLING_BACK",
+		5: "TRANSFER_BATCH_STATUS_COMPLETED",
+		6: "TRANSFER_BATCH_STATUS_PARTIALLY_COMPLETED",
+		7: "TRANSFER_BATCH_STATUS_FAILED",
+	}
+	TransferBatchStatus_value = map[string]int32{
+		"TRANSFER_BATCH_STATUS_UNSPECIFIED":         0,
+		"TRANSFER_BATCH_STATUS_REJECTED":            1,
+		"TRANSFER_BATCH_STATUS_ACCEPTED":            2,
+		"TRANSFER_BATCH_STATUS_PROCESSING":          3,
+		"TRANSFER_BATCH_STATUS_ROLLING_BACK":        4,
+		"TRANSFER_BATCH_STATUS_COMPLETED":           5,
+		"TRANSFER_BATCH_STATUS_PARTIALLY_COMPLETED": 6,
+		"TRANSFER_BATCH_STATUS_FAILED":              7,
+	}
+)
+
+func (x TransferBatchStatus) Enum() *TransferBatchStatus {
+	p := new(TransferBatchStatus)
+	*p = x
+	return p
+}
+
+func (x TransferBatchStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferBatchStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_bank_transfer_batch_proto_enumTypes[2].Descriptor()
+}
+
+func (TransferBatchStatus) Type() protoreflect.EnumType {
+	return &file_bank_transfer_batch_proto_enumTypes[2]
+}
+
+func (x TransferBatchStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferBatchStatus.Descriptor instead.
+func (TransferBatchStatus) EnumDescriptor() ([]byte, []int) {
+	return file_bank_transfer_batch_proto_rawDescGZIP(), []int{2}
+}
+
+type TransferBatchMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Format TransferBatchFormat `protobuf:"varint,1,opt,name=format,proto3,enum=bank.TransferBatchFormat" json:"format,omitempty"`
+	Mode   TransferBatchMode   `protobuf:"varint,2,opt,name=mode,proto3,enum=bank.TransferBatchMode" json:"mode,omitempty"`
+	// identifies the file, a file is imported once. The MsgId of a pain.001
+	// file, or a digest of the file, when empty.
+	MessageId string `protobuf:"bytes,3,opt,name=message_id,proto3" json:"message_id,omitempty"`
+	// sender of the lines that name none
+	DebtorAccountNumber string `protobuf:"bytes,4,opt,name=debtor_account_number,proto3" json:"debtor_account_number,omitempty"`
+	Channel             string `protobuf:"bytes,5,opt,name=channel,proto3" json:"channel,omitempty"`
+	// checked against the file when set
+	NumberOfTransactions *int32   `protobuf:"varint,6,opt,name=number_of_transactions,proto3,oneof" json:"number_of_transactions,omitempty"`
+	ControlSum           *float64 `protobuf:"fixed64,7,opt,name=control_sum,proto3,oneof" json:"control_sum,omitempty"`
+}
+
+func (x *TransferBatchMetadata) Reset() {
+	*x = TransferBatchMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_transfer_batch_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferBatchMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchMetadata) ProtoMessage() {}
+
+func (x *TransferBatchMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_transfer_batch_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchMetadata.ProtoReflect.Descriptor instead.
+func (*TransferBatchMetadata) Descriptor() ([]byte, []int) {
+	return file_bank_transfer_batch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransferBatchMetadata) GetFormat() TransferBatchFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED
+}
+
+func (x *TransferBatchMetadata) GetMode() TransferBatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TransferBatchMode_TRANSFER_BATCH_MODE_UNSPECIFIED
+}
+
+func (x *TransferBatchMetadata) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *TransferBatchMetadata) GetDebtorAccountNumber() string {
+	if x != nil {
+		return x.DebtorAccountNumber
+	}
+	return ""
+}
+
+func (x *TransferBatchMetadata) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *TransferBatchMetadata) GetNumberOfTransactions() int32 {
+	if x != nil && x.NumberOfTransactions != nil {
+		return *x.NumberOfTransactions
+	}
+	return 0
+}
+
+func (x *TransferBatchMetadata) GetControlSum() float64 {
+	if x != nil && x.ControlSum != nil {
+		return *x.ControlSum
+	}
+	return 0
+}
+
+type UploadTransferBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Content:
+	//	*UploadTransferBatchRequest_Metadata
+	//	*UploadTransferBatchRequest_Chunk
+	Content isUploadTransferBatchRequest_Content `protobuf_oneof:"content"`
+}
+
+func (x *UploadTransferBatchRequest) Reset() {
+	*x = UploadTransferBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_transfer_batch_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadTransferBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadTransferBatchRequest) ProtoMessage() {}
+
+func (x *UploadTransferBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_transfer_batch_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadTransferBatchRequest.ProtoReflect.Descriptor instead.
+func (*UploadTransferBatchRequest) Descriptor() ([]byte, []int) {
+	return file_bank_transfer_batch_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *UploadTransferBatchRequest) GetContent() isUploadTransferBatchRequest_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (x *UploadTransferBatchRequest) GetMetadata() *TransferBatchMetadata {
+	if x, ok := x.GetContent().(*UploadTransferBatchRequest_Metadata); ok {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadTransferBatchRequest) GetChunk() []byte {
+	if x, ok := x.GetContent().(*UploadTransferBatchRequest_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+type isUploadTransferBatchRequest_Content interface {
+	isUploadTransferBatchRequest_Content()
+}
+
+type UploadTransferBatchRequest_Metadata struct {
+	Metadata *TransferBatchMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadTransferBatchRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadTransferBatchRequest_Metadata) isUploadTransferBatchRequest_Content() {}
+
+func (*UploadTransferBatchRequest_Chunk) isUploadTransferBatchRequest_Content() {}
+
+type TransferBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchId string `protobuf:"bytes,1,opt,name=batch_id,proto3" json:"batch_id,omitempty"`
+}
+
+func (x *TransferBatchRequest) Reset() {
+	*x = TransferBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_transfer_batch_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchRequest) ProtoMessage() {}
+
+func (x *TransferBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_transfer_batch_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchRequest.ProtoReflect.Descriptor instead.
+func (*TransferBatchRequest) Descriptor() ([]byte, []int) {
+	return file_bank_transfer_batch_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TransferBatchRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+type TransferBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchId              string              `protobuf:"bytes,1,opt,name=batch_id,proto3" json:"batch_id,omitempty"`
+	MessageId            string              `protobuf:"bytes,2,opt,name=message_id,proto3" json:"message_id,omitempty"`
+	Format               TransferBatchFormat `protobuf:"varint,3,opt,name=format,proto3,enum=bank.TransferBatchFormat" json:"format,omitempty"`
+	Mode                 TransferBatchMode   `protobuf:"varint,4,opt,name=mode,proto3,enum=bank.TransferBatchMode" json:"mode,omitempty"`
+	Status               TransferBatchStatus `protobuf:"varint,5,opt,name=status,proto3,enum=bank.TransferBatchStatus" json:"status,omitempty"`
+	NumberOfTransactions int32               `protobuf:"varint,6,opt,name=number_of_transactions,proto3" json:"number_of_transactions,omitempty"`
+	ControlSum           float64             `protobuf:"fixed64,7,opt,name=control_sum,proto3" json:"control_sum,omitempty"`
+	// an ISO 20022 status reason code, like AM18, and why the batch was
+	// rejected or failed
+	ReasonCode string `protobuf:"bytes,8,opt,name=reason_code,proto3" json:"reason_code,omitempty"`
+	Reason     string `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	// transactions by status
+	Pending   int32              `protobuf:"varint,10,opt,name=pending,proto3" json:"pending,omitempty"`
+	Succeeded int32              `protobuf:"varint,11,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed    int32              `protobuf:"varint,12,opt,name=failed,proto3" json:"failed,omitempty"`
+	Rejected  int32              `protobuf:"varint,13,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Cancelled int32              `protobuf:"varint,14,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Reversed  int32              `protobuf:"varint,15,opt,name=reversed,proto3" json:"reversed,omitempty"`
+	CreatedAt *datetime.DateTime `protobuf:"bytes,16,opt,name=created_at,proto3" json:"created_at,omitempty"`
+	// unset until the batch is done
+	CompletedAt *datetime.DateTime `protobuf:"bytes,17,opt,name=completed_at,proto3" json:"completed_at,omitempty"`
+	// transferred, and not reversed when the batch rolled back
+	ReversalFailed int32 `protobuf:"varint,18,opt,name=reversal_failed,proto3" json:"reversal_failed,omitempty"`
+}
+
+func (x *TransferBatch) Reset() {
+	*x = TransferBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_transfer_batch_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatch) ProtoMessage() {}
+
+func (x *TransferBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_transfer_batch_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatch.ProtoReflect.Descriptor instead.
+func (*TransferBatch) Descriptor() ([]byte, []int) {
+	return file_bank_transfer_batch_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransferBatch) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *TransferBatch) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *TransferBatch) GetFormat() TransferBatchFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TransferBatchFormat_TRANSFER_BATCH_FORMAT_UNSPECIFIED
+}
+
+func (x *TransferBatch) GetMode() TransferBatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TransferBatchMode_TRANSFER_BATCH_MODE_UNSPECIFIED
+}
+
+func (x *TransferBatch) GetStatus() TransferBatchStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransferBatchStatus_TRANSFER_BATCH_STATUS_UNSPECIFIED
+}
+
+func (x *TransferBatch) GetNumberOfTransactions() int32 {
+	if x != nil {
+		return x.NumberOfTransactions
+	}
+	return 0
+}
+
+func (x *TransferBatch) GetControlSum() float64 {
+	if x != nil {
+		return x.ControlSum
+	}
+	return 0
+}
+
+func (x *TransferBatch) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *TransferBatch) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TransferBatch) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *TransferBatch) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *TransferBatch) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *TransferBatch) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *TransferBatch) GetCancelled() int32 {
+	if x != nil {
+		return x.Cancelled
+	}
+	return 0
+}
+
+func (x *TransferBatch) GetReversed() int32 {
+	if x != nil {
+		return x.Reversed
+	}
+	return 0
+}
+
+func (x *TransferBatch) GetCreatedAt() *datetime.DateTime {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TransferBatch) GetCompletedAt() *datetime.DateTime {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *TransferBatch) GetReversalFailed() int32 {
+	if x != nil {
+		return x.ReversalFailed
+	}
+	return 0
+}
+
+type TransferBatchReportChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *TransferBatchReportChunk) Reset() {
+	*x = TransferBatchReportChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bank_transfer_batch_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferBatchReportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchReportChunk) ProtoMessage() {}
+
+func (x *TransferBatchReportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_transfer_batch_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchReportChunk.ProtoReflect.Descriptor instead.
+func (*TransferBatchReportChunk) Descriptor() ([]byte, []int) {
+	return file_bank_transfer_batch_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransferBatchReportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_bank_transfer_batch_proto protoreflect.FileDescriptor
+
+var file_bank_transfer_batch_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x62, 0x61, 0x6e,
+	0x6b, 0x1a, 0x1a, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x64,
+	0x61, 0x74, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf6, 0x02,
+	0x0a, 0x15, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x12, 0x34, 0x0a, 0x15, 0x64, 0x65, 0x62, 0x74, 0x6f,
+	0x72, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x64, 0x65, 0x62, 0x74, 0x6f, 0x72, 0x5f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x3b, 0x0a, 0x16, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x16, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f,
+	0x73, 0x75, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x0b, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x73, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x42, 0x19, 0x0a, 0x17, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x5f, 0x73, 0x75, 0x6d, 0x22, 0x7a, 0x0a, 0x1a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x16, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x22, 0x32, 0x0a, 0x14, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x22, 0xb4, 0x05, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x5f, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x5f, 0x69, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x16, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f,
+	0x6f, 0x66, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x73, 0x75, 0x6d, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x73, 0x75,
+	0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64,
+	0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65,
+	0x64, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x6c, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65,
+	0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65,
+	0x64, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x12, 0x39, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x61, 0x6c, 0x5f,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x72, 0x65,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x6c, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22, 0x2e, 0x0a,
+	0x18, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x2a, 0x7f, 0x0a,
+	0x13, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x25, 0x0a, 0x21, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52,
+	0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x54,
+	0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x43, 0x53, 0x56, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x54, 0x52,
+	0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x46, 0x4f, 0x52,
+	0x4d, 0x41, 0x54, 0x5f, 0x50, 0x41, 0x49, 0x4e, 0x5f, 0x30, 0x30, 0x31, 0x10, 0x02, 0x2a, 0x85,
+	0x01, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x1f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52,
+	0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x26, 0x0a, 0x22, 0x54, 0x52, 0x41,
+	0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x41, 0x4c, 0x4c, 0x5f, 0x4f, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x48, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x23, 0x0a, 0x1f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41,
+	0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x42, 0x45, 0x53, 0x54, 0x5f, 0x45, 0x46,
+	0x46, 0x4f, 0x52, 0x54, 0x10, 0x02, 0x2a, 0xc8, 0x02, 0x0a, 0x13, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25,
+	0x0a, 0x21, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45,
+	0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52,
+	0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x54, 0x52, 0x41,
+	0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x50, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x24, 0x0a,
+	0x20, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x49, 0x4e,
+	0x47, 0x10, 0x03, 0x12, 0x26, 0x0a, 0x22, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f,
+	0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x4f, 0x4c,
+	0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x04, 0x12, 0x23, 0x0a, 0x1f, 0x54,
+	0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x05,
+	0x12, 0x2d, 0x0a, 0x29, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54,
+	0x43, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x41, 0x52, 0x54, 0x49, 0x41,
+	0x4c, 0x4c, 0x59, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x06, 0x12,
+	0x20, 0x0a, 0x1c, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x42, 0x41, 0x54, 0x43,
+	0x48, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10,
+	0x07, 0x32, 0x89, 0x02, 0x0a, 0x14, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x13, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x20, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x22, 0x00, 0x28, 0x01, 0x12, 0x45, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x1a, 0x2e, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x62,
+	0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x2e,
+	0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x61, 0x6e, 0x6b,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x42, 0x44, 0x5a,
+	0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x6a, 0x61,
+	0x72, 0x61, 0x6d, 0x61, 0x75, 0x6c, 0x61, 0x6e, 0x61, 0x2f, 0x67, 0x6f, 0x2d, 0x67, 0x72, 0x70,
+	0x63, 0x2d, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x2d, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x62,
+	0x61, 0x6e, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bank_transfer_batch_proto_rawDescOnce sync.Once
+	file_bank_transfer_batch_proto_rawDescData = file_bank_transfer_batch_proto_rawDesc
+)
+
+func file_bank_transfer_batch_proto_rawDescGZIP() []byte {
+	file_bank_transfer_batch_proto_rawDescOnce.Do(func() {
+		file_bank_transfer_batch_proto_rawDescData = protoimpl.X.CompressGZIP(file_bank_transfer_batch_proto_rawDescData)
+	})
+	return file_bank_transfer_batch_proto_rawDescData
+}
+
+var file_bank_transfer_batch_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_bank_transfer_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_bank_transfer_batch_proto_goTypes = []any{
+	(TransferBatchFormat)(0),           // 0: bank.TransferBatchFormat
+	(TransferBatchMode)(0),             // 1: bank.TransferBatchMode
+	(TransferBatchStatus)(0),           // 2: bank.TransferBatchStatus
+	(*TransferBatchMetadata)(nil),      // 3: bank.TransferBatchMetadata
+	(*UploadTransferBatchRequest)(nil), // 4: bank.UploadTransferBatchRequest
+	(*TransferBatchRequest)(nil),       // 5: bank.TransferBatchRequest
+	(*TransferBatch)(nil),              // 6: bank.TransferBatch
+	(*TransferBatchReportChunk)(nil),   // 7: bank.TransferBatchReportChunk
+	(*datetime.DateTime)(nil),          // 8: google.type.DateTime
+}
+var file_bank_transfer_batch_proto_depIdxs = []int32{
+	0,  // 0: bank.TransferBatchMetadata.format:type_name -> bank.TransferBatchFormat
+	1,  // 1: bank.TransferBatchMetadata.mode:type_name -> bank.TransferBatchMode
+	3,  // 2: bank.UploadTransferBatchRequest.metadata:type_name -> bank.TransferBatchMetadata
+	0,  // 3: bank.TransferBatch.format:type_name -> bank.TransferBatchFormat
+	1,  // 4: bank.TransferBatch.mode:type_name -> bank.TransferBatchMode
+	2,  // 5: bank.TransferBatch.status:type_name -> bank.TransferBatchStatus
+	8,  // 6: bank.TransferBatch.created_at:type_name -> google.type.DateTime
+	8,  // 7: bank.TransferBatch.completed_at:type_name -> google.type.DateTime
+	4,  // 8: bank.TransferBatchService.UploadTransferBatch:input_type -> bank.UploadTransferBatchRequest
+	5,  // 9: bank.TransferBatchService.GetTransferBatch:input_type -> bank.TransferBatchRequest
+	5,  // 10: bank.TransferBatchService.GetTransferBatchReport:input_type -> bank.TransferBatchRequest
+	6,  // 11: bank.TransferBatchService.UploadTransferBatch:output_type -> bank.TransferBatch
+	6,  // 12: bank.TransferBatchService.GetTransferBatch:output_type -> bank.TransferBatch
+	7,  // 13: bank.TransferBatchService.GetTransferBatchReport:output_type -> bank.TransferBatchReportChunk
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_bank_transfer_batch_proto_init() }
+func file_bank_transfer_batch_proto_init() {
+	if File_bank_transfer_batch_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_bank_transfer_batch_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*TransferBatchMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_transfer_batch_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*UploadTransferBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_transfer_batch_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*TransferBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_transfer_batch_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*TransferBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bank_transfer_batch_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*TransferBatchReportChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_bank_transfer_batch_proto_msgTypes[0].OneofWrappers = []any{}
+	file_bank_transfer_batch_proto_msgTypes[1].OneofWrappers = []any{
+		(*UploadTransferBatchRequest_Metadata)(nil),
+		(*UploadTransferBatchRequest_Chunk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bank_transfer_batch_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bank_transfer_batch_proto_goTypes,
+		DependencyIndexes: file_bank_transfer_batch_proto_depIdxs,
+		EnumInfos:         file_bank_transfer_batch_proto_enumTypes,
+		MessageInfos:      file_bank_transfer_batch_proto_msgTypes,
+	}.Build()
+	File_bank_transfer_batch_proto = out.File
+	file_bank_transfer_batch_proto_rawDesc = nil
+	file_bank_transfer_batch_proto_goTypes = nil
+	file_bank_transfer_batch_proto_depIdxs = nil
+}
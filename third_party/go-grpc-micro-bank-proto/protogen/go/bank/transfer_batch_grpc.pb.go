@@ -0,0 +1,206 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: bank/transfer_batch.proto
+
+package bank
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TransferBatchService_UploadTransferBatch_FullMethodName    = "/bank.TransferBatchService/UploadTransferBatch"
+	TransferBatchService_GetTransferBatch_FullMethodName       = "/bank.TransferBatchService/GetTransferBatch"
+	TransferBatchService_GetTransferBatchReport_FullMethodName = "/bank.TransferBatchService/GetTransferBatchReport"
+)
+
+// TransferBatchServiceClient is the client API for TransferBatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TransferBatchService imports files of credit transfers, like payrolls, and
+// executes them in the background.
+type TransferBatchServiceClient interface {
+	// the first message carries the metadata, the ones after the file in
+	// chunks. Every line is validated before the batch is stored.
+	UploadTransferBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadTransferBatchRequest, TransferBatch], error)
+	GetTransferBatch(ctx context.Context, in *TransferBatchRequest, opts ...grpc.CallOption) (*TransferBatch, error)
+	// the pain.002 status report of the batch as it stands, in chunks
+	GetTransferBatchReport(ctx context.Context, in *TransferBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TransferBatchReportChunk], error)
+}
+
+type transferBatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTransferBatchServiceClient(cc grpc.ClientConnInterface) TransferBatchServiceClient {
+	return &transferBatchServiceClient{cc}
+}
+
+func (c *transferBatchServiceClient) UploadTransferBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadTransferBatchRequest, TransferBatch], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TransferBatchService_ServiceDesc.Streams[0], TransferBatchService_UploadTransferBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadTransferBatchRequest, TransferBatch]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransferBatchService_UploadTransferBatchClient = grpc.ClientStreamingClient[UploadTransferBatchRequest, TransferBatch]
+
+func (c *transferBatchServiceClient) GetTransferBatch(ctx context.Context, in *TransferBatchRequest, opts ...grpc.CallOption) (*TransferBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferBatch)
+	err := c.cc.Invoke(ctx, TransferBatchService_GetTransferBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transferBatchServiceClient) GetTransferBatchReport(ctx context.Context, in *TransferBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TransferBatchReportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TransferBatchService_ServiceDesc.Streams[1], TransferBatchService_GetTransferBatchReport_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TransferBatchRequest, TransferBatchReportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransferBatchService_GetTransferBatchReportClient = grpc.ServerStreamingClient[TransferBatchReportChunk]
+
+// TransferBatchServiceServer is the server API for TransferBatchService service.
+// All implementations must embed UnimplementedTransferBatchServiceServer
+// for forward compatibility.
+//
+// TransferBatchService imports files of credit transfers, like payrolls, and
+// executes them in the background.
+type TransferBatchServiceServer interface {
+	// the first message carries the metadata, the ones after the file in
+	// chunks. Every line is validated before the batch is stored.
+	UploadTransferBatch(grpc.ClientStreamingServer[UploadTransferBatchRequest, TransferBatch]) error
+	GetTransferBatch(context.Context, *TransferBatchRequest) (*TransferBatch, error)
+	// the pain.002 status report of the batch as it stands, in chunks
+	GetTransferBatchReport(*TransferBatchRequest, grpc.ServerStreamingServer[TransferBatchReportChunk]) error
+	mustEmbedUnimplementedTransferBatchServiceServer()
+}
+
+// UnimplementedTransferBatchServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTransferBatchServiceServer struct{}
+
+func (UnimplementedTransferBatchServiceServer) UploadTransferBatch(grpc.ClientStreamingServer[UploadTransferBatchRequest, TransferBatch]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadTransferBatch not implemented")
+}
+func (UnimplementedTransferBatchServiceServer) GetTransferBatch(context.Context, *TransferBatchRequest) (*TransferBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferBatch not implemented")
+}
+func (UnimplementedTransferBatchServiceServer) GetTransferBatchReport(*TransferBatchRequest, grpc.ServerStreamingServer[TransferBatchReportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetTransferBatchReport not implemented")
+}
+func (UnimplementedTransferBatchServiceServer) mustEmbedUnimplementedTransferBatchServiceServer() {}
+func (UnimplementedTransferBatchServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeTransferBatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TransferBatchServiceServer will
+// result in compilation errors.
+type UnsafeTransferBatchServiceServer interface {
+	mustEmbedUnimplementedTransferBatchServiceServer()
+}
+
+func RegisterTransferBatchServiceServer(s grpc.ServiceRegistrar, srv TransferBatchServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTransferBatchServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TransferBatchService_ServiceDesc, srv)
+}
+
+func _TransferBatchService_UploadTransferBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TransferBatchServiceServer).UploadTransferBatch(&grpc.GenericServerStream[UploadTransferBatchRequest, TransferBatch]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransferBatchService_UploadTransferBatchServer = grpc.ClientStreamingServer[UploadTransferBatchRequest, TransferBatch]
+
+func _TransferBatchService_GetTransferBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferBatchServiceServer).GetTransferBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferBatchService_GetTransferBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferBatchServiceServer).GetTransferBatch(ctx, req.(*TransferBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransferBatchService_GetTransferBatchReport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransferBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TransferBatchServiceServer).GetTransferBatchReport(m, &grpc.GenericServerStream[TransferBatchRequest, TransferBatchReportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TransferBatchService_GetTransferBatchReportServer = grpc.ServerStreamingServer[TransferBatchReportChunk]
+
+// TransferBatchService_ServiceDesc is the grpc.ServiceDesc for TransferBatchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TransferBatchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bank.TransferBatchService",
+	HandlerType: (*TransferBatchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTransferBatch",
+			Handler:    _TransferBatchService_GetTransferBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadTransferBatch",
+			Handler:       _TransferBatchService_UploadTransferBatch_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetTransferBatchReport",
+			Handler:       _TransferBatchService_GetTransferBatchReport_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bank/transfer_batch.proto",
+}
@@ -1,10 +1,12 @@
 package util
 
 import (
-	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
+	"google.golang.org/genproto/googleapis/type/date"
 	"google.golang.org/genproto/googleapis/type/datetime"
 )
 
@@ -26,7 +28,7 @@ func ParseIntToRupiah(angka int) string {
 }
 
 func reverseInt(angka int) string {
-	angkaStr := string(angka)
+	angkaStr := strconv.Itoa(angka)
 	angkaRev := ""
 	for i := len(angkaStr) - 1; i >= 0; i-- {
 		angkaRev += string(angkaStr[i])
@@ -43,10 +45,14 @@ func reverseString(str string) string {
 }
 
 func FormatRupiah(amount float64) string {
-	strAmount := fmt.Sprintf("%.2f", amount)
-	parts := strings.Split(strAmount, ".")
-	intPart := parts[0]
-	decimalPart := parts[1]
+	return "Rp " + FormatNumber(amount, 2)
+}
+
+// FormatNumber writes amount the Indonesian way, rounded to decimals: a dot
+// every 3 digits and a decimal comma, e.g. -1.234.567,89.
+func FormatNumber(amount float64, decimals int) string {
+	strAmount := strconv.FormatFloat(math.Abs(amount), 'f', decimals, 64)
+	intPart, decimalPart, _ := strings.Cut(strAmount, ".")
 
 	// Sisipkan tanda titik setiap 3 digit dari belakang
 	n := len(intPart)
@@ -56,9 +62,16 @@ func FormatRupiah(amount float64) string {
 		}
 	}
 
+	// a negative amount rounding to zero has no sign
+	if amount < 0 && strings.Trim(strAmount, "0.") != "" {
+		intPart = "-" + intPart
+	}
+	if decimalPart == "" {
+		return intPart
+	}
+
 	// Gabungkan bagian integer dan desimal dengan tanda koma
-	rupiah := "Rp " + intPart + "," + decimalPart
-	return rupiah
+	return intPart + "," + decimalPart
 }
 
 func ToTime(dt *datetime.DateTime) (time.Time, error) {
@@ -83,16 +96,41 @@ func ToTime(dt *datetime.DateTime) (time.Time, error) {
 }
 
 func CurrentDatetime() *datetime.DateTime {
-	now := time.Now()
+	return ToDatetime(time.Now())
+}
+
+// ToDatetime converts t to a google.type.DateTime in UTC.
+func ToDatetime(t time.Time) *datetime.DateTime {
+	t = t.UTC()
 
 	return &datetime.DateTime{
-		Year:       int32(now.Year()),
-		Month:      int32(now.Month()),
-		Day:        int32(now.Day()),
-		Hours:      int32(now.Hour()),
-		Minutes:    int32(now.Minute()),
-		Seconds:    int32(now.Second()),
-		Nanos:      int32(now.Second()),
+		Year:       int32(t.Year()),
+		Month:      int32(t.Month()),
+		Day:        int32(t.Day()),
+		Hours:      int32(t.Hour()),
+		Minutes:    int32(t.Minute()),
+		Seconds:    int32(t.Second()),
+		Nanos:      int32(t.Nanosecond()),
 		TimeOffset: &datetime.DateTime_UtcOffset{},
 	}
 }
+
+// ToDate converts t to the google.type.Date of its UTC day.
+func ToDate(t time.Time) *date.Date {
+	t = t.UTC()
+
+	return &date.Date{
+		Year:  int32(t.Year()),
+		Month: int32(t.Month()),
+		Day:   int32(t.Day()),
+	}
+}
+
+// DateToTime is midnight UTC of d, the zero time for nil.
+func DateToTime(d *date.Date) time.Time {
+	if d == nil {
+		return time.Time{}
+	}
+
+	return time.Date(int(d.Year), time.Month(d.Month), int(d.Day), 0, 0, 0, 0, time.UTC)
+}